@@ -625,6 +625,44 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			`
+			namespace "default" {
+				policy = "write"
+				capabilities = ["write-fs"]
+			}
+			`,
+			"",
+			&Policy{
+				Namespaces: []*NamespacePolicy{
+					{
+						Name:   "default",
+						Policy: PolicyWrite,
+						Capabilities: []string{
+							NamespaceCapabilityWriteFS,
+							NamespaceCapabilityListJobs,
+							NamespaceCapabilityParseJob,
+							NamespaceCapabilityReadJob,
+							NamespaceCapabilityCSIListVolume,
+							NamespaceCapabilityCSIReadVolume,
+							NamespaceCapabilityReadJobScaling,
+							NamespaceCapabilityListScalingPolicies,
+							NamespaceCapabilityReadScalingPolicy,
+							NamespaceCapabilityScaleJob,
+							NamespaceCapabilitySubmitJob,
+							NamespaceCapabilityDispatchJob,
+							NamespaceCapabilityReadLogs,
+							NamespaceCapabilityReadFS,
+							NamespaceCapabilityAllocExec,
+							NamespaceCapabilityAllocLifecycle,
+							NamespaceCapabilityCSIMountVolume,
+							NamespaceCapabilityCSIWriteVolume,
+							NamespaceCapabilitySubmitRecommendation,
+						},
+					},
+				},
+			},
+		},
 		{
 			`
 			node_pool "pool-read-only" {
@@ -285,6 +285,7 @@ type Allocation struct {
 	DesiredTransition     DesiredTransition
 	ClientStatus          string
 	ClientDescription     string
+	Evicted               bool
 	TaskStates            map[string]*TaskState
 	DeploymentID          string
 	DeploymentStatus      *AllocDeploymentStatus
@@ -315,6 +316,9 @@ type AllocationMetric struct {
 	DimensionExhausted map[string]int
 	QuotaExhausted     []string
 	ResourcesExhausted map[string]*Resources
+
+	DisruptionBudgetExhausted map[string]int
+
 	// Deprecated, replaced with ScoreMetaData
 	Scores            map[string]float64
 	AllocationTime    time.Duration
@@ -345,6 +349,7 @@ func (a *Allocation) Stub() *AllocationListStub {
 		DesiredDescription:    a.DesiredDescription,
 		ClientStatus:          a.ClientStatus,
 		ClientDescription:     a.ClientDescription,
+		Evicted:               a.Evicted,
 		TaskStates:            a.TaskStates,
 		DeploymentStatus:      a.DeploymentStatus,
 		FollowupEvalID:        a.FollowupEvalID,
@@ -406,6 +411,7 @@ type AllocationListStub struct {
 	DesiredDescription    string
 	ClientStatus          string
 	ClientDescription     string
+	Evicted               bool
 	TaskStates            map[string]*TaskState
 	DeploymentStatus      *AllocDeploymentStatus
 	FollowupEvalID        string
@@ -426,6 +432,7 @@ type AllocDeploymentStatus struct {
 	Healthy     *bool
 	Timestamp   time.Time
 	Canary      bool
+	Standby     bool
 	ModifyIndex uint64
 }
 
@@ -207,6 +207,21 @@ type DeploymentState struct {
 	PlacedAllocs      int
 	HealthyAllocs     int
 	UnhealthyAllocs   int
+	AnalysisStep      int
+	AnalysisResults   []*AnalysisResult
+	HoldPeriod        time.Duration
+	HoldUntil         time.Time
+	HoldEvalID        string
+	DependsOn         []string
+}
+
+// AnalysisResult is the result of an analysis gate at a step of a deployment.
+type AnalysisResult struct {
+	Step    int
+	Gate    string
+	Status  string
+	Message string
+	Time    time.Time
 }
 
 // DeploymentIndexSort is a wrapper to sort deployments by CreateIndex. We
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/url"
+	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -137,6 +140,59 @@ func (a *AllocFS) Cat(alloc *Allocation, path string, q *QueryOptions) (io.ReadC
 		})
 }
 
+// Archive returns a gzip compressed tar archive of the directory at the given
+// path of an allocation directory. Only the files matching one of the include
+// glob patterns, if any, and none of the exclude glob patterns are archived.
+// Archives can be downloaded until the allocation is garbage collected.
+//
+// The caller must close the returned ReadCloser.
+func (a *AllocFS) Archive(alloc *Allocation, path string, include, exclude []string, q *QueryOptions) (io.ReadCloser, error) {
+	reqPath := fmt.Sprintf("/v1/client/fs/archive/%s", alloc.ID)
+	return queryClientNode(a.client, alloc, reqPath, q,
+		func(q *QueryOptions) {
+			q.Params["path"] = path
+			if len(include) > 0 {
+				q.Params["include"] = strings.Join(include, ",")
+			}
+			if len(exclude) > 0 {
+				q.Params["exclude"] = strings.Join(exclude, ",")
+			}
+		})
+}
+
+// Upload writes the content of r to the file at the given path of an
+// allocation directory, replacing the file if it exists. The parent directory
+// must exist. If mode is zero, the file is created with 0644 permissions.
+func (a *AllocFS) Upload(alloc *Allocation, path string, r io.Reader, mode os.FileMode, q *WriteOptions) (*WriteMeta, error) {
+	v := url.Values{}
+	v.Set("path", path)
+	if mode != 0 {
+		v.Set("mode", strconv.FormatUint(uint64(mode.Perm()), 8))
+	}
+	return a.client.put(fmt.Sprintf("/v1/client/fs/upload/%s?%s", alloc.ID, v.Encode()), r, nil, q)
+}
+
+// Mkdir creates the directory at the given path of an allocation directory,
+// along with any missing parents. If mode is zero, the directories are created
+// with 0755 permissions.
+func (a *AllocFS) Mkdir(alloc *Allocation, path string, mode os.FileMode, q *WriteOptions) (*WriteMeta, error) {
+	v := url.Values{}
+	v.Set("path", path)
+	if mode != 0 {
+		v.Set("mode", strconv.FormatUint(uint64(mode.Perm()), 8))
+	}
+	return a.client.put(fmt.Sprintf("/v1/client/fs/mkdir/%s?%s", alloc.ID, v.Encode()), nil, nil, q)
+}
+
+// Remove removes the file or directory at the given path of an allocation
+// directory. Non-empty directories are only removed if recursive is true.
+func (a *AllocFS) Remove(alloc *Allocation, path string, recursive bool, q *WriteOptions) (*WriteMeta, error) {
+	v := url.Values{}
+	v.Set("path", path)
+	v.Set("recursive", strconv.FormatBool(recursive))
+	return a.client.delete(fmt.Sprintf("/v1/client/fs/rm/%s?%s", alloc.ID, v.Encode()), nil, nil, q)
+}
+
 // Stream streams the content of a file blocking on EOF.
 // The parameters are:
 // * path: path to file to stream.
@@ -10,6 +10,7 @@ import (
 	"io"
 	"maps"
 	"net/url"
+	"slices"
 	"sort"
 	"strconv"
 	"time"
@@ -37,6 +38,19 @@ const (
 	// PeriodicSpecCron is used for a cron spec.
 	PeriodicSpecCron = "cron"
 
+	// PeriodicConcurrencyAllow, PeriodicConcurrencyForbid and
+	// PeriodicConcurrencyReplace are the concurrency policies of periodic
+	// jobs.
+	PeriodicConcurrencyAllow   = "allow"
+	PeriodicConcurrencyForbid  = "forbid"
+	PeriodicConcurrencyReplace = "replace"
+
+	// PeriodicCatchUpLatest, PeriodicCatchUpAll and PeriodicCatchUpNone are
+	// the catch up policies of periodic jobs.
+	PeriodicCatchUpLatest = "latest"
+	PeriodicCatchUpAll    = "all"
+	PeriodicCatchUpNone   = "none"
+
 	// DefaultNamespace is the default namespace.
 	DefaultNamespace = "default"
 
@@ -565,8 +579,14 @@ type UpdateStrategy struct {
 	HealthyDeadline  *time.Duration `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
 	ProgressDeadline *time.Duration `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
 	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
+	CanaryPercent    *int           `mapstructure:"canary_percent" hcl:"canary_percent,optional"`
 	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
 	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	Strategy         *string        `mapstructure:"strategy" hcl:"strategy,optional"`
+	HoldPeriod       *time.Duration `mapstructure:"hold_period" hcl:"hold_period,optional"`
+	DependsOn        []string       `mapstructure:"depends_on" hcl:"depends_on,optional"`
+
+	Analysis *AnalysisStrategy `mapstructure:"analysis" hcl:"analysis,block"`
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -581,7 +601,10 @@ func DefaultUpdateStrategy() *UpdateStrategy {
 		ProgressDeadline: pointerOf(10 * time.Minute),
 		AutoRevert:       pointerOf(false),
 		Canary:           pointerOf(0),
+		CanaryPercent:    pointerOf(0),
 		AutoPromote:      pointerOf(false),
+		Strategy:         pointerOf("rolling"),
+		HoldPeriod:       pointerOf(time.Duration(0)),
 	}
 }
 
@@ -624,10 +647,28 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.Canary = pointerOf(*u.Canary)
 	}
 
+	if u.CanaryPercent != nil {
+		copy.CanaryPercent = pointerOf(*u.CanaryPercent)
+	}
+
 	if u.AutoPromote != nil {
 		copy.AutoPromote = pointerOf(*u.AutoPromote)
 	}
 
+	if u.Strategy != nil {
+		copy.Strategy = pointerOf(*u.Strategy)
+	}
+
+	if u.HoldPeriod != nil {
+		copy.HoldPeriod = pointerOf(*u.HoldPeriod)
+	}
+
+	if u.DependsOn != nil {
+		copy.DependsOn = slices.Clone(u.DependsOn)
+	}
+
+	copy.Analysis = u.Analysis.Copy()
+
 	return copy
 }
 
@@ -668,9 +709,29 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 		u.Canary = pointerOf(*o.Canary)
 	}
 
+	if o.CanaryPercent != nil {
+		u.CanaryPercent = pointerOf(*o.CanaryPercent)
+	}
+
 	if o.AutoPromote != nil {
 		u.AutoPromote = pointerOf(*o.AutoPromote)
 	}
+
+	if o.Strategy != nil {
+		u.Strategy = pointerOf(*o.Strategy)
+	}
+
+	if o.HoldPeriod != nil {
+		u.HoldPeriod = pointerOf(*o.HoldPeriod)
+	}
+
+	if o.DependsOn != nil {
+		u.DependsOn = slices.Clone(o.DependsOn)
+	}
+
+	if o.Analysis != nil {
+		u.Analysis = o.Analysis.Copy()
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -708,9 +769,25 @@ func (u *UpdateStrategy) Canonicalize() {
 		u.Canary = d.Canary
 	}
 
+	if u.CanaryPercent == nil {
+		u.CanaryPercent = d.CanaryPercent
+	}
+
 	if u.AutoPromote == nil {
 		u.AutoPromote = d.AutoPromote
 	}
+
+	if u.Strategy == nil {
+		u.Strategy = d.Strategy
+	}
+
+	if u.HoldPeriod == nil {
+		u.HoldPeriod = d.HoldPeriod
+	}
+
+	if u.Analysis != nil {
+		u.Analysis.Canonicalize()
+	}
 }
 
 // Empty returns whether the UpdateStrategy is empty or has user defined values.
@@ -755,9 +832,99 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if u.CanaryPercent != nil && *u.CanaryPercent != 0 {
+		return false
+	}
+
+	if u.Strategy != nil && *u.Strategy != "" && *u.Strategy != "rolling" {
+		return false
+	}
+
+	if u.HoldPeriod != nil && *u.HoldPeriod != 0 {
+		return false
+	}
+
+	if len(u.DependsOn) != 0 {
+		return false
+	}
+
+	if u.Analysis != nil {
+		return false
+	}
+
 	return true
 }
 
+// AnalysisStrategy configures the steps at which a deployment pauses to run
+// analysis gates before updating more allocations.
+type AnalysisStrategy struct {
+	Steps []int           `mapstructure:"steps" hcl:"steps,optional"`
+	Delay *time.Duration  `mapstructure:"delay" hcl:"delay,optional"`
+	Gates []*AnalysisGate `mapstructure:"gate" hcl:"gate,block"`
+}
+
+func (a *AnalysisStrategy) Copy() *AnalysisStrategy {
+	if a == nil {
+		return nil
+	}
+
+	copy := &AnalysisStrategy{
+		Steps: slices.Clone(a.Steps),
+	}
+	if a.Delay != nil {
+		copy.Delay = pointerOf(*a.Delay)
+	}
+	for _, g := range a.Gates {
+		copy.Gates = append(copy.Gates, g.Copy())
+	}
+	return copy
+}
+
+func (a *AnalysisStrategy) Canonicalize() {
+	if len(a.Steps) == 0 {
+		a.Steps = []int{100}
+	}
+	if a.Delay == nil {
+		a.Delay = pointerOf(time.Duration(0))
+	}
+	for _, g := range a.Gates {
+		g.Canonicalize()
+	}
+}
+
+// AnalysisGate is a check run at every step of a deployment analysis. Gates
+// are http webhooks, PromQL queries or job actions depending on their type.
+// The http and query gates reference an analysis provider of the server
+// configuration.
+type AnalysisGate struct {
+	Name     string         `hcl:"name,label"`
+	Type     string         `mapstructure:"type" hcl:"type,optional"`
+	Provider string         `mapstructure:"provider" hcl:"provider,optional"`
+	Query    string         `mapstructure:"query" hcl:"query,optional"`
+	Task     string         `mapstructure:"task" hcl:"task,optional"`
+	Action   string         `mapstructure:"action" hcl:"action,optional"`
+	Timeout  *time.Duration `mapstructure:"timeout" hcl:"timeout,optional"`
+}
+
+func (g *AnalysisGate) Copy() *AnalysisGate {
+	if g == nil {
+		return nil
+	}
+
+	copy := new(AnalysisGate)
+	*copy = *g
+	if g.Timeout != nil {
+		copy.Timeout = pointerOf(*g.Timeout)
+	}
+	return copy
+}
+
+func (g *AnalysisGate) Canonicalize() {
+	if g.Timeout == nil {
+		g.Timeout = pointerOf(30 * time.Second)
+	}
+}
+
 type Multiregion struct {
 	Strategy *MultiregionStrategy `hcl:"strategy,block"`
 	Regions  []*MultiregionRegion `hcl:"region,block"`
@@ -833,12 +1000,16 @@ type MultiregionRegion struct {
 
 // PeriodicConfig is for serializing periodic config for a job.
 type PeriodicConfig struct {
-	Enabled         *bool    `hcl:"enabled,optional"`
-	Spec            *string  `hcl:"cron,optional"`
-	Specs           []string `hcl:"crons,optional"`
-	SpecType        *string
-	ProhibitOverlap *bool   `mapstructure:"prohibit_overlap" hcl:"prohibit_overlap,optional"`
-	TimeZone        *string `mapstructure:"time_zone" hcl:"time_zone,optional"`
+	Enabled           *bool    `hcl:"enabled,optional"`
+	Spec              *string  `hcl:"cron,optional"`
+	Specs             []string `hcl:"crons,optional"`
+	SpecType          *string
+	ProhibitOverlap   *bool          `mapstructure:"prohibit_overlap" hcl:"prohibit_overlap,optional"`
+	ConcurrencyPolicy *string        `mapstructure:"concurrency_policy" hcl:"concurrency_policy,optional"`
+	StartingDeadline  *time.Duration `mapstructure:"starting_deadline" hcl:"starting_deadline,optional"`
+	CatchUp           *string        `mapstructure:"catch_up" hcl:"catch_up,optional"`
+	HistoryLimit      *int           `mapstructure:"history_limit" hcl:"history_limit,optional"`
+	TimeZone          *string        `mapstructure:"time_zone" hcl:"time_zone,optional"`
 }
 
 func (p *PeriodicConfig) Canonicalize() {
@@ -857,6 +1028,22 @@ func (p *PeriodicConfig) Canonicalize() {
 	if p.ProhibitOverlap == nil {
 		p.ProhibitOverlap = pointerOf(false)
 	}
+	if p.ConcurrencyPolicy == nil {
+		if *p.ProhibitOverlap {
+			p.ConcurrencyPolicy = pointerOf(PeriodicConcurrencyForbid)
+		} else {
+			p.ConcurrencyPolicy = pointerOf(PeriodicConcurrencyAllow)
+		}
+	}
+	if p.StartingDeadline == nil {
+		p.StartingDeadline = pointerOf(time.Duration(0))
+	}
+	if p.CatchUp == nil {
+		p.CatchUp = pointerOf(PeriodicCatchUpLatest)
+	}
+	if p.HistoryLimit == nil {
+		p.HistoryLimit = pointerOf(0)
+	}
 	if p.TimeZone == nil || *p.TimeZone == "" {
 		p.TimeZone = pointerOf("UTC")
 	}
@@ -943,6 +1130,25 @@ type JobSubmission struct {
 	Variables string
 }
 
+const (
+	JobDependencyConditionComplete   = "complete"
+	JobDependencyConditionSuccessful = "successful"
+	JobDependencyConditionHealthy    = "healthy"
+)
+
+// JobDependency references a job whose condition must be met before the
+// dependent job is placed.
+type JobDependency struct {
+	JobID     string `mapstructure:"job" hcl:"job"`
+	Condition string `hcl:"condition,optional"`
+}
+
+func (d *JobDependency) Canonicalize() {
+	if d.Condition == "" {
+		d.Condition = JobDependencyConditionSuccessful
+	}
+}
+
 type JobUIConfig struct {
 	Description string       `hcl:"description,optional"`
 	Links       []*JobUILink `hcl:"link,block"`
@@ -1031,10 +1237,12 @@ type Job struct {
 	Update           *UpdateStrategy         `hcl:"update,block"`
 	Multiregion      *Multiregion            `hcl:"multiregion,block"`
 	Spreads          []*Spread               `hcl:"spread,block"`
+	DependsOn        []*JobDependency        `hcl:"depends_on,block"`
 	Periodic         *PeriodicConfig         `hcl:"periodic,block"`
 	ParameterizedJob *ParameterizedJobConfig `hcl:"parameterized,block"`
 	Reschedule       *ReschedulePolicy       `hcl:"reschedule,block"`
 	Migrate          *MigrateStrategy        `hcl:"migrate,block"`
+	DisruptionBudget *DisruptionBudget       `hcl:"disruption_budget,block"`
 	Meta             map[string]string       `hcl:"meta,block"`
 	ConsulToken      *string                 `mapstructure:"consul_token" hcl:"consul_token,optional"`
 	VaultToken       *string                 `mapstructure:"vault_token" hcl:"vault_token,optional"`
@@ -1052,6 +1260,7 @@ type Job struct {
 	NomadTokenID             *string `mapstructure:"nomad_token_id"`
 	Status                   *string
 	StatusDescription        *string
+	CompletionFailed         bool
 	Stable                   *bool
 	Version                  *uint64
 	SubmitTime               *int64
@@ -1164,6 +1373,9 @@ func (j *Job) Canonicalize() {
 	for _, a := range j.Affinities {
 		a.Canonicalize()
 	}
+	for _, d := range j.DependsOn {
+		d.Canonicalize()
+	}
 
 	if j.UI != nil {
 		j.UI.Canonicalize()
@@ -1217,6 +1429,15 @@ type TaskGroupSummary struct {
 	Starting int
 	Lost     int
 	Unknown  int
+
+	ArraySucceeded []ArrayIndexRange
+	ArrayFailed    []ArrayIndexRange
+}
+
+// ArrayIndexRange is an inclusive range of array indices.
+type ArrayIndexRange struct {
+	Start int
+	End   int
 }
 
 // JobListStub is used to return a subset of information about
@@ -1576,6 +1797,10 @@ type JobEvaluateRequest struct {
 // EvalOptions is used to encapsulate options when forcing a job evaluation
 type EvalOptions struct {
 	ForceReschedule bool
+
+	// ArrayIndices limits forced rescheduling to the failed allocations of
+	// array task groups at these indices.
+	ArrayIndices []int
 }
 
 // ActionExec is used to run a pre-defined command inside a running task.
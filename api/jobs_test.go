@@ -309,6 +309,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Strategy:         pointerOf("rolling"),
+					HoldPeriod:       pointerOf(time.Duration(0)),
+					CanaryPercent:    pointerOf(0),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -322,6 +325,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:           pointerOf(15 * time.Second),
+							DelayFunction:   pointerOf("constant"),
+							MaxDelay:        pointerOf(time.Duration(0)),
 							Attempts:        pointerOf(2),
 							Interval:        pointerOf(30 * time.Minute),
 							Mode:            pointerOf("fail"),
@@ -349,6 +354,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(false),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(false),
+							Strategy:         pointerOf("rolling"),
+							HoldPeriod:       pointerOf(time.Duration(0)),
+							CanaryPercent:    pointerOf(0),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -410,6 +418,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:           pointerOf(15 * time.Second),
+							DelayFunction:   pointerOf("constant"),
+							MaxDelay:        pointerOf(time.Duration(0)),
 							Attempts:        pointerOf(3),
 							Interval:        pointerOf(24 * time.Hour),
 							Mode:            pointerOf("fail"),
@@ -490,6 +500,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Strategy:         pointerOf("rolling"),
+					HoldPeriod:       pointerOf(time.Duration(0)),
+					CanaryPercent:    pointerOf(0),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -503,6 +516,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:           pointerOf(15 * time.Second),
+							DelayFunction:   pointerOf("constant"),
+							MaxDelay:        pointerOf(time.Duration(0)),
 							Attempts:        pointerOf(2),
 							Interval:        pointerOf(30 * time.Minute),
 							Mode:            pointerOf("fail"),
@@ -530,6 +545,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(false),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(false),
+							Strategy:         pointerOf("rolling"),
+							HoldPeriod:       pointerOf(time.Duration(0)),
+							CanaryPercent:    pointerOf(0),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -667,6 +685,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(true),
+					Strategy:         pointerOf("rolling"),
+					HoldPeriod:       pointerOf(time.Duration(0)),
+					CanaryPercent:    pointerOf(0),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -677,6 +698,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Interval:        pointerOf(5 * time.Minute),
 							Attempts:        pointerOf(10),
 							Delay:           pointerOf(25 * time.Second),
+							DelayFunction:   pointerOf("constant"),
+							MaxDelay:        pointerOf(time.Duration(0)),
 							Mode:            pointerOf("delay"),
 							RenderTemplates: pointerOf(false),
 						},
@@ -707,6 +730,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(true),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(true),
+							Strategy:         pointerOf("rolling"),
+							HoldPeriod:       pointerOf(time.Duration(0)),
+							CanaryPercent:    pointerOf(0),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -723,6 +749,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 									Interval:        pointerOf(5 * time.Minute),
 									Attempts:        pointerOf(20),
 									Delay:           pointerOf(25 * time.Second),
+									DelayFunction:   pointerOf("constant"),
+									MaxDelay:        pointerOf(time.Duration(0)),
 									Mode:            pointerOf("delay"),
 									RenderTemplates: pointerOf(false),
 								},
@@ -839,14 +867,21 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Strategy:         pointerOf("rolling"),
+					HoldPeriod:       pointerOf(time.Duration(0)),
+					CanaryPercent:    pointerOf(0),
 				},
 				Periodic: &PeriodicConfig{
-					Enabled:         pointerOf(true),
-					Spec:            pointerOf(""),
-					Specs:           []string{},
-					SpecType:        pointerOf(PeriodicSpecCron),
-					ProhibitOverlap: pointerOf(false),
-					TimeZone:        pointerOf("UTC"),
+					Enabled:           pointerOf(true),
+					Spec:              pointerOf(""),
+					Specs:             []string{},
+					SpecType:          pointerOf(PeriodicSpecCron),
+					ProhibitOverlap:   pointerOf(false),
+					ConcurrencyPolicy: pointerOf(PeriodicConcurrencyAllow),
+					StartingDeadline:  pointerOf(time.Duration(0)),
+					CatchUp:           pointerOf(PeriodicCatchUpLatest),
+					HistoryLimit:      pointerOf(0),
+					TimeZone:          pointerOf("UTC"),
 				},
 			},
 		},
@@ -933,6 +968,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Strategy:         pointerOf("rolling"),
+					HoldPeriod:       pointerOf(time.Duration(0)),
+					CanaryPercent:    pointerOf(0),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -946,6 +984,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:           pointerOf(15 * time.Second),
+							DelayFunction:   pointerOf("constant"),
+							MaxDelay:        pointerOf(time.Duration(0)),
 							Attempts:        pointerOf(2),
 							Interval:        pointerOf(30 * time.Minute),
 							Mode:            pointerOf("fail"),
@@ -973,6 +1013,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(true),
 							Canary:           pointerOf(1),
 							AutoPromote:      pointerOf(true),
+							Strategy:         pointerOf("rolling"),
+							HoldPeriod:       pointerOf(time.Duration(0)),
+							CanaryPercent:    pointerOf(0),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -996,6 +1039,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:           pointerOf(15 * time.Second),
+							DelayFunction:   pointerOf("constant"),
+							MaxDelay:        pointerOf(time.Duration(0)),
 							Attempts:        pointerOf(2),
 							Interval:        pointerOf(30 * time.Minute),
 							Mode:            pointerOf("fail"),
@@ -1023,6 +1068,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(false),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(false),
+							Strategy:         pointerOf("rolling"),
+							HoldPeriod:       pointerOf(time.Duration(0)),
+							CanaryPercent:    pointerOf(0),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1117,6 +1165,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Strategy:         pointerOf("rolling"),
+					HoldPeriod:       pointerOf(time.Duration(0)),
+					CanaryPercent:    pointerOf(0),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -1130,6 +1181,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:           pointerOf(15 * time.Second),
+							DelayFunction:   pointerOf("constant"),
+							MaxDelay:        pointerOf(time.Duration(0)),
 							Attempts:        pointerOf(2),
 							Interval:        pointerOf(30 * time.Minute),
 							Mode:            pointerOf("fail"),
@@ -1157,6 +1210,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(false),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(false),
+							Strategy:         pointerOf("rolling"),
+							HoldPeriod:       pointerOf(time.Duration(0)),
+							CanaryPercent:    pointerOf(0),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1168,6 +1224,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 								RestartPolicy: &RestartPolicy{
 									Attempts:        pointerOf(5),
 									Delay:           pointerOf(1 * time.Second),
+									DelayFunction:   pointerOf("constant"),
+									MaxDelay:        pointerOf(time.Duration(0)),
 									Interval:        pointerOf(30 * time.Minute),
 									Mode:            pointerOf("fail"),
 									RenderTemplates: pointerOf(true),
@@ -1186,6 +1244,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:           pointerOf(20 * time.Second),
+							DelayFunction:   pointerOf("constant"),
+							MaxDelay:        pointerOf(time.Duration(0)),
 							Attempts:        pointerOf(2),
 							Interval:        pointerOf(30 * time.Minute),
 							Mode:            pointerOf("fail"),
@@ -1213,6 +1273,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(false),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(false),
+							Strategy:         pointerOf("rolling"),
+							HoldPeriod:       pointerOf(time.Duration(0)),
+							CanaryPercent:    pointerOf(0),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1223,6 +1286,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 								KillTimeout: pointerOf(5 * time.Second),
 								RestartPolicy: &RestartPolicy{
 									Delay:           pointerOf(20 * time.Second),
+									DelayFunction:   pointerOf("constant"),
+									MaxDelay:        pointerOf(time.Duration(0)),
 									Attempts:        pointerOf(2),
 									Interval:        pointerOf(30 * time.Minute),
 									Mode:            pointerOf("fail"),
@@ -1296,6 +1361,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Strategy:         pointerOf("rolling"),
+					HoldPeriod:       pointerOf(time.Duration(0)),
+					CanaryPercent:    pointerOf(0),
 				},
 			},
 		},
@@ -2280,6 +2348,24 @@ func TestJobs_AddAffinity(t *testing.T) {
 	must.Eq(t, expect, job.Affinities)
 }
 
+func TestJobs_Canonicalize_DependsOn(t *testing.T) {
+	testutil.Parallel(t)
+
+	job := &Job{
+		DependsOn: []*JobDependency{
+			{JobID: "db"},
+			{JobID: "migrate", Condition: JobDependencyConditionComplete},
+		},
+	}
+	job.Canonicalize()
+
+	expect := []*JobDependency{
+		{JobID: "db", Condition: JobDependencyConditionSuccessful},
+		{JobID: "migrate", Condition: JobDependencyConditionComplete},
+	}
+	must.Eq(t, expect, job.DependsOn)
+}
+
 func TestJobs_Sort(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -73,6 +73,7 @@ type Namespace struct {
 	Name                  string
 	Description           string
 	Quota                 string
+	EvalQueueWeight       int                             `mapstructure:"eval_queue_weight"`
 	Capabilities          *NamespaceCapabilities          `hcl:"capabilities,block"`
 	NodePoolConfiguration *NamespaceNodePoolConfiguration `hcl:"node_pool_config,block"`
 	VaultConfiguration    *NamespaceVaultConfiguration    `hcl:"vault,block"`
@@ -128,4 +128,5 @@ type NodePool struct {
 type NodePoolSchedulerConfiguration struct {
 	SchedulerAlgorithm            SchedulerAlgorithm `hcl:"scheduler_algorithm,optional"`
 	MemoryOversubscriptionEnabled *bool              `hcl:"memory_oversubscription_enabled,optional"`
+	LoadAwareConfig               *LoadAwareConfig   `hcl:"load_aware_config,block"`
 }
@@ -23,6 +23,12 @@ const (
 	NodeSchedulingEligible   = "eligible"
 	NodeSchedulingIneligible = "ineligible"
 
+	// NodeTaintEffectNoSchedule, PreferNoSchedule and NoExecute are the
+	// effects a node taint has on the allocations that do not tolerate it.
+	NodeTaintEffectNoSchedule       = "no_schedule"
+	NodeTaintEffectPreferNoSchedule = "prefer_no_schedule"
+	NodeTaintEffectNoExecute        = "no_execute"
+
 	DrainStatusDraining DrainStatus = "draining"
 	DrainStatusComplete DrainStatus = "complete"
 	DrainStatusCanceled DrainStatus = "canceled"
@@ -431,6 +437,44 @@ func (n *Nodes) ToggleEligibility(nodeID string, eligible bool, q *WriteOptions)
 	return &resp, nil
 }
 
+// NodeUpdateTaintsRequest is used to replace the taints of a node.
+type NodeUpdateTaintsRequest struct {
+	// NodeID is the node to update the taints for.
+	NodeID string
+	Taints []*NodeTaint
+
+	// NodeModifyIndex, if set, is the ModifyIndex the node must have for its
+	// taints to be replaced.
+	NodeModifyIndex uint64
+}
+
+// NodeTaintsUpdateResponse is used to respond to a node taints update
+type NodeTaintsUpdateResponse struct {
+	NodeModifyIndex uint64
+	EvalIDs         []string
+	EvalCreateIndex uint64
+	WriteMeta
+}
+
+// UpdateTaints is used to replace the taints of the node. If modifyIndex is
+// non-zero, the taints are only replaced if the node's ModifyIndex matches it,
+// so that concurrent updates do not overwrite each other.
+func (n *Nodes) UpdateTaints(nodeID string, taints []*NodeTaint, modifyIndex uint64, q *WriteOptions) (*NodeTaintsUpdateResponse, error) {
+	req := &NodeUpdateTaintsRequest{
+		NodeID:          nodeID,
+		Taints:          taints,
+		NodeModifyIndex: modifyIndex,
+	}
+
+	var resp NodeTaintsUpdateResponse
+	wm, err := n.client.put("/v1/node/"+nodeID+"/taints", req, &resp, q)
+	if err != nil {
+		return nil, err
+	}
+	resp.WriteMeta = *wm
+	return &resp, nil
+}
+
 // Allocations is used to return the allocations associated with a node.
 func (n *Nodes) Allocations(nodeID string, q *QueryOptions) ([]*Allocation, *QueryMeta, error) {
 	var resp []*Allocation
@@ -550,6 +594,8 @@ type Node struct {
 	Reserved              *Resources
 	NodeResources         *NodeResources
 	ReservedResources     *NodeReservedResources
+	Utilization           *NodeUtilization
+	Pressure              *NodePressure
 	Links                 map[string]string
 	Meta                  map[string]string
 	NodeClass             string
@@ -558,6 +604,7 @@ type Node struct {
 	Drain                 bool
 	DrainStrategy         *DrainStrategy
 	SchedulingEligibility string
+	Taints                []*NodeTaint
 	Status                string
 	StatusDescription     string
 	StatusUpdatedAt       int64
@@ -572,6 +619,21 @@ type Node struct {
 	ModifyIndex           uint64
 }
 
+// NodeTaint marks a node so that only allocations that tolerate the taint
+// are placed on it.
+type NodeTaint struct {
+	Key    string
+	Value  string
+	Effect string
+}
+
+func (t *NodeTaint) String() string {
+	if t.Value == "" {
+		return fmt.Sprintf("%s:%s", t.Key, t.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect)
+}
+
 type NodeResources struct {
 	Cpu      NodeCpuResources
 	Memory   NodeMemoryResources
@@ -597,6 +659,22 @@ type NodeDiskResources struct {
 	DiskMB int64
 }
 
+// NodeUtilization is the smoothed CPU and memory utilization of a node, as
+// measured by its client.
+type NodeUtilization struct {
+	CPUPercent    float64
+	MemoryPercent float64
+	UpdatedAt     int64
+}
+
+// NodePressure is the resource pressure of a node, as reported by its client
+// when it evicts allocations.
+type NodePressure struct {
+	Memory    bool
+	Disk      bool
+	UpdatedAt int64
+}
+
 type NodeReservedResources struct {
 	Cpu      NodeReservedCpuResources
 	Memory   NodeReservedMemoryResources
@@ -172,6 +172,14 @@ type SchedulerConfiguration struct {
 	// MemoryOversubscriptionEnabled specifies whether memory oversubscription is enabled
 	MemoryOversubscriptionEnabled bool
 
+	// LoadAwareConfig specifies whether the actual utilization reported by
+	// clients is taken into account when scoring nodes.
+	LoadAwareConfig LoadAwareConfig
+
+	// RebalanceConfig specifies whether allocations of service jobs are
+	// periodically migrated to restore their placement goals.
+	RebalanceConfig RebalanceConfig
+
 	// RejectJobRegistration disables new job registrations except with a
 	// management ACL token
 	RejectJobRegistration bool
@@ -185,6 +193,43 @@ type SchedulerConfiguration struct {
 	ModifyIndex uint64
 }
 
+// LoadAwareConfig specifies how the actual CPU and memory utilization reported
+// by clients is used to score nodes.
+type LoadAwareConfig struct {
+	// Enabled specifies whether nodes are penalized according to their
+	// actual utilization.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// UtilizationThreshold is the percentage of CPU or memory utilization
+	// above which nodes are penalized.
+	UtilizationThreshold int `hcl:"utilization_threshold,optional"`
+}
+
+// RebalanceConfig specifies how allocations of service jobs are periodically
+// migrated when they violate their spread or affinity goals, or run on
+// over-utilized nodes.
+type RebalanceConfig struct {
+	// Enabled specifies whether the rebalancer runs.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// DryRun specifies whether the rebalancer only reports the allocations
+	// it would migrate without migrating them.
+	DryRun bool `hcl:"dry_run,optional"`
+
+	// Namespaces is the list of namespaces opted in for rebalancing. The
+	// wildcard "*" opts in every namespace.
+	Namespaces []string `hcl:"namespaces,optional"`
+
+	// MaxMigrations is the maximum number of allocations migrated per
+	// rebalance run.
+	MaxMigrations int `hcl:"max_migrations,optional"`
+
+	// UtilizationThreshold is the percentage of CPU or memory utilization
+	// above which allocations are migrated off of a node. Zero disables
+	// rebalancing based on utilization.
+	UtilizationThreshold int `hcl:"utilization_threshold,optional"`
+}
+
 // SchedulerConfigurationResponse is the response object that wraps SchedulerConfiguration
 type SchedulerConfigurationResponse struct {
 	// SchedulerConfig contains scheduler config options
@@ -193,6 +238,25 @@ type SchedulerConfigurationResponse struct {
 	QueryMeta
 }
 
+// RebalanceCandidate is an allocation the rebalancer migrates, or would
+// migrate in dry run mode.
+type RebalanceCandidate struct {
+	AllocID   string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	NodeID    string
+	Reason    string
+}
+
+// SchedulerRebalanceCandidatesResponse is the response object used to
+// retrieve the allocations the rebalancer would migrate.
+type SchedulerRebalanceCandidatesResponse struct {
+	Candidates []*RebalanceCandidate
+
+	QueryMeta
+}
+
 // SchedulerSetConfigurationResponse is the response object used
 // when updating scheduler configuration
 type SchedulerSetConfigurationResponse struct {
@@ -209,8 +273,9 @@ type SchedulerSetConfigurationResponse struct {
 type SchedulerAlgorithm string
 
 const (
-	SchedulerAlgorithmBinpack SchedulerAlgorithm = "binpack"
-	SchedulerAlgorithmSpread  SchedulerAlgorithm = "spread"
+	SchedulerAlgorithmBinpack  SchedulerAlgorithm = "binpack"
+	SchedulerAlgorithmSpread   SchedulerAlgorithm = "spread"
+	SchedulerAlgorithmBalanced SchedulerAlgorithm = "balanced"
 )
 
 // PreemptionConfig specifies whether preemption is enabled based on scheduler type
@@ -231,6 +296,17 @@ func (op *Operator) SchedulerGetConfiguration(q *QueryOptions) (*SchedulerConfig
 	return &resp, qm, nil
 }
 
+// SchedulerRebalanceCandidates is used to query the allocations the rebalancer
+// would migrate with the current Scheduler configuration.
+func (op *Operator) SchedulerRebalanceCandidates(q *QueryOptions) (*SchedulerRebalanceCandidatesResponse, *QueryMeta, error) {
+	var resp SchedulerRebalanceCandidatesResponse
+	qm, err := op.c.query("/v1/operator/scheduler/rebalance", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // SchedulerSetConfiguration is used to set the current Scheduler configuration.
 func (op *Operator) SchedulerSetConfiguration(conf *SchedulerConfiguration, q *WriteOptions) (*SchedulerSetConfigurationResponse, *WriteMeta, error) {
 	var out SchedulerSetConfigurationResponse
@@ -18,6 +18,7 @@ type Resources struct {
 	Networks    []*NetworkResource `hcl:"network,block"`
 	Devices     []*RequestedDevice `hcl:"device,block"`
 	NUMA        *NUMAResource      `hcl:"numa,block"`
+	IO          *IOResource        `hcl:"io,block"`
 
 	// COMPAT(0.10)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
@@ -53,6 +54,7 @@ func (r *Resources) Canonicalize() {
 	}
 
 	r.NUMA.Canonicalize()
+	r.IO.Canonicalize()
 }
 
 // DefaultResources is a small resources object that contains the
@@ -103,6 +105,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.NUMA != nil {
 		r.NUMA = other.NUMA.Copy()
 	}
+	if other.IO != nil {
+		r.IO = other.IO.Copy()
+	}
 }
 
 // NUMAResource contains the NUMA affinity request for scheduling purposes.
@@ -131,6 +136,82 @@ func (n *NUMAResource) Canonicalize() {
 	}
 }
 
+// IOResource contains the block I/O limits of a task. They are only enforced
+// by drivers that support the cgroup v2 io controller.
+type IOResource struct {
+	// Weight is the relative share of block I/O of the task, between 1 and
+	// 10000.
+	Weight *int `hcl:"weight,optional"`
+
+	// Devices are the bandwidth and operation limits of the task on
+	// individual block devices.
+	Devices []*IODeviceResource `hcl:"device,block"`
+}
+
+func (r *IOResource) Copy() *IOResource {
+	if r == nil {
+		return nil
+	}
+	c := &IOResource{
+		Weight: pointerCopy(r.Weight),
+	}
+	for _, d := range r.Devices {
+		c.Devices = append(c.Devices, d.Copy())
+	}
+	return c
+}
+
+func (r *IOResource) Canonicalize() {
+	if r == nil {
+		return
+	}
+	if r.Weight == nil {
+		r.Weight = pointerOf(0)
+	}
+	for _, d := range r.Devices {
+		d.Canonicalize()
+	}
+}
+
+// IODeviceResource limits the block I/O of a task on a block device.
+type IODeviceResource struct {
+	// Path is the absolute path of the block device, such as /dev/sda.
+	Path string `hcl:",label"`
+
+	ReadBps   *int64 `mapstructure:"read_bps" hcl:"read_bps,optional"`
+	WriteBps  *int64 `mapstructure:"write_bps" hcl:"write_bps,optional"`
+	ReadIOPS  *int64 `mapstructure:"read_iops" hcl:"read_iops,optional"`
+	WriteIOPS *int64 `mapstructure:"write_iops" hcl:"write_iops,optional"`
+}
+
+func (d *IODeviceResource) Copy() *IODeviceResource {
+	if d == nil {
+		return nil
+	}
+	return &IODeviceResource{
+		Path:      d.Path,
+		ReadBps:   pointerCopy(d.ReadBps),
+		WriteBps:  pointerCopy(d.WriteBps),
+		ReadIOPS:  pointerCopy(d.ReadIOPS),
+		WriteIOPS: pointerCopy(d.WriteIOPS),
+	}
+}
+
+func (d *IODeviceResource) Canonicalize() {
+	if d.ReadBps == nil {
+		d.ReadBps = pointerOf(int64(0))
+	}
+	if d.WriteBps == nil {
+		d.WriteBps = pointerOf(int64(0))
+	}
+	if d.ReadIOPS == nil {
+		d.ReadIOPS = pointerOf(int64(0))
+	}
+	if d.WriteIOPS == nil {
+		d.WriteIOPS = pointerOf(int64(0))
+	}
+}
+
 type Port struct {
 	Label       string `hcl:",label"`
 	Value       int    `hcl:"static,optional"`
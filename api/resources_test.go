@@ -109,3 +109,40 @@ func TestNUMAResource_Canonicalize(t *testing.T) {
 	n2.Canonicalize()
 	must.Eq(t, &NUMAResource{Affinity: "none"}, n2)
 }
+
+func TestIOResource_Copy(t *testing.T) {
+	testutil.Parallel(t)
+
+	r1 := &IOResource{
+		Weight:  pointerOf(100),
+		Devices: []*IODeviceResource{{Path: "/dev/sda", ReadBps: pointerOf(int64(1024))}},
+	}
+	r2 := r1.Copy()
+	must.Eq(t, r1, r2)
+
+	*r1.Weight = 200
+	*r1.Devices[0].ReadBps = 2048
+	must.Eq(t, 100, *r2.Weight)
+	must.Eq(t, 1024, *r2.Devices[0].ReadBps)
+}
+
+func TestIOResource_Canonicalize(t *testing.T) {
+	testutil.Parallel(t)
+
+	var r1 *IOResource
+	r1.Canonicalize()
+	must.Nil(t, r1)
+
+	r2 := &IOResource{Devices: []*IODeviceResource{{Path: "/dev/sda"}}}
+	r2.Canonicalize()
+	must.Eq(t, &IOResource{
+		Weight: pointerOf(0),
+		Devices: []*IODeviceResource{{
+			Path:      "/dev/sda",
+			ReadBps:   pointerOf(int64(0)),
+			WriteBps:  pointerOf(int64(0)),
+			ReadIOPS:  pointerOf(int64(0)),
+			WriteIOPS: pointerOf(int64(0)),
+		}},
+	}, r2)
+}
@@ -54,19 +54,49 @@ type CpuStats struct {
 	Measured         []string
 }
 
-// ResourceUsage holds information related to cpu and memory stats
+// IOStats holds block I/O related stats
+type IOStats struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadOps    uint64
+	WriteOps   uint64
+	Measured   []string
+}
+
+// ResourceUsage holds information related to cpu, memory and block I/O stats
 type ResourceUsage struct {
 	MemoryStats *MemoryStats
 	CpuStats    *CpuStats
+	IOStats     *IOStats
 	DeviceStats []*DeviceGroupStats
 }
 
+// PSIStats holds the pressure stall information of a resource
+type PSIStats struct {
+	SomeAvg10  float64
+	SomeAvg60  float64
+	SomeAvg300 float64
+	SomeTotal  uint64
+	FullAvg10  float64
+	FullAvg60  float64
+	FullAvg300 float64
+	FullTotal  uint64
+}
+
+// PressureStats holds the pressure stall information of a task
+type PressureStats struct {
+	Memory *PSIStats
+	CPU    *PSIStats
+	IO     *PSIStats
+}
+
 // TaskResourceUsage holds aggregated resource usage of all processes in a Task
 // and the resource usage of the individual pids
 type TaskResourceUsage struct {
 	ResourceUsage *ResourceUsage
 	Timestamp     int64
 	Pids          map[string]*ResourceUsage
+	Pressure      *PressureStats
 }
 
 // AllocResourceUsage holds the aggregated task resource usage of the
@@ -101,6 +131,8 @@ type RestartPolicy struct {
 	Interval        *time.Duration `hcl:"interval,optional"`
 	Attempts        *int           `hcl:"attempts,optional"`
 	Delay           *time.Duration `hcl:"delay,optional"`
+	DelayFunction   *string        `mapstructure:"delay_function" hcl:"delay_function,optional"`
+	MaxDelay        *time.Duration `mapstructure:"max_delay" hcl:"max_delay,optional"`
 	Mode            *string        `hcl:"mode,optional"`
 	RenderTemplates *bool          `mapstructure:"render_templates" hcl:"render_templates,optional"`
 }
@@ -115,6 +147,12 @@ func (r *RestartPolicy) Merge(rp *RestartPolicy) {
 	if rp.Delay != nil {
 		r.Delay = rp.Delay
 	}
+	if rp.DelayFunction != nil {
+		r.DelayFunction = rp.DelayFunction
+	}
+	if rp.MaxDelay != nil {
+		r.MaxDelay = rp.MaxDelay
+	}
 	if rp.Mode != nil {
 		r.Mode = rp.Mode
 	}
@@ -175,6 +213,10 @@ type ReschedulePolicy struct {
 
 	// Unlimited allows rescheduling attempts until they succeed
 	Unlimited *bool `mapstructure:"unlimited" hcl:"unlimited,optional"`
+
+	// OnMaxRunDuration allows rescheduling allocations that failed because a
+	// task exceeded its max run duration.
+	OnMaxRunDuration *bool `mapstructure:"on_max_run_duration" hcl:"on_max_run_duration,optional"`
 }
 
 func (r *ReschedulePolicy) Merge(rp *ReschedulePolicy) {
@@ -199,6 +241,9 @@ func (r *ReschedulePolicy) Merge(rp *ReschedulePolicy) {
 	if rp.Unlimited != nil {
 		r.Unlimited = rp.Unlimited
 	}
+	if rp.OnMaxRunDuration != nil {
+		r.OnMaxRunDuration = rp.OnMaxRunDuration
+	}
 }
 
 func (r *ReschedulePolicy) Canonicalize(jobType string) {
@@ -361,6 +406,68 @@ func (s *Spread) Canonicalize() {
 	}
 }
 
+// TopologySpread is used to serialize a task group's topology spread, which
+// bounds the skew of allocations across the domains formed by its attributes.
+type TopologySpread struct {
+	Attributes        []string `hcl:"attributes,optional"`
+	MaxSkew           *int     `mapstructure:"max_skew" hcl:"max_skew,optional"`
+	WhenUnsatisfiable *string  `mapstructure:"when_unsatisfiable" hcl:"when_unsatisfiable,optional"`
+}
+
+func NewTopologySpread(attributes []string, maxSkew int) *TopologySpread {
+	return &TopologySpread{
+		Attributes: attributes,
+		MaxSkew:    pointerOf(maxSkew),
+	}
+}
+
+func (ts *TopologySpread) Canonicalize() {
+	if ts.MaxSkew == nil {
+		ts.MaxSkew = pointerOf(1)
+	}
+	if ts.WhenUnsatisfiable == nil {
+		ts.WhenUnsatisfiable = pointerOf("block")
+	}
+}
+
+// AllocationAffinity is used to serialize a task group's affinity or
+// anti-affinity to the allocations of other jobs.
+type AllocationAffinity struct {
+	Namespace string            `hcl:"namespace,optional"`
+	JobID     string            `mapstructure:"job" hcl:"job,optional"`
+	TaskGroup string            `mapstructure:"group" hcl:"group,optional"`
+	Meta      map[string]string `hcl:"meta,block"`
+	Anti      *bool             `hcl:"anti,optional"`
+	Required  *bool             `hcl:"required,optional"`
+	Weight    *int8             `hcl:"weight,optional"`
+}
+
+func (a *AllocationAffinity) Canonicalize() {
+	if a.Anti == nil {
+		a.Anti = pointerOf(false)
+	}
+	if a.Required == nil {
+		a.Required = pointerOf(false)
+	}
+	if a.Weight == nil && !*a.Required {
+		a.Weight = pointerOf(int8(50))
+	}
+}
+
+// Toleration is used to serialize a task group's toleration of node taints.
+type Toleration struct {
+	Key      string  `hcl:"key,optional"`
+	Operator *string `hcl:"operator,optional"`
+	Value    string  `hcl:"value,optional"`
+	Effect   string  `hcl:"effect,optional"`
+}
+
+func (t *Toleration) Canonicalize() {
+	if t.Operator == nil {
+		t.Operator = pointerOf("equal")
+	}
+}
+
 // EphemeralDisk is an ephemeral disk object
 type EphemeralDisk struct {
 	Sticky  *bool `hcl:"sticky,optional"`
@@ -449,6 +556,45 @@ func (m *MigrateStrategy) Copy() *MigrateStrategy {
 	return nm
 }
 
+// DisruptionBudget bounds the number of allocations of a task group that can
+// be voluntarily disrupted at the same time, such as by node drains or
+// preemption.
+type DisruptionBudget struct {
+	MaxUnavailable int `mapstructure:"max_unavailable" hcl:"max_unavailable,optional"`
+	MinAvailable   int `mapstructure:"min_available" hcl:"min_available,optional"`
+}
+
+func (d *DisruptionBudget) Copy() *DisruptionBudget {
+	if d == nil {
+		return nil
+	}
+	nd := new(DisruptionBudget)
+	*nd = *d
+	return nd
+}
+
+// ArrayConfig configures a batch task group to run one allocation for each
+// index of a range. Start and End are inclusive.
+type ArrayConfig struct {
+	Start       int `hcl:"start,optional"`
+	End         int `hcl:"end"`
+	MaxParallel int `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
+}
+
+// Size returns the number of indices of the array.
+func (a *ArrayConfig) Size() int {
+	return a.End - a.Start + 1
+}
+
+// CompletionPolicy configures a batch task group to keep placing allocations
+// until Completions of them complete successfully, running at most
+// Parallelism at once and tolerating up to BackoffLimit failed allocations.
+type CompletionPolicy struct {
+	Completions  int `hcl:"completions"`
+	Parallelism  int `hcl:"parallelism,optional"`
+	BackoffLimit int `mapstructure:"backoff_limit" hcl:"backoff_limit,optional"`
+}
+
 // VolumeRequest is a representation of a storage volume that a TaskGroup wishes to use.
 type VolumeRequest struct {
 	Name           string           `hcl:"name,label"`
@@ -500,6 +646,7 @@ type TaskGroup struct {
 	Affinities       []*Affinity               `hcl:"affinity,block"`
 	Tasks            []*Task                   `hcl:"task,block"`
 	Spreads          []*Spread                 `hcl:"spread,block"`
+	TopologySpreads  []*TopologySpread         `hcl:"topology_spread,block"`
 	Volumes          map[string]*VolumeRequest `hcl:"volume,block"`
 	RestartPolicy    *RestartPolicy            `hcl:"restart,block"`
 	Disconnect       *DisconnectStrategy       `hcl:"disconnect,block"`
@@ -507,6 +654,7 @@ type TaskGroup struct {
 	EphemeralDisk    *EphemeralDisk            `hcl:"ephemeral_disk,block"`
 	Update           *UpdateStrategy           `hcl:"update,block"`
 	Migrate          *MigrateStrategy          `hcl:"migrate,block"`
+	DisruptionBudget *DisruptionBudget         `hcl:"disruption_budget,block"`
 	Networks         []*NetworkResource        `hcl:"network,block"`
 	Meta             map[string]string         `hcl:"meta,block"`
 	Services         []*Service                `hcl:"service,block"`
@@ -518,7 +666,14 @@ type TaskGroup struct {
 	Scaling             *ScalingPolicy `hcl:"scaling,block"`
 	Consul              *Consul        `hcl:"consul,block"`
 	// To be deprecated after 1.8.0 infavour of Disconnect.Replace
-	PreventRescheduleOnLost *bool `hcl:"prevent_reschedule_on_lost,optional"`
+	PreventRescheduleOnLost *bool             `hcl:"prevent_reschedule_on_lost,optional"`
+	Gang                    *bool             `hcl:"gang,optional"`
+	Array                   *ArrayConfig      `hcl:"array,block"`
+	MaxRunDuration          *time.Duration    `mapstructure:"max_run_duration" hcl:"max_run_duration,optional"`
+	Completion              *CompletionPolicy `hcl:"completion,block"`
+
+	AllocationAffinities []*AllocationAffinity `hcl:"allocation_affinity,block"`
+	Tolerations          []*Toleration         `hcl:"toleration,block"`
 }
 
 // NewTaskGroup creates a new TaskGroup.
@@ -535,7 +690,11 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 		g.Name = pointerOf("")
 	}
 
-	if g.Count == nil {
+	if g.Array != nil {
+		g.Count = pointerOf(g.Array.Size())
+	} else if g.Completion != nil {
+		g.Count = pointerOf(g.Completion.Completions)
+	} else if g.Count == nil {
 		if g.Scaling != nil && g.Scaling.Min != nil {
 			g.Count = pointerOf(int(*g.Scaling.Min))
 		} else {
@@ -609,6 +768,11 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 		g.Migrate.Canonicalize()
 	}
 
+	// Inherit the disruption budget from the job if the group has none
+	if g.DisruptionBudget == nil && job.DisruptionBudget != nil {
+		g.DisruptionBudget = job.DisruptionBudget.Copy()
+	}
+
 	var defaultRestartPolicy *RestartPolicy
 	switch *job.Type {
 	case "service", "system":
@@ -629,6 +793,15 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	for _, spread := range g.Spreads {
 		spread.Canonicalize()
 	}
+	for _, ts := range g.TopologySpreads {
+		ts.Canonicalize()
+	}
+	for _, aa := range g.AllocationAffinities {
+		aa.Canonicalize()
+	}
+	for _, t := range g.Tolerations {
+		t.Canonicalize()
+	}
 	for _, a := range g.Affinities {
 		a.Canonicalize()
 	}
@@ -653,6 +826,8 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 func defaultServiceJobRestartPolicy() *RestartPolicy {
 	return &RestartPolicy{
 		Delay:           pointerOf(15 * time.Second),
+		DelayFunction:   pointerOf("constant"),
+		MaxDelay:        pointerOf(time.Duration(0)),
 		Attempts:        pointerOf(2),
 		Interval:        pointerOf(30 * time.Minute),
 		Mode:            pointerOf(RestartPolicyModeFail),
@@ -665,6 +840,8 @@ func defaultServiceJobRestartPolicy() *RestartPolicy {
 func defaultBatchJobRestartPolicy() *RestartPolicy {
 	return &RestartPolicy{
 		Delay:           pointerOf(15 * time.Second),
+		DelayFunction:   pointerOf("constant"),
+		MaxDelay:        pointerOf(time.Duration(0)),
 		Attempts:        pointerOf(3),
 		Interval:        pointerOf(24 * time.Hour),
 		Mode:            pointerOf(RestartPolicyModeFail),
@@ -789,6 +966,7 @@ type Task struct {
 	CSIPluginConfig *TaskCSIPluginConfig   `mapstructure:"csi_plugin" json:",omitempty" hcl:"csi_plugin,block"`
 	Leader          bool                   `hcl:"leader,optional"`
 	ShutdownDelay   time.Duration          `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
+	MaxRunDuration  time.Duration          `mapstructure:"max_run_duration" hcl:"max_run_duration,optional"`
 	KillSignal      string                 `mapstructure:"kill_signal" hcl:"kill_signal,optional"`
 	Kind            string                 `hcl:"kind,optional"`
 	ScalingPolicies []*ScalingPolicy       `hcl:"scaling,block"`
@@ -1144,6 +1322,11 @@ const (
 	TaskLeaderDead             = "Leader Task Dead"
 	TaskBuildingTaskDir        = "Building Task Directory"
 	TaskClientReconnected      = "Reconnected"
+	TaskMaxRunDurationExceeded = "Max Run Duration Exceeded"
+	TaskOOMKilled              = "OOM Killed"
+	TaskMemoryPressure         = "Memory Pressure"
+	TaskCPUThrottled           = "CPU Throttled"
+	TaskEvicted                = "Evicted"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -586,8 +586,11 @@ func TestTaskGroup_Merge_Update(t *testing.T) {
 	must.Eq(t, &UpdateStrategy{
 		AutoRevert:       pointerOf(true),
 		AutoPromote:      pointerOf(false),
+		Strategy:         pointerOf("rolling"),
+		HoldPeriod:       pointerOf(time.Duration(0)),
 		Canary:           pointerOf(5),
 		HealthCheck:      pointerOf("foo"),
+		CanaryPercent:    pointerOf(0),
 		HealthyDeadline:  pointerOf(5 * time.Minute),
 		ProgressDeadline: pointerOf(10 * time.Minute),
 		MaxParallel:      pointerOf(1),
@@ -750,6 +753,67 @@ func TestTaskGroup_Canonicalize_MigrateStrategy(t *testing.T) {
 	}
 }
 
+func TestTaskGroup_Canonicalize_DisruptionBudget(t *testing.T) {
+	testutil.Parallel(t)
+
+	job := &Job{
+		ID:               pointerOf("test"),
+		Type:             pointerOf("service"),
+		DisruptionBudget: &DisruptionBudget{MaxUnavailable: 1},
+	}
+	job.Canonicalize()
+
+	// Groups without a budget inherit the job's
+	tg := &TaskGroup{Name: pointerOf("foo")}
+	tg.Canonicalize(job)
+	must.Eq(t, &DisruptionBudget{MaxUnavailable: 1}, tg.DisruptionBudget)
+
+	// The group budget replaces the job's
+	tg = &TaskGroup{
+		Name:             pointerOf("bar"),
+		DisruptionBudget: &DisruptionBudget{MinAvailable: 2},
+	}
+	tg.Canonicalize(job)
+	must.Eq(t, &DisruptionBudget{MinAvailable: 2}, tg.DisruptionBudget)
+}
+
+func TestTaskGroup_Canonicalize_Array(t *testing.T) {
+	testutil.Parallel(t)
+
+	job := &Job{
+		ID:   pointerOf("test"),
+		Type: pointerOf("batch"),
+	}
+	job.Canonicalize()
+
+	// The count of array groups is the size of the array
+	tg := &TaskGroup{
+		Name:  pointerOf("foo"),
+		Count: pointerOf(1),
+		Array: &ArrayConfig{Start: 10, End: 19, MaxParallel: 2},
+	}
+	tg.Canonicalize(job)
+	must.Eq(t, 10, *tg.Count)
+}
+
+func TestTaskGroup_Canonicalize_Completion(t *testing.T) {
+	testutil.Parallel(t)
+
+	job := &Job{
+		ID:   pointerOf("test"),
+		Type: pointerOf("batch"),
+	}
+	job.Canonicalize()
+
+	// The count of completion groups is the number of completions
+	tg := &TaskGroup{
+		Name:       pointerOf("foo"),
+		Completion: &CompletionPolicy{Completions: 50, Parallelism: 10, BackoffLimit: 5},
+	}
+	tg.Canonicalize(job)
+	must.Eq(t, 50, *tg.Count)
+}
+
 // TestSpread_Canonicalize asserts that the spread block is canonicalized correctly
 func TestSpread_Canonicalize(t *testing.T) {
 	testutil.Parallel(t)
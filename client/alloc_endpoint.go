@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/armon/go-metrics"
@@ -281,7 +282,8 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 	// Check alloc-exec permission.
 	if err != nil {
 		return pointer.Of(int64(400)), err
-	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityAllocExec) {
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityAllocExec) &&
+		!isDeploymentActionIdentity(ident, &req) {
 		return nil, nstructs.ErrPermissionDenied
 	}
 
@@ -371,6 +373,18 @@ func (a *Allocations) execImpl(encoder *codec.Encoder, decoder *codec.Decoder, e
 	return nil, nil
 }
 
+// isDeploymentActionIdentity returns whether the request runs an action of the
+// task identified by a workload identity that servers sign to run the actions
+// of deployment analysis gates.
+func isDeploymentActionIdentity(ident *nstructs.AuthenticatedIdentity, req *cstructs.AllocExecRequest) bool {
+	if ident == nil || ident.Claims == nil || req.Action == "" {
+		return false
+	}
+	claims := ident.Claims
+	return slices.Contains(claims.Audience, nstructs.WorkloadIdentityDeploymentActionAud) &&
+		claims.AllocationID == req.AllocID && claims.TaskName == req.Task
+}
+
 // newExecStream returns a new exec stream as expected by drivers that interpolate with RPC streaming format
 func newExecStream(decoder *codec.Decoder, encoder *codec.Encoder) drivers.ExecTaskStream {
 	buf := new(bytes.Buffer)
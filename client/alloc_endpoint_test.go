@@ -769,6 +769,29 @@ OUTER:
 	}
 }
 
+func TestAlloc_IsDeploymentActionIdentity(t *testing.T) {
+	ci.Parallel(t)
+
+	req := &cstructs.AllocExecRequest{AllocID: "alloc", Task: "web", Action: "check"}
+	claims := func(aud, allocID, task string) *nstructs.AuthenticatedIdentity {
+		c := &nstructs.IdentityClaims{AllocationID: allocID, TaskName: task}
+		c.Audience = []string{aud}
+		return &nstructs.AuthenticatedIdentity{Claims: c}
+	}
+	aud := nstructs.WorkloadIdentityDeploymentActionAud
+
+	must.True(t, isDeploymentActionIdentity(claims(aud, "alloc", "web"), req))
+	must.False(t, isDeploymentActionIdentity(nil, req))
+	must.False(t, isDeploymentActionIdentity(&nstructs.AuthenticatedIdentity{ACLToken: mock.ACLToken()}, req))
+	must.False(t, isDeploymentActionIdentity(claims(nstructs.WorkloadIdentityDefaultAud, "alloc", "web"), req))
+	must.False(t, isDeploymentActionIdentity(claims(aud, "other", "web"), req))
+	must.False(t, isDeploymentActionIdentity(claims(aud, "alloc", "other"), req))
+
+	// Only actions can be run, not arbitrary commands
+	cmd := &cstructs.AllocExecRequest{AllocID: "alloc", Task: "web", Cmd: []string{"/bin/sh"}}
+	must.False(t, isDeploymentActionIdentity(claims(aud, "alloc", "web"), cmd))
+}
+
 func TestAlloc_ExecStreaming_NoAllocation(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -151,8 +151,12 @@ type AllocDirFS interface {
 	Stat(path string) (*cstructs.AllocFileInfo, error)
 	ReadAt(path string, offset int64) (io.ReadCloser, error)
 	Snapshot(w io.Writer) error
+	Archive(w io.Writer, path string, include, exclude []string) error
 	BlockUntilExists(ctx context.Context, path string) (chan error, error)
 	ChangeEvents(ctx context.Context, path string, curOffset int64) (*watch.FileChanges, error)
+	WriteFile(path string, data []byte, perm os.FileMode) error
+	Mkdir(path string, perm os.FileMode) error
+	Remove(path string, recursive bool) error
 }
 
 // NewAllocDir initializes the AllocDir struct with allocDir as base path for
@@ -267,6 +271,155 @@ func (d *AllocDir) Snapshot(w io.Writer) error {
 	return nil
 }
 
+// Archive writes a tar archive of the file or directory at the path relative
+// to the alloc dir to w. Files are named relative to the alloc dir, and
+// symlinks are archived as links without being followed. Special files such as
+// fifos and sockets are skipped.
+//
+// Files are only archived if include is empty or if their path relative to the
+// alloc dir, the path of one of their parent directories or their name matches
+// one of the include globs. Files and directories whose path or name matches
+// one of the exclude globs are skipped. The secrets and private directories of
+// tasks, and the shared alloc directory linked into them, are always skipped,
+// and archiving a path within them is prohibited.
+func (d *AllocDir) Archive(w io.Writer, path string, include, exclude []string) error {
+	if escapes, err := escapingfs.PathEscapesAllocDir(d.AllocDir, "", path); err != nil {
+		return fmt.Errorf("Failed to check if path escapes alloc directory: %w", err)
+	} else if escapes {
+		return fmt.Errorf("Path escapes the alloc directory")
+	}
+	for _, globs := range [][]string{include, exclude} {
+		for _, glob := range globs {
+			if _, err := filepath.Match(glob, ""); err != nil {
+				return fmt.Errorf("invalid glob %q: %w", glob, err)
+			}
+		}
+	}
+
+	p := filepath.Join(d.AllocDir, path)
+	if kind := d.protectedDir(p); kind != "" {
+		return fmt.Errorf("Reading %s file prohibited: %s", kind, path)
+	}
+
+	d.mu.RLock()
+	linked := make(map[string]struct{}, len(d.TaskDirs))
+	for _, dir := range d.TaskDirs {
+		linked[dir.SharedTaskDir] = struct{}{}
+	}
+	d.mu.RUnlock()
+
+	a := &archiver{
+		d:       d,
+		tw:      tar.NewWriter(w),
+		include: include,
+		exclude: exclude,
+		linked:  linked,
+	}
+	if err := d.archive(a, path); err != nil {
+		return err
+	}
+	return a.tw.Close()
+}
+
+// archiver writes the files walked by Archive to a tar archive.
+type archiver struct {
+	d                *AllocDir
+	tw               *tar.Writer
+	include, exclude []string
+
+	// linked are the shared alloc dirs linked into the task dirs, which are
+	// skipped since the shared alloc dir is archived on its own.
+	linked map[string]struct{}
+}
+
+// add writes the file at relPath to the archive, given its absolute path p as
+// resolved and its file info. The target of symlinks and the content of
+// regular files are read with readlink and open. It returns filepath.SkipDir
+// if the content of a directory must be skipped.
+func (a *archiver) add(p, relPath string, fileInfo os.FileInfo,
+	readlink func() (string, error), open func() (io.ReadCloser, error)) error {
+
+	// Skip the secrets and private directories of tasks, wherever the walk
+	// entered them from
+	_, linked := a.linked[p]
+	if linked || a.d.protectedDir(p) != "" {
+		if fileInfo.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+	if relPath == "." {
+		return nil
+	}
+
+	// Skip special files such as the log fifos and sockets
+	if fileInfo.Mode()&(os.ModeType&^(os.ModeDir|os.ModeSymlink)) != 0 {
+		return nil
+	}
+	if matchArchiveGlob(a.exclude, relPath, false) {
+		if fileInfo.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+	if len(a.include) > 0 && (fileInfo.IsDir() || !matchArchiveGlob(a.include, relPath, true)) {
+		// Parent directories are created when extracting the files
+		return nil
+	}
+
+	link := ""
+	if fileInfo.Mode()&os.ModeSymlink != 0 {
+		target, err := readlink()
+		if err != nil {
+			return fmt.Errorf("error reading symlink: %v", err)
+		}
+		link = target
+	}
+	hdr, err := tar.FileInfoHeader(fileInfo, link)
+	if err != nil {
+		return fmt.Errorf("error creating file header: %w", err)
+	}
+	hdr.Name = filepath.ToSlash(relPath)
+	if err := a.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	// Only regular files have content
+	if !fileInfo.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Copy at most the size in the header in case the file is growing
+	_, err = io.CopyN(a.tw, file, hdr.Size)
+	return err
+}
+
+// matchArchiveGlob returns true if the path relative to the alloc dir or its
+// name matches one of the globs. If parents is true, the paths of its parent
+// directories are matched as well.
+func matchArchiveGlob(globs []string, relPath string, parents bool) bool {
+	for _, glob := range globs {
+		if ok, _ := filepath.Match(glob, filepath.Base(relPath)); ok {
+			return true
+		}
+		for p := relPath; p != "." && p != string(filepath.Separator); p = filepath.Dir(p) {
+			if ok, _ := filepath.Match(glob, p); ok {
+				return true
+			}
+			if !parents {
+				break
+			}
+		}
+	}
+	return false
+}
+
 // Move other alloc directory's shared path and local dir to this alloc dir.
 func (d *AllocDir) Move(other Interface, tasks []*structs.Task) error {
 	d.mu.RLock()
@@ -464,18 +617,9 @@ func (d *AllocDir) ReadAt(path string, offset int64) (io.ReadCloser, error) {
 	p := filepath.Join(d.AllocDir, path)
 
 	// Check if it is trying to read into a secret directory
-	d.mu.RLock()
-	for _, dir := range d.TaskDirs {
-		if filepath.HasPrefix(p, dir.SecretsDir) {
-			d.mu.RUnlock()
-			return nil, fmt.Errorf("Reading secret file prohibited: %s", path)
-		}
-		if filepath.HasPrefix(p, dir.PrivateDir) {
-			d.mu.RUnlock()
-			return nil, fmt.Errorf("Reading private file prohibited: %s", path)
-		}
+	if kind := d.protectedDir(p); kind != "" {
+		return nil, fmt.Errorf("Reading %s file prohibited: %s", kind, path)
 	}
-	d.mu.RUnlock()
 
 	f, err := os.Open(p)
 	if err != nil {
@@ -534,6 +678,48 @@ func (d *AllocDir) ChangeEvents(ctx context.Context, path string, curOffset int6
 	return watcher.ChangeEvents(t, curOffset)
 }
 
+// protectedDir returns "secret" or "private" if the absolute path p is within
+// the secrets or private directory of a task, and an empty string otherwise.
+func (d *AllocDir) protectedDir(p string) string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, dir := range d.TaskDirs {
+		if filepath.HasPrefix(p, dir.SecretsDir) {
+			return "secret"
+		}
+		if filepath.HasPrefix(p, dir.PrivateDir) {
+			return "private"
+		}
+	}
+	return ""
+}
+
+// checkWritable returns an error if the absolute path p, given as path
+// relative to the alloc dir, is within the secrets or private directory of a
+// task.
+func (d *AllocDir) checkWritable(p, path string) error {
+	if kind := d.protectedDir(p); kind != "" {
+		return fmt.Errorf("Writing %s file prohibited: %s", kind, path)
+	}
+	return nil
+}
+
+// checkRemovable returns an error if the absolute path p, given as path
+// relative to the alloc dir, is one of the directories created by Nomad for
+// the allocation and its tasks.
+func (d *AllocDir) checkRemovable(p, path string) error {
+	d.mu.RLock()
+	protected := p == d.AllocDir || p == d.SharedDir
+	for _, dir := range d.TaskDirs {
+		protected = protected || p == dir.Dir || p == dir.LocalDir
+	}
+	d.mu.RUnlock()
+	if protected {
+		return fmt.Errorf("Removing allocation directory prohibited: %s", path)
+	}
+	return nil
+}
+
 // getFileWatcher returns a FileWatcher for the given path.
 func getFileWatcher(path string) watch.FileWatcher {
 	return watch.NewPollingFileWatcher(path)
@@ -0,0 +1,467 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package allocdir
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// resolveBeneath are the flags used to resolve the paths modified in the
+	// alloc dir. Tasks can swap any directory they own for a symlink at any
+	// time, so symlinks are never followed and paths can't leave the
+	// directory they are resolved from, rather than being checked before use.
+	resolveBeneath = unix.RESOLVE_BENEATH | unix.RESOLVE_NO_SYMLINKS | unix.RESOLVE_NO_MAGICLINKS
+
+	// maxOpenRetries is the number of times the resolution of a path is
+	// retried when a concurrent rename makes the kernel give up on it.
+	maxOpenRetries = 10
+
+	// maxRemoveRetries is the number of times the content of a directory
+	// being removed recursively is listed again when entries are added to it
+	// concurrently.
+	maxRemoveRetries = 10
+)
+
+// allocRoot is an open handle on the alloc dir, used to resolve the paths
+// modified beneath it.
+type allocRoot struct {
+	d *AllocDir
+
+	// fd is the O_PATH file descriptor of the alloc dir.
+	fd int
+
+	// real is the path of the alloc dir as resolved by the kernel, which
+	// differs from d.AllocDir when the data dir is behind a symlink.
+	real string
+}
+
+// openRoot opens the alloc dir to resolve paths beneath it.
+func (d *AllocDir) openRoot() (*allocRoot, error) {
+	fd, err := unix.Open(d.AllocDir, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: d.AllocDir, Err: err}
+	}
+	real, err := fdPath(fd)
+	if err != nil {
+		_ = unix.Close(fd)
+		return nil, err
+	}
+	return &allocRoot{d: d, fd: fd, real: real}, nil
+}
+
+func (r *allocRoot) Close() error {
+	return unix.Close(r.fd)
+}
+
+// open opens the path relative to the directory dirfd, beneath it and
+// without following symlinks.
+func (r *allocRoot) open(dirfd int, path string, flags uint64) (int, error) {
+	how := &unix.OpenHow{
+		Flags:   flags | unix.O_CLOEXEC,
+		Resolve: resolveBeneath,
+	}
+	for i := 0; ; i++ {
+		fd, err := unix.Openat2(dirfd, path, how)
+		switch {
+		case err == nil:
+			return fd, nil
+		case (err == unix.EAGAIN || err == unix.EINTR) && i < maxOpenRetries:
+			continue
+		case err == unix.ENOSYS:
+			return -1, errors.New("Modifying allocation files requires Linux 5.6 or later")
+		case err == unix.ELOOP || err == unix.EXDEV:
+			return -1, fmt.Errorf("Path escapes the alloc directory")
+		default:
+			return -1, &os.PathError{Op: "open", Path: path, Err: err}
+		}
+	}
+}
+
+// path returns the absolute path of name in the directory dirfd, as resolved
+// by the kernel and expressed relative to d.AllocDir so it can be checked
+// against the directories of the allocation.
+func (r *allocRoot) path(dirfd int, name string) (string, error) {
+	dir, err := fdPath(dirfd)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(r.real, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("Path escapes the alloc directory")
+	}
+	return filepath.Join(r.d.AllocDir, rel, name), nil
+}
+
+// openParent resolves the parent directory of path, which must already be
+// cleaned and relative to the alloc dir, and returns its file descriptor
+// along with the absolute resolved path of path.
+func (r *allocRoot) openParent(path, rel string) (int, string, error) {
+	fd, err := r.open(r.fd, filepath.Dir(rel), unix.O_PATH|unix.O_DIRECTORY)
+	if err != nil {
+		return -1, "", err
+	}
+	p, err := r.path(fd, filepath.Base(rel))
+	if err == nil {
+		err = r.d.checkWritable(p, path)
+	}
+	if err != nil {
+		_ = unix.Close(fd)
+		return -1, "", err
+	}
+	return fd, p, nil
+}
+
+// writableRel returns path relative to the alloc dir, once cleaned, if it
+// doesn't escape the alloc dir nor names the secrets or private directory of
+// a task. Paths are checked again once resolved.
+func (d *AllocDir) writableRel(path string) (string, error) {
+	p := filepath.Join(d.AllocDir, path)
+	rel, err := filepath.Rel(d.AllocDir, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("Path escapes the alloc directory")
+	}
+	if err := d.checkWritable(p, path); err != nil {
+		return "", err
+	}
+	return rel, nil
+}
+
+// WriteFile writes data to the file at the path relative to the alloc dir,
+// replacing it if it exists. The parent directory must exist, and the file is
+// owned by the owner of its parent directory so that tasks can access it.
+// The file is replaced atomically so tasks never read a partial write.
+func (d *AllocDir) WriteFile(path string, data []byte, perm os.FileMode) error {
+	rel, err := d.writableRel(path)
+	if err != nil {
+		return err
+	}
+	if rel == "." {
+		return fmt.Errorf("Path is a directory: %s", path)
+	}
+
+	root, err := d.openRoot()
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	parent, _, err := root.openParent(path, rel)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(parent)
+
+	name := filepath.Base(rel)
+	var st unix.Stat_t
+	if err := unix.Fstatat(parent, name, &st, unix.AT_SYMLINK_NOFOLLOW); err == nil && st.Mode&unix.S_IFMT == unix.S_IFDIR {
+		return fmt.Errorf("Path is a directory: %s", path)
+	}
+
+	tmp := "." + name + "." + uuid.Short()
+	fd, err := unix.Openat(parent, tmp, unix.O_WRONLY|unix.O_CREAT|unix.O_EXCL|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0o600)
+	if err != nil {
+		return &os.PathError{Op: "open", Path: filepath.Join(filepath.Dir(path), tmp), Err: err}
+	}
+	f := os.NewFile(uintptr(fd), tmp)
+	defer unix.Unlinkat(parent, tmp, 0)
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Chmod(perm)
+	}
+	if err == nil {
+		err = fchownAsParent(fd, parent)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := unix.Renameat(parent, tmp, parent, name); err != nil {
+		return &os.LinkError{Op: "rename", Old: tmp, New: path, Err: err}
+	}
+	return nil
+}
+
+// Mkdir creates the directory at the path relative to the alloc dir along with
+// any missing parents. The directories created are owned by the owner of the
+// closest existing parent so that tasks can access them.
+func (d *AllocDir) Mkdir(path string, perm os.FileMode) error {
+	rel, err := d.writableRel(path)
+	if err != nil {
+		return err
+	}
+	if rel == "." {
+		return nil
+	}
+
+	root, err := d.openRoot()
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	dir, err := root.open(root.fd, ".", unix.O_PATH|unix.O_DIRECTORY)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = unix.Close(dir) }()
+
+	// Walk down the path one directory at a time, creating the missing ones
+	// and checking each where it was actually resolved.
+	for _, name := range strings.Split(rel, string(filepath.Separator)) {
+		p, err := root.path(dir, name)
+		if err != nil {
+			return err
+		}
+		if err := d.checkWritable(p, path); err != nil {
+			return err
+		}
+
+		err = unix.Mkdirat(dir, name, uint32(perm.Perm()))
+		created := err == nil
+		if err != nil && err != unix.EEXIST {
+			return &os.PathError{Op: "mkdir", Path: path, Err: err}
+		}
+
+		next, err := root.open(dir, name, unix.O_PATH|unix.O_DIRECTORY)
+		if err != nil {
+			return err
+		}
+		_ = unix.Close(dir)
+		dir = next
+
+		if created {
+			if err := fchownAsParent(dir, -1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Remove removes the file or directory at the path relative to the alloc dir.
+// Non-empty directories are only removed if recursive is true. The
+// directories created by Nomad for the allocation and its tasks cannot be
+// removed.
+func (d *AllocDir) Remove(path string, recursive bool) error {
+	rel, err := d.writableRel(path)
+	if err != nil {
+		return err
+	}
+	if rel == "." {
+		return fmt.Errorf("Removing allocation directory prohibited: %s", path)
+	}
+
+	root, err := d.openRoot()
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	parent, p, err := root.openParent(path, rel)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(parent)
+	if err := d.checkRemovable(p, path); err != nil {
+		return err
+	}
+
+	name := filepath.Base(rel)
+	var st unix.Stat_t
+	if err := unix.Fstatat(parent, name, &st, unix.AT_SYMLINK_NOFOLLOW); err != nil {
+		return &os.PathError{Op: "remove", Path: path, Err: err}
+	}
+	if st.Mode&unix.S_IFMT != unix.S_IFDIR {
+		err = unix.Unlinkat(parent, name, 0)
+	} else if recursive {
+		err = root.removeAll(parent, name)
+	} else {
+		err = unix.Unlinkat(parent, name, unix.AT_REMOVEDIR)
+	}
+	if err != nil {
+		return &os.PathError{Op: "remove", Path: path, Err: err}
+	}
+	return nil
+}
+
+// removeAll removes the directory name in the directory dirfd along with its
+// content, without following symlinks.
+func (r *allocRoot) removeAll(dirfd int, name string) error {
+	fd, err := r.open(dirfd, name, unix.O_RDONLY|unix.O_DIRECTORY)
+	if err != nil {
+		return err
+	}
+	dir := os.NewFile(uintptr(fd), name)
+	defer dir.Close()
+
+	for i := 0; ; i++ {
+		if _, err := dir.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		names, err := dir.Readdirnames(-1)
+		if err != nil {
+			return err
+		}
+		for _, entry := range names {
+			var st unix.Stat_t
+			err := unix.Fstatat(fd, entry, &st, unix.AT_SYMLINK_NOFOLLOW)
+			if err == nil {
+				if st.Mode&unix.S_IFMT == unix.S_IFDIR {
+					err = r.removeAll(fd, entry)
+				} else {
+					err = unix.Unlinkat(fd, entry, 0)
+				}
+			}
+			if err != nil && !errors.Is(err, unix.ENOENT) {
+				return err
+			}
+		}
+
+		err = unix.Unlinkat(dirfd, name, unix.AT_REMOVEDIR)
+		if err != unix.ENOTEMPTY || i == maxRemoveRetries {
+			return err
+		}
+	}
+}
+
+// fchownAsParent sets the owner of the file fd to the owner of the directory
+// parent, or of the parent directory of fd if parent is -1.
+func fchownAsParent(fd, parent int) error {
+	var st unix.Stat_t
+	var err error
+	if parent == -1 {
+		err = unix.Fstatat(fd, "..", &st, unix.AT_SYMLINK_NOFOLLOW)
+	} else {
+		err = unix.Fstat(parent, &st)
+	}
+	if err != nil {
+		return err
+	}
+	return unix.Fchownat(fd, "", int(st.Uid), int(st.Gid), unix.AT_EMPTY_PATH)
+}
+
+// fdPath returns the path of the file fd as resolved by the kernel.
+func fdPath(fd int) (string, error) {
+	return os.Readlink("/proc/self/fd/" + strconv.Itoa(fd))
+}
+
+// archive adds the file or directory at path relative to the alloc dir to the
+// archive. Every file is opened relative to its parent directory without
+// following symlinks, and checked where the kernel resolved it, so tasks can't
+// redirect the walk by swapping files for symlinks while it runs.
+func (d *AllocDir) archive(a *archiver, path string) error {
+	rel, err := filepath.Rel(d.AllocDir, filepath.Join(d.AllocDir, path))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return fmt.Errorf("Path escapes the alloc directory")
+	}
+
+	root, err := d.openRoot()
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	parent, err := root.open(root.fd, filepath.Dir(rel), unix.O_PATH|unix.O_DIRECTORY)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(parent)
+
+	p, err := root.path(parent, filepath.Base(rel))
+	if err != nil {
+		return err
+	}
+	if kind := d.protectedDir(p); kind != "" {
+		return fmt.Errorf("Reading %s file prohibited: %s", kind, path)
+	}
+	return root.archive(a, parent, filepath.Base(rel), rel)
+}
+
+// archive adds the file name in the directory dirfd to the archive, along
+// with its content if it is a directory.
+func (r *allocRoot) archive(a *archiver, dirfd int, name, relPath string) error {
+	fd, err := r.open(dirfd, name, unix.O_PATH|unix.O_NOFOLLOW)
+	if err != nil {
+		return err
+	}
+	f := os.NewFile(uintptr(fd), name)
+	defer f.Close()
+
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	p, err := r.path(fd, "")
+	if err != nil {
+		return err
+	}
+
+	readlink := func() (string, error) {
+		buf := make([]byte, unix.PathMax)
+		n, err := unix.Readlinkat(fd, "", buf)
+		if err != nil {
+			return "", err
+		}
+		return string(buf[:n]), nil
+	}
+	open := func() (io.ReadCloser, error) {
+		// Don't block on a fifo swapped in for the file
+		return r.openSame(dirfd, name, fileInfo, unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK)
+	}
+	switch err := a.add(p, relPath, fileInfo, readlink, open); {
+	case err == filepath.SkipDir:
+		return nil
+	case err != nil || !fileInfo.IsDir():
+		return err
+	}
+
+	dir, err := r.openSame(dirfd, name, fileInfo, unix.O_RDONLY|unix.O_DIRECTORY)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	names, err := dir.Readdirnames(-1)
+	if err != nil {
+		return err
+	}
+	slices.Sort(names)
+	for _, entry := range names {
+		if err := r.archive(a, int(dir.Fd()), entry, filepath.Join(relPath, entry)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openSame opens the file name in the directory dirfd with flags, and fails if
+// it isn't the file described by fileInfo anymore.
+func (r *allocRoot) openSame(dirfd int, name string, fileInfo os.FileInfo, flags uint64) (*os.File, error) {
+	fd, err := r.open(dirfd, name, flags)
+	if err != nil {
+		return nil, err
+	}
+	f := os.NewFile(uintptr(fd), name)
+	if fi, err := f.Stat(); err != nil || !os.SameFile(fileInfo, fi) {
+		f.Close()
+		return nil, fmt.Errorf("file %s changed while being archived", name)
+	}
+	return f, nil
+}
@@ -0,0 +1,210 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package allocdir
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
+	"github.com/shoenig/test/must"
+	"golang.org/x/sys/unix"
+)
+
+func TestAllocDir_Write_Symlink(t *testing.T) {
+	ci.Parallel(t)
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	must.NoError(t, d.Build())
+	defer func() { _ = d.Destroy() }()
+
+	td := d.NewTaskDir(t1.Name)
+	must.NoError(t, td.Build(fsisolation.None, nil, "nobody"))
+
+	// a task links its local dir to its secrets dir, which stays within the
+	// alloc dir
+	secret := filepath.Join(td.SecretsDir, "token")
+	must.NoError(t, os.WriteFile(secret, []byte("secret"), 0o600))
+	must.NoError(t, os.Symlink("../secrets", filepath.Join(td.LocalDir, "link")))
+	secrets := dirNames(t, td.SecretsDir)
+
+	link := filepath.Join(t1.Name, TaskLocal, "link")
+	must.Error(t, d.WriteFile(filepath.Join(link, "token"), []byte("hi"), 0o644))
+	must.Error(t, d.WriteFile(filepath.Join(link, "other"), []byte("hi"), 0o644))
+	must.Error(t, d.Mkdir(filepath.Join(link, "dir"), 0o755))
+	must.Error(t, d.Remove(filepath.Join(link, "token"), false))
+
+	b, err := os.ReadFile(secret)
+	must.NoError(t, err)
+	must.Eq(t, "secret", string(b))
+	must.Eq(t, secrets, dirNames(t, td.SecretsDir))
+
+	// the link itself can be replaced or removed
+	must.NoError(t, d.Remove(link, false))
+	must.NoError(t, d.Mkdir(link, 0o755))
+	must.NoError(t, d.WriteFile(filepath.Join(link, "token"), []byte("hi"), 0o644))
+}
+
+func TestAllocDir_Write_SymlinkRace(t *testing.T) {
+	ci.Parallel(t)
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	must.NoError(t, d.Build())
+	defer func() { _ = d.Destroy() }()
+
+	td := d.NewTaskDir(t1.Name)
+	must.NoError(t, td.Build(fsisolation.None, nil, "nobody"))
+
+	// a task keeps swapping a directory with a symlink to its secrets dir,
+	// hoping to win the race against the checks of the path
+	dir := filepath.Join(td.LocalDir, "dir")
+	swap := filepath.Join(td.LocalDir, "swap")
+	must.NoError(t, os.Mkdir(dir, 0o755))
+	must.NoError(t, os.Symlink("../secrets", swap))
+	secrets := dirNames(t, td.SecretsDir)
+
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		for {
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+			_ = unix.Renameat2(unix.AT_FDCWD, dir, unix.AT_FDCWD, swap, unix.RENAME_EXCHANGE)
+		}
+	}()
+
+	target := filepath.Join(t1.Name, TaskLocal, "dir")
+	for i := 0; i < 1000; i++ {
+		_ = d.WriteFile(filepath.Join(target, "file"), []byte("hi"), 0o644)
+		_ = d.Mkdir(filepath.Join(target, "sub"), 0o755)
+	}
+	close(stopCh)
+	<-doneCh
+
+	// nothing was ever written to the secrets dir
+	must.Eq(t, secrets, dirNames(t, td.SecretsDir))
+}
+
+func TestAllocDir_Archive_Symlink(t *testing.T) {
+	ci.Parallel(t)
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	must.NoError(t, d.Build())
+	defer func() { _ = d.Destroy() }()
+
+	td := d.NewTaskDir(t1.Name)
+	must.NoError(t, td.Build(fsisolation.None, nil, "nobody"))
+
+	// a task links its local dir to its secrets dir, which stays within the
+	// alloc dir
+	must.NoError(t, os.WriteFile(filepath.Join(td.SecretsDir, "token"), []byte("secret"), 0o600))
+	must.NoError(t, os.Symlink("../secrets", filepath.Join(td.LocalDir, "link")))
+
+	link := filepath.Join(t1.Name, TaskLocal, "link")
+	_, err := archiveFiles(d, filepath.Join(link, "token"))
+	must.Error(t, err)
+
+	// the link is archived without being followed
+	files, err := archiveFiles(d, link)
+	must.NoError(t, err)
+	must.Eq(t, map[string]string{"web/local/link": "-> ../secrets"}, files)
+}
+
+func TestAllocDir_Archive_SymlinkRace(t *testing.T) {
+	ci.Parallel(t)
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	must.NoError(t, d.Build())
+	defer func() { _ = d.Destroy() }()
+
+	td := d.NewTaskDir(t1.Name)
+	must.NoError(t, td.Build(fsisolation.None, nil, "nobody"))
+
+	host := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(host, "shadow"), []byte("host"), 0o600))
+
+	// a task keeps swapping a directory with a symlink to a host directory,
+	// hoping to win the race against the walk of the archive
+	dir := filepath.Join(td.LocalDir, "dir")
+	swap := filepath.Join(td.LocalDir, "swap")
+	must.NoError(t, os.Mkdir(dir, 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "shadow"), []byte("task"), 0o600))
+	must.NoError(t, os.Symlink(host, swap))
+
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		for {
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+			_ = unix.Renameat2(unix.AT_FDCWD, dir, unix.AT_FDCWD, swap, unix.RENAME_EXCHANGE)
+		}
+	}()
+
+	for i := 0; i < 500; i++ {
+		for _, path := range []string{filepath.Join(t1.Name, TaskLocal), filepath.Join(t1.Name, TaskLocal, "dir")} {
+			files, _ := archiveFiles(d, path)
+			for name, content := range files {
+				must.NotEq(t, "host", content, must.Sprintf("host file archived as %s", name))
+			}
+		}
+	}
+	close(stopCh)
+	<-doneCh
+}
+
+// archiveFiles archives the path relative to the alloc dir and returns the
+// content of the regular files and the target of the symlinks written to the
+// archive, even if archiving fails midway.
+func archiveFiles(d *AllocDir, path string) (map[string]string, error) {
+	var b bytes.Buffer
+	archiveErr := d.Archive(&b, path, nil, nil)
+
+	files := map[string]string{}
+	tr := tar.NewReader(&b)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return files, archiveErr
+		}
+		switch hdr.Typeflag {
+		case tar.TypeReg:
+			content, _ := io.ReadAll(tr)
+			files[hdr.Name] = string(content)
+		case tar.TypeSymlink:
+			files[hdr.Name] = "-> " + hdr.Linkname
+		}
+	}
+}
+
+// dirNames returns the names of the entries of the directory dir.
+func dirNames(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	must.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+// +build !linux
+
+package allocdir
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/nomad/helper/escapingfs"
+)
+
+// writablePath returns the absolute path of a path relative to the alloc dir
+// if it can be modified: it must not escape the alloc dir, even through
+// symlinks, and must not be within the secrets or private directory of a
+// task.
+//
+// Unlike on Linux, the path is checked before being used so tasks swapping
+// its components for symlinks in the meantime can redirect the change.
+func (d *AllocDir) writablePath(path string) (string, error) {
+	if escapes, err := escapingfs.PathEscapesAllocDirOnWrite(d.AllocDir, "", path); err != nil {
+		return "", fmt.Errorf("Failed to check if path escapes alloc directory: %w", err)
+	} else if escapes {
+		return "", fmt.Errorf("Path escapes the alloc directory")
+	}
+
+	p := filepath.Join(d.AllocDir, path)
+	if err := d.checkWritable(p, path); err != nil {
+		return "", err
+	}
+	return p, nil
+}
+
+// WriteFile writes data to the file at the path relative to the alloc dir,
+// replacing it if it exists. The parent directory must exist, and the file is
+// owned by the owner of its parent directory so that tasks can access it.
+// The file is replaced atomically so tasks never read a partial write.
+func (d *AllocDir) WriteFile(path string, data []byte, perm os.FileMode) error {
+	p, err := d.writablePath(path)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(p); err == nil && info.IsDir() {
+		return fmt.Errorf("Path is a directory: %s", path)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(p), "."+filepath.Base(p)+".*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, perm); err != nil {
+		return err
+	}
+	if err := chownAsParent(tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+// Mkdir creates the directory at the path relative to the alloc dir along with
+// any missing parents. The directories created are owned by the owner of the
+// closest existing parent so that tasks can access them.
+func (d *AllocDir) Mkdir(path string, perm os.FileMode) error {
+	p, err := d.writablePath(path)
+	if err != nil {
+		return err
+	}
+
+	// Find the directories that do not exist yet, from the closest to the
+	// existing parent up to p.
+	var missing []string
+	for dir := p; !pathExists(dir); dir = filepath.Dir(dir) {
+		missing = append([]string{dir}, missing...)
+	}
+
+	if err := os.MkdirAll(p, perm); err != nil {
+		return err
+	}
+	for _, dir := range missing {
+		if err := chownAsParent(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove removes the file or directory at the path relative to the alloc dir.
+// Non-empty directories are only removed if recursive is true. The
+// directories created by Nomad for the allocation and its tasks cannot be
+// removed.
+func (d *AllocDir) Remove(path string, recursive bool) error {
+	p, err := d.writablePath(path)
+	if err != nil {
+		return err
+	}
+	if err := d.checkRemovable(p, path); err != nil {
+		return err
+	}
+
+	if _, err := os.Lstat(p); err != nil {
+		return err
+	}
+	if recursive {
+		return os.RemoveAll(p)
+	}
+	return os.Remove(p)
+}
+
+// chownAsParent sets the owner of path to the owner of its parent directory,
+// on systems that support it.
+func chownAsParent(path string) error {
+	fi, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	uid, gid := getOwner(fi)
+	if uid == idUnsupported || gid == idUnsupported {
+		return nil
+	}
+	return os.Lchown(path, uid, gid)
+}
+
+// archive adds the file or directory at path relative to the alloc dir to the
+// archive.
+//
+// Unlike on Linux, files are opened by path so tasks swapping them for
+// symlinks while the walk runs can redirect it.
+func (d *AllocDir) archive(a *archiver, path string) error {
+	return filepath.Walk(filepath.Join(d.AllocDir, path), func(p string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(d.AllocDir, p)
+		if err != nil {
+			return err
+		}
+		readlink := func() (string, error) { return os.Readlink(p) }
+		open := func() (io.ReadCloser, error) { return os.Open(p) }
+		return a.add(p, relPath, fileInfo, readlink, open)
+	})
+}
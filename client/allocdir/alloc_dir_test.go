@@ -203,6 +203,94 @@ func TestAllocDir_Snapshot(t *testing.T) {
 	must.SliceLen(t, 2, links)
 }
 
+func TestAllocDir_Archive(t *testing.T) {
+	ci.Parallel(t)
+
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	defer d.Destroy()
+	must.NoError(t, d.Build())
+
+	td := d.NewTaskDir(t1.Name)
+	must.NoError(t, td.Build(fsisolation.None, nil, "nobody"))
+
+	// Write files to the shared and task dirs
+	must.NoError(t, os.WriteFile(filepath.Join(d.SharedDir, LogDirName, "web.stdout.0"), []byte("out"), 0o666))
+	must.NoError(t, os.WriteFile(filepath.Join(d.SharedDir, SharedDataDir, "core.dump"), []byte("core"), 0o666))
+	must.NoError(t, os.WriteFile(filepath.Join(td.LocalDir, "app.conf"), []byte("conf"), 0o666))
+	must.NoError(t, os.WriteFile(filepath.Join(td.SecretsDir, "token"), []byte("secret"), 0o666))
+	must.NoError(t, os.Symlink("/etc/passwd", filepath.Join(td.LocalDir, "passwd")))
+	must.NoError(t, unix.Mkfifo(filepath.Join(d.SharedDir, LogDirName, ".web.stdout.fifo"), 0o600))
+
+	// archive returns the content of the regular files and the target of the
+	// symlinks in the archive
+	archive := func(path string, include, exclude []string) map[string]string {
+		var b bytes.Buffer
+		must.NoError(t, d.Archive(&b, path, include, exclude))
+
+		entries := map[string]string{}
+		tr := tar.NewReader(&b)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			must.NoError(t, err)
+			switch hdr.Typeflag {
+			case tar.TypeReg:
+				content, err := io.ReadAll(tr)
+				must.NoError(t, err)
+				entries[hdr.Name] = string(content)
+			case tar.TypeSymlink:
+				entries[hdr.Name] = "-> " + hdr.Linkname
+			case tar.TypeFifo:
+				t.Fatalf("unexpected fifo %s in archive", hdr.Name)
+			}
+		}
+		return entries
+	}
+
+	// secrets and fifos are never archived and symlinks are not followed
+	must.Eq(t, map[string]string{
+		"alloc/logs/web.stdout.0": "out",
+		"alloc/data/core.dump":    "core",
+		"web/local/app.conf":      "conf",
+		"web/local/passwd":        "-> /etc/passwd",
+	}, archive("/", nil, nil))
+
+	// archive a directory
+	must.Eq(t, map[string]string{
+		"web/local/app.conf": "conf",
+		"web/local/passwd":   "-> /etc/passwd",
+	}, archive("web/local", nil, nil))
+
+	// include directories and file names
+	must.Eq(t, map[string]string{
+		"alloc/logs/web.stdout.0": "out",
+		"web/local/app.conf":      "conf",
+	}, archive("/", []string{"alloc/logs", "*.conf"}, nil))
+
+	// exclude directories and file names
+	must.Eq(t, map[string]string{
+		"alloc/data/core.dump": "core",
+	}, archive("/", nil, []string{"logs", "web"}))
+
+	// secrets cannot be included
+	must.Eq(t, map[string]string{}, archive("/", []string{"token"}, nil))
+
+	var b bytes.Buffer
+	must.ErrorContains(t, d.Archive(&b, "../foo", nil, nil), "escapes")
+	must.ErrorContains(t, d.Archive(&b, "/", []string{"[z-a"}, nil), "invalid glob")
+
+	// secrets and private files cannot be archived directly either
+	err := d.Archive(&b, filepath.Join(t1.Name, TaskSecrets, "token"), nil, nil)
+	must.EqError(t, err, "Reading secret file prohibited: web/secrets/token")
+	err = d.Archive(&b, filepath.Join(t1.Name, TaskPrivate), nil, nil)
+	must.EqError(t, err, "Reading private file prohibited: web/private")
+	must.Zero(t, b.Len())
+}
+
 func TestAllocDir_Move(t *testing.T) {
 	ci.Parallel(t)
 
@@ -284,6 +372,22 @@ func TestAllocDir_EscapeChecking(t *testing.T) {
 	if _, err := d.ChangeEvents(context.Background(), "../foo", 0); err == nil || !strings.Contains(err.Error(), "escapes") {
 		t.Fatalf("ChangeEvents of escaping path didn't error: %v", err)
 	}
+
+	// WriteFile
+	must.ErrorContains(t, d.WriteFile("../foo", []byte("hi"), 0o644), "escapes")
+
+	// Mkdir
+	must.ErrorContains(t, d.Mkdir("../foo", 0o755), "escapes")
+
+	// Remove
+	must.ErrorContains(t, d.Remove("../foo", true), "escapes")
+
+	// Writing through a symlink to outside the alloc dir
+	outside := t.TempDir()
+	must.NoError(t, os.Symlink(outside, filepath.Join(d.SharedDir, "link")))
+	must.ErrorContains(t, d.WriteFile("alloc/link/foo", []byte("hi"), 0o644), "escapes")
+	must.ErrorContains(t, d.Mkdir("alloc/link/foo", 0o755), "escapes")
+	must.FileNotExists(t, filepath.Join(outside, "foo"))
 }
 
 // Test that `nomad fs` can't read secrets
@@ -310,6 +414,108 @@ func TestAllocDir_ReadAt_SecretDir(t *testing.T) {
 	must.EqError(t, err, "Reading secret file prohibited: web/secrets/test_file")
 }
 
+func TestAllocDir_WriteFile(t *testing.T) {
+	ci.Parallel(t)
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	must.NoError(t, d.Build())
+	defer func() { _ = d.Destroy() }()
+
+	td := d.NewTaskDir(t1.Name)
+	must.NoError(t, td.Build(fsisolation.None, nil, "nobody"))
+
+	target := filepath.Join(t1.Name, TaskLocal, "config.json")
+	full := filepath.Join(d.AllocDir, target)
+
+	// write a new file
+	must.NoError(t, d.WriteFile(target, []byte("hello"), 0o640))
+	b, err := os.ReadFile(full)
+	must.NoError(t, err)
+	must.Eq(t, "hello", string(b))
+	fi, err := os.Stat(full)
+	must.NoError(t, err)
+	must.Eq(t, os.FileMode(0o640), fi.Mode().Perm())
+
+	// replace it
+	must.NoError(t, d.WriteFile(target, []byte("bye"), 0o644))
+	b, err = os.ReadFile(full)
+	must.NoError(t, err)
+	must.Eq(t, "bye", string(b))
+
+	// only the file is left in the directory
+	files, err := d.List(filepath.Join(t1.Name, TaskLocal))
+	must.NoError(t, err)
+	must.Len(t, 1, files)
+
+	// the parent directory must exist
+	err = d.WriteFile(filepath.Join(t1.Name, TaskLocal, "foo", "bar"), nil, 0o644)
+	must.ErrorIs(t, err, os.ErrNotExist)
+
+	// directories cannot be overwritten
+	err = d.WriteFile(filepath.Join(t1.Name, TaskLocal), nil, 0o644)
+	must.EqError(t, err, "Path is a directory: web/local")
+
+	// secrets cannot be written
+	err = d.WriteFile(filepath.Join(t1.Name, TaskSecrets, "token"), nil, 0o644)
+	must.EqError(t, err, "Writing secret file prohibited: web/secrets/token")
+}
+
+func TestAllocDir_Mkdir(t *testing.T) {
+	ci.Parallel(t)
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	must.NoError(t, d.Build())
+	defer func() { _ = d.Destroy() }()
+
+	target := filepath.Join(SharedAllocName, SharedDataDir, "foo", "bar")
+	must.NoError(t, d.Mkdir(target, 0o750))
+
+	fi, err := d.Stat(target)
+	must.NoError(t, err)
+	must.True(t, fi.IsDir)
+
+	// creating an existing directory is not an error
+	must.NoError(t, d.Mkdir(target, 0o750))
+}
+
+func TestAllocDir_Remove(t *testing.T) {
+	ci.Parallel(t)
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	must.NoError(t, d.Build())
+	defer func() { _ = d.Destroy() }()
+
+	td := d.NewTaskDir(t1.Name)
+	must.NoError(t, td.Build(fsisolation.None, nil, "nobody"))
+
+	dir := filepath.Join(SharedAllocName, SharedDataDir, "foo")
+	file := filepath.Join(dir, "bar")
+	must.NoError(t, d.Mkdir(dir, 0o755))
+	must.NoError(t, d.WriteFile(file, []byte("hi"), 0o644))
+
+	// non-empty directories are only removed recursively
+	must.Error(t, d.Remove(dir, false))
+	must.NoError(t, d.Remove(file, false))
+	must.FileNotExists(t, filepath.Join(d.AllocDir, file))
+	must.NoError(t, d.Mkdir(filepath.Join(dir, "baz"), 0o755))
+	must.NoError(t, d.Remove(dir, true))
+	must.DirNotExists(t, filepath.Join(d.AllocDir, dir))
+
+	// missing files cannot be removed
+	must.ErrorIs(t, d.Remove(dir, true), os.ErrNotExist)
+
+	// the directories of the allocation cannot be removed
+	for _, path := range []string{"/", SharedAllocName, t1.Name, filepath.Join(t1.Name, TaskLocal)} {
+		err := d.Remove(path, true)
+		must.ErrorContains(t, err, "Removing allocation directory prohibited")
+	}
+	err := d.Remove(filepath.Join(t1.Name, TaskSecrets), true)
+	must.EqError(t, err, "Writing secret file prohibited: web/secrets")
+}
+
 func TestAllocDir_SplitPath(t *testing.T) {
 	ci.Parallel(t)
 
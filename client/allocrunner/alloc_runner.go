@@ -827,6 +827,7 @@ func (ar *allocRunner) clientAlloc(taskStates map[string]*structs.TaskState) *st
 	} else {
 		a.ClientStatus, a.ClientDescription = getClientStatus(taskStates)
 	}
+	a.Evicted = a.ClientStatus == structs.AllocClientStatusFailed && allocEvicted(taskStates)
 
 	// If the allocation is terminal, make sure all required fields are properly
 	// set.
@@ -877,10 +878,22 @@ func (ar *allocRunner) clientAlloc(taskStates map[string]*structs.TaskState) *st
 	return a
 }
 
+// allocEvicted returns whether a task of the allocation failed because the
+// client evicted it.
+func allocEvicted(taskStates map[string]*structs.TaskState) bool {
+	for _, state := range taskStates {
+		if state.EvictionReason() != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // getClientStatus takes in the task states for a given allocation and computes
 // the client status and description
 func getClientStatus(taskStates map[string]*structs.TaskState) (status, description string) {
-	var pending, running, dead, failed bool
+	var pending, running, dead, failed, maxRunDurationExceeded bool
+	var evictionReason string
 	for _, state := range taskStates {
 		switch state.State {
 		case structs.TaskStateRunning:
@@ -890,6 +903,10 @@ func getClientStatus(taskStates map[string]*structs.TaskState) (status, descript
 		case structs.TaskStateDead:
 			if state.Failed {
 				failed = true
+				maxRunDurationExceeded = maxRunDurationExceeded || state.MaxRunDurationExceeded()
+				if reason := state.EvictionReason(); reason != "" {
+					evictionReason = reason
+				}
 			} else {
 				dead = true
 			}
@@ -897,7 +914,11 @@ func getClientStatus(taskStates map[string]*structs.TaskState) (status, descript
 	}
 
 	// Determine the alloc status
-	if failed {
+	if evictionReason != "" {
+		return structs.AllocClientStatusFailed, evictionReason
+	} else if maxRunDurationExceeded {
+		return structs.AllocClientStatusFailed, "Tasks exceeded their max run duration"
+	} else if failed {
 		return structs.AllocClientStatusFailed, "Failed tasks"
 	} else if running {
 		return structs.AllocClientStatusRunning, "Tasks are running"
@@ -1393,6 +1414,39 @@ func (ar *allocRunner) restartTasks(ctx context.Context, event *structs.TaskEven
 	return err.ErrorOrNil()
 }
 
+// Evict kills the tasks of the allocation because the node is running low on
+// memory or disk. The tasks fail with the reason so that the allocation is
+// reported as failed and rescheduled on another node.
+func (ar *allocRunner) Evict(reason string) error {
+	var err *multierror.Error
+	var errMutex sync.Mutex
+
+	var wg sync.WaitGroup
+	for tn, tr := range ar.tasks {
+		wg.Add(1)
+		go func(taskName string, taskRunner *taskrunner.TaskRunner) {
+			defer wg.Done()
+
+			event := structs.NewTaskEvent(structs.TaskEvicted).
+				SetKillReason(reason).
+				SetKillTimeout(taskRunner.Task().KillTimeout, ar.clientConfig.MaxKillTimeout).
+				SetFailsTask()
+
+			// Ignore ErrTaskNotRunning errors since tasks that are not
+			// running have nothing to evict.
+			e := taskRunner.Kill(context.TODO(), event)
+			if e != nil && e != taskrunner.ErrTaskNotRunning {
+				errMutex.Lock()
+				defer errMutex.Unlock()
+				err = multierror.Append(err, fmt.Errorf("failed to evict task %s: %v", taskName, e))
+			}
+		}(tn, tr)
+	}
+	wg.Wait()
+
+	return err.ErrorOrNil()
+}
+
 // Signal sends a signal request to task runners inside an allocation. If the
 // taskName is empty, then it is sent to all tasks.
 func (ar *allocRunner) Signal(taskName, signal string) error {
@@ -2470,6 +2470,45 @@ func TestAllocRunner_PreKill_RunOnDone(t *testing.T) {
 	))
 }
 
+func TestAllocRunner_ClientAlloc_Evicted(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc.Copy())
+	t.Cleanup(cleanup)
+
+	arIface, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+	ar := arIface.(*allocRunner)
+
+	// tasks failing on their own don't mark the allocation as evicted
+	failed := &structs.TaskState{
+		State:  structs.TaskStateDead,
+		Failed: true,
+		Events: []*structs.TaskEvent{structs.NewTaskEvent(structs.TaskTerminated)},
+	}
+	calloc := ar.clientAlloc(map[string]*structs.TaskState{task.Name: failed})
+	must.Eq(t, structs.AllocClientStatusFailed, calloc.ClientStatus)
+	must.False(t, calloc.Evicted)
+
+	evicted := &structs.TaskState{
+		State:  structs.TaskStateDead,
+		Failed: true,
+		Events: []*structs.TaskEvent{
+			structs.NewTaskEvent(structs.TaskEvicted).
+				SetKillReason("Evicted due to node memory pressure").
+				SetFailsTask(),
+			structs.NewTaskEvent(structs.TaskKilled),
+		},
+	}
+	calloc = ar.clientAlloc(map[string]*structs.TaskState{task.Name: evicted})
+	must.Eq(t, structs.AllocClientStatusFailed, calloc.ClientStatus)
+	must.Eq(t, "Evicted due to node memory pressure", calloc.ClientDescription)
+	must.True(t, calloc.Evicted)
+}
+
 func TestAllocRunner_GetUpdatePriority(t *testing.T) {
 	ci.Parallel(t)
 
@@ -50,6 +50,7 @@ type groupServiceHook struct {
 
 	// The following fields may be updated
 	canary         bool
+	standby        bool
 	services       []*structs.Service
 	networks       structs.Networks
 	ports          structs.AllocatedPorts
@@ -113,6 +114,7 @@ func newGroupServiceHook(cfg groupServiceHookConfig) *groupServiceHook {
 
 	if cfg.alloc.DeploymentStatus != nil {
 		h.canary = cfg.alloc.DeploymentStatus.Canary
+		h.standby = cfg.alloc.DeploymentStatus.Standby
 	}
 
 	return h
@@ -153,9 +155,10 @@ func (h *groupServiceHook) Update(req *interfaces.RunnerUpdateRequest) error {
 	oldWorkloadServices := h.getWorkloadServicesLocked()
 
 	// Store new updated values out of request
-	canary := false
+	canary, standby := false, false
 	if req.Alloc.DeploymentStatus != nil {
 		canary = req.Alloc.DeploymentStatus.Canary
+		standby = req.Alloc.DeploymentStatus.Standby
 	}
 
 	var networks structs.Networks
@@ -174,6 +177,7 @@ func (h *groupServiceHook) Update(req *interfaces.RunnerUpdateRequest) error {
 	h.networks = networks
 	h.services = tg.Services
 	h.canary = canary
+	h.standby = standby
 	h.delay = shutdown
 	h.taskEnvBuilder.UpdateTask(req.Alloc, nil)
 
@@ -287,6 +291,11 @@ func (h *groupServiceHook) getWorkloadServicesLocked() *serviceregistration.Work
 		Namespace: h.namespace,
 	}
 
+	// Allocations held by a blue/green deployment don't register services
+	if h.standby {
+		interpolatedServices = nil
+	}
+
 	// Create task services struct with request's driver metadata
 	return &serviceregistration.WorkloadServices{
 		AllocInfo:         info,
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var _ interfaces.TaskPrestartHook = (*maxRunDurationHook)(nil)
+var _ interfaces.TaskPoststartHook = (*maxRunDurationHook)(nil)
+var _ interfaces.TaskStopHook = (*maxRunDurationHook)(nil)
+var _ interfaces.ShutdownHook = (*maxRunDurationHook)(nil)
+
+// maxRunDurationFirstStartedAtKey is the hook state key of the time the task
+// first started at, in nanoseconds since the Unix epoch.
+const maxRunDurationFirstStartedAtKey = "first_started_at"
+
+type maxRunDurationHookConfig struct {
+	// duration is the max run duration of the task
+	duration time.Duration
+
+	// startedAt returns the time the task was last started at
+	startedAt func() time.Time
+
+	lifecycle ti.TaskLifecycle
+	logger    hclog.Logger
+}
+
+// maxRunDurationHook kills and fails the task once it has been running for
+// longer than its max run duration. The deadline is computed from the time the
+// task first started at, which is persisted in the hook state so that it is
+// not reset when the task or the client restarts.
+type maxRunDurationHook struct {
+	duration  time.Duration
+	startedAt func() time.Time
+	lifecycle ti.TaskLifecycle
+
+	// firstStartedAt is the time the task first started at
+	firstStartedAt time.Time
+
+	// deadline is the time at which the task is killed
+	deadline time.Time
+
+	// cancel stops the deadline timer
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	logger hclog.Logger
+}
+
+func newMaxRunDurationHook(c *maxRunDurationHookConfig) *maxRunDurationHook {
+	h := &maxRunDurationHook{
+		duration:  c.duration,
+		startedAt: c.startedAt,
+		lifecycle: c.lifecycle,
+	}
+	h.logger = c.logger.Named(h.Name())
+	return h
+}
+
+func (*maxRunDurationHook) Name() string {
+	return "max_run_duration"
+}
+
+// Prestart restores the time the task first started at from the hook state,
+// and persists it once the task has started. The task state only holds the
+// time the task last started at, so the first start time is captured before
+// the task is restarted or restored by the client for the first time.
+func (h *maxRunDurationHook) Prestart(_ context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.firstStartedAt.IsZero() {
+		if v, ok := req.PreviousState[maxRunDurationFirstStartedAtKey]; ok {
+			nanos, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse first start time %q: %w", v, err)
+			}
+			h.firstStartedAt = time.Unix(0, nanos)
+		} else {
+			// The task has already started if it is being restarted or
+			// restored, and is zero before the first start
+			h.firstStartedAt = h.startedAt()
+		}
+	}
+
+	if !h.firstStartedAt.IsZero() {
+		resp.State = map[string]string{
+			maxRunDurationFirstStartedAtKey: strconv.FormatInt(h.firstStartedAt.UnixNano(), 10),
+		}
+	}
+	return nil
+}
+
+func (h *maxRunDurationHook) Poststart(_ context.Context, _ *interfaces.TaskPoststartRequest, _ *interfaces.TaskPoststartResponse) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.firstStartedAt.IsZero() {
+		h.firstStartedAt = h.startedAt()
+		if h.firstStartedAt.IsZero() {
+			h.firstStartedAt = time.Now()
+		}
+	}
+	if h.deadline.IsZero() {
+		h.deadline = h.firstStartedAt.Add(h.duration)
+	}
+
+	if h.cancel != nil {
+		h.cancel()
+	}
+
+	// Using a new context so the timer keeps running after the Poststart
+	// request completes, it is canceled when the task stops
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	go h.wait(ctx, h.deadline)
+
+	return nil
+}
+
+// wait kills the task once the deadline is reached, unless the context is
+// canceled first.
+func (h *maxRunDurationHook) wait(ctx context.Context, deadline time.Time) {
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-timer.C:
+	}
+
+	h.logger.Info("task exceeded its max run duration, killing task", "max_run_duration", h.duration)
+	event := structs.NewTaskEvent(structs.TaskMaxRunDurationExceeded).
+		SetKillReason(fmt.Sprintf("Task exceeded its max run duration of %v", h.duration)).
+		SetFailsTask()
+	if err := h.lifecycle.Kill(context.Background(), event); err != nil {
+		h.logger.Error("failed to kill task", "error", err)
+	}
+}
+
+func (h *maxRunDurationHook) Stop(context.Context, *interfaces.TaskStopRequest, *interfaces.TaskStopResponse) error {
+	h.stop()
+	return nil
+}
+
+func (h *maxRunDurationHook) Shutdown() {
+	h.stop()
+}
+
+func (h *maxRunDurationHook) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
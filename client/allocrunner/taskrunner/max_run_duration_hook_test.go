@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	trtesting "github.com/hashicorp/nomad/client/allocrunner/taskrunner/testing"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestMaxRunDurationHook_Kill(t *testing.T) {
+	ci.Parallel(t)
+
+	lifecycle := trtesting.NewMockTaskHooks()
+	h := newMaxRunDurationHook(&maxRunDurationHookConfig{
+		duration:  100 * time.Millisecond,
+		startedAt: func() time.Time { return time.Now() },
+		lifecycle: lifecycle,
+		logger:    testlog.HCLogger(t),
+	})
+	must.NoError(t, h.Poststart(context.Background(), nil, nil))
+
+	select {
+	case event := <-lifecycle.KillCh:
+		must.Eq(t, structs.TaskMaxRunDurationExceeded, event.Type)
+		must.True(t, event.FailsTask)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the task to be killed")
+	}
+}
+
+func TestMaxRunDurationHook_Restart(t *testing.T) {
+	ci.Parallel(t)
+
+	// The deadline is computed from the first start of the task
+	startedAt := time.Now().Add(-time.Hour)
+	lifecycle := trtesting.NewMockTaskHooks()
+	h := newMaxRunDurationHook(&maxRunDurationHookConfig{
+		duration:  time.Hour + 100*time.Millisecond,
+		startedAt: func() time.Time { return startedAt },
+		lifecycle: lifecycle,
+		logger:    testlog.HCLogger(t),
+	})
+	must.NoError(t, h.Poststart(context.Background(), nil, nil))
+
+	// Restarting the task doesn't reset the deadline
+	startedAt = time.Now()
+	must.NoError(t, h.Poststart(context.Background(), nil, nil))
+
+	select {
+	case event := <-lifecycle.KillCh:
+		must.Eq(t, structs.TaskMaxRunDurationExceeded, event.Type)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the task to be killed")
+	}
+}
+
+func TestMaxRunDurationHook_Stop(t *testing.T) {
+	ci.Parallel(t)
+
+	lifecycle := trtesting.NewMockTaskHooks()
+	h := newMaxRunDurationHook(&maxRunDurationHookConfig{
+		duration:  100 * time.Millisecond,
+		startedAt: func() time.Time { return time.Now() },
+		lifecycle: lifecycle,
+		logger:    testlog.HCLogger(t),
+	})
+	must.NoError(t, h.Poststart(context.Background(), nil, nil))
+	must.NoError(t, h.Stop(context.Background(), nil, nil))
+
+	select {
+	case <-lifecycle.KillCh:
+		t.Fatal("task killed after the hook stopped")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestMaxRunDurationHook_PersistFirstStart(t *testing.T) {
+	ci.Parallel(t)
+
+	newHook := func(startedAt time.Time) *maxRunDurationHook {
+		return newMaxRunDurationHook(&maxRunDurationHookConfig{
+			duration:  time.Hour + 100*time.Millisecond,
+			startedAt: func() time.Time { return startedAt },
+			lifecycle: trtesting.NewMockTaskHooks(),
+			logger:    testlog.HCLogger(t),
+		})
+	}
+
+	// Nothing is persisted before the task first starts
+	h := newHook(time.Time{})
+	var resp interfaces.TaskPrestartResponse
+	must.NoError(t, h.Prestart(context.Background(), &interfaces.TaskPrestartRequest{}, &resp))
+	must.Nil(t, resp.State)
+
+	// The first start time is persisted when the task restarts
+	firstStartedAt := time.Now().Add(-time.Hour)
+	h = newHook(firstStartedAt)
+	must.NoError(t, h.Prestart(context.Background(), &interfaces.TaskPrestartRequest{}, &resp))
+	must.Eq(t, strconv.FormatInt(firstStartedAt.UnixNano(), 10), resp.State[maxRunDurationFirstStartedAtKey])
+
+	// A restored client uses the persisted first start time rather than the
+	// time the task last started at
+	lifecycle := trtesting.NewMockTaskHooks()
+	h = newMaxRunDurationHook(&maxRunDurationHookConfig{
+		duration:  time.Hour + 100*time.Millisecond,
+		startedAt: func() time.Time { return time.Now() },
+		lifecycle: lifecycle,
+		logger:    testlog.HCLogger(t),
+	})
+	req := &interfaces.TaskPrestartRequest{PreviousState: resp.State}
+	resp = interfaces.TaskPrestartResponse{}
+	must.NoError(t, h.Prestart(context.Background(), req, &resp))
+	must.Eq(t, req.PreviousState, resp.State)
+	must.NoError(t, h.Poststart(context.Background(), nil, nil))
+
+	select {
+	case event := <-lifecycle.KillCh:
+		must.Eq(t, structs.TaskMaxRunDurationExceeded, event.Type)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the task to be killed")
+	}
+}
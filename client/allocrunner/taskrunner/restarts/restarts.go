@@ -20,6 +20,7 @@ const (
 	ReasonNoRestartsAllowed  = "Policy allows no restarts"
 	ReasonUnrecoverableError = "Error was unrecoverable"
 	ReasonWithinPolicy       = "Restart within policy"
+	ReasonBackoff            = "Restart within policy, backing off"
 	ReasonDelay              = "Exceeded allowed attempts, applying a delay"
 )
 
@@ -235,8 +236,13 @@ func (r *RestartTracker) GetState() (string, time.Duration) {
 		}
 	}
 
-	r.reason = ReasonWithinPolicy
-	return structs.TaskRestarting, r.jitter()
+	delay := r.delay()
+	if delay > r.policy.Delay {
+		r.reason = ReasonBackoff
+	} else {
+		r.reason = ReasonWithinPolicy
+	}
+	return structs.TaskRestarting, r.jitter(delay)
 }
 
 // getDelay returns the delay time to enter the next interval.
@@ -246,10 +252,31 @@ func (r *RestartTracker) getDelay() time.Duration {
 	return end.Sub(now)
 }
 
+// delay returns the delay before the current restart according to the delay
+// function of the policy. The delay grows with the number of restarts in the
+// current interval, up to the max delay of the policy.
+func (r *RestartTracker) delay() time.Duration {
+	delay := r.policy.Delay
+	switch r.policy.DelayFunction {
+	case "exponential":
+		for i := 1; i < r.count && delay < r.policy.MaxDelay; i++ {
+			delay *= 2
+		}
+	case "fibonacci":
+		var prev time.Duration
+		for i := 1; i < r.count && delay < r.policy.MaxDelay; i++ {
+			prev, delay = delay, delay+prev
+		}
+	default:
+		return delay
+	}
+	return min(delay, r.policy.MaxDelay)
+}
+
 // jitter returns the delay time plus a jitter.
-func (r *RestartTracker) jitter() time.Duration {
-	// Get the delay and ensure it is valid.
-	d := r.policy.Delay.Nanoseconds()
+func (r *RestartTracker) jitter(delay time.Duration) time.Duration {
+	// Ensure the delay is valid.
+	d := delay.Nanoseconds()
 	if d == 0 {
 		d = 1
 	}
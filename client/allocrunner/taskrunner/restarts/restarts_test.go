@@ -203,6 +203,51 @@ func TestClient_RestartTracker_StartError_Recoverable_Delay(t *testing.T) {
 	}
 }
 
+func TestClient_RestartTracker_DelayFunction(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		delayFunction string
+		expected      []time.Duration
+	}{
+		{
+			delayFunction: "constant",
+			expected:      []time.Duration{1, 1, 1, 1, 1, 1},
+		},
+		{
+			delayFunction: "exponential",
+			expected:      []time.Duration{1, 2, 4, 8, 10, 10},
+		},
+		{
+			delayFunction: "fibonacci",
+			expected:      []time.Duration{1, 1, 2, 3, 5, 8},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.delayFunction, func(t *testing.T) {
+			p := testPolicy(true, structs.RestartPolicyModeFail)
+			p.Attempts = len(tc.expected)
+			p.DelayFunction = tc.delayFunction
+			p.MaxDelay = 10 * time.Second
+			rt := NewRestartTracker(p, structs.JobTypeService, nil)
+
+			for i, expected := range tc.expected {
+				expected *= time.Second
+				state, when := rt.SetExitResult(testExitResult(127)).GetState()
+				require.Equal(t, structs.TaskRestarting, state)
+				require.GreaterOrEqual(t, when, expected, "restart %d", i)
+				require.LessOrEqual(t, when, expected+time.Duration(float64(expected)*jitter), "restart %d", i)
+				if expected > p.Delay {
+					require.Equal(t, ReasonBackoff, rt.GetReason())
+				} else {
+					require.Equal(t, ReasonWithinPolicy, rt.GetReason())
+				}
+			}
+		})
+	}
+}
+
 func TestClient_RestartTracker_Lifecycle(t *testing.T) {
 	ci.Parallel(t)
 
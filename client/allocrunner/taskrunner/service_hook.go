@@ -59,6 +59,7 @@ type serviceHook struct {
 	driverExec tinterfaces.ScriptExecutor
 	driverNet  *drivers.DriverNetwork
 	canary     bool
+	standby    bool
 	services   []*structs.Service
 	networks   structs.Networks
 	ports      structs.AllocatedPorts
@@ -113,6 +114,7 @@ func newServiceHook(c serviceHookConfig) *serviceHook {
 	if c.alloc.DeploymentStatus != nil && c.alloc.DeploymentStatus.Canary {
 		h.canary = true
 	}
+	h.standby = c.alloc.DeploymentStatus.IsStandby()
 
 	h.logger = c.logger.Named(h.Name())
 	return h
@@ -168,6 +170,7 @@ func (h *serviceHook) updateHookFields(req *interfaces.TaskUpdateRequest) error
 	if req.Alloc.DeploymentStatus != nil {
 		canary = req.Alloc.DeploymentStatus.Canary
 	}
+	standby := req.Alloc.DeploymentStatus.IsStandby()
 
 	var networks structs.Networks
 	if res := req.Alloc.AllocatedResources.Tasks[h.taskName]; res != nil {
@@ -184,6 +187,7 @@ func (h *serviceHook) updateHookFields(req *interfaces.TaskUpdateRequest) error
 	h.services = task.Services
 	h.networks = networks
 	h.canary = canary
+	h.standby = standby
 	h.ports = req.Alloc.AllocatedResources.Shared.Ports
 
 	// An update may change the service provider, therefore we need to account
@@ -249,6 +253,11 @@ func (h *serviceHook) getWorkloadServices() *serviceregistration.WorkloadService
 		Namespace: h.namespace,
 	}
 
+	// Allocations held by a blue/green deployment don't register services
+	if h.standby {
+		interpolatedServices = nil
+	}
+
 	// Create task services struct with request's driver metadata
 	return &serviceregistration.WorkloadServices{
 		AllocInfo:         info,
@@ -5,11 +5,13 @@ package taskrunner
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -22,11 +24,29 @@ type StatsUpdater interface {
 	UpdateStats(*cstructs.TaskResourceUsage)
 }
 
-// statsHook manages the task stats collection goroutine.
+// statsHook manages the task stats collection goroutine and the goroutine
+// watching the pressure of the task's cgroup.
 type statsHook struct {
 	updater  StatsUpdater
+	events   ti.EventEmitter
 	interval time.Duration
 
+	// cgroup is the cgroup v2 directory of the task from which pressure
+	// stall information and OOM kills are read. Empty if the client does not
+	// use cgroups v2.
+	cgroup string
+
+	// pressure is the latest pressure stall information of the task, which
+	// is added to the stats from the driver
+	pressure     *cstructs.PressureStats
+	pressureLock sync.Mutex
+
+	// tracker detects pressure events from consecutive samples of the task's
+	// cgroup. It is nil when the cgroup is not being watched. trackerLock
+	// serializes sampling between the watcher and Exited.
+	tracker     *pressureTracker
+	trackerLock sync.Mutex
+
 	// cancel is called by Exited
 	cancel context.CancelFunc
 
@@ -35,10 +55,12 @@ type statsHook struct {
 	logger hclog.Logger
 }
 
-func newStatsHook(su StatsUpdater, interval time.Duration, logger hclog.Logger) *statsHook {
+func newStatsHook(su StatsUpdater, events ti.EventEmitter, interval time.Duration, cgroup string, logger hclog.Logger) *statsHook {
 	h := &statsHook{
 		updater:  su,
+		events:   events,
 		interval: interval,
+		cgroup:   cgroup,
 	}
 	h.logger = logger.Named(h.Name())
 	return h
@@ -67,6 +89,11 @@ func (h *statsHook) Poststart(_ context.Context, req *interfaces.TaskPoststartRe
 	h.cancel = cancel
 	go h.collectResourceUsageStats(ctx, req.DriverStats)
 
+	h.setPressure(nil)
+	if h.cgroup != "" && h.startPressureTracker() {
+		go h.watchPressure(ctx)
+	}
+
 	return nil
 }
 
@@ -79,6 +106,12 @@ func (h *statsHook) Exited(context.Context, *interfaces.TaskExitedRequest, *inte
 		return nil
 	}
 
+	// Take a final pressure sample while the task's cgroup still exists, so
+	// an OOM kill that caused the task to exit is not missed between ticks
+	if err := h.samplePressure(); err != nil {
+		h.logger.Debug("failed to read task cgroup pressure on exit", "error", err)
+	}
+
 	// Call cancel to stop stats collection
 	h.cancel()
 
@@ -115,6 +148,9 @@ MAIN:
 			}
 
 			// Update stats on TaskRunner and emit them
+			if pressure := h.latestPressure(); pressure != nil {
+				ru.Pressure = pressure
+			}
 			h.updater.UpdateStats(ru)
 
 		case <-ctx.Done():
@@ -172,6 +208,88 @@ MAIN:
 	goto MAIN
 }
 
+// startPressureTracker takes the baseline pressure sample of the task's cgroup
+// that later samples are compared to. It returns false if the cgroup cannot be
+// read, such as when the driver does not place the task in the cgroup managed
+// by the client.
+func (h *statsHook) startPressureTracker() bool {
+	h.trackerLock.Lock()
+	defer h.trackerLock.Unlock()
+
+	h.tracker = nil
+	sample, err := readPressure(h.cgroup)
+	if err != nil {
+		h.logger.Debug("failed to read task cgroup pressure, not watching", "error", err)
+		return false
+	}
+	h.setPressure(sample.stats)
+
+	h.tracker = new(pressureTracker)
+	h.emitEvents(h.tracker.observe(sample))
+	return true
+}
+
+// watchPressure samples the pressure of the task's cgroup on every stats
+// interval. Watching ends when the context is canceled or the cgroup cannot be
+// read.
+func (h *statsHook) watchPressure(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		if err := h.samplePressure(); err != nil {
+			h.logger.Debug("failed to read task cgroup pressure, no longer watching", "error", err)
+			return
+		}
+	}
+}
+
+// samplePressure reads the pressure of the task's cgroup and emits task events
+// when the task is OOM killed, under sustained memory pressure or CPU
+// throttled. It is a no-op if the cgroup is not being watched.
+func (h *statsHook) samplePressure() error {
+	h.trackerLock.Lock()
+	defer h.trackerLock.Unlock()
+
+	if h.tracker == nil {
+		return nil
+	}
+
+	sample, err := readPressure(h.cgroup)
+	if err != nil {
+		h.tracker = nil
+		return err
+	}
+	h.setPressure(sample.stats)
+
+	h.emitEvents(h.tracker.observe(sample))
+	return nil
+}
+
+func (h *statsHook) emitEvents(events []*structs.TaskEvent) {
+	for _, event := range events {
+		h.events.EmitEvent(event)
+	}
+}
+
+func (h *statsHook) setPressure(pressure *cstructs.PressureStats) {
+	h.pressureLock.Lock()
+	defer h.pressureLock.Unlock()
+	h.pressure = pressure
+}
+
+func (h *statsHook) latestPressure() *cstructs.PressureStats {
+	h.pressureLock.Lock()
+	defer h.pressureLock.Unlock()
+	return h.pressure
+}
+
 func (h *statsHook) Shutdown() {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -182,3 +300,81 @@ func (h *statsHook) Shutdown() {
 
 	h.cancel()
 }
+
+const (
+	// memoryPressureThreshold is the percentage of time over the last minute
+	// the processes of a task may be stalled on memory before the task is
+	// considered to be under sustained memory pressure.
+	memoryPressureThreshold = 10.0
+
+	// cpuThrottleThreshold is the share of CPU scheduling periods in a stats
+	// interval in which a task may be throttled before it is considered to be
+	// CPU throttled.
+	cpuThrottleThreshold = 0.5
+)
+
+// pressureSample is a reading of the pressure stall information and the OOM
+// kill and CPU throttling counters of a task's cgroup.
+type pressureSample struct {
+	stats *cstructs.PressureStats
+
+	oomKills         uint64
+	periods          uint64
+	throttledPeriods uint64
+}
+
+// pressureTracker compares consecutive pressure samples of a task to detect
+// OOM kills, sustained memory pressure and CPU throttling. Memory pressure and
+// CPU throttling are reported once when they start and again only after they
+// have subsided in between.
+type pressureTracker struct {
+	last *pressureSample
+
+	memoryPressure bool
+	cpuThrottled   bool
+}
+
+// observe records the sample and returns the task events it gives rise to.
+func (t *pressureTracker) observe(sample *pressureSample) []*structs.TaskEvent {
+	var events []*structs.TaskEvent
+
+	if memory := sample.stats.Memory; memory != nil {
+		pressured := memory.SomeAvg60 >= memoryPressureThreshold
+		if pressured && !t.memoryPressure {
+			events = append(events, structs.NewTaskEvent(structs.TaskMemoryPressure).
+				SetMessage(fmt.Sprintf("Task stalled on memory %.1f%% of the time over the last minute", memory.SomeAvg60)))
+		}
+		t.memoryPressure = pressured
+	}
+
+	// The counters are only compared once there is a previous sample, as the
+	// task may have been OOM killed or throttled before it was watched.
+	last := t.last
+	t.last = sample
+	if last == nil {
+		return events
+	}
+
+	if sample.oomKills > last.oomKills {
+		kills := sample.oomKills - last.oomKills
+		msg := "Task process killed by the OOM killer"
+		if kills > 1 {
+			msg = fmt.Sprintf("%d task processes killed by the OOM killer", kills)
+		}
+		events = append(events, structs.NewTaskEvent(structs.TaskOOMKilled).
+			SetMessage(msg).
+			SetOOMKilled(true))
+	}
+
+	if sample.periods > last.periods && sample.throttledPeriods >= last.throttledPeriods {
+		ratio := float64(sample.throttledPeriods-last.throttledPeriods) / float64(sample.periods-last.periods)
+		throttled := ratio >= cpuThrottleThreshold
+		if throttled && !t.cpuThrottled {
+			events = append(events, structs.NewTaskEvent(structs.TaskCPUThrottled).
+				SetMessage(fmt.Sprintf("Task throttled in %.0f%% of CPU periods", ratio*100)))
+		}
+		t.cpuThrottled = throttled
+	}
+
+	return events
+}
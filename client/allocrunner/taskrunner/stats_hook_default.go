@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package taskrunner
+
+import (
+	"errors"
+)
+
+// pressureCgroup returns the empty string as pressure is only watched on
+// Linux
+func pressureCgroup(string, string, bool) string {
+	return ""
+}
+
+func readPressure(string) (*pressureSample, error) {
+	return nil, errors.New("task pressure is only available on linux")
+}
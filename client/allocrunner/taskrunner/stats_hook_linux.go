@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package taskrunner
+
+import (
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// pressureCgroup returns the cgroup directory of the task whose pressure is
+// watched by the stats hook, which is only possible with cgroups v2.
+func pressureCgroup(allocID, task string, cores bool) string {
+	if cgroupslib.GetMode() != cgroupslib.CG2 {
+		return ""
+	}
+	return cgroupslib.LinuxResourcesPath(allocID, task, cores)
+}
+
+// readPressure reads a pressure sample from the cgroup at dir. The memory
+// events of the cgroup must be readable, whereas pressure stall information
+// is left out if the kernel does not provide it.
+func readPressure(dir string) (*pressureSample, error) {
+	events, err := cgroupslib.ReadMemoryEvents(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := &pressureSample{
+		stats: &cstructs.PressureStats{
+			Memory: readPSI(dir, "memory"),
+			CPU:    readPSI(dir, "cpu"),
+			IO:     readPSI(dir, "io"),
+		},
+		oomKills: events.OOMKill,
+	}
+
+	if stat, err := cgroupslib.ReadCPUStat(dir); err == nil {
+		sample.periods = stat.Periods
+		sample.throttledPeriods = stat.ThrottledPeriods
+	}
+
+	return sample, nil
+}
+
+func readPSI(dir, resource string) *cstructs.PSIStats {
+	psi, err := cgroupslib.ReadPSI(dir, resource)
+	if err != nil {
+		return nil
+	}
+	return &cstructs.PSIStats{
+		SomeAvg10:  psi.SomeAvg10,
+		SomeAvg60:  psi.SomeAvg60,
+		SomeAvg300: psi.SomeAvg300,
+		SomeTotal:  psi.SomeTotal,
+		FullAvg10:  psi.FullAvg10,
+		FullAvg60:  psi.FullAvg60,
+		FullAvg300: psi.FullAvg300,
+		FullTotal:  psi.FullTotal,
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package taskrunner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	trtesting "github.com/hashicorp/nomad/client/allocrunner/taskrunner/testing"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// TestTaskRunner_StatsHook_Pressure asserts the stats hook reads the pressure
+// of the task's cgroup, adds it to the task stats and emits events.
+func TestTaskRunner_StatsHook_Pressure(t *testing.T) {
+	ci.Parallel(t)
+
+	cgroup := t.TempDir()
+	write := func(filename, content string) {
+		must.NoError(t, os.WriteFile(filepath.Join(cgroup, filename), []byte(content), 0644))
+	}
+	write("memory.events", "low 0\nhigh 0\nmax 0\noom 0\noom_kill 0\n")
+	write("memory.pressure", "some avg10=1.00 avg60=2.00 avg300=3.00 total=100\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=0\n")
+	write("cpu.stat", "nr_periods 0\nnr_throttled 0\nthrottled_usec 0\n")
+
+	su := newMockStatsUpdater()
+	emitter := new(trtesting.MockEmitter)
+	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: new(mockDriverStats)}
+
+	h := newStatsHook(su, emitter, 50*time.Millisecond, cgroup, testlog.HCLogger(t))
+	defer h.Exited(context.Background(), nil, nil)
+	must.NoError(t, h.Poststart(context.Background(), poststartReq, nil))
+
+	// stats collected before the first pressure reading have no pressure
+	var ru *cstructs.TaskResourceUsage
+	timeout := time.After(10 * time.Second)
+	for ru == nil || ru.Pressure == nil {
+		select {
+		case ru = <-su.Ch:
+		case <-timeout:
+			t.Fatalf("timeout waiting for stats with pressure")
+		}
+	}
+	must.Eq(t, &cstructs.PSIStats{SomeAvg10: 1, SomeAvg60: 2, SomeAvg300: 3, SomeTotal: 100}, ru.Pressure.Memory)
+	must.Nil(t, ru.Pressure.CPU)
+
+	write("memory.events", "low 0\nhigh 0\nmax 4\noom 1\noom_kill 1\n")
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return len(emitter.Events()) > 0 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.Eq(t, structs.TaskOOMKilled, emitter.Events()[0].Type)
+}
+
+// TestTaskRunner_StatsHook_Pressure_OOMKillOnExit asserts an OOM kill is
+// reported when the task exits before the next pressure reading.
+func TestTaskRunner_StatsHook_Pressure_OOMKillOnExit(t *testing.T) {
+	ci.Parallel(t)
+
+	cgroup := t.TempDir()
+	write := func(filename, content string) {
+		must.NoError(t, os.WriteFile(filepath.Join(cgroup, filename), []byte(content), 0644))
+	}
+	write("memory.events", "low 0\nhigh 0\nmax 0\noom 0\noom_kill 0\n")
+	write("cpu.stat", "nr_periods 0\nnr_throttled 0\nthrottled_usec 0\n")
+
+	emitter := new(trtesting.MockEmitter)
+	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: new(mockDriverStats)}
+
+	// the interval is long enough that no reading happens between Poststart
+	// and Exited
+	h := newStatsHook(newMockStatsUpdater(), emitter, time.Hour, cgroup, testlog.HCLogger(t))
+	must.NoError(t, h.Poststart(context.Background(), poststartReq, nil))
+
+	write("memory.events", "low 0\nhigh 0\nmax 1\noom 1\noom_kill 1\n")
+	must.NoError(t, h.Exited(context.Background(), nil, nil))
+
+	events := emitter.Events()
+	must.Len(t, 1, events)
+	must.Eq(t, structs.TaskOOMKilled, events[0].Type)
+
+	// the OOM kill is not reported again
+	must.NoError(t, h.Exited(context.Background(), nil, nil))
+	must.Len(t, 1, emitter.Events())
+}
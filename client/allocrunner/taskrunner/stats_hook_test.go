@@ -11,8 +11,11 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	trtesting "github.com/hashicorp/nomad/client/allocrunner/taskrunner/testing"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
 
@@ -96,7 +99,7 @@ func TestTaskRunner_StatsHook_PoststartExited(t *testing.T) {
 	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: ds}
 
 	// Create hook
-	h := newStatsHook(su, time.Minute, logger)
+	h := newStatsHook(su, new(trtesting.MockEmitter), time.Minute, "", logger)
 
 	// Always call Exited to cleanup goroutines
 	defer h.Exited(context.Background(), nil, nil)
@@ -131,7 +134,7 @@ func TestTaskRunner_StatsHook_Periodic(t *testing.T) {
 	// Exited() can complete within the interval.
 	const interval = 500 * time.Millisecond
 
-	h := newStatsHook(su, interval, logger)
+	h := newStatsHook(su, new(trtesting.MockEmitter), interval, "", logger)
 	defer h.Exited(context.Background(), nil, nil)
 
 	// Run prestart
@@ -194,7 +197,7 @@ func TestTaskRunner_StatsHook_NotImplemented(t *testing.T) {
 
 	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: ds}
 
-	h := newStatsHook(su, 1, logger)
+	h := newStatsHook(su, new(trtesting.MockEmitter), 1, "", logger)
 	defer h.Exited(context.Background(), nil, nil)
 
 	// Run prestart
@@ -220,7 +223,7 @@ func TestTaskRunner_StatsHook_Backoff(t *testing.T) {
 
 	poststartReq := &interfaces.TaskPoststartRequest{DriverStats: ds}
 
-	h := newStatsHook(su, time.Minute, logger)
+	h := newStatsHook(su, new(trtesting.MockEmitter), time.Minute, "", logger)
 	defer h.Exited(context.Background(), nil, nil)
 
 	// Run prestart
@@ -239,3 +242,51 @@ DRAIN:
 
 	require.Equal(t, ds.Called(), 1)
 }
+
+// TestTaskRunner_StatsHook_PressureTracker asserts pressure samples give rise
+// to OOM kill, memory pressure and CPU throttling events.
+func TestTaskRunner_StatsHook_PressureTracker(t *testing.T) {
+	ci.Parallel(t)
+
+	sample := func(memoryAvg60 float64, oomKills, periods, throttled uint64) *pressureSample {
+		return &pressureSample{
+			stats: &cstructs.PressureStats{
+				Memory: &cstructs.PSIStats{SomeAvg60: memoryAvg60},
+			},
+			oomKills:         oomKills,
+			periods:          periods,
+			throttledPeriods: throttled,
+		}
+	}
+	types := func(events []*structs.TaskEvent) []string {
+		result := make([]string, 0, len(events))
+		for _, e := range events {
+			result = append(result, e.Type)
+		}
+		return result
+	}
+
+	tracker := new(pressureTracker)
+
+	// counters observed before watching are not reported
+	must.SliceEmpty(t, tracker.observe(sample(0, 2, 100, 100)))
+
+	events := tracker.observe(sample(0, 3, 200, 110))
+	must.Eq(t, []string{structs.TaskOOMKilled}, types(events))
+	must.Eq(t, "Task process killed by the OOM killer", events[0].Message)
+	must.Eq(t, "true", events[0].Details["oom_killed"])
+
+	events = tracker.observe(sample(25, 3, 300, 190))
+	must.Eq(t, []string{structs.TaskMemoryPressure, structs.TaskCPUThrottled}, types(events))
+	must.Eq(t, "Task stalled on memory 25.0% of the time over the last minute", events[0].Message)
+	must.Eq(t, "Task throttled in 80% of CPU periods", events[1].Message)
+
+	// sustained pressure and throttling are only reported once
+	must.SliceEmpty(t, tracker.observe(sample(30, 3, 400, 290)))
+
+	// until they have subsided
+	must.SliceEmpty(t, tracker.observe(sample(5, 3, 500, 300)))
+	events = tracker.observe(sample(15, 5, 600, 400))
+	must.Eq(t, []string{structs.TaskMemoryPressure, structs.TaskOOMKilled, structs.TaskCPUThrottled}, types(events))
+	must.Eq(t, "2 task processes killed by the OOM killer", events[1].Message)
+}
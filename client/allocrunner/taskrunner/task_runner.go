@@ -1609,6 +1609,35 @@ func (tr *TaskRunner) setGaugeForCPU(ru *cstructs.TaskResourceUsage) {
 	}
 }
 
+func (tr *TaskRunner) setGaugeForIO(ru *cstructs.TaskResourceUsage) {
+	is := ru.ResourceUsage.IOStats
+
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "io", "read_bytes"},
+		float32(is.ReadBytes), tr.baseLabels)
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "io", "write_bytes"},
+		float32(is.WriteBytes), tr.baseLabels)
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "io", "read_ops"},
+		float32(is.ReadOps), tr.baseLabels)
+	metrics.SetGaugeWithLabels([]string{"client", "allocs", "io", "write_ops"},
+		float32(is.WriteOps), tr.baseLabels)
+}
+
+func (tr *TaskRunner) setGaugeForPressure(ru *cstructs.TaskResourceUsage) {
+	publishPSI := func(resource string, psi *cstructs.PSIStats) {
+		if psi == nil {
+			return
+		}
+		metrics.SetGaugeWithLabels([]string{"client", "allocs", "pressure", resource, "some_avg10"},
+			float32(psi.SomeAvg10), tr.baseLabels)
+		metrics.SetGaugeWithLabels([]string{"client", "allocs", "pressure", resource, "full_avg10"},
+			float32(psi.FullAvg10), tr.baseLabels)
+	}
+
+	publishPSI("memory", ru.Pressure.Memory)
+	publishPSI("cpu", ru.Pressure.CPU)
+	publishPSI("io", ru.Pressure.IO)
+}
+
 // emitStats emits resource usage stats of tasks to remote metrics collector
 // sinks
 func (tr *TaskRunner) emitStats(ru *cstructs.TaskResourceUsage) {
@@ -1627,6 +1656,16 @@ func (tr *TaskRunner) emitStats(ru *cstructs.TaskResourceUsage) {
 	} else {
 		tr.logger.Debug("Skipping cpu stats for allocation", "reason", "CpuStats is nil")
 	}
+
+	// block I/O stats are only reported by some drivers
+	if ru.ResourceUsage.IOStats != nil {
+		tr.setGaugeForIO(ru)
+	}
+
+	// pressure is only available on Linux with cgroups v2
+	if ru.Pressure != nil {
+		tr.setGaugeForPressure(ru)
+	}
 }
 
 // appendTaskEvent updates the task status by appending the new event.
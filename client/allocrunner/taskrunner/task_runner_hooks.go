@@ -70,7 +70,7 @@ func (tr *TaskRunner) initHooks() {
 		newDispatchHook(alloc, hookLogger),
 		newVolumeHook(tr, hookLogger),
 		newArtifactHook(tr, tr.getter, hookLogger),
-		newStatsHook(tr, tr.clientConfig.StatsCollectionInterval, hookLogger),
+		newStatsHook(tr, tr, tr.clientConfig.StatsCollectionInterval, pressureCgroup(alloc.ID, task.Name, task.UsesCores()), hookLogger),
 		newDeviceHook(tr.devicemanager, hookLogger),
 		newAPIHook(tr.shutdownCtx, tr.clientConfig.APIListenerRegistrar, hookLogger),
 		newWranglerHook(tr.wranglers, task.Name, alloc.ID, task.UsesCores(), hookLogger),
@@ -193,6 +193,16 @@ func (tr *TaskRunner) initHooks() {
 		tr.runnerHooks = append(tr.runnerHooks, newRemoteTaskHook(tr, hookLogger))
 	}
 
+	// If the task has a max run duration, add the hook enforcing it
+	if task.MaxRunDuration > 0 {
+		tr.runnerHooks = append(tr.runnerHooks, newMaxRunDurationHook(&maxRunDurationHookConfig{
+			duration:  task.MaxRunDuration,
+			startedAt: func() time.Time { return tr.TaskState().StartedAt },
+			lifecycle: tr,
+			logger:    hookLogger,
+		}))
+	}
+
 	// If this task has a pause schedule, initialize the pause (Enterprise)
 	if task.Schedule != nil {
 		tr.runnerHooks = append(tr.runnerHooks, newPauseHook(tr, hookLogger))
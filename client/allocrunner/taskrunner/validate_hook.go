@@ -11,6 +11,7 @@ import (
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -66,6 +67,16 @@ func validateTask(task *structs.Task, taskEnv *taskenv.TaskEnv, conf *config.Con
 		}
 	}
 
+	// Validate the block devices of the io limits, as the kernel only accepts
+	// limits on whole disks
+	if task.Resources != nil && task.Resources.IO != nil {
+		for _, device := range task.Resources.IO.Devices {
+			if err := cgroupslib.ValidateIODevice(device.Path); err != nil {
+				mErr.Errors = append(mErr.Errors, err)
+			}
+		}
+	}
+
 	if len(mErr.Errors) == 1 {
 		return mErr.Errors[0]
 	}
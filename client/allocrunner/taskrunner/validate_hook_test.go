@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/require"
 )
@@ -65,3 +66,26 @@ func TestTaskRunner_Validate_ServiceName(t *testing.T) {
 	task.Services[0].Name = "${BAD}"
 	require.Error(t, validateTask(task, builder.Build(), conf))
 }
+
+func TestTaskRunner_Validate_IODevice(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireLinux(t)
+
+	taskEnv := taskenv.NewEmptyBuilder().Build()
+	conf := config.DefaultConfig()
+
+	// io limits can only be set on block devices
+	task := &structs.Task{
+		Driver: "exec",
+		Resources: &structs.Resources{
+			IO: &structs.IOResources{
+				Devices: []*structs.IODeviceResource{{Path: "/dev/null", ReadBps: 1024}},
+			},
+		},
+	}
+	err := validateTask(task, taskEnv, conf)
+	require.ErrorContains(t, err, `io device "/dev/null" is not a block device`)
+
+	task.Resources.IO.Devices = nil
+	require.NoError(t, validateTask(task, taskEnv, conf))
+}
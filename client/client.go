@@ -180,6 +180,10 @@ type Client struct {
 	heartbeatLock   sync.Mutex
 	heartbeatStop   *heartbeatStop
 
+	// evictionManager evicts allocations when the host is under memory or
+	// disk pressure. Nil unless eviction is enabled.
+	evictionManager *evictionManager
+
 	// triggerDiscoveryCh triggers Consul discovery; see triggerDiscovery
 	triggerDiscoveryCh chan struct{}
 
@@ -554,6 +558,11 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	statsCollector := hoststats.NewHostStatsCollector(c.logger, c.topology, c.GetConfig().AllocDir, c.devicemanager.AllStats)
 	c.hostStatsCollector = statsCollector
 
+	// Evict allocations under node pressure if enabled
+	if cfg.Eviction != nil {
+		c.evictionManager = newEvictionManager(cfg.Eviction, c.getAllocRunners, c.updateNodePressure, c.logger)
+	}
+
 	// Add the garbage collector
 	gcConfig := &GCConfig{
 		MaxAllocs:           cfg.GCMaxAllocs,
@@ -2089,8 +2098,9 @@ func (c *Client) registerNode(authToken string) error {
 func (c *Client) updateNodeStatus() error {
 	start := time.Now()
 	req := structs.NodeUpdateStatusRequest{
-		NodeID: c.NodeID(),
-		Status: structs.NodeStatusReady,
+		NodeID:      c.NodeID(),
+		Status:      structs.NodeStatusReady,
+		Utilization: c.Node().Utilization,
 		WriteRequest: structs.WriteRequest{
 			Region:    c.Region(),
 			AuthToken: c.secretNodeID(),
@@ -3213,9 +3223,16 @@ func (c *Client) emitStats() {
 			next.Reset(config.StatsCollectionInterval)
 			if err != nil {
 				c.logger.Warn("error fetching host resource usage stats", "error", err)
-			} else if config.PublishNodeMetrics {
+			} else {
+				c.updateNodeUtilization()
+				if c.evictionManager != nil {
+					c.evictionManager.check(c.hostStatsCollector.Stats())
+				}
+
 				// Publish Node metrics if operator has opted in
-				c.emitHostStats()
+				if config.PublishNodeMetrics {
+					c.emitHostStats()
+				}
 			}
 
 			c.emitClientMetrics()
@@ -3225,6 +3242,56 @@ func (c *Client) emitStats() {
 	}
 }
 
+// updateNodeUtilization updates the node with the smoothed utilization of the
+// host, but only if it changed significantly since it was last updated. It is
+// sent to the servers along with the next heartbeat rather than by
+// re-registering the node, which would modify it.
+func (c *Client) updateNodeUtilization() {
+	utilization := c.hostStatsCollector.Utilization()
+	if utilization == nil {
+		return
+	}
+
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+
+	next := &structs.NodeUtilization{
+		CPUPercent:    utilization.CPUPercent,
+		MemoryPercent: utilization.MemoryPercent,
+		UpdatedAt:     time.Now().Unix(),
+	}
+	if !next.ChangedSignificantly(c.config.Node.Utilization) {
+		return
+	}
+
+	newConfig := c.config.Copy()
+	newConfig.Node.Utilization = next
+	c.config = newConfig
+}
+
+// updateNodePressure updates the node with the pressure of the host and sends
+// it to the servers, but only if the resources under pressure changed.
+func (c *Client) updateNodePressure(pressure *structs.NodePressure) {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+
+	prev := c.config.Node.Pressure
+	if prev.Equal(pressure) {
+		return
+	}
+
+	if pressure.Pressured() {
+		c.logger.Warn("node is under resource pressure", "pressure", pressure.String())
+	} else {
+		c.logger.Info("node is no longer under resource pressure", "pressure", prev.String())
+	}
+
+	newConfig := c.config.Copy()
+	newConfig.Node.Pressure = pressure.Copy()
+	c.config = newConfig
+	c.updateNode()
+}
+
 // setGaugeForMemoryStats proxies metrics for memory specific statistics
 func (c *Client) setGaugeForMemoryStats(nodeID string, hStats *hoststats.HostStats, baseLabels []metrics.Label) {
 	metrics.SetGaugeWithLabels([]string{"client", "host", "memory", "total"}, float32(hStats.Memory.Total), baseLabels)
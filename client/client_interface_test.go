@@ -138,6 +138,7 @@ func (ar *emptyAllocRunner) RestartTask(taskName string, taskEvent *structs.Task
 }
 func (ar *emptyAllocRunner) RestartRunning(taskEvent *structs.TaskEvent) error { return nil }
 func (ar *emptyAllocRunner) RestartAll(taskEvent *structs.TaskEvent) error     { return nil }
+func (ar *emptyAllocRunner) Evict(reason string) error                         { return nil }
 
 func (ar *emptyAllocRunner) GetTaskEventHandler(taskName string) drivermanager.EventHandler {
 	return nil
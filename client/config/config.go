@@ -336,6 +336,10 @@ type Config struct {
 	// Drain configuration from the agent's config file.
 	Drain *DrainConfig
 
+	// Eviction configuration from the agent's config file. Nil if the client
+	// does not evict allocations under node pressure.
+	Eviction *EvictionConfig
+
 	// Uesrs configuration from the agent's config file.
 	Users *UsersConfig
 
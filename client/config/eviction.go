@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+const (
+	// DefaultEvictionMemoryThreshold is the default percentage of host memory
+	// in use above which a node is under memory pressure.
+	DefaultEvictionMemoryThreshold = 95
+
+	// DefaultEvictionDiskThreshold is the default percentage of the disk of
+	// the allocation directory in use above which a node is under disk
+	// pressure.
+	DefaultEvictionDiskThreshold = 95
+
+	// DefaultEvictionRecoveryMargin is the default number of percentage
+	// points below its threshold that the usage of a resource under pressure
+	// must drop to for the node to recover, so that a usage hovering around
+	// the threshold doesn't flap the pressure of the node.
+	DefaultEvictionRecoveryMargin = 5
+)
+
+// EvictionConfig describes how the client evicts allocations when its host
+// runs low on memory or allocation directory disk space.
+type EvictionConfig struct {
+	// MemoryThreshold is the percentage of host memory in use above which
+	// the node is under memory pressure.
+	MemoryThreshold float64
+
+	// DiskThreshold is the percentage of the disk of the allocation
+	// directory in use above which the node is under disk pressure.
+	DiskThreshold float64
+
+	// MemoryRecoveryThreshold and DiskRecoveryThreshold are the percentages
+	// of usage below which a node under memory or disk pressure recovers.
+	MemoryRecoveryThreshold float64
+	DiskRecoveryThreshold   float64
+}
+
+// EvictionConfigFromAgent creates the internal read-only copy of the client
+// agent's EvictionConfig. It returns nil if eviction is not enabled.
+func EvictionConfigFromAgent(c *config.EvictionConfig) (*EvictionConfig, error) {
+	if c == nil || c.Enabled == nil || !*c.Enabled {
+		return nil, nil
+	}
+
+	memoryThreshold := DefaultEvictionMemoryThreshold
+	diskThreshold := DefaultEvictionDiskThreshold
+
+	if c.MemoryThreshold != nil {
+		memoryThreshold = *c.MemoryThreshold
+	}
+	if memoryThreshold < 1 || memoryThreshold > 100 {
+		return nil, fmt.Errorf("memory_threshold must be between 1 and 100: %d", memoryThreshold)
+	}
+	if c.DiskThreshold != nil {
+		diskThreshold = *c.DiskThreshold
+	}
+	if diskThreshold < 1 || diskThreshold > 100 {
+		return nil, fmt.Errorf("disk_threshold must be between 1 and 100: %d", diskThreshold)
+	}
+
+	memoryRecovery, err := recoveryThreshold("memory", memoryThreshold, c.MemoryRecoveryThreshold)
+	if err != nil {
+		return nil, err
+	}
+	diskRecovery, err := recoveryThreshold("disk", diskThreshold, c.DiskRecoveryThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EvictionConfig{
+		MemoryThreshold:         float64(memoryThreshold),
+		DiskThreshold:           float64(diskThreshold),
+		MemoryRecoveryThreshold: float64(memoryRecovery),
+		DiskRecoveryThreshold:   float64(diskRecovery),
+	}, nil
+}
+
+// recoveryThreshold returns the recovery threshold of the resource, which
+// must be below its threshold and defaults to DefaultEvictionRecoveryMargin
+// below it.
+func recoveryThreshold(resource string, threshold int, recovery *int) (int, error) {
+	if recovery == nil {
+		return max(threshold-DefaultEvictionRecoveryMargin, 0), nil
+	}
+	if *recovery < 0 || *recovery >= threshold {
+		return 0, fmt.Errorf("%s_recovery_threshold must be between 0 and %s_threshold (%d): %d",
+			resource, resource, threshold, *recovery)
+	}
+	return *recovery, nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestEvictionConfigFromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		config *config.EvictionConfig
+		exp    *EvictionConfig
+		err    string
+	}{
+		{
+			name:   "nil",
+			config: nil,
+			exp:    nil,
+		},
+		{
+			name: "disabled",
+			config: &config.EvictionConfig{
+				MemoryThreshold: pointer.Of(90),
+			},
+			exp: nil,
+		},
+		{
+			name: "defaults",
+			config: &config.EvictionConfig{
+				Enabled: pointer.Of(true),
+			},
+			exp: &EvictionConfig{
+				MemoryThreshold:         DefaultEvictionMemoryThreshold,
+				DiskThreshold:           DefaultEvictionDiskThreshold,
+				MemoryRecoveryThreshold: DefaultEvictionMemoryThreshold - DefaultEvictionRecoveryMargin,
+				DiskRecoveryThreshold:   DefaultEvictionDiskThreshold - DefaultEvictionRecoveryMargin,
+			},
+		},
+		{
+			name: "thresholds",
+			config: &config.EvictionConfig{
+				Enabled:         pointer.Of(true),
+				MemoryThreshold: pointer.Of(90),
+				DiskThreshold:   pointer.Of(80),
+			},
+			exp: &EvictionConfig{
+				MemoryThreshold:         90,
+				DiskThreshold:           80,
+				MemoryRecoveryThreshold: 85,
+				DiskRecoveryThreshold:   75,
+			},
+		},
+		{
+			name: "recovery thresholds",
+			config: &config.EvictionConfig{
+				Enabled:                 pointer.Of(true),
+				MemoryThreshold:         pointer.Of(3),
+				MemoryRecoveryThreshold: pointer.Of(2),
+				DiskThreshold:           pointer.Of(4),
+			},
+			exp: &EvictionConfig{
+				MemoryThreshold:         3,
+				DiskThreshold:           4,
+				MemoryRecoveryThreshold: 2,
+				DiskRecoveryThreshold:   0,
+			},
+		},
+		{
+			name: "invalid memory recovery threshold",
+			config: &config.EvictionConfig{
+				Enabled:                 pointer.Of(true),
+				MemoryThreshold:         pointer.Of(90),
+				MemoryRecoveryThreshold: pointer.Of(90),
+			},
+			err: "memory_recovery_threshold must be between 0 and memory_threshold (90): 90",
+		},
+		{
+			name: "invalid disk recovery threshold",
+			config: &config.EvictionConfig{
+				Enabled:               pointer.Of(true),
+				DiskRecoveryThreshold: pointer.Of(-1),
+			},
+			err: "disk_recovery_threshold must be between 0 and disk_threshold (95): -1",
+		},
+		{
+			name: "invalid memory threshold",
+			config: &config.EvictionConfig{
+				Enabled:         pointer.Of(true),
+				MemoryThreshold: pointer.Of(0),
+			},
+			err: "memory_threshold must be between 1 and 100",
+		},
+		{
+			name: "invalid disk threshold",
+			config: &config.EvictionConfig{
+				Enabled:       pointer.Of(true),
+				DiskThreshold: pointer.Of(101),
+			},
+			err: "disk_threshold must be between 1 and 100",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := EvictionConfigFromAgent(tc.config)
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, got)
+		})
+	}
+}
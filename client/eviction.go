@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/hoststats"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// evictionManager evicts allocations when the host runs low on memory or on
+// disk for the allocation directory, lowest job priority first, and reports
+// the pressure of the node so that the servers stop placing allocations on
+// it until the pressure clears. A resource comes under pressure once its
+// usage reaches its threshold, and only recovers once its usage drops below
+// its lower recovery threshold, so that a usage hovering around the
+// threshold doesn't flap the pressure of the node.
+type evictionManager struct {
+	config      *config.EvictionConfig
+	getRunners  func() map[string]interfaces.AllocRunner
+	setPressure func(*structs.NodePressure)
+	logger      hclog.Logger
+
+	// current is the pressure of the node as of the last check, or nil if
+	// it isn't under pressure.
+	current *structs.NodePressure
+
+	// evicting is true while an allocation is being evicted, so that only
+	// one allocation is evicted at a time and the next one is only chosen
+	// once the host stats reflect the resources freed by the previous one.
+	evicting bool
+	lock     sync.Mutex
+}
+
+func newEvictionManager(
+	config *config.EvictionConfig,
+	getRunners func() map[string]interfaces.AllocRunner,
+	setPressure func(*structs.NodePressure),
+	logger hclog.Logger) *evictionManager {
+
+	return &evictionManager{
+		config:      config,
+		getRunners:  getRunners,
+		setPressure: setPressure,
+		logger:      logger.Named("eviction"),
+	}
+}
+
+// check is called with the latest host stats. It reports the pressure of the
+// node and starts evicting an allocation if the node is under pressure and no
+// eviction is in progress.
+func (m *evictionManager) check(hs *hoststats.HostStats) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	pressure := m.pressure(hs)
+	m.current = pressure
+	m.setPressure(pressure)
+	if !pressure.Pressured() || m.evicting {
+		return
+	}
+	m.evicting = true
+
+	go func() {
+		m.evict(pressure)
+
+		m.lock.Lock()
+		m.evicting = false
+		m.lock.Unlock()
+	}()
+}
+
+// pressure returns the pressure of the node given the host stats and its
+// current pressure, or nil if the node is not under pressure. It must be
+// called with the lock held.
+func (m *evictionManager) pressure(hs *hoststats.HostStats) *structs.NodePressure {
+	pressured := func(used, threshold, recovery float64, current bool) bool {
+		return used >= threshold || (current && used >= recovery)
+	}
+
+	current := m.current
+	if current == nil {
+		current = &structs.NodePressure{}
+	}
+
+	pressure := &structs.NodePressure{
+		Memory: pressured(hs.Memory.UsedPercent(),
+			m.config.MemoryThreshold, m.config.MemoryRecoveryThreshold, current.Memory),
+		Disk: hs.AllocDirStats != nil && pressured(hs.AllocDirStats.UsedPercent,
+			m.config.DiskThreshold, m.config.DiskRecoveryThreshold, current.Disk),
+		UpdatedAt: time.Now().Unix(),
+	}
+	if !pressure.Pressured() {
+		return nil
+	}
+	return pressure
+}
+
+// evict evicts the first allocation in eviction order and waits for its tasks
+// to be killed.
+func (m *evictionManager) evict(pressure *structs.NodePressure) {
+	candidates := evictionCandidates(m.getRunners(), pressure.Memory)
+	if len(candidates) == 0 {
+		m.logger.Warn("node is under pressure but no allocation can be evicted", "pressure", pressure.String())
+		return
+	}
+
+	ar := candidates[0]
+	alloc := ar.Alloc()
+	m.logger.Warn("evicting allocation because node is under pressure",
+		"alloc_id", alloc.ID, "job", alloc.JobID, "priority", alloc.Job.Priority, "pressure", pressure.String())
+
+	reason := fmt.Sprintf("Evicted due to node %s pressure", pressure.String())
+	if err := ar.Evict(reason); err != nil {
+		m.logger.Error("failed to evict allocation", "alloc_id", alloc.ID, "error", err)
+	}
+}
+
+// evictionCandidates returns the allocation runners whose allocations can be
+// evicted, in the order in which they are evicted: lowest job priority first,
+// then the ones using the most memory if the node is under memory pressure,
+// then the most recently created. Allocations of system and sysbatch jobs are
+// never evicted as they cannot be placed on another node.
+func evictionCandidates(runners map[string]interfaces.AllocRunner, memory bool) []interfaces.AllocRunner {
+	type candidate struct {
+		runner      interfaces.AllocRunner
+		alloc       *structs.Allocation
+		memoryUsage uint64
+	}
+
+	candidates := make([]candidate, 0, len(runners))
+	for _, ar := range runners {
+		if ar.IsDestroyed() {
+			continue
+		}
+
+		alloc := ar.Alloc()
+		if alloc == nil || alloc.Job == nil || alloc.ServerTerminalStatus() {
+			continue
+		}
+		switch alloc.Job.Type {
+		case structs.JobTypeSystem, structs.JobTypeSysBatch:
+			continue
+		}
+		switch ar.AllocState().ClientStatus {
+		case structs.AllocClientStatusPending, structs.AllocClientStatusRunning:
+		default:
+			continue
+		}
+
+		c := candidate{runner: ar, alloc: alloc}
+		if memory {
+			c.memoryUsage = allocMemoryUsage(ar)
+		}
+		candidates = append(candidates, c)
+	}
+
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		return cmp.Or(
+			cmp.Compare(a.alloc.Job.Priority, b.alloc.Job.Priority),
+			cmp.Compare(b.memoryUsage, a.memoryUsage),
+			cmp.Compare(b.alloc.CreateIndex, a.alloc.CreateIndex),
+		)
+	})
+
+	result := make([]interfaces.AllocRunner, 0, len(candidates))
+	for _, c := range candidates {
+		result = append(result, c.runner)
+	}
+	return result
+}
+
+// allocMemoryUsage returns the memory used by the tasks of an allocation, or
+// zero if it is not known.
+func allocMemoryUsage(ar interfaces.AllocRunner) uint64 {
+	stats, err := ar.StatsReporter().LatestAllocStats("")
+	if err != nil || stats == nil || stats.ResourceUsage == nil || stats.ResourceUsage.MemoryStats == nil {
+		return 0
+	}
+	ms := stats.ResourceUsage.MemoryStats
+	return max(ms.Usage, ms.RSS)
+}
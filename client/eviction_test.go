@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/allocrunner/state"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/hoststats"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// evictionTestRunner is an AllocRunner reporting memory usage and recording
+// the reason it was evicted for.
+type evictionTestRunner struct {
+	*emptyAllocRunner
+	memoryUsage uint64
+
+	evicted string
+	lock    sync.Mutex
+}
+
+func newEvictionTestRunner(alloc *structs.Allocation, clientStatus string, memoryUsage uint64) *evictionTestRunner {
+	return &evictionTestRunner{
+		emptyAllocRunner: &emptyAllocRunner{
+			alloc:      alloc,
+			allocState: &state.State{ClientStatus: clientStatus},
+		},
+		memoryUsage: memoryUsage,
+	}
+}
+
+func (ar *evictionTestRunner) StatsReporter() interfaces.AllocStatsReporter { return ar }
+
+func (ar *evictionTestRunner) LatestAllocStats(string) (*cstructs.AllocResourceUsage, error) {
+	return &cstructs.AllocResourceUsage{
+		ResourceUsage: &cstructs.ResourceUsage{
+			MemoryStats: &cstructs.MemoryStats{Usage: ar.memoryUsage},
+		},
+	}, nil
+}
+
+func (ar *evictionTestRunner) Evict(reason string) error {
+	ar.lock.Lock()
+	defer ar.lock.Unlock()
+	ar.evicted = reason
+	return nil
+}
+
+func (ar *evictionTestRunner) evictionReason() string {
+	ar.lock.Lock()
+	defer ar.lock.Unlock()
+	return ar.evicted
+}
+
+func TestEvictionManager_evictionCandidates(t *testing.T) {
+	ci.Parallel(t)
+
+	newAlloc := func(jobType string, priority int, createIndex uint64) *structs.Allocation {
+		alloc := mock.Alloc()
+		alloc.Job.Type = jobType
+		alloc.Job.Priority = priority
+		alloc.CreateIndex = createIndex
+		return alloc
+	}
+
+	lowLarge := newEvictionTestRunner(newAlloc(structs.JobTypeService, 10, 1), structs.AllocClientStatusPending, 500)
+	lowOld := newEvictionTestRunner(newAlloc(structs.JobTypeService, 10, 2), structs.AllocClientStatusRunning, 100)
+	lowNew := newEvictionTestRunner(newAlloc(structs.JobTypeBatch, 10, 3), structs.AllocClientStatusRunning, 100)
+	high := newEvictionTestRunner(newAlloc(structs.JobTypeService, 90, 4), structs.AllocClientStatusRunning, 1000)
+	system := newEvictionTestRunner(newAlloc(structs.JobTypeSystem, 1, 5), structs.AllocClientStatusRunning, 1000)
+	complete := newEvictionTestRunner(newAlloc(structs.JobTypeBatch, 1, 6), structs.AllocClientStatusComplete, 0)
+	stopped := newEvictionTestRunner(newAlloc(structs.JobTypeService, 1, 7), structs.AllocClientStatusRunning, 0)
+	stopped.alloc.DesiredStatus = structs.AllocDesiredStatusStop
+
+	runners := map[string]interfaces.AllocRunner{}
+	for _, ar := range []*evictionTestRunner{lowOld, lowNew, lowLarge, high, system, complete, stopped} {
+		runners[ar.alloc.ID] = ar
+	}
+
+	// under memory pressure the allocations using the most memory go first
+	must.Eq(t, []interfaces.AllocRunner{lowLarge, lowNew, lowOld, high},
+		evictionCandidates(runners, true))
+
+	// otherwise the most recently created go first
+	must.Eq(t, []interfaces.AllocRunner{lowNew, lowOld, lowLarge, high},
+		evictionCandidates(runners, false))
+}
+
+func TestEvictionManager_check(t *testing.T) {
+	ci.Parallel(t)
+
+	low := newEvictionTestRunner(mock.Alloc(), structs.AllocClientStatusRunning, 0)
+	low.alloc.Job.Priority = 10
+	high := newEvictionTestRunner(mock.Alloc(), structs.AllocClientStatusRunning, 0)
+	high.alloc.Job.Priority = 90
+	runners := map[string]interfaces.AllocRunner{
+		low.alloc.ID:  low,
+		high.alloc.ID: high,
+	}
+
+	var pressure *structs.NodePressure
+	var pressureLock sync.Mutex
+	m := newEvictionManager(
+		&config.EvictionConfig{MemoryThreshold: 90, DiskThreshold: 80},
+		func() map[string]interfaces.AllocRunner { return runners },
+		func(p *structs.NodePressure) {
+			pressureLock.Lock()
+			defer pressureLock.Unlock()
+			pressure = p
+		},
+		testlog.HCLogger(t),
+	)
+
+	// below the thresholds nothing is evicted
+	m.check(&hoststats.HostStats{
+		Memory:        &hoststats.MemoryStats{Total: 100, Available: 20},
+		AllocDirStats: &hoststats.DiskStats{UsedPercent: 50},
+	})
+	must.Nil(t, pressure)
+
+	// above the disk threshold the lowest priority allocation is evicted
+	m.check(&hoststats.HostStats{
+		Memory:        &hoststats.MemoryStats{Total: 100, Available: 20},
+		AllocDirStats: &hoststats.DiskStats{UsedPercent: 85},
+	})
+	pressureLock.Lock()
+	must.True(t, pressure.Disk)
+	must.False(t, pressure.Memory)
+	pressureLock.Unlock()
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return low.evictionReason() != "" }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.Eq(t, "Evicted due to node disk pressure", low.evictionReason())
+	must.Eq(t, "", high.evictionReason())
+}
+
+func TestEvictionManager_pressure_Hysteresis(t *testing.T) {
+	ci.Parallel(t)
+
+	m := newEvictionManager(
+		&config.EvictionConfig{
+			MemoryThreshold:         90,
+			MemoryRecoveryThreshold: 80,
+			DiskThreshold:           90,
+			DiskRecoveryThreshold:   80,
+		},
+		func() map[string]interfaces.AllocRunner { return nil },
+		func(*structs.NodePressure) {},
+		testlog.HCLogger(t),
+	)
+
+	check := func(memoryUsed, diskUsed float64) *structs.NodePressure {
+		m.check(&hoststats.HostStats{
+			Memory:        &hoststats.MemoryStats{Total: 100, Available: uint64(100 - memoryUsed)},
+			AllocDirStats: &hoststats.DiskStats{UsedPercent: diskUsed},
+		})
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		return m.current
+	}
+
+	// the node isn't under pressure until a threshold is reached
+	must.Nil(t, check(85, 85))
+
+	pressure := check(95, 85)
+	must.True(t, pressure.Memory)
+	must.False(t, pressure.Disk)
+
+	// the pressure holds while the usage is above the recovery threshold,
+	// even once it is below the threshold
+	pressure = check(85, 85)
+	must.True(t, pressure.Memory)
+	must.False(t, pressure.Disk)
+
+	pressure = check(85, 90)
+	must.True(t, pressure.Memory)
+	must.True(t, pressure.Disk)
+
+	// each resource recovers below its own recovery threshold
+	pressure = check(75, 85)
+	must.False(t, pressure.Memory)
+	must.True(t, pressure.Disk)
+
+	must.Nil(t, check(85, 75))
+}
@@ -4,7 +4,9 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -70,6 +72,12 @@ const (
 	// and end of a file.
 	OriginStart = "start"
 	OriginEnd   = "end"
+
+	// defaultUploadFileMode and defaultMkdirFileMode are the permissions of
+	// the files and directories created in an allocation's directory when the
+	// request does not set any.
+	defaultUploadFileMode = os.FileMode(0o644)
+	defaultMkdirFileMode  = os.FileMode(0o755)
 )
 
 // FileSystem endpoint is used for accessing the logs and filesystem of
@@ -82,6 +90,7 @@ func NewFileSystemEndpoint(c *Client) *FileSystem {
 	f := &FileSystem{c}
 	f.c.streamingRpcs.Register("FileSystem.Logs", f.logs)
 	f.c.streamingRpcs.Register("FileSystem.Stream", f.stream)
+	f.c.streamingRpcs.Register("FileSystem.Archive", f.archive)
 	return f
 }
 
@@ -157,6 +166,98 @@ func (f *FileSystem) Stat(args *cstructs.FsStatRequest, reply *cstructs.FsStatRe
 	return nil
 }
 
+// Upload is used to write a file to the allocation's directory.
+func (f *FileSystem) Upload(args *cstructs.FsUploadRequest, reply *structs.GenericResponse) error {
+	defer metrics.MeasureSince([]string{"client", "file_system", "upload"}, time.Now())
+
+	if args.Path == "" {
+		return pathNotPresentErr
+	}
+
+	alloc, err := f.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check namespace write-fs permission.
+	if aclObj, err := f.c.ResolveToken(args.QueryOptions.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityWriteFS) {
+		return structs.ErrPermissionDenied
+	}
+
+	fs, err := f.c.GetAllocFS(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	mode := args.Mode.Perm()
+	if mode == 0 {
+		mode = defaultUploadFileMode
+	}
+	return fs.WriteFile(args.Path, args.Data, mode)
+}
+
+// Mkdir is used to create a directory in the allocation's directory.
+func (f *FileSystem) Mkdir(args *cstructs.FsMkdirRequest, reply *structs.GenericResponse) error {
+	defer metrics.MeasureSince([]string{"client", "file_system", "mkdir"}, time.Now())
+
+	if args.Path == "" {
+		return pathNotPresentErr
+	}
+
+	alloc, err := f.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check namespace write-fs permission.
+	if aclObj, err := f.c.ResolveToken(args.QueryOptions.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityWriteFS) {
+		return structs.ErrPermissionDenied
+	}
+
+	fs, err := f.c.GetAllocFS(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	mode := args.Mode.Perm()
+	if mode == 0 {
+		mode = defaultMkdirFileMode
+	}
+	return fs.Mkdir(args.Path, mode)
+}
+
+// Remove is used to remove a file or directory from the allocation's
+// directory.
+func (f *FileSystem) Remove(args *cstructs.FsRemoveRequest, reply *structs.GenericResponse) error {
+	defer metrics.MeasureSince([]string{"client", "file_system", "remove"}, time.Now())
+
+	if args.Path == "" {
+		return pathNotPresentErr
+	}
+
+	alloc, err := f.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check namespace write-fs permission.
+	if aclObj, err := f.c.ResolveToken(args.QueryOptions.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityWriteFS) {
+		return structs.ErrPermissionDenied
+	}
+
+	fs, err := f.c.GetAllocFS(args.AllocID)
+	if err != nil {
+		return err
+	}
+	return fs.Remove(args.Path, args.Recursive)
+}
+
 // stream is is used to stream the contents of file in an allocation's
 // directory.
 func (f *FileSystem) stream(conn io.ReadWriteCloser) {
@@ -343,6 +444,100 @@ OUTER:
 	}
 }
 
+// archive is used to stream a gzip compressed tar archive of a directory in
+// an allocation's directory.
+func (f *FileSystem) archive(conn io.ReadWriteCloser) {
+	defer metrics.MeasureSince([]string{"client", "file_system", "archive"}, time.Now())
+	defer conn.Close()
+
+	// Decode the arguments
+	var req cstructs.FsArchiveRequest
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+
+	if err := decoder.Decode(&req); err != nil {
+		handleStreamResultError(err, pointer.Of(int64(http.StatusInternalServerError)), encoder)
+		return
+	}
+
+	if req.AllocID == "" {
+		handleStreamResultError(allocIDNotPresentErr, pointer.Of(int64(http.StatusBadRequest)), encoder)
+		return
+	}
+
+	// Terminal allocations can be archived until they are garbage collected
+	ar, err := f.c.getAllocRunner(req.AllocID)
+	if err != nil {
+		handleStreamResultError(structs.NewErrUnknownAllocation(req.AllocID), pointer.Of(int64(http.StatusNotFound)), encoder)
+		return
+	}
+	if ar.IsDestroyed() {
+		handleStreamResultError(
+			fmt.Errorf("state for allocation %s not found on client", req.AllocID),
+			pointer.Of(int64(http.StatusNotFound)),
+			encoder,
+		)
+		return
+	}
+	alloc := ar.Alloc()
+
+	// Check read permissions
+	if aclObj, err := f.c.ResolveToken(req.QueryOptions.AuthToken); err != nil {
+		handleStreamResultError(err, pointer.Of(int64(http.StatusForbidden)), encoder)
+		return
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadFS) {
+		handleStreamResultError(structs.ErrPermissionDenied, pointer.Of(int64(http.StatusForbidden)), encoder)
+		return
+	}
+
+	// Validate the arguments
+	if req.Path == "" {
+		handleStreamResultError(pathNotPresentErr, pointer.Of(int64(http.StatusBadRequest)), encoder)
+		return
+	}
+
+	fs := ar.GetAllocDir()
+	if _, err := fs.Stat(req.Path); err != nil {
+		code := pointer.Of(int64(http.StatusBadRequest))
+		if structs.IsErrNoSuchFileOrDirectory(err) {
+			code = pointer.Of(int64(http.StatusNotFound))
+		}
+		handleStreamResultError(err, code, encoder)
+		return
+	}
+
+	// Batch the compressed archive into frames of at most streamFrameSize
+	frames := bufio.NewWriterSize(&streamPayloadWriter{conn: conn, encoder: encoder}, streamFrameSize)
+	gz := gzip.NewWriter(frames)
+
+	err = fs.Archive(gz, req.Path, req.Include, req.Exclude)
+	if err == nil {
+		err = gz.Close()
+	}
+	if err == nil {
+		err = frames.Flush()
+	}
+	if err != nil {
+		handleStreamResultError(err, pointer.Of(int64(http.StatusInternalServerError)), encoder)
+		return
+	}
+}
+
+// streamPayloadWriter is an io.Writer sending the bytes written as the
+// payload of StreamErrWrapper frames.
+type streamPayloadWriter struct {
+	conn    io.Writer
+	encoder *codec.Encoder
+}
+
+func (w *streamPayloadWriter) Write(p []byte) (int, error) {
+	if err := w.encoder.Encode(cstructs.StreamErrWrapper{Payload: p}); err != nil {
+		return 0, err
+	}
+	w.encoder.Reset(w.conn)
+	return len(p), nil
+}
+
 // logs is is used to stream a task's logs.
 func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 	defer metrics.MeasureSince([]string{"client", "file_system", "logs"}, time.Now())
@@ -4,6 +4,9 @@
 package client
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -187,6 +190,152 @@ func TestFS_Stat_ACL(t *testing.T) {
 	}
 }
 
+func TestFS_Upload(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server and client
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+	}
+	alloc := testutil.WaitForRunning(t, s.RPC, job)[0]
+	qo := structs.QueryOptions{Region: "global"}
+
+	// Create a directory
+	var resp structs.GenericResponse
+	must.NoError(t, c.ClientRPC("FileSystem.Mkdir", &cstructs.FsMkdirRequest{
+		AllocID:      alloc.ID,
+		Path:         "alloc/data/config",
+		QueryOptions: qo,
+	}, &resp))
+
+	// Upload a file to it
+	must.NoError(t, c.ClientRPC("FileSystem.Upload", &cstructs.FsUploadRequest{
+		AllocID:      alloc.ID,
+		Path:         "alloc/data/config/app.conf",
+		Data:         []byte("hotfix"),
+		QueryOptions: qo,
+	}, &resp))
+
+	var statResp cstructs.FsStatResponse
+	must.NoError(t, c.ClientRPC("FileSystem.Stat", &cstructs.FsStatRequest{
+		AllocID:      alloc.ID,
+		Path:         "alloc/data/config/app.conf",
+		QueryOptions: qo,
+	}, &statResp))
+	must.Eq(t, 6, statResp.Info.Size)
+	must.Eq(t, "-rw-r--r--", statResp.Info.FileMode)
+
+	// Uploads cannot escape the alloc dir
+	err := c.ClientRPC("FileSystem.Upload", &cstructs.FsUploadRequest{
+		AllocID:      alloc.ID,
+		Path:         "../../app.conf",
+		Data:         []byte("hotfix"),
+		QueryOptions: qo,
+	}, &resp)
+	must.ErrorContains(t, err, "escapes")
+
+	// Remove the directory
+	must.NoError(t, c.ClientRPC("FileSystem.Remove", &cstructs.FsRemoveRequest{
+		AllocID:      alloc.ID,
+		Path:         "alloc/data/config",
+		Recursive:    true,
+		QueryOptions: qo,
+	}, &resp))
+
+	err = c.ClientRPC("FileSystem.Stat", &cstructs.FsStatRequest{
+		AllocID:      alloc.ID,
+		Path:         "alloc/data/config",
+		QueryOptions: qo,
+	}, &statResp)
+	must.ErrorContains(t, err, "no such file or directory")
+}
+
+func TestFS_Upload_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server
+	s, root, cleanupS := nomad.TestACLServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	client, cleanup := TestClient(t, func(c *config.Config) {
+		c.ACLEnabled = true
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	// Reading the filesystem does not allow writing to it
+	policyBad := mock.NamespacePolicy(structs.DefaultNamespace, "write", nil)
+	tokenBad := mock.CreatePolicyAndToken(t, s.State(), 1005, "invalid", policyBad)
+
+	policyGood := mock.NamespacePolicy(structs.DefaultNamespace, "",
+		[]string{acl.NamespaceCapabilityWriteFS})
+	tokenGood := mock.CreatePolicyAndToken(t, s.State(), 1009, "valid2", policyGood)
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for": "20s",
+	}
+
+	// Wait for client to be running job
+	alloc := testutil.WaitForRunningWithToken(t, s.RPC, job, root.SecretID)[0]
+
+	cases := []struct {
+		Name          string
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Name:          "bad token",
+			Token:         tokenBad.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:  "good token",
+			Token: tokenGood.SecretID,
+		},
+		{
+			Name:  "root token",
+			Token: root.SecretID,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := &cstructs.FsUploadRequest{
+				AllocID: alloc.ID,
+				Path:    "alloc/data/app.conf",
+				Data:    []byte("hotfix"),
+				QueryOptions: structs.QueryOptions{
+					Region:    "global",
+					AuthToken: c.Token,
+					Namespace: structs.DefaultNamespace,
+				},
+			}
+
+			var resp structs.GenericResponse
+			err := client.ClientRPC("FileSystem.Upload", req, &resp)
+			if c.ExpectedError == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, c.ExpectedError)
+			}
+		})
+	}
+}
+
 func TestFS_List_NoAlloc(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -486,6 +635,120 @@ func TestFS_Stream_GC(t *testing.T) {
 	}
 }
 
+// archiveStream makes a FileSystem.Archive request to the client and returns
+// the archive payload or the error sent on the stream.
+func archiveStream(t *testing.T, c *Client, req *cstructs.FsArchiveRequest) ([]byte, *cstructs.RpcError) {
+	handler, err := c.StreamingRpcHandler("FileSystem.Archive")
+	must.NoError(t, err)
+
+	p1, p2 := net.Pipe()
+	defer p1.Close()
+	go handler(p2)
+
+	encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+	must.NoError(t, encoder.Encode(req))
+
+	var payload []byte
+	decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+	for {
+		var msg cstructs.StreamErrWrapper
+		if err := decoder.Decode(&msg); err != nil {
+			if err == io.EOF || strings.Contains(err.Error(), "closed") {
+				return payload, nil
+			}
+			t.Fatalf("error decoding: %v", err)
+		}
+		if msg.Error != nil {
+			return nil, msg.Error
+		}
+		payload = append(payload, msg.Payload...)
+	}
+}
+
+func TestFS_Archive(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server and client
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	expected := "Hello from the other side"
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for":       "10ms",
+		"stdout_string": expected,
+	}
+	alloc := testutil.WaitForRunning(t, s.RPC, job)[0]
+
+	// Wait for the alloc to be terminal, archives are available until it is
+	// garbage collected
+	testutil.WaitForResult(func() (bool, error) {
+		ar, err := c.getAllocRunner(alloc.ID)
+		if err != nil {
+			return false, err
+		}
+		if status := ar.AllocState().ClientStatus; status != structs.AllocClientStatusComplete {
+			return false, fmt.Errorf("alloc status is %q", status)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	payload, rpcErr := archiveStream(t, c, &cstructs.FsArchiveRequest{
+		AllocID:      alloc.ID,
+		Path:         "alloc/logs",
+		Include:      []string{"*.stdout.*"},
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	})
+	must.Nil(t, rpcErr)
+
+	gz, err := gzip.NewReader(bytes.NewReader(payload))
+	must.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		must.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		must.NoError(t, err)
+		files[hdr.Name] = string(content)
+	}
+	must.Eq(t, map[string]string{
+		"alloc/logs/web.stdout.0": expected,
+	}, files)
+
+	// Missing paths are not found
+	_, rpcErr = archiveStream(t, c, &cstructs.FsArchiveRequest{
+		AllocID:      alloc.ID,
+		Path:         "alloc/missing",
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	})
+	must.NotNil(t, rpcErr)
+	must.Eq(t, http.StatusNotFound, *rpcErr.Code)
+
+	// Invalid globs are rejected
+	_, rpcErr = archiveStream(t, c, &cstructs.FsArchiveRequest{
+		AllocID:      alloc.ID,
+		Path:         "/",
+		Exclude:      []string{"[bad"},
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	})
+	must.NotNil(t, rpcErr)
+	must.StrContains(t, rpcErr.Error(), "syntax error in pattern")
+}
+
 func TestFS_Stream_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -37,6 +37,15 @@ type MemoryStats struct {
 	Free      uint64
 }
 
+// UsedPercent returns the percentage of memory that is not available for new
+// allocations, including memory that cannot be reclaimed from caches.
+func (m *MemoryStats) UsedPercent() float64 {
+	if m == nil || m.Total == 0 || m.Available > m.Total {
+		return 0
+	}
+	return float64(m.Total-m.Available) / float64(m.Total) * 100
+}
+
 // CPUStats represents stats related to cpu usage
 type CPUStats struct {
 	CPU          string
@@ -76,6 +85,7 @@ type HostStatsCollector struct {
 	top                  *numalib.Topology
 	statsCalculator      map[string]*HostCpuStatsCalculator
 	hostStats            *HostStats
+	utilization          *Utilization
 	hostStatsLock        sync.RWMutex
 	allocDir             string
 	deviceStatsCollector DeviceStatsCollector
@@ -161,6 +171,7 @@ func (h *HostStatsCollector) collectLocked() error {
 
 	// Update the collected status object.
 	h.hostStats = hs
+	h.utilization = smoothUtilization(h.utilization, hs)
 
 	return nil
 }
@@ -230,6 +241,15 @@ func (h *HostStatsCollector) Stats() *HostStats {
 	return h.hostStats
 }
 
+// Utilization returns the smoothed utilization of the host, or nil if no
+// stats have been collected yet
+func (h *HostStatsCollector) Utilization() *Utilization {
+	h.hostStatsLock.RLock()
+	defer h.hostStatsLock.RUnlock()
+
+	return h.utilization.Copy()
+}
+
 // toDiskStats merges UsageStat and PartitionStat to create a DiskStat
 func (h *HostStatsCollector) toDiskStats(usage *disk.UsageStat, partitionStat *disk.PartitionStat) *DiskStats {
 	ds := DiskStats{
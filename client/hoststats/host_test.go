@@ -52,3 +52,17 @@ func TestHostCpuStatsCalculator_DecreasedIOWait(t *testing.T) {
 	_, _, _, total := calculator.Calculate(times)
 	must.GreaterEq(t, 0.0, total, must.Sprint("total must never be negative"))
 }
+
+func TestMemoryStats_UsedPercent(t *testing.T) {
+	var nilStats *MemoryStats
+	must.Eq(t, 0.0, nilStats.UsedPercent())
+	must.Eq(t, 0.0, (&MemoryStats{}).UsedPercent())
+
+	stats := &MemoryStats{
+		Total:     1000,
+		Available: 250,
+		Used:      500,
+		Free:      100,
+	}
+	must.Eq(t, 75.0, stats.UsedPercent())
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+// utilizationSmoothingFactor is the weight given to the latest sample when
+// smoothing the utilization of the host. With the default stats collection
+// interval of one second, samples older than about a minute have a negligible
+// weight.
+const utilizationSmoothingFactor = 0.05
+
+// Utilization is the smoothed CPU and memory utilization of the host.
+type Utilization struct {
+	// CPUPercent is the percentage of the CPU of the host in use.
+	CPUPercent float64
+
+	// MemoryPercent is the percentage of the memory of the host in use.
+	MemoryPercent float64
+}
+
+// Copy returns a copy of the utilization.
+func (u *Utilization) Copy() *Utilization {
+	if u == nil {
+		return nil
+	}
+	nu := *u
+	return &nu
+}
+
+// smoothUtilization returns the exponentially weighted moving average of the
+// previous utilization and the utilization of the host stats. If there is no
+// previous utilization, the utilization of the host stats is returned as is.
+func smoothUtilization(prev *Utilization, hs *HostStats) *Utilization {
+	sample := &Utilization{}
+	if len(hs.CPU) > 0 {
+		for _, cpu := range hs.CPU {
+			sample.CPUPercent += cpu.TotalPercent
+		}
+		sample.CPUPercent /= float64(len(hs.CPU))
+	}
+	if hs.Memory != nil && hs.Memory.Total > 0 {
+		sample.MemoryPercent = float64(hs.Memory.Used) / float64(hs.Memory.Total) * 100
+	}
+
+	if prev == nil {
+		return sample
+	}
+
+	return &Utilization{
+		CPUPercent: prev.CPUPercent +
+			utilizationSmoothingFactor*(sample.CPUPercent-prev.CPUPercent),
+		MemoryPercent: prev.MemoryPercent +
+			utilizationSmoothingFactor*(sample.MemoryPercent-prev.MemoryPercent),
+	}
+}
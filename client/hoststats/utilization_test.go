@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestSmoothUtilization(t *testing.T) {
+	hs := &HostStats{
+		CPU: []*CPUStats{
+			{CPU: "cpu0", TotalPercent: 80},
+			{CPU: "cpu1", TotalPercent: 40},
+		},
+		Memory: &MemoryStats{Total: 1000, Used: 500},
+	}
+
+	// The first sample is used as is
+	u := smoothUtilization(nil, hs)
+	must.Eq(t, &Utilization{CPUPercent: 60, MemoryPercent: 50}, u)
+
+	// Later samples are smoothed
+	hs.CPU = []*CPUStats{
+		{CPU: "cpu0", TotalPercent: 100},
+		{CPU: "cpu1", TotalPercent: 100},
+	}
+	hs.Memory.Used = 1000
+	u = smoothUtilization(u, hs)
+	must.Eq(t, 62, u.CPUPercent)
+	must.Eq(t, 52.5, u.MemoryPercent)
+
+	// Missing stats are treated as unused
+	u = smoothUtilization(nil, &HostStats{})
+	must.Eq(t, &Utilization{}, u)
+}
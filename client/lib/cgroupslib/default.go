@@ -14,3 +14,8 @@ func LinuxResourcesPath(string, string, bool) string {
 func MaybeDisableMemorySwappiness() *uint64 {
 	return nil
 }
+
+// ValidateIODevice does nothing on non-Linux systems
+func ValidateIODevice(string) error {
+	return nil
+}
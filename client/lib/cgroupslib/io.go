@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// IOLimit is the io.max limit of a cgroup on a block device. Limits that are
+// zero are not enforced.
+type IOLimit struct {
+	// Path is the path of the block device, such as /dev/sda.
+	Path string
+
+	ReadBps   int64
+	WriteBps  int64
+	ReadIOPS  int64
+	WriteIOPS int64
+}
+
+// WriteIO writes the io.weight and io.max interface files of the cgroup at
+// dir. A weight of zero leaves the default weight of the cgroup unchanged.
+//
+// Only cgroups v2 is supported.
+func WriteIO(dir string, weight int, limits []IOLimit) error {
+	ed := OpenPath(dir)
+
+	if weight > 0 {
+		if err := ed.Write("io.weight", "default "+strconv.Itoa(weight)); err != nil {
+			return fmt.Errorf("failed to write io.weight: %w", err)
+		}
+	}
+
+	for _, limit := range limits {
+		major, minor, err := blockDevice(limit.Path)
+		if err != nil {
+			return err
+		}
+		if err := ed.Write("io.max", formatIOMax(major, minor, limit)); err != nil {
+			return fmt.Errorf("failed to write io.max for device %q: %w", limit.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// sysDevBlock is the sysfs directory of the block devices of the host, named
+// after their major and minor numbers.
+const sysDevBlock = "/sys/dev/block"
+
+// ValidateIODevice returns an error if path is not a whole block device, which
+// is the only kind of device io.max accepts limits for.
+func ValidateIODevice(path string) error {
+	_, _, err := blockDevice(path)
+	return err
+}
+
+// blockDevice returns the major and minor numbers of the whole block device at
+// path.
+func blockDevice(path string) (uint32, uint32, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return 0, 0, fmt.Errorf("failed to stat io device %q: %w", path, err)
+	}
+	if st.Mode&unix.S_IFMT != unix.S_IFBLK {
+		return 0, 0, fmt.Errorf("io device %q is not a block device", path)
+	}
+	rdev := uint64(st.Rdev)
+	major, minor := unix.Major(rdev), unix.Minor(rdev)
+	if err := checkWholeDisk(sysDevBlock, path, major, minor); err != nil {
+		return 0, 0, err
+	}
+	return major, minor, nil
+}
+
+// checkWholeDisk returns an error if the block device is a partition, as the
+// kernel rejects io.max limits on partitions. The sysfs entry of a partition
+// links to a directory under the one of its disk, which is used to name the
+// disk in the error.
+func checkWholeDisk(sysDir, path string, major, minor uint32) error {
+	dir := filepath.Join(sysDir, fmt.Sprintf("%d:%d", major, minor))
+	if _, err := os.Stat(filepath.Join(dir, "partition")); err != nil {
+		return nil
+	}
+
+	disk := "its whole disk"
+	if target, err := filepath.EvalSymlinks(dir); err == nil {
+		disk = filepath.Join("/dev", filepath.Base(filepath.Dir(target)))
+	}
+	return fmt.Errorf("io device %q is a partition, io limits must be set on %s instead", path, disk)
+}
+
+// formatIOMax returns the io.max line setting the limits of the device.
+func formatIOMax(major, minor uint32, limit IOLimit) string {
+	value := func(n int64) string {
+		if n <= 0 {
+			return "max"
+		}
+		return strconv.FormatInt(n, 10)
+	}
+	return fmt.Sprintf("%d:%d rbps=%s wbps=%s riops=%s wiops=%s",
+		major, minor,
+		value(limit.ReadBps), value(limit.WriteBps),
+		value(limit.ReadIOPS), value(limit.WriteIOPS),
+	)
+}
+
+// IOStat is the block I/O of a cgroup summed over all devices.
+type IOStat struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadOps    uint64
+	WriteOps   uint64
+}
+
+// ReadIOStat reads the io.stat interface file of the cgroup at dir.
+//
+// Only cgroups v2 is supported.
+func ReadIOStat(dir string) (*IOStat, error) {
+	content, err := OpenPath(dir).Read("io.stat")
+	if err != nil {
+		return nil, err
+	}
+	return parseIOStat(content), nil
+}
+
+// parseIOStat sums the content of io.stat, which has one line per device like
+//
+//	8:0 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0
+func parseIOStat(content string) *IOStat {
+	stat := new(IOStat)
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "rbytes":
+				stat.ReadBytes += n
+			case "wbytes":
+				stat.WriteBytes += n
+			case "rios":
+				stat.ReadOps += n
+			case "wios":
+				stat.WriteOps += n
+			}
+		}
+	}
+	return stat
+}
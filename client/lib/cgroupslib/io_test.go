@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_formatIOMax(t *testing.T) {
+	line := formatIOMax(8, 16, IOLimit{
+		Path:      "/dev/sdb",
+		ReadBps:   1048576,
+		WriteIOPS: 100,
+	})
+	must.Eq(t, "8:16 rbps=1048576 wbps=max riops=max wiops=100", line)
+}
+
+func Test_blockDevice(t *testing.T) {
+	_, _, err := blockDevice("/dev/null")
+	must.ErrorContains(t, err, "is not a block device")
+
+	_, _, err = blockDevice("/dev/does-not-exist")
+	must.ErrorContains(t, err, "failed to stat io device")
+}
+
+func Test_checkWholeDisk(t *testing.T) {
+	// sysfs links the entries of partitions to a directory under the one of
+	// their disk
+	sysDir := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(sysDir, "block", "sda", "sda1"), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(sysDir, "block", "sda", "sda1", "partition"), []byte("1\n"), 0o644))
+	must.NoError(t, os.MkdirAll(filepath.Join(sysDir, "dev", "block"), 0o755))
+	must.NoError(t, os.Symlink("../../block/sda", filepath.Join(sysDir, "dev", "block", "8:0")))
+	must.NoError(t, os.Symlink("../../block/sda/sda1", filepath.Join(sysDir, "dev", "block", "8:1")))
+
+	devBlock := filepath.Join(sysDir, "dev", "block")
+	must.NoError(t, checkWholeDisk(devBlock, "/dev/sda", 8, 0))
+
+	err := checkWholeDisk(devBlock, "/dev/sda1", 8, 1)
+	must.EqError(t, err, `io device "/dev/sda1" is a partition, io limits must be set on /dev/sda instead`)
+
+	// Devices missing from sysfs are left to the kernel to reject
+	must.NoError(t, checkWholeDisk(devBlock, "/dev/sdb", 8, 16))
+}
+
+func Test_parseIOStat(t *testing.T) {
+	content := `8:16 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0
+8:0 rbytes=90430464 wbytes=299008000 rios=8950 wios=1252 dbytes=50331648 dios=3021`
+
+	stat := parseIOStat(content)
+	must.Eq(t, &IOStat{
+		ReadBytes:  91889664,
+		WriteBytes: 613781504,
+		ReadOps:    9142,
+		WriteOps:   1605,
+	}, stat)
+
+	must.Eq(t, &IOStat{}, parseIOStat(""))
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PSI is the pressure stall information of a cgroup for one resource. The
+// averages are the percentage of wall time over the last 10, 60 and 300
+// seconds in which some or all of the processes of the cgroup were stalled
+// waiting on the resource. The totals are the stall time in microseconds.
+type PSI struct {
+	SomeAvg10  float64
+	SomeAvg60  float64
+	SomeAvg300 float64
+	SomeTotal  uint64
+
+	FullAvg10  float64
+	FullAvg60  float64
+	FullAvg300 float64
+	FullTotal  uint64
+}
+
+// ReadPSI reads the <resource>.pressure interface file of the cgroup at dir,
+// where resource is one of "memory", "cpu" or "io".
+//
+// Only cgroups v2 is supported.
+func ReadPSI(dir, resource string) (*PSI, error) {
+	content, err := OpenPath(dir).Read(resource + ".pressure")
+	if err != nil {
+		return nil, err
+	}
+	return parsePSI(content)
+}
+
+// parsePSI parses the content of a pressure file, which looks like
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//
+// The full line is absent from cpu.pressure on older kernels.
+func parsePSI(content string) (*PSI, error) {
+	psi := new(PSI)
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var avg10, avg60, avg300 *float64
+		var total *uint64
+		switch fields[0] {
+		case "some":
+			avg10, avg60, avg300, total = &psi.SomeAvg10, &psi.SomeAvg60, &psi.SomeAvg300, &psi.SomeTotal
+		case "full":
+			avg10, avg60, avg300, total = &psi.FullAvg10, &psi.FullAvg60, &psi.FullAvg300, &psi.FullTotal
+		default:
+			return nil, fmt.Errorf("unexpected pressure line %q", line)
+		}
+
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("unexpected pressure field %q", field)
+			}
+			var err error
+			switch key {
+			case "avg10":
+				*avg10, err = strconv.ParseFloat(value, 64)
+			case "avg60":
+				*avg60, err = strconv.ParseFloat(value, 64)
+			case "avg300":
+				*avg300, err = strconv.ParseFloat(value, 64)
+			case "total":
+				*total, err = strconv.ParseUint(value, 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse pressure field %q: %w", field, err)
+			}
+		}
+	}
+	return psi, nil
+}
+
+// MemoryEvents are the counters of the memory.events interface file of a
+// cgroup, which include the events of its descendants.
+type MemoryEvents struct {
+	Low     uint64
+	High    uint64
+	Max     uint64
+	OOM     uint64
+	OOMKill uint64
+}
+
+// ReadMemoryEvents reads the memory.events interface file of the cgroup at
+// dir.
+//
+// Only cgroups v2 is supported.
+func ReadMemoryEvents(dir string) (*MemoryEvents, error) {
+	content, err := OpenPath(dir).Read("memory.events")
+	if err != nil {
+		return nil, err
+	}
+	counters := parseFlatKeyed(content)
+	return &MemoryEvents{
+		Low:     counters["low"],
+		High:    counters["high"],
+		Max:     counters["max"],
+		OOM:     counters["oom"],
+		OOMKill: counters["oom_kill"],
+	}, nil
+}
+
+// CPUStat are the throttling counters of the cpu.stat interface file of a
+// cgroup.
+type CPUStat struct {
+	Periods          uint64
+	ThrottledPeriods uint64
+	ThrottledUsec    uint64
+}
+
+// ReadCPUStat reads the cpu.stat interface file of the cgroup at dir.
+//
+// Only cgroups v2 is supported.
+func ReadCPUStat(dir string) (*CPUStat, error) {
+	content, err := OpenPath(dir).Read("cpu.stat")
+	if err != nil {
+		return nil, err
+	}
+	counters := parseFlatKeyed(content)
+	return &CPUStat{
+		Periods:          counters["nr_periods"],
+		ThrottledPeriods: counters["nr_throttled"],
+		ThrottledUsec:    counters["throttled_usec"],
+	}, nil
+}
+
+// parseFlatKeyed parses the content of a flat keyed interface file, which has
+// one "key value" pair per line. Lines that are not counters are ignored.
+func parseFlatKeyed(content string) map[string]uint64 {
+	counters := make(map[string]uint64)
+	for _, line := range strings.Split(content, "\n") {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		counters[key] = n
+	}
+	return counters
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_parsePSI(t *testing.T) {
+	content := `some avg10=1.50 avg60=12.25 avg300=3.00 total=123456
+full avg10=0.50 avg60=2.00 avg300=0.75 total=6789`
+
+	psi, err := parsePSI(content)
+	must.NoError(t, err)
+	must.Eq(t, &PSI{
+		SomeAvg10:  1.5,
+		SomeAvg60:  12.25,
+		SomeAvg300: 3,
+		SomeTotal:  123456,
+		FullAvg10:  0.5,
+		FullAvg60:  2,
+		FullAvg300: 0.75,
+		FullTotal:  6789,
+	}, psi)
+
+	psi, err = parsePSI("some avg10=0.00 avg60=0.00 avg300=0.00 total=42")
+	must.NoError(t, err)
+	must.Eq(t, &PSI{SomeTotal: 42}, psi)
+
+	_, err = parsePSI("some avg10=abc")
+	must.ErrorContains(t, err, `failed to parse pressure field "avg10=abc"`)
+
+	_, err = parsePSI("partial avg10=0.00")
+	must.ErrorContains(t, err, "unexpected pressure line")
+}
+
+func Test_ReadMemoryEvents(t *testing.T) {
+	dir := t.TempDir()
+	content := "low 0\nhigh 12\nmax 40\noom 3\noom_kill 2\noom_group_kill 0\n"
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "memory.events"), []byte(content), 0644))
+
+	events, err := ReadMemoryEvents(dir)
+	must.NoError(t, err)
+	must.Eq(t, &MemoryEvents{
+		High:    12,
+		Max:     40,
+		OOM:     3,
+		OOMKill: 2,
+	}, events)
+}
+
+func Test_ReadCPUStat(t *testing.T) {
+	dir := t.TempDir()
+	content := `usage_usec 8262
+user_usec 5384
+system_usec 2877
+nr_periods 100
+nr_throttled 25
+throttled_usec 50000`
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "cpu.stat"), []byte(content), 0644))
+
+	stat, err := ReadCPUStat(dir)
+	must.NoError(t, err)
+	must.Eq(t, &CPUStat{
+		Periods:          100,
+		ThrottledPeriods: 25,
+		ThrottledUsec:    50000,
+	}, stat)
+}
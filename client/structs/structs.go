@@ -7,6 +7,7 @@ package structs
 
 import (
 	"errors"
+	"os"
 	"time"
 
 	"github.com/hashicorp/nomad/client/hoststats"
@@ -110,6 +111,70 @@ type FsStatResponse struct {
 	structs.QueryMeta
 }
 
+// FsArchiveRequest is the initial request for streaming a gzip compressed tar
+// archive of a directory.
+type FsArchiveRequest struct {
+	// AllocID is the allocation to archive the directory of
+	AllocID string
+
+	// Path is the path of the file or directory to archive
+	Path string
+
+	// Include are globs matching the files to archive. All files are
+	// archived if empty.
+	Include []string
+
+	// Exclude are globs matching the files and directories to skip
+	Exclude []string
+
+	structs.QueryOptions
+}
+
+// FsUploadRequest is used to write a file to an allocation's directory.
+type FsUploadRequest struct {
+	// AllocID is the allocation to write the file in
+	AllocID string
+
+	// Path is the path of the file to write
+	Path string
+
+	// Data is the content of the file
+	Data []byte
+
+	// Mode is the permissions of the file
+	Mode os.FileMode
+
+	structs.QueryOptions
+}
+
+// FsMkdirRequest is used to create a directory in an allocation's directory.
+type FsMkdirRequest struct {
+	// AllocID is the allocation to create the directory in
+	AllocID string
+
+	// Path is the path of the directory to create
+	Path string
+
+	// Mode is the permissions of the directory
+	Mode os.FileMode
+
+	structs.QueryOptions
+}
+
+// FsRemoveRequest is used to remove a file from an allocation's directory.
+type FsRemoveRequest struct {
+	// AllocID is the allocation to remove the file from
+	AllocID string
+
+	// Path is the path of the file or directory to remove
+	Path string
+
+	// Recursive removes non-empty directories
+	Recursive bool
+
+	structs.QueryOptions
+}
+
 // FsStreamRequest is the initial request for streaming the content of a file.
 type FsStreamRequest struct {
 	// AllocID is the allocation to stream logs from
@@ -287,25 +352,83 @@ func (cs *CpuStats) Add(other *CpuStats) {
 	cs.Measured = joinStringSet(cs.Measured, other.Measured)
 }
 
-// ResourceUsage holds information related to cpu and memory stats
+// IOStats holds block I/O related stats
+type IOStats struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+	ReadOps    uint64
+	WriteOps   uint64
+
+	// A list of fields whose values were actually sampled
+	Measured []string
+}
+
+func (is *IOStats) Add(other *IOStats) {
+	if other == nil {
+		return
+	}
+
+	is.ReadBytes += other.ReadBytes
+	is.WriteBytes += other.WriteBytes
+	is.ReadOps += other.ReadOps
+	is.WriteOps += other.WriteOps
+	is.Measured = joinStringSet(is.Measured, other.Measured)
+}
+
+// ResourceUsage holds information related to cpu, memory and block I/O stats
 type ResourceUsage struct {
 	MemoryStats *MemoryStats
 	CpuStats    *CpuStats
+	IOStats     *IOStats
 	DeviceStats []*device.DeviceGroupStats
 }
 
 func (ru *ResourceUsage) Add(other *ResourceUsage) {
 	ru.MemoryStats.Add(other.MemoryStats)
 	ru.CpuStats.Add(other.CpuStats)
+	if other.IOStats != nil {
+		if ru.IOStats == nil {
+			ru.IOStats = &IOStats{}
+		}
+		ru.IOStats.Add(other.IOStats)
+	}
 	ru.DeviceStats = append(ru.DeviceStats, other.DeviceStats...)
 }
 
+// PSIStats holds the pressure stall information of a resource. The averages
+// are the percentage of wall time over the last 10, 60 and 300 seconds in
+// which some or all of the processes of a task were stalled waiting on the
+// resource. The totals are the stall time in microseconds.
+type PSIStats struct {
+	SomeAvg10  float64
+	SomeAvg60  float64
+	SomeAvg300 float64
+	SomeTotal  uint64
+
+	FullAvg10  float64
+	FullAvg60  float64
+	FullAvg300 float64
+	FullTotal  uint64
+}
+
+// PressureStats holds the pressure stall information of a task, which is
+// only available on Linux with cgroups v2
+type PressureStats struct {
+	Memory *PSIStats
+	CPU    *PSIStats
+	IO     *PSIStats
+}
+
 // TaskResourceUsage holds aggregated resource usage of all processes in a Task
 // and the resource usage of the individual pids
 type TaskResourceUsage struct {
 	ResourceUsage *ResourceUsage
 	Timestamp     int64 // UnixNano
 	Pids          map[string]*ResourceUsage
+
+	// Pressure is the pressure stall information of the task, if the client
+	// is able to read it from the cgroup of the task
+	Pressure *PressureStats
 }
 
 // AllocResourceUsage holds the aggregated task resource usage of the
@@ -76,6 +76,10 @@ const (
 	// AllocIndex is the environment variable for passing the allocation index.
 	AllocIndex = "NOMAD_ALLOC_INDEX"
 
+	// ArrayIndex is the environment variable for passing the array index of
+	// allocations of array task groups.
+	ArrayIndex = "NOMAD_ARRAY_INDEX"
+
 	// Datacenter is the environment variable for passing the datacenter in which the alloc is running.
 	Datacenter = "NOMAD_DC"
 
@@ -423,6 +427,7 @@ type Builder struct {
 	memMaxLimit          int64
 	taskName             string
 	allocIndex           int
+	arrayIndex           string
 	datacenter           string
 	cgroupParent         string
 	namespace            string
@@ -537,6 +542,9 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 	if b.allocIndex != -1 {
 		envMap[AllocIndex] = strconv.Itoa(b.allocIndex)
 	}
+	if b.arrayIndex != "" {
+		envMap[ArrayIndex] = b.arrayIndex
+	}
 	if b.taskName != "" {
 		envMap[TaskName] = b.taskName
 	}
@@ -748,6 +756,10 @@ func (b *Builder) setAlloc(alloc *structs.Allocation) *Builder {
 	b.allocName = alloc.Name
 	b.groupName = alloc.TaskGroup
 	b.allocIndex = int(alloc.Index())
+	b.arrayIndex = ""
+	if idx, ok := alloc.ArrayIndex(); ok {
+		b.arrayIndex = strconv.Itoa(idx)
+	}
 	b.jobID = alloc.Job.ID
 	b.jobName = alloc.Job.Name
 	b.jobParentID = alloc.Job.ParentID
@@ -703,6 +703,22 @@ func TestEnvironment_DashesInTaskName(t *testing.T) {
 	}
 }
 
+func TestEnvironment_ArrayIndex(t *testing.T) {
+	ci.Parallel(t)
+
+	a := mock.Alloc()
+	a.Name = structs.AllocName(a.JobID, a.TaskGroup, 3)
+	task := a.Job.TaskGroups[0].Tasks[0]
+
+	envMap := NewBuilder(mock.Node(), a, task, "global").Build().Map()
+	require.NotContains(t, envMap, ArrayIndex)
+
+	a.Job.TaskGroups[0].Array = &structs.ArrayConfig{Start: 100, End: 199}
+	envMap = NewBuilder(mock.Node(), a, task, "global").Build().Map()
+	require.Equal(t, "3", envMap[AllocIndex])
+	require.Equal(t, "103", envMap[ArrayIndex])
+}
+
 // TestEnvironment_UpdateTask asserts env vars and task meta are updated when a
 // task is updated.
 func TestEnvironment_UpdateTask(t *testing.T) {
@@ -493,6 +493,13 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 		conf.RootKeyRotationThreshold = dur
 	}
+	if rebalanceInterval := agentConfig.Server.RebalanceInterval; rebalanceInterval != "" {
+		dur, err := time.ParseDuration(rebalanceInterval)
+		if err != nil {
+			return nil, err
+		}
+		conf.RebalanceInterval = dur
+	}
 
 	if heartbeatGrace := agentConfig.Server.HeartbeatGrace; heartbeatGrace != 0 {
 		conf.HeartbeatGrace = heartbeatGrace
@@ -565,6 +572,19 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		return nil, fmt.Errorf("deploy_query_rate_limit must be greater than 0")
 	}
 
+	if len(agentConfig.Server.AnalysisProviders) > 0 {
+		conf.AnalysisProviders = make(map[string]*structs.AnalysisProvider, len(agentConfig.Server.AnalysisProviders))
+		for _, p := range agentConfig.Server.AnalysisProviders {
+			if err := p.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid analysis_provider %q: %v", p.Name, err)
+			}
+			if _, ok := conf.AnalysisProviders[p.Name]; ok {
+				return nil, fmt.Errorf("analysis_provider %q is duplicated", p.Name)
+			}
+			conf.AnalysisProviders[p.Name] = p.Copy()
+		}
+	}
+
 	// Set plan rejection tracker configuration.
 	if planRejectConf := agentConfig.Server.PlanRejectionTracker; planRejectConf != nil {
 		if planRejectConf.Enabled != nil {
@@ -909,6 +929,12 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	}
 	conf.Drain = drainConfig
 
+	evictionConfig, err := clientconfig.EvictionConfigFromAgent(agentConfig.Client.Eviction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid eviction config: %v", err)
+	}
+	conf.Eviction = evictionConfig
+
 	conf.Users = clientconfig.UsersConfigFromAgent(agentConfig.Client.Users)
 
 	return conf, nil
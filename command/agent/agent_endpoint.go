@@ -104,6 +104,14 @@ func (s *HTTPServer) AgentSelfRequest(resp http.ResponseWriter, req *http.Reques
 		self.Config.Telemetry.CirconusAPIToken = "<redacted>"
 	}
 
+	if self.Config != nil && self.Config.Server != nil {
+		for _, p := range self.Config.Server.AnalysisProviders {
+			for k := range p.Headers {
+				p.Headers[k] = "<redacted>"
+			}
+		}
+	}
+
 	return self, nil
 }
 
@@ -447,6 +447,58 @@ func TestAgent_ServerConfig_PlanRejectionTracker(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_AnalysisProviders(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		providers   []*structs.AnalysisProvider
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			providers: []*structs.AnalysisProvider{
+				{Name: "checks", Type: "http", Address: "https://checks.example.com", Method: "POST"},
+				{Name: "prometheus", Type: "query", Address: "http://127.0.0.1:9090"},
+			},
+		},
+		{
+			name: "invalid",
+			providers: []*structs.AnalysisProvider{
+				{Name: "checks", Type: "http", Address: "checks.example.com"},
+			},
+			expectedErr: `invalid analysis_provider "checks"`,
+		},
+		{
+			name: "duplicate",
+			providers: []*structs.AnalysisProvider{
+				{Name: "checks", Type: "http", Address: "https://checks.example.com"},
+				{Name: "checks", Type: "http", Address: "https://other.example.com"},
+			},
+			expectedErr: `analysis_provider "checks" is duplicated`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := DevConfig(nil)
+			must.NoError(t, config.normalizeAddrs())
+			config.Server.AnalysisProviders = tc.providers
+
+			serverConfig, err := convertServerConfig(config)
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			must.NoError(t, err)
+			must.MapLen(t, len(tc.providers), serverConfig.AnalysisProviders)
+			for _, p := range tc.providers {
+				must.Eq(t, p, serverConfig.AnalysisProviders[p.Name])
+			}
+		})
+	}
+}
+
 func TestAgent_ServerConfig_RaftMultiplier_Ok(t *testing.T) {
 	ci.Parallel(t)
 
@@ -383,6 +383,10 @@ type ClientConfig struct {
 	// Drain specifies whether to drain the client on shutdown; ignored in dev mode.
 	Drain *config.DrainConfig `hcl:"drain_on_shutdown"`
 
+	// Eviction specifies whether and when the client evicts allocations
+	// because its host runs low on memory or disk.
+	Eviction *config.EvictionConfig `hcl:"eviction"`
+
 	// Users is used to configure parameters around operating system users.
 	Users *config.UsersConfig `hcl:"users"`
 
@@ -409,6 +413,7 @@ func (c *ClientConfig) Copy() *ClientConfig {
 	nc.NomadServiceDiscovery = pointer.Copy(c.NomadServiceDiscovery)
 	nc.Artifact = c.Artifact.Copy()
 	nc.Drain = c.Drain.Copy()
+	nc.Eviction = c.Eviction.Copy()
 	nc.Users = c.Users.Copy()
 	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
 	return &nc
@@ -568,6 +573,11 @@ type ServerConfig struct {
 	// collection interval.
 	RootKeyRotationThreshold string `hcl:"root_key_rotation_threshold"`
 
+	// RebalanceInterval is how often we dispatch a job to migrate
+	// allocations that violate their placement goals, when rebalancing is
+	// enabled in the scheduler configuration.
+	RebalanceInterval string `hcl:"rebalance_interval"`
+
 	// HeartbeatGrace is the grace period beyond the TTL to account for network,
 	// processing delays and clock skew before marking a node as "down".
 	HeartbeatGrace    time.Duration
@@ -674,6 +684,10 @@ type ServerConfig struct {
 	// DeploymentWatcher to throttle the amount of simultaneously deployments
 	DeploymentQueryRateLimit float64 `hcl:"deploy_query_rate_limit"`
 
+	// AnalysisProviders are the endpoints, with their credentials, that the
+	// http and query analysis gates of jobs reference by name.
+	AnalysisProviders []*structs.AnalysisProvider `hcl:"analysis_provider"`
+
 	// RaftBoltConfig configures boltdb as used by raft.
 	RaftBoltConfig *RaftBoltConfig `hcl:"raft_boltdb"`
 
@@ -743,6 +757,7 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.JobDefaultPriority = pointer.Copy(s.JobDefaultPriority)
 	ns.JobMaxPriority = pointer.Copy(s.JobMaxPriority)
 	ns.JobTrackedVersions = pointer.Copy(s.JobTrackedVersions)
+	ns.AnalysisProviders = helper.CopySlice(s.AnalysisProviders)
 	return &ns
 }
 
@@ -2110,6 +2125,9 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.RootKeyRotationThreshold != "" {
 		result.RootKeyRotationThreshold = b.RootKeyRotationThreshold
 	}
+	if b.RebalanceInterval != "" {
+		result.RebalanceInterval = b.RebalanceInterval
+	}
 	if b.HeartbeatGrace != 0 {
 		result.HeartbeatGrace = b.HeartbeatGrace
 	}
@@ -2185,6 +2203,10 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.DeploymentQueryRateLimit = b.DeploymentQueryRateLimit
 	}
 
+	if len(b.AnalysisProviders) != 0 {
+		result.AnalysisProviders = mergeAnalysisProviders(s.AnalysisProviders, b.AnalysisProviders)
+	}
+
 	if b.Search != nil {
 		result.Search = &Search{FuzzyEnabled: b.Search.FuzzyEnabled}
 		if b.Search.LimitQuery > 0 {
@@ -2240,6 +2262,25 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	return &result
 }
 
+// mergeAnalysisProviders merges two sets of analysis providers, the providers
+// of b replacing the ones of a with the same name.
+func mergeAnalysisProviders(a, b []*structs.AnalysisProvider) []*structs.AnalysisProvider {
+	n := helper.CopySlice(a)
+	seen := make(map[string]int, len(a))
+	for i, p := range a {
+		seen[p.Name] = i
+	}
+
+	for _, p := range b {
+		if i, ok := seen[p.Name]; ok {
+			n[i] = p.Copy()
+			continue
+		}
+		n = append(n, p.Copy())
+	}
+	return n
+}
+
 // Merge is used to merge two client configs together
 func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	result := *a
@@ -2411,6 +2452,7 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 
 	result.Artifact = a.Artifact.Merge(b.Artifact)
 	result.Drain = a.Drain.Merge(b.Drain)
+	result.Eviction = a.Eviction.Merge(b.Eviction)
 	result.Users = a.Users.Merge(b.Users)
 
 	return &result
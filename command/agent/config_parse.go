@@ -321,10 +321,17 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "server")
 	}
 
-	for _, k := range []string{"preemption_config"} {
+	for _, k := range []string{"preemption_config", "load_aware_config", "rebalance_config"} {
 		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, k)
 	}
 
+	// Remove AnalysisProvider extra keys
+	for _, p := range c.Server.AnalysisProviders {
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, p.Name)
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, "analysis_provider")
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, "headers")
+	}
+
 	for _, k := range []string{"datadog_tags"} {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, k)
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "telemetry")
@@ -156,6 +156,12 @@ var basicConfig = &Config{
 		LicensePath:        "/tmp/nomad.hclic",
 		JobDefaultPriority: pointer.Of(100),
 		JobMaxPriority:     pointer.Of(200),
+		AnalysisProviders: []*structs.AnalysisProvider{{
+			Name:    "prometheus",
+			Type:    "query",
+			Address: "http://127.0.0.1:9090",
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+		}},
 	},
 	ACL: &ACLConfig{
 		Enabled:                  true,
@@ -10,6 +10,8 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -19,6 +21,10 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
+// fsUploadMaxBytes is the maximum size of a file uploaded to an allocation's
+// directory.
+const fsUploadMaxBytes = 64 * 1024 * 1024
+
 var (
 	allocIDNotPresentErr  = CodedError(400, "must provide a valid alloc id")
 	fileNameNotPresentErr = CodedError(400, "must provide a file name")
@@ -41,6 +47,14 @@ func (s *HTTPServer) FsRequest(resp http.ResponseWriter, req *http.Request) (int
 		return s.wrapUntrustedContent(s.FileCatRequest)(resp, req)
 	case strings.HasPrefix(path, "stream/"):
 		return s.Stream(resp, req)
+	case strings.HasPrefix(path, "archive/"):
+		return s.FileArchiveRequest(resp, req)
+	case strings.HasPrefix(path, "upload/"):
+		return s.FileUploadRequest(resp, req)
+	case strings.HasPrefix(path, "mkdir/"):
+		return s.DirectoryCreateRequest(resp, req)
+	case strings.HasPrefix(path, "rm/"):
+		return s.FileRemoveRequest(resp, req)
 	case strings.HasPrefix(path, "logs/"):
 		// Logs are *trusted* content because the endpoint
 		// explicitly sets the Content-Type to text/plain or
@@ -133,6 +147,137 @@ func (s *HTTPServer) FileStatRequest(resp http.ResponseWriter, req *http.Request
 	return reply.Info, nil
 }
 
+func (s *HTTPServer) FileUploadRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var allocID, path string
+	if allocID = strings.TrimPrefix(req.URL.Path, "/v1/client/fs/upload/"); allocID == "" {
+		return nil, allocIDNotPresentErr
+	}
+	if path = req.URL.Query().Get("path"); path == "" {
+		return nil, fileNameNotPresentErr
+	}
+	mode, err := parseFileMode(req)
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	// Read the whole file as it is sent in a single RPC
+	data, err := io.ReadAll(io.LimitReader(req.Body, fsUploadMaxBytes+1))
+	if err != nil {
+		return nil, CodedError(400, fmt.Sprintf("failed to read file: %v", err))
+	}
+	if len(data) > fsUploadMaxBytes {
+		return nil, CodedError(413, fmt.Sprintf("file exceeds the maximum upload size of %d bytes", fsUploadMaxBytes))
+	}
+
+	// Create the request
+	args := &cstructs.FsUploadRequest{
+		AllocID: allocID,
+		Path:    path,
+		Data:    data,
+		Mode:    mode,
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	return nil, s.fsWriteRPC("FileSystem.Upload", allocID, args)
+}
+
+func (s *HTTPServer) DirectoryCreateRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var allocID, path string
+	if allocID = strings.TrimPrefix(req.URL.Path, "/v1/client/fs/mkdir/"); allocID == "" {
+		return nil, allocIDNotPresentErr
+	}
+	if path = req.URL.Query().Get("path"); path == "" {
+		return nil, fileNameNotPresentErr
+	}
+	mode, err := parseFileMode(req)
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	// Create the request
+	args := &cstructs.FsMkdirRequest{
+		AllocID: allocID,
+		Path:    path,
+		Mode:    mode,
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	return nil, s.fsWriteRPC("FileSystem.Mkdir", allocID, args)
+}
+
+func (s *HTTPServer) FileRemoveRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodDelete {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var allocID, path string
+	if allocID = strings.TrimPrefix(req.URL.Path, "/v1/client/fs/rm/"); allocID == "" {
+		return nil, allocIDNotPresentErr
+	}
+	if path = req.URL.Query().Get("path"); path == "" {
+		return nil, fileNameNotPresentErr
+	}
+	recursive, err := parseBool(req, "recursive")
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	// Create the request
+	args := &cstructs.FsRemoveRequest{
+		AllocID:   allocID,
+		Path:      path,
+		Recursive: recursive != nil && *recursive,
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	return nil, s.fsWriteRPC("FileSystem.Remove", allocID, args)
+}
+
+// fsWriteRPC makes an RPC modifying the directory of the allocation, routing
+// it to the client running the allocation.
+func (s *HTTPServer) fsWriteRPC(method, allocID string, args interface{}) error {
+	localClient, remoteClient, localServer := s.rpcHandlerForAlloc(allocID)
+
+	var reply structs.GenericResponse
+	var rpcErr error
+	if localClient {
+		rpcErr = s.agent.Client().ClientRPC(method, args, &reply)
+	} else if remoteClient {
+		rpcErr = s.agent.Client().RPC(method, args, &reply)
+	} else if localServer {
+		rpcErr = s.agent.Server().RPC(method, args, &reply)
+	}
+
+	if rpcErr != nil {
+		if structs.IsErrNoNodeConn(rpcErr) || structs.IsErrUnknownAllocation(rpcErr) || structs.IsErrNoSuchFileOrDirectory(rpcErr) {
+			rpcErr = CodedError(404, rpcErr.Error())
+		}
+	}
+	return rpcErr
+}
+
+// parseFileMode parses the octal mode query parameter, returning zero if it is
+// not present.
+func parseFileMode(req *http.Request) (os.FileMode, error) {
+	str := req.URL.Query().Get("mode")
+	if str == "" {
+		return 0, nil
+	}
+	mode, err := strconv.ParseUint(str, 8, 32)
+	if err != nil || os.FileMode(mode)&^os.ModePerm != 0 {
+		return 0, fmt.Errorf("Failed to parse value of %q (%v) as file permissions", "mode", str)
+	}
+	return os.FileMode(mode), nil
+}
+
 func (s *HTTPServer) FileReadAtRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	var allocID, path string
 	var offset, limit int64
@@ -198,6 +343,75 @@ func (s *HTTPServer) FileCatRequest(resp http.ResponseWriter, req *http.Request)
 	return s.fsStreamImpl(resp, req, "FileSystem.Stream", fsReq, fsReq.AllocID)
 }
 
+// FileArchiveRequest streams a gzip compressed tar archive of a directory in
+// an allocation's directory. The parameters are:
+//   - path: path to the directory to archive, defaults to the alloc dir.
+//   - include: glob patterns of the files to archive, defaults to all files.
+//   - exclude: glob patterns of the files to leave out of the archive.
+//
+// The include and exclude parameters may be repeated or hold a comma
+// separated list of patterns.
+func (s *HTTPServer) FileArchiveRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var allocID, path string
+
+	q := req.URL.Query()
+
+	if allocID = strings.TrimPrefix(req.URL.Path, "/v1/client/fs/archive/"); allocID == "" {
+		return nil, allocIDNotPresentErr
+	}
+	if path = q.Get("path"); path == "" {
+		path = "/"
+	}
+
+	// Create the request arguments
+	fsReq := &cstructs.FsArchiveRequest{
+		AllocID: allocID,
+		Path:    path,
+		Include: parseGlobs(q["include"]),
+		Exclude: parseGlobs(q["exclude"]),
+	}
+	s.parse(resp, req, &fsReq.QueryOptions.Region, &fsReq.QueryOptions)
+
+	// The archive is binary content so Go's http.ResponseWriter must not
+	// sniff its Content-Type.
+	resp.Header().Set("Content-Type", "application/gzip")
+	resp.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", archiveFileName(allocID, path)))
+
+	// Make the request
+	out, err := s.fsStreamImpl(resp, req, "FileSystem.Archive", fsReq, fsReq.AllocID)
+	if err != nil {
+		// Errors returned before the archive was streamed are not gzip
+		resp.Header().Del("Content-Type")
+		resp.Header().Del("Content-Disposition")
+	}
+	return out, err
+}
+
+// parseGlobs splits the comma separated glob patterns of a repeated query
+// parameter.
+func parseGlobs(values []string) []string {
+	var globs []string
+	for _, v := range values {
+		for _, glob := range strings.Split(v, ",") {
+			if glob = strings.TrimSpace(glob); glob != "" {
+				globs = append(globs, glob)
+			}
+		}
+	}
+	return globs
+}
+
+// archiveFileName returns the name of the archive of a directory in an
+// allocation's directory.
+func archiveFileName(allocID, path string) string {
+	name := allocID
+	if base := filepath.Base(filepath.Clean("/" + path)); base != "/" {
+		name += "-" + base
+	}
+	return name + ".tar.gz"
+}
+
 // Stream streams the content of a file blocking on EOF.
 // The parameters are:
 //   - path: path to file to stream.
@@ -4,6 +4,8 @@
 package agent
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"encoding/base64"
 	"fmt"
 	"io"
@@ -19,6 +21,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
 
@@ -377,6 +380,109 @@ func TestHTTP_FS_Cat(t *testing.T) {
 	})
 }
 
+func TestHTTP_FS_Upload(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		a := mockFSAlloc(s.client.NodeID(), nil)
+		addAllocToClient(s, a, terminalClientAlloc)
+
+		// reads are not allowed
+		path := fmt.Sprintf("/v1/client/fs/upload/%s?path=alloc/data/hotfix/app.conf", a.ID)
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		must.NoError(t, err)
+		_, err = s.Server.FileUploadRequest(httptest.NewRecorder(), req)
+		must.EqError(t, err, ErrInvalidMethod)
+
+		// the mode must be valid permissions
+		req, err = http.NewRequest(http.MethodPut, path+"&mode=1777", nil)
+		must.NoError(t, err)
+		_, err = s.Server.FileUploadRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "as file permissions")
+
+		// create the directory and upload the file
+		mkdirPath := fmt.Sprintf("/v1/client/fs/mkdir/%s?path=alloc/data/hotfix", a.ID)
+		req, err = http.NewRequest(http.MethodPut, mkdirPath, nil)
+		must.NoError(t, err)
+		_, err = s.Server.DirectoryCreateRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+
+		req, err = http.NewRequest(http.MethodPut, path+"&mode=0600", strings.NewReader("hotfix"))
+		must.NoError(t, err)
+		_, err = s.Server.FileUploadRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+
+		catPath := fmt.Sprintf("/v1/client/fs/cat/%s?path=alloc/data/hotfix/app.conf", a.ID)
+		req, err = http.NewRequest(http.MethodGet, catPath, nil)
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.FileCatRequest(respW, req)
+		must.NoError(t, err)
+		output, err := io.ReadAll(respW.Result().Body)
+		must.NoError(t, err)
+		must.Eq(t, "hotfix", string(output))
+
+		// remove the directory
+		rmPath := fmt.Sprintf("/v1/client/fs/rm/%s?path=alloc/data/hotfix&recursive=true", a.ID)
+		req, err = http.NewRequest(http.MethodDelete, rmPath, nil)
+		must.NoError(t, err)
+		_, err = s.Server.FileRemoveRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+
+		req, err = http.NewRequest(http.MethodDelete, rmPath, nil)
+		must.NoError(t, err)
+		_, err = s.Server.FileRemoveRequest(httptest.NewRecorder(), req)
+		codedErr, ok := err.(HTTPCodedError)
+		must.True(t, ok)
+		must.Eq(t, 404, codedErr.Code())
+	})
+}
+
+func TestHTTP_FS_Archive(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		a := mockFSAlloc(s.client.NodeID(), nil)
+		addAllocToClient(s, a, terminalClientAlloc)
+
+		// archives of terminal allocs can be downloaded
+		path := fmt.Sprintf("%s/v1/client/fs/archive/%s?path=alloc&include=*.stdout.*,*.stderr.*&include=missing", s.HTTPAddr(), a.ID)
+		resp, err := http.DefaultClient.Get(path)
+		must.NoError(t, err)
+		defer resp.Body.Close()
+		must.Eq(t, http.StatusOK, resp.StatusCode)
+		must.Eq(t, "application/gzip", resp.Header.Get("Content-Type"))
+		must.Eq(t, fmt.Sprintf(`attachment; filename="%s-alloc.tar.gz"`, a.ID),
+			resp.Header.Get("Content-Disposition"))
+
+		gz, err := gzip.NewReader(resp.Body)
+		must.NoError(t, err)
+		tr := tar.NewReader(gz)
+
+		files := map[string]string{}
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			must.NoError(t, err)
+			content, err := io.ReadAll(tr)
+			must.NoError(t, err)
+			files[hdr.Name] = string(content)
+		}
+		must.Eq(t, map[string]string{
+			"alloc/logs/web.stdout.0": defaultLoggerMockDriverStdout,
+			"alloc/logs/web.stderr.0": "",
+		}, files)
+
+		// errors are not sent as archives
+		path = fmt.Sprintf("%s/v1/client/fs/archive/%s?path=alloc/missing", s.HTTPAddr(), a.ID)
+		resp, err = http.DefaultClient.Get(path)
+		must.NoError(t, err)
+		defer resp.Body.Close()
+		must.Eq(t, http.StatusNotFound, resp.StatusCode)
+		must.NotEq(t, "application/gzip", resp.Header.Get("Content-Type"))
+	})
+}
+
 // TestHTTP_FS_Cat_XSS asserts that the cat API is safe from XSS.
 func TestHTTP_FS_Cat_XSS(t *testing.T) {
 	ci.Parallel(t)
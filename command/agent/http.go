@@ -494,6 +494,7 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/system/reconcile/summaries", s.wrap(s.ReconcileJobSummaries))
 
 	s.mux.HandleFunc("/v1/operator/scheduler/configuration", s.wrap(s.OperatorSchedulerConfiguration))
+	s.mux.HandleFunc("/v1/operator/scheduler/rebalance", s.wrap(s.OperatorSchedulerRebalance))
 
 	s.mux.HandleFunc("/v1/event/stream", s.wrap(s.EventStream))
 
@@ -1064,12 +1064,26 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		}
 	}
 
+	if len(job.DependsOn) > 0 {
+		j.DependsOn = []*structs.JobDependency{}
+		for _, d := range job.DependsOn {
+			j.DependsOn = append(j.DependsOn, &structs.JobDependency{
+				JobID:     d.JobID,
+				Condition: d.Condition,
+			})
+		}
+	}
+
 	if job.Periodic != nil {
 		j.Periodic = &structs.PeriodicConfig{
-			Enabled:         *job.Periodic.Enabled,
-			SpecType:        *job.Periodic.SpecType,
-			ProhibitOverlap: *job.Periodic.ProhibitOverlap,
-			TimeZone:        *job.Periodic.TimeZone,
+			Enabled:           *job.Periodic.Enabled,
+			SpecType:          *job.Periodic.SpecType,
+			ProhibitOverlap:   *job.Periodic.ProhibitOverlap,
+			ConcurrencyPolicy: *job.Periodic.ConcurrencyPolicy,
+			StartingDeadline:  *job.Periodic.StartingDeadline,
+			CatchUp:           *job.Periodic.CatchUp,
+			HistoryLimit:      *job.Periodic.HistoryLimit,
+			TimeZone:          *job.Periodic.TimeZone,
 		}
 
 		if job.Periodic.Spec != nil {
@@ -1133,6 +1147,8 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		Attempts:        *taskGroup.RestartPolicy.Attempts,
 		Interval:        *taskGroup.RestartPolicy.Interval,
 		Delay:           *taskGroup.RestartPolicy.Delay,
+		DelayFunction:   *taskGroup.RestartPolicy.DelayFunction,
+		MaxDelay:        *taskGroup.RestartPolicy.MaxDelay,
 		Mode:            *taskGroup.RestartPolicy.Mode,
 		RenderTemplates: *taskGroup.RestartPolicy.RenderTemplates,
 	}
@@ -1143,6 +1159,30 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		tg.PreventRescheduleOnLost = *taskGroup.PreventRescheduleOnLost
 	}
 
+	if taskGroup.Gang != nil {
+		tg.Gang = *taskGroup.Gang
+	}
+
+	if taskGroup.Array != nil {
+		tg.Array = &structs.ArrayConfig{
+			Start:       taskGroup.Array.Start,
+			End:         taskGroup.Array.End,
+			MaxParallel: taskGroup.Array.MaxParallel,
+		}
+	}
+
+	if taskGroup.Completion != nil {
+		tg.Completion = &structs.CompletionPolicy{
+			Completions:  taskGroup.Completion.Completions,
+			Parallelism:  taskGroup.Completion.Parallelism,
+			BackoffLimit: taskGroup.Completion.BackoffLimit,
+		}
+	}
+
+	if taskGroup.MaxRunDuration != nil {
+		tg.MaxRunDuration = *taskGroup.MaxRunDuration
+	}
+
 	if taskGroup.ShutdownDelay != nil {
 		tg.ShutdownDelay = taskGroup.ShutdownDelay
 	}
@@ -1164,6 +1204,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 			MaxDelay:      *taskGroup.ReschedulePolicy.MaxDelay,
 			Unlimited:     *taskGroup.ReschedulePolicy.Unlimited,
 		}
+
+		if taskGroup.ReschedulePolicy.OnMaxRunDuration != nil {
+			tg.ReschedulePolicy.OnMaxRunDuration = *taskGroup.ReschedulePolicy.OnMaxRunDuration
+		}
 	}
 
 	if taskGroup.Disconnect != nil {
@@ -1181,6 +1225,13 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		}
 	}
 
+	if taskGroup.DisruptionBudget != nil {
+		tg.DisruptionBudget = &structs.DisruptionBudget{
+			MaxUnavailable: taskGroup.DisruptionBudget.MaxUnavailable,
+			MinAvailable:   taskGroup.DisruptionBudget.MinAvailable,
+		}
+	}
+
 	if taskGroup.Migrate != nil {
 		tg.Migrate = &structs.MigrateStrategy{
 			MaxParallel:     *taskGroup.Migrate.MaxParallel,
@@ -1207,6 +1258,32 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		}
 	}
 
+	if len(taskGroup.TopologySpreads) > 0 {
+		tg.TopologySpreads = []*structs.TopologySpread{}
+		for _, ts := range taskGroup.TopologySpreads {
+			tg.TopologySpreads = append(tg.TopologySpreads, ApiTopologySpreadToStructs(ts))
+		}
+	}
+
+	if len(taskGroup.AllocationAffinities) > 0 {
+		tg.AllocationAffinities = []*structs.AllocationAffinity{}
+		for _, aa := range taskGroup.AllocationAffinities {
+			tg.AllocationAffinities = append(tg.AllocationAffinities, ApiAllocationAffinityToStructs(aa))
+		}
+	}
+
+	if len(taskGroup.Tolerations) > 0 {
+		tg.Tolerations = []*structs.Toleration{}
+		for _, t := range taskGroup.Tolerations {
+			tg.Tolerations = append(tg.Tolerations, &structs.Toleration{
+				Key:      t.Key,
+				Operator: *t.Operator,
+				Value:    t.Value,
+				Effect:   t.Effect,
+			})
+		}
+	}
+
 	if len(taskGroup.Volumes) > 0 {
 		tg.Volumes = map[string]*structs.VolumeRequest{}
 		for k, v := range taskGroup.Volumes {
@@ -1246,6 +1323,7 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 			HealthyDeadline:  *taskGroup.Update.HealthyDeadline,
 			ProgressDeadline: *taskGroup.Update.ProgressDeadline,
 			Canary:           *taskGroup.Update.Canary,
+			CanaryPercent:    *taskGroup.Update.CanaryPercent,
 		}
 
 		// boolPtr fields may be nil, others will have pointers to default values via Canonicalize
@@ -1256,6 +1334,22 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		if taskGroup.Update.Strategy != nil {
+			tg.Update.Strategy = *taskGroup.Update.Strategy
+		}
+
+		if taskGroup.Update.HoldPeriod != nil {
+			tg.Update.HoldPeriod = *taskGroup.Update.HoldPeriod
+		}
+
+		if len(taskGroup.Update.DependsOn) != 0 {
+			tg.Update.DependsOn = slices.Clone(taskGroup.Update.DependsOn)
+		}
+
+		if taskGroup.Update.Analysis != nil {
+			tg.Update.Analysis = ApiAnalysisStrategyToStructs(taskGroup.Update.Analysis)
+		}
 	}
 
 	if len(taskGroup.Tasks) > 0 {
@@ -1288,6 +1382,7 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 	structsTask.Meta = apiTask.Meta
 	structsTask.KillTimeout = *apiTask.KillTimeout
 	structsTask.ShutdownDelay = apiTask.ShutdownDelay
+	structsTask.MaxRunDuration = apiTask.MaxRunDuration
 	structsTask.KillSignal = apiTask.KillSignal
 	structsTask.Kind = structs.TaskKind(apiTask.Kind)
 	structsTask.Constraints = ApiConstraintsToStructs(apiTask.Constraints)
@@ -1316,6 +1411,8 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 			Attempts:        *apiTask.RestartPolicy.Attempts,
 			Interval:        *apiTask.RestartPolicy.Interval,
 			Delay:           *apiTask.RestartPolicy.Delay,
+			DelayFunction:   *apiTask.RestartPolicy.DelayFunction,
+			MaxDelay:        *apiTask.RestartPolicy.MaxDelay,
 			Mode:            *apiTask.RestartPolicy.Mode,
 			RenderTemplates: *apiTask.RestartPolicy.RenderTemplates,
 		}
@@ -1528,6 +1625,21 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		}
 	}
 
+	if in.IO != nil {
+		out.IO = &structs.IOResources{
+			Weight: *in.IO.Weight,
+		}
+		for _, d := range in.IO.Devices {
+			out.IO.Devices = append(out.IO.Devices, &structs.IODeviceResource{
+				Path:      d.Path,
+				ReadBps:   *d.ReadBps,
+				WriteBps:  *d.WriteBps,
+				ReadIOPS:  *d.ReadIOPS,
+				WriteIOPS: *d.WriteIOPS,
+			})
+		}
+	}
+
 	return out
 }
 
@@ -2159,6 +2271,53 @@ func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	return ret
 }
 
+func ApiTopologySpreadToStructs(a1 *api.TopologySpread) *structs.TopologySpread {
+	return &structs.TopologySpread{
+		Attributes:        slices.Clone(a1.Attributes),
+		MaxSkew:           *a1.MaxSkew,
+		WhenUnsatisfiable: *a1.WhenUnsatisfiable,
+	}
+}
+
+func ApiAnalysisStrategyToStructs(a1 *api.AnalysisStrategy) *structs.AnalysisStrategy {
+	ret := &structs.AnalysisStrategy{
+		Steps: slices.Clone(a1.Steps),
+	}
+	if a1.Delay != nil {
+		ret.Delay = *a1.Delay
+	}
+	for _, g := range a1.Gates {
+		gate := &structs.AnalysisGate{
+			Name:     g.Name,
+			Type:     g.Type,
+			Provider: g.Provider,
+			Query:    g.Query,
+			Task:     g.Task,
+			Action:   g.Action,
+		}
+		if g.Timeout != nil {
+			gate.Timeout = *g.Timeout
+		}
+		ret.Gates = append(ret.Gates, gate)
+	}
+	return ret
+}
+
+func ApiAllocationAffinityToStructs(a1 *api.AllocationAffinity) *structs.AllocationAffinity {
+	ret := &structs.AllocationAffinity{
+		Namespace: a1.Namespace,
+		JobID:     a1.JobID,
+		TaskGroup: a1.TaskGroup,
+		Meta:      maps.Clone(a1.Meta),
+		Anti:      *a1.Anti,
+		Required:  *a1.Required,
+	}
+	if a1.Weight != nil {
+		ret.Weight = *a1.Weight
+	}
+	return ret
+}
+
 // validateEvalPriorityOpt ensures the supplied evaluation priority override
 // value is within acceptable bounds.
 func validateEvalPriorityOpt(priority int) HTTPCodedError {
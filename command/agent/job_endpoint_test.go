@@ -3024,12 +3024,14 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			MaxParallel: 5,
 		},
 		Periodic: &structs.PeriodicConfig{
-			Enabled:         true,
-			Spec:            "spec",
-			Specs:           []string{"spec"},
-			SpecType:        "cron",
-			ProhibitOverlap: true,
-			TimeZone:        "test zone",
+			Enabled:           true,
+			Spec:              "spec",
+			Specs:             []string{"spec"},
+			SpecType:          "cron",
+			ProhibitOverlap:   true,
+			ConcurrencyPolicy: structs.PeriodicConcurrencyForbid,
+			CatchUp:           structs.PeriodicCatchUpLatest,
+			TimeZone:          "test zone",
 		},
 		ParameterizedJob: &structs.ParameterizedJobConfig{
 			Payload:      "payload",
@@ -3078,6 +3080,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					Interval:        1 * time.Second,
 					Attempts:        5,
 					Delay:           10 * time.Second,
+					DelayFunction:   "constant",
 					Mode:            "delay",
 					RenderTemplates: false,
 				},
@@ -3122,6 +3125,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					AutoRevert:       true,
 					AutoPromote:      false,
 					Canary:           1,
+					Strategy:         structs.UpdateStrategyRolling,
 				},
 				Meta: map[string]string{
 					"key": "value",
@@ -3246,6 +3250,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							Interval:        2 * time.Second,
 							Attempts:        10,
 							Delay:           20 * time.Second,
+							DelayFunction:   "constant",
 							Mode:            "delay",
 							RenderTemplates: false,
 						},
@@ -3584,6 +3589,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					Interval:        1 * time.Second,
 					Attempts:        5,
 					Delay:           10 * time.Second,
+					DelayFunction:   "constant",
 					Mode:            "delay",
 					RenderTemplates: false,
 				},
@@ -3644,6 +3650,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							Interval:        1 * time.Second,
 							Attempts:        5,
 							Delay:           10 * time.Second,
+							DelayFunction:   "constant",
 							Mode:            "delay",
 							RenderTemplates: false,
 						},
@@ -3735,6 +3742,7 @@ func TestJobs_ApiJobToStructsJobUpdate(t *testing.T) {
 		AutoRevert:       true,
 		AutoPromote:      false,
 		Canary:           2,
+		Strategy:         structs.UpdateStrategyRolling,
 	}
 
 	group2 := structs.UpdateStrategy{
@@ -3747,6 +3755,7 @@ func TestJobs_ApiJobToStructsJobUpdate(t *testing.T) {
 		AutoRevert:       false,
 		AutoPromote:      true,
 		Canary:           3,
+		Strategy:         structs.UpdateStrategyRolling,
 	}
 
 	require.Equal(t, jobUpdate, structsJob.Update)
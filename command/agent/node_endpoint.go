@@ -57,6 +57,9 @@ func (s *HTTPServer) NodeSpecificRequest(resp http.ResponseWriter, req *http.Req
 	case strings.HasSuffix(path, "/eligibility"):
 		nodeName := strings.TrimSuffix(path, "/eligibility")
 		return s.nodeToggleEligibility(resp, req, nodeName)
+	case strings.HasSuffix(path, "/taints"):
+		nodeName := strings.TrimSuffix(path, "/taints")
+		return s.nodeUpdateTaints(resp, req, nodeName)
 	case strings.HasSuffix(path, "/purge"):
 		nodeName := strings.TrimSuffix(path, "/purge")
 		return s.nodePurge(resp, req, nodeName)
@@ -170,6 +173,30 @@ func (s *HTTPServer) nodeToggleEligibility(resp http.ResponseWriter, req *http.R
 	return out, nil
 }
 
+func (s *HTTPServer) nodeUpdateTaints(resp http.ResponseWriter, req *http.Request,
+	nodeID string) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var taintsRequest structs.NodeUpdateTaintsRequest
+	if err := decodeBody(req, &taintsRequest); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if taintsRequest.NodeID == "" {
+		taintsRequest.NodeID = nodeID
+	}
+
+	s.parseWriteRequest(req, &taintsRequest.WriteRequest)
+
+	var out structs.NodeTaintsUpdateResponse
+	if err := s.agent.RPC("Node.UpdateTaints", &taintsRequest, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) nodeQuery(resp http.ResponseWriter, req *http.Request,
 	nodeID string) (interface{}, error) {
 	if req.Method != http.MethodGet {
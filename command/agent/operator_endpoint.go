@@ -308,6 +308,27 @@ func (s *HTTPServer) schedulerGetConfig(resp http.ResponseWriter, req *http.Requ
 	return reply, nil
 }
 
+// OperatorSchedulerRebalance is used to list the allocations the rebalancer
+// would migrate.
+func (s *HTTPServer) OperatorSchedulerRebalance(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.SchedulerRebalanceCandidatesResponse
+	if err := s.agent.RPC("Operator.SchedulerRebalanceCandidates", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply, nil
+}
+
 func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	var args structs.SchedulerSetConfigRequest
 	s.parseWriteRequest(req, &args.WriteRequest)
@@ -322,6 +343,17 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 		MemoryOversubscriptionEnabled: conf.MemoryOversubscriptionEnabled,
 		RejectJobRegistration:         conf.RejectJobRegistration,
 		PauseEvalBroker:               conf.PauseEvalBroker,
+		LoadAwareConfig: structs.LoadAwareConfig{
+			Enabled:              conf.LoadAwareConfig.Enabled,
+			UtilizationThreshold: conf.LoadAwareConfig.UtilizationThreshold,
+		},
+		RebalanceConfig: structs.RebalanceConfig{
+			Enabled:              conf.RebalanceConfig.Enabled,
+			DryRun:               conf.RebalanceConfig.DryRun,
+			Namespaces:           conf.RebalanceConfig.Namespaces,
+			MaxMigrations:        conf.RebalanceConfig.MaxMigrations,
+			UtilizationThreshold: conf.RebalanceConfig.UtilizationThreshold,
+		},
 		PreemptionConfig: structs.PreemptionConfig{
 			SystemSchedulerEnabled:   conf.PreemptionConfig.SystemSchedulerEnabled,
 			SysBatchSchedulerEnabled: conf.PreemptionConfig.SysBatchSchedulerEnabled,
@@ -165,6 +165,15 @@ server {
     }
   }
 
+  analysis_provider "prometheus" {
+    type    = "query"
+    address = "http://127.0.0.1:9090"
+
+    headers {
+      Authorization = "Bearer secret"
+    }
+  }
+
   license_path = "/tmp/nomad.hclic"
 }
 
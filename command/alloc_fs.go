@@ -16,6 +16,7 @@ import (
 	humanize "github.com/dustin/go-humanize"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/api/contexts"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
 	"github.com/posener/complete"
 )
 
@@ -78,6 +79,21 @@ FS Specific Options:
 
   -c
     Sets the tail location in number of bytes relative to the end of the file.
+
+  -archive
+    Write a gzip compressed tar archive of the directory at the path to stdout
+    instead of listing it. Archives can be downloaded from terminal allocations
+    until they are garbage collected.
+
+  -include <glob>
+    Only archive the files whose name or path relative to the alloc dir match
+    the glob pattern. This option may be specified multiple times. Requires
+    -archive.
+
+  -exclude <glob>
+    Leave the files and directories whose name or path relative to the alloc
+    dir match the glob pattern out of the archive. This option may be specified
+    multiple times. Requires -archive.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -97,6 +113,9 @@ func (f *AllocFSCommand) AutocompleteFlags() complete.Flags {
 			"-tail":    complete.PredictNothing,
 			"-n":       complete.PredictAnything,
 			"-c":       complete.PredictAnything,
+			"-archive": complete.PredictNothing,
+			"-include": complete.PredictAnything,
+			"-exclude": complete.PredictAnything,
 		})
 }
 
@@ -118,8 +137,9 @@ func (f *AllocFSCommand) AutocompleteArgs() complete.Predictor {
 func (f *AllocFSCommand) Name() string { return "alloc fs" }
 
 func (f *AllocFSCommand) Run(args []string) int {
-	var verbose, machine, job, stat, tail, follow bool
+	var verbose, machine, job, stat, tail, follow, archive bool
 	var numLines, numBytes int64
+	var include, exclude []string
 
 	flags := f.Meta.FlagSet(f.Name(), FlagSetClient)
 	flags.Usage = func() { f.Ui.Output(f.Help()) }
@@ -131,6 +151,9 @@ func (f *AllocFSCommand) Run(args []string) int {
 	flags.BoolVar(&tail, "tail", false, "")
 	flags.Int64Var(&numLines, "n", -1, "")
 	flags.Int64Var(&numBytes, "c", -1, "")
+	flags.BoolVar(&archive, "archive", false, "")
+	flags.Var((*flaghelper.StringFlag)(&include), "include", "")
+	flags.Var((*flaghelper.StringFlag)(&exclude), "exclude", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -153,6 +176,17 @@ func (f *AllocFSCommand) Run(args []string) int {
 		return 1
 	}
 
+	if archive && (stat || follow || tail) {
+		f.Ui.Error("The -archive flag cannot be combined with -stat, -f or -tail")
+		f.Ui.Error(commandErrorText(f))
+		return 1
+	}
+	if !archive && (len(include) > 0 || len(exclude) > 0) {
+		f.Ui.Error("The -include and -exclude flags require -archive")
+		f.Ui.Error(commandErrorText(f))
+		return 1
+	}
+
 	path := "/"
 	if len(args) == 2 {
 		path = args[1]
@@ -215,6 +249,22 @@ func (f *AllocFSCommand) Run(args []string) int {
 		return 1
 	}
 
+	// If we want an archive, write it to stdout and exit.
+	if archive {
+		r, err := client.AllocFS().Archive(alloc, path, include, exclude, nil)
+		if err != nil {
+			f.Ui.Error(fmt.Sprintf("Error archiving alloc dir: %s", err))
+			return 1
+		}
+		defer r.Close()
+
+		if _, err := io.Copy(os.Stdout, r); err != nil {
+			f.Ui.Error(fmt.Sprintf("Error archiving alloc dir: %s", err))
+			return 1
+		}
+		return 0
+	}
+
 	// Get file stat info
 	file, _, err := client.AllocFS().Stat(alloc, path, nil)
 	if err != nil {
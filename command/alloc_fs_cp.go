@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type AllocFSCopyCommand struct {
+	Meta
+}
+
+func (f *AllocFSCopyCommand) Help() string {
+	helpText := `
+Usage: nomad alloc fs cp [options] <source> <destination>
+
+  Copy a file between the local filesystem and an allocation directory. One
+  of the source or the destination must be an allocation path, in the form
+  <allocation>:<path>, where the path is relative to the root of the alloc dir.
+  A local path of "-" reads the file from stdin or writes it to stdout.
+
+  Copying into an allocation replaces the file if it exists, and its parent
+  directory must exist. If the destination is a directory, the file is copied
+  into it with the name of the source. Files cannot be copied into the secrets
+  directory of a task.
+
+  When ACLs are enabled, this command requires a token with the 'write-fs'
+  capability to copy into an allocation, or the 'read-fs' capability to copy
+  from it, and the 'read-job' and 'list-jobs' capabilities for the
+  allocation's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Copy Specific Options:
+
+  -verbose
+    Show full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (f *AllocFSCopyCommand) Synopsis() string {
+	return "Copy files to or from an allocation directory"
+}
+
+func (f *AllocFSCopyCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(f.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (f *AllocFSCopyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := f.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Allocs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Allocs]
+	})
+}
+
+func (f *AllocFSCopyCommand) Name() string { return "alloc fs cp" }
+
+func (f *AllocFSCopyCommand) Run(args []string) int {
+	var verbose bool
+
+	flags := f.Meta.FlagSet(f.Name(), FlagSetClient)
+	flags.Usage = func() { f.Ui.Output(f.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	args = flags.Args()
+
+	if len(args) != 2 {
+		f.Ui.Error("This command takes two arguments: <source> <destination>")
+		f.Ui.Error(commandErrorText(f))
+		return 1
+	}
+
+	srcAlloc, srcPath, srcRemote := parseAllocPath(args[0])
+	dstAlloc, dstPath, dstRemote := parseAllocPath(args[1])
+	if srcRemote == dstRemote {
+		f.Ui.Error("Exactly one of the source or the destination must be an allocation path: <allocation>:<path>")
+		f.Ui.Error(commandErrorText(f))
+		return 1
+	}
+
+	client, err := f.Meta.Client()
+	if err != nil {
+		f.Ui.Error(fmt.Sprintf("Error initializing client: %v", err))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	allocID := srcAlloc
+	if dstRemote {
+		allocID = dstAlloc
+	}
+	alloc, err := f.lookupAlloc(client, allocID, length)
+	if err != nil {
+		f.Ui.Error(err.Error())
+		return 1
+	}
+
+	if srcRemote {
+		err = f.download(client, alloc, srcPath, dstPath)
+	} else {
+		err = f.upload(client, alloc, srcPath, dstPath)
+	}
+	if err != nil {
+		f.Ui.Error(err.Error())
+		return 1
+	}
+	return 0
+}
+
+// parseAllocPath splits an argument of the form <allocation>:<path> and
+// returns whether it is an allocation path. Allocation IDs have at least two
+// characters so Windows drive letters are treated as local paths.
+func parseAllocPath(arg string) (string, string, bool) {
+	allocID, path, ok := strings.Cut(arg, ":")
+	if !ok || len(allocID) < 2 || strings.ContainsAny(allocID, `/\`) {
+		return "", arg, false
+	}
+	if path == "" {
+		path = "/"
+	}
+	return allocID, path, true
+}
+
+// lookupAlloc returns the allocation matching the ID prefix.
+func (f *AllocFSCopyCommand) lookupAlloc(client *api.Client, allocID string, length int) (*api.Allocation, error) {
+	allocID = sanitizeUUIDPrefix(allocID)
+	allocs, _, err := client.Allocations().PrefixList(allocID)
+	if err != nil {
+		return nil, fmt.Errorf("Error querying allocation: %v", err)
+	}
+	if len(allocs) == 0 {
+		return nil, fmt.Errorf("No allocation(s) with prefix or id %q found", allocID)
+	}
+	if len(allocs) > 1 {
+		out := formatAllocListStubs(allocs, false, length)
+		return nil, fmt.Errorf("Prefix matched multiple allocations\n\n%s", out)
+	}
+
+	q := &api.QueryOptions{Namespace: allocs[0].Namespace}
+	alloc, _, err := client.Allocations().Info(allocs[0].ID, q)
+	if err != nil {
+		return nil, fmt.Errorf("Error querying allocation: %s", err)
+	}
+	return alloc, nil
+}
+
+// download copies the file at src in the allocation directory to the local
+// path dst.
+func (f *AllocFSCopyCommand) download(client *api.Client, alloc *api.Allocation, src, dst string) error {
+	file, _, err := client.AllocFS().Stat(alloc, src, nil)
+	if err != nil {
+		return fmt.Errorf("Error reading file: %v", err)
+	}
+	if file.IsDir {
+		return fmt.Errorf("Copying directories is not supported: %s", src)
+	}
+
+	r, err := client.AllocFS().Cat(alloc, src, nil)
+	if err != nil {
+		return fmt.Errorf("Error reading file: %v", err)
+	}
+	defer r.Close()
+
+	if dst == "-" {
+		if _, err := io.Copy(os.Stdout, r); err != nil {
+			return fmt.Errorf("Error reading file: %v", err)
+		}
+		return nil
+	}
+
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		dst = filepath.Join(dst, path.Base(src))
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("Error creating file: %v", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, r); err != nil {
+		return fmt.Errorf("Error reading file: %v", err)
+	}
+	return out.Close()
+}
+
+// upload copies the local file at src to the path dst in the allocation
+// directory, keeping its permissions.
+func (f *AllocFSCopyCommand) upload(client *api.Client, alloc *api.Allocation, src, dst string) error {
+	var r io.Reader = os.Stdin
+	var mode os.FileMode
+	if src != "-" {
+		info, err := os.Stat(src)
+		if err != nil {
+			return fmt.Errorf("Error reading file: %v", err)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("Copying directories is not supported: %s", src)
+		}
+		mode = info.Mode().Perm()
+
+		in, err := os.Open(src)
+		if err != nil {
+			return fmt.Errorf("Error reading file: %v", err)
+		}
+		defer in.Close()
+		r = in
+	}
+
+	// Copy into the destination if it is a directory
+	if file, _, err := client.AllocFS().Stat(alloc, dst, nil); err == nil && file.IsDir {
+		if src == "-" {
+			return fmt.Errorf("Destination is a directory: %s", dst)
+		}
+		dst = path.Join(dst, filepath.Base(src))
+	}
+
+	q := &api.WriteOptions{Namespace: alloc.Namespace}
+	if _, err := client.AllocFS().Upload(alloc, dst, r, mode, q); err != nil {
+		return fmt.Errorf("Error uploading file: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestFSCopyCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &AllocFSCopyCommand{}
+}
+
+func TestFSCopyCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &AllocFSCopyCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails when both paths are local
+	code = cmd.Run([]string{"foo", "bar"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Exactly one of the source or the destination")
+	ui.ErrorWriter.Reset()
+
+	// Fails when both paths are in allocations
+	code = cmd.Run([]string{"foobar:foo", "foobar:bar"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Exactly one of the source or the destination")
+	ui.ErrorWriter.Reset()
+
+	// Fails on missing alloc
+	code = cmd.Run([]string{"-address=" + url, "26470238-5CF2-438F-8772-DC67CFB0705C:alloc/foo", "-"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No allocation(s) with prefix or id")
+}
+
+func TestFSCopyCommand_parseAllocPath(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		arg     string
+		allocID string
+		path    string
+		remote  bool
+	}{
+		{arg: "26470238:alloc/data/app.conf", allocID: "26470238", path: "alloc/data/app.conf", remote: true},
+		{arg: "26470238:", allocID: "26470238", path: "/", remote: true},
+		{arg: "./app.conf", path: "./app.conf"},
+		{arg: "-", path: "-"},
+		{arg: `C:\app.conf`, path: `C:\app.conf`},
+		{arg: "./dir:with/colon", path: "./dir:with/colon"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.arg, func(t *testing.T) {
+			allocID, path, remote := parseAllocPath(tc.arg)
+			must.Eq(t, tc.allocID, allocID)
+			must.Eq(t, tc.path, path)
+			must.Eq(t, tc.remote, remote)
+		})
+	}
+}
@@ -87,6 +87,24 @@ func TestFSCommand_Fails(t *testing.T) {
 
 	out = ui.ErrorWriter.String()
 	must.StrContains(t, out, "No allocation(s) with prefix or id")
+
+	ui.ErrorWriter.Reset()
+
+	// Fails on archives combined with streaming
+	code = cmd.Run([]string{"-address=" + url, "-archive", "-f", "foobar"})
+	must.One(t, code)
+
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "cannot be combined")
+
+	ui.ErrorWriter.Reset()
+
+	// Fails on globs without an archive
+	code = cmd.Run([]string{"-address=" + url, "-include", "*.log", "foobar"})
+	must.One(t, code)
+
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "require -archive")
 }
 
 func TestFSCommand_AutocompleteArgs(t *testing.T) {
@@ -254,6 +254,15 @@ func formatAllocShortInfo(alloc *api.Allocation, client *api.Client) string {
 	return formatKV(basic)
 }
 
+// allocClientStatus returns the client status of the allocation, noting if it
+// failed because the client evicted it.
+func allocClientStatus(alloc *api.Allocation) string {
+	if alloc.Evicted {
+		return alloc.ClientStatus + " (evicted)"
+	}
+	return alloc.ClientStatus
+}
+
 func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength int, verbose bool) (string, error) {
 	var formattedCreateTime, formattedModifyTime string
 
@@ -273,7 +282,7 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 		fmt.Sprintf("Node Name|%s", alloc.NodeName),
 		fmt.Sprintf("Job ID|%s", alloc.JobID),
 		fmt.Sprintf("Job Version|%d", *alloc.Job.Version),
-		fmt.Sprintf("Client Status|%s", alloc.ClientStatus),
+		fmt.Sprintf("Client Status|%s", allocClientStatus(alloc)),
 		fmt.Sprintf("Client Description|%s", alloc.ClientDescription),
 		fmt.Sprintf("Desired Status|%s", alloc.DesiredStatus),
 		fmt.Sprintf("Desired Description|%s", alloc.DesiredDescription),
@@ -568,6 +577,18 @@ func buildDisplayMessage(event *api.TaskEvent) string {
 		desc = "Leader Task in Group dead"
 	case api.TaskClientReconnected:
 		desc = "Client reconnected"
+	case api.TaskMaxRunDurationExceeded:
+		if event.KillReason != "" {
+			desc = event.KillReason
+		} else {
+			desc = "Task exceeded its max run duration"
+		}
+	case api.TaskEvicted:
+		if event.KillReason != "" {
+			desc = event.KillReason
+		} else {
+			desc = "Task evicted by the client"
+		}
 	default:
 		desc = event.Message
 	}
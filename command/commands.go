@@ -270,6 +270,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"alloc fs cp": func() (cli.Command, error) {
+			return &AllocFSCopyCommand{
+				Meta: meta,
+			}, nil
+		},
 		"alloc logs": func() (cli.Command, error) {
 			return &AllocLogsCommand{
 				Meta: meta,
@@ -626,6 +631,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"node taint": func() (cli.Command, error) {
+			return &NodeTaintCommand{
+				Meta: meta,
+			}, nil
+		},
 		"node status": func() (cli.Command, error) {
 			return &NodeStatusCommand{
 				Meta: meta,
@@ -799,11 +809,21 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator scheduler rebalance-candidates": func() (cli.Command, error) {
+			return &OperatorSchedulerRebalanceCandidates{
+				Meta: meta,
+			}, nil
+		},
 		"operator scheduler set-config": func() (cli.Command, error) {
 			return &OperatorSchedulerSetConfig{
 				Meta: meta,
 			}, nil
 		},
+		"operator scheduler simulate": func() (cli.Command, error) {
+			return &OperatorSchedulerSimulateCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator root keyring": func() (cli.Command, error) {
 			return &OperatorRootKeyringCommand{
 				Meta: meta,
@@ -226,6 +226,21 @@ func (c *EvalStatusCommand) Run(args []string) int {
 	}
 	c.Ui.Output(formatKV(basic))
 
+	if len(eval.BlockedDisruptions) > 0 {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Blocked Disruptions[reset]"))
+		keys := make([]string, 0, len(eval.BlockedDisruptions))
+		for key := range eval.BlockedDisruptions {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		blocked := []string{"Job/Task Group|Blocked"}
+		for _, key := range keys {
+			blocked = append(blocked, fmt.Sprintf("%s|%d", key, eval.BlockedDisruptions[key]))
+		}
+		c.Ui.Output(formatList(blocked))
+	}
+
 	if failures {
 		c.Ui.Output(c.Colorize().Color("\n[bold]Failed Placements[reset]"))
 		sorted := sortedTaskGroupFromMetrics(eval.FailedTGAllocs)
@@ -5,6 +5,7 @@ package command
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -15,6 +16,7 @@ import (
 type JobEvalCommand struct {
 	Meta
 	forceRescheduling bool
+	arrayIndices      string
 }
 
 func (c *JobEvalCommand) Help() string {
@@ -42,6 +44,12 @@ Eval Options:
     Force reschedule failed allocations even if they are not currently
     eligible for rescheduling.
 
+  -array-indices
+    Comma separated list of indices or ranges of indices, such as "3,7,10-12",
+    of array task groups to retry. Only the failed allocations at these indices
+    are rescheduled, even if their reschedule policy is disabled or exhausted.
+    Implies -force-reschedule.
+
   -detach
     Return immediately instead of entering monitor mode. The ID
     of the evaluation created will be printed to the screen, which can be
@@ -61,6 +69,7 @@ func (c *JobEvalCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-force-reschedule": complete.PredictNothing,
+			"-array-indices":    complete.PredictAnything,
 			"-detach":           complete.PredictNothing,
 			"-verbose":          complete.PredictNothing,
 		})
@@ -89,6 +98,7 @@ func (c *JobEvalCommand) Run(args []string) int {
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&c.forceRescheduling, "force-reschedule", false, "")
+	flags.StringVar(&c.arrayIndices, "array-indices", "", "")
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 
@@ -104,6 +114,17 @@ func (c *JobEvalCommand) Run(args []string) int {
 		return 1
 	}
 
+	var arrayIndices []int
+	if c.arrayIndices != "" {
+		var err error
+		arrayIndices, err = parseArrayIndices(c.arrayIndices)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Invalid -array-indices: %s", err))
+			return 1
+		}
+		c.forceRescheduling = true
+	}
+
 	// Get the HTTP client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -128,6 +149,7 @@ func (c *JobEvalCommand) Run(args []string) int {
 	// Call eval endpoint
 	opts := api.EvalOptions{
 		ForceReschedule: c.forceRescheduling,
+		ArrayIndices:    arrayIndices,
 	}
 	w := &api.WriteOptions{
 		Namespace: namespace,
@@ -146,3 +168,30 @@ func (c *JobEvalCommand) Run(args []string) int {
 	mon := newMonitor(c.Ui, client, length)
 	return mon.monitor(evalId)
 }
+
+// parseArrayIndices parses a comma separated list of array indices and
+// inclusive ranges of indices, such as "3,7,10-12".
+func parseArrayIndices(s string) ([]int, error) {
+	var indices []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		start, end, isRange := strings.Cut(part, "-")
+
+		first, err := strconv.Atoi(start)
+		if err != nil || first < 0 {
+			return nil, fmt.Errorf("invalid index %q", part)
+		}
+		last := first
+		if isRange {
+			last, err = strconv.Atoi(end)
+			if err != nil || last < first {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+		}
+
+		for i := first; i <= last; i++ {
+			indices = append(indices, i)
+		}
+	}
+	return indices, nil
+}
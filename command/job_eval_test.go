@@ -245,3 +245,16 @@ namespace "default" {
 		})
 	}
 }
+
+func TestJobEvalCommand_parseArrayIndices(t *testing.T) {
+	ci.Parallel(t)
+
+	indices, err := parseArrayIndices("3, 7,10-12")
+	must.NoError(t, err)
+	must.Eq(t, []int{3, 7, 10, 11, 12}, indices)
+
+	for _, input := range []string{"", "a", "-1", "5-2", "1-b"} {
+		_, err := parseArrayIndices(input)
+		must.Error(t, err, must.Sprintf("input %q", input))
+	}
+}
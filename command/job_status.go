@@ -5,7 +5,9 @@ package command
 
 import (
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -231,6 +233,11 @@ func (c *JobStatusCommand) Run(args []string) int {
 		return 0
 	}
 
+	status := getStatusString(*job.Status, job.Stop)
+	if job.CompletionFailed {
+		status = fmt.Sprintf("%s (failed)", *job.Status)
+	}
+
 	// Format the job info
 	basic := []string{
 		fmt.Sprintf("ID|%s", *job.ID),
@@ -241,11 +248,15 @@ func (c *JobStatusCommand) Run(args []string) int {
 		fmt.Sprintf("Datacenters|%s", strings.Join(job.Datacenters, ",")),
 		fmt.Sprintf("Namespace|%s", *job.Namespace),
 		fmt.Sprintf("Node Pool|%s", nodePool),
-		fmt.Sprintf("Status|%s", getStatusString(*job.Status, job.Stop)),
+		fmt.Sprintf("Status|%s", status),
 		fmt.Sprintf("Periodic|%v", periodic),
 		fmt.Sprintf("Parameterized|%v", parameterized),
 	}
 
+	if job.StatusDescription != nil && *job.StatusDescription != "" {
+		basic = append(basic, fmt.Sprintf("Status Description|%s", *job.StatusDescription))
+	}
+
 	if job.DispatchIdempotencyToken != nil && *job.DispatchIdempotencyToken != "" {
 		basic = append(basic, fmt.Sprintf("Idempotency Token|%v", *job.DispatchIdempotencyToken))
 	}
@@ -414,6 +425,7 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 	// Determine latest evaluation with failures whose follow up hasn't
 	// completed, this is done while formatting
 	var latestFailedPlacement *api.Evaluation
+	var pendingDependencies []string
 	blockedEval := false
 
 	// Format the evals
@@ -431,6 +443,7 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 
 		if eval.Status == "blocked" {
 			blockedEval = true
+			pendingDependencies = append(pendingDependencies, eval.PendingDependencies...)
 		}
 
 		if len(eval.FailedTGAllocs) == 0 {
@@ -448,6 +461,11 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 		c.Ui.Output(formatList(evals))
 	}
 
+	if len(job.DependsOn) > 0 {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Dependencies[reset]"))
+		c.Ui.Output(c.formatDependencies(client, job, pendingDependencies))
+	}
+
 	if blockedEval && latestFailedPlacement != nil {
 		c.outputFailedPlacements(latestFailedPlacement)
 	}
@@ -465,6 +483,46 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 	return nil
 }
 
+// formatDependencies returns the dependency graph of the job as a tree, with
+// the condition and status of each job depended on. Direct dependencies the
+// job is blocked on are marked as pending.
+func (c *JobStatusCommand) formatDependencies(client *api.Client, job *api.Job, pending []string) string {
+	q := &api.QueryOptions{Namespace: *job.Namespace}
+
+	var lines []string
+	var walk func(deps []*api.JobDependency, path []string)
+	walk = func(deps []*api.JobDependency, path []string) {
+		indent := strings.Repeat("  ", len(path)-1)
+		for _, dep := range deps {
+			line := fmt.Sprintf("%s%s (%s)", indent, dep.JobID, dep.Condition)
+			if len(path) == 1 && slices.Contains(pending, dep.JobID) {
+				line += " [pending]"
+			}
+
+			if slices.Contains(path, dep.JobID) {
+				lines = append(lines, line+": dependency cycle")
+				continue
+			}
+
+			depJob, _, err := client.Jobs().Info(dep.JobID, q)
+			if err != nil {
+				if strings.Contains(err.Error(), "404") {
+					lines = append(lines, line+": not found")
+				} else {
+					lines = append(lines, fmt.Sprintf("%s: error querying job: %s", line, err))
+				}
+				continue
+			}
+
+			lines = append(lines, fmt.Sprintf("%s: %s", line, getStatusString(*depJob.Status, depJob.Stop)))
+			walk(depJob.DependsOn, append(slices.Clone(path), dep.JobID))
+		}
+	}
+	walk(job.DependsOn, []string{*job.ID})
+
+	return strings.Join(lines, "\n")
+}
+
 func (c *JobStatusCommand) formatDeployment(client *api.Client, d *api.Deployment) string {
 	// Format the high-level elements
 	high := []string{
@@ -612,6 +670,24 @@ func (c *JobStatusCommand) outputJobSummary(client *api.Client, job *api.Job) er
 			)
 		}
 		c.Ui.Output(formatList(summaries))
+
+		// Print the progress of array task groups
+		var arrays []string
+		for _, tg := range job.TaskGroups {
+			if tg.Array == nil {
+				continue
+			}
+			tgs := summary.Summary[*tg.Name]
+			arrays = append(arrays, fmt.Sprintf("%s|%d-%d|%d|%d|%d|%s",
+				*tg.Name, tg.Array.Start, tg.Array.End, tg.Array.MaxParallel,
+				countArrayIndices(tgs.ArraySucceeded), countArrayIndices(tgs.ArrayFailed),
+				formatArrayIndices(tgs.ArrayFailed),
+			))
+		}
+		if len(arrays) > 0 {
+			c.Ui.Output(c.Colorize().Color("\n[bold]Array Summary[reset]"))
+			c.Ui.Output(formatList(append([]string{"Task Group|Indices|Max Parallel|Succeeded|Failed|Failed Indices"}, arrays...)))
+		}
 	}
 
 	// Always display the summary if we are periodic or parameterized, but
@@ -632,6 +708,32 @@ func (c *JobStatusCommand) outputJobSummary(client *api.Client, job *api.Job) er
 	return nil
 }
 
+// countArrayIndices returns the number of indices in the ranges.
+func countArrayIndices(ranges []api.ArrayIndexRange) int {
+	n := 0
+	for _, r := range ranges {
+		n += r.End - r.Start + 1
+	}
+	return n
+}
+
+// formatArrayIndices formats ranges of array indices as a comma separated
+// list, such as "0-4,7,9-12".
+func formatArrayIndices(ranges []api.ArrayIndexRange) string {
+	if len(ranges) == 0 {
+		return "<none>"
+	}
+	parts := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Start == r.End {
+			parts = append(parts, strconv.Itoa(r.Start))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", r.Start, r.End))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
 // outputReschedulingEvals displays eval IDs and time for any
 // delayed evaluations by task group
 func (c *JobStatusCommand) outputReschedulingEvals(client *api.Client, job *api.Job, allocListStubs []*api.AllocationListStub, uuidLength int) error {
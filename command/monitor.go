@@ -359,6 +359,11 @@ func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix strin
 		out += fmt.Sprintf("%s* Quota limit hit %q\n", prefix, dim)
 	}
 
+	// Print disruption budget info
+	for group, num := range metrics.DisruptionBudgetExhausted {
+		out += fmt.Sprintf("%s* Disruption budget of %q prevented %d preemptions\n", prefix, group, num)
+	}
+
 	// Print scores
 	if scores {
 		if len(metrics.ScoreMetaData) > 0 {
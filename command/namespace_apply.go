@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/hcl"
@@ -50,6 +51,10 @@ Apply Options:
   -description
     An optional description for the namespace.
 
+  -eval-queue-weight
+    The relative share of the evaluation broker given to the namespace. Must
+    be between 0 and 1000, and 0 uses the default weight of 1.
+
   -json
     Parse the input as a JSON namespace specification.
 `
@@ -59,9 +64,10 @@ Apply Options:
 func (c *NamespaceApplyCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-description": complete.PredictAnything,
-			"-quota":       QuotaPredictor(c.Meta.Client),
-			"-json":        complete.PredictNothing,
+			"-description":       complete.PredictAnything,
+			"-eval-queue-weight": complete.PredictAnything,
+			"-quota":             QuotaPredictor(c.Meta.Client),
+			"-json":              complete.PredictNothing,
 		})
 }
 
@@ -82,6 +88,7 @@ func (c *NamespaceApplyCommand) Name() string { return "namespace apply" }
 func (c *NamespaceApplyCommand) Run(args []string) int {
 	var jsonInput bool
 	var description, quota *string
+	var evalQueueWeight *int
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -93,6 +100,14 @@ func (c *NamespaceApplyCommand) Run(args []string) int {
 		quota = &s
 		return nil
 	}), "quota", "")
+	flags.Var((flaghelper.FuncVar)(func(s string) error {
+		weight, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("invalid eval queue weight %q: %v", s, err)
+		}
+		evalQueueWeight = &weight
+		return nil
+	}), "eval-queue-weight", "")
 	flags.BoolVar(&jsonInput, "json", false, "")
 
 	if err := flags.Parse(args); err != nil {
@@ -120,7 +135,7 @@ func (c *NamespaceApplyCommand) Run(args []string) int {
 	}
 
 	if fi, err := os.Stat(file); (file == "-" || err == nil) && !fi.IsDir() {
-		if quota != nil || description != nil {
+		if quota != nil || description != nil || evalQueueWeight != nil {
 			c.Ui.Warn("Flags are ignored when a file is specified!")
 		}
 
@@ -183,6 +198,9 @@ func (c *NamespaceApplyCommand) Run(args []string) int {
 		if quota != nil {
 			namespace.Quota = *quota
 		}
+		if evalQueueWeight != nil {
+			namespace.EvalQueueWeight = *evalQueueWeight
+		}
 	}
 	_, err = client.Namespaces().Register(namespace, nil)
 	if err != nil {
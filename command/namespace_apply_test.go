@@ -62,6 +62,38 @@ func TestNamespaceApplyCommand_Good(t *testing.T) {
 	must.SliceLen(t, 2, namespaces)
 }
 
+func TestNamespaceApplyCommand_EvalQueueWeight(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &NamespaceApplyCommand{Meta: Meta{Ui: ui}}
+
+	// Create a namespace with an eval queue weight
+	code := cmd.Run([]string{"-address=" + url, "-eval-queue-weight=3", "foo"})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+
+	ns, _, err := client.Namespaces().Info("foo", nil)
+	must.NoError(t, err)
+	must.Eq(t, 3, ns.EvalQueueWeight)
+
+	// Ensure updating another field keeps the weight
+	code = cmd.Run([]string{"-address=" + url, "-description=bar", "foo"})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+
+	ns, _, err = client.Namespaces().Info("foo", nil)
+	must.NoError(t, err)
+	must.Eq(t, "bar", ns.Description)
+	must.Eq(t, 3, ns.EvalQueueWeight)
+
+	// Ensure an invalid weight is rejected
+	code = cmd.Run([]string{"-address=" + url, "-eval-queue-weight=many", "foo"})
+	must.One(t, code)
+}
+
 func TestNamespaceApplyCommand_parseNamesapceSpec(t *testing.T) {
 	ci.Parallel(t)
 
@@ -77,6 +109,8 @@ name        = "test-namespace"
 description = "Test namespace"
 quota       = "test"
 
+eval_queue_weight = 3
+
 capabilities {
   enabled_task_drivers  = ["exec", "docker"]
   disabled_task_drivers = ["raw_exec"]
@@ -101,9 +135,10 @@ meta {
   dept = "eng"
 }`,
 			expected: &api.Namespace{
-				Name:        "test-namespace",
-				Description: "Test namespace",
-				Quota:       "test",
+				Name:            "test-namespace",
+				Description:     "Test namespace",
+				Quota:           "test",
+				EvalQueueWeight: 3,
 				Capabilities: &api.NamespaceCapabilities{
 					EnabledTaskDrivers:  []string{"exec", "docker"},
 					DisabledTaskDrivers: []string{"raw_exec"},
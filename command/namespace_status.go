@@ -6,6 +6,7 @@ package command
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -209,10 +210,15 @@ func formatNamespaceBasics(ns *api.Namespace) string {
 			disabled_drivers = strings.Join(ns.Capabilities.DisabledTaskDrivers, ",")
 		}
 	}
+	evalQueueWeight := "1"
+	if ns.EvalQueueWeight > 0 {
+		evalQueueWeight = strconv.Itoa(ns.EvalQueueWeight)
+	}
 	basic := []string{
 		fmt.Sprintf("Name|%s", ns.Name),
 		fmt.Sprintf("Description|%s", ns.Description),
 		fmt.Sprintf("Quota|%s", ns.Quota),
+		fmt.Sprintf("EvalQueueWeight|%s", evalQueueWeight),
 		fmt.Sprintf("EnabledDrivers|%s", enabled_drivers),
 		fmt.Sprintf("DisabledDrivers|%s", disabled_drivers),
 	}
@@ -139,6 +139,12 @@ func (c *NodePoolInfoCommand) Run(args []string) int {
 				fmt.Sprintf("Memory Oversubscription Enabled|%v", *schedConfig.MemoryOversubscriptionEnabled),
 			)
 		}
+		if loadAware := schedConfig.LoadAwareConfig; loadAware != nil {
+			schedConfigOut = append(schedConfigOut,
+				fmt.Sprintf("Load Aware Scoring|%v", loadAware.Enabled),
+				fmt.Sprintf("Load Aware Utilization Threshold|%v", loadAware.UtilizationThreshold),
+			)
+		}
 		c.Ui.Output(formatKV(schedConfigOut))
 	} else {
 		c.Ui.Output("No scheduler configuration")
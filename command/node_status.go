@@ -442,6 +442,22 @@ func nodeNetworkNames(n *api.Node) []string {
 	return networks
 }
 
+// formatNodePressure returns the resources of a node under pressure, or the
+// empty string if the node is not under pressure.
+func formatNodePressure(p *api.NodePressure) string {
+	if p == nil {
+		return ""
+	}
+	var resources []string
+	if p.Memory {
+		resources = append(resources, "memory")
+	}
+	if p.Disk {
+		resources = append(resources, "disk")
+	}
+	return strings.Join(resources, ",")
+}
+
 func formatDrain(n *api.Node) string {
 	if n.DrainStrategy != nil {
 		b := new(strings.Builder)
@@ -492,6 +508,10 @@ func (c *NodeStatusCommand) formatNode(client *api.Client, node *api.Node) int {
 		fmt.Sprintf("CSI Drivers|%s", strings.Join(nodeCSINodeNames(node), ",")),
 	}
 
+	if pressure := formatNodePressure(node.Pressure); pressure != "" {
+		basic = append(basic, fmt.Sprintf("Pressure|%s", pressure))
+	}
+
 	if c.short {
 		basic = append(basic, fmt.Sprintf("Host Volumes|%s", strings.Join(nodeVolumeNames(node), ",")))
 		basic = append(basic, fmt.Sprintf("Host Networks|%s", strings.Join(nodeNetworkNames(node), ",")))
@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/posener/complete"
+)
+
+const (
+	// nodeTaintsMaxAttempts is the number of times the taints of a node are
+	// updated when they are modified concurrently.
+	nodeTaintsMaxAttempts = 5
+
+	// nodeTaintsEnforceIndexErr is the error returned when the taints of a
+	// node were modified concurrently.
+	nodeTaintsEnforceIndexErr = "Enforcing node modify index"
+)
+
+type NodeTaintCommand struct {
+	Meta
+}
+
+func (c *NodeTaintCommand) Help() string {
+	helpText := `
+Usage: nomad node taint [options] <node> [<key>[=<value>]:<effect>...]
+
+  Adds, replaces, or removes taints on a node. A taint prevents allocations of
+  task groups that do not tolerate it from being placed on the node. The
+  effect of a taint is one of:
+
+    no_schedule          New allocations are not placed on the node.
+    prefer_no_schedule   The scheduler avoids placing new allocations on the
+                         node, but may still do so.
+    no_execute           New allocations are not placed on the node, and
+                         existing allocations are migrated off of it.
+
+  A taint replaces any existing taint with the same key and effect. If no
+  taints or removals are given, the taints of the node are listed.
+
+  The -self flag is useful to set the taints of the local node.
+
+  If ACLs are enabled, this option requires a token with the 'node:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Node Taint Options:
+
+  -clear
+    Remove all existing taints from the node before applying the given taints.
+
+  -remove <key>[:<effect>]
+    Remove the taints with the given key, and effect if set. May be specified
+    multiple times.
+
+  -self
+    Set the taints of the local node.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeTaintCommand) Synopsis() string {
+	return "Add or remove taints on a given node"
+}
+
+func (c *NodeTaintCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-clear":  complete.PredictNothing,
+			"-remove": complete.PredictAnything,
+			"-self":   complete.PredictNothing,
+		})
+}
+
+func (c *NodeTaintCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Nodes, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Nodes]
+	})
+}
+
+func (c *NodeTaintCommand) Name() string { return "node taint" }
+
+func (c *NodeTaintCommand) Run(args []string) int {
+	var clearAll, self bool
+	var remove flaghelper.StringFlag
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&clearAll, "clear", false, "")
+	flags.Var(&remove, "remove", "")
+	flags.BoolVar(&self, "self", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got a node ID
+	args = flags.Args()
+	if !self && len(args) == 0 {
+		c.Ui.Error("Node ID must be specified if -self isn't being used")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	var nodeID string
+	if !self {
+		nodeID, args = args[0], args[1:]
+	}
+
+	// Parse the taints before contacting the cluster
+	taints := make([]*api.NodeTaint, 0, len(args))
+	for _, arg := range args {
+		taint, err := parseNodeTaint(arg)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+		taints = append(taints, taint)
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// If -self flag is set then determine the current node.
+	if self {
+		if nodeID, err = getLocalNodeID(client); err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
+	// Check if node exists
+	if len(nodeID) == 1 {
+		c.Ui.Error("Identifier must contain at least two characters.")
+		return 1
+	}
+
+	nodeID = sanitizeUUIDPrefix(nodeID)
+	nodes, _, err := client.Nodes().PrefixList(nodeID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error updating node taints: %s", err))
+		return 1
+	}
+	// Return error if no nodes are found
+	if len(nodes) == 0 {
+		c.Ui.Error(fmt.Sprintf("No node(s) with prefix or id %q found", nodeID))
+		return 1
+	}
+	if len(nodes) > 1 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple nodes\n\n%s",
+			formatNodeStubList(nodes, true)))
+		return 1
+	}
+
+	// List the taints if there is nothing to change
+	if !clearAll && len(remove) == 0 && len(taints) == 0 {
+		node, _, err := client.Nodes().Info(nodes[0].ID, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading node taints: %s", err))
+			return 1
+		}
+		c.Ui.Output(formatNodeTaints(node.Taints))
+		return 0
+	}
+
+	// Update the taints of the node as of its modify index, and retry with
+	// the latest taints if they were modified concurrently
+	var node *api.Node
+	for attempt := 1; ; attempt++ {
+		node, _, err = client.Nodes().Info(nodes[0].ID, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error updating node taints: %s", err))
+			return 1
+		}
+
+		updated := updateNodeTaints(node.Taints, taints, remove, clearAll)
+		_, err = client.Nodes().UpdateTaints(node.ID, updated, node.ModifyIndex, nil)
+		if err == nil {
+			break
+		}
+		if attempt == nodeTaintsMaxAttempts || !strings.Contains(err.Error(), nodeTaintsEnforceIndexErr) {
+			c.Ui.Error(fmt.Sprintf("Error updating node taints: %s", err))
+			return 1
+		}
+	}
+
+	c.Ui.Output(fmt.Sprintf("Node %q taints updated", node.ID))
+	return 0
+}
+
+// updateNodeTaints returns the existing taints of a node after removing the
+// taints matching the <key>[:<effect>] removals, or all of them if clearAll is
+// set, and adding or replacing the given taints.
+func updateNodeTaints(existing, taints []*api.NodeTaint, remove []string, clearAll bool) []*api.NodeTaint {
+	var updated []*api.NodeTaint
+	if !clearAll {
+		updated = slices.DeleteFunc(slices.Clone(existing), func(existing *api.NodeTaint) bool {
+			for _, r := range remove {
+				key, effect, _ := strings.Cut(r, ":")
+				if existing.Key == key && (effect == "" || existing.Effect == effect) {
+					return true
+				}
+			}
+			return slices.ContainsFunc(taints, func(t *api.NodeTaint) bool {
+				return existing.Key == t.Key && existing.Effect == t.Effect
+			})
+		})
+	}
+	return append(updated, taints...)
+}
+
+// parseNodeTaint parses a taint in the <key>[=<value>]:<effect> format.
+func parseNodeTaint(s string) (*api.NodeTaint, error) {
+	i := strings.LastIndex(s, ":")
+	if i == -1 {
+		return nil, fmt.Errorf("Invalid taint %q: expected <key>[=<value>]:<effect>", s)
+	}
+
+	key, value, _ := strings.Cut(s[:i], "=")
+	if key == "" {
+		return nil, fmt.Errorf("Invalid taint %q: missing key", s)
+	}
+
+	switch effect := s[i+1:]; effect {
+	case api.NodeTaintEffectNoSchedule, api.NodeTaintEffectPreferNoSchedule, api.NodeTaintEffectNoExecute:
+		return &api.NodeTaint{Key: key, Value: value, Effect: effect}, nil
+	default:
+		return nil, fmt.Errorf("Invalid taint %q: unknown effect %q", s, effect)
+	}
+}
+
+// formatNodeTaints returns a list of the taints of a node.
+func formatNodeTaints(taints []*api.NodeTaint) string {
+	if len(taints) == 0 {
+		return "No taints found"
+	}
+
+	out := make([]string, 0, len(taints)+1)
+	out = append(out, "Key|Value|Effect")
+	for _, taint := range taints {
+		out = append(out, fmt.Sprintf("%s|%s|%s", taint.Key, taint.Value, taint.Effect))
+	}
+	return formatList(out)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodeTaintCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &NodeTaintCommand{}
+}
+
+func TestNodeTaintCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &NodeTaintCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	must.One(t, cmd.Run([]string{}))
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid taints
+	must.One(t, cmd.Run([]string{"12345678-abcd-efab-cdef-123456789abc", "gpu"}))
+	must.StrContains(t, ui.ErrorWriter.String(), `Invalid taint "gpu"`)
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	must.One(t, cmd.Run([]string{"-address=nope", "12345678-abcd-efab-cdef-123456789abc"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Error updating node taints")
+}
+
+func TestNodeTaintCommand_parseNodeTaint(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		input    string
+		expected *api.NodeTaint
+		err      string
+	}{
+		{
+			input:    "dedicated=gpu:no_schedule",
+			expected: &api.NodeTaint{Key: "dedicated", Value: "gpu", Effect: api.NodeTaintEffectNoSchedule},
+		},
+		{
+			input:    "maintenance:no_execute",
+			expected: &api.NodeTaint{Key: "maintenance", Effect: api.NodeTaintEffectNoExecute},
+		},
+		{
+			input: "maintenance",
+			err:   "expected <key>[=<value>]:<effect>",
+		},
+		{
+			input: "=gpu:no_schedule",
+			err:   "missing key",
+		},
+		{
+			input: "spot:NoSchedule",
+			err:   `unknown effect "NoSchedule"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			taint, err := parseNodeTaint(tc.input)
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+			} else {
+				must.NoError(t, err)
+				must.Eq(t, tc.expected, taint)
+			}
+		})
+	}
+}
+
+func TestNodeTaintCommand_updateNodeTaints(t *testing.T) {
+	ci.Parallel(t)
+
+	gpu := &api.NodeTaint{Key: "gpu", Effect: api.NodeTaintEffectNoSchedule}
+	spot := &api.NodeTaint{Key: "spot", Effect: api.NodeTaintEffectPreferNoSchedule}
+	existing := []*api.NodeTaint{gpu, spot}
+
+	// Taints with the same key and effect are replaced
+	dedicated := &api.NodeTaint{Key: "gpu", Value: "dedicated", Effect: api.NodeTaintEffectNoSchedule}
+	must.Eq(t, []*api.NodeTaint{spot, dedicated},
+		updateNodeTaints(existing, []*api.NodeTaint{dedicated}, nil, false))
+
+	// Taints are removed by key, and effect if set
+	must.Eq(t, []*api.NodeTaint{spot},
+		updateNodeTaints(existing, nil, []string{"gpu"}, false))
+	must.Eq(t, existing,
+		updateNodeTaints(existing, nil, []string{"gpu:no_execute"}, false))
+
+	// All taints are cleared
+	must.Eq(t, []*api.NodeTaint{dedicated},
+		updateNodeTaints(existing, []*api.NodeTaint{dedicated}, nil, true))
+
+	// The existing taints are not modified
+	must.Eq(t, []*api.NodeTaint{gpu, spot}, existing)
+}
@@ -82,6 +82,13 @@ func (o *OperatorSchedulerGetConfig) Run(args []string) int {
 		fmt.Sprintf("Memory Oversubscription|%v", schedConfig.MemoryOversubscriptionEnabled),
 		fmt.Sprintf("Reject Job Registration|%v", schedConfig.RejectJobRegistration),
 		fmt.Sprintf("Pause Eval Broker|%v", schedConfig.PauseEvalBroker),
+		fmt.Sprintf("Load Aware Scoring|%v", schedConfig.LoadAwareConfig.Enabled),
+		fmt.Sprintf("Load Aware Utilization Threshold|%v", schedConfig.LoadAwareConfig.UtilizationThreshold),
+		fmt.Sprintf("Rebalance|%v", schedConfig.RebalanceConfig.Enabled),
+		fmt.Sprintf("Rebalance Dry Run|%v", schedConfig.RebalanceConfig.DryRun),
+		fmt.Sprintf("Rebalance Namespaces|%s", strings.Join(schedConfig.RebalanceConfig.Namespaces, ",")),
+		fmt.Sprintf("Rebalance Max Migrations|%v", schedConfig.RebalanceConfig.MaxMigrations),
+		fmt.Sprintf("Rebalance Utilization Threshold|%v", schedConfig.RebalanceConfig.UtilizationThreshold),
 		fmt.Sprintf("Preemption System Scheduler|%v", schedConfig.PreemptionConfig.SystemSchedulerEnabled),
 		fmt.Sprintf("Preemption Service Scheduler|%v", schedConfig.PreemptionConfig.ServiceSchedulerEnabled),
 		fmt.Sprintf("Preemption Batch Scheduler|%v", schedConfig.PreemptionConfig.BatchSchedulerEnabled),
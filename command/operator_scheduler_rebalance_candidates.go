@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorSchedulerRebalanceCandidates satisfies the cli.Command
+// interface.
+var _ cli.Command = &OperatorSchedulerRebalanceCandidates{}
+
+type OperatorSchedulerRebalanceCandidates struct {
+	Meta
+
+	json bool
+	tmpl string
+}
+
+func (o *OperatorSchedulerRebalanceCandidates) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(o.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		},
+	)
+}
+
+func (o *OperatorSchedulerRebalanceCandidates) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (o *OperatorSchedulerRebalanceCandidates) Name() string {
+	return "operator scheduler rebalance-candidates"
+}
+
+func (o *OperatorSchedulerRebalanceCandidates) Run(args []string) int {
+	var verbose bool
+
+	flags := o.Meta.FlagSet("rebalance-candidates", FlagSetClient)
+	flags.BoolVar(&o.json, "json", false, "")
+	flags.StringVar(&o.tmpl, "t", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Set up a client.
+	client, err := o.Meta.Client()
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	resp, _, err := client.Operator().SchedulerRebalanceCandidates(nil)
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error querying rebalance candidates: %s", err))
+		return 1
+	}
+
+	if o.json || len(o.tmpl) > 0 {
+		out, err := Format(o.json, o.tmpl, resp.Candidates)
+		if err != nil {
+			o.Ui.Error(err.Error())
+			return 1
+		}
+		o.Ui.Output(out)
+		return 0
+	}
+
+	if len(resp.Candidates) == 0 {
+		o.Ui.Output("No allocations to rebalance")
+		return 0
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	out := make([]string, 0, len(resp.Candidates)+1)
+	out = append(out, "Alloc ID|Namespace|Job ID|Task Group|Node ID|Reason")
+	for _, candidate := range resp.Candidates {
+		out = append(out, fmt.Sprintf("%s|%s|%s|%s|%s|%s",
+			limit(candidate.AllocID, length),
+			candidate.Namespace,
+			candidate.JobID,
+			candidate.TaskGroup,
+			limit(candidate.NodeID, length),
+			candidate.Reason))
+	}
+	o.Ui.Output(formatList(out))
+	return 0
+}
+
+func (o *OperatorSchedulerRebalanceCandidates) Synopsis() string {
+	return "Display the allocations the rebalancer would migrate"
+}
+
+func (o *OperatorSchedulerRebalanceCandidates) Help() string {
+	helpText := `
+Usage: nomad operator scheduler rebalance-candidates [options]
+
+  Displays the allocations the rebalancer would migrate in its next run with
+  the current scheduler configuration, along with the reason of each
+  migration. The candidates are listed whether rebalancing is enabled or not,
+  which allows reviewing them before enabling rebalancing or while it runs in
+  dry run mode.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Scheduler Rebalance Candidates Options:
+
+  -json
+    Output the rebalance candidates in their JSON format.
+
+  -t
+    Format and display the rebalance candidates using a Go template.
+
+  -verbose
+    Display full allocation and node IDs.
+`
+
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerRebalanceCandidates_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	c := &OperatorSchedulerRebalanceCandidates{Meta: Meta{Ui: ui}}
+
+	// There is nothing to rebalance in an empty cluster.
+	must.Zero(t, c.Run([]string{"-address=" + addr}))
+	must.StrContains(t, ui.OutputWriter.String(), "No allocations to rebalance")
+	ui.OutputWriter.Reset()
+
+	// Request JSON output and test.
+	must.Zero(t, c.Run([]string{"-address=" + addr, "-json"}))
+	must.StrContains(t, ui.OutputWriter.String(), "[]")
+	ui.OutputWriter.Reset()
+
+	// Test an unsupported flag.
+	must.One(t, c.Run([]string{"-address=" + addr, "-yaml"}))
+	must.StrContains(t, ui.OutputWriter.String(), "Usage: nomad operator scheduler rebalance-candidates")
+}
@@ -25,6 +25,13 @@ type OperatorSchedulerSetConfig struct {
 	checkIndex               string
 	schedulerAlgorithm       string
 	memoryOversubscription   flagHelper.BoolValue
+	loadAware                flagHelper.BoolValue
+	loadAwareThreshold       int
+	rebalance                flagHelper.BoolValue
+	rebalanceDryRun          flagHelper.BoolValue
+	rebalanceNamespaces      string
+	rebalanceMaxMigrations   int
+	rebalanceThreshold       int
 	rejectJobRegistration    flagHelper.BoolValue
 	pauseEvalBroker          flagHelper.BoolValue
 	preemptBatchScheduler    flagHelper.BoolValue
@@ -40,8 +47,16 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-scheduler-algorithm": complete.PredictSet(
 				string(api.SchedulerAlgorithmBinpack),
 				string(api.SchedulerAlgorithmSpread),
+				string(api.SchedulerAlgorithmBalanced),
 			),
 			"-memory-oversubscription":    complete.PredictSet("true", "false"),
+			"-load-aware":                 complete.PredictSet("true", "false"),
+			"-load-aware-threshold":       complete.PredictAnything,
+			"-rebalance":                  complete.PredictSet("true", "false"),
+			"-rebalance-dry-run":          complete.PredictSet("true", "false"),
+			"-rebalance-namespaces":       complete.PredictAnything,
+			"-rebalance-max-migrations":   complete.PredictAnything,
+			"-rebalance-threshold":        complete.PredictAnything,
 			"-reject-job-registration":    complete.PredictSet("true", "false"),
 			"-pause-eval-broker":          complete.PredictSet("true", "false"),
 			"-preempt-batch-scheduler":    complete.PredictSet("true", "false"),
@@ -66,6 +81,13 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	flags.StringVar(&o.checkIndex, "check-index", "", "")
 	flags.StringVar(&o.schedulerAlgorithm, "scheduler-algorithm", "", "")
 	flags.Var(&o.memoryOversubscription, "memory-oversubscription", "")
+	flags.Var(&o.loadAware, "load-aware", "")
+	flags.IntVar(&o.loadAwareThreshold, "load-aware-threshold", -1, "")
+	flags.Var(&o.rebalance, "rebalance", "")
+	flags.Var(&o.rebalanceDryRun, "rebalance-dry-run", "")
+	flags.StringVar(&o.rebalanceNamespaces, "rebalance-namespaces", "", "")
+	flags.IntVar(&o.rebalanceMaxMigrations, "rebalance-max-migrations", -1, "")
+	flags.IntVar(&o.rebalanceThreshold, "rebalance-threshold", -1, "")
 	flags.Var(&o.rejectJobRegistration, "reject-job-registration", "")
 	flags.Var(&o.pauseEvalBroker, "pause-eval-broker", "")
 	flags.Var(&o.preemptBatchScheduler, "preempt-batch-scheduler", "")
@@ -128,6 +150,21 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 		schedulerConfig.SchedulerAlgorithm = api.SchedulerAlgorithm(o.schedulerAlgorithm)
 	}
 	o.memoryOversubscription.Merge(&schedulerConfig.MemoryOversubscriptionEnabled)
+	o.loadAware.Merge(&schedulerConfig.LoadAwareConfig.Enabled)
+	if o.loadAwareThreshold >= 0 {
+		schedulerConfig.LoadAwareConfig.UtilizationThreshold = o.loadAwareThreshold
+	}
+	o.rebalance.Merge(&schedulerConfig.RebalanceConfig.Enabled)
+	o.rebalanceDryRun.Merge(&schedulerConfig.RebalanceConfig.DryRun)
+	if o.rebalanceNamespaces != "" {
+		schedulerConfig.RebalanceConfig.Namespaces = strings.Split(o.rebalanceNamespaces, ",")
+	}
+	if o.rebalanceMaxMigrations >= 0 {
+		schedulerConfig.RebalanceConfig.MaxMigrations = o.rebalanceMaxMigrations
+	}
+	if o.rebalanceThreshold >= 0 {
+		schedulerConfig.RebalanceConfig.UtilizationThreshold = o.rebalanceThreshold
+	}
 	o.rejectJobRegistration.Merge(&schedulerConfig.RejectJobRegistration)
 	o.pauseEvalBroker.Merge(&schedulerConfig.PauseEvalBroker)
 	o.preemptBatchScheduler.Merge(&schedulerConfig.PreemptionConfig.BatchSchedulerEnabled)
@@ -173,15 +210,48 @@ Scheduler Set Config Options:
     matches the current server side version. If a non-zero value is passed, it
     ensures that the scheduler config is being updated from a known state.
 
-  -scheduler-algorithm=["binpack"|"spread"]
+  -scheduler-algorithm=["binpack"|"spread"|"balanced"]
     Specifies whether scheduler binpacks or spreads allocations on available
-    nodes.
+    nodes, or balances the utilization of the CPU, memory and disk of each
+    node.
 
   -memory-oversubscription=[true|false]
     When true, tasks may exceed their reserved memory limit, if the client has
     excess memory capacity. Tasks must specify memory_max to take advantage of
     memory oversubscription.
 
+  -load-aware=[true|false]
+    When true, nodes are penalized according to the actual CPU and memory
+    utilization reported by their client, in addition to the resources reserved
+    by their allocations.
+
+  -load-aware-threshold=<percent>
+    Specifies the percentage of CPU or memory utilization above which nodes are
+    penalized when -load-aware is enabled. Defaults to 0, which penalizes nodes
+    proportionally to their utilization.
+
+  -rebalance=[true|false]
+    When true, the allocations of service jobs that violate their spread or
+    affinity goals, or that run on over-utilized nodes, are periodically
+    migrated within the limits of their migrate block and disruption budget.
+
+  -rebalance-dry-run=[true|false]
+    When true, the allocations that would be rebalanced are only logged by the
+    leader and are not migrated.
+
+  -rebalance-namespaces=<namespaces>
+    Comma separated list of the namespaces opted in for rebalancing. The
+    wildcard "*" opts in every namespace.
+
+  -rebalance-max-migrations=<count>
+    Specifies the maximum number of allocations migrated per rebalancing run.
+    Defaults to 10 when set to 0.
+
+  -rebalance-threshold=<percent>
+    Specifies the percentage of CPU or memory utilization above which
+    allocations are migrated off of a node. Defaults to 0, which disables
+    rebalancing based on utilization.
+
   -reject-job-registration=[true|false]
     When true, the server will return permission denied errors for job registration,
     job dispatch, and job scale APIs, unless the ACL token for the request is a
@@ -0,0 +1,358 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	flagHelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorSchedulerSimulateCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+
+type OperatorSchedulerSimulateCommand struct {
+	Meta
+	JobGetter
+
+	// The scheduler configuration flags allow us to tell whether the user set
+	// a value or not, so only the values set are overridden in the snapshot's
+	// scheduler configuration.
+	schedulerAlgorithm       string
+	memoryOversubscription   flagHelper.BoolValue
+	loadAware                flagHelper.BoolValue
+	loadAwareThreshold       int
+	preemptBatchScheduler    flagHelper.BoolValue
+	preemptServiceScheduler  flagHelper.BoolValue
+	preemptSysBatchScheduler flagHelper.BoolValue
+	preemptSystemScheduler   flagHelper.BoolValue
+}
+
+func (c *OperatorSchedulerSimulateCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler simulate [options] -snapshot <file> <path>
+
+  Runs the scheduler for a job against the cluster state of a snapshot, and
+  displays the allocations that would be placed and preempted, and the reason
+  of any placement failure. The simulation runs locally and does not contact
+  or modify the cluster.
+
+  The snapshot can be created with "nomad operator snapshot save". If the
+  supplied path is "-", the jobfile is read from stdin.
+
+  To simulate the job "example.nomad.hcl" using the spread algorithm:
+
+    $ nomad operator scheduler simulate -snapshot backup.snap \
+        -scheduler-algorithm=spread example.nomad.hcl
+
+Scheduler Simulate Options:
+
+  -snapshot=<file>
+    Path to the snapshot file used as cluster state. Required.
+
+  -verbose
+    Display full information, including the scores of the nodes evaluated for
+    failed placements.
+
+  -json
+    Parses the job file as JSON. If the outer object has a Job field, such as
+    from "nomad job inspect" or "nomad run -output", the value of the field is
+    used as the job.
+
+  -hcl1
+    Parses the job file as HCLv1. Takes precedence over "-hcl2-strict".
+
+  -hcl2-strict
+    Whether an error should be produced from the HCL2 parser where a variable
+    has been supplied which is not defined within the root variables. Defaults
+    to true, but ignored if "-hcl1" is also defined.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times.
+
+  -var-file=path
+    Path to HCL2 file containing user variables.
+
+Scheduler Configuration Options:
+
+  The following options override the scheduler configuration stored in the
+  snapshot for the simulation. See "nomad operator scheduler set-config" for
+  their description.
+
+  -scheduler-algorithm=["binpack"|"spread"|"balanced"]
+  -memory-oversubscription=[true|false]
+  -load-aware=[true|false]
+  -load-aware-threshold=<percent>
+  -preempt-batch-scheduler=[true|false]
+  -preempt-service-scheduler=[true|false]
+  -preempt-sysbatch-scheduler=[true|false]
+  -preempt-system-scheduler=[true|false]
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSchedulerSimulateCommand) Synopsis() string {
+	return "Simulate the scheduling of a job against a snapshot"
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-snapshot":    complete.PredictFiles("*.snap"),
+		"-verbose":     complete.PredictNothing,
+		"-json":        complete.PredictNothing,
+		"-hcl1":        complete.PredictNothing,
+		"-hcl2-strict": complete.PredictNothing,
+		"-var":         complete.PredictAnything,
+		"-var-file":    complete.PredictFiles("*.var"),
+		"-scheduler-algorithm": complete.PredictSet(
+			string(api.SchedulerAlgorithmBinpack),
+			string(api.SchedulerAlgorithmSpread),
+			string(api.SchedulerAlgorithmBalanced),
+		),
+		"-memory-oversubscription":    complete.PredictSet("true", "false"),
+		"-load-aware":                 complete.PredictSet("true", "false"),
+		"-load-aware-threshold":       complete.PredictAnything,
+		"-preempt-batch-scheduler":    complete.PredictSet("true", "false"),
+		"-preempt-service-scheduler":  complete.PredictSet("true", "false"),
+		"-preempt-sysbatch-scheduler": complete.PredictSet("true", "false"),
+		"-preempt-system-scheduler":   complete.PredictSet("true", "false"),
+	}
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*.nomad"),
+		complete.PredictFiles("*.hcl"),
+		complete.PredictFiles("*.json"),
+	)
+}
+
+func (c *OperatorSchedulerSimulateCommand) Name() string {
+	return "operator scheduler simulate"
+}
+
+func (c *OperatorSchedulerSimulateCommand) Run(args []string) int {
+	var snapshotPath string
+	var verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&snapshotPath, "snapshot", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&c.JobGetter.JSON, "json", false, "")
+	flags.BoolVar(&c.JobGetter.HCL1, "hcl1", false, "")
+	flags.BoolVar(&c.JobGetter.Strict, "hcl2-strict", true, "")
+	flags.Var(&c.JobGetter.Vars, "var", "")
+	flags.Var(&c.JobGetter.VarFiles, "var-file", "")
+	flags.StringVar(&c.schedulerAlgorithm, "scheduler-algorithm", "", "")
+	flags.Var(&c.memoryOversubscription, "memory-oversubscription", "")
+	flags.Var(&c.loadAware, "load-aware", "")
+	flags.IntVar(&c.loadAwareThreshold, "load-aware-threshold", -1, "")
+	flags.Var(&c.preemptBatchScheduler, "preempt-batch-scheduler", "")
+	flags.Var(&c.preemptServiceScheduler, "preempt-service-scheduler", "")
+	flags.Var(&c.preemptSysBatchScheduler, "preempt-sysbatch-scheduler", "")
+	flags.Var(&c.preemptSystemScheduler, "preempt-system-scheduler", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job file
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if snapshotPath == "" {
+		c.Ui.Error("The -snapshot flag is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if c.JobGetter.HCL1 {
+		c.JobGetter.Strict = false
+	}
+
+	if err := c.JobGetter.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid job options: %s", err))
+		return 1
+	}
+
+	// Get Job struct from Jobfile
+	_, apiJob, err := c.JobGetter.Get(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+		return 1
+	}
+
+	job := agent.ApiJobToStructJob(apiJob)
+	job.Canonicalize()
+	if err := job.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error validating job: %s", err))
+		return 1
+	}
+
+	f, err := os.Open(snapshotPath)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error opening snapshot file: %s", err))
+		return 1
+	}
+	defer f.Close()
+
+	store, meta, err := raftutil.RestoreFromArchive(f, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read archive file: %s", err))
+		return 1
+	}
+
+	_, schedConfig, err := store.SchedulerConfig()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading scheduler configuration: %s", err))
+		return 1
+	}
+	schedConfig = c.mergeSchedulerConfig(schedConfig)
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:   "simulate",
+		Level:  hclog.Error,
+		Output: os.Stderr,
+	})
+
+	result, err := raftutil.SimulateJob(logger, store, job, schedConfig)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error simulating job: %s", err))
+		return 1
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[bold]==> Simulated job %q against snapshot index %d[reset]", job.ID, meta.Index)))
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Placements[reset]"))
+	c.Ui.Output(formatSimulatedAllocs(result.Placements(), length))
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Preemptions[reset]"))
+	c.Ui.Output(formatSimulatedAllocs(result.Preemptions(), length))
+
+	if len(result.Eval.FailedTGAllocs) == 0 {
+		c.Ui.Output(c.Colorize().Color("\n[bold][green]All tasks successfully allocated.[reset]"))
+		return 0
+	}
+
+	c.Ui.Output(c.Colorize().Color("\n[bold][yellow]Failed Placements[reset]"))
+	groups := make([]string, 0, len(result.Eval.FailedTGAllocs))
+	for tg := range result.Eval.FailedTGAllocs {
+		groups = append(groups, tg)
+	}
+	sort.Strings(groups)
+
+	for _, tg := range groups {
+		metrics, err := simulatedAllocMetric(result.Eval.FailedTGAllocs[tg])
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting placement failures: %s", err))
+			return 1
+		}
+
+		noun := "allocation"
+		if metrics.CoalescedFailures > 0 {
+			noun += "s"
+		}
+		c.Ui.Output(fmt.Sprintf("Task Group %q (failed to place %d %s):",
+			tg, metrics.CoalescedFailures+1, noun))
+		c.Ui.Output(strings.TrimSuffix(formatAllocMetrics(metrics, verbose, "  "), "\n"))
+	}
+
+	return 0
+}
+
+// mergeSchedulerConfig returns a copy of the scheduler configuration with the
+// values set by the operator merged onto it.
+func (c *OperatorSchedulerSimulateCommand) mergeSchedulerConfig(
+	config *structs.SchedulerConfiguration) *structs.SchedulerConfiguration {
+
+	if config == nil {
+		config = &structs.SchedulerConfiguration{}
+	} else {
+		config = config.Copy()
+	}
+
+	if c.schedulerAlgorithm != "" {
+		config.SchedulerAlgorithm = structs.SchedulerAlgorithm(c.schedulerAlgorithm)
+	}
+	c.memoryOversubscription.Merge(&config.MemoryOversubscriptionEnabled)
+	c.loadAware.Merge(&config.LoadAwareConfig.Enabled)
+	if c.loadAwareThreshold >= 0 {
+		config.LoadAwareConfig.UtilizationThreshold = c.loadAwareThreshold
+	}
+	c.preemptBatchScheduler.Merge(&config.PreemptionConfig.BatchSchedulerEnabled)
+	c.preemptServiceScheduler.Merge(&config.PreemptionConfig.ServiceSchedulerEnabled)
+	c.preemptSysBatchScheduler.Merge(&config.PreemptionConfig.SysBatchSchedulerEnabled)
+	c.preemptSystemScheduler.Merge(&config.PreemptionConfig.SystemSchedulerEnabled)
+	return config
+}
+
+// formatSimulatedAllocs formats the allocations of a simulation, sorted by
+// node and allocation name.
+func formatSimulatedAllocs(nodeAllocs map[string][]*structs.Allocation, length int) string {
+	var allocs []*structs.Allocation
+	for _, a := range nodeAllocs {
+		allocs = append(allocs, a...)
+	}
+	if len(allocs) == 0 {
+		return "No allocations"
+	}
+
+	sort.Slice(allocs, func(i, j int) bool {
+		if allocs[i].NodeID != allocs[j].NodeID {
+			return allocs[i].NodeID < allocs[j].NodeID
+		}
+		return allocs[i].Name < allocs[j].Name
+	})
+
+	out := make([]string, len(allocs)+1)
+	out[0] = "ID|Node ID|Node Name|Job ID|Task Group|Name"
+	for i, alloc := range allocs {
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s|%s",
+			limit(alloc.ID, length),
+			limit(alloc.NodeID, length),
+			alloc.NodeName,
+			alloc.JobID,
+			alloc.TaskGroup,
+			alloc.Name)
+	}
+	return formatList(out)
+}
+
+// simulatedAllocMetric converts the metrics computed by the scheduler to
+// their API representation, which shares the same JSON encoding, so they can
+// be formatted like the metrics returned by the agent.
+func simulatedAllocMetric(metric *structs.AllocMetric) (*api.AllocationMetric, error) {
+	buf, err := json.Marshal(metric)
+	if err != nil {
+		return nil, err
+	}
+
+	var out api.AllocationMetric
+	if err := json.Unmarshal(buf, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerSimulateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+}
+
+func TestOperatorSchedulerSimulateCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	tmpDir := t.TempDir()
+	jobPath := filepath.Join(tmpDir, "example.nomad.hcl")
+	must.NoError(t, os.WriteFile(jobPath, []byte(testSimulateJob), 0600))
+
+	invalidSnap := filepath.Join(tmpDir, "invalid.snap")
+	must.NoError(t, os.WriteFile(invalidSnap, []byte("invalid data"), 0600))
+
+	testCases := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "no job",
+			args: []string{"-snapshot", invalidSnap},
+			err:  "This command takes one argument",
+		},
+		{
+			name: "no snapshot",
+			args: []string{jobPath},
+			err:  "The -snapshot flag is required",
+		},
+		{
+			name: "snapshot not found",
+			args: []string{"-snapshot", filepath.Join(tmpDir, "foo"), jobPath},
+			err:  "no such file",
+		},
+		{
+			name: "invalid snapshot",
+			args: []string{"-snapshot", invalidSnap, jobPath},
+			err:  "Failed to read archive file",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+			code := cmd.Run(tc.args)
+			must.One(t, code)
+			must.StrContains(t, ui.ErrorWriter.String(), tc.err)
+		})
+	}
+}
+
+func TestOperatorSchedulerSimulateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// The snapshot is taken from a server without clients, so the job can't
+	// be placed anywhere.
+	snapPath := generateSnapshotFile(t, nil)
+
+	jobPath := filepath.Join(t.TempDir(), "example.nomad.hcl")
+	must.NoError(t, os.WriteFile(jobPath, []byte(testSimulateJob), 0600))
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-snapshot", snapPath, "-scheduler-algorithm=spread", jobPath})
+	must.Zero(t, code)
+
+	output := ui.OutputWriter.String()
+	must.StrContains(t, output, `Simulated job "example"`)
+	must.StrContains(t, output, "No allocations")
+	must.StrContains(t, output, `Task Group "web" (failed to place 1 allocation)`)
+	must.StrContains(t, output, "No nodes were eligible for evaluation")
+}
+
+const testSimulateJob = `
+job "example" {
+  datacenters = ["dc1"]
+
+  group "web" {
+    task "web" {
+      driver = "raw_exec"
+
+      config {
+        command = "/bin/sleep"
+      }
+    }
+  }
+}
+`
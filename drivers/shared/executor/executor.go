@@ -703,11 +703,13 @@ func (e *UniversalExecutor) handleStats(ch chan *cstructs.TaskResourceUsage, ctx
 		}
 
 		stats := e.processStats.StatProcesses()
+		usage := procstats.Aggregate(e.systemCpuStats, stats)
+		usage.ResourceUsage.IOStats = e.ioStats()
 
 		select {
 		case <-ctx.Done():
 			return
-		case ch <- procstats.Aggregate(e.systemCpuStats, stats):
+		case ch <- usage:
 		}
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-set/v2"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
@@ -43,3 +44,7 @@ func (e *UniversalExecutor) ListProcesses() set.Collection[int] {
 func (e *UniversalExecutor) setSubCmdCgroup(*exec.Cmd, string) (func(), error) {
 	return func() {}, nil
 }
+
+func (e *UniversalExecutor) ioStats() *cstructs.IOStats {
+	return nil
+}
@@ -60,6 +60,9 @@ var (
 
 	// ExecutorCgroupMeasuredCpuStats is the list of CPU stats captures by the executor
 	ExecutorCgroupMeasuredCpuStats = []string{"System Mode", "User Mode", "Throttled Periods", "Throttled Time", "Percent"}
+
+	// ExecutorCgroupV2MeasuredIOStats is the list of block I/O stats captured by the executor with cgroup-v2
+	ExecutorCgroupV2MeasuredIOStats = []string{"Read Bytes", "Write Bytes", "Read Ops", "Write Ops"}
 )
 
 // LibcontainerExecutor implements an Executor with the runc/libcontainer api
@@ -465,10 +468,19 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 			TotalTicks:       l.systemCpuStats.TicksConsumed(totalPercent),
 			Measured:         ExecutorCgroupMeasuredCpuStats,
 		}
+
+		// Block I/O Related Stats, the io controller is only enabled by
+		// Nomad in cgroups v2
+		var is *cstructs.IOStats
+		if cgroupslib.GetMode() == cgroupslib.CG2 {
+			is = blkioStats(&stats.BlkioStats)
+		}
+
 		taskResUsage := cstructs.TaskResourceUsage{
 			ResourceUsage: &cstructs.ResourceUsage{
 				MemoryStats: ms,
 				CpuStats:    cs,
+				IOStats:     is,
 			},
 			Timestamp: ts.UTC().UnixNano(),
 			Pids:      pstats,
@@ -483,6 +495,30 @@ func (l *LibcontainerExecutor) handleStats(ch chan *cstructs.TaskResourceUsage,
 	}
 }
 
+// blkioStats sums the block I/O of the container over all devices.
+func blkioStats(stats *cgroups.BlkioStats) *cstructs.IOStats {
+	is := &cstructs.IOStats{
+		Measured: ExecutorCgroupV2MeasuredIOStats,
+	}
+	for _, entry := range stats.IoServiceBytesRecursive {
+		switch entry.Op {
+		case "Read":
+			is.ReadBytes += entry.Value
+		case "Write":
+			is.WriteBytes += entry.Value
+		}
+	}
+	for _, entry := range stats.IoServicedRecursive {
+		switch entry.Op {
+		case "Read":
+			is.ReadOps += entry.Value
+		case "Write":
+			is.WriteOps += entry.Value
+		}
+	}
+	return is
+}
+
 // Signal sends a signal to the process managed by the executor
 func (l *LibcontainerExecutor) Signal(s os.Signal) error {
 	return l.userProc.Signal(s)
@@ -795,6 +831,10 @@ func (l *LibcontainerExecutor) configureCG1(cfg *runc.Config, command *ExecComma
 	// set cpu resources
 	cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
 
+	if hasIOLimits(command) {
+		l.logger.Warn("block I/O limits require cgroups v2 and are not enforced")
+	}
+
 	// we need to manually set the cpuset, because libcontainer will not set
 	// it for our special cpuset cgroup
 	if err := l.cpusetCG1(cpusetPath, cpuCores); err != nil {
@@ -832,6 +872,12 @@ func (l *LibcontainerExecutor) configureCG2(cfg *runc.Config, command *ExecComma
 	scope := filepath.Base(cg)
 	cfg.Cgroups.Path = filepath.Join("/", cgroupslib.NomadCgroupParent, partition, scope)
 
+	// the cgroup is pre-created by the client, so write the block I/O limits
+	// before libcontainer starts the task in it
+	if err := configureIOCG2(cg, command); err != nil {
+		return err
+	}
+
 	// todo(shoenig): we will also want to set cpu bandwidth (i.e. cpu_hard_limit)
 	// hopefully for 1.7
 	return nil
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/go-set/v2"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/lib/nsutil"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
 	"github.com/hashicorp/nomad/helper/users"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -106,6 +107,29 @@ func (e *UniversalExecutor) ListProcesses() set.Collection[procstats.ProcessID]
 	return procstats.List(e.command)
 }
 
+// ioStats returns the block I/O of the task read from its cgroup, or nil if it
+// can not be read.
+func (e *UniversalExecutor) ioStats() *cstructs.IOStats {
+	if cgroupslib.GetMode() != cgroupslib.CG2 {
+		return nil
+	}
+	cgroup := e.command.StatsCgroup()
+	if cgroup == "" {
+		return nil
+	}
+	stat, err := cgroupslib.ReadIOStat(cgroup)
+	if err != nil {
+		return nil
+	}
+	return &cstructs.IOStats{
+		ReadBytes:  stat.ReadBytes,
+		WriteBytes: stat.WriteBytes,
+		ReadOps:    stat.ReadOps,
+		WriteOps:   stat.WriteOps,
+		Measured:   ExecutorCgroupV2MeasuredIOStats,
+	}
+}
+
 func (e *UniversalExecutor) statCG(cgroup string) (int, func(), error) {
 	fd, err := unix.Open(cgroup, unix.O_PATH, 0)
 	cleanup := func() {
@@ -139,7 +163,9 @@ func (e *UniversalExecutor) configureResourceContainer(command *ExecCommand, pid
 		}
 		cgCleanup = e.enterCG1(cgroup, command.CpusetCgroup())
 	default:
-		e.configureCG2(cgroup, command)
+		if err := e.configureCG2(cgroup, command); err != nil {
+			return nil, err
+		}
 		// configure child process to spawn in the cgroup
 		// get file descriptor of the cgroup made for this task
 		fd, cleanup, err := e.statCG(cgroup)
@@ -228,6 +254,10 @@ func (e *UniversalExecutor) configureCG1(cgroup string, command *ExecCommand) er
 		_ = ed.Write("memory.swappiness", strconv.FormatInt(value, 10))
 	}
 
+	if hasIOLimits(command) {
+		e.logger.Warn("block I/O limits require cgroups v2 and are not enforced")
+	}
+
 	// write cpu shares
 	cpuShares := strconv.FormatInt(command.Resources.LinuxResources.CPUShares, 10)
 	ed = cgroupslib.OpenFromFreezerCG1(cgroup, "cpu")
@@ -243,10 +273,10 @@ func (e *UniversalExecutor) configureCG1(cgroup string, command *ExecCommand) er
 	return nil
 }
 
-func (e *UniversalExecutor) configureCG2(cgroup string, command *ExecCommand) {
+func (e *UniversalExecutor) configureCG2(cgroup string, command *ExecCommand) error {
 	// some drivers like qemu entirely own resource management
 	if command.Resources == nil || command.Resources.LinuxResources == nil {
-		return
+		return nil
 	}
 
 	// write memory cgroup files
@@ -278,6 +308,9 @@ func (e *UniversalExecutor) configureCG2(cgroup string, command *ExecCommand) {
 	// write cpuset cgroup file, if set
 	cpusetCpus := command.Resources.LinuxResources.CpusetCpus
 	_ = ed.Write("cpuset.cpus", cpusetCpus)
+
+	// write io cgroup files, if set
+	return configureIOCG2(cgroup, command)
 }
 
 func (e *UniversalExecutor) setOomAdj(oomScore int32) error {
@@ -292,6 +325,39 @@ func (*UniversalExecutor) computeCPU(command *ExecCommand) uint64 {
 	return cpuWeight
 }
 
+// hasIOLimits returns whether the task sets block I/O limits.
+func hasIOLimits(command *ExecCommand) bool {
+	res := command.Resources.NomadResources
+	return res != nil && res.IO != nil && (res.IO.Weight > 0 || len(res.IO.Devices) > 0)
+}
+
+// configureIOCG2 writes the block I/O limits of the task to the io.weight and
+// io.max interface files of its cgroup. Unlike cpu and memory, a failure to
+// apply them fails the task so that it never runs without the limits it
+// asked for.
+func configureIOCG2(cgroup string, command *ExecCommand) error {
+	if !hasIOLimits(command) {
+		return nil
+	}
+
+	io := command.Resources.NomadResources.IO
+	limits := make([]cgroupslib.IOLimit, 0, len(io.Devices))
+	for _, device := range io.Devices {
+		limits = append(limits, cgroupslib.IOLimit{
+			Path:      device.Path,
+			ReadBps:   device.ReadBps,
+			WriteBps:  device.WriteBps,
+			ReadIOPS:  device.ReadIOPS,
+			WriteIOPS: device.WriteIOPS,
+		})
+	}
+
+	if err := cgroupslib.WriteIO(cgroup, io.Weight, limits); err != nil {
+		return fmt.Errorf("failed to configure block I/O limits: %w", err)
+	}
+	return nil
+}
+
 func mbToBytes(n int64) int64 {
 	return n * 1024 * 1024
 }
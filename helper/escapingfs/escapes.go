@@ -104,6 +104,55 @@ func PathEscapesAllocDir(base, prefix, path string) (bool, error) {
 	return false, nil
 }
 
+// PathEscapesAllocDirOnWrite returns true if writing to base/prefix/path would
+// escape the given base directory.
+//
+// Unlike PathEscapesAllocDir, a path that does not exist yet is checked by
+// resolving the symlinks of its closest existing parent, and a dangling
+// symlink is treated as escaping since writing through it would create its
+// target.
+//
+// The base directory must be an absolute path.
+func PathEscapesAllocDirOnWrite(base, prefix, path string) (bool, error) {
+	full := filepath.Join(base, prefix, path)
+
+	// If base is not an absolute path, the caller passed in the wrong thing.
+	if !filepath.IsAbs(base) {
+		return false, errors.New("alloc dir must be absolute")
+	}
+
+	// Check path does not escape the alloc dir using relative paths.
+	if escapes, err := PathEscapesAllocViaRelative(prefix, path); err != nil {
+		return false, err
+	} else if escapes {
+		return true, nil
+	}
+
+	// Find the closest existing parent of the path, which may be the path
+	// itself.
+	existing := full
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return false, err
+		}
+		existing = filepath.Dir(existing)
+	}
+
+	// Check it does not escape the alloc dir using symlinks.
+	if escapes, err := pathEscapesBaseViaSymlink(base, existing); err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, err
+	} else if escapes {
+		return true, nil
+	}
+
+	return false, nil
+}
+
 // PathEscapesSandbox returns whether previously cleaned path inside the
 // sandbox directory (typically this will be the allocation directory)
 // escapes.
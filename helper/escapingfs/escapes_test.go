@@ -146,6 +146,60 @@ func Test_PathEscapesAllocDir(t *testing.T) {
 	})
 }
 
+func Test_PathEscapesAllocDirOnWrite(t *testing.T) {
+
+	t.Run("no-escape", func(t *testing.T) {
+		dir := t.TempDir()
+
+		write(t, filepath.Join(dir, "foo"), "hi")
+
+		escape, err := PathEscapesAllocDirOnWrite(dir, "", "/foo")
+		must.NoError(t, err)
+		must.False(t, escape)
+	})
+
+	t.Run("no-escape-no-exist", func(t *testing.T) {
+		dir := t.TempDir()
+
+		escape, err := PathEscapesAllocDirOnWrite(dir, "", "/no/exist")
+		must.NoError(t, err)
+		must.False(t, escape)
+	})
+
+	t.Run("symlink-parent-escape", func(t *testing.T) {
+		dir := t.TempDir()
+
+		// link from dir/link to another directory
+		link := filepath.Join(dir, "link")
+		must.NoError(t, os.Symlink(t.TempDir(), link))
+
+		escape, err := PathEscapesAllocDirOnWrite(dir, "", "/link/no-exist")
+		must.NoError(t, err)
+		must.True(t, escape)
+	})
+
+	t.Run("dangling-symlink-escape", func(t *testing.T) {
+		dir := t.TempDir()
+
+		// link from dir/link to a file that does not exist yet
+		link := filepath.Join(dir, "link")
+		target := filepath.Join(t.TempDir(), "no-exist")
+		must.NoError(t, os.Symlink(target, link))
+
+		escape, err := PathEscapesAllocDirOnWrite(dir, "", "/link")
+		must.NoError(t, err)
+		must.True(t, escape)
+	})
+
+	t.Run("relative-escape", func(t *testing.T) {
+		dir := t.TempDir()
+
+		escape, err := PathEscapesAllocDirOnWrite(dir, "", "../../foo")
+		must.NoError(t, err)
+		must.True(t, escape)
+	})
+}
+
 func TestPathEscapesSandbox(t *testing.T) {
 	cases := []struct {
 		name     string
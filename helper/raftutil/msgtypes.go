@@ -65,6 +65,9 @@ var msgTypeNames = map[structs.MessageType]string{
 	structs.ACLBindingRulesDeleteRequestType:             "ACLBindingRulesDeleteRequestType",
 	structs.NodePoolUpsertRequestType:                    "NodePoolUpsertRequestType",
 	structs.NodePoolDeleteRequestType:                    "NodePoolDeleteRequestType",
+	structs.NodeUpdateTaintsRequestType:                  "NodeUpdateTaintsRequestType",
+	structs.NodeUpdateUtilizationRequestType:             "NodeUpdateUtilizationRequestType",
+	structs.DeploymentAnalysisRequestType:                "DeploymentAnalysisRequestType",
 	structs.NamespaceUpsertRequestType:                   "NamespaceUpsertRequestType",
 	structs.NamespaceDeleteRequestType:                   "NamespaceDeleteRequestType",
 }
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package raftutil
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+// SimulationResult is the outcome of running the scheduler for a job against
+// a state store.
+type SimulationResult struct {
+	// Plans are the plans submitted by the scheduler. They have already been
+	// applied to the state store.
+	Plans []*structs.Plan
+
+	// Eval is the evaluation as last updated by the scheduler, including the
+	// metrics of any failed placements.
+	Eval *structs.Evaluation
+
+	// CreatedEvals are the evaluations created by the scheduler, such as
+	// blocked evaluations.
+	CreatedEvals []*structs.Evaluation
+}
+
+// SimulateJob registers the job in the state store and runs the scheduler
+// against it. The state store is expected to be a throwaway copy of the
+// cluster state, such as one restored with RestoreFromArchive, as plans are
+// applied to it. If schedConfig is set it replaces the scheduler
+// configuration of the state store before the job is scheduled. The job is
+// mutated the same way a registration would, such as to set its default node
+// pool, before it is compared with the registered version of the job.
+func SimulateJob(logger hclog.Logger, store *state.StateStore, job *structs.Job,
+	schedConfig *structs.SchedulerConfiguration) (*SimulationResult, error) {
+
+	if job.IsPeriodic() || job.IsParameterized() {
+		return nil, errors.New("periodic and parameterized jobs cannot be simulated")
+	}
+
+	job, _, err := nomad.MutateSimulatedJob(job)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := store.LatestIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest index: %w", err)
+	}
+
+	if schedConfig != nil {
+		if err := schedConfig.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid scheduler configuration: %w", err)
+		}
+		index++
+		if err := store.SchedulerSetConfig(index, schedConfig); err != nil {
+			return nil, fmt.Errorf("failed to set scheduler configuration: %w", err)
+		}
+	}
+
+	existingJob, err := store.JobByID(nil, job.Namespace, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup job: %w", err)
+	}
+
+	// Only insert the job if it has changed, so that existing deployments and
+	// allocations are reused the same way a real registration would
+	jobModifyIndex := uint64(0)
+	if existingJob != nil {
+		jobModifyIndex = existingJob.JobModifyIndex
+	}
+	if existingJob == nil || existingJob.SpecChanged(job) {
+		index++
+		if err := store.UpsertJob(structs.JobRegisterRequestType, index, nil, job); err != nil {
+			return nil, fmt.Errorf("failed to register job: %w", err)
+		}
+		jobModifyIndex = index
+	}
+
+	now := time.Now().UnixNano()
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    structs.EvalTriggerJobRegister,
+		JobID:          job.ID,
+		JobModifyIndex: jobModifyIndex,
+		Status:         structs.EvalStatusPending,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+	index++
+	if err := store.UpsertEvals(structs.EvalUpdateRequestType, index, []*structs.Evaluation{eval}); err != nil {
+		return nil, fmt.Errorf("failed to create evaluation: %w", err)
+	}
+
+	snap, err := store.Snapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot state: %w", err)
+	}
+
+	// Use a planner that applies plans to the state store and records the
+	// submitted plans and evaluations.
+	planner, err := newSimulationPlanner(store, index)
+	if err != nil {
+		return nil, err
+	}
+
+	sched, err := scheduler.NewScheduler(eval.Type, logger, nil, snap, planner)
+	if err != nil {
+		return nil, err
+	}
+	if err := sched.Process(eval); err != nil {
+		return nil, fmt.Errorf("failed to process evaluation: %w", err)
+	}
+
+	result := &SimulationResult{
+		Plans:        planner.plans,
+		Eval:         eval,
+		CreatedEvals: planner.createdEvals,
+	}
+	if n := len(planner.evals); n > 0 {
+		result.Eval = planner.evals[n-1]
+	}
+	return result, nil
+}
+
+// Placements returns the allocations placed by the simulation, keyed by node
+// ID.
+func (r *SimulationResult) Placements() map[string][]*structs.Allocation {
+	placements := make(map[string][]*structs.Allocation)
+	for _, plan := range r.Plans {
+		for nodeID, allocs := range plan.NodeAllocation {
+			placements[nodeID] = append(placements[nodeID], allocs...)
+		}
+	}
+	return placements
+}
+
+// Preemptions returns the allocations preempted by the simulation, keyed by
+// node ID.
+func (r *SimulationResult) Preemptions() map[string][]*structs.Allocation {
+	preemptions := make(map[string][]*structs.Allocation)
+	for _, plan := range r.Plans {
+		for nodeID, allocs := range plan.NodePreemptions {
+			preemptions[nodeID] = append(preemptions[nodeID], allocs...)
+		}
+	}
+	return preemptions
+}
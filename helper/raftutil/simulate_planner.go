@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package raftutil
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+	nomadversion "github.com/hashicorp/nomad/version"
+)
+
+// simulationPlanner is a scheduler.Planner applying the plans and evaluations
+// of a simulated scheduler to a throwaway state store. Plans are applied in
+// full, as no other scheduler competes for the cluster, and raft indexes
+// continue from the latest index of the state store.
+type simulationPlanner struct {
+	store *state.StateStore
+	index uint64
+
+	// serverVersion is the version of the servers the scheduler runs on,
+	// which is the version of this binary as it runs the simulated scheduler.
+	serverVersion *version.Version
+
+	plans        []*structs.Plan
+	evals        []*structs.Evaluation
+	createdEvals []*structs.Evaluation
+}
+
+var _ scheduler.Planner = (*simulationPlanner)(nil)
+
+// newSimulationPlanner returns a planner applying changes to the state store
+// at indexes following index.
+func newSimulationPlanner(store *state.StateStore, index uint64) (*simulationPlanner, error) {
+	v, err := version.NewVersion(nomadversion.GetVersion().VersionNumber())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse server version: %w", err)
+	}
+	return &simulationPlanner{
+		store:         store,
+		index:         index,
+		serverVersion: v,
+	}, nil
+}
+
+// nextIndex returns the raft index of the next change to the state store.
+func (p *simulationPlanner) nextIndex() uint64 {
+	p.index++
+	return p.index
+}
+
+// SubmitPlan applies the plan to the state store the same way the plan
+// applier of the leader does.
+func (p *simulationPlanner) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, scheduler.State, error) {
+	p.plans = append(p.plans, plan)
+
+	index := p.nextIndex()
+	now := time.Now().UTC().UnixNano()
+
+	result := &structs.PlanResult{
+		NodeUpdate:        plan.NodeUpdate,
+		NodeAllocation:    plan.NodeAllocation,
+		NodePreemptions:   plan.NodePreemptions,
+		Deployment:        plan.Deployment,
+		DeploymentUpdates: plan.DeploymentUpdates,
+		AllocIndex:        index,
+	}
+
+	req := structs.ApplyPlanResultsRequest{
+		AllocUpdateRequest: structs.AllocUpdateRequest{
+			Job: plan.Job,
+		},
+		Deployment:        result.Deployment,
+		DeploymentUpdates: result.DeploymentUpdates,
+		EvalID:            plan.EvalID,
+		UpdatedAt:         now,
+	}
+	for _, updateList := range result.NodeUpdate {
+		for _, alloc := range updateList {
+			req.AllocsStopped = append(req.AllocsStopped, &structs.AllocationDiff{
+				ID:                 alloc.ID,
+				DesiredDescription: alloc.DesiredDescription,
+				ClientStatus:       alloc.ClientStatus,
+				ModifyTime:         now,
+				FollowupEvalID:     alloc.FollowupEvalID,
+			})
+		}
+	}
+	for _, allocList := range result.NodeAllocation {
+		for _, alloc := range allocList {
+			if alloc.CreateTime == 0 {
+				alloc.CreateTime = now
+			}
+			alloc.ModifyTime = now
+			req.AllocsUpdated = append(req.AllocsUpdated, alloc)
+		}
+	}
+	for _, preemptions := range result.NodePreemptions {
+		for _, alloc := range preemptions {
+			req.AllocsPreempted = append(req.AllocsPreempted, &structs.AllocationDiff{
+				ID:                    alloc.ID,
+				PreemptedByAllocation: alloc.PreemptedByAllocation,
+				ModifyTime:            now,
+			})
+		}
+	}
+
+	if err := p.store.UpsertPlanResults(structs.ApplyPlanResultsRequestType, index, &req); err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+// UpdateEval stores the updated evaluation.
+func (p *simulationPlanner) UpdateEval(eval *structs.Evaluation) error {
+	p.evals = append(p.evals, eval)
+	return p.store.UpsertEvals(structs.EvalUpdateRequestType, p.nextIndex(), []*structs.Evaluation{eval})
+}
+
+// CreateEval stores the evaluation created by the scheduler.
+func (p *simulationPlanner) CreateEval(eval *structs.Evaluation) error {
+	p.createdEvals = append(p.createdEvals, eval)
+	return p.store.UpsertEvals(structs.EvalUpdateRequestType, p.nextIndex(), []*structs.Evaluation{eval})
+}
+
+// ReblockEval checks the evaluation is blocked. There is no blocked evals
+// tracker to re-insert it into during a simulation.
+func (p *simulationPlanner) ReblockEval(eval *structs.Evaluation) error {
+	existing, err := p.store.EvalByID(nil, eval.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return fmt.Errorf("evaluation does not exist to be reblocked")
+	}
+	if existing.Status != structs.EvalStatusBlocked {
+		return fmt.Errorf("evaluation %q is not already in a blocked state", existing.ID)
+	}
+	return nil
+}
+
+// ServersMeetMinimumVersion returns whether the version of the simulated
+// servers is at least minVersion, ignoring pre-release metadata the same way
+// the servers do.
+func (p *simulationPlanner) ServersMeetMinimumVersion(minVersion *version.Version, _ bool) bool {
+	return !p.serverVersion.LessThan(minVersion) ||
+		slices.Equal(minVersion.Segments(), p.serverVersion.Segments())
+}
@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package raftutil
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateJob(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), mock.Node()))
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+
+	// Add a group that can't fit on any node
+	big := job.TaskGroups[0].Copy()
+	big.Name = "big"
+	big.Count = 1
+	big.Tasks[0].Resources.CPU = 100000
+	job.TaskGroups = append(job.TaskGroups, big)
+
+	schedConfig := &structs.SchedulerConfiguration{
+		SchedulerAlgorithm: structs.SchedulerAlgorithmSpread,
+	}
+
+	result, err := SimulateJob(testlog.HCLogger(t), store, job, schedConfig)
+	require.NoError(t, err)
+	require.Len(t, result.Plans, 1)
+
+	// Ensure the allocs were placed across both nodes
+	placements := result.Placements()
+	require.Len(t, placements, 2)
+	placed := 0
+	for _, allocs := range placements {
+		placed += len(allocs)
+	}
+	require.Equal(t, 3, placed)
+	require.Empty(t, result.Preemptions())
+
+	// Ensure the failed placement is reported
+	require.Len(t, result.Eval.FailedTGAllocs, 1)
+	require.Contains(t, result.Eval.FailedTGAllocs, "big")
+	require.Equal(t, 2, result.Eval.FailedTGAllocs["big"].NodesExhausted)
+	require.Len(t, result.CreatedEvals, 1)
+	require.Equal(t, structs.EvalStatusBlocked, result.CreatedEvals[0].Status)
+
+	// Ensure the scheduler configuration was overridden
+	_, config, err := store.SchedulerConfig()
+	require.NoError(t, err)
+	require.Equal(t, structs.SchedulerAlgorithmSpread, config.SchedulerAlgorithm)
+}
+
+func TestSimulateJob_Invalid(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+
+	job := mock.PeriodicJob()
+	_, err := SimulateJob(testlog.HCLogger(t), store, job, nil)
+	require.EqualError(t, err, "periodic and parameterized jobs cannot be simulated")
+
+	job = mock.Job()
+	schedConfig := &structs.SchedulerConfiguration{SchedulerAlgorithm: "random"}
+	_, err = SimulateJob(testlog.HCLogger(t), store, job, schedConfig)
+	require.ErrorContains(t, err, "invalid scheduler configuration")
+}
+
+func TestSimulateJob_Indexes(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, mock.Node()))
+
+	latest, err := store.LatestIndex()
+	require.NoError(t, err)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	result, err := SimulateJob(testlog.HCLogger(t), store, job, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Plans, 1)
+
+	// Ensure the simulation continued from the latest index of the state
+	allocs, err := store.AllocsByJob(nil, job.Namespace, job.ID, true)
+	require.NoError(t, err)
+	require.Len(t, allocs, 1)
+	require.Greater(t, allocs[0].CreateIndex, latest)
+	require.Greater(t, result.Eval.ModifyIndex, allocs[0].CreateIndex)
+}
+
+func TestSimulationPlanner_ServersMeetMinimumVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	planner, err := newSimulationPlanner(state.TestStateStore(t), 0)
+	require.NoError(t, err)
+
+	require.True(t, planner.ServersMeetMinimumVersion(version.Must(version.NewVersion("1.3.0")), true))
+	require.True(t, planner.ServersMeetMinimumVersion(planner.serverVersion, true))
+	require.False(t, planner.ServersMeetMinimumVersion(version.Must(version.NewVersion("99.0.0")), true))
+}
+
+func TestSimulateJob_DefaultNodePool(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+
+	pool := mock.NodePool()
+	require.NoError(t, store.UpsertNodePools(structs.MsgTypeTestSetup, 100, []*structs.NodePool{pool}))
+
+	defaultNode := mock.Node()
+	poolNode := mock.Node()
+	poolNode.NodePool = pool.Name
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 101, defaultNode))
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 102, poolNode))
+
+	// A job without a node pool is only placed on the default pool, as it
+	// would be once registered
+	job := mock.Job()
+	job.NodePool = ""
+	job.TaskGroups[0].Count = 4
+	schedConfig := &structs.SchedulerConfiguration{
+		SchedulerAlgorithm: structs.SchedulerAlgorithmSpread,
+	}
+	result, err := SimulateJob(testlog.HCLogger(t), store, job, schedConfig)
+	require.NoError(t, err)
+
+	placements := result.Placements()
+	require.Len(t, placements, 1)
+	require.Len(t, placements[defaultNode.ID], 4)
+
+	stored, err := store.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.NodePoolDefault, stored.NodePool)
+}
+
+func TestSimulateJob_Unchanged(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, mock.Node()))
+
+	job := mock.Job()
+	job.NodePool = ""
+	job.TaskGroups[0].Count = 2
+	result, err := SimulateJob(testlog.HCLogger(t), store, job.Copy(), nil)
+	require.NoError(t, err)
+	require.Len(t, result.Plans, 1)
+
+	stored, err := store.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+
+	// Resubmitting the same job neither registers a new version nor
+	// replaces its allocations
+	result, err = SimulateJob(testlog.HCLogger(t), store, job.Copy(), nil)
+	require.NoError(t, err)
+	require.Empty(t, result.Placements())
+
+	resubmitted, err := store.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, stored.Version, resubmitted.Version)
+	require.Equal(t, stored.JobModifyIndex, resubmitted.JobModifyIndex)
+
+	allocs, err := store.AllocsByJob(nil, job.Namespace, job.ID, true)
+	require.NoError(t, err)
+	require.Len(t, allocs, 2)
+	for _, alloc := range allocs {
+		require.Equal(t, structs.AllocDesiredStatusRun, alloc.DesiredStatus)
+	}
+}
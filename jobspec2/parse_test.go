@@ -1232,3 +1232,43 @@ func TestIdentity(t *testing.T) {
 	must.Eq(t, "sighup", altID.ChangeSignal)
 	must.Eq(t, 2*time.Hour, altID.TTL)
 }
+
+func TestParse_IOResources(t *testing.T) {
+	ci.Parallel(t)
+
+	hcl := `
+job "example" {
+  group "group" {
+    task "task" {
+      driver = "exec"
+
+      resources {
+        io {
+          weight = 200
+
+          device "/dev/sda" {
+            read_bps   = 10485760
+            write_iops = 500
+          }
+        }
+      }
+    }
+  }
+}
+`
+	job, err := ParseWithConfig(&ParseConfig{
+		Path:    "input.hcl",
+		Body:    []byte(hcl),
+		AllowFS: false,
+	})
+	must.NoError(t, err)
+
+	io := job.TaskGroups[0].Tasks[0].Resources.IO
+	must.NotNil(t, io)
+	must.Eq(t, 200, *io.Weight)
+	must.Len(t, 1, io.Devices)
+	must.Eq(t, "/dev/sda", io.Devices[0].Path)
+	must.Eq(t, 10485760, *io.Devices[0].ReadBps)
+	must.Eq(t, 500, *io.Devices[0].WriteIOPS)
+	must.Nil(t, io.Devices[0].WriteBps)
+}
@@ -4,6 +4,7 @@
 package nomad
 
 import (
+	"slices"
 	"sync"
 	"time"
 
@@ -31,7 +32,9 @@ const (
 // certain class of nodes becomes available. An evaluation is put into the
 // blocked state when it is run through the scheduler and produced failed
 // allocations. It is unblocked when the capacity of a node that could run the
-// failed allocation becomes available.
+// failed allocation becomes available. Evaluations of jobs whose dependencies
+// are not met are also blocked, and are unblocked when one of the jobs they
+// depend on changes.
 type BlockedEvals struct {
 	// logger is the logger to use by the blocked eval tracker.
 	logger hclog.Logger
@@ -49,6 +52,10 @@ type BlockedEvals struct {
 	// classes.
 	escaped map[string]wrappedEval
 
+	// dependent is the set of evaluations waiting for the dependencies of
+	// their job to be met.
+	dependent map[string]wrappedEval
+
 	// system is the set of system evaluations that failed to start on nodes because of
 	// resource constraints.
 	system *systemEvals
@@ -66,6 +73,11 @@ type BlockedEvals struct {
 	// time they are being blocked.
 	unblockIndexes map[string]uint64
 
+	// dependencyIndexes maps the jobs depended on to the index at which they
+	// last changed. This is used to check if a dependent evaluation could have
+	// been unblocked while it was in the scheduler.
+	dependencyIndexes map[structs.NamespacedID]uint64
+
 	// duplicates is the set of evaluations for jobs that had pre-existing
 	// blocked evaluations. These should be marked as cancelled since only one
 	// blocked eval is needed per job.
@@ -101,17 +113,19 @@ type wrappedEval struct {
 // unblocked evals into the passed broker.
 func NewBlockedEvals(evalBroker *EvalBroker, logger hclog.Logger) *BlockedEvals {
 	return &BlockedEvals{
-		logger:           logger.Named("blocked_evals"),
-		evalBroker:       evalBroker,
-		captured:         make(map[string]wrappedEval),
-		escaped:          make(map[string]wrappedEval),
-		system:           newSystemEvals(),
-		jobs:             make(map[structs.NamespacedID]string),
-		unblockIndexes:   make(map[string]uint64),
-		capacityChangeCh: make(chan *capacityUpdate, unblockBuffer),
-		duplicateCh:      make(chan struct{}, 1),
-		stopCh:           make(chan struct{}),
-		stats:            NewBlockedStats(),
+		logger:            logger.Named("blocked_evals"),
+		evalBroker:        evalBroker,
+		captured:          make(map[string]wrappedEval),
+		escaped:           make(map[string]wrappedEval),
+		dependent:         make(map[string]wrappedEval),
+		system:            newSystemEvals(),
+		jobs:              make(map[structs.NamespacedID]string),
+		unblockIndexes:    make(map[string]uint64),
+		dependencyIndexes: make(map[structs.NamespacedID]uint64),
+		capacityChangeCh:  make(chan *capacityUpdate, unblockBuffer),
+		duplicateCh:       make(chan struct{}, 1),
+		stopCh:            make(chan struct{}),
+		stats:             NewBlockedStats(),
 	}
 }
 
@@ -187,7 +201,7 @@ func (b *BlockedEvals) processBlock(eval *structs.Evaluation, token string) {
 	// older index. The scheduler could have been invoked with a snapshot of
 	// state that was prior to additional capacity being added or allocations
 	// becoming terminal.
-	if b.missedUnblock(eval) {
+	if b.missedUnblock(eval) || b.missedDependencyUnblock(eval) {
 		// Just re-enqueue the eval immediately. We pass the token so that the
 		// eval_broker can properly handle the case in which the evaluation is
 		// still outstanding.
@@ -210,6 +224,14 @@ func (b *BlockedEvals) processBlock(eval *structs.Evaluation, token string) {
 		token: token,
 	}
 
+	// Evaluations waiting on job dependencies are only unblocked when the
+	// jobs they depend on change, not on capacity changes.
+	if len(eval.PendingDependencies) != 0 {
+		b.dependent[eval.ID] = wrapped
+		b.stats.TotalDependent++
+		return
+	}
+
 	// If the eval has escaped, meaning computed node classes could not capture
 	// the constraints of the job, we store the eval separately as we have to
 	// unblock it whenever node capacity changes. This is because we don't know
@@ -257,6 +279,16 @@ func (b *BlockedEvals) processBlockJobDuplicate(eval *structs.Evaluation) (newCa
 			dup = eval
 			newCancelled = true
 		}
+	} else if existingW, ok = b.dependent[existingID]; ok {
+		if latestEvalIndex(existingW.eval) <= latestEvalIndex(eval) {
+			delete(b.dependent, existingID)
+			dup = existingW.eval
+			b.stats.TotalDependent--
+			b.stats.Unblock(dup)
+		} else {
+			dup = eval
+			newCancelled = true
+		}
 	} else {
 		existingW, ok = b.escaped[existingID]
 		if !ok {
@@ -350,6 +382,19 @@ func (b *BlockedEvals) missedUnblock(eval *structs.Evaluation) bool {
 	return false
 }
 
+// missedDependencyUnblock returns whether one of the jobs the evaluation
+// depends on changed while the evaluation was in the scheduler. This should be
+// called with the lock held.
+func (b *BlockedEvals) missedDependencyUnblock(eval *structs.Evaluation) bool {
+	for _, jobID := range eval.PendingDependencies {
+		index := b.dependencyIndexes[structs.NewNamespacedID(jobID, eval.Namespace)]
+		if eval.SnapshotIndex < index {
+			return true
+		}
+	}
+	return false
+}
+
 // Untrack causes any blocked evaluation for the passed job to be no longer
 // tracked. Untrack is called when there is a successful evaluation for the job
 // and a blocked evaluation is no longer needed.
@@ -398,6 +443,13 @@ func (b *BlockedEvals) Untrack(jobID, namespace string) {
 			b.stats.TotalQuotaLimit--
 		}
 	}
+
+	if w, ok := b.dependent[evalID]; ok {
+		delete(b.jobs, nsID)
+		delete(b.dependent, evalID)
+		b.stats.TotalDependent--
+		b.stats.Unblock(w.eval)
+	}
 }
 
 // Unblock causes any evaluation that could potentially make progress on a
@@ -500,6 +552,41 @@ func (b *BlockedEvals) UnblockClassAndQuota(class, quota string, index uint64) {
 	}
 }
 
+// UnblockJob enqueues the evaluations waiting on the dependencies of their job
+// when the passed job, which they depend on, changes. The scheduler then
+// checks whether the dependencies are met.
+func (b *BlockedEvals) UnblockJob(jobID, namespace string, index uint64) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	// Do nothing if not enabled
+	if !b.enabled {
+		return
+	}
+
+	// Store the index in which the unblock happened. We use this on subsequent
+	// block calls in case the evaluation was in the scheduler when the job
+	// changed.
+	b.dependencyIndexes[structs.NewNamespacedID(jobID, namespace)] = index
+
+	unblocked := make(map[*structs.Evaluation]string)
+	for id, wrapped := range b.dependent {
+		if wrapped.eval.Namespace != namespace || !slices.Contains(wrapped.eval.PendingDependencies, jobID) {
+			continue
+		}
+
+		unblocked[wrapped.eval] = wrapped.token
+		delete(b.dependent, id)
+		delete(b.jobs, structs.NewNamespacedID(wrapped.eval.JobID, wrapped.eval.Namespace))
+		b.stats.TotalDependent--
+		b.stats.Unblock(wrapped.eval)
+	}
+
+	if len(unblocked) != 0 {
+		b.evalBroker.EnqueueAll(unblocked)
+	}
+}
+
 // UnblockNode finds any blocked evalution that's node specific (system jobs) and enqueues
 // it on the eval broker
 func (b *BlockedEvals) UnblockNode(nodeID string, index uint64) {
@@ -695,11 +782,14 @@ func (b *BlockedEvals) Flush() {
 	b.stats.TotalEscaped = 0
 	b.stats.TotalBlocked = 0
 	b.stats.TotalQuotaLimit = 0
+	b.stats.TotalDependent = 0
 	b.stats.BlockedResources = NewBlockedResourcesStats()
 	b.captured = make(map[string]wrappedEval)
 	b.escaped = make(map[string]wrappedEval)
+	b.dependent = make(map[string]wrappedEval)
 	b.jobs = make(map[structs.NamespacedID]string)
 	b.unblockIndexes = make(map[string]uint64)
+	b.dependencyIndexes = make(map[structs.NamespacedID]uint64)
 	b.timetable = nil
 	b.duplicates = nil
 	b.capacityChangeCh = make(chan *capacityUpdate, unblockBuffer)
@@ -720,6 +810,7 @@ func (b *BlockedEvals) Stats() *BlockedStats {
 	stats.TotalEscaped = b.stats.TotalEscaped
 	stats.TotalBlocked = b.stats.TotalBlocked
 	stats.TotalQuotaLimit = b.stats.TotalQuotaLimit
+	stats.TotalDependent = b.stats.TotalDependent
 	stats.BlockedResources = b.stats.BlockedResources.Copy()
 
 	return stats
@@ -739,6 +830,7 @@ func (b *BlockedEvals) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 			metrics.SetGauge([]string{"nomad", "blocked_evals", "total_quota_limit"}, float32(stats.TotalQuotaLimit))
 			metrics.SetGauge([]string{"nomad", "blocked_evals", "total_blocked"}, float32(stats.TotalBlocked))
 			metrics.SetGauge([]string{"nomad", "blocked_evals", "total_escaped"}, float32(stats.TotalEscaped))
+			metrics.SetGauge([]string{"nomad", "blocked_evals", "total_dependent"}, float32(stats.TotalDependent))
 
 			for k, v := range stats.BlockedResources.ByJob {
 				labels := []metrics.Label{
@@ -796,6 +888,11 @@ func (b *BlockedEvals) pruneUnblockIndexes(cutoff time.Time) {
 			delete(b.unblockIndexes, key)
 		}
 	}
+	for key, index := range b.dependencyIndexes {
+		if index < oldThreshold {
+			delete(b.dependencyIndexes, key)
+		}
+	}
 }
 
 // pruneStats is used to prune any zero value stats that are excessively old.
@@ -22,6 +22,10 @@ type BlockedStats struct {
 	// to the quota limit being reached.
 	TotalQuotaLimit int
 
+	// TotalDependent is the total number of blocked evaluations that are
+	// waiting for the dependencies of their job to be met.
+	TotalDependent int
+
 	// BlockedResources stores the amount of resources requested by blocked
 	// evaluations.
 	BlockedResources *BlockedResourcesStats
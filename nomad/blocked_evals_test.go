@@ -350,6 +350,69 @@ func TestBlockedEvals_UnblockEligible_IncidentalQuota(t *testing.T) {
 	requireBlockedEvalsEnqueued(t, blocked, broker, 1)
 }
 
+func TestBlockedEvals_UnblockJob(t *testing.T) {
+	ci.Parallel(t)
+
+	blocked, broker := testBlockedEvals(t)
+
+	// Create a blocked eval waiting on two jobs.
+	e := mock.BlockedEval()
+	e.PendingDependencies = []string{"db", "cache"}
+	e.SnapshotIndex = 900
+	blocked.Block(e)
+
+	blockedStats := blocked.Stats()
+	must.Eq(t, 1, blockedStats.TotalBlocked)
+	must.Eq(t, 1, blockedStats.TotalDependent)
+
+	// Capacity changes and changes of unrelated jobs don't unblock it.
+	blocked.Unblock("v1:123", 1000)
+	blocked.UnblockJob("web", e.Namespace, 1001)
+	blocked.UnblockJob("db", "other", 1002)
+	must.Eq(t, 0, broker.Stats().TotalReady)
+	must.Eq(t, 1, blocked.Stats().TotalDependent)
+
+	blocked.UnblockJob("cache", e.Namespace, 1003)
+	requireBlockedEvalsEnqueued(t, blocked, broker, 1)
+	must.Eq(t, 0, blocked.Stats().TotalDependent)
+}
+
+func TestBlockedEvals_UnblockJob_Prior(t *testing.T) {
+	ci.Parallel(t)
+
+	blocked, broker := testBlockedEvals(t)
+
+	// The job depended on changed while the eval was in the scheduler.
+	blocked.UnblockJob("db", structs.DefaultNamespace, 1000)
+
+	e := mock.BlockedEval()
+	e.PendingDependencies = []string{"db"}
+	e.SnapshotIndex = 999
+	blocked.Block(e)
+
+	requireBlockedEvalsEnqueued(t, blocked, broker, 1)
+	must.Eq(t, 0, blocked.Stats().TotalDependent)
+}
+
+func TestBlockedEvals_Untrack_Dependent(t *testing.T) {
+	ci.Parallel(t)
+
+	blocked, _ := testBlockedEvals(t)
+
+	e := mock.BlockedEval()
+	e.PendingDependencies = []string{"db"}
+	blocked.Block(e)
+	must.Eq(t, 1, blocked.Stats().TotalDependent)
+
+	blocked.Untrack(e.JobID, e.Namespace)
+	blocked.pruneStats(time.Now().UTC())
+
+	blockedStats := blocked.Stats()
+	must.Eq(t, 0, blockedStats.TotalBlocked)
+	must.Eq(t, 0, blockedStats.TotalDependent)
+	must.MapLen(t, 0, blockedStats.BlockedResources.ByJob)
+}
+
 func TestBlockedEvals_UnblockIneligible_Quota(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
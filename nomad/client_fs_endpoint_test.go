@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
 
@@ -452,6 +453,71 @@ func TestClientFS_Stat_ACL(t *testing.T) {
 	}
 }
 
+func TestClientFS_Write_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	// Create a token that can only read the filesystem
+	policyBad := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadFS})
+	tokenBad := mock.CreatePolicyAndToken(t, s.State(), 1005, "invalid", policyBad)
+
+	policyGood := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityWriteFS})
+	tokenGood := mock.CreatePolicyAndToken(t, s.State(), 1009, "valid2", policyGood)
+
+	// Upsert the allocation
+	state := s.State()
+	alloc := mock.Alloc()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1010, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1011, []*structs.Allocation{alloc}))
+
+	cases := []struct {
+		Name          string
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Name:          "bad token",
+			Token:         tokenBad.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "good token",
+			Token:         tokenGood.SecretID,
+			ExpectedError: structs.ErrUnknownNodePrefix,
+		},
+		{
+			Name:          "root token",
+			Token:         root.SecretID,
+			ExpectedError: structs.ErrUnknownNodePrefix,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			qo := structs.QueryOptions{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+				AuthToken: c.Token,
+			}
+			reqs := map[string]any{
+				"FileSystem.Upload": &cstructs.FsUploadRequest{AllocID: alloc.ID, Path: "alloc/foo", QueryOptions: qo},
+				"FileSystem.Mkdir":  &cstructs.FsMkdirRequest{AllocID: alloc.ID, Path: "alloc/bar", QueryOptions: qo},
+				"FileSystem.Remove": &cstructs.FsRemoveRequest{AllocID: alloc.ID, Path: "alloc/foo", QueryOptions: qo},
+			}
+			for method, req := range reqs {
+				var resp structs.GenericResponse
+				err := msgpackrpc.CallWithCodec(codec, method, req, &resp)
+				must.ErrorContains(t, err, c.ExpectedError, must.Sprint(method))
+			}
+		})
+	}
+}
+
 func TestClientFS_Stat_Remote(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -733,6 +799,95 @@ func TestClientFS_Streaming_ACL(t *testing.T) {
 	}
 }
 
+func TestClientFS_Archive_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	// Create a bad token and a token that can only write
+	policyBad := mock.NamespacePolicy("other", "", []string{acl.NamespaceCapabilityReadFS})
+	tokenBad := mock.CreatePolicyAndToken(t, s.State(), 1005, "invalid", policyBad)
+
+	policyWrite := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityWriteFS})
+	tokenWrite := mock.CreatePolicyAndToken(t, s.State(), 1007, "write", policyWrite)
+
+	policyGood := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadFS})
+	tokenGood := mock.CreatePolicyAndToken(t, s.State(), 1009, "valid", policyGood)
+
+	// Upsert the allocation
+	state := s.State()
+	alloc := mock.Alloc()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1010, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1011, []*structs.Allocation{alloc}))
+
+	cases := []struct {
+		Name          string
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Name:          "bad token",
+			Token:         tokenBad.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "write token",
+			Token:         tokenWrite.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "good token",
+			Token:         tokenGood.SecretID,
+			ExpectedError: structs.ErrUnknownNodePrefix,
+		},
+		{
+			Name:          "root token",
+			Token:         root.SecretID,
+			ExpectedError: structs.ErrUnknownNodePrefix,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := &cstructs.FsArchiveRequest{
+				AllocID: alloc.ID,
+				Path:    "/",
+				QueryOptions: structs.QueryOptions{
+					Namespace: structs.DefaultNamespace,
+					Region:    "global",
+					AuthToken: c.Token,
+				},
+			}
+
+			// Get the handler
+			handler, err := s.StreamingRpcHandler("FileSystem.Archive")
+			must.NoError(t, err)
+
+			// Create a pipe
+			p1, p2 := net.Pipe()
+			defer p1.Close()
+			defer p2.Close()
+
+			// Start the handler
+			go handler(p2)
+
+			// Send the request
+			encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+			must.NoError(t, encoder.Encode(req))
+
+			// The request fails before reaching a client
+			var msg cstructs.StreamErrWrapper
+			decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+			must.NoError(t, decoder.Decode(&msg))
+			must.NotNil(t, msg.Error)
+			must.StrContains(t, msg.Error.Error(), c.ExpectedError)
+		})
+	}
+}
+
 func TestClientFS_Streaming_Local(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -235,6 +235,11 @@ type Config struct {
 	// rekey any variables associated with a key in the Rekeying state
 	VariablesRekeyInterval time.Duration
 
+	// RebalanceInterval is how often we dispatch a job to migrate
+	// allocations that violate their placement goals, when rebalancing is
+	// enabled in the scheduler configuration
+	RebalanceInterval time.Duration
+
 	// EvalNackTimeout controls how long we allow a sub-scheduler to
 	// work on an evaluation before we consider it failed and Nack it.
 	// This allows that evaluation to be handed to another sub-scheduler
@@ -419,6 +424,10 @@ type Config struct {
 	// DeploymentWatcher to throttle the amount of simultaneously deployments
 	DeploymentQueryRateLimit float64
 
+	// AnalysisProviders are the endpoints the http and query analysis gates of
+	// deployments can send requests to, keyed by name.
+	AnalysisProviders map[string]*structs.AnalysisProvider
+
 	// JobDefaultPriority is the default Job priority if not specified.
 	JobDefaultPriority int
 
@@ -462,6 +471,7 @@ func (c *Config) Copy() *Config {
 	nc.AutopilotConfig = c.AutopilotConfig.Copy()
 	nc.LicenseConfig = c.LicenseConfig.Copy()
 	nc.SearchConfig = c.SearchConfig.Copy()
+	nc.AnalysisProviders = helper.DeepCopyMap(c.AnalysisProviders)
 
 	return &nc
 }
@@ -573,6 +583,7 @@ func DefaultConfig() *Config {
 		RootKeyGCThreshold:               1 * time.Hour,
 		RootKeyRotationThreshold:         720 * time.Hour, // 30 days
 		VariablesRekeyInterval:           10 * time.Minute,
+		RebalanceInterval:                5 * time.Minute,
 		EvalNackTimeout:                  60 * time.Second,
 		EvalDeliveryLimit:                3,
 		EvalNackInitialReenqueueDelay:    1 * time.Second,
@@ -14,6 +14,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -68,6 +69,8 @@ func (c *CoreScheduler) Process(eval *structs.Evaluation) error {
 		return c.variablesRekey(eval)
 	case structs.CoreJobForceGC:
 		return c.forceGC(eval)
+	case structs.CoreJobRebalance:
+		return c.rebalance(eval)
 	default:
 		return fmt.Errorf("core scheduler cannot handle job '%s'", eval.JobID)
 	}
@@ -1123,6 +1126,105 @@ func (c *CoreScheduler) rotateVariables(iter memdb.ResultIterator, eval *structs
 	return nil
 }
 
+// rebalance is used to migrate the allocations of service jobs that violate
+// their spread or affinity goals, or that run on over-utilized nodes. In dry
+// run mode the allocations are only reported.
+func (c *CoreScheduler) rebalance(eval *structs.Evaluation) error {
+	_, schedConfig, err := c.snap.SchedulerConfig()
+	if err != nil {
+		return err
+	}
+	if schedConfig == nil || !schedConfig.RebalanceConfig.Enabled {
+		return nil
+	}
+	config := &schedConfig.RebalanceConfig
+
+	candidates, err := rebalanceCandidates(c.logger, c.snap, config)
+	if err != nil {
+		return err
+	}
+
+	transitions := make(map[string]*structs.DesiredTransition, len(candidates))
+	jobs := make(map[structs.NamespacedID]struct{})
+	for _, candidate := range candidates {
+		alloc := candidate.Alloc
+		c.logger.Info("rebalancing allocation", "alloc_id", alloc.ID,
+			"job", alloc.JobID, "namespace", alloc.Namespace, "group", alloc.TaskGroup,
+			"node_id", alloc.NodeID, "reason", candidate.Reason, "dry_run", config.DryRun)
+		transitions[alloc.ID] = &structs.DesiredTransition{Migrate: pointer.Of(true)}
+		jobs[alloc.JobNamespacedID()] = struct{}{}
+	}
+
+	if config.DryRun || len(transitions) == 0 {
+		return nil
+	}
+
+	evals := make([]*structs.Evaluation, 0, len(jobs))
+	now := time.Now().UTC().UnixNano()
+	for jns := range jobs {
+		job, err := c.snap.JobByID(nil, jns.Namespace, jns.ID)
+		if err != nil {
+			return err
+		}
+		evals = append(evals, &structs.Evaluation{
+			ID:          uuid.Generate(),
+			Namespace:   job.Namespace,
+			Priority:    job.Priority,
+			Type:        job.Type,
+			TriggeredBy: structs.EvalTriggerRebalance,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+			CreateTime:  now,
+			ModifyTime:  now,
+		})
+	}
+
+	req := &structs.AllocUpdateDesiredTransitionRequest{
+		Allocs: transitions,
+		Evals:  evals,
+		WriteRequest: structs.WriteRequest{
+			Region:    c.srv.config.Region,
+			AuthToken: eval.LeaderACL,
+		},
+	}
+	var resp structs.GenericResponse
+	if err := c.srv.RPC("Alloc.UpdateDesiredTransition", req, &resp); err != nil {
+		c.logger.Error("rebalance failed to migrate allocations", "error", err)
+		return err
+	}
+	return nil
+}
+
+// rebalanceCandidates returns the allocations the rebalancer migrates in a
+// single run with the given configuration, up to its maximum number of
+// migrations.
+func rebalanceCandidates(logger log.Logger, snap *state.StateSnapshot,
+	config *structs.RebalanceConfig) ([]*scheduler.RebalanceCandidate, error) {
+
+	iter, err := snap.Jobs(memdb.NewWatchSet(), state.SortDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	rebalancer := scheduler.NewRebalancer(logger, snap, config)
+	maxMigrations := config.EffectiveMaxMigrations()
+
+	var candidates []*scheduler.RebalanceCandidate
+	for i := iter.Next(); i != nil && len(candidates) < maxMigrations; i = iter.Next() {
+		job := i.(*structs.Job)
+
+		jobCandidates, err := rebalancer.JobCandidates(job)
+		if err != nil {
+			logger.Error("rebalance failed to find candidates for job",
+				"job", job.ID, "namespace", job.Namespace, "error", err)
+			continue
+		}
+		n := min(len(jobCandidates), maxMigrations-len(candidates))
+		candidates = append(candidates, jobCandidates[:n]...)
+	}
+	return candidates, nil
+}
+
 // getThreshold returns the index threshold for determining whether an
 // object is old enough to GC
 func (c *CoreScheduler) getThreshold(eval *structs.Evaluation, objectName, configName string, configThreshold time.Duration) uint64 {
@@ -1911,6 +1911,80 @@ func TestCoreScheduler_DeploymentGC(t *testing.T) {
 	assert.NotNil(out3, "Terminal Deployment With Allocs")
 }
 
+func TestCoreScheduler_Rebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Place the allocations of a job on an over-utilized node
+	store := s1.fsm.State()
+	node1, node2 := mock.Node(), mock.Node()
+	node1.Utilization = &structs.NodeUtilization{CPUPercent: 95}
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node1))
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1001, node2))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Migrate = &structs.MigrateStrategy{MaxParallel: 2}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node1.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1003, allocs))
+
+	rebalance := func(config structs.RebalanceConfig, index uint64) {
+		must.NoError(t, store.SchedulerSetConfig(index, &structs.SchedulerConfiguration{
+			RebalanceConfig: config,
+		}))
+		snap, err := store.Snapshot()
+		must.NoError(t, err)
+		core := NewCoreScheduler(s1, snap)
+		must.NoError(t, core.Process(s1.coreJobEval(structs.CoreJobRebalance, index)))
+	}
+	migrating := func() int {
+		n := 0
+		for _, alloc := range allocs {
+			out, err := store.AllocByID(nil, alloc.ID)
+			must.NoError(t, err)
+			if out.DesiredTransition.ShouldMigrate() {
+				n++
+			}
+		}
+		return n
+	}
+
+	config := structs.RebalanceConfig{
+		Enabled:              true,
+		DryRun:               true,
+		Namespaces:           []string{"*"},
+		MaxMigrations:        1,
+		UtilizationThreshold: 80,
+	}
+
+	// Dry run doesn't migrate allocations
+	rebalance(config, 1004)
+	must.Zero(t, migrating())
+
+	// MaxMigrations limits the number of migrated allocations
+	config.DryRun = false
+	rebalance(config, 1005)
+	must.Eq(t, 1, migrating())
+
+	evals, err := store.EvalsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, evals)
+	must.Eq(t, structs.EvalTriggerRebalance, evals[0].TriggeredBy)
+}
+
 func TestCoreScheduler_DeploymentGC_Force(t *testing.T) {
 	ci.Parallel(t)
 	for _, withAcl := range []bool{false, true} {
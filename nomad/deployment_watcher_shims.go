@@ -4,7 +4,17 @@
 package nomad
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	dproto "github.com/hashicorp/nomad/plugins/drivers/proto"
 )
 
 // deploymentWatcherRaftShim is the shim that provides the state watching
@@ -57,3 +67,142 @@ func (d *deploymentWatcherRaftShim) UpdateAllocDesiredTransition(req *structs.Al
 	fsmErrIntf, index, raftErr := d.apply(structs.AllocUpdateDesiredTransitionRequestType, req)
 	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
 }
+
+func (d *deploymentWatcherRaftShim) UpdateDeploymentAnalysis(req *structs.ApplyDeploymentAnalysisRequest) (uint64, error) {
+	fsmErrIntf, index, raftErr := d.apply(structs.DeploymentAnalysisRequestType, req)
+	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
+}
+
+// deploymentActionRunner runs the job actions of the action analysis gates of
+// deployments, by executing them in the allocation through its client.
+type deploymentActionRunner struct {
+	srv *Server
+}
+
+const (
+	// maxActionOutput limits the output of an action that is kept.
+	maxActionOutput = 4096
+
+	// actionIdentityTTL is how long the identity used to run an action is
+	// valid. It only needs to outlive the start of the exec session.
+	actionIdentityTTL = time.Minute
+)
+
+func (r *deploymentActionRunner) RunAction(ctx context.Context, alloc *structs.Allocation, task, action string) (int, []byte, error) {
+	token, err := r.actionIdentity(alloc, task)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	conn, err := r.clientConn(alloc.NodeID)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer conn.Close()
+
+	// Close the connection when the context is done to unblock the decoder
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+
+	req := &cstructs.AllocExecRequest{
+		AllocID: alloc.ID,
+		Task:    task,
+		Action:  action,
+		QueryOptions: structs.QueryOptions{
+			Region:    r.srv.Region(),
+			Namespace: alloc.Namespace,
+			AuthToken: token,
+		},
+	}
+	if err := encoder.Encode(req); err != nil {
+		return 0, nil, err
+	}
+
+	// Actions don't read any input
+	stdin := &drivers.ExecTaskStreamingRequestMsg{
+		Stdin: &dproto.ExecTaskStreamingIOOperation{Close: true},
+	}
+	if err := encoder.Encode(stdin); err != nil {
+		return 0, nil, err
+	}
+
+	var output []byte
+	for {
+		var msg cstructs.StreamErrWrapper
+		if err := decoder.Decode(&msg); err != nil {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			return 0, output, err
+		}
+		if msg.Error != nil {
+			return 0, output, msg.Error
+		}
+
+		var frame drivers.ExecTaskStreamingResponseMsg
+		if err := json.Unmarshal(msg.Payload, &frame); err != nil {
+			return 0, output, err
+		}
+		for _, o := range []*dproto.ExecTaskStreamingIOOperation{frame.Stdout, frame.Stderr} {
+			if o != nil && len(output) < maxActionOutput {
+				output = append(output, o.Data[:min(len(o.Data), maxActionOutput-len(output))]...)
+			}
+		}
+		if frame.Exited {
+			if frame.Result == nil {
+				return 0, output, nil
+			}
+			return int(frame.Result.ExitCode), output, nil
+		}
+	}
+}
+
+// actionIdentity signs a short-lived workload identity for the task of the
+// allocation, with the audience clients only accept to run the actions of
+// that task. The leader ACL token must never be sent to clients.
+func (r *deploymentActionRunner) actionIdentity(alloc *structs.Allocation, task string) (string, error) {
+	if alloc.Job == nil {
+		return "", fmt.Errorf("allocation %q has no job", alloc.ID)
+	}
+
+	wihandle := &structs.WIHandle{
+		WorkloadIdentifier: task,
+		WorkloadType:       structs.WorkloadTypeTask,
+	}
+	wid := &structs.WorkloadIdentity{
+		Name:     structs.WorkloadIdentityDeploymentActionName,
+		Audience: []string{structs.WorkloadIdentityDeploymentActionAud},
+		TTL:      actionIdentityTTL,
+	}
+	claims := structs.NewIdentityClaims(alloc.Job, alloc, wihandle, wid, time.Now())
+	if claims == nil {
+		return "", fmt.Errorf("task %q not found in allocation %q", task, alloc.ID)
+	}
+
+	token, _, err := r.srv.encrypter.SignClaims(claims)
+	return token, err
+}
+
+// clientConn returns a streaming connection to the exec endpoint of the node,
+// either directly or by forwarding to the server connected to the node.
+func (r *deploymentActionRunner) clientConn(nodeID string) (net.Conn, error) {
+	if state, ok := r.srv.getNodeConn(nodeID); ok {
+		return NodeStreamingRpc(state.Session, "Allocations.Exec")
+	}
+
+	srv, err := r.srv.serverWithNodeConn(nodeID, r.srv.Region())
+	if err != nil {
+		return nil, err
+	}
+	return r.srv.streamingRpc(srv, "Allocations.Exec")
+}
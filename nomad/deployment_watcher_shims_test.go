@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestDeploymentActionRunner_ActionIdentity(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	t.Cleanup(cleanupS1)
+	codec := rpcClient(t, s1)
+	testutil.WaitForKeyring(t, s1.RPC, "global")
+
+	alloc := mock.Alloc()
+	must.NoError(t, s1.fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, alloc.Job))
+	must.NoError(t, s1.fsm.State().UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	runner := &deploymentActionRunner{srv: s1}
+	_, err := runner.actionIdentity(alloc, "missing")
+	must.ErrorContains(t, err, `task "missing" not found`)
+
+	token, err := runner.actionIdentity(alloc, "web")
+	must.NoError(t, err)
+	must.NotEq(t, s1.getLeaderAcl(), token)
+
+	// Ensure the identity resolves to the task of the allocation with the
+	// deployment action audience
+	req := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{Region: "global", AuthToken: token},
+	}
+	var resp structs.ACLWhoAmIResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.WhoAmI", req, &resp))
+	must.NotNil(t, resp.Identity.Claims)
+	must.Eq(t, alloc.ID, resp.Identity.Claims.AllocationID)
+	must.Eq(t, "web", resp.Identity.Claims.TaskName)
+	must.Eq(t, []string{structs.WorkloadIdentityDeploymentActionAud}, []string(resp.Identity.Claims.Audience))
+
+	// Ensure the identity is short-lived
+	claims, err := s1.encrypter.VerifyClaim(token)
+	must.NoError(t, err)
+	must.NotNil(t, claims.Expiry)
+	must.Eq(t, claims.IssuedAt.Time().Add(actionIdentityTTL), claims.Expiry.Time())
+}
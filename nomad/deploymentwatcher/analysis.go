@@ -0,0 +1,420 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// maxAnalysisResponseBody limits how much of the response of an http or
+	// query gate is read. Responses are never recorded in the results of the
+	// gates, which are readable by anyone who can read the deployment.
+	maxAnalysisResponseBody = 64 * 1024
+)
+
+// ActionRunner runs the job actions of action analysis gates.
+type ActionRunner interface {
+	// RunAction runs the action of the task in the allocation and returns its
+	// exit code and output.
+	RunAction(ctx context.Context, alloc *structs.Allocation, task, action string) (int, []byte, error)
+}
+
+// analyzer runs the analysis gates of deployments.
+type analyzer struct {
+	client    *http.Client
+	providers map[string]*structs.AnalysisProvider
+	actions   ActionRunner
+}
+
+func newAnalyzer(providers map[string]*structs.AnalysisProvider, actions ActionRunner) *analyzer {
+	return &analyzer{
+		client:    cleanhttp.DefaultClient(),
+		providers: providers,
+		actions:   actions,
+	}
+}
+
+// provider returns the analysis provider the gate references. Jobs are
+// validated against the providers on submission, but the configuration of the
+// server may have changed since.
+func (a *analyzer) provider(gate *structs.AnalysisGate) (*structs.AnalysisProvider, error) {
+	p, ok := a.providers[gate.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown analysis provider %q", gate.Provider)
+	}
+	if p.Type != gate.Type {
+		return nil, fmt.Errorf("analysis provider %q has type %q", gate.Provider, p.Type)
+	}
+	return p, nil
+}
+
+// runGate runs the gate and returns why it failed, if it did. The allocs are
+// the healthy allocations of the task group the gate runs for.
+func (a *analyzer) runGate(ctx context.Context, gate *structs.AnalysisGate, allocs []*structs.Allocation) error {
+	timeout := gate.Timeout
+	if timeout <= 0 {
+		timeout = structs.DefaultAnalysisGateTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch gate.Type {
+	case structs.AnalysisGateTypeHTTP:
+		p, err := a.provider(gate)
+		if err != nil {
+			return err
+		}
+		return a.runHTTP(ctx, p)
+	case structs.AnalysisGateTypeQuery:
+		p, err := a.provider(gate)
+		if err != nil {
+			return err
+		}
+		return a.runQuery(ctx, p, gate.Query)
+	case structs.AnalysisGateTypeAction:
+		return a.runAction(ctx, gate, allocs)
+	default:
+		return fmt.Errorf("unknown gate type %q", gate.Type)
+	}
+}
+
+// runHTTP passes if the endpoint of the provider responds with a 2xx status
+// code.
+func (a *analyzer) runHTTP(ctx context.Context, p *structs.AnalysisProvider) error {
+	method := p.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := newProviderRequest(ctx, p, method, p.Address)
+	if err != nil {
+		return err
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return requestError(err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxAnalysisResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// queryResponse is the response of the instant query endpoint of the
+// Prometheus HTTP API.
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// runQuery passes if the query returns at least one series, or a non-zero
+// scalar.
+func (a *analyzer) runQuery(ctx context.Context, p *structs.AnalysisProvider, query string) error {
+	u := strings.TrimSuffix(p.Address, "/") + "/api/v1/query?" +
+		url.Values{"query": []string{query}}.Encode()
+	req, err := newProviderRequest(ctx, p, http.MethodGet, u)
+	if err != nil {
+		return err
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return requestError(err)
+	}
+	defer resp.Body.Close()
+
+	var out queryResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxAnalysisResponseBody)).Decode(&out); err != nil {
+		return fmt.Errorf("failed to decode query response with code %d", resp.StatusCode)
+	}
+	if out.Status != "success" {
+		return fmt.Errorf("query failed: %s", out.Error)
+	}
+
+	switch out.Data.ResultType {
+	case "vector", "matrix":
+		var series []json.RawMessage
+		if err := json.Unmarshal(out.Data.Result, &series); err != nil {
+			return errors.New("failed to decode query result")
+		}
+		if len(series) == 0 {
+			return errors.New("query returned no result")
+		}
+	case "scalar":
+		// Scalars are returned as [<time>, "<value>"]
+		var sample []any
+		if err := json.Unmarshal(out.Data.Result, &sample); err != nil || len(sample) != 2 {
+			return errors.New("failed to decode query result")
+		}
+		s, _ := sample[1].(string)
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return errors.New("invalid scalar value")
+		}
+		if v == 0 {
+			return errors.New("query returned zero")
+		}
+	default:
+		return fmt.Errorf("unsupported query result type %q", out.Data.ResultType)
+	}
+	return nil
+}
+
+// newProviderRequest returns a request to the provider with its headers.
+func newProviderRequest(ctx context.Context, p *structs.AnalysisProvider, method, u string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range p.Headers {
+		req.Header.Set(k, v)
+	}
+	return req, nil
+}
+
+// requestError returns the error of a request to a provider without its URL,
+// which may contain credentials.
+func requestError(err error) error {
+	var uErr *url.Error
+	if errors.As(err, &uErr) {
+		return fmt.Errorf("request failed: %w", uErr.Err)
+	}
+	return err
+}
+
+// runAction passes if the action of the gate exits successfully in a healthy
+// allocation of the deployment.
+func (a *analyzer) runAction(ctx context.Context, gate *structs.AnalysisGate, allocs []*structs.Allocation) error {
+	if a.actions == nil {
+		return errors.New("action gates are not supported")
+	}
+	if len(allocs) == 0 {
+		return errors.New("no healthy allocation to run the action in")
+	}
+
+	alloc := allocs[0]
+	code, output, err := a.actions.RunAction(ctx, alloc, gate.Task, gate.Action)
+	if err != nil {
+		return fmt.Errorf("failed to run action in allocation %s: %v", alloc.ID, err)
+	}
+	if code != 0 {
+		return fmt.Errorf("action exited with code %d in allocation %s: %s",
+			code, alloc.ID, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// analysisDue returns whether the gates of the current analysis step of the
+// task group can run. In the canary phase of the deployment the gates of the
+// first step run once every canary is healthy, the task group is then
+// promoted once it passes. Otherwise they run once the allocations of the step
+// are healthy.
+func analysisDue(analysis *structs.AnalysisStrategy, dstate *structs.DeploymentState) bool {
+	if analysis.Passed(dstate.AnalysisStep) {
+		return false
+	}
+
+	if dstate.DesiredCanaries > 0 && !dstate.Promoted {
+		return dstate.AnalysisStep == 0 &&
+			len(dstate.PlacedCanaries) >= dstate.DesiredCanaries &&
+			dstate.HealthyAllocs >= dstate.DesiredCanaries
+	}
+
+	target, _ := analysis.StepTarget(dstate.AnalysisStep, dstate.DesiredTotal)
+	return dstate.HealthyAllocs >= target
+}
+
+// groupAnalysis returns the analysis of the task group of the job, if any.
+func groupAnalysis(job *structs.Job, group string) *structs.AnalysisStrategy {
+	tg := job.LookupTaskGroup(group)
+	if tg == nil || tg.Update == nil {
+		return nil
+	}
+	return tg.Update.Analysis
+}
+
+// checkAnalysis starts the gates of the first task group whose current
+// analysis step is due. Only one analysis runs at a time for a deployment.
+func (w *deploymentWatcher) checkAnalysis() {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	if w.analyzing || w.d.Status != structs.DeploymentStatusRunning {
+		return
+	}
+
+	for name, dstate := range w.d.TaskGroups {
+		analysis := groupAnalysis(w.j, name)
+		if analysis == nil || dstate.AnalysisStep < w.analyzed[name] || !analysisDue(analysis, dstate) {
+			continue
+		}
+
+		// Track the started step so a stale deployment doesn't run the gates
+		// of a step twice
+		w.analyzing = true
+		w.analyzed[name] = dstate.AnalysisStep + 1
+		go w.runAnalysis(name, dstate.AnalysisStep, dstate.AutoRevert, analysis)
+		return
+	}
+}
+
+// runAnalysis runs the gates of the analysis step of the task group and records
+// their results. Passing the last step of a canary task group promotes the
+// deployment once it is ready, while a failing gate fails the deployment and
+// rolls it back if the task group auto-reverts.
+func (w *deploymentWatcher) runAnalysis(group string, step int, autoRevert bool, analysis *structs.AnalysisStrategy) {
+	defer func() {
+		w.l.Lock()
+		w.analyzing = false
+		w.l.Unlock()
+
+		// Check whether another task group or step is due
+		select {
+		case w.deploymentUpdateCh <- struct{}{}:
+		default:
+		}
+	}()
+
+	logger := w.logger.With("task_group", group, "step", analysis.Steps[step])
+	if analysis.Delay > 0 {
+		timer := time.NewTimer(analysis.Delay)
+		defer timer.Stop()
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-timer.C:
+		}
+	}
+
+	allocs, err := w.healthyAllocs(group)
+	if err != nil {
+		logger.Error("failed to lookup healthy allocations", "error", err)
+		w.retryAnalysis(group, step)
+		return
+	}
+
+	passed := true
+	results := make([]*structs.AnalysisResult, 0, len(analysis.Gates))
+	for _, gate := range analysis.Gates {
+		err := w.analyzer.runGate(w.ctx, gate, allocs)
+		if w.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.Warn("analysis gate failed", "gate", gate.Name, "error", err)
+			passed = false
+		}
+		results = append(results, structs.NewAnalysisResult(analysis.Steps[step], gate.Name, err))
+	}
+
+	req := &structs.ApplyDeploymentAnalysisRequest{
+		DeploymentID: w.deploymentID,
+		TaskGroup:    group,
+		Results:      results,
+		Passed:       passed,
+		Eval:         w.getEval(),
+	}
+	if !passed {
+		desc := structs.DeploymentStatusDescriptionFailedAnalysis
+		if autoRevert {
+			j, err := w.latestStableJob()
+			if err != nil {
+				logger.Error("failed to lookup latest stable job", "error", err)
+			}
+			if j != nil {
+				req.Job, desc = w.handleRollbackValidity(j, desc)
+			} else {
+				desc = structs.DeploymentStatusDescriptionNoRollbackTarget(desc)
+			}
+		}
+		req.DeploymentUpdate = w.getDeploymentStatusUpdate(structs.DeploymentStatusFailed, desc)
+	}
+
+	if _, err := w.upsertDeploymentAnalysis(req); err != nil {
+		logger.Error("failed to record analysis results", "error", err)
+		w.retryAnalysis(group, step)
+		return
+	}
+	logger.Debug("analysis completed", "passed", passed)
+
+	if passed {
+		if err := w.promoteAnalyzed(); err != nil {
+			logger.Error("failed to auto promote deployment", "error", err)
+		}
+	}
+}
+
+// retryAnalysis allows the gates of the analysis step of the task group to run
+// again after they couldn't be recorded.
+func (w *deploymentWatcher) retryAnalysis(group string, step int) {
+	w.l.Lock()
+	defer w.l.Unlock()
+	w.analyzed[group] = step
+}
+
+// promoteAnalyzed promotes the deployment using its latest state, after the
+// canaries of a task group passed their analysis.
+func (w *deploymentWatcher) promoteAnalyzed() error {
+	snap, err := w.state.Snapshot()
+	if err != nil {
+		return err
+	}
+	d, err := snap.DeploymentByID(nil, w.deploymentID)
+	if err != nil || d == nil {
+		return err
+	}
+	allocs, err := snap.AllocsByDeployment(nil, w.deploymentID)
+	if err != nil {
+		return err
+	}
+
+	stubs := make([]*structs.AllocListStub, 0, len(allocs))
+	for _, alloc := range allocs {
+		stubs = append(stubs, alloc.Stub(nil))
+	}
+	return w.autoPromote(d, stubs)
+}
+
+// healthyAllocs returns the running allocations of the task group in the
+// deployment that are healthy.
+func (w *deploymentWatcher) healthyAllocs(group string) ([]*structs.Allocation, error) {
+	snap, err := w.state.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	allocs, err := snap.AllocsByDeployment(nil, w.deploymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	healthy := make([]*structs.Allocation, 0, len(allocs))
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != group || alloc.TerminalStatus() || !alloc.DeploymentStatus.IsHealthy() {
+			continue
+		}
+		healthy = append(healthy, alloc)
+	}
+	return healthy, nil
+}
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestAnalyzer_QueryGate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		response string
+		err      string
+	}{
+		{
+			name:     "vector",
+			response: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1,"0.5"]}]}}`,
+		},
+		{
+			name:     "empty vector",
+			response: `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			err:      "query returned no result",
+		},
+		{
+			name:     "scalar",
+			response: `{"status":"success","data":{"resultType":"scalar","result":[1,"1"]}}`,
+		},
+		{
+			name:     "zero scalar",
+			response: `{"status":"success","data":{"resultType":"scalar","result":[1,"0"]}}`,
+			err:      "query returned zero",
+		},
+		{
+			name:     "error",
+			response: `{"status":"error","error":"parse error"}`,
+			err:      "query failed: parse error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				must.Eq(t, "/api/v1/query", r.URL.Path)
+				must.Eq(t, "up == 1", r.URL.Query().Get("query"))
+				rw.Write([]byte(tc.response))
+			}))
+			defer srv.Close()
+
+			providers := map[string]*structs.AnalysisProvider{
+				"prometheus": {Name: "prometheus", Type: structs.AnalysisGateTypeQuery, Address: srv.URL},
+			}
+			gate := &structs.AnalysisGate{
+				Name:     "query",
+				Type:     structs.AnalysisGateTypeQuery,
+				Provider: "prometheus",
+				Query:    "up == 1",
+				Timeout:  5 * time.Second,
+			}
+			err := newAnalyzer(providers, nil).runGate(context.Background(), gate, nil)
+			if tc.err == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestAnalyzer_HTTPGate(t *testing.T) {
+	ci.Parallel(t)
+
+	var code atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		must.Eq(t, http.MethodPost, r.Method)
+		must.Eq(t, "Bearer secret", r.Header.Get("Authorization"))
+		rw.WriteHeader(int(code.Load()))
+		rw.Write([]byte("internal response"))
+	}))
+	defer srv.Close()
+
+	providers := map[string]*structs.AnalysisProvider{
+		"webhook": {
+			Name:    "webhook",
+			Type:    structs.AnalysisGateTypeHTTP,
+			Address: srv.URL,
+			Method:  http.MethodPost,
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+		},
+	}
+	gate := &structs.AnalysisGate{
+		Name:     "webhook",
+		Type:     structs.AnalysisGateTypeHTTP,
+		Provider: "webhook",
+		Timeout:  5 * time.Second,
+	}
+	a := newAnalyzer(providers, nil)
+
+	code.Store(http.StatusOK)
+	must.NoError(t, a.runGate(context.Background(), gate, nil))
+
+	// The response of the provider isn't part of the error, which is recorded
+	// in the results of the deployment
+	code.Store(http.StatusServiceUnavailable)
+	err := a.runGate(context.Background(), gate, nil)
+	must.EqError(t, err, "unexpected response code 503")
+}
+
+func TestAnalyzer_UnknownProvider(t *testing.T) {
+	ci.Parallel(t)
+
+	providers := map[string]*structs.AnalysisProvider{
+		"prometheus": {Name: "prometheus", Type: structs.AnalysisGateTypeQuery, Address: "http://127.0.0.1:9090"},
+	}
+	a := newAnalyzer(providers, nil)
+
+	gate := &structs.AnalysisGate{
+		Name:     "webhook",
+		Type:     structs.AnalysisGateTypeHTTP,
+		Provider: "unknown",
+		Timeout:  5 * time.Second,
+	}
+	err := a.runGate(context.Background(), gate, nil)
+	must.EqError(t, err, `unknown analysis provider "unknown"`)
+
+	// Gates can't use a provider of another type
+	gate.Provider = "prometheus"
+	err = a.runGate(context.Background(), gate, nil)
+	must.EqError(t, err, `analysis provider "prometheus" has type "query"`)
+}
+
+type mockActionRunner struct {
+	code   int
+	output string
+	allocs []string
+}
+
+func (m *mockActionRunner) RunAction(_ context.Context, alloc *structs.Allocation, task, action string) (int, []byte, error) {
+	m.allocs = append(m.allocs, alloc.ID)
+	return m.code, []byte(m.output), nil
+}
+
+func TestAnalyzer_ActionGate(t *testing.T) {
+	ci.Parallel(t)
+
+	gate := &structs.AnalysisGate{
+		Name:    "smoke",
+		Type:    structs.AnalysisGateTypeAction,
+		Task:    "web",
+		Action:  "smoke",
+		Timeout: 5 * time.Second,
+	}
+	alloc := mock.Alloc()
+
+	// Action gates can't run without a runner or allocation
+	err := newAnalyzer(nil, nil).runGate(context.Background(), gate, []*structs.Allocation{alloc})
+	must.ErrorContains(t, err, "not supported")
+
+	runner := &mockActionRunner{}
+	err = newAnalyzer(nil, runner).runGate(context.Background(), gate, nil)
+	must.ErrorContains(t, err, "no healthy allocation")
+
+	must.NoError(t, newAnalyzer(nil, runner).runGate(context.Background(), gate, []*structs.Allocation{alloc}))
+	must.Eq(t, []string{alloc.ID}, runner.allocs)
+
+	runner.code, runner.output = 1, "smoke test failed"
+	err = newAnalyzer(nil, runner).runGate(context.Background(), gate, []*structs.Allocation{alloc})
+	must.ErrorContains(t, err, "exited with code 1")
+	must.ErrorContains(t, err, "smoke test failed")
+}
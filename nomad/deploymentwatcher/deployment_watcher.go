@@ -53,6 +53,10 @@ type deploymentTriggers interface {
 	// upsertDeploymentAllocHealth is used to set the health of allocations in a
 	// deployment
 	upsertDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error)
+
+	// upsertDeploymentAnalysis is used to record the results of the analysis
+	// gates of a task group in a deployment
+	upsertDeploymentAnalysis(req *structs.ApplyDeploymentAnalysisRequest) (uint64, error)
 }
 
 // deploymentWatcher is used to watch a single deployment and trigger the
@@ -101,6 +105,15 @@ type deploymentWatcher struct {
 	// by holding the lock or using the setter and getter methods.
 	latestEval uint64
 
+	// analyzer runs the analysis gates of the deployment
+	analyzer *analyzer
+
+	// analyzing marks whether analysis gates are running and analyzed is the
+	// number of analysis steps started for each task group. Access should be
+	// done through the lock.
+	analyzing bool
+	analyzed  map[string]int
+
 	logger log.Logger
 	ctx    context.Context
 	exitFn context.CancelFunc
@@ -112,7 +125,7 @@ type deploymentWatcher struct {
 func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 	logger log.Logger, state *state.StateStore, d *structs.Deployment,
 	j *structs.Job, triggers deploymentTriggers,
-	deploymentRPC DeploymentRPC, jobRPC JobRPC) *deploymentWatcher {
+	deploymentRPC DeploymentRPC, jobRPC JobRPC, analyzer *analyzer) *deploymentWatcher {
 
 	ctx, exitFn := context.WithCancel(parent)
 	w := &deploymentWatcher{
@@ -125,6 +138,8 @@ func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 		deploymentTriggers: triggers,
 		DeploymentRPC:      deploymentRPC,
 		JobRPC:             jobRPC,
+		analyzer:           analyzer,
+		analyzed:           make(map[string]int),
 		logger:             logger.With("deployment_id", d.ID, "job", j.NamespacedID()),
 		ctx:                ctx,
 		exitFn:             exitFn,
@@ -281,14 +296,20 @@ func (w *deploymentWatcher) PromoteDeployment(
 
 // autoPromoteDeployment creates a synthetic promotion request, and upserts it for processing
 func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStub) error {
-	d := w.getDeployment()
+	return w.autoPromote(w.getDeployment(), allocs)
+}
+
+// autoPromote promotes the deployment if the canaries of every task group are
+// ready to be promoted.
+func (w *deploymentWatcher) autoPromote(d *structs.Deployment, allocs []*structs.AllocListStub) error {
 	if !d.HasPlacedCanaries() || !d.RequiresPromotion() {
 		return nil
 	}
 
 	// AutoPromote iff every task group with canaries is marked auto_promote and is healthy. The whole
 	// job version has been incremented, so we promote together. See also AutoRevert
-	for _, dstate := range d.TaskGroups {
+	unpromoted := false
+	for name, dstate := range d.TaskGroups {
 
 		// skip auto promote canary validation if the task group has no canaries
 		// to prevent auto promote hanging on mixed canary/non-canary taskgroup deploys
@@ -296,6 +317,22 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 			continue
 		}
 
+		// Task groups waiting for the task groups they depend on are promoted
+		// once their canaries are placed
+		if dstate.Waiting() {
+			continue
+		}
+		unpromoted = unpromoted || !dstate.Promoted
+
+		// Canaries of task groups with an analysis are promoted once they
+		// passed the gates of its first step
+		if groupAnalysis(w.j, name) != nil {
+			if dstate.AnalysisStep == 0 {
+				return nil
+			}
+			continue
+		}
+
 		if !dstate.AutoPromote || len(dstate.PlacedCanaries) < dstate.DesiredCanaries {
 			return nil
 		}
@@ -313,6 +350,9 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 			return nil
 		}
 	}
+	if !unpromoted {
+		return nil
+	}
 
 	// Send the request
 	_, err := w.upsertDeploymentPromotion(&structs.ApplyDeploymentPromoteRequest{
@@ -502,6 +542,8 @@ FAIL:
 				break FAIL
 			}
 
+			w.checkAnalysis()
+
 		case updates = <-allocsCh:
 			if err := updates.err; err != nil {
 				if err == context.Canceled || w.ctx.Err() == context.Canceled {
@@ -542,6 +584,10 @@ FAIL:
 				w.logger.Error("failed to auto promote deployment", "error", err)
 			}
 
+			// Run the analysis gates of the next step once its allocations
+			// are healthy
+			w.checkAnalysis()
+
 			// Create an eval to push the deployment along
 			if res.createEval || len(res.allowReplacements) != 0 {
 				w.createBatchedUpdate(res.allowReplacements, allocIndex)
@@ -669,6 +715,11 @@ func (w *deploymentWatcher) shouldFail() (fail, rollback bool, err error) {
 
 	fail = false
 	for tg, dstate := range d.TaskGroups {
+		// Task groups waiting on their analysis gates have made progress
+		if analysis := groupAnalysis(w.j, tg); analysis != nil && analysisDue(analysis, dstate) {
+			continue
+		}
+
 		// If we are in a canary state we fail if there aren't enough healthy
 		// allocs to satisfy DesiredCanaries
 		if dstate.DesiredCanaries > 0 && !dstate.Promoted {
@@ -55,6 +55,10 @@ type DeploymentRaftEndpoints interface {
 	// UpdateAllocDesiredTransition is used to update the desired transition
 	// for allocations.
 	UpdateAllocDesiredTransition(req *structs.AllocUpdateDesiredTransitionRequest) (uint64, error)
+
+	// UpdateDeploymentAnalysis is used to record the results of the analysis
+	// gates of a task group in a deployment
+	UpdateDeploymentAnalysis(req *structs.ApplyDeploymentAnalysisRequest) (uint64, error)
 }
 
 // Watcher is used to watch deployments and their allocations created
@@ -84,6 +88,9 @@ type Watcher struct {
 	// server interface for Job RPCs
 	jobRPC JobRPC
 
+	// analyzer runs the analysis gates of deployments
+	analyzer *analyzer
+
 	// watchers is the set of active watchers, one per deployment
 	watchers map[string]*deploymentWatcher
 
@@ -103,6 +110,8 @@ type Watcher struct {
 func NewDeploymentsWatcher(logger log.Logger,
 	raft DeploymentRaftEndpoints,
 	deploymentRPC DeploymentRPC, jobRPC JobRPC,
+	actions ActionRunner,
+	providers map[string]*structs.AnalysisProvider,
 	stateQueriesPerSecond float64,
 	updateBatchDuration time.Duration,
 ) *Watcher {
@@ -111,6 +120,7 @@ func NewDeploymentsWatcher(logger log.Logger,
 		raft:                raft,
 		deploymentRPC:       deploymentRPC,
 		jobRPC:              jobRPC,
+		analyzer:            newAnalyzer(providers, actions),
 		queryLimiter:        rate.NewLimiter(rate.Limit(stateQueriesPerSecond), 100),
 		updateBatchDuration: updateBatchDuration,
 		logger:              logger.Named("deployments_watcher"),
@@ -301,7 +311,7 @@ func (w *Watcher) addLocked(d *structs.Deployment) (*deploymentWatcher, error) {
 	}
 
 	watcher := newDeploymentWatcher(w.ctx, w.queryLimiter, w.logger, w.state, d, job,
-		w, w.deploymentRPC, w.jobRPC)
+		w, w.deploymentRPC, w.jobRPC, w.analyzer)
 	w.watchers[d.ID] = watcher
 	return watcher, nil
 }
@@ -482,3 +492,8 @@ func (w *Watcher) upsertDeploymentPromotion(req *structs.ApplyDeploymentPromoteR
 func (w *Watcher) upsertDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error) {
 	return w.raft.UpdateDeploymentAllocHealth(req)
 }
+
+// upsertDeploymentAnalysis commits the given analysis results to Raft
+func (w *Watcher) upsertDeploymentAnalysis(req *structs.ApplyDeploymentAnalysisRequest) (uint64, error) {
+	return w.raft.UpdateDeploymentAnalysis(req)
+}
@@ -5,6 +5,9 @@ package deploymentwatcher
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -25,7 +28,7 @@ import (
 
 func testDeploymentWatcher(t *testing.T, qps float64, batchDur time.Duration) (*Watcher, *mockBackend) {
 	m := newMockBackend(t)
-	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, nil, nil, qps, batchDur)
+	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, nil, nil, nil, nil, qps, batchDur)
 	return w, m
 }
 
@@ -2132,3 +2135,175 @@ func TestWatcher_PurgeDeployment(t *testing.T) {
 		wait.Gap(10*time.Millisecond),
 	))
 }
+
+// testAnalysisUpdate returns an update strategy with an analysis whose http
+// gate sends its requests to url through a provider of the watcher.
+func testAnalysisUpdate(w *Watcher, url string, steps ...int) *structs.UpdateStrategy {
+	w.analyzer.providers = map[string]*structs.AnalysisProvider{
+		"webhook": {Name: "webhook", Type: structs.AnalysisGateTypeHTTP, Address: url},
+	}
+
+	u := structs.DefaultUpdateStrategy.Copy()
+	u.MaxParallel = 2
+	u.ProgressDeadline = 0
+	u.Analysis = &structs.AnalysisStrategy{
+		Steps: steps,
+		Gates: []*structs.AnalysisGate{{
+			Name:     "webhook",
+			Type:     structs.AnalysisGateTypeHTTP,
+			Provider: "webhook",
+			Timeout:  5 * time.Second,
+		}},
+	}
+	return u
+}
+
+// Tests that canaries that pass their analysis are promoted
+func TestWatcher_Analysis_PromoteCanaries(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer srv.Close()
+
+	j := mock.Job()
+	j.TaskGroups[0].Update = testAnalysisUpdate(w, srv.URL, 100)
+	j.TaskGroups[0].Update.Canary = 2
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups["web"].DesiredCanaries = 2
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		a := mock.Alloc()
+		a.DeploymentID = d.ID
+		a.DeploymentStatus = &structs.AllocDeploymentStatus{Canary: true}
+		allocs = append(allocs, a)
+		d.TaskGroups["web"].PlacedCanaries = append(d.TaskGroups["web"].PlacedCanaries, a.ID)
+	}
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+	must.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), allocs))
+
+	m.On("UpdateDeploymentAllocHealth", mocker.Anything).Return(nil)
+	m.On("UpdateDeploymentAnalysis", mocker.MatchedBy(func(req *structs.ApplyDeploymentAnalysisRequest) bool {
+		return req.DeploymentID == d.ID && req.Passed && req.DeploymentUpdate == nil && req.Eval != nil
+	})).Return(nil)
+	m.On("UpdateDeploymentPromotion", mocker.MatchedBy(matchDeploymentPromoteRequest(&matchDeploymentPromoteRequestConfig{
+		Promotion: &structs.DeploymentPromoteRequest{DeploymentID: d.ID, All: true},
+		Eval:      true,
+	}))).Return(nil)
+	m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+	w.SetEnabled(true, m.state)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return watchersCount(w) == 1 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// The canaries are not promoted before they are healthy
+	must.Eq(t, int32(0), requests.Load())
+
+	var resp structs.DeploymentUpdateResponse
+	must.NoError(t, w.SetAllocHealth(&structs.DeploymentAllocHealthRequest{
+		DeploymentID:         d.ID,
+		HealthyAllocationIDs: []string{allocs[0].ID, allocs[1].ID},
+	}, &resp))
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			out, _ := m.state.DeploymentByID(nil, d.ID)
+			return out.TaskGroups["web"].Promoted
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	out, err := m.state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	dstate := out.TaskGroups["web"]
+	must.Eq(t, 1, dstate.AnalysisStep)
+	must.Len(t, 1, dstate.AnalysisResults)
+	must.True(t, dstate.AnalysisResults[0].Passed())
+	must.Eq(t, int32(1), requests.Load())
+	m.AssertNumberOfCalls(t, "UpdateDeploymentAnalysis", 1)
+}
+
+// Tests that a failed analysis gate fails the deployment and rolls back the job
+func TestWatcher_Analysis_FailedGate_Rollback(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+		rw.Write([]byte("error rate too high"))
+	}))
+	defer srv.Close()
+
+	j := mock.Job()
+	j.TaskGroups[0].Count = 2
+	j.TaskGroups[0].Update = testAnalysisUpdate(w, srv.URL, 50, 100)
+	j.TaskGroups[0].Update.AutoRevert = true
+	j.Stable = true
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups["web"].AutoRevert = true
+	d.TaskGroups["web"].DesiredTotal = 2
+	a := mock.Alloc()
+	a.DeploymentID = d.ID
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+	must.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{a}))
+
+	// Upsert the job again to get a new version to roll back from
+	j2 := j.Copy()
+	j2.Stable = false
+	j2.Meta["foo"] = "bar"
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j2))
+
+	m.On("UpdateDeploymentAllocHealth", mocker.Anything).Return(nil)
+	m.On("UpdateDeploymentAnalysis", mocker.MatchedBy(func(req *structs.ApplyDeploymentAnalysisRequest) bool {
+		return req.DeploymentID == d.ID && !req.Passed &&
+			req.DeploymentUpdate != nil &&
+			req.DeploymentUpdate.Status == structs.DeploymentStatusFailed &&
+			req.DeploymentUpdate.StatusDescription == structs.DeploymentStatusDescriptionRollback(
+				structs.DeploymentStatusDescriptionFailedAnalysis, 0) &&
+			req.Job != nil && req.Job.Version == 0
+	})).Return(nil)
+	m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+	w.SetEnabled(true, m.state)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return watchersCount(w) == 1 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// The first step runs the gates once half of the allocations are healthy
+	var resp structs.DeploymentUpdateResponse
+	must.NoError(t, w.SetAllocHealth(&structs.DeploymentAllocHealthRequest{
+		DeploymentID:         d.ID,
+		HealthyAllocationIDs: []string{a.ID},
+	}, &resp))
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return watchersCount(w) == 0 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	out, err := m.state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.DeploymentStatusFailed, out.Status)
+	dstate := out.TaskGroups["web"]
+	must.Eq(t, 0, dstate.AnalysisStep)
+	must.Len(t, 1, dstate.AnalysisResults)
+	must.Eq(t, "unexpected response code 500", dstate.AnalysisResults[0].Message)
+	m.AssertNumberOfCalls(t, "UpdateDeploymentAnalysis", 1)
+}
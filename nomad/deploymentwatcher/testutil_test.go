@@ -179,6 +179,12 @@ func matchDeploymentPromoteRequest(c *matchDeploymentPromoteRequestConfig) func(
 		return true
 	}
 }
+func (m *mockBackend) UpdateDeploymentAnalysis(req *structs.ApplyDeploymentAnalysisRequest) (uint64, error) {
+	m.Called(req)
+	i := m.nextIndex()
+	return i, m.state.UpdateDeploymentAnalysis(structs.MsgTypeTestSetup, i, req)
+}
+
 func (m *mockBackend) UpdateDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error) {
 	m.Called(req)
 	i := m.nextIndex()
@@ -97,6 +97,10 @@ type allocMigrateBatcher struct {
 	// updates holds pending client status updates for allocations
 	updates []*structs.Allocation
 
+	// blocked holds pending allocations whose drain was held back by the
+	// disruption budget of their task group
+	blocked []*structs.Allocation
+
 	// updateFuture is used to wait for the pending batch update
 	// to complete. This may be nil if no batch is pending.
 	updateFuture *structs.BatchFuture
@@ -129,7 +133,7 @@ type NodeDrainerConfig struct {
 }
 
 // NodeDrainer is used to orchestrate migrating allocations off of draining
-// nodes.
+// nodes, and off of nodes with no_execute taints they do not tolerate.
 type NodeDrainer struct {
 	enabled bool
 	logger  log.Logger
@@ -150,6 +154,10 @@ type NodeDrainer struct {
 	deadlineNotifier        DrainDeadlineNotifier
 	deadlineNotifierFactory DrainDeadlineNotifierFactory
 
+	// taintWatcher watches nodes with no_execute taints and evicts the
+	// allocations that do not tolerate them.
+	taintWatcher *nodeTaintWatcher
+
 	// state is the state that is watched for state changes.
 	state *state.StateStore
 
@@ -220,6 +228,7 @@ func (n *NodeDrainer) flush(state *state.StateStore) {
 	n.jobWatcher = n.jobFactory(n.ctx, n.queryLimiter, n.state, n.logger)
 	n.nodeWatcher = n.nodeFactory(n.ctx, n.queryLimiter, n.state, n.logger, n)
 	n.deadlineNotifier = n.deadlineNotifierFactory(n.ctx)
+	n.taintWatcher = NewNodeTaintWatcher(n.ctx, n.queryLimiter, n.state, n.logger, n.batchDrain)
 	n.nodes = make(map[string]*drainingNode, 32)
 }
 
@@ -285,7 +294,7 @@ func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 // transition to drain. The handler blocks till the changes to the allocation
 // have occurred.
 func (n *NodeDrainer) handleJobAllocDrain(req *DrainRequest) {
-	index, err := n.batchDrainAllocs(req.Allocs)
+	index, err := n.batchDrain(req.Allocs, req.Blocked)
 	req.Resp.Respond(index, err)
 }
 
@@ -335,7 +344,7 @@ func (n *NodeDrainer) handleMigratedAllocs(allocs []*structs.Allocation) {
 	// Stop any running system jobs on otherwise done nodes
 	if len(remainingAllocs) > 0 {
 		future := structs.NewBatchFuture()
-		n.drainAllocs(future, remainingAllocs)
+		n.drainAllocs(future, remainingAllocs, nil)
 		if err := future.Wait(); err != nil {
 			n.logger.Error("failed to drain remaining allocs from done nodes", "num_allocs", len(remainingAllocs), "error", err)
 		}
@@ -358,9 +367,17 @@ func (n *NodeDrainer) handleMigratedAllocs(allocs []*structs.Allocation) {
 // batchDrainAllocs is used to batch the draining of allocations. It will block
 // until the batch is complete.
 func (n *NodeDrainer) batchDrainAllocs(allocs []*structs.Allocation) (uint64, error) {
+	return n.batchDrain(allocs, nil)
+}
+
+// batchDrain is used to batch the draining of allocations, along with the
+// allocations whose drain was blocked by disruption budgets. It will block
+// until the batch is complete.
+func (n *NodeDrainer) batchDrain(allocs, blocked []*structs.Allocation) (uint64, error) {
 	// Add this to the batch
 	n.batcher.Lock()
 	n.batcher.updates = append(n.batcher.updates, allocs...)
+	n.batcher.blocked = append(n.batcher.blocked, blocked...)
 
 	// Start a new batch if none
 	future := n.batcher.updateFuture
@@ -371,14 +388,16 @@ func (n *NodeDrainer) batchDrainAllocs(allocs []*structs.Allocation) (uint64, er
 			// Get the pending updates
 			n.batcher.Lock()
 			updates := n.batcher.updates
+			blocked := n.batcher.blocked
 			future := n.batcher.updateFuture
 			n.batcher.updates = nil
+			n.batcher.blocked = nil
 			n.batcher.updateFuture = nil
 			n.batcher.updateTimer = nil
 			n.batcher.Unlock()
 
 			// Perform the batch update
-			n.drainAllocs(future, updates)
+			n.drainAllocs(future, updates, blocked)
 		})
 	}
 	n.batcher.Unlock()
@@ -392,8 +411,8 @@ func (n *NodeDrainer) batchDrainAllocs(allocs []*structs.Allocation) (uint64, er
 
 // drainAllocs is a non batch, marking of the desired transition to migrate for
 // the set of allocations. It will also create the necessary evaluations for the
-// affected jobs.
-func (n *NodeDrainer) drainAllocs(future *structs.BatchFuture, allocs []*structs.Allocation) {
+// affected jobs, recording the drains blocked by disruption budgets on them.
+func (n *NodeDrainer) drainAllocs(future *structs.BatchFuture, allocs, blocked []*structs.Allocation) {
 	// Compute the effected jobs and make the transition map
 	jobs := make(map[structs.NamespacedID]*structs.Allocation, 4)
 	transitions := make(map[string]*structs.DesiredTransition, len(allocs))
@@ -404,19 +423,31 @@ func (n *NodeDrainer) drainAllocs(future *structs.BatchFuture, allocs []*structs
 		jobs[alloc.JobNamespacedID()] = alloc
 	}
 
+	// Count the blocked drains per job and task group
+	blockedDisruptions := make(map[structs.NamespacedID]map[string]int)
+	for _, alloc := range blocked {
+		jns := alloc.JobNamespacedID()
+		jobs[jns] = alloc
+		if blockedDisruptions[jns] == nil {
+			blockedDisruptions[jns] = make(map[string]int)
+		}
+		blockedDisruptions[jns][alloc.DisruptionKey()]++
+	}
+
 	evals := make([]*structs.Evaluation, 0, len(jobs))
 	now := time.Now().UTC().UnixNano()
-	for _, alloc := range jobs {
+	for jns, alloc := range jobs {
 		evals = append(evals, &structs.Evaluation{
-			ID:          uuid.Generate(),
-			Namespace:   alloc.Namespace,
-			Priority:    alloc.Job.Priority,
-			Type:        alloc.Job.Type,
-			TriggeredBy: structs.EvalTriggerNodeDrain,
-			JobID:       alloc.JobID,
-			Status:      structs.EvalStatusPending,
-			CreateTime:  now,
-			ModifyTime:  now,
+			ID:                 uuid.Generate(),
+			Namespace:          alloc.Namespace,
+			Priority:           alloc.Job.Priority,
+			Type:               alloc.Job.Type,
+			TriggeredBy:        structs.EvalTriggerNodeDrain,
+			JobID:              alloc.JobID,
+			Status:             structs.EvalStatusPending,
+			BlockedDisruptions: blockedDisruptions[jns],
+			CreateTime:         now,
+			ModifyTime:         now,
 		})
 	}
 
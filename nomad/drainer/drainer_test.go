@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package drainer
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// TestNodeDrainer_DrainAllocs_Blocked asserts the drains blocked by disruption
+// budgets are recorded on the evaluations created for their jobs.
+func TestNodeDrainer_DrainAllocs_Blocked(t *testing.T) {
+	ci.Parallel(t)
+
+	_, store, drainer := testNodeDrainWatcher(t)
+
+	drained := mock.Alloc()
+	blocked := []*structs.Allocation{mock.Alloc(), mock.Alloc()}
+	blocked[0].JobID = drained.JobID
+	blocked[0].Job = drained.Job
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 100,
+		append([]*structs.Allocation{drained}, blocked...)))
+
+	future := structs.NewBatchFuture()
+	drainer.drainAllocs(future, []*structs.Allocation{drained}, blocked)
+	must.NoError(t, future.Wait())
+
+	// The alloc that was drained is marked for migration, but not the
+	// blocked ones
+	out, err := store.AllocByID(nil, drained.ID)
+	must.NoError(t, err)
+	must.True(t, out.DesiredTransition.ShouldMigrate())
+	for _, alloc := range blocked {
+		out, err := store.AllocByID(nil, alloc.ID)
+		must.NoError(t, err)
+		must.False(t, out.DesiredTransition.ShouldMigrate())
+	}
+
+	// Both jobs get an eval recording their blocked drains
+	for _, alloc := range blocked {
+		evals, err := store.EvalsByJob(nil, alloc.Namespace, alloc.JobID)
+		must.NoError(t, err)
+		must.Len(t, 1, evals)
+		must.Eq(t, structs.EvalTriggerNodeDrain, evals[0].TriggeredBy)
+		must.Eq(t, map[string]int{alloc.DisruptionKey(): 1}, evals[0].BlockedDisruptions)
+	}
+}
@@ -6,6 +6,7 @@ package drainer
 import (
 	"context"
 	"fmt"
+	"maps"
 	"sync"
 
 	log "github.com/hashicorp/go-hclog"
@@ -19,7 +20,12 @@ import (
 
 type DrainRequest struct {
 	Allocs []*structs.Allocation
-	Resp   *structs.BatchFuture
+
+	// Blocked is the set of allocations that should have been drained but
+	// were held back by the disruption budget of their task group.
+	Blocked []*structs.Allocation
+
+	Resp *structs.BatchFuture
 }
 
 func NewDrainRequest(allocs []*structs.Allocation) *DrainRequest {
@@ -57,6 +63,10 @@ type drainingJobWatcher struct {
 	// jobs is the set of tracked jobs.
 	jobs map[structs.NamespacedID]struct{}
 
+	// blocked is the number of drains held back by disruption budgets per
+	// task group of the tracked jobs, as last reported to the drainer.
+	blocked map[structs.NamespacedID]map[string]int
+
 	// queryCtx is used to cancel a blocking query.
 	queryCtx    context.Context
 	queryCancel context.CancelFunc
@@ -84,6 +94,7 @@ func NewDrainingJobWatcher(ctx context.Context, limiter *rate.Limiter, state *st
 		logger:      logger.Named("job_watcher"),
 		state:       state,
 		jobs:        make(map[structs.NamespacedID]struct{}, 64),
+		blocked:     make(map[structs.NamespacedID]map[string]int),
 		drainCh:     make(chan *DrainRequest),
 		migratedCh:  make(chan []*structs.Allocation),
 	}
@@ -137,6 +148,7 @@ func (w *drainingJobWatcher) deregisterJob(jobID, namespace string) {
 		Namespace: namespace,
 	}
 	delete(w.jobs, jns)
+	delete(w.blocked, jns)
 	w.logger.Trace("deregistering job", "job", jns)
 }
 
@@ -190,7 +202,7 @@ func (w *drainingJobWatcher) watch() {
 		}
 
 		currentJobs := w.drainingJobs()
-		var allDrain, allMigrated []*structs.Allocation
+		var allDrain, allBlocked, allMigrated []*structs.Allocation
 		for jns, allocs := range jobAllocs {
 			// Check if the job is still registered
 			if _, ok := currentJobs[jns]; !ok {
@@ -231,16 +243,25 @@ func (w *drainingJobWatcher) watch() {
 			allDrain = append(allDrain, result.drain...)
 			allMigrated = append(allMigrated, result.migrated...)
 
+			// Only report blocked drains when they change, so that a drain
+			// held back by a disruption budget is recorded once rather than
+			// every time the job's allocations are updated
+			if w.updateBlocked(jns, result.blocked) {
+				allBlocked = append(allBlocked, result.blocked...)
+			}
+
 			// Stop tracking this job
 			if result.done {
 				w.deregisterJob(job.ID, job.Namespace)
 			}
 		}
 
-		if len(allDrain) != 0 {
+		if len(allDrain) != 0 || len(allBlocked) != 0 {
 			// Create the request
 			req := NewDrainRequest(allDrain)
-			w.logger.Trace("sending drain request for allocs", "num_allocs", len(allDrain))
+			req.Blocked = allBlocked
+			w.logger.Trace("sending drain request for allocs",
+				"num_allocs", len(allDrain), "num_blocked", len(allBlocked))
 
 			select {
 			case w.drainCh <- req:
@@ -280,12 +301,38 @@ func (w *drainingJobWatcher) watch() {
 	}
 }
 
+// updateBlocked records the drains of the job held back by disruption budgets
+// and returns whether they changed since they were last recorded.
+func (w *drainingJobWatcher) updateBlocked(jns structs.NamespacedID, blocked []*structs.Allocation) bool {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	counts := make(map[string]int)
+	for _, alloc := range blocked {
+		counts[alloc.TaskGroup]++
+	}
+	if maps.Equal(counts, w.blocked[jns]) {
+		return false
+	}
+
+	if len(counts) == 0 {
+		delete(w.blocked, jns)
+	} else {
+		w.blocked[jns] = counts
+	}
+	return true
+}
+
 // jobResult is the set of actions to take for a draining job given its current
 // state.
 type jobResult struct {
 	// drain is the set of allocations to emit for draining.
 	drain []*structs.Allocation
 
+	// blocked is the set of allocations that should have been drained but
+	// were held back by the disruption budget of their task group.
+	blocked []*structs.Allocation
+
 	// migrated is the set of allocations to emit as migrated
 	migrated []*structs.Allocation
 
@@ -302,7 +349,7 @@ func newJobResult() *jobResult {
 }
 
 func (r *jobResult) String() string {
-	return fmt.Sprintf("Drain %d ; Migrate %d ; Done %v", len(r.drain), len(r.migrated), r.done)
+	return fmt.Sprintf("Drain %d ; Blocked %d ; Migrate %d ; Done %v", len(r.drain), len(r.blocked), len(r.migrated), r.done)
 }
 
 // handleJob takes the state of a draining job and returns the desired actions.
@@ -311,9 +358,9 @@ func handleJob(snap *state.StateSnapshot, job *structs.Job, allocs []*structs.Al
 	batch := job.Type == structs.JobTypeBatch
 	taskGroups := make(map[string]*structs.TaskGroup, len(job.TaskGroups))
 	for _, tg := range job.TaskGroups {
-		// Only capture the groups that have a migrate strategy or we are just
-		// watching batch
-		if tg.Migrate != nil || batch {
+		// Only capture the groups that have a migrate strategy or disruption
+		// budget, or we are just watching batch
+		if tg.Migrate != nil || tg.DisruptionBudget != nil || batch {
 			taskGroups[tg.Name] = tg
 		}
 	}
@@ -348,6 +395,7 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 	// Determine how many allocations can be drained
 	drainingNodes := make(map[string]bool, 4)
 	healthy := 0
+	available := 0
 	remainingDrainingAlloc := false
 	var drainable []*structs.Allocation
 
@@ -383,6 +431,11 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 			healthy++
 		}
 
+		// Count the allocs available with regards to the disruption budget
+		if alloc.DisruptionAvailable() {
+			available++
+		}
+
 		// An alloc can't be considered for migration if:
 		// - It isn't on a draining node
 		// - It is already terminal on the client
@@ -412,9 +465,21 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 	}
 
 	// Determine how many we can drain
-	thresholdCount := tg.Count - tg.Migrate.MaxParallel
-	numToDrain := healthy - thresholdCount
-	numToDrain = min(len(drainable), numToDrain)
+	numToDrain := len(drainable)
+	if tg.Migrate != nil {
+		thresholdCount := tg.Count - tg.Migrate.MaxParallel
+		numToDrain = min(numToDrain, healthy-thresholdCount)
+	}
+
+	// Never drain more than the disruption budget allows, and record the
+	// drains it holds back
+	if tg.DisruptionBudget != nil {
+		allowed := max(0, tg.DisruptionBudget.AllowedDisruptions(tg.Count, available))
+		if numToDrain > allowed {
+			result.blocked = append(result.blocked, drainable[allowed:numToDrain]...)
+			numToDrain = allowed
+		}
+	}
 	if numToDrain <= 0 {
 		return nil
 	}
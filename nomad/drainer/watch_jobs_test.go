@@ -329,11 +329,15 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 		allocCount  int  // number of allocs in test (defaults to 10)
 		maxParallel int  // max_parallel (defaults to 1)
 
+		// disruptionBudget is set on the task group if not nil
+		disruptionBudget *structs.DisruptionBudget
+
 		// addAllocFn will be called allocCount times to create test allocs,
 		// and the allocs default to be healthy on the draining node
 		addAllocFn func(idx int, a *structs.Allocation, drainingID, runningID string)
 
 		expectDrained  int
+		expectBlocked  int
 		expectMigrated int
 		expectDone     bool
 	}{
@@ -577,6 +581,47 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 				}
 			},
 		},
+		{
+			// min_available=8 only allows 2 of the 10 running allocs to be
+			// drained even though max_parallel would allow more
+			name:             "disruption-budget-min-available",
+			expectDrained:    2,
+			expectBlocked:    3,
+			maxParallel:      5,
+			disruptionBudget: &structs.DisruptionBudget{MinAvailable: 8},
+			addAllocFn: func(i int, a *structs.Allocation, drainingID, runningID string) {
+				a.ClientStatus = structs.AllocClientStatusRunning
+			},
+		},
+		{
+			// max_unavailable=3 with one alloc already migrating leaves room
+			// for 2 more
+			name:             "disruption-budget-max-unavailable",
+			expectDrained:    2,
+			expectBlocked:    3,
+			maxParallel:      5,
+			disruptionBudget: &structs.DisruptionBudget{MaxUnavailable: 3},
+			addAllocFn: func(i int, a *structs.Allocation, drainingID, runningID string) {
+				a.ClientStatus = structs.AllocClientStatusRunning
+				if i == 0 {
+					a.DesiredTransition.Migrate = pointer.Of(true)
+				}
+			},
+		},
+		{
+			// allocs that are not running don't count as available so the
+			// budget blocks any drain
+			name:             "disruption-budget-exhausted",
+			expectDrained:    0,
+			expectBlocked:    5,
+			maxParallel:      5,
+			disruptionBudget: &structs.DisruptionBudget{MinAvailable: 8},
+			addAllocFn: func(i int, a *structs.Allocation, drainingID, runningID string) {
+				if i%2 == 0 {
+					a.ClientStatus = structs.AllocClientStatusRunning
+				}
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -599,6 +644,7 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 			if tc.maxParallel > 0 {
 				job.TaskGroups[0].Migrate.MaxParallel = tc.maxParallel
 			}
+			job.TaskGroups[0].DisruptionBudget = tc.disruptionBudget
 			must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, nil, job))
 
 			var allocs []*structs.Allocation
@@ -629,6 +675,7 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 			res := newJobResult()
 			must.NoError(t, handleTaskGroup(snap, tc.batch, job.TaskGroups[0], allocs, 102, res))
 			test.Len(t, tc.expectDrained, res.drain, test.Sprint("expected drained allocs"))
+			test.Len(t, tc.expectBlocked, res.blocked, test.Sprint("expected blocked allocs"))
 			test.Len(t, tc.expectMigrated, res.migrated, test.Sprint("expected migrated allocs"))
 			test.Eq(t, tc.expectDone, res.done)
 		})
@@ -775,3 +822,25 @@ func TestHandleTaskGroup_GarbageCollectedNode(t *testing.T) {
 	require.Empty(res.migrated)
 	require.True(res.done)
 }
+
+// TestDrainingJobWatcher_UpdateBlocked asserts drains blocked by disruption
+// budgets are only reported when they change.
+func TestDrainingJobWatcher_UpdateBlocked(t *testing.T) {
+	ci.Parallel(t)
+
+	w, cancel := testDrainingJobWatcher(t, state.TestStateStore(t))
+	defer cancel()
+
+	alloc := mock.Alloc()
+	jns := alloc.JobNamespacedID()
+
+	must.False(t, w.updateBlocked(jns, nil))
+	must.True(t, w.updateBlocked(jns, []*structs.Allocation{alloc}))
+	must.False(t, w.updateBlocked(jns, []*structs.Allocation{alloc}))
+	must.True(t, w.updateBlocked(jns, []*structs.Allocation{alloc, mock.Alloc()}))
+
+	// Unblocking the drains is reported once and forgets the job
+	must.True(t, w.updateBlocked(jns, nil))
+	must.False(t, w.updateBlocked(jns, nil))
+	must.MapEmpty(t, w.blocked)
+}
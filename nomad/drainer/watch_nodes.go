@@ -93,7 +93,7 @@ func (n *NodeDrainer) Update(node *structs.Node) {
 			n.logger.Error("error getting remaining allocs on drained node", "node_id", node.ID, "error", err)
 		} else if len(remaining) > 0 {
 			future := structs.NewBatchFuture()
-			n.drainAllocs(future, remaining)
+			n.drainAllocs(future, remaining, nil)
 			if err := future.Wait(); err != nil {
 				n.logger.Error("failed to drain remaining allocs from done node", "num_allocs", len(remaining), "node_id", node.ID, "error", err)
 			}
@@ -0,0 +1,304 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package drainer
+
+import (
+	"context"
+	"maps"
+
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper"
+
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/time/rate"
+)
+
+// AllocEvictFn is used to mark allocations for migration off of their node,
+// along with the allocations whose eviction was blocked by disruption budgets.
+// It blocks until the allocations have been updated.
+type AllocEvictFn func(allocs, blocked []*structs.Allocation) (uint64, error)
+
+// nodeTaintWatcher is used to watch nodes with no_execute taints and evict the
+// allocations running on them that do not tolerate the taints.
+type nodeTaintWatcher struct {
+	ctx    context.Context
+	logger log.Logger
+
+	// state is the state that is watched for state changes.
+	state *state.StateStore
+
+	// limiter is used to limit the rate of blocking queries
+	limiter *rate.Limiter
+
+	// evict is used to mark the allocations to evict for migration
+	evict AllocEvictFn
+
+	// blocked is the number of evictions held back by disruption budgets per
+	// job and task group, as last reported.
+	blocked map[structs.NamespacedID]map[string]int
+}
+
+// taintEvictions is the set of allocations to evict from tainted nodes.
+type taintEvictions struct {
+	// evict is the set of allocations to mark for migration.
+	evict []*structs.Allocation
+
+	// blocked is the set of allocations that should have been evicted but
+	// were held back by the disruption budget of their task group.
+	blocked []*structs.Allocation
+}
+
+// NewNodeTaintWatcher returns a new node taint watcher.
+func NewNodeTaintWatcher(ctx context.Context, limiter *rate.Limiter, state *state.StateStore, logger log.Logger, evict AllocEvictFn) *nodeTaintWatcher {
+	w := &nodeTaintWatcher{
+		ctx:     ctx,
+		limiter: limiter,
+		logger:  logger.Named("taint_watcher"),
+		state:   state,
+		evict:   evict,
+		blocked: make(map[structs.NamespacedID]map[string]int),
+	}
+
+	go w.watch()
+	return w
+}
+
+// watch is the long lived watching routine that detects allocations that must
+// be evicted from tainted nodes.
+func (w *nodeTaintWatcher) watch() {
+	timer, stop := helper.NewSafeTimer(stateReadErrorDelay)
+	defer stop()
+
+	windex := uint64(1)
+
+	for {
+		timer.Reset(stateReadErrorDelay)
+		evictions, index, err := w.getEvictableAllocs(windex)
+		if err == nil {
+			// Only report blocked evictions when they change, so that an
+			// eviction held back by a budget doesn't create an evaluation on
+			// every allocation update.
+			blocked := w.updateBlocked(evictions.blocked)
+			if len(evictions.evict) > 0 || len(blocked) > 0 {
+				w.logger.Debug("evicting allocs from tainted nodes",
+					"num_allocs", len(evictions.evict), "num_blocked", len(blocked))
+				_, err = w.evict(evictions.evict, blocked)
+			}
+		}
+		if err != nil {
+			if err == context.Canceled {
+				return
+			}
+
+			w.logger.Error("error evicting allocs from tainted nodes at index", "index", windex, "error", err)
+			select {
+			case <-w.ctx.Done():
+				return
+			case <-timer.C:
+				continue
+			}
+		}
+
+		// update index for next run
+		windex = index
+	}
+}
+
+// updateBlocked records the evictions held back by disruption budgets and
+// returns those of the jobs whose blocked evictions changed since they were
+// last recorded.
+func (w *nodeTaintWatcher) updateBlocked(blocked []*structs.Allocation) []*structs.Allocation {
+	counts := make(map[structs.NamespacedID]map[string]int)
+	for _, alloc := range blocked {
+		jns := alloc.JobNamespacedID()
+		if counts[jns] == nil {
+			counts[jns] = make(map[string]int)
+		}
+		counts[jns][alloc.TaskGroup]++
+	}
+
+	changed := make(map[structs.NamespacedID]bool)
+	for jns, jobCounts := range counts {
+		if !maps.Equal(jobCounts, w.blocked[jns]) {
+			changed[jns] = true
+		}
+	}
+	for jns := range w.blocked {
+		if _, ok := counts[jns]; !ok {
+			changed[jns] = true
+		}
+	}
+	w.blocked = counts
+
+	var resp []*structs.Allocation
+	for _, alloc := range blocked {
+		if changed[alloc.JobNamespacedID()] {
+			resp = append(resp, alloc)
+		}
+	}
+	return resp
+}
+
+// getEvictableAllocs returns the allocations that do not tolerate the
+// no_execute taints of their node, blocking until the nodes or allocations
+// are after the given index.
+func (w *nodeTaintWatcher) getEvictableAllocs(minIndex uint64) (*taintEvictions, uint64, error) {
+	if err := w.limiter.Wait(w.ctx); err != nil {
+		return nil, 0, err
+	}
+
+	resp, index, err := w.state.BlockingQuery(w.getEvictableAllocsImpl, minIndex, w.ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp.(*taintEvictions), index, nil
+}
+
+// getEvictableAllocsImpl is used to get the allocations to evict from the
+// state store, returning them and the highest of the node and allocation
+// table indexes. Evictions are bounded by the disruption budgets of the task
+// groups, and those held back are returned as blocked.
+func (w *nodeTaintWatcher) getEvictableAllocsImpl(ws memdb.WatchSet, state *state.StateStore) (interface{}, uint64, error) {
+	iter, err := state.Nodes(ws)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	index, err := state.Index("nodes")
+	if err != nil {
+		return nil, 0, err
+	}
+	allocsIndex, err := state.Index("allocs")
+	if err != nil {
+		return nil, 0, err
+	}
+	index = max(index, allocsIndex)
+
+	var candidates []*structs.Allocation
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		node := raw.(*structs.Node)
+		if !hasNoExecuteTaint(node) {
+			continue
+		}
+
+		allocs, err := state.AllocsByNode(ws, node.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, alloc := range allocs {
+			if alloc.TerminalStatus() || alloc.DesiredTransition.ShouldMigrate() {
+				continue
+			}
+
+			var tolerations []*structs.Toleration
+			if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil {
+				tolerations = tg.Tolerations
+			}
+			if len(node.UntoleratedTaints(tolerations, structs.NodeTaintEffectNoExecute)) > 0 {
+				candidates = append(candidates, alloc)
+			}
+		}
+	}
+
+	resp, err := applyDisruptionBudgets(ws, state, candidates)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp, index, nil
+}
+
+// applyDisruptionBudgets splits the candidate allocations into those that can
+// be evicted and those blocked by the disruption budget of their task group.
+// Task groups without a disruption budget are evicted according to their
+// migrate strategy instead, as drains are, and the evictions it holds back are
+// not reported as blocked.
+func applyDisruptionBudgets(ws memdb.WatchSet, state *state.StateStore, candidates []*structs.Allocation) (*taintEvictions, error) {
+	resp := &taintEvictions{}
+
+	// Group the candidates by job and task group
+	type groupKey struct {
+		jns   structs.NamespacedID
+		group string
+	}
+	var keys []groupKey
+	groups := make(map[groupKey][]*structs.Allocation)
+	for _, alloc := range candidates {
+		key := groupKey{jns: alloc.JobNamespacedID(), group: alloc.TaskGroup}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], alloc)
+	}
+
+	for _, key := range keys {
+		allocs := groups[key]
+
+		// Prefer the current version of the job, falling back to the one
+		// the allocations were placed with if it was purged
+		job, err := state.JobByID(ws, key.jns.Namespace, key.jns.ID)
+		if err != nil {
+			return nil, err
+		}
+		if job == nil {
+			job = allocs[0].Job
+		}
+		tg := job.LookupTaskGroup(key.group)
+		if tg == nil || (tg.DisruptionBudget == nil && tg.Migrate == nil) {
+			resp.evict = append(resp.evict, allocs...)
+			continue
+		}
+
+		// Count the allocations of the group available with regards to the
+		// disruption budget, and those healthy from a migration standpoint
+		// that are not already being migrated
+		jobAllocs, err := state.AllocsByJob(ws, key.jns.Namespace, key.jns.ID, false)
+		if err != nil {
+			return nil, err
+		}
+		available, healthy := 0, 0
+		for _, alloc := range jobAllocs {
+			if alloc.TaskGroup != key.group {
+				continue
+			}
+			if alloc.DisruptionAvailable() {
+				available++
+			}
+			if !alloc.TerminalStatus() && alloc.DeploymentStatus.HasHealth() &&
+				!alloc.DesiredTransition.ShouldMigrate() {
+				healthy++
+			}
+		}
+
+		if tg.DisruptionBudget == nil {
+			thresholdCount := tg.Count - tg.Migrate.MaxParallel
+			allowed := max(0, min(len(allocs), healthy-thresholdCount))
+			resp.evict = append(resp.evict, allocs[:allowed]...)
+			continue
+		}
+
+		allowed := min(len(allocs), tg.DisruptionBudget.AllowedDisruptions(tg.Count, available))
+		resp.evict = append(resp.evict, allocs[:allowed]...)
+		resp.blocked = append(resp.blocked, allocs[allowed:]...)
+	}
+
+	return resp, nil
+}
+
+// hasNoExecuteTaint returns whether the node has any no_execute taint.
+func hasNoExecuteTaint(node *structs.Node) bool {
+	for _, taint := range node.Taints {
+		if taint.Effect == structs.NodeTaintEffectNoExecute {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package drainer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+	"golang.org/x/time/rate"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// TestNodeTaintWatcher_Evict tests that allocations that do not tolerate the
+// no_execute taints of their node are evicted, and only once.
+func TestNodeTaintWatcher_Evict(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	raft := &MockRaftApplierShim{state: store}
+
+	var lock sync.Mutex
+	evicted := map[string]int{}
+	evict := func(allocs, _ []*structs.Allocation) (uint64, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		transitions := make(map[string]*structs.DesiredTransition, len(allocs))
+		for _, alloc := range allocs {
+			evicted[alloc.ID]++
+			transitions[alloc.ID] = &structs.DesiredTransition{Migrate: pointer.Of(true)}
+		}
+		return raft.AllocUpdateDesiredTransition(transitions, nil)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	NewNodeTaintWatcher(ctx, rate.NewLimiter(100.0, 100), store, testlog.HCLogger(t), evict)
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	// Create a job that tolerates the taint and one that does not
+	tolerating := mock.Job()
+	tolerating.TaskGroups[0].Tolerations = []*structs.Toleration{{
+		Key:      "dedicated",
+		Operator: structs.TolerationOperatorExists,
+	}}
+	intolerant := mock.Job()
+	intolerant.TaskGroups[0].Count = 1
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, tolerating))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, nil, intolerant))
+
+	allocs := make([]*structs.Allocation, 0, 2)
+	for _, job := range []*structs.Job{tolerating, intolerant} {
+		alloc := mock.Alloc()
+		alloc.ID = uuid.Generate()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.NodeID = node.ID
+		alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(true)}
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 103, allocs))
+
+	// A no_schedule taint does not evict running allocations
+	must.NoError(t, store.UpdateNodeTaints(structs.MsgTypeTestSetup, 104, node.ID, 0,
+		[]*structs.NodeTaint{{Key: "dedicated", Effect: structs.NodeTaintEffectNoSchedule}},
+		time.Now().Unix(), nil))
+
+	must.NoError(t, store.UpdateNodeTaints(structs.MsgTypeTestSetup, 105, node.ID, 0,
+		[]*structs.NodeTaint{{Key: "dedicated", Effect: structs.NodeTaintEffectNoExecute}},
+		time.Now().Unix(), nil))
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+		wait.BoolFunc(func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return evicted[allocs[1].ID] == 1
+		}),
+	))
+
+	must.Wait(t, wait.ContinualSuccess(
+		wait.Timeout(100*time.Millisecond),
+		wait.Gap(10*time.Millisecond),
+		wait.BoolFunc(func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(evicted) == 1 && evicted[allocs[1].ID] == 1
+		}),
+	))
+}
+
+// TestNodeTaintWatcher_DisruptionBudget tests that evictions from tainted nodes
+// are bounded by the disruption budget of the task group, and that the ones
+// held back are reported as blocked.
+func TestNodeTaintWatcher_DisruptionBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	raft := &MockRaftApplierShim{state: store}
+
+	var lock sync.Mutex
+	evicted := map[string]int{}
+	var blocked []*structs.Allocation
+	evict := func(allocs, blockedAllocs []*structs.Allocation) (uint64, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		transitions := make(map[string]*structs.DesiredTransition, len(allocs))
+		for _, alloc := range allocs {
+			evicted[alloc.ID]++
+			transitions[alloc.ID] = &structs.DesiredTransition{Migrate: pointer.Of(true)}
+		}
+		blocked = blockedAllocs
+		return raft.AllocUpdateDesiredTransition(transitions, nil)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	NewNodeTaintWatcher(ctx, rate.NewLimiter(100.0, 100), store, testlog.HCLogger(t), evict)
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{MaxUnavailable: 1}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, job))
+
+	allocs := make([]*structs.Allocation, 0, 3)
+	for i := 0; i < 3; i++ {
+		alloc := mock.Alloc()
+		alloc.ID = uuid.Generate()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.NodeID = node.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 102, allocs))
+
+	must.NoError(t, store.UpdateNodeTaints(structs.MsgTypeTestSetup, 103, node.ID, 0,
+		[]*structs.NodeTaint{{Key: "dedicated", Effect: structs.NodeTaintEffectNoExecute}},
+		time.Now().Unix(), nil))
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+		wait.BoolFunc(func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(evicted) == 1
+		}),
+	))
+
+	// Only one allocation is evicted while the other two are held back by
+	// the budget until the evicted one is replaced
+	must.Wait(t, wait.ContinualSuccess(
+		wait.Timeout(100*time.Millisecond),
+		wait.Gap(10*time.Millisecond),
+		wait.BoolFunc(func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(evicted) == 1
+		}),
+	))
+
+	lock.Lock()
+	defer lock.Unlock()
+	must.Len(t, 2, blocked)
+	for _, alloc := range blocked {
+		must.MapNotContainsKey(t, evicted, alloc.ID)
+	}
+}
+
+// TestNodeTaintWatcher_MigrateMaxParallel tests that evictions of task groups
+// without a disruption budget are bounded by the max_parallel of their migrate
+// strategy, and that the ones held back are not reported as blocked.
+func TestNodeTaintWatcher_MigrateMaxParallel(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	raft := &MockRaftApplierShim{state: store}
+
+	var lock sync.Mutex
+	evicted := map[string]int{}
+	var blocked []*structs.Allocation
+	evict := func(allocs, blockedAllocs []*structs.Allocation) (uint64, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		transitions := make(map[string]*structs.DesiredTransition, len(allocs))
+		for _, alloc := range allocs {
+			evicted[alloc.ID]++
+			transitions[alloc.ID] = &structs.DesiredTransition{Migrate: pointer.Of(true)}
+		}
+		blocked = append(blocked, blockedAllocs...)
+		return raft.AllocUpdateDesiredTransition(transitions, nil)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	NewNodeTaintWatcher(ctx, rate.NewLimiter(100.0, 100), store, testlog.HCLogger(t), evict)
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 4
+	job.TaskGroups[0].Migrate.MaxParallel = 2
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, job))
+
+	allocs := make([]*structs.Allocation, 0, 4)
+	for i := 0; i < 4; i++ {
+		alloc := mock.Alloc()
+		alloc.ID = uuid.Generate()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.NodeID = node.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(true)}
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 102, allocs))
+
+	must.NoError(t, store.UpdateNodeTaints(structs.MsgTypeTestSetup, 103, node.ID, 0,
+		[]*structs.NodeTaint{{Key: "dedicated", Effect: structs.NodeTaintEffectNoExecute}},
+		time.Now().Unix(), nil))
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+		wait.BoolFunc(func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(evicted) == 2
+		}),
+	))
+
+	// Only max_parallel allocations are evicted until they are replaced
+	must.Wait(t, wait.ContinualSuccess(
+		wait.Timeout(100*time.Millisecond),
+		wait.Gap(10*time.Millisecond),
+		wait.BoolFunc(func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(evicted) == 2
+		}),
+	))
+
+	lock.Lock()
+	defer lock.Unlock()
+	must.SliceEmpty(t, blocked)
+}
@@ -47,6 +47,10 @@ var (
 // to only dequeue work they know how to handle. The broker is designed to be entirely
 // in-memory and is managed by the leader node.
 //
+// Evaluations of the same priority are shared fairly across namespaces,
+// proportionally to the eval queue weight of each namespace, so that a
+// namespace with a large number of evaluations cannot starve the others.
+//
 // The broker must provide at-least-once delivery semantics. It relies on explicit
 // Ack/Nack messages to handle this. If a delivery is not Ack'd in a sufficient time
 // span, it will be assumed Nack'd.
@@ -59,6 +63,11 @@ type EvalBroker struct {
 
 	stats *BrokerStats
 
+	// prunedNamespaces are the namespaces whose stats were pruned since the
+	// stats were last emitted, so that their gauges are emitted a final time
+	// as zero.
+	prunedNamespaces map[string]struct{}
+
 	// evals tracks queued evaluations by ID to de-duplicate enqueue.
 	// The counter is the number of times we've attempted delivery,
 	// and is used to eventually fail an evaluation.
@@ -74,8 +83,12 @@ type EvalBroker struct {
 	// now safe for the Eval.Ack RPC to cancel in batches
 	cancelable []*structs.Evaluation
 
-	// ready tracks the ready jobs by scheduler in a priority queue
-	ready map[string]ReadyEvaluations
+	// ready tracks the ready jobs by scheduler in a fair queue
+	ready map[string]*readyQueue
+
+	// namespaceWeightFn returns the eval queue weight of a namespace. If nil
+	// all namespaces have the same weight.
+	namespaceWeightFn func(namespace string) int
 
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
@@ -157,7 +170,7 @@ func NewEvalBroker(ctx context.Context, timeout, initialNackDelay, subsequentNac
 		jobEvals:             make(map[structs.NamespacedID]string),
 		pending:              make(map[structs.NamespacedID]PendingEvaluations),
 		cancelable:           make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest),
-		ready:                make(map[string]ReadyEvaluations),
+		ready:                make(map[string]*readyQueue),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -168,13 +181,35 @@ func NewEvalBroker(ctx context.Context, timeout, initialNackDelay, subsequentNac
 		dequeuedTime:         make(map[string]time.Time),
 		delayHeap:            delayheap.NewDelayHeap(),
 		delayedEvalsUpdateCh: make(chan struct{}, 1),
+		prunedNamespaces:     make(map[string]struct{}),
 	}
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 
 	return b, nil
 }
 
+// SetNamespaceWeightFn sets the function used to lookup the eval queue weight
+// of a namespace when its evaluations are enqueued.
+func (b *EvalBroker) SetNamespaceWeightFn(fn func(namespace string) int) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	b.namespaceWeightFn = fn
+}
+
+// namespaceWeight returns the eval queue weight of the namespace. It must be
+// called with the lock held.
+func (b *EvalBroker) namespaceWeight(namespace string) int {
+	if b.namespaceWeightFn == nil {
+		return 1
+	}
+	if weight := b.namespaceWeightFn(namespace); weight > 0 {
+		return weight
+	}
+	return 1
+}
+
 // Enabled is used to check if the broker is enabled.
 func (b *EvalBroker) Enabled() bool {
 	b.l.RLock()
@@ -345,21 +380,22 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string, track
 		heap.Push(&pending, eval)
 		b.pending[namespacedID] = pending
 		b.stats.TotalPending += 1
+		b.namespaceStats(eval.Namespace).Pending += 1
 		return
 	}
 
 	// Find the next ready eval by scheduler class
-	readyQueue, ok := b.ready[sched]
+	queue, ok := b.ready[sched]
 	if !ok {
-		readyQueue = make([]*structs.Evaluation, 0, 16)
+		queue = newReadyQueue()
+		b.ready[sched] = queue
 		if _, ok := b.waiting[sched]; !ok {
 			b.waiting[sched] = make(chan struct{}, 1)
 		}
 	}
 
-	// Push onto the heap
-	heap.Push(&readyQueue, eval)
-	b.ready[sched] = readyQueue
+	// Push onto the namespace's heap
+	queue.push(eval, b.namespaceWeight(eval.Namespace))
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -369,6 +405,7 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string, track
 		b.stats.ByScheduler[sched] = bySched
 	}
 	bySched.Ready += 1
+	b.namespaceStats(eval.Namespace).Ready += 1
 
 	// Unblock any pending dequeues
 	select {
@@ -411,6 +448,9 @@ SCAN:
 				{Name: "eval_type", Value: eval.Type},
 				{Name: "triggered_by", Value: eval.TriggeredBy},
 			})
+			metrics.MeasureSinceWithLabels([]string{"nomad", "broker", "namespace", "wait_time"}, t, []metrics.Label{
+				{Name: "namespace", Value: eval.Namespace},
+			})
 		}
 		b.l.Unlock()
 		return eval, token, nil
@@ -446,13 +486,13 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 	var eligiblePriority int
 	for _, sched := range schedulers {
 		// Get the ready queue for this scheduler
-		readyQueue, ok := b.ready[sched]
+		queue, ok := b.ready[sched]
 		if !ok {
 			continue
 		}
 
 		// Peek at the next item
-		ready := readyQueue.Peek()
+		ready := queue.peek()
 		if ready == nil {
 			continue
 		}
@@ -491,10 +531,7 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 // dequeueForSched is used to dequeue the next work item for a given scheduler.
 // This assumes locks are held and that this scheduler has work
 func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
-	readyQueue := b.ready[sched]
-	raw := heap.Pop(&readyQueue)
-	b.ready[sched] = readyQueue
-	eval := raw.(*structs.Evaluation)
+	eval := b.ready[sched].pop()
 
 	// Generate a UUID for the token
 	token := uuid.Generate()
@@ -520,6 +557,8 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	bySched := b.stats.ByScheduler[sched]
 	bySched.Ready -= 1
 	bySched.Unacked += 1
+	b.namespaceStats(eval.Namespace).Ready -= 1
+	b.pruneNamespaceStats(eval.Namespace)
 
 	return eval, token, nil
 }
@@ -649,14 +688,18 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 		b.cancelable = append(b.cancelable, cancelable...)
 		b.stats.TotalCancelable = len(b.cancelable)
 		b.stats.TotalPending -= len(cancelable)
+		nsStats := b.namespaceStats(namespacedID.Namespace)
+		nsStats.Pending -= len(cancelable)
 
 		// If any remain, enqueue an eval
 		if len(pending) > 0 {
 			raw := heap.Pop(&pending)
 			eval := raw.(*structs.Evaluation)
 			b.stats.TotalPending -= 1
+			nsStats.Pending -= 1
 			b.enqueueLocked(eval, eval.Type, true)
 		}
+		b.pruneNamespaceStats(namespacedID.Namespace)
 
 		// Clean up if there are no more after that
 		if len(pending) > 0 {
@@ -839,11 +882,15 @@ func (b *EvalBroker) flush() {
 	b.stats.TotalCancelable = 0
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	for namespace := range b.stats.ByNamespace {
+		b.prunedNamespaces[namespace] = struct{}{}
+	}
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.evals = make(map[string]int)
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.pending = make(map[structs.NamespacedID]PendingEvaluations)
 	b.cancelable = make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest)
-	b.ready = make(map[string]ReadyEvaluations)
+	b.ready = make(map[string]*readyQueue)
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
@@ -928,6 +975,7 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	stats := new(BrokerStats)
 	stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	stats.ByScheduler = make(map[string]*SchedulerStats)
+	stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	b.l.RLock()
 	defer b.l.RUnlock()
@@ -946,9 +994,49 @@ func (b *EvalBroker) Stats() *BrokerStats {
 		subStatCopy := *subStat
 		stats.ByScheduler[sched] = &subStatCopy
 	}
+	for ns, subStat := range b.stats.ByNamespace {
+		subStatCopy := *subStat
+		stats.ByNamespace[ns] = &subStatCopy
+	}
 	return stats
 }
 
+// namespaceStats returns the stats of the namespace, creating them if needed.
+// It must be called with the lock held.
+func (b *EvalBroker) namespaceStats(namespace string) *NamespaceStats {
+	nsStats, ok := b.stats.ByNamespace[namespace]
+	if !ok {
+		nsStats = &NamespaceStats{}
+		b.stats.ByNamespace[namespace] = nsStats
+		delete(b.prunedNamespaces, namespace)
+	}
+	return nsStats
+}
+
+// pruneNamespaceStats removes the stats of the namespace once it has no ready
+// or pending evaluations left, so that the stats of deleted namespaces do not
+// linger. It must be called with the lock held.
+func (b *EvalBroker) pruneNamespaceStats(namespace string) {
+	if nsStats, ok := b.stats.ByNamespace[namespace]; ok && nsStats.Ready == 0 && nsStats.Pending == 0 {
+		delete(b.stats.ByNamespace, namespace)
+		b.prunedNamespaces[namespace] = struct{}{}
+	}
+}
+
+// takePrunedNamespaces returns the namespaces whose stats were pruned since
+// it was last called.
+func (b *EvalBroker) takePrunedNamespaces() []string {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	pruned := make([]string, 0, len(b.prunedNamespaces))
+	for namespace := range b.prunedNamespaces {
+		pruned = append(pruned, namespace)
+	}
+	clear(b.prunedNamespaces)
+	return pruned
+}
+
 // Cancelable retrieves a batch of previously-pending evaluations that are now
 // stale and ready to mark for canceling. The eval RPC will call this with a
 // batch size set to avoid sending overly large raft messages.
@@ -977,6 +1065,9 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 
 		select {
 		case <-timer.C:
+			// Take the pruned namespaces before the stats, so a namespace
+			// with new evaluations since it was pruned isn't zeroed
+			pruned := b.takePrunedNamespaces()
 			stats := b.Stats()
 			metrics.SetGauge([]string{"nomad", "broker", "total_ready"}, float32(stats.TotalReady))
 			metrics.SetGauge([]string{"nomad", "broker", "total_unacked"}, float32(stats.TotalUnacked))
@@ -996,6 +1087,21 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 				metrics.SetGauge([]string{"nomad", "broker", sched, "ready"}, float32(schedStats.Ready))
 				metrics.SetGauge([]string{"nomad", "broker", sched, "unacked"}, float32(schedStats.Unacked))
 			}
+			for ns, nsStats := range stats.ByNamespace {
+				labels := []metrics.Label{{Name: "namespace", Value: ns}}
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "ready"},
+					float32(nsStats.Ready), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "pending"},
+					float32(nsStats.Pending), labels)
+			}
+			for _, ns := range pruned {
+				if _, ok := stats.ByNamespace[ns]; ok {
+					continue
+				}
+				labels := []metrics.Label{{Name: "namespace", Value: ns}}
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "ready"}, 0, labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "pending"}, 0, labels)
+			}
 
 		case <-stopCh:
 			return
@@ -1012,6 +1118,7 @@ type BrokerStats struct {
 	TotalCancelable int
 	DelayedEvals    map[string]*structs.Evaluation
 	ByScheduler     map[string]*SchedulerStats
+	ByNamespace     map[string]*NamespaceStats
 }
 
 // SchedulerStats returns the stats per scheduler
@@ -1020,6 +1127,12 @@ type SchedulerStats struct {
 	Unacked int
 }
 
+// NamespaceStats returns the stats per namespace
+type NamespaceStats struct {
+	Ready   int
+	Pending int
+}
+
 // Len is for the sorting interface
 func (r ReadyEvaluations) Len() int {
 	return len(r)
@@ -1063,6 +1176,123 @@ func (r ReadyEvaluations) Peek() *structs.Evaluation {
 	return r[n-1]
 }
 
+// readyQueue is the queue of ready evaluations of a scheduler. Evaluations are
+// kept in a priority queue per namespace, and namespaces whose next
+// evaluations have the same priority are dequeued in proportion to their
+// weight using stride scheduling: each namespace has a pass which is advanced
+// by the inverse of its weight every time one of its evaluations is dequeued,
+// and the namespace with the lowest pass is dequeued next.
+type readyQueue struct {
+	// namespaces are the queues of the namespaces with ready evaluations.
+	// Queues are removed once empty so the map doesn't grow with every
+	// namespace that was ever ready.
+	namespaces map[string]*namespaceReadyQueue
+
+	// pass is the virtual time of the queue, which is the pass of the last
+	// namespace dequeued. Namespaces that become ready start one stride after
+	// it, as if they had just been dequeued, so they can't accumulate credit
+	// while idle nor skip their turn by emptying their queue.
+	pass float64
+
+	// activations counts the namespaces that became ready, and is used to
+	// dequeue namespaces in the order they became ready when all else is
+	// equal.
+	activations uint64
+}
+
+// namespaceReadyQueue is the queue of ready evaluations of a namespace.
+type namespaceReadyQueue struct {
+	evals     ReadyEvaluations
+	weight    int
+	pass      float64
+	activated uint64
+}
+
+// head returns the evaluation of the namespace that would be dequeued next.
+// Unlike ReadyEvaluations.Peek, it is the top of the heap. The queue must not
+// be empty.
+func (nq *namespaceReadyQueue) head() *structs.Evaluation {
+	return nq.evals[0]
+}
+
+func newReadyQueue() *readyQueue {
+	return &readyQueue{
+		namespaces: make(map[string]*namespaceReadyQueue),
+	}
+}
+
+// push adds an evaluation to the queue of its namespace, updating the weight
+// of the namespace.
+func (q *readyQueue) push(eval *structs.Evaluation, weight int) {
+	nq, ok := q.namespaces[eval.Namespace]
+	if !ok {
+		nq = &namespaceReadyQueue{
+			evals: make([]*structs.Evaluation, 0, 16),
+			pass:  q.pass + 1/float64(weight),
+		}
+		q.namespaces[eval.Namespace] = nq
+
+		q.activations++
+		nq.activated = q.activations
+	}
+	nq.weight = weight
+	heap.Push(&nq.evals, eval)
+}
+
+// next returns the namespace queue whose evaluation should be dequeued next,
+// or nil if the queue is empty.
+func (q *readyQueue) next() *namespaceReadyQueue {
+	var next *namespaceReadyQueue
+	var nextEval *structs.Evaluation
+	for _, nq := range q.namespaces {
+		eval := nq.head()
+
+		switch {
+		case next == nil:
+		case eval.Priority != nextEval.Priority:
+			if eval.Priority < nextEval.Priority {
+				continue
+			}
+		case nq.pass != next.pass:
+			if nq.pass > next.pass {
+				continue
+			}
+		case eval.CreateIndex != nextEval.CreateIndex:
+			if eval.CreateIndex > nextEval.CreateIndex {
+				continue
+			}
+		case nq.activated > next.activated:
+			continue
+		}
+		next, nextEval = nq, eval
+	}
+	return next
+}
+
+// peek returns the evaluation that would be dequeued next, or nil if the
+// queue is empty.
+func (q *readyQueue) peek() *structs.Evaluation {
+	if nq := q.next(); nq != nil {
+		return nq.head()
+	}
+	return nil
+}
+
+// pop removes and returns the next evaluation, advancing the pass of its
+// namespace. The queue of the namespace is removed if it is now empty. The
+// queue must not be empty.
+func (q *readyQueue) pop() *structs.Evaluation {
+	nq := q.next()
+	eval := heap.Pop(&nq.evals).(*structs.Evaluation)
+
+	q.pass = nq.pass
+	nq.pass += 1 / float64(nq.weight)
+	if len(nq.evals) == 0 {
+		delete(q.namespaces, eval.Namespace)
+	}
+	return eval
+}
+
 // Len is for the sorting interface
 func (p PendingEvaluations) Len() int {
 	return len(p)
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	metrics "github.com/armon/go-metrics"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
 	"github.com/shoenig/test"
 	"github.com/shoenig/test/must"
@@ -436,6 +437,7 @@ func TestEvalBroker_Serialize_DuplicateJobID(t *testing.T) {
 		stats := b.Stats()
 		stats.DelayedEvals = nil
 		stats.ByScheduler = nil
+		stats.ByNamespace = nil
 		return *stats
 	}
 
@@ -460,32 +462,33 @@ func TestEvalBroker_Serialize_DuplicateJobID(t *testing.T) {
 
 	// eval4 and eval5 are ready
 	// eval6 and eval7 are pending
-	// Dequeue should get 4th eval
+	// Dequeue should get 5th eval, because namespace-two hasn't had its
+	// fair share of dequeues yet
 	out, token, err = b.Dequeue(defaultSched, time.Second)
 	must.NoError(t, err)
-	must.Eq(t, out, eval4, must.Sprint("expected 4th eval"))
+	must.Eq(t, out, eval5, must.Sprint("expected 5th eval"))
 
 	must.Eq(t, BrokerStats{TotalReady: 1, TotalUnacked: 1,
 		TotalPending: 2, TotalCancelable: 2}, getStats())
 
-	// Ack should clear the rest of namespace-one pending but leave
-	// namespace-two untouched
-	err = b.Ack(eval4.ID, token)
+	// Ack should clear the rest of namespace-two pending but leave
+	// namespace-one untouched
+	err = b.Ack(eval5.ID, token)
 	must.NoError(t, err)
 
-	must.Eq(t, BrokerStats{TotalReady: 1, TotalUnacked: 0,
-		TotalPending: 2, TotalCancelable: 2}, getStats())
+	must.Eq(t, BrokerStats{TotalReady: 2, TotalUnacked: 0,
+		TotalPending: 0, TotalCancelable: 3}, getStats())
 
-	// Dequeue should get 5th eval
+	// Dequeue should get 4th eval, now that both namespaces had the same
+	// number of dequeues
 	out, token, err = b.Dequeue(defaultSched, time.Second)
 	must.NoError(t, err)
-	must.Eq(t, out, eval5, must.Sprint("expected 5th eval"))
+	must.Eq(t, out, eval4, must.Sprint("expected 4th eval"))
 
-	must.Eq(t, BrokerStats{TotalReady: 0, TotalUnacked: 1,
-		TotalPending: 2, TotalCancelable: 2}, getStats())
+	must.Eq(t, BrokerStats{TotalReady: 1, TotalUnacked: 1,
+		TotalPending: 0, TotalCancelable: 3}, getStats())
 
-	// Ack should clear remaining namespace-two pending evals
-	err = b.Ack(eval5.ID, token)
+	err = b.Ack(eval4.ID, token)
 	must.NoError(t, err)
 
 	must.Eq(t, BrokerStats{TotalReady: 1, TotalUnacked: 0,
@@ -1389,6 +1392,205 @@ func TestEvalBroker_NamespacedJobs(t *testing.T) {
 
 }
 
+func TestEvalBroker_NamespaceFairShare(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetNamespaceWeightFn(func(namespace string) int {
+		if namespace == "other" {
+			return 2
+		}
+		return 0
+	})
+	b.SetEnabled(true)
+
+	newEval := func(ns string, priority int, index uint64) *structs.Evaluation {
+		eval := mock.Eval()
+		eval.Namespace = ns
+		eval.Priority = priority
+		eval.CreateIndex = index
+		b.Enqueue(eval)
+		return eval
+	}
+
+	// The flood namespace enqueues its evals first, with lower create
+	// indexes, followed by a single higher priority eval
+	for i := uint64(1); i <= 5; i++ {
+		newEval("flood", 50, i)
+	}
+	for i := uint64(10); i <= 12; i++ {
+		newEval("other", 50, i)
+	}
+	urgent := newEval("flood", 70, 20)
+
+	stats := b.Stats()
+	must.Eq(t, 6, stats.ByNamespace["flood"].Ready)
+	must.Eq(t, 3, stats.ByNamespace["other"].Ready)
+
+	// Higher priority evals are always dequeued first
+	out, _, err := b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, urgent.ID, out.ID)
+
+	// The other namespace has twice the weight of the flood namespace, so it
+	// gets two dequeues for each of the flood namespace until it is empty,
+	// the urgent eval counting toward the share of the flood namespace
+	var namespaces []string
+	for i := 0; i < 8; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		namespaces = append(namespaces, out.Namespace)
+	}
+	must.Eq(t, []string{
+		"other", "other", "other", "flood", "flood", "flood", "flood", "flood",
+	}, namespaces)
+
+	// The stats of namespaces without any ready or pending evals are pruned
+	stats = b.Stats()
+	must.MapEmpty(t, stats.ByNamespace)
+}
+
+func TestEvalBroker_NamespaceStats_Pruned(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	// Enqueue two evals for the same job so that the second one is pending
+	eval1 := mock.Eval()
+	eval1.Namespace = "ephemeral"
+	eval1.CreateIndex, eval1.ModifyIndex = 1, 1
+	eval2 := mock.Eval()
+	eval2.Namespace = eval1.Namespace
+	eval2.JobID = eval1.JobID
+	eval2.CreateIndex, eval2.ModifyIndex = 2, 2
+	b.Enqueue(eval1)
+	b.Enqueue(eval2)
+
+	stats := b.Stats()
+	must.Eq(t, &NamespaceStats{Ready: 1, Pending: 1}, stats.ByNamespace["ephemeral"])
+
+	// Acking the first eval makes the pending one ready
+	out, token, err := b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, eval1.ID, out.ID)
+	must.NoError(t, b.Ack(out.ID, token))
+
+	stats = b.Stats()
+	must.Eq(t, &NamespaceStats{Ready: 1}, stats.ByNamespace["ephemeral"])
+
+	// Dequeuing the last eval leaves nothing behind for the namespace
+	out, _, err = b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, eval2.ID, out.ID)
+
+	stats = b.Stats()
+	must.MapNotContainsKey(t, stats.ByNamespace, "ephemeral")
+}
+
+// TestEvalBroker_NamespaceStats_EmitPruned asserts the gauges of a namespace
+// are emitted as zero once its queue drains. It replaces the global metrics
+// sink, so it doesn't run in parallel.
+func TestEvalBroker_NamespaceStats_EmitPruned(t *testing.T) {
+	sink := metrics.NewInmemSink(time.Minute, time.Minute)
+	cfg := metrics.DefaultConfig("")
+	cfg.EnableHostname = false
+	cfg.EnableRuntimeMetrics = false
+	_, err := metrics.NewGlobal(cfg, sink)
+	must.NoError(t, err)
+
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	go b.EmitStats(10*time.Millisecond, stopCh)
+
+	gauge := func(name string) (float32, bool) {
+		key := fmt.Sprintf("nomad.broker.namespace.%s;namespace=drained", name)
+		intervals := sink.Data()
+		for i := len(intervals) - 1; i >= 0; i-- {
+			interval := intervals[i]
+			interval.RLock()
+			value, ok := interval.Gauges[key]
+			interval.RUnlock()
+			if ok {
+				return value.Value, true
+			}
+		}
+		return 0, false
+	}
+	waitForGauge := func(name string, expected float32) {
+		must.Wait(t, wait.InitialSuccess(
+			wait.Timeout(time.Second),
+			wait.Gap(10*time.Millisecond),
+			wait.BoolFunc(func() bool {
+				value, ok := gauge(name)
+				return ok && value == expected
+			}),
+		))
+	}
+
+	eval := mock.Eval()
+	eval.Namespace = "drained"
+	b.Enqueue(eval)
+	waitForGauge("ready", 1)
+	waitForGauge("pending", 0)
+
+	out, token, err := b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, eval.ID, out.ID)
+	must.NoError(t, b.Ack(out.ID, token))
+
+	stats := b.Stats()
+	must.MapNotContainsKey(t, stats.ByNamespace, "drained")
+	waitForGauge("ready", 0)
+	waitForGauge("pending", 0)
+
+	// The pruned namespace is only zeroed once
+	must.SliceEmpty(t, b.takePrunedNamespaces())
+}
+
+func TestEvalBroker_ReadyQueue_Pruned(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	// Enqueue one eval per namespace, and a second one in ns-0
+	for i := 0; i < 11; i++ {
+		eval := mock.Eval()
+		eval.Namespace = fmt.Sprintf("ns-%d", i%10)
+		b.Enqueue(eval)
+	}
+
+	b.l.RLock()
+	must.MapLen(t, 10, b.ready[mock.Eval().Type].namespaces)
+	b.l.RUnlock()
+
+	for i := 0; i < 11; i++ {
+		out, token, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		must.NoError(t, b.Ack(out.ID, token))
+	}
+
+	// The queues of the namespaces are removed once empty, but the pass of
+	// the scheduler queue is kept so namespaces becoming ready again start
+	// one stride after it
+	b.l.RLock()
+	queue := b.ready[mock.Eval().Type]
+	must.MapEmpty(t, queue.namespaces)
+	pass := queue.pass
+	b.l.RUnlock()
+	must.Eq(t, 2, pass)
+
+	eval := mock.Eval()
+	eval.Namespace = "ns-0"
+	b.Enqueue(eval)
+
+	b.l.RLock()
+	must.MapLen(t, 1, queue.namespaces)
+	must.Eq(t, pass+1, queue.namespaces["ns-0"].pass)
+	b.l.RUnlock()
+}
+
 func TestEvalBroker_ReadyEvals_Ordering(t *testing.T) {
 
 	ready := ReadyEvaluations{}
@@ -1567,6 +1769,7 @@ func TestEvalBroker_IntegrationTest(t *testing.T) {
 		stats := srv.evalBroker.Stats()
 		stats.DelayedEvals = nil
 		stats.ByScheduler = nil
+		stats.ByNamespace = nil
 		return *stats
 	}
 
@@ -297,6 +297,8 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyDeploymentPromotion(msgType, buf[1:], log.Index)
 	case structs.DeploymentAllocHealthRequestType:
 		return n.applyDeploymentAllocHealth(msgType, buf[1:], log.Index)
+	case structs.DeploymentAnalysisRequestType:
+		return n.applyDeploymentAnalysis(msgType, buf[1:], log.Index)
 	case structs.DeploymentDeleteRequestType:
 		return n.applyDeploymentDelete(buf[1:], log.Index)
 	case structs.JobStabilityRequestType:
@@ -321,6 +323,10 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyAllocUpdateDesiredTransition(msgType, buf[1:], log.Index)
 	case structs.NodeUpdateEligibilityRequestType:
 		return n.applyNodeEligibilityUpdate(msgType, buf[1:], log.Index)
+	case structs.NodeUpdateTaintsRequestType:
+		return n.applyNodeTaintsUpdate(msgType, buf[1:], log.Index)
+	case structs.NodeUpdateUtilizationRequestType:
+		return n.applyNodeUtilizationUpdate(msgType, buf[1:], log.Index)
 	case structs.BatchNodeUpdateDrainRequestType:
 		return n.applyBatchDrainUpdate(msgType, buf[1:], log.Index)
 	case structs.SchedulerConfigRequestType:
@@ -567,6 +573,47 @@ func (n *nomadFSM) applyNodeEligibilityUpdate(msgType structs.MessageType, buf [
 	return nil
 }
 
+func (n *nomadFSM) applyNodeTaintsUpdate(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "node_taints_update"}, time.Now())
+	var req structs.NodeUpdateTaintsRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateNodeTaints(msgType, index, req.NodeID, req.NodeModifyIndex, req.Taints, req.UpdatedAt, req.NodeEvent); err != nil {
+		n.logger.Error("UpdateNodeTaints failed", "error", err)
+		return err
+	}
+
+	// Unblock evals for the node since allocations that were blocked by its
+	// previous taints may now be placed on it.
+	node, err := n.state.NodeByID(nil, req.NodeID)
+	if err != nil {
+		n.logger.Error("UpdateNodeTaints failed to lookup node", "node_id", req.NodeID, "error", err)
+		return err
+	}
+	if node != nil && node.Ready() {
+		n.blockedEvals.Unblock(node.ComputedClass, index)
+		n.blockedEvals.UnblockNode(req.NodeID, index)
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyNodeUtilizationUpdate(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "node_utilization_update"}, time.Now())
+	var req structs.NodeUpdateUtilizationRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateNodeUtilization(msgType, index, req.NodeID, req.Utilization); err != nil {
+		n.logger.Error("UpdateNodeUtilization failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 func (n *nomadFSM) applyNodePoolUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_node_pool_upsert"}, time.Now())
 	var req structs.NodePoolUpsertRequest
@@ -626,6 +673,10 @@ func (n *nomadFSM) applyUpsertJob(msgType structs.MessageType, buf []byte, index
 		return fmt.Errorf("failed adding job to periodic dispatcher: %v", err)
 	}
 
+	// Unblock evals waiting on the job, since registering it may meet the
+	// dependencies of other jobs
+	n.blockedEvals.UnblockJob(req.Job.ID, req.Job.Namespace, index)
+
 	// Create a watch set
 	ws := memdb.NewWatchSet()
 
@@ -887,7 +938,7 @@ func (n *nomadFSM) handleUpsertedEval(eval *structs.Evaluation) {
 	} else if eval.ShouldBlock() {
 		n.blockedEvals.Block(eval)
 	} else if eval.Status == structs.EvalStatusComplete &&
-		len(eval.FailedTGAllocs) == 0 {
+		len(eval.FailedTGAllocs) == 0 && eval.BlockedEval == "" {
 		// If we have a successful evaluation for a node, untrack any
 		// blocked evaluation
 		n.blockedEvals.Untrack(eval.JobID, eval.Namespace)
@@ -937,10 +988,12 @@ func (n *nomadFSM) applyAllocClientUpdate(msgType structs.MessageType, buf []byt
 	ws := memdb.NewWatchSet()
 
 	// Updating the allocs with the job id and task group name
+	jobs := make(map[structs.NamespacedID]struct{})
 	for _, alloc := range req.Alloc {
 		if existing, _ := n.state.AllocByID(ws, alloc.ID); existing != nil {
 			alloc.JobID = existing.JobID
 			alloc.TaskGroup = existing.TaskGroup
+			jobs[existing.JobNamespacedID()] = struct{}{}
 		}
 	}
 
@@ -958,6 +1011,12 @@ func (n *nomadFSM) applyAllocClientUpdate(msgType structs.MessageType, buf []byt
 		}
 	}
 
+	// Unblock evals waiting on the jobs of the allocations, since the status
+	// of the jobs may have changed.
+	for job := range jobs {
+		n.blockedEvals.UnblockJob(job.ID, job.Namespace, index)
+	}
+
 	// Unblock evals for the nodes computed node class if the client has
 	// finished running an allocation.
 	for _, alloc := range req.Alloc {
@@ -1123,6 +1182,11 @@ func (n *nomadFSM) applyDeploymentStatusUpdate(msgType structs.MessageType, buf
 		return err
 	}
 
+	// Unblock evals waiting on the job of the deployment to be healthy
+	if d, err := n.state.DeploymentByID(nil, req.DeploymentUpdate.DeploymentID); err == nil && d != nil {
+		n.blockedEvals.UnblockJob(d.JobID, d.Namespace, index)
+	}
+
 	n.handleUpsertedEval(req.Eval)
 	return nil
 }
@@ -1162,6 +1226,24 @@ func (n *nomadFSM) applyDeploymentAllocHealth(msgType structs.MessageType, buf [
 	return nil
 }
 
+// applyDeploymentAnalysis is used to record the results of the analysis gates
+// of a deployment
+func (n *nomadFSM) applyDeploymentAnalysis(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_deployment_analysis"}, time.Now())
+	var req structs.ApplyDeploymentAnalysisRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateDeploymentAnalysis(msgType, index, &req); err != nil {
+		n.logger.Error("UpdateDeploymentAnalysis failed", "error", err)
+		return err
+	}
+
+	n.handleUpsertedEval(req.Eval)
+	return nil
+}
+
 // applyDeploymentDelete is used to delete a set of deployments
 func (n *nomadFSM) applyDeploymentDelete(buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_deployment_delete"}, time.Now())
@@ -1453,6 +1453,67 @@ func TestFSM_UpdateAllocFromClient_Unblock(t *testing.T) {
 	})
 }
 
+func TestFSM_UpdateAllocFromClient_UnblockDependent(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+	fsm.blockedEvals.SetEnabled(true)
+	state := fsm.State()
+
+	alloc := mock.Alloc()
+	must.NoError(t, state.UpsertJobSummary(8, mock.JobSummary(alloc.JobID)))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 10, []*structs.Allocation{alloc}))
+
+	// Block an eval waiting on the job of the alloc
+	eval := mock.Eval()
+	eval.Status = structs.EvalStatusBlocked
+	eval.PendingDependencies = []string{alloc.JobID}
+	eval.SnapshotIndex = 10
+	fsm.blockedEvals.Block(eval)
+	must.Eq(t, 1, fsm.blockedEvals.Stats().TotalDependent)
+
+	clientAlloc := alloc.Copy()
+	clientAlloc.ClientStatus = structs.AllocClientStatusComplete
+	req := structs.AllocUpdateRequest{
+		Alloc: []*structs.Allocation{clientAlloc},
+	}
+	buf, err := structs.Encode(structs.AllocClientUpdateRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify the eval was unblocked.
+	bStats := fsm.blockedEvals.Stats()
+	must.Eq(t, 0, bStats.TotalBlocked)
+	must.Eq(t, 0, bStats.TotalDependent)
+}
+
+func TestFSM_RegisterJob_UnblockDependent(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+	fsm.blockedEvals.SetEnabled(true)
+
+	job := mock.Job()
+
+	// Block an eval waiting on the job before it is registered
+	eval := mock.Eval()
+	eval.Status = structs.EvalStatusBlocked
+	eval.PendingDependencies = []string{job.ID}
+	fsm.blockedEvals.Block(eval)
+	must.Eq(t, 1, fsm.blockedEvals.Stats().TotalDependent)
+
+	req := structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	buf, err := structs.Encode(structs.JobRegisterRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify the eval was unblocked.
+	must.Eq(t, 0, fsm.blockedEvals.Stats().TotalDependent)
+}
+
 func TestFSM_UpdateAllocFromClient(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
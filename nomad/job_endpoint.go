@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -86,6 +87,7 @@ func NewJobEndpoints(s *Server, ctx *RPCContext) *Job {
 			jobNamespaceConstraintCheckHook{srv: s},
 			jobNodePoolValidatingHook{srv: s},
 			&jobValidate{srv: s},
+			jobDependenciesValidate{srv: s},
 			&memoryOversubscriptionValidate{srv: s},
 			jobNumaHook{},
 			&jobSchedHook{},
@@ -152,6 +154,11 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return structs.ErrPermissionDenied
 	}
 
+	// Validate Allocation Affinity Permissions
+	if !allowAllocationAffinities(aclObj, args.Job) {
+		return structs.ErrPermissionDenied
+	}
+
 	// Validate Volume Permissions
 	for _, tg := range args.Job.TaskGroups {
 		for _, vol := range tg.Volumes {
@@ -461,6 +468,29 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	return nil
 }
 
+// allowAllocationAffinities returns whether the ACL allows the allocation
+// affinities of the job, since they reveal where the allocations they match
+// are placed. Matching allocations in another namespace requires reading its
+// jobs, and matching them in every namespace requires a management token.
+func allowAllocationAffinities(aclObj *acl.ACL, job *structs.Job) bool {
+	for _, tg := range job.TaskGroups {
+		for _, aa := range tg.AllocationAffinities {
+			switch aa.Namespace {
+			case "", job.Namespace:
+			case structs.AllocationAffinityAnyNamespace:
+				if !aclObj.IsManagement() {
+					return false
+				}
+			default:
+				if !aclObj.AllowNsOp(aa.Namespace, acl.NamespaceCapabilityReadJob) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
 // propagateScalingPolicyIDs propagates scaling policy IDs from existing job
 // to updated job, or generates random IDs in new job
 func propagateScalingPolicyIDs(old, new *structs.Job) error {
@@ -770,6 +800,10 @@ func (j *Job) Evaluate(args *structs.JobEvaluateRequest, reply *structs.JobRegis
 		return fmt.Errorf("can't evaluate parameterized job")
 	}
 
+	if len(args.EvalOptions.ArrayIndices) > 0 && !args.EvalOptions.ForceReschedule {
+		return fmt.Errorf("array indices can only be set when forcing rescheduling")
+	}
+
 	forceRescheduleAllocs := make(map[string]*structs.DesiredTransition)
 
 	if args.EvalOptions.ForceReschedule {
@@ -781,8 +815,19 @@ func (j *Job) Evaluate(args *structs.JobEvaluateRequest, reply *structs.JobRegis
 
 		for _, alloc := range allocs {
 			taskGroup := job.LookupTaskGroup(alloc.TaskGroup)
-			// Forcing rescheduling is only allowed if task group has rescheduling enabled
-			if taskGroup == nil || !taskGroup.ReschedulePolicy.Enabled() {
+			if taskGroup == nil {
+				continue
+			}
+
+			// Only the allocations at the requested array indices are force
+			// rescheduled, regardless of the reschedule policy
+			if len(args.EvalOptions.ArrayIndices) > 0 {
+				idx, ok := alloc.ArrayIndex()
+				if !ok || !slices.Contains(args.EvalOptions.ArrayIndices, idx) {
+					continue
+				}
+			} else if !taskGroup.ReschedulePolicy.Enabled() {
+				// Forcing rescheduling is only allowed if task group has rescheduling enabled
 				continue
 			}
 
@@ -1065,6 +1110,18 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 	}
 
 	if args.Count != nil {
+		// The count of array task groups is the size of the array
+		if group.Array != nil {
+			return structs.NewErrRPCCoded(400,
+				fmt.Sprintf("task group %q is an array and cannot be scaled", groupName))
+		}
+
+		// The count of completion task groups is the number of completions
+		if group.Completion != nil {
+			return structs.NewErrRPCCoded(400,
+				fmt.Sprintf("task group %q has a completion policy and cannot be scaled", groupName))
+		}
+
 		// Further validation for count-based scaling event
 		if group.Scaling != nil {
 			if *args.Count < group.Scaling.Min {
@@ -1783,6 +1840,9 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 		if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
 			return structs.ErrPermissionDenied
 		}
+		if !allowAllocationAffinities(aclObj, args.Job) {
+			return structs.ErrPermissionDenied
+		}
 		// Check if override is set and we do not have permissions
 		if args.PolicyOverride {
 			if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySentinelOverride) {
@@ -18,23 +18,32 @@ func (jobImplicitIdentitiesHook) Name() string {
 }
 
 func (h jobImplicitIdentitiesHook) Mutate(job *structs.Job) (*structs.Job, []error, error) {
+	addImplicitIdentities(job, h.srv.config)
+	return job, nil, nil
+}
+
+// addImplicitIdentities completes the identities of the services of the job
+// and adds the default identities of the server configuration to services and
+// tasks without one. If config is nil only the identities already in the job
+// are completed, which does not depend on the server.
+func addImplicitIdentities(job *structs.Job, config *Config) {
 	for _, tg := range job.TaskGroups {
 		var hasIdentity bool
 
 		for _, s := range tg.Services {
-			h.handleConsulService(s, tg)
+			handleConsulService(config, s, tg)
 			hasIdentity = hasIdentity || s.Identity != nil
 		}
 
 		for _, t := range tg.Tasks {
 			for _, s := range t.Services {
-				h.handleConsulService(s, tg)
+				handleConsulService(config, s, tg)
 				hasIdentity = hasIdentity || s.Identity != nil
 			}
 			if len(t.Templates) > 0 {
-				h.handleConsulTasks(t, tg)
+				handleConsulTasks(config, t, tg)
 			}
-			h.handleVault(t)
+			handleVault(config, t)
 			hasIdentity = hasIdentity || (len(t.Identities) > 0)
 		}
 
@@ -42,8 +51,6 @@ func (h jobImplicitIdentitiesHook) Mutate(job *structs.Job) (*structs.Job, []err
 			tg.Constraints = append(tg.Constraints, implicitIdentityClientVersionConstraint())
 		}
 	}
-
-	return job, nil, nil
 }
 
 // implicitIdentityClientVersionConstraint is used when the client needs to
@@ -65,7 +72,7 @@ func implicitIdentityClientVersionConstraint() *structs.Constraint {
 //
 // If the service already has an identity the server sets the identity name and
 // service name values.
-func (h jobImplicitIdentitiesHook) handleConsulService(s *structs.Service, tg *structs.TaskGroup) {
+func handleConsulService(config *Config, s *structs.Service, tg *structs.TaskGroup) {
 	if s.Provider != "" && s.Provider != "consul" {
 		return
 	}
@@ -73,9 +80,13 @@ func (h jobImplicitIdentitiesHook) handleConsulService(s *structs.Service, tg *s
 	// Use the identity specified in the service.
 	serviceWID := s.Identity
 	if serviceWID == nil {
+		if config == nil {
+			return
+		}
+
 		// If the service doesn't specify an identity, fallback to the service
 		// identity defined in the server configuration.
-		serviceWID = h.srv.config.ConsulServiceIdentity(s.GetConsulClusterName(tg))
+		serviceWID = config.ConsulServiceIdentity(s.GetConsulClusterName(tg))
 		if serviceWID == nil {
 			// If no identity is found, skip injecting the implicit identity
 			// and fallback to the legacy flow.
@@ -90,7 +101,7 @@ func (h jobImplicitIdentitiesHook) handleConsulService(s *structs.Service, tg *s
 	s.Identity = serviceWID
 }
 
-func (h jobImplicitIdentitiesHook) handleConsulTasks(t *structs.Task, tg *structs.TaskGroup) {
+func handleConsulTasks(config *Config, t *structs.Task, tg *structs.TaskGroup) {
 	widName := t.Consul.IdentityName()
 
 	// Use the Consul identity specified in the task if present
@@ -99,10 +110,13 @@ func (h jobImplicitIdentitiesHook) handleConsulTasks(t *structs.Task, tg *struct
 			return
 		}
 	}
+	if config == nil {
+		return
+	}
 
 	// If task doesn't specify an identity for Consul, fallback to the
 	// default identity defined in the server configuration.
-	taskWID := h.srv.config.ConsulTaskIdentity(t.GetConsulClusterName(tg))
+	taskWID := config.ConsulTaskIdentity(t.GetConsulClusterName(tg))
 	if taskWID == nil {
 		// If no identity is found skip inject the implicit identity and
 		// fallback to the legacy flow.
@@ -116,8 +130,8 @@ func (h jobImplicitIdentitiesHook) handleConsulTasks(t *structs.Task, tg *struct
 //  1. The task has a Vault block.
 //  2. The task does not have an identity for the Vault cluster.
 //  3. The server is configured with a `vault.default_identity`.
-func (h jobImplicitIdentitiesHook) handleVault(t *structs.Task) {
-	if t.Vault == nil {
+func handleVault(config *Config, t *structs.Task) {
+	if t.Vault == nil || config == nil {
 		return
 	}
 
@@ -130,7 +144,7 @@ func (h jobImplicitIdentitiesHook) handleVault(t *structs.Task) {
 
 	// If the task doesn't specify an identity for Vault, fallback to the
 	// default identity defined in the server configuration.
-	vaultWID = h.srv.config.VaultIdentityConfig(t.GetVaultClusterName())
+	vaultWID = config.VaultIdentityConfig(t.GetVaultClusterName())
 	if vaultWID == nil {
 		// If no identity is found skip inject the implicit identity and
 		// fallback to the legacy flow.
@@ -9,6 +9,14 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
+// setDefaultNodePool places a job that doesn't specify a node pool in the
+// default node pool.
+func setDefaultNodePool(job *structs.Job) {
+	if job.NodePool == "" {
+		job.NodePool = structs.NodePoolDefault
+	}
+}
+
 // jobNodePoolValidatingHook is an admission hook that ensures the job has valid
 // node pool configuration.
 type jobNodePoolValidatingHook struct {
@@ -24,9 +24,6 @@ func (c jobNodePoolMutatingHook) Name() string {
 }
 
 func (c jobNodePoolMutatingHook) Mutate(job *structs.Job) (*structs.Job, []error, error) {
-	if job.NodePool == "" {
-		job.NodePool = structs.NodePoolDefault
-	}
-
+	setDefaultNodePool(job)
 	return job, nil, nil
 }
@@ -206,6 +206,44 @@ func (j *Job) admissionMutators(job *structs.Job) (_ *structs.Job, warnings []er
 	return job, warnings, err
 }
 
+// MutateSimulatedJob applies the admission mutators that don't depend on the
+// server, such as the default node pool and the implied constraints, so that a
+// job simulated against a snapshot of the state matches the job a registration
+// would have stored. Identities are not defaulted from the server
+// configuration, but the identities of the job are completed.
+func MutateSimulatedJob(job *structs.Job) (_ *structs.Job, warnings []error, err error) {
+	job.Canonicalize()
+	if job.Priority == 0 {
+		job.Priority = structs.JobDefaultPriority
+	}
+
+	mutators := []jobMutator{
+		jobConnectHook{},
+		jobExposeCheckHook{},
+		jobImpliedConstraints{},
+	}
+
+	var w []error
+	for _, mutator := range mutators {
+		job, w, err = mutator.Mutate(job)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error in job mutator %s: %v", mutator.Name(), err)
+		}
+		warnings = append(warnings, w...)
+	}
+
+	setDefaultNodePool(job)
+	addImplicitIdentities(job, nil)
+
+	job, w, err = jobNumaHook{}.Mutate(job)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error in job mutator %s: %v", jobNumaHook{}.Name(), err)
+	}
+	warnings = append(warnings, w...)
+
+	return job, warnings, nil
+}
+
 // admissionValidators returns a slice of validation warnings and a multierror
 // of validation failures.
 func (j *Job) admissionValidators(origJob *structs.Job) ([]error, error) {
@@ -522,6 +560,12 @@ func (v *jobValidate) Validate(job *structs.Job) (warnings []error, err error) {
 			multierror.Append(validationErrors, vaultErrs)
 			warnings = append(warnings, vaultWarns...)
 		}
+
+		if tg.Update != nil {
+			if err := tg.Update.Analysis.ValidateProviders(v.srv.config.AnalysisProviders); err != nil {
+				multierror.Append(validationErrors, fmt.Errorf("Task group %s: %w", tg.Name, err))
+			}
+		}
 	}
 
 	return warnings, validationErrors.ErrorOrNil()
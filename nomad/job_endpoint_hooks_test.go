@@ -16,6 +16,38 @@ import (
 	"github.com/shoenig/test/must"
 )
 
+func Test_jobValidate_Validate_analysisProviders(t *testing.T) {
+	ci.Parallel(t)
+
+	impl := jobValidate{srv: &Server{
+		config: &Config{
+			JobMaxPriority: 100,
+			AnalysisProviders: map[string]*structs.AnalysisProvider{
+				"checks": {Name: "checks", Type: structs.AnalysisGateTypeHTTP, Address: "http://127.0.0.1:8080"},
+			},
+		},
+	}}
+
+	job := mock.Job()
+	job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job.TaskGroups[0].Update.Analysis = &structs.AnalysisStrategy{
+		Steps: []int{100},
+		Gates: []*structs.AnalysisGate{{
+			Name:     "webhook",
+			Type:     structs.AnalysisGateTypeHTTP,
+			Provider: "checks",
+			Timeout:  structs.DefaultAnalysisGateTimeout,
+		}},
+	}
+	_, err := impl.Validate(job)
+	must.NoError(t, err)
+
+	// Jobs can only reference the providers of the server configuration
+	job.TaskGroups[0].Update.Analysis.Gates[0].Provider = "unknown"
+	_, err = impl.Validate(job)
+	must.ErrorContains(t, err, `Analysis gate "webhook" references unknown provider "unknown"`)
+}
+
 func Test_jobValidate_Validate_consul_service(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1386,6 +1418,32 @@ func Test_jobCanonicalizer_Mutate(t *testing.T) {
 	}
 }
 
+func TestMutateSimulatedJob(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.NodePool = ""
+	job.TaskGroups[0].Constraints = nil
+	job.TaskGroups[0].Tasks[0].Services = []*structs.Service{{
+		Name:     "web",
+		Provider: "consul",
+		Identity: &structs.WorkloadIdentity{Audience: []string{"consul.io"}},
+	}}
+	job.TaskGroups[0].Tasks[0].Vault = &structs.Vault{Cluster: "default"}
+
+	job, _, err := MutateSimulatedJob(job)
+	must.NoError(t, err)
+	must.Eq(t, structs.NodePoolDefault, job.NodePool)
+
+	// the identity of the service is completed, but no default identity is
+	// added for Vault without a server configuration
+	task := job.TaskGroups[0].Tasks[0]
+	must.Eq(t, task.Services[0].MakeUniqueIdentityName(), task.Services[0].Identity.Name)
+	must.Eq(t, "web", task.Services[0].Identity.ServiceName)
+	must.SliceEmpty(t, task.Identities)
+	must.SliceContains(t, job.TaskGroups[0].Constraints, implicitIdentityClientVersionConstraint())
+}
+
 func TestJob_submissionController(t *testing.T) {
 	ci.Parallel(t)
 	args := &structs.JobRegisterRequest{
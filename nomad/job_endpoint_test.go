@@ -2400,6 +2400,85 @@ func TestJobEndpoint_Register_ACL_Namespace(t *testing.T) {
 	assert.NotNil(out, "expected job")
 }
 
+func TestJobEndpoint_Register_ACL_AllocationAffinity(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	namespaces := []*structs.Namespace{mock.Namespace(), mock.Namespace(), mock.Namespace()}
+	namespaces[0].Name = "test"
+	namespaces[1].Name = "readable"
+	namespaces[2].Name = "private"
+	must.NoError(t, s1.fsm.State().UpsertNamespaces(1000, namespaces))
+
+	// The token can submit jobs to the test namespace and read the jobs of
+	// the readable one, but not those of the private one
+	policy := mock.NamespacePolicy("test", "", []string{acl.NamespaceCapabilitySubmitJob}) +
+		mock.NamespacePolicy("readable", "", []string{acl.NamespaceCapabilityReadJob})
+	token := mock.CreatePolicyAndToken(t, s1.State(), 1001, "affinity", policy)
+
+	testCases := []struct {
+		name      string
+		namespace string
+		token     *structs.ACLToken
+		allowed   bool
+	}{
+		{name: "own namespace", namespace: "", token: token, allowed: true},
+		{name: "readable namespace", namespace: "readable", token: token, allowed: true},
+		{name: "private namespace", namespace: "private", token: token, allowed: false},
+		{name: "any namespace", namespace: structs.AllocationAffinityAnyNamespace, token: token, allowed: false},
+		{name: "any namespace with management token", namespace: structs.AllocationAffinityAnyNamespace, token: root, allowed: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := mock.Job()
+			job.Namespace = "test"
+			job.TaskGroups[0].AllocationAffinities = []*structs.AllocationAffinity{{
+				Namespace: tc.namespace,
+				JobID:     "cache",
+				Weight:    50,
+			}}
+
+			planReq := &structs.JobPlanRequest{
+				Job: job,
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					Namespace: job.Namespace,
+					AuthToken: tc.token.SecretID,
+				},
+			}
+			var planResp structs.JobPlanResponse
+			err := msgpackrpc.CallWithCodec(codec, "Job.Plan", planReq, &planResp)
+			if tc.allowed {
+				must.NoError(t, err)
+			} else {
+				must.EqError(t, err, structs.ErrPermissionDenied.Error())
+			}
+
+			req := &structs.JobRegisterRequest{
+				Job: job,
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					Namespace: job.Namespace,
+					AuthToken: tc.token.SecretID,
+				},
+			}
+			var resp structs.JobRegisterResponse
+			err = msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+			if tc.allowed {
+				must.NoError(t, err)
+			} else {
+				must.EqError(t, err, structs.ErrPermissionDenied.Error())
+			}
+		})
+	}
+}
+
 func TestJobRegister_ACL_RejectedBySchedulerConfig(t *testing.T) {
 	ci.Parallel(t)
 	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
@@ -3321,6 +3400,71 @@ func TestJobEndpoint_ForceRescheduleEvaluate(t *testing.T) {
 	require.True(*alloc.DesiredTransition.ForceReschedule)
 }
 
+func TestJobEndpoint_ForceRescheduleEvaluate_ArrayIndices(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Array = &structs.ArrayConfig{Start: 5, End: 7}
+	job.TaskGroups[0].Count = 3
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	state := s1.fsm.State()
+	job, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+
+	// Create a failed alloc for each index
+	var allocs []*structs.Allocation
+	for i := uint(0); i < 3; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.Namespace = job.Namespace
+		alloc.Name = structs.AllocName(job.ID, alloc.TaskGroup, i)
+		alloc.ClientStatus = structs.AllocClientStatusFailed
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, resp.Index+1, allocs))
+
+	reEval := &structs.JobEvaluateRequest{
+		JobID:       job.ID,
+		EvalOptions: structs.EvalOptions{ArrayIndices: []int{6}},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Array indices require forcing rescheduling
+	err = msgpackrpc.CallWithCodec(codec, "Job.Evaluate", reEval, &resp)
+	must.ErrorContains(t, err, "array indices can only be set when forcing rescheduling")
+
+	reEval.EvalOptions.ForceReschedule = true
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Evaluate", reEval, &resp))
+
+	// Only the allocation at the requested index is force rescheduled
+	for i, alloc := range allocs {
+		out, err := state.AllocByID(nil, alloc.ID)
+		must.NoError(t, err)
+		must.Eq(t, i == 1, out.DesiredTransition.ShouldForceReschedule())
+	}
+}
+
 func TestJobEndpoint_Evaluate_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -8285,6 +8429,36 @@ func TestJobEndpoint_Scale_SystemJob(t *testing.T) {
 		`400,cannot scale jobs of type "system"`)
 }
 
+func TestJobEndpoint_Scale_Array(t *testing.T) {
+	ci.Parallel(t)
+
+	testServer, testServerCleanup := TestServer(t, nil)
+	defer testServerCleanup()
+	codec := rpcClient(t, testServer)
+	testutil.WaitForLeader(t, testServer.RPC)
+	state := testServer.fsm.State()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Array = &structs.ArrayConfig{End: 9}
+	job.TaskGroups[0].Count = 10
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	scaleReq := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+		},
+		Count: pointer.Of(int64(13)),
+		WriteRequest: structs.WriteRequest{
+			Region:    DefaultRegion,
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Scale", scaleReq, &resp)
+	must.ErrorContains(t, err, "is an array and cannot be scaled")
+}
+
 func TestJobEndpoint_Scale_BatchJob(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -69,3 +70,62 @@ func taskValidateDriver(task *structs.Task, ns *structs.Namespace) bool {
 	}
 	return allow
 }
+
+// jobDependenciesValidate rejects jobs whose dependencies form a cycle with
+// the jobs already registered, since none of the jobs of the cycle could ever
+// be placed. It warns about dependencies on jobs that don't exist yet.
+type jobDependenciesValidate struct {
+	srv *Server
+}
+
+func (jobDependenciesValidate) Name() string {
+	return "dependencies"
+}
+
+func (v jobDependenciesValidate) Validate(job *structs.Job) (warnings []error, err error) {
+	if len(job.DependsOn) == 0 {
+		return nil, nil
+	}
+
+	snap, err := v.srv.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk the dependency graph depth first, starting with the job being
+	// registered since its dependencies may have changed.
+	visited := make(map[string]bool)
+	var visit func(deps []*structs.JobDependency, path []string) error
+	visit = func(deps []*structs.JobDependency, path []string) error {
+		for _, dep := range deps {
+			if dep.JobID == job.ID {
+				return fmt.Errorf("job dependency cycle: %s", strings.Join(append(path, dep.JobID), " -> "))
+			}
+			if visited[dep.JobID] {
+				continue
+			}
+			visited[dep.JobID] = true
+
+			depJob, err := snap.JobByID(nil, job.Namespace, dep.JobID)
+			if err != nil {
+				return err
+			}
+			if depJob == nil {
+				if len(path) == 1 {
+					warnings = append(warnings, fmt.Errorf(
+						"dependency job %q does not exist, the job will not be placed until it is registered", dep.JobID))
+				}
+				continue
+			}
+			if err := visit(depJob.DependsOn, append(path, dep.JobID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := visit(job.DependsOn, []string{job.ID}); err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
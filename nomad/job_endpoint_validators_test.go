@@ -120,3 +120,43 @@ func TestJobNamespaceConstraintCheckHook_validate(t *testing.T) {
 	_, err = hook.Validate(job)
 	require.Equal(t, err.Error(), "used task drivers [\"exec\" \"raw_exec\"] are not allowed in namespace \"default\"")
 }
+
+func TestJobDependenciesValidate(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	hook := jobDependenciesValidate{srv: s1}
+	require.Equal(t, "dependencies", hook.Name())
+
+	// Register db <- cache <- web
+	db := mock.Job()
+	db.ID = "db"
+	cache := mock.Job()
+	cache.ID = "cache"
+	cache.DependsOn = []*structs.JobDependency{{JobID: "db", Condition: structs.JobDependencyConditionHealthy}}
+	web := mock.Job()
+	web.ID = "web"
+	web.DependsOn = []*structs.JobDependency{{JobID: "cache", Condition: structs.JobDependencyConditionHealthy}}
+	for i, job := range []*structs.Job{db, cache, web} {
+		require.NoError(t, s1.fsm.State().UpsertJob(structs.MsgTypeTestSetup, uint64(1000+i), nil, job))
+	}
+
+	warnings, err := hook.Validate(web)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+
+	// Dependencies on jobs that don't exist only warn
+	job := mock.Job()
+	job.DependsOn = []*structs.JobDependency{{JobID: "missing", Condition: structs.JobDependencyConditionComplete}}
+	warnings, err = hook.Validate(job)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+
+	// Updating db to depend on web closes a cycle
+	db = db.Copy()
+	db.DependsOn = []*structs.JobDependency{{JobID: "web", Condition: structs.JobDependencyConditionHealthy}}
+	_, err = hook.Validate(db)
+	require.EqualError(t, err, "job dependency cycle: db -> web -> cache -> db")
+}
@@ -975,17 +975,18 @@ func (s *Server) restorePeriodicDispatcher() error {
 				job.ID, job.Namespace)
 		}
 
-		// nextLaunch is the next launch that should occur.
-		nextLaunch, err := job.Periodic.Next(launch.Launch.In(job.Periodic.GetLocation()))
+		// missed are the launches that should have occurred since the last
+		// launch and should still occur, according to the catch up policy
+		// and starting deadline of the job.
+		missed, err := job.Periodic.MissedLaunches(launch.Launch.In(job.Periodic.GetLocation()), now)
 		if err != nil {
 			logger.Error("failed to determine next periodic launch for job", "job", job.NamespacedID(), "error", err)
 			continue
 		}
 
-		// We skip force launching the job if  there should be no next launch
-		// (the zero case) or if the next launch time is in the future. If it is
-		// in the future, it will be handled by the periodic dispatcher.
-		if nextLaunch.IsZero() || !nextLaunch.Before(now) {
+		// We skip force launching the job if no launch was missed. Future
+		// launches are handled by the periodic dispatcher.
+		if len(missed) == 0 {
 			continue
 		}
 
@@ -999,10 +1000,19 @@ func (s *Server) restorePeriodicDispatcher() error {
 			continue
 		}
 
-		if _, err := s.periodicDispatcher.ForceEval(job.Namespace, job.ID); err != nil {
+		// Catching up on several missed launches launches each of them at
+		// its own launch time, otherwise the job is launched now.
+		if len(missed) > 1 {
+			for _, launchTime := range missed {
+				if _, err := s.periodicDispatcher.createEval(job, launchTime); err != nil {
+					return fmt.Errorf("catch up launch of periodic job %q failed: %v", job.NamespacedID(), err)
+				}
+			}
+		} else if _, err := s.periodicDispatcher.ForceEval(job.Namespace, job.ID); err != nil {
 			logger.Error("force run of periodic job failed", "job", job.NamespacedID(), "error", err)
 			return fmt.Errorf("force run of periodic job %q failed: %v", job.NamespacedID(), err)
 		}
+		s.periodicDispatcher.purgeHistory(job)
 
 		logger.Debug("periodic job force run during leadership establishment", "job", job.NamespacedID())
 	}
@@ -1012,20 +1022,14 @@ func (s *Server) restorePeriodicDispatcher() error {
 
 // cronJobOverlapAllowed checks if the job allows for overlap and if there are already
 // instances of the job running in order to determine if a new evaluation needs to
-// be created upon periodic dispatcher restore
+// be created upon periodic dispatcher restore. Running instances of jobs that
+// replace them are stopped.
 func (s *Server) cronJobOverlapAllowed(job *structs.Job) (bool, error) {
-	if job.Periodic.ProhibitOverlap {
-		running, err := s.periodicDispatcher.dispatcher.RunningChildren(job)
-		if err != nil {
-			return false, fmt.Errorf("failed to determine if periodic job has running children %q error %q", job.NamespacedID(), err)
-		}
-
-		if running {
-			return false, nil
-		}
+	allowed, err := s.periodicDispatcher.ApplyConcurrencyPolicy(job)
+	if err != nil {
+		return false, fmt.Errorf("failed to apply concurrency policy of periodic job %q: %v", job.NamespacedID(), err)
 	}
-
-	return true, nil
+	return allowed, nil
 }
 
 // schedulePeriodic is used to do periodic job dispatch while we are leader
@@ -1048,6 +1052,8 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 	defer rootKeyGC.Stop()
 	variablesRekey := time.NewTicker(s.config.VariablesRekeyInterval)
 	defer variablesRekey.Stop()
+	rebalance := time.NewTicker(s.config.RebalanceInterval)
+	defer rebalance.Stop()
 
 	// Set up the expired ACL local token garbage collection timer.
 	localTokenExpiredGC, localTokenExpiredGCStop := helper.NewSafeTimer(s.config.ACLTokenExpirationGCInterval)
@@ -1101,6 +1107,10 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 			if index, ok := s.getLatestIndex(); ok {
 				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobVariablesRekey, index))
 			}
+		case <-rebalance.C:
+			if index, ok := s.getLatestIndex(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobRebalance, index))
+			}
 		case <-stopCh:
 			return
 		}
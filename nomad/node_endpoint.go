@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -49,6 +50,9 @@ const (
 	// ineligible
 	NodeEligibilityEventIneligible = "Node marked as ineligible for scheduling"
 
+	// NodeTaintsEventUpdated is used when the nodes taints are updated
+	NodeTaintsEventUpdated = "Node taints updated"
+
 	// NodeHeartbeatEventReregistered is the message used when the node becomes
 	// reregistered by the heartbeat.
 	NodeHeartbeatEventReregistered = "Node reregistered by heartbeat"
@@ -298,6 +302,7 @@ func shouldCreateNodeEval(original, updated *structs.Node) bool {
 		reflect.DeepEqual(original.Meta, updated.Meta) &&
 		reflect.DeepEqual(original.Drivers, updated.Drivers) &&
 		reflect.DeepEqual(original.HostVolumes, updated.HostVolumes) &&
+		original.Pressure.Equal(updated.Pressure) &&
 		equalDevices(original, updated))
 }
 
@@ -637,6 +642,11 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 		}
 	}
 
+	// The utilization is stored on its own below, so that it doesn't modify
+	// the node
+	utilization := args.Utilization
+	args.Utilization = nil
+
 	// Commit this update via Raft
 	var index uint64
 	if node.Status != args.Status || args.NodeEvent != nil {
@@ -656,6 +666,20 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 		reply.NodeModifyIndex = index
 	}
 
+	// Store the utilization reported by the client if it changed
+	// significantly, so that heartbeats don't modify the nodes table on
+	// every report
+	if utilization != nil && utilization.ChangedSignificantly(node.Utilization) {
+		req := &structs.NodeUpdateUtilizationRequest{
+			NodeID:       args.NodeID,
+			Utilization:  utilization,
+			WriteRequest: structs.WriteRequest{Region: args.Region},
+		}
+		if _, _, err := n.srv.raftApply(structs.NodeUpdateUtilizationRequestType, req); err != nil {
+			n.logger.Warn("utilization update failed", "node_id", args.NodeID, "error", err)
+		}
+	}
+
 	// Check if we should trigger evaluations
 	if structs.ShouldDrainNode(args.Status) ||
 		nodeStatusTransitionRequiresEval(args.Status, node.Status) {
@@ -931,6 +955,118 @@ func (n *Node) UpdateEligibility(args *structs.NodeUpdateEligibilityRequest,
 	return nil
 }
 
+// UpdateTaints is used to replace the taints of a node
+func (n *Node) UpdateTaints(args *structs.NodeUpdateTaintsRequest,
+	reply *structs.NodeTaintsUpdateResponse) error {
+
+	authErr := n.srv.Authenticate(n.ctx, args)
+	if done, err := n.srv.forward("Node.UpdateTaints", args, args, reply); done {
+		return err
+	}
+	n.srv.MeasureRPCRate("node", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "update_taints"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := n.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Verify the arguments
+	if args.NodeID == "" {
+		return fmt.Errorf("missing node ID for setting taints")
+	}
+	if args.NodeEvent != nil {
+		return fmt.Errorf("node event must not be set")
+	}
+
+	var mErr multierror.Error
+	seen := make(map[string]struct{}, len(args.Taints))
+	for _, taint := range args.Taints {
+		if taint == nil {
+			mErr.Errors = append(mErr.Errors, errors.New("missing taint"))
+			continue
+		}
+		if err := taint.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid taint %q: %v", taint, err))
+			continue
+		}
+		key := taint.Key + ":" + taint.Effect
+		if _, ok := seen[key]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("duplicate taint %q", key))
+		}
+		seen[key] = struct{}{}
+	}
+	if err := mErr.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	// Look for the node
+	snap, err := n.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	node, err := snap.NodeByID(nil, args.NodeID)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("node not found")
+	}
+
+	// Determine whether any taint is removed, in which case allocations may
+	// now be placed on the node
+	removed := slices.ContainsFunc(node.Taints, func(taint *structs.NodeTaint) bool {
+		return !slices.ContainsFunc(args.Taints, taint.Equal)
+	})
+	if !removed && len(node.Taints) == len(args.Taints) {
+		return nil // Nothing to do
+	}
+
+	// Update the timestamp of when the node status was updated
+	args.UpdatedAt = time.Now().Unix()
+
+	// Construct the node event
+	args.NodeEvent = structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemCluster).
+		SetMessage(NodeTaintsEventUpdated)
+	n.logger.Info("node taints updated", "node_id", node.ID, "num_taints", len(args.Taints))
+
+	// Commit this update via Raft
+	outErr, index, err := n.srv.raftApply(structs.NodeUpdateTaintsRequestType, args)
+	if err != nil {
+		n.logger.Error("taints update failed", "error", err)
+		return err
+	}
+	if outErr != nil {
+		if err, ok := outErr.(error); ok && err != nil {
+			n.logger.Error("taints update failed", "error", err)
+			return err
+		}
+	}
+
+	// If a taint is removed, create Node evaluations because there may be a
+	// System job registered that should be evaluated.
+	if removed {
+		evalIDs, evalIndex, err := n.createNodeEvals(node, index)
+		if err != nil {
+			n.logger.Error("eval creation failed", "error", err)
+			return err
+		}
+		reply.EvalIDs = evalIDs
+		reply.EvalCreateIndex = evalIndex
+	}
+
+	// Set the reply index
+	reply.NodeModifyIndex = index
+	reply.Index = index
+	return nil
+}
+
 // Evaluate is used to force a re-evaluation of the node
 func (n *Node) Evaluate(args *structs.NodeEvaluateRequest, reply *structs.NodeUpdateResponse) error {
 
@@ -1400,6 +1536,21 @@ func (n *Node) UpdateAlloc(args *structs.AllocUpdateRequest, reply *structs.Gene
 			}
 		}
 
+		// Array task groups that limit their parallelism start their next
+		// indices once an allocation finishes.
+		if evalTriggerBy == "" && taskGroup != nil && taskGroup.Array != nil &&
+			taskGroup.Array.MaxParallel > 0 && allocToUpdate.TerminalStatus() {
+			evalTriggerBy = structs.EvalTriggerArrayProgress
+		}
+
+		// Completion task groups replace failed allocations regardless of
+		// their reschedule policy and start new allocations once others
+		// finish.
+		if evalTriggerBy == "" && taskGroup != nil && taskGroup.Completion != nil &&
+			allocToUpdate.TerminalStatus() {
+			evalTriggerBy = structs.EvalTriggerCompletionProgress
+		}
+
 		var eval *structs.Evaluation
 		// If unknown, and not an orphan, set the trigger by.
 		if evalTriggerBy != structs.EvalTriggerJobDeregister &&
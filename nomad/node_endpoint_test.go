@@ -2099,6 +2099,129 @@ func TestClientEndpoint_UpdateEligibility(t *testing.T) {
 	require.Equal(NodeEligibilityEventEligible, out.Events[2].Message)
 }
 
+func TestClientEndpoint_UpdateTaints(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the register request
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+
+	// Fetch the response
+	var resp structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	// Invalid and duplicate taints are rejected
+	req := &structs.NodeUpdateTaintsRequest{
+		NodeID: node.ID,
+		Taints: []*structs.NodeTaint{
+			{Key: "gpu", Effect: "bad"},
+			{Key: "spot", Effect: structs.NodeTaintEffectNoSchedule},
+			{Key: "spot", Value: "true", Effect: structs.NodeTaintEffectNoSchedule},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp2 structs.NodeTaintsUpdateResponse
+	err := msgpackrpc.CallWithCodec(codec, "Node.UpdateTaints", req, &resp2)
+	must.ErrorContains(t, err, `invalid taint "gpu:bad"`)
+	must.ErrorContains(t, err, `duplicate taint "spot:no_schedule"`)
+
+	// Add a taint
+	req.Taints = []*structs.NodeTaint{{Key: "spot", Effect: structs.NodeTaintEffectNoSchedule}}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateTaints", req, &resp2))
+	must.NonZero(t, resp2.Index)
+	must.Zero(t, resp2.EvalCreateIndex)
+	must.SliceEmpty(t, resp2.EvalIDs)
+
+	// Check for the node in the FSM
+	state := s1.fsm.State()
+	out, err := state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, req.Taints, out.Taints)
+	must.Len(t, 2, out.Events)
+	must.Eq(t, NodeTaintsEventUpdated, out.Events[1].Message)
+
+	// Register a system job
+	job := mock.SystemJob()
+	must.NoError(t, s1.State().UpsertJob(structs.MsgTypeTestSetup, 10, nil, job))
+
+	// Remove the taint and expect evals
+	req.Taints = nil
+	var resp3 structs.NodeTaintsUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateTaints", req, &resp3))
+	must.NonZero(t, resp3.Index)
+	must.NonZero(t, resp3.EvalCreateIndex)
+	must.Len(t, 1, resp3.EvalIDs)
+
+	out, err = state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.SliceEmpty(t, out.Taints)
+	must.Len(t, 3, out.Events)
+}
+
+func TestClientEndpoint_UpdateStatus_Utilization(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	state := s1.fsm.State()
+	out, err := state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	modifyIndex := out.ModifyIndex
+
+	// Heartbeat with the utilization of the node
+	req := &structs.NodeUpdateStatusRequest{
+		NodeID:       node.ID,
+		Status:       structs.NodeStatusReady,
+		Utilization:  &structs.NodeUtilization{CPUPercent: 42, MemoryPercent: 21, UpdatedAt: 7},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateStatus", req, &resp))
+
+	// The utilization is stored without modifying the node
+	out, err = state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, req.Utilization, out.Utilization)
+	must.Eq(t, modifyIndex, out.ModifyIndex)
+
+	// So updating the taints of the node conditioned on its modify index
+	// still succeeds
+	taintsReq := &structs.NodeUpdateTaintsRequest{
+		NodeID:          node.ID,
+		Taints:          []*structs.NodeTaint{{Key: "spot", Effect: structs.NodeTaintEffectNoSchedule}},
+		NodeModifyIndex: modifyIndex,
+		WriteRequest:    structs.WriteRequest{Region: "global"},
+	}
+	var taintsResp structs.NodeTaintsUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateTaints", taintsReq, &taintsResp))
+
+	// Heartbeats with the same utilization don't write it again
+	index, err := state.Index("nodes")
+	must.NoError(t, err)
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateStatus", req, &resp))
+	after, err := state.Index("nodes")
+	must.NoError(t, err)
+	must.Eq(t, index, after)
+}
+
 func TestClientEndpoint_UpdateEligibility_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4655,6 +4778,10 @@ func TestClientEndpoint_ShouldCreateNodeEval(t *testing.T) {
 				}
 			},
 		},
+		{
+			"pressure changed",
+			func(n *structs.Node) { n.Pressure = &structs.NodePressure{Memory: true} },
+		},
 	}
 
 	for _, c := range positiveCases {
@@ -4679,6 +4806,8 @@ func TestClientEndpoint_UpdateAlloc_Evals_ByTrigger(t *testing.T) {
 		missingJob         bool
 		missingAlloc       bool
 		invalidTaskGroup   bool
+		array              bool
+		completion         bool
 	}
 
 	testCases := []testCase{
@@ -4727,6 +4856,26 @@ func TestClientEndpoint_UpdateAlloc_Evals_ByTrigger(t *testing.T) {
 			missingAlloc:       false,
 			invalidTaskGroup:   false,
 		},
+		{
+			name:               "complete-array-alloc",
+			clientStatus:       structs.AllocClientStatusComplete,
+			serverClientStatus: structs.AllocClientStatusRunning,
+			triggerBy:          structs.EvalTriggerArrayProgress,
+			missingJob:         false,
+			missingAlloc:       false,
+			invalidTaskGroup:   false,
+			array:              true,
+		},
+		{
+			name:               "failed-completion-alloc",
+			clientStatus:       structs.AllocClientStatusFailed,
+			serverClientStatus: structs.AllocClientStatusRunning,
+			triggerBy:          structs.EvalTriggerCompletionProgress,
+			missingJob:         false,
+			missingAlloc:       false,
+			invalidTaskGroup:   false,
+			completion:         true,
+		},
 		{
 			name:               "no-alloc-at-server",
 			clientStatus:       structs.AllocClientStatusUnknown,
@@ -4776,6 +4925,15 @@ func TestClientEndpoint_UpdateAlloc_Evals_ByTrigger(t *testing.T) {
 
 			job := mock.Job()
 			job.ID = tc.name + "-test-job"
+			if tc.array {
+				job.Type = structs.JobTypeBatch
+				job.TaskGroups[0].Array = &structs.ArrayConfig{End: 9, MaxParallel: 2}
+			}
+			if tc.completion {
+				job.Type = structs.JobTypeBatch
+				job.TaskGroups[0].Completion = &structs.CompletionPolicy{Completions: 10, Parallelism: 2}
+				job.TaskGroups[0].ReschedulePolicy = &structs.ReschedulePolicy{Attempts: 0, Unlimited: false}
+			}
 
 			if !tc.missingJob {
 				err = fsmState.UpsertJob(structs.MsgTypeTestSetup, 101, nil, job)
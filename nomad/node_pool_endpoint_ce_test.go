@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !ent
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+// TestNodePoolEndpoint_UpsertNodePools_SchedulerConfiguration asserts the
+// scheduler configuration of node pools, including the scheduler algorithms,
+// memory oversubscription and load aware scoring, requires Nomad Enterprise.
+func TestNodePoolEndpoint_UpsertNodePools_SchedulerConfiguration(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	testCases := []struct {
+		name   string
+		config *structs.NodePoolSchedulerConfiguration
+	}{
+		{
+			name: "balanced algorithm",
+			config: &structs.NodePoolSchedulerConfiguration{
+				SchedulerAlgorithm: structs.SchedulerAlgorithmBalanced,
+			},
+		},
+		{
+			name: "memory oversubscription",
+			config: &structs.NodePoolSchedulerConfiguration{
+				MemoryOversubscriptionEnabled: pointer.Of(true),
+			},
+		},
+		{
+			name: "load aware scoring",
+			config: &structs.NodePoolSchedulerConfiguration{
+				LoadAwareConfig: &structs.LoadAwareConfig{
+					Enabled:              true,
+					UtilizationThreshold: 80,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := mock.NodePool()
+			pool.SchedulerConfiguration = tc.config
+
+			req := &structs.NodePoolUpsertRequest{
+				WriteRequest: structs.WriteRequest{
+					Region: "global",
+				},
+				NodePools: []*structs.NodePool{pool},
+			}
+			var resp structs.GenericResponse
+			err := msgpackrpc.CallWithCodec(codec, "NodePool.UpsertNodePools", req, &resp)
+			must.ErrorContains(t, err, `Feature "Node Pools Governance" is unlicensed`)
+
+			got, err := s.fsm.State().NodePoolByName(nil, pool.Name)
+			must.NoError(t, err)
+			must.Nil(t, got)
+		})
+	}
+}
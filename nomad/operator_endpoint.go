@@ -553,6 +553,62 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 	return nil
 }
 
+// SchedulerRebalanceCandidates is used to retrieve the allocations the
+// rebalancer would migrate with the current Scheduler configuration, whether
+// rebalancing is enabled or not.
+func (op *Operator) SchedulerRebalanceCandidates(args *structs.GenericRequest, reply *structs.SchedulerRebalanceCandidatesResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.SchedulerRebalanceCandidates", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := op.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	index, config, err := snap.SchedulerConfig()
+	if err != nil {
+		return err
+	} else if config == nil {
+		return fmt.Errorf("scheduler config not initialized yet")
+	}
+
+	candidates, err := rebalanceCandidates(op.logger, snap, &config.RebalanceConfig)
+	if err != nil {
+		return err
+	}
+
+	reply.Candidates = make([]*structs.RebalanceCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		alloc := candidate.Alloc
+		reply.Candidates = append(reply.Candidates, &structs.RebalanceCandidate{
+			AllocID:   alloc.ID,
+			Namespace: alloc.Namespace,
+			JobID:     alloc.JobID,
+			TaskGroup: alloc.TaskGroup,
+			NodeID:    alloc.NodeID,
+			Reason:    candidate.Reason,
+		})
+	}
+	reply.QueryMeta.Index = index
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
 func (op *Operator) forwardStreamingRPC(region string, method string, args interface{}, in io.ReadWriteCloser) error {
 	server, err := op.srv.findRegionServer(region)
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/hashicorp/raft"
 	"github.com/shoenig/test/must"
@@ -699,6 +700,77 @@ func TestOperator_SchedulerGetConfiguration(t *testing.T) {
 	require.True(reply.SchedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
 }
 
+func TestOperator_SchedulerRebalanceCandidates(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	// Place the allocations of a job on an over-utilized node, and only dry
+	// run rebalancing
+	node := mock.Node()
+	node.Utilization = &structs.NodeUtilization{CPUPercent: 95}
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1001, mock.Node()))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Migrate = &structs.MigrateStrategy{MaxParallel: 2}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1003, allocs))
+	must.NoError(t, store.SchedulerSetConfig(1004, &structs.SchedulerConfiguration{
+		RebalanceConfig: structs.RebalanceConfig{
+			DryRun:               true,
+			Namespaces:           []string{"*"},
+			MaxMigrations:        1,
+			UtilizationThreshold: 80,
+		},
+	}))
+
+	arg := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+
+	// An anonymous request is denied
+	var reply structs.SchedulerRebalanceCandidatesResponse
+	err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalanceCandidates", &arg, &reply)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// The candidates are limited by the maximum number of migrations
+	arg.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalanceCandidates", &arg, &reply))
+	must.Eq(t, 1004, reply.Index)
+	must.Len(t, 1, reply.Candidates)
+
+	candidate := reply.Candidates[0]
+	must.Eq(t, job.ID, candidate.JobID)
+	must.Eq(t, job.Namespace, candidate.Namespace)
+	must.Eq(t, node.ID, candidate.NodeID)
+	must.Eq(t, scheduler.RebalanceReasonNodeUtilization, candidate.Reason)
+
+	// Listing the candidates never migrates them
+	for _, alloc := range allocs {
+		out, err := store.AllocByID(nil, alloc.ID)
+		must.NoError(t, err)
+		must.False(t, out.DesiredTransition.ShouldMigrate())
+	}
+}
+
 func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	ci.Parallel(t)
 
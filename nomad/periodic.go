@@ -4,9 +4,11 @@
 package nomad
 
 import (
+	"cmp"
 	"container/heap"
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -45,6 +47,13 @@ type JobEvalDispatcher interface {
 
 	// RunningChildren returns whether the passed job has any running children.
 	RunningChildren(job *structs.Job) (bool, error)
+
+	// StopChildren stops the children of the passed job that are not dead.
+	StopChildren(job *structs.Job) error
+
+	// PurgeChildren purges the oldest dead children of the passed job so that
+	// at most keep of them remain.
+	PurgeChildren(job *structs.Job, keep int) error
 }
 
 // DispatchJob creates an evaluation for the passed job and commits both the
@@ -135,6 +144,103 @@ func (s *Server) RunningChildren(job *structs.Job) (bool, error) {
 	return false, nil
 }
 
+// children returns the child jobs of the passed periodic job, oldest first.
+func (s *Server) children(job *structs.Job) ([]*structs.Job, error) {
+	snap, err := s.fsm.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("%s%s", job.ID, structs.PeriodicLaunchSuffix)
+	iter, err := snap.JobsByIDPrefix(nil, job.Namespace, prefix, state.SortDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	var children []*structs.Job
+	for i := iter.Next(); i != nil; i = iter.Next() {
+		child := i.(*structs.Job)
+		if child.ParentID == job.ID {
+			children = append(children, child)
+		}
+	}
+	slices.SortFunc(children, func(a, b *structs.Job) int {
+		return cmp.Compare(a.CreateIndex, b.CreateIndex)
+	})
+	return children, nil
+}
+
+// StopChildren stops the children of the passed job that are not dead by
+// deregistering them.
+func (s *Server) StopChildren(job *structs.Job) error {
+	children, err := s.children(job)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if child.Stop || child.Status == structs.JobStatusDead {
+			continue
+		}
+
+		now := time.Now().UTC().UnixNano()
+		eval := &structs.Evaluation{
+			ID:          uuid.Generate(),
+			Namespace:   child.Namespace,
+			Priority:    child.Priority,
+			Type:        child.Type,
+			TriggeredBy: structs.EvalTriggerJobDeregister,
+			JobID:       child.ID,
+			Status:      structs.EvalStatusPending,
+			CreateTime:  now,
+			ModifyTime:  now,
+		}
+		req := structs.JobDeregisterRequest{
+			JobID:      child.ID,
+			Eval:       eval,
+			SubmitTime: now,
+			WriteRequest: structs.WriteRequest{
+				Namespace: child.Namespace,
+			},
+		}
+		if _, _, err := s.raftApply(structs.JobDeregisterRequestType, req); err != nil {
+			return fmt.Errorf("failed to stop child job %q: %v", child.ID, err)
+		}
+	}
+	return nil
+}
+
+// PurgeChildren purges the oldest dead children of the passed job so that at
+// most keep of them remain. Their evaluations and allocations are garbage
+// collected afterwards.
+func (s *Server) PurgeChildren(job *structs.Job, keep int) error {
+	children, err := s.children(job)
+	if err != nil {
+		return err
+	}
+
+	var dead []*structs.Job
+	for _, child := range children {
+		if child.Status == structs.JobStatusDead {
+			dead = append(dead, child)
+		}
+	}
+	if len(dead) <= keep {
+		return nil
+	}
+
+	req := structs.JobBatchDeregisterRequest{
+		Jobs: make(map[structs.NamespacedID]*structs.JobDeregisterOptions),
+	}
+	for _, child := range dead[:len(dead)-keep] {
+		req.Jobs[child.NamespacedID()] = &structs.JobDeregisterOptions{Purge: true}
+	}
+	if _, _, err := s.raftApply(structs.JobBatchDeregisterRequestType, req); err != nil {
+		return fmt.Errorf("failed to purge child jobs: %v", err)
+	}
+	return nil
+}
+
 // NewPeriodicDispatch returns a periodic dispatcher that is used to track and
 // launch periodic jobs.
 func NewPeriodicDispatch(logger log.Logger, dispatcher JobEvalDispatcher) *PeriodicDispatch {
@@ -347,26 +453,68 @@ func (p *PeriodicDispatch) dispatch(job *structs.Job, launchTime time.Time) {
 		p.logger.Error("failed to update next launch of periodic job", "job", job.NamespacedID(), "error", err)
 	}
 
+	// The lock isn't held while applying the concurrency policy since
+	// stopping the children of the job is applied through raft, which
+	// updates the dispatcher.
+	p.l.Unlock()
+
+	// Skip launches that are later than the starting deadline allows, for
+	// instance because the dispatcher was blocked.
+	if job.Periodic.Late(launchTime, time.Now()) {
+		p.logger.Debug("skipping launch of periodic job because it missed its starting deadline",
+			"job", job.NamespacedID(), "launch_time", launchTime)
+		return
+	}
+
 	// If the job prohibits overlapping and there are running children, we skip
 	// the launch.
-	if job.Periodic.ProhibitOverlap {
+	allowed, err := p.ApplyConcurrencyPolicy(job)
+	if err != nil {
+		p.logger.Error("failed to apply concurrency policy of periodic job", "job", job.NamespacedID(), "error", err)
+		return
+	}
+	if !allowed {
+		p.logger.Debug("skipping launch of periodic job because job prohibits overlap", "job", job.NamespacedID())
+		return
+	}
+
+	p.logger.Debug(" launching job", "job", job.NamespacedID(), "launch_time", launchTime)
+	if _, err := p.createEval(job, launchTime); err != nil {
+		return
+	}
+	p.purgeHistory(job)
+}
+
+// ApplyConcurrencyPolicy applies the concurrency policy of the periodic job
+// before a launch. It returns whether the job can be launched, which is not
+// the case if the job prohibits overlap and has running children. Jobs that
+// replace their running children have them stopped.
+func (p *PeriodicDispatch) ApplyConcurrencyPolicy(job *structs.Job) (bool, error) {
+	switch {
+	case job.Periodic.ProhibitsOverlap():
 		running, err := p.dispatcher.RunningChildren(job)
 		if err != nil {
-			p.logger.Error("failed to determine if periodic job has running children", "job", job.NamespacedID(), "error", err)
-			p.l.Unlock()
-			return
+			return false, fmt.Errorf("failed to determine if periodic job has running children: %v", err)
 		}
+		return !running, nil
 
-		if running {
-			p.logger.Debug("skipping launch of periodic job because job prohibits overlap", "job", job.NamespacedID())
-			p.l.Unlock()
-			return
+	case job.Periodic.ConcurrencyPolicy == structs.PeriodicConcurrencyReplace:
+		if err := p.dispatcher.StopChildren(job); err != nil {
+			return false, fmt.Errorf("failed to stop running children of periodic job: %v", err)
 		}
 	}
+	return true, nil
+}
 
-	p.logger.Debug(" launching job", "job", job.NamespacedID(), "launch_time", launchTime)
-	p.l.Unlock()
-	p.createEval(job, launchTime)
+// purgeHistory purges the oldest dead children of the periodic job beyond
+// its history limit.
+func (p *PeriodicDispatch) purgeHistory(job *structs.Job) {
+	if job.Periodic.HistoryLimit == 0 {
+		return
+	}
+	if err := p.dispatcher.PurgeChildren(job, job.Periodic.HistoryLimit); err != nil {
+		p.logger.Error("failed to purge children of periodic job", "job", job.NamespacedID(), "error", err)
+	}
 }
 
 // nextLaunch returns the next job to launch and when it should be launched. If
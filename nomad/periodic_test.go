@@ -54,6 +54,35 @@ func (m *MockJobEvalDispatcher) RunningChildren(parent *structs.Job) (bool, erro
 	return false, nil
 }
 
+func (m *MockJobEvalDispatcher) StopChildren(parent *structs.Job) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, job := range m.Jobs {
+		if job.ParentID == parent.ID && job.Namespace == parent.Namespace {
+			job.Stop = true
+		}
+	}
+	return nil
+}
+
+func (m *MockJobEvalDispatcher) PurgeChildren(parent *structs.Job, keep int) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var dead []*structs.Job
+	for _, job := range m.Jobs {
+		if job.ParentID == parent.ID && job.Namespace == parent.Namespace &&
+			job.Status == structs.JobStatusDead {
+			dead = append(dead, job)
+		}
+	}
+	sort.Slice(dead, func(i, j int) bool { return dead[i].ID < dead[j].ID })
+	for len(dead) > keep {
+		delete(m.Jobs, dead[0].NamespacedID())
+		dead = dead[1:]
+	}
+	return nil
+}
+
 // LaunchTimes returns the launch times of child jobs in sorted order.
 func (m *MockJobEvalDispatcher) LaunchTimes(p *PeriodicDispatch, namespace, parentID string) ([]time.Time, error) {
 	m.lock.Lock()
@@ -427,6 +456,32 @@ func TestPeriodicDispatch_Run_DisallowOverlaps(t *testing.T) {
 	}
 }
 
+func TestPeriodicDispatch_Run_ReplaceOverlaps(t *testing.T) {
+	ci.Parallel(t)
+	p, m := testPeriodicDispatcher(t)
+
+	// Create a job that will trigger two launches and replaces running
+	// children.
+	launch1 := time.Now().Round(1 * time.Second).Add(1 * time.Second)
+	launch2 := time.Now().Round(1 * time.Second).Add(2 * time.Second)
+	job := testPeriodicJob(launch1, launch2)
+	job.Periodic.ConcurrencyPolicy = structs.PeriodicConcurrencyReplace
+	require.NoError(t, p.Add(job))
+
+	time.Sleep(3 * time.Second)
+
+	// Check that both jobs were launched and the first one was stopped.
+	times, err := m.LaunchTimes(p, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{launch1, launch2}, []time.Time(times))
+
+	for _, child := range m.dispatchedJobs(job) {
+		launch, err := p.LaunchTime(child.ID)
+		require.NoError(t, err)
+		require.Equal(t, launch.Equal(launch1), child.Stop)
+	}
+}
+
 func TestPeriodicDispatch_Run_Multiple(t *testing.T) {
 	ci.Parallel(t)
 	p, m := testPeriodicDispatcher(t)
@@ -726,6 +781,76 @@ func TestPeriodicDispatch_RunningChildren_ActiveEvals(t *testing.T) {
 	}
 }
 
+func TestPeriodicDispatch_StopChildren(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Insert periodic job and child.
+	state := s1.fsm.State()
+	job := mock.PeriodicJob()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	childjob := deriveChildJob(job)
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, childjob))
+
+	require.NoError(t, s1.StopChildren(job))
+
+	out, err := state.JobByID(nil, childjob.Namespace, childjob.ID)
+	require.NoError(t, err)
+	require.True(t, out.Stop)
+
+	// The parent is left untouched
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.False(t, out.Stop)
+}
+
+func TestPeriodicDispatch_PurgeChildren(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+	job := mock.PeriodicJob()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	// Insert three dead children and a running one
+	var children []*structs.Job
+	for i := 0; i < 4; i++ {
+		child := mock.Job()
+		child.ParentID = job.ID
+		child.ID = fmt.Sprintf("%s%s%d", job.ID, structs.PeriodicLaunchSuffix, i)
+		index := uint64(1001 + 2*i)
+		require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, index, nil, child))
+
+		eval := mock.Eval()
+		eval.JobID = child.ID
+		eval.Status = structs.EvalStatusComplete
+		if i == 3 {
+			eval.Status = structs.EvalStatusPending
+		}
+		require.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, index+1, []*structs.Evaluation{eval}))
+		children = append(children, child)
+	}
+
+	// Only the most recent dead child is kept
+	require.NoError(t, s1.PurgeChildren(job, 1))
+	for i, child := range children {
+		out, err := state.JobByID(nil, child.Namespace, child.ID)
+		require.NoError(t, err)
+		if i < 2 {
+			require.Nil(t, out)
+		} else {
+			require.NotNil(t, out)
+		}
+	}
+}
+
 func TestPeriodicDispatch_RunningChildren_ActiveAllocs(t *testing.T) {
 	ci.Parallel(t)
 
@@ -760,6 +760,9 @@ func evaluateNodePlan(snap *state.StateSnapshot, plan *structs.Plan, nodeID stri
 	if node.SchedulingEligibility == structs.NodeSchedulingIneligible {
 		return false, "node is not eligible", nil
 	}
+	if node.Pressure.Pressured() {
+		return false, "node is under resource pressure", nil
+	}
 
 	// Determine the proposed allocation by first removing allocations
 	// that are planned evictions and adding the new allocations.
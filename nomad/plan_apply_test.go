@@ -820,6 +820,28 @@ func TestPlanApply_EvalNodePlan_NodeDrain(t *testing.T) {
 	}
 }
 
+func TestPlanApply_EvalNodePlan_NodePressure(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+	node := mock.Node()
+	node.Pressure = &structs.NodePressure{Memory: true}
+	state.UpsertNode(structs.MsgTypeTestSetup, 1000, node)
+	snap, _ := state.Snapshot()
+
+	alloc := mock.Alloc()
+	plan := &structs.Plan{
+		Job: alloc.Job,
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID: {alloc},
+		},
+	}
+
+	fit, reason, err := evaluateNodePlan(snap, plan, node.ID)
+	must.NoError(t, err)
+	must.False(t, fit)
+	must.Eq(t, "node is under resource pressure", reason)
+}
+
 func TestPlanApply_EvalNodePlan_NodeNotExist(t *testing.T) {
 	ci.Parallel(t)
 	state := testStateStore(t)
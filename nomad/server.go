@@ -381,6 +381,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 	}
 	s.evalBroker = evalBroker
 
+	// Share the eval broker across namespaces according to their weight
+	s.evalBroker.SetNamespaceWeightFn(s.namespaceEvalQueueWeight)
+
 	// Create the blocked evals
 	s.blockedEvals = NewBlockedEvals(s.evalBroker, s.logger)
 
@@ -1168,6 +1171,8 @@ func (s *Server) setupDeploymentWatcher() error {
 		raftShim,
 		NewDeploymentEndpoint(s, nil),
 		NewJobEndpoints(s, nil),
+		&deploymentActionRunner{srv: s},
+		s.config.AnalysisProviders,
 		s.config.DeploymentQueryRateLimit,
 		deploymentwatcher.CrossDeploymentUpdateBatchDuration,
 	)
@@ -1361,6 +1366,19 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	ent.Register(server)
 }
 
+// namespaceEvalQueueWeight returns the eval queue weight of a namespace, or
+// zero if the namespace can't be found.
+func (s *Server) namespaceEvalQueueWeight(namespace string) int {
+	if s.fsm == nil {
+		return 0
+	}
+	ns, err := s.fsm.State().NamespaceByName(nil, namespace)
+	if err != nil || ns == nil {
+		return 0
+	}
+	return ns.EvalQueueWeight
+}
+
 // setupRaft is used to setup and initialize Raft
 func (s *Server) setupRaft() error {
 
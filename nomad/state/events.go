@@ -22,11 +22,13 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.JobBatchDeregisterRequestType:                structs.TypeJobBatchDeregistered,
 	structs.AllocUpdateDesiredTransitionRequestType:      structs.TypeAllocationUpdateDesiredStatus,
 	structs.NodeUpdateEligibilityRequestType:             structs.TypeNodeDrain,
+	structs.NodeUpdateTaintsRequestType:                  structs.TypeNodeTaints,
 	structs.NodeUpdateDrainRequestType:                   structs.TypeNodeDrain,
 	structs.BatchNodeUpdateDrainRequestType:              structs.TypeNodeDrain,
 	structs.DeploymentStatusUpdateRequestType:            structs.TypeDeploymentUpdate,
 	structs.DeploymentPromoteRequestType:                 structs.TypeDeploymentPromotion,
 	structs.DeploymentAllocHealthRequestType:             structs.TypeDeploymentAllocHealth,
+	structs.DeploymentAnalysisRequestType:                structs.TypeDeploymentAnalysis,
 	structs.ApplyPlanResultsRequestType:                  structs.TypePlanResult,
 	structs.ACLTokenDeleteRequestType:                    structs.TypeACLTokenDeleted,
 	structs.ACLTokenUpsertRequestType:                    structs.TypeACLTokenUpserted,
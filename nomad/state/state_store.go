@@ -999,6 +999,7 @@ func upsertNodeTxn(txn *txn, index uint64, node *structs.Node) error {
 		node.SchedulingEligibility = exist.SchedulingEligibility // Retain the eligibility
 		node.DrainStrategy = exist.DrainStrategy                 // Retain the drain strategy
 		node.LastDrain = exist.LastDrain                         // Retain the drain metadata
+		node.Taints = exist.Taints                               // Retain the taints
 
 		// Retain the last index the node missed a heartbeat.
 		if node.LastMissedHeartbeatIndex < exist.LastMissedHeartbeatIndex {
@@ -1312,6 +1313,89 @@ func (s *StateStore) updateNodeEligibilityImpl(index uint64, nodeID string, elig
 	return nil
 }
 
+// UpdateNodeTaints is used to replace the taints of a node. If modifyIndex is
+// set, the taints are only replaced if the node has this ModifyIndex.
+func (s *StateStore) UpdateNodeTaints(msgType structs.MessageType, index uint64, nodeID string, modifyIndex uint64, taints []*structs.NodeTaint, updatedAt int64, event *structs.NodeEvent) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// Lookup the node
+	existing, err := txn.First("nodes", "id", nodeID)
+	if err != nil {
+		return fmt.Errorf("node lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("node not found")
+	}
+	if current := existing.(*structs.Node).ModifyIndex; modifyIndex != 0 && modifyIndex != current {
+		return fmt.Errorf("%s %d: node has conflicting modify index %d",
+			structs.NodeTaintsEnforceIndexErrPrefix, modifyIndex, current)
+	}
+
+	// Copy the existing node
+	copyNode := existing.(*structs.Node).Copy()
+	copyNode.StatusUpdatedAt = updatedAt
+
+	// Add the event if given
+	if event != nil {
+		appendNodeEvents(index, copyNode, []*structs.NodeEvent{event})
+	}
+
+	// Update the taints in the copy
+	copyNode.Taints = taints
+	copyNode.ModifyIndex = index
+
+	// Insert the node
+	if err := txn.Insert("nodes", copyNode); err != nil {
+		return fmt.Errorf("node update failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"nodes", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// UpdateNodeUtilization is used to update the utilization of a node reported
+// by its client. The ModifyIndex of the node is left unchanged since the
+// utilization is telemetry rather than a change to the node, so that it
+// doesn't invalidate updates conditioned on the ModifyIndex, such as those of
+// the taints of the node. A utilization that didn't change significantly is
+// dropped, so that reports don't bump the index of the nodes table and wake
+// up every blocking query on nodes.
+func (s *StateStore) UpdateNodeUtilization(msgType structs.MessageType, index uint64, nodeID string, utilization *structs.NodeUtilization) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// Lookup the node
+	existing, err := txn.First("nodes", "id", nodeID)
+	if err != nil {
+		return fmt.Errorf("node lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("node not found")
+	}
+
+	existingNode := existing.(*structs.Node)
+	if !utilization.ChangedSignificantly(existingNode.Utilization) {
+		return nil
+	}
+
+	// Update the utilization in a copy of the node
+	copyNode := existingNode.Copy()
+	copyNode.Utilization = utilization
+
+	// Insert the node
+	if err := txn.Insert("nodes", copyNode); err != nil {
+		return fmt.Errorf("node update failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"nodes", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
 // UpsertNodeEvents adds the node events to the nodes, rotating events as
 // necessary.
 func (s *StateStore) UpsertNodeEvents(msgType structs.MessageType, index uint64, nodeEvents map[string][]*structs.NodeEvent) error {
@@ -1791,6 +1875,15 @@ func (s *StateStore) upsertJobImpl(index uint64, sub *structs.JobSubmission, job
 		if err != nil {
 			return fmt.Errorf("setting job status for %q failed: %v", job.ID, err)
 		}
+
+		// The completion failure only needs to be recomputed when the job
+		// status or version changes.
+		if job.Status == existingJob.Status && job.Version == existingJob.Version {
+			job.CompletionFailed = existingJob.CompletionFailed
+			job.StatusDescription = existingJob.StatusDescription
+		} else if err := s.setJobCompletionFailure(txn, job); err != nil {
+			return fmt.Errorf("setting job status for %q failed: %v", job.ID, err)
+		}
 	} else {
 		job.CreateIndex = index
 		job.ModifyIndex = index
@@ -3952,6 +4045,7 @@ func (s *StateStore) nestedUpdateAllocFromClient(txn *txn, index uint64, alloc *
 	// Pull in anything the client is the authority on
 	copyAlloc.ClientStatus = alloc.ClientStatus
 	copyAlloc.ClientDescription = alloc.ClientDescription
+	copyAlloc.Evicted = alloc.Evicted
 	copyAlloc.TaskStates = alloc.TaskStates
 	copyAlloc.NetworkStatus = alloc.NetworkStatus
 
@@ -4820,13 +4914,25 @@ func (s *StateStore) updateDeploymentStatusImpl(index uint64, u *structs.Deploym
 	} else if deployment == nil {
 		return fmt.Errorf("Deployment ID %q couldn't be updated as it does not exist", u.DeploymentID)
 	} else if !deployment.Active() {
+		// The hold period of a deployment that was terminated since the
+		// update was computed no longer matters
+		if u.Status == "" {
+			return nil
+		}
 		return fmt.Errorf("Deployment %q has terminal status %q:", deployment.ID, deployment.Status)
 	}
 
 	// Apply the new status
 	copy := deployment.Copy()
-	copy.Status = u.Status
-	copy.StatusDescription = u.StatusDescription
+	if u.Status != "" {
+		copy.Status = u.Status
+		copy.StatusDescription = u.StatusDescription
+	}
+	for tg, evalID := range u.HoldEvalIDs {
+		if dstate, ok := copy.TaskGroups[tg]; ok {
+			dstate.HoldEvalID = evalID
+		}
+	}
 	copy.ModifyIndex = index
 
 	// Insert the deployment
@@ -4846,6 +4952,74 @@ func (s *StateStore) updateDeploymentStatusImpl(index uint64, u *structs.Deploym
 		}
 	}
 
+	// If a blue/green deployment failed or was cancelled while holding the
+	// previous allocations, put them back in service.
+	if !copy.Active() && copy.Status != structs.DeploymentStatusSuccessful {
+		held := make(map[string]struct{})
+		for tg, dstate := range copy.TaskGroups {
+			if !dstate.HoldUntil.IsZero() {
+				held[tg] = struct{}{}
+			}
+		}
+		if len(held) != 0 {
+			if err := s.updateStandbyAllocs(index, copy, held, false, txn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// updateStandbyAllocs marks the non-terminal allocations of the given task
+// groups that are not part of the blue/green deployment as standby or back in
+// service.
+func (s *StateStore) updateStandbyAllocs(index uint64, deployment *structs.Deployment,
+	groups map[string]struct{}, standby bool, txn *txn) error {
+
+	iter, err := txn.Get("allocs", "job", deployment.Namespace, deployment.JobID)
+	if err != nil {
+		return err
+	}
+
+	var updated []*structs.Allocation
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		alloc := raw.(*structs.Allocation)
+		if _, ok := groups[alloc.TaskGroup]; !ok ||
+			alloc.DeploymentID == deployment.ID ||
+			alloc.Job.CreateIndex != deployment.JobCreateIndex ||
+			alloc.TerminalStatus() ||
+			alloc.DeploymentStatus.IsStandby() == standby {
+			continue
+		}
+
+		copy := alloc.Copy()
+		if copy.DeploymentStatus == nil {
+			copy.DeploymentStatus = &structs.AllocDeploymentStatus{}
+		}
+		copy.DeploymentStatus.Standby = standby
+		copy.DeploymentStatus.ModifyIndex = index
+		copy.ModifyIndex = index
+		copy.AllocModifyIndex = index
+		updated = append(updated, copy)
+	}
+
+	// Insert once done iterating since the inserts invalidate the iterator
+	for _, alloc := range updated {
+		if err := txn.Insert("allocs", alloc); err != nil {
+			return fmt.Errorf("alloc insert failed: %v", err)
+		}
+	}
+	if len(updated) != 0 {
+		if err := txn.Insert("index", &IndexEntry{"allocs", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -4963,8 +5137,11 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 			continue
 		}
 
+		// Promoting all the task groups skips those waiting for the task
+		// groups they depend on, they are promoted once their canaries are
+		// placed
 		need := dstate.DesiredCanaries
-		if need == 0 {
+		if need == 0 || (req.All && dstate.Waiting()) {
 			continue
 		}
 
@@ -4980,9 +5157,10 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 	// Update deployment
 	copy := deployment.Copy()
 	copy.ModifyIndex = index
+	held := make(map[string]struct{})
 	for tg, status := range copy.TaskGroups {
 		_, ok := groupIndex[tg]
-		if !req.All && !ok {
+		if (!req.All && !ok) || (req.All && status.Waiting()) {
 			continue
 		}
 
@@ -4990,6 +5168,13 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 		if status.ProgressDeadline > 0 && !status.RequireProgressBy.IsZero() {
 			status.RequireProgressBy = time.Now().Add(status.ProgressDeadline)
 		}
+
+		// keep the previous allocations of blue/green task groups running
+		// for their hold period
+		if status.HoldPeriod > 0 && !status.Promoted {
+			status.HoldUntil = time.Now().Add(status.HoldPeriod)
+			held[tg] = struct{}{}
+		}
 		status.Promoted = true
 	}
 
@@ -5010,6 +5195,13 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 		}
 	}
 
+	// Take the previous allocations of the held task groups out of service
+	if len(held) != 0 {
+		if err := s.updateStandbyAllocs(index, copy, held, true, txn); err != nil {
+			return err
+		}
+	}
+
 	// For each promotable allocation remove the canary field
 	for _, alloc := range promotable {
 		promoted := alloc.Copy()
@@ -5126,6 +5318,68 @@ func (s *StateStore) UpdateDeploymentAllocHealth(msgType structs.MessageType, in
 	return txn.Commit()
 }
 
+// UpdateDeploymentAnalysis is used to record the results of the analysis gates
+// of a task group of the deployment, advance it to its next analysis step and
+// potentially make an evaluation
+func (s *StateStore) UpdateDeploymentAnalysis(msgType structs.MessageType, index uint64, req *structs.ApplyDeploymentAnalysisRequest) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// Retrieve deployment and ensure it is not terminal and is active
+	ws := memdb.NewWatchSet()
+	deployment, err := s.deploymentByIDImpl(ws, req.DeploymentID, txn)
+	if err != nil {
+		return err
+	} else if deployment == nil {
+		return fmt.Errorf("Deployment ID %q couldn't be updated as it does not exist", req.DeploymentID)
+	} else if !deployment.Active() {
+		return fmt.Errorf("Deployment %q has terminal status %q:", deployment.ID, deployment.Status)
+	}
+
+	copy := deployment.Copy()
+	dstate, ok := copy.TaskGroups[req.TaskGroup]
+	if !ok {
+		return fmt.Errorf("Deployment %q does not have task group %q", deployment.ID, req.TaskGroup)
+	}
+	dstate.AnalysisResults = append(dstate.AnalysisResults, req.Results...)
+
+	// Advance to the next step and give the allocations it updates a full
+	// progress deadline, like promoting canaries does
+	if req.Passed {
+		dstate.AnalysisStep++
+		if dstate.ProgressDeadline > 0 && !dstate.RequireProgressBy.IsZero() {
+			dstate.RequireProgressBy = time.Now().Add(dstate.ProgressDeadline)
+		}
+	}
+
+	if err := s.upsertDeploymentImpl(index, copy, txn); err != nil {
+		return err
+	}
+
+	// Update the deployment status as needed.
+	if req.DeploymentUpdate != nil {
+		if err := s.updateDeploymentStatusImpl(index, req.DeploymentUpdate, txn); err != nil {
+			return err
+		}
+	}
+
+	// Upsert the job if necessary
+	if req.Job != nil {
+		if err := s.upsertJobImpl(index, nil, req.Job, false, txn); err != nil {
+			return err
+		}
+	}
+
+	// Upsert the optional eval
+	if req.Eval != nil {
+		if err := s.nestedUpsertEval(txn, index, req.Eval); err != nil {
+			return err
+		}
+	}
+
+	return txn.Commit()
+}
+
 // LatestIndex returns the greatest index value for all indexes.
 func (s *StateStore) LatestIndex() (uint64, error) {
 	indexes, err := s.Indexes()
@@ -5318,6 +5572,9 @@ func (s *StateStore) ReconcileJobSummaries(index uint64) error {
 			default:
 				s.logger.Error("invalid client status set on allocation", "client_status", alloc.ClientStatus, "alloc_id", alloc.ID)
 			}
+			if idx, ok := alloc.ArrayIndex(); ok {
+				tg.UpdateArrayIndex(idx, alloc.ClientStatus)
+			}
 			summary.Summary[alloc.TaskGroup] = tg
 		}
 
@@ -5394,6 +5651,9 @@ func (s *StateStore) setJobStatus(index uint64, txn *txn,
 	updated := job.Copy()
 	updated.Status = newStatus
 	updated.ModifyIndex = index
+	if err := s.setJobCompletionFailure(txn, updated); err != nil {
+		return err
+	}
 
 	// Insert the job
 	if err := txn.Insert("jobs", updated); err != nil {
@@ -5528,6 +5788,36 @@ func (s *StateStore) getJobStatus(txn *txn, job *structs.Job, evalDelete bool) (
 	return structs.JobStatusPending, nil
 }
 
+// setJobCompletionFailure sets whether the job failed because a completion
+// task group exhausted its backoff limit. It reads all the allocations of the
+// job, so it must only be called when the job status or version changes. The
+// status description is only cleared if it was set by a completion failure.
+func (s *StateStore) setJobCompletionFailure(txn *txn, job *structs.Job) error {
+	if job.CompletionFailed {
+		job.CompletionFailed = false
+		job.StatusDescription = ""
+	}
+	if job.Status != structs.JobStatusDead || !job.HasCompletionPolicy() {
+		return nil
+	}
+
+	iter, err := txn.Get("allocs", "job", job.Namespace, job.ID)
+	if err != nil {
+		return err
+	}
+
+	var allocs []*structs.Allocation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		allocs = append(allocs, raw.(*structs.Allocation))
+	}
+
+	if tg := job.FailedCompletionGroup(allocs); tg != nil {
+		job.CompletionFailed = true
+		job.StatusDescription = fmt.Sprintf("Task group %q failed after exceeding its backoff limit of %d", tg.Name, tg.Completion.BackoffLimit)
+	}
+	return nil
+}
+
 // updateSummaryWithJob creates or updates job summaries when new jobs are
 // upserted or existing ones are updated
 func (s *StateStore) updateSummaryWithJob(index uint64, job *structs.Job,
@@ -5990,6 +6280,11 @@ func (s *StateStore) updateSummaryWithAlloc(index uint64, alloc *structs.Allocat
 				"alloc_id", existingAlloc.ID, "client_status", existingAlloc.ClientStatus)
 		}
 		summaryChanged = true
+
+		// Track the indices of array task groups that have finished
+		if idx, ok := alloc.ArrayIndex(); ok {
+			tgSummary.UpdateArrayIndex(idx, alloc.ClientStatus)
+		}
 	}
 	jobSummary.Summary[alloc.TaskGroup] = tgSummary
 
@@ -5,6 +5,7 @@ package state
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"sort"
@@ -452,6 +453,69 @@ func TestStateStore_UpsertPlanResults_PreemptedAllocs(t *testing.T) {
 }
 
 // This test checks that deployment updates are applied correctly
+// TestStateStore_UpsertPlanResults_HoldEvalIDs asserts recording the hold
+// follow up evaluations of a deployment doesn't overwrite the updates made to
+// it since the plan was computed.
+func TestStateStore_UpsertPlanResults_HoldEvalIDs(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 998, nil, job))
+
+	d := mock.Deployment()
+	d.JobID = job.ID
+	must.NoError(t, state.UpsertDeployment(1000, d))
+
+	// The deployment watcher updates the health of the deployment after the
+	// scheduler took its snapshot
+	watched := d.Copy()
+	watched.TaskGroups["web"].HealthyAllocs = 2
+	watched.TaskGroups["web"].UnhealthyAllocs = 1
+	must.NoError(t, state.UpsertDeployment(1001, watched))
+
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1002, []*structs.Evaluation{eval}))
+
+	res := structs.ApplyPlanResultsRequest{
+		AllocUpdateRequest: structs.AllocUpdateRequest{
+			Job: job,
+		},
+		DeploymentUpdates: []*structs.DeploymentStatusUpdate{{
+			DeploymentID: d.ID,
+			HoldEvalIDs:  map[string]string{"web": eval.ID},
+		}},
+		EvalID: eval.ID,
+	}
+	must.NoError(t, state.UpsertPlanResults(structs.MsgTypeTestSetup, 1003, &res))
+
+	out, err := state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, d.Status, out.Status)
+	must.Eq(t, d.StatusDescription, out.StatusDescription)
+	must.Eq(t, eval.ID, out.TaskGroups["web"].HoldEvalID)
+	must.Eq(t, 2, out.TaskGroups["web"].HealthyAllocs)
+	must.Eq(t, 1, out.TaskGroups["web"].UnhealthyAllocs)
+	must.Eq(t, 1003, out.ModifyIndex)
+
+	// Recording them for a deployment that terminated since is a no-op
+	must.NoError(t, state.UpdateDeploymentStatus(structs.MsgTypeTestSetup, 1004,
+		&structs.DeploymentStatusUpdateRequest{
+			DeploymentUpdate: &structs.DeploymentStatusUpdate{
+				DeploymentID: d.ID,
+				Status:       structs.DeploymentStatusFailed,
+			},
+		}))
+	res.DeploymentUpdates[0].HoldEvalIDs = map[string]string{"web": uuid.Generate()}
+	must.NoError(t, state.UpsertPlanResults(structs.MsgTypeTestSetup, 1005, &res))
+
+	out, err = state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.DeploymentStatusFailed, out.Status)
+	must.Eq(t, eval.ID, out.TaskGroups["web"].HoldEvalID)
+}
+
 func TestStateStore_UpsertPlanResults_DeploymentUpdates(t *testing.T) {
 	ci.Parallel(t)
 	state := testStateStore(t)
@@ -1954,6 +2018,100 @@ func TestStateStore_UpdateNodeEligibility(t *testing.T) {
 	require.Contains(err.Error(), "while it is draining")
 }
 
+func TestStateStore_UpdateNodeUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	utilization := &structs.NodeUtilization{CPUPercent: 42, MemoryPercent: 21, UpdatedAt: 7}
+	must.NoError(t, state.UpdateNodeUtilization(structs.MsgTypeTestSetup, 1001, node.ID, utilization))
+
+	// The utilization is updated without modifying the node
+	out, err := state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, utilization, out.Utilization)
+	must.Eq(t, 1000, out.ModifyIndex)
+
+	index, err := state.Index("nodes")
+	must.NoError(t, err)
+	must.Eq(t, 1001, index)
+
+	// A utilization that didn't change significantly is dropped and doesn't
+	// bump the index of the nodes table
+	minor := &structs.NodeUtilization{CPUPercent: 45, MemoryPercent: 19, UpdatedAt: 8}
+	must.NoError(t, state.UpdateNodeUtilization(structs.MsgTypeTestSetup, 1002, node.ID, minor))
+
+	out, err = state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, utilization, out.Utilization)
+
+	index, err = state.Index("nodes")
+	must.NoError(t, err)
+	must.Eq(t, 1001, index)
+
+	// Updating the utilization of an unknown node fails
+	must.ErrorContains(t, state.UpdateNodeUtilization(structs.MsgTypeTestSetup, 1003,
+		uuid.Generate(), utilization), "node not found")
+}
+
+func TestStateStore_UpdateNodeTaints(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// Create a watchset so we can test that updating the taints fires the watch
+	ws := memdb.NewWatchSet()
+	_, err := state.NodeByID(ws, node.ID)
+	must.NoError(t, err)
+
+	taints := []*structs.NodeTaint{{Key: "gpu", Effect: structs.NodeTaintEffectNoSchedule}}
+	event := &structs.NodeEvent{
+		Message:   "Node taints updated",
+		Subsystem: structs.NodeEventSubsystemCluster,
+		Timestamp: time.Now(),
+	}
+	must.NoError(t, state.UpdateNodeTaints(structs.MsgTypeTestSetup, 1001, node.ID, 0, taints, 7, event))
+	must.True(t, watchFired(ws))
+
+	out, err := state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, taints, out.Taints)
+	must.Len(t, 2, out.Events)
+	must.Eq(t, event, out.Events[1])
+	must.Eq(t, 1001, out.ModifyIndex)
+
+	index, err := state.Index("nodes")
+	must.NoError(t, err)
+	must.Eq(t, 1001, index)
+
+	// Taints are retained when the client re-registers the node
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1002, node.Copy()))
+	out, err = state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, taints, out.Taints)
+
+	// Updating the taints with a stale modify index fails
+	err = state.UpdateNodeTaints(structs.MsgTypeTestSetup, 1003, node.ID, 1001, nil, 7, nil)
+	must.ErrorContains(t, err, structs.NodeTaintsEnforceIndexErrPrefix)
+	out, err = state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, taints, out.Taints)
+
+	// Updating the taints with the current modify index succeeds
+	must.NoError(t, state.UpdateNodeTaints(structs.MsgTypeTestSetup, 1004, node.ID, 1002, nil, 7, nil))
+	out, err = state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.SliceEmpty(t, out.Taints)
+
+	// Updating the taints of an unknown node fails
+	must.ErrorContains(t, state.UpdateNodeTaints(structs.MsgTypeTestSetup, 1005,
+		uuid.Generate(), 0, nil, 7, nil), "node not found")
+}
+
 func TestStateStore_Nodes(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5650,6 +5808,33 @@ func TestStateStore_UpdateAllocsFromClient(t *testing.T) {
 	must.False(t, watchFired(ws))
 }
 
+func TestStateStore_UpdateAllocsFromClient_Evicted(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	alloc := mock.Alloc()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, alloc.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+	update := &structs.Allocation{
+		ID:                alloc.ID,
+		NodeID:            alloc.NodeID,
+		ClientStatus:      structs.AllocClientStatusFailed,
+		ClientDescription: "Evicted due to node memory pressure",
+		Evicted:           true,
+		JobID:             alloc.JobID,
+		TaskGroup:         alloc.TaskGroup,
+	}
+	must.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{update}))
+
+	out, err := state.AllocByID(nil, alloc.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.AllocClientStatusFailed, out.ClientStatus)
+	must.True(t, out.Evicted)
+	must.True(t, out.Stub(nil).Evicted)
+}
+
 func TestStateStore_UpdateAllocsFromClient_ChildJob(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7482,6 +7667,95 @@ func TestStateStore_SetJobStatus(t *testing.T) {
 	}
 }
 
+func TestStateStore_SetJobStatus_CompletionFailed(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	job := mock.BatchJob()
+	job.TaskGroups[0].Completion = &structs.CompletionPolicy{Completions: 10, BackoffLimit: 1}
+	job.TaskGroups[0].Count = 10
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	newAlloc := func(status string) *structs.Allocation {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.DesiredStatus = structs.AllocDesiredStatusStop
+		alloc.ClientStatus = status
+		return alloc
+	}
+
+	// Failures within the backoff limit don't fail the job
+	running := newAlloc(structs.AllocClientStatusRunning)
+	running.DesiredStatus = structs.AllocDesiredStatusRun
+	allocs := []*structs.Allocation{
+		newAlloc(structs.AllocClientStatusFailed),
+		running,
+	}
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.JobStatusRunning, out.Status)
+	must.False(t, out.CompletionFailed)
+	must.Eq(t, "", out.StatusDescription)
+
+	// The failure is recorded when the job becomes dead
+	failed := running.Copy()
+	failed.ClientStatus = structs.AllocClientStatusFailed
+	must.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 1002,
+		[]*structs.Allocation{failed}))
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.JobStatusDead, out.Status)
+	must.True(t, out.CompletionFailed)
+	must.Eq(t, `Task group "web" failed after exceeding its backoff limit of 1`, out.StatusDescription)
+	must.Eq(t, 1002, out.ModifyIndex)
+
+	// Updates that keep the job dead don't modify it
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1003,
+		[]*structs.Allocation{newAlloc(structs.AllocClientStatusComplete)}))
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.True(t, out.CompletionFailed)
+	must.Eq(t, 1002, out.ModifyIndex)
+
+	// The failure is cleared when the job is no longer dead
+	running = newAlloc(structs.AllocClientStatusRunning)
+	running.DesiredStatus = structs.AllocDesiredStatusRun
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1004,
+		[]*structs.Allocation{running}))
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.JobStatusRunning, out.Status)
+	must.False(t, out.CompletionFailed)
+	must.Eq(t, "", out.StatusDescription)
+}
+
+func TestStateStore_SetJobStatus_KeepsStatusDescription(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	job := mock.BatchJob()
+	job.StatusDescription = "description"
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	// Ensure the status description of a job without a completion policy is
+	// not cleared when its status changes
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.TaskGroup = job.TaskGroups[0].Name
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.JobStatusRunning, out.Status)
+	must.False(t, out.CompletionFailed)
+	must.Eq(t, "description", out.StatusDescription)
+}
+
 func TestStateStore_GetJobStatus_NoEvalsOrAllocs(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7917,6 +8191,60 @@ func TestJobSummary_UpdateClientStatus(t *testing.T) {
 	}
 }
 
+func TestJobSummary_UpdateClientStatus_Array(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	job := mock.BatchJob()
+	job.TaskGroups[0].Array = &structs.ArrayConfig{Start: 10, End: 13}
+	job.TaskGroups[0].Count = 4
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	newAlloc := func(index uint) *structs.Allocation {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.Name = structs.AllocName(job.ID, alloc.TaskGroup, index)
+		return alloc
+	}
+	allocs := []*structs.Allocation{newAlloc(0), newAlloc(1), newAlloc(2)}
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+
+	update := func(index uint64, alloc *structs.Allocation, status string) {
+		alloc = alloc.Copy()
+		alloc.ClientStatus = status
+		must.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, index, []*structs.Allocation{alloc}))
+	}
+	update(1002, allocs[0], structs.AllocClientStatusComplete)
+	update(1003, allocs[1], structs.AllocClientStatusFailed)
+	update(1004, allocs[2], structs.AllocClientStatusRunning)
+
+	summary, err := state.JobSummaryByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	tgSummary := summary.Summary[job.TaskGroups[0].Name]
+	must.Eq(t, "10", tgSummary.ArraySucceeded.String())
+	must.Eq(t, "11", tgSummary.ArrayFailed.String())
+
+	// Retrying the failed index successfully
+	retry := newAlloc(1)
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1005, []*structs.Allocation{retry}))
+	update(1006, retry, structs.AllocClientStatusComplete)
+
+	summary, err = state.JobSummaryByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	tgSummary = summary.Summary[job.TaskGroups[0].Name]
+	must.Eq(t, "10-11", tgSummary.ArraySucceeded.String())
+	must.Len(t, 0, tgSummary.ArrayFailed)
+
+	// Reconciling the summary computes the same indices
+	must.NoError(t, state.ReconcileJobSummaries(1007))
+	summary, err = state.JobSummaryByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, tgSummary.ArraySucceeded, summary.Summary[job.TaskGroups[0].Name].ArraySucceeded)
+	must.Len(t, 0, summary.Summary[job.TaskGroups[0].Name].ArrayFailed)
+}
+
 // Test that nonexistent deployment can't be updated
 func TestStateStore_UpsertDeploymentStatusUpdate_Nonexistent(t *testing.T) {
 	ci.Parallel(t)
@@ -8445,6 +8773,142 @@ func TestStateStore_UpsertDeploymentPromotion_Subset(t *testing.T) {
 	require.True(aout3.DeploymentStatus.Canary)
 }
 
+// Test that promoting a blue/green deployment holds the previous allocations
+// and failing it puts them back in service
+func TestStateStore_UpsertDeploymentPromotion_Hold(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	j := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1, nil, j))
+	j, _ = state.JobByID(nil, j.Namespace, j.ID)
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.JobCreateIndex = j.CreateIndex
+	d.TaskGroups = map[string]*structs.DeploymentState{
+		"web": {
+			DesiredTotal:    1,
+			DesiredCanaries: 1,
+			HoldPeriod:      time.Hour,
+		},
+	}
+
+	// The previous allocation and its blue/green canary
+	prev := mock.Alloc()
+	prev.Job = j
+	prev.JobID = j.ID
+	prev.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(true)}
+
+	canary := mock.Alloc()
+	canary.Job = j
+	canary.JobID = j.ID
+	canary.DeploymentID = d.ID
+	canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: pointer.Of(true),
+		Canary:  true,
+	}
+	d.TaskGroups["web"].PlacedCanaries = []string{canary.ID}
+
+	must.NoError(t, state.UpsertDeployment(2, d))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 3, []*structs.Allocation{prev, canary}))
+
+	req := &structs.ApplyDeploymentPromoteRequest{
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+			DeploymentID: d.ID,
+			All:          true,
+		},
+	}
+	must.NoError(t, state.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 4, req))
+
+	dout, err := state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.True(t, dout.TaskGroups["web"].Holding(time.Now()))
+
+	prevOut, err := state.AllocByID(nil, prev.ID)
+	must.NoError(t, err)
+	must.True(t, prevOut.DeploymentStatus.IsStandby())
+	must.Eq(t, 4, prevOut.AllocModifyIndex)
+
+	canaryOut, err := state.AllocByID(nil, canary.ID)
+	must.NoError(t, err)
+	must.False(t, canaryOut.DeploymentStatus.IsCanary())
+	must.False(t, canaryOut.DeploymentStatus.IsStandby())
+
+	// Failing the deployment puts the previous allocation back in service
+	must.NoError(t, state.UpdateDeploymentStatus(structs.MsgTypeTestSetup, 5, &structs.DeploymentStatusUpdateRequest{
+		DeploymentUpdate: &structs.DeploymentStatusUpdate{
+			DeploymentID: d.ID,
+			Status:       structs.DeploymentStatusFailed,
+		},
+	}))
+
+	prevOut, err = state.AllocByID(nil, prev.ID)
+	must.NoError(t, err)
+	must.False(t, prevOut.DeploymentStatus.IsStandby())
+	must.Eq(t, 5, prevOut.AllocModifyIndex)
+}
+
+// Test promoting all the groups of a deployment skips the groups waiting for
+// the groups they depend on
+func TestStateStore_UpsertDeploymentPromotion_Waiting(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	j := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1, nil, j))
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups = map[string]*structs.DeploymentState{
+		"web": {
+			DesiredTotal:    1,
+			DesiredCanaries: 1,
+		},
+		"api": {
+			DesiredTotal:    1,
+			DesiredCanaries: 1,
+			DependsOn:       []string{"web"},
+		},
+	}
+
+	canary := mock.Alloc()
+	canary.Job = j
+	canary.JobID = j.ID
+	canary.TaskGroup = "web"
+	canary.DeploymentID = d.ID
+	canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: pointer.Of(true),
+		Canary:  true,
+	}
+	d.TaskGroups["web"].PlacedCanaries = []string{canary.ID}
+
+	must.NoError(t, state.UpsertDeployment(2, d))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 3, []*structs.Allocation{canary}))
+
+	req := &structs.ApplyDeploymentPromoteRequest{
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+			DeploymentID: d.ID,
+			All:          true,
+		},
+	}
+	must.NoError(t, state.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 4, req))
+
+	dout, err := state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.True(t, dout.TaskGroups["web"].Promoted)
+	must.False(t, dout.TaskGroups["api"].Promoted)
+	must.True(t, dout.RequiresPromotion())
+
+	// Promoting the waiting group explicitly requires its canaries
+	req.All = false
+	req.Groups = []string{"api"}
+	err = state.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 5, req)
+	must.ErrorContains(t, err, `Task group "api" has 0/1 healthy allocations`)
+}
+
 // Test that allocation health can't be set against a nonexistent deployment
 func TestStateStore_UpsertDeploymentAllocHealth_Nonexistent(t *testing.T) {
 	ci.Parallel(t)
@@ -8765,6 +9229,74 @@ func TestStateStore_UpsertDeploymentAllocHealth(t *testing.T) {
 	}
 }
 
+func TestStateStore_UpdateDeploymentAnalysis(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	d := mock.Deployment()
+	d.TaskGroups["web"].ProgressDeadline = 5 * time.Minute
+	d.TaskGroups["web"].RequireProgressBy = time.Now().Add(-time.Minute)
+	must.NoError(t, state.UpsertDeployment(1, d))
+
+	// Unknown deployments and task groups are rejected
+	err := state.UpdateDeploymentAnalysis(structs.MsgTypeTestSetup, 2,
+		&structs.ApplyDeploymentAnalysisRequest{DeploymentID: uuid.Generate(), TaskGroup: "web"})
+	must.ErrorContains(t, err, "does not exist")
+	err = state.UpdateDeploymentAnalysis(structs.MsgTypeTestSetup, 2,
+		&structs.ApplyDeploymentAnalysisRequest{DeploymentID: d.ID, TaskGroup: "api"})
+	must.ErrorContains(t, err, "does not have task group")
+
+	// A passed step advances the task group and resets its progress deadline
+	e := mock.Eval()
+	err = state.UpdateDeploymentAnalysis(structs.MsgTypeTestSetup, 3, &structs.ApplyDeploymentAnalysisRequest{
+		DeploymentID: d.ID,
+		TaskGroup:    "web",
+		Results:      []*structs.AnalysisResult{structs.NewAnalysisResult(10, "webhook", nil)},
+		Passed:       true,
+		Eval:         e,
+	})
+	must.NoError(t, err)
+
+	out, err := state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	dstate := out.TaskGroups["web"]
+	must.Eq(t, 1, dstate.AnalysisStep)
+	must.Len(t, 1, dstate.AnalysisResults)
+	must.True(t, dstate.RequireProgressBy.After(time.Now()))
+	must.Eq(t, 3, out.ModifyIndex)
+
+	eval, err := state.EvalByID(nil, e.ID)
+	must.NoError(t, err)
+	must.NotNil(t, eval)
+
+	// A failed step records its results and fails the deployment
+	err = state.UpdateDeploymentAnalysis(structs.MsgTypeTestSetup, 4, &structs.ApplyDeploymentAnalysisRequest{
+		DeploymentID: d.ID,
+		TaskGroup:    "web",
+		Results:      []*structs.AnalysisResult{structs.NewAnalysisResult(50, "webhook", errors.New("bad"))},
+		DeploymentUpdate: &structs.DeploymentStatusUpdate{
+			DeploymentID:      d.ID,
+			Status:            structs.DeploymentStatusFailed,
+			StatusDescription: structs.DeploymentStatusDescriptionFailedAnalysis,
+		},
+	})
+	must.NoError(t, err)
+
+	out, err = state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	dstate = out.TaskGroups["web"]
+	must.Eq(t, 1, dstate.AnalysisStep)
+	must.Len(t, 2, dstate.AnalysisResults)
+	must.False(t, dstate.AnalysisResults[1].Passed())
+	must.Eq(t, structs.DeploymentStatusFailed, out.Status)
+
+	// Terminal deployments can't be updated
+	err = state.UpdateDeploymentAnalysis(structs.MsgTypeTestSetup, 5,
+		&structs.ApplyDeploymentAnalysisRequest{DeploymentID: d.ID, TaskGroup: "web", Passed: true})
+	must.ErrorContains(t, err, "has terminal status")
+}
+
 func TestStateStore_UpsertVaultAccessors(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import "github.com/hashicorp/nomad/helper/pointer"
+
+// EvictionConfig describes how a client evicts allocations when its host runs
+// low on memory or allocation directory disk space.
+type EvictionConfig struct {
+	// Enabled turns on the eviction of allocations under node pressure.
+	Enabled *bool `hcl:"enabled"`
+
+	// MemoryThreshold is the percentage of host memory in use above which
+	// the node is under memory pressure.
+	MemoryThreshold *int `hcl:"memory_threshold"`
+
+	// DiskThreshold is the percentage of the disk of the allocation
+	// directory in use above which the node is under disk pressure.
+	DiskThreshold *int `hcl:"disk_threshold"`
+
+	// MemoryRecoveryThreshold is the percentage of host memory in use below
+	// which a node under memory pressure recovers from it.
+	MemoryRecoveryThreshold *int `hcl:"memory_recovery_threshold"`
+
+	// DiskRecoveryThreshold is the percentage of the disk of the allocation
+	// directory in use below which a node under disk pressure recovers from
+	// it.
+	DiskRecoveryThreshold *int `hcl:"disk_recovery_threshold"`
+}
+
+func (e *EvictionConfig) Copy() *EvictionConfig {
+	if e == nil {
+		return nil
+	}
+
+	ne := new(EvictionConfig)
+	*ne = *e
+	return ne
+}
+
+func (e *EvictionConfig) Merge(o *EvictionConfig) *EvictionConfig {
+	switch {
+	case e == nil:
+		return o.Copy()
+	case o == nil:
+		return e.Copy()
+	default:
+		ne := e.Copy()
+		if o.Enabled != nil {
+			ne.Enabled = pointer.Copy(o.Enabled)
+		}
+		if o.MemoryThreshold != nil {
+			ne.MemoryThreshold = pointer.Copy(o.MemoryThreshold)
+		}
+		if o.DiskThreshold != nil {
+			ne.DiskThreshold = pointer.Copy(o.DiskThreshold)
+		}
+		if o.MemoryRecoveryThreshold != nil {
+			ne.MemoryRecoveryThreshold = pointer.Copy(o.MemoryRecoveryThreshold)
+		}
+		if o.DiskRecoveryThreshold != nil {
+			ne.DiskRecoveryThreshold = pointer.Copy(o.DiskRecoveryThreshold)
+		}
+		return ne
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestEvictionConfig_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *EvictionConfig
+	must.Nil(t, nilConfig.Copy())
+
+	e := &EvictionConfig{
+		Enabled:         pointer.Of(true),
+		MemoryThreshold: pointer.Of(90),
+	}
+	c := e.Copy()
+	must.Eq(t, e, c)
+
+	c.DiskThreshold = pointer.Of(80)
+	must.Nil(t, e.DiskThreshold)
+}
+
+func TestEvictionConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		input    *EvictionConfig
+		merge    *EvictionConfig
+		expected *EvictionConfig
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			merge:    nil,
+			expected: nil,
+		},
+		{
+			name:     "nil input",
+			input:    nil,
+			merge:    &EvictionConfig{Enabled: pointer.Of(true)},
+			expected: &EvictionConfig{Enabled: pointer.Of(true)},
+		},
+		{
+			name:     "nil merge",
+			input:    &EvictionConfig{MemoryThreshold: pointer.Of(90)},
+			merge:    nil,
+			expected: &EvictionConfig{MemoryThreshold: pointer.Of(90)},
+		},
+		{
+			name: "partial merge",
+			input: &EvictionConfig{
+				Enabled:                 pointer.Of(true),
+				MemoryThreshold:         pointer.Of(90),
+				DiskThreshold:           pointer.Of(85),
+				MemoryRecoveryThreshold: pointer.Of(80),
+			},
+			merge: &EvictionConfig{
+				Enabled:               pointer.Of(false),
+				DiskThreshold:         pointer.Of(95),
+				DiskRecoveryThreshold: pointer.Of(90),
+			},
+			expected: &EvictionConfig{
+				Enabled:                 pointer.Of(false),
+				MemoryThreshold:         pointer.Of(90),
+				DiskThreshold:           pointer.Of(95),
+				MemoryRecoveryThreshold: pointer.Of(80),
+				DiskRecoveryThreshold:   pointer.Of(90),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, tc.input.Merge(tc.merge))
+		})
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Deployment analysis pauses a deployment at configured steps and runs gates
+// that decide whether the deployment continues, is promoted or fails.
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// AnalysisGateTypeHTTP gates send a request to the HTTP endpoint of their
+	// provider and pass when it responds with a 2xx status code.
+	AnalysisGateTypeHTTP = "http"
+
+	// AnalysisGateTypeQuery gates run a PromQL query against the Prometheus
+	// compatible HTTP API of their provider and pass when the query returns a
+	// result.
+	AnalysisGateTypeQuery = "query"
+
+	// AnalysisGateTypeAction gates run a job action in a healthy allocation
+	// of the deployment and pass when it exits successfully.
+	AnalysisGateTypeAction = "action"
+
+	// AnalysisStatusPassed and AnalysisStatusFailed are the statuses of the
+	// result of an analysis gate.
+	AnalysisStatusPassed = "passed"
+	AnalysisStatusFailed = "failed"
+
+	// DefaultAnalysisGateTimeout is the time an analysis gate can take before
+	// it fails.
+	DefaultAnalysisGateTimeout = 30 * time.Second
+
+	// maxAnalysisResultMessage limits the length of the message of an
+	// analysis result.
+	maxAnalysisResultMessage = 512
+)
+
+// AnalysisStrategy configures the steps at which a deployment pauses to run
+// analysis gates before updating more allocations of the task group.
+type AnalysisStrategy struct {
+	// Steps are the percentages of the allocations of the task group that
+	// are updated before the deployment pauses to run the gates, in
+	// increasing order.
+	Steps []int
+
+	// Delay is how long to wait once the allocations of a step are healthy
+	// before running the gates.
+	Delay time.Duration
+
+	// Gates are run at every step and must all pass for the deployment to
+	// continue.
+	Gates []*AnalysisGate
+}
+
+func (a *AnalysisStrategy) Copy() *AnalysisStrategy {
+	if a == nil {
+		return nil
+	}
+
+	c := new(AnalysisStrategy)
+	*c = *a
+	c.Steps = slices.Clone(a.Steps)
+	c.Gates = helper.CopySlice(a.Gates)
+	return c
+}
+
+func (a *AnalysisStrategy) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+	if len(a.Steps) == 0 {
+		mErr = multierror.Append(mErr, errors.New("Analysis must have at least one step"))
+	}
+	for i, step := range a.Steps {
+		if step <= 0 || step > 100 {
+			mErr = multierror.Append(mErr, fmt.Errorf("Analysis step must be between 1 and 100: %d", step))
+		} else if i > 0 && step <= a.Steps[i-1] {
+			mErr = multierror.Append(mErr, fmt.Errorf("Analysis steps must be in increasing order: %d <= %d", step, a.Steps[i-1]))
+		}
+	}
+	if a.Delay < 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("Analysis delay must be zero or greater: %v", a.Delay))
+	}
+
+	if len(a.Gates) == 0 {
+		mErr = multierror.Append(mErr, errors.New("Analysis must have at least one gate"))
+	}
+	names := make(map[string]struct{}, len(a.Gates))
+	for _, gate := range a.Gates {
+		if _, ok := names[gate.Name]; ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("Analysis gate %q is duplicated", gate.Name))
+		}
+		names[gate.Name] = struct{}{}
+
+		if err := gate.Validate(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Analysis gate %q validation failed: %v", gate.Name, err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// StepTarget returns the number of allocations out of total that are updated
+// once the deployment reaches the given step, rounded up. It returns false if
+// the step is past the last step of the analysis.
+func (a *AnalysisStrategy) StepTarget(step, total int) (int, bool) {
+	if a == nil || step >= len(a.Steps) {
+		return 0, false
+	}
+	return int(math.Ceil(float64(a.Steps[step]) * float64(total) / 100)), true
+}
+
+// Passed returns whether the gates of every step passed once the deployment
+// reached the given step.
+func (a *AnalysisStrategy) Passed(step int) bool {
+	return a == nil || step >= len(a.Steps)
+}
+
+// AnalysisGate is a check run at every step of a deployment analysis.
+type AnalysisGate struct {
+	// Name is the unique name of the gate.
+	Name string
+
+	// Type is the type of the gate: http, query or action.
+	Type string
+
+	// Provider is the name of the analysis provider of the server
+	// configuration that http and query gates send their request to. Jobs
+	// can't set the address or the credentials of the request themselves.
+	Provider string
+
+	// Query is the PromQL query that query gates run. The query must only
+	// return a result when the deployment is healthy, for example by
+	// comparing the error rate of the job to a threshold.
+	Query string
+
+	// Task and Action are the task and the name of the job action that action
+	// gates run.
+	Task   string
+	Action string
+
+	// Timeout is the time the gate can take before it fails.
+	Timeout time.Duration
+}
+
+func (g *AnalysisGate) Copy() *AnalysisGate {
+	if g == nil {
+		return nil
+	}
+
+	c := new(AnalysisGate)
+	*c = *g
+	return c
+}
+
+func (g *AnalysisGate) Validate() error {
+	if g == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+	if g.Name == "" {
+		mErr = multierror.Append(mErr, errors.New("missing name"))
+	}
+
+	switch g.Type {
+	case AnalysisGateTypeHTTP:
+		if g.Provider == "" {
+			mErr = multierror.Append(mErr, errors.New("http gates require a provider"))
+		}
+	case AnalysisGateTypeQuery:
+		if g.Provider == "" {
+			mErr = multierror.Append(mErr, errors.New("query gates require a provider"))
+		}
+		if g.Query == "" {
+			mErr = multierror.Append(mErr, errors.New("query gates require a query"))
+		}
+	case AnalysisGateTypeAction:
+		if g.Task == "" || g.Action == "" {
+			mErr = multierror.Append(mErr, errors.New("action gates require a task and an action"))
+		}
+		if g.Provider != "" {
+			mErr = multierror.Append(mErr, errors.New("action gates can't have a provider"))
+		}
+	default:
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid type %q, must be one of %q",
+			g.Type, []string{AnalysisGateTypeHTTP, AnalysisGateTypeQuery, AnalysisGateTypeAction}))
+	}
+
+	if g.Timeout <= 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("timeout must be greater than zero: %v", g.Timeout))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// AnalysisProvider is an endpoint of the server configuration that http and
+// query analysis gates reference by name, so that jobs can't make servers send
+// requests to arbitrary addresses.
+type AnalysisProvider struct {
+	// Name is the name gates reference the provider by.
+	Name string `hcl:",key"`
+
+	// Type is the type of the gates that can use the provider: http or query.
+	Type string `hcl:"type"`
+
+	// Address is the URL http gates send their request to, or the address of
+	// the Prometheus compatible HTTP API query gates run against.
+	Address string `hcl:"address"`
+
+	// Method is the HTTP method of the requests of http gates.
+	Method string `hcl:"method"`
+
+	// Headers are added to the requests sent to the provider, for instance to
+	// authenticate them.
+	Headers map[string]string `hcl:"headers"`
+}
+
+func (p *AnalysisProvider) Copy() *AnalysisProvider {
+	if p == nil {
+		return nil
+	}
+
+	c := new(AnalysisProvider)
+	*c = *p
+	c.Headers = maps.Clone(p.Headers)
+	return c
+}
+
+func (p *AnalysisProvider) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+	if p.Name == "" {
+		mErr = multierror.Append(mErr, errors.New("missing name"))
+	}
+
+	switch p.Type {
+	case AnalysisGateTypeHTTP:
+	case AnalysisGateTypeQuery:
+		if p.Method != "" {
+			mErr = multierror.Append(mErr, errors.New("method is only supported by http providers"))
+		}
+	default:
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid type %q, must be one of %q",
+			p.Type, []string{AnalysisGateTypeHTTP, AnalysisGateTypeQuery}))
+	}
+
+	if u, err := url.Parse(p.Address); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid address %q", p.Address))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// ValidateProviders returns an error if an http or query gate of the analysis
+// references a provider that isn't in the given providers, or that doesn't
+// support the type of the gate.
+func (a *AnalysisStrategy) ValidateProviders(providers map[string]*AnalysisProvider) error {
+	if a == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+	for _, gate := range a.Gates {
+		if gate.Provider == "" {
+			continue
+		}
+		provider, ok := providers[gate.Provider]
+		if !ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("Analysis gate %q references unknown provider %q", gate.Name, gate.Provider))
+		} else if provider.Type != gate.Type {
+			mErr = multierror.Append(mErr, fmt.Errorf("Analysis gate %q of type %q can't use provider %q of type %q",
+				gate.Name, gate.Type, gate.Provider, provider.Type))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// AnalysisResult is the result of an analysis gate at a step of a deployment.
+type AnalysisResult struct {
+	// Step is the percentage of the step the gate ran at.
+	Step int
+
+	// Gate is the name of the gate.
+	Gate string
+
+	// Status is whether the gate passed or failed.
+	Status string
+
+	// Message describes the outcome of the gate.
+	Message string
+
+	// Time is when the gate finished.
+	Time time.Time
+}
+
+// NewAnalysisResult returns the result of the gate at the given step, the gate
+// passed if err is nil.
+func NewAnalysisResult(step int, gate string, err error) *AnalysisResult {
+	r := &AnalysisResult{
+		Step:   step,
+		Gate:   gate,
+		Status: AnalysisStatusPassed,
+		Time:   time.Now(),
+	}
+	if err != nil {
+		r.Status = AnalysisStatusFailed
+		r.Message = err.Error()
+		if len(r.Message) > maxAnalysisResultMessage {
+			r.Message = r.Message[:maxAnalysisResultMessage]
+		}
+	}
+	return r
+}
+
+func (r *AnalysisResult) Copy() *AnalysisResult {
+	if r == nil {
+		return nil
+	}
+
+	c := new(AnalysisResult)
+	*c = *r
+	return c
+}
+
+// Passed returns whether the gate passed.
+func (r *AnalysisResult) Passed() bool {
+	return r != nil && r.Status == AnalysisStatusPassed
+}
+
+// ApplyDeploymentAnalysisRequest is used to record the results of the analysis
+// gates of a task group in a deployment via Raft.
+type ApplyDeploymentAnalysisRequest struct {
+	// DeploymentID and TaskGroup are the deployment and task group the gates
+	// ran for.
+	DeploymentID string
+	TaskGroup    string
+
+	// Results are the results of the gates.
+	Results []*AnalysisResult
+
+	// Passed advances the task group to its next analysis step.
+	Passed bool
+
+	// An optional field to update the status of a deployment
+	DeploymentUpdate *DeploymentStatusUpdate
+
+	// Job is used to optionally upsert a job. This is used when a failed
+	// analysis fails the deployment and it auto-reverts to the latest stable
+	// job.
+	Job *Job
+
+	// An optional evaluation to create after recording the results
+	Eval *Evaluation
+
+	WriteRequest
+}
@@ -0,0 +1,297 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func testAnalysisStrategy() *AnalysisStrategy {
+	return &AnalysisStrategy{
+		Steps: []int{10, 50, 100},
+		Delay: time.Minute,
+		Gates: []*AnalysisGate{
+			{
+				Name:     "webhook",
+				Type:     AnalysisGateTypeHTTP,
+				Provider: "checks",
+				Timeout:  DefaultAnalysisGateTimeout,
+			},
+			{
+				Name:     "errors",
+				Type:     AnalysisGateTypeQuery,
+				Provider: "prometheus",
+				Query:    "sum(rate(errors[5m])) < 1",
+				Timeout:  DefaultAnalysisGateTimeout,
+			},
+			{
+				Name:    "smoke",
+				Type:    AnalysisGateTypeAction,
+				Task:    "web",
+				Action:  "smoke",
+				Timeout: DefaultAnalysisGateTimeout,
+			},
+		},
+	}
+}
+
+func TestAnalysisStrategy_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	a := testAnalysisStrategy()
+	c := a.Copy()
+	must.Eq(t, a, c)
+
+	c.Steps[0] = 20
+	c.Gates[0].Provider = "other"
+	must.Eq(t, 10, a.Steps[0])
+	must.Eq(t, "checks", a.Gates[0].Provider)
+}
+
+func TestAnalysisStrategy_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		modify func(*AnalysisStrategy)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(*AnalysisStrategy) {},
+		},
+		{
+			name:   "no steps",
+			modify: func(a *AnalysisStrategy) { a.Steps = nil },
+			err:    "at least one step",
+		},
+		{
+			name:   "step out of range",
+			modify: func(a *AnalysisStrategy) { a.Steps = []int{0, 101} },
+			err:    "between 1 and 100",
+		},
+		{
+			name:   "unordered steps",
+			modify: func(a *AnalysisStrategy) { a.Steps = []int{50, 50} },
+			err:    "increasing order",
+		},
+		{
+			name:   "negative delay",
+			modify: func(a *AnalysisStrategy) { a.Delay = -time.Second },
+			err:    "delay must be zero or greater",
+		},
+		{
+			name:   "no gates",
+			modify: func(a *AnalysisStrategy) { a.Gates = nil },
+			err:    "at least one gate",
+		},
+		{
+			name:   "duplicate gate",
+			modify: func(a *AnalysisStrategy) { a.Gates[1].Name = a.Gates[0].Name },
+			err:    "is duplicated",
+		},
+		{
+			name:   "missing provider",
+			modify: func(a *AnalysisStrategy) { a.Gates[1].Provider = "" },
+			err:    "query gates require a provider",
+		},
+		{
+			name:   "action provider",
+			modify: func(a *AnalysisStrategy) { a.Gates[2].Provider = "checks" },
+			err:    "action gates can't have a provider",
+		},
+		{
+			name:   "missing query",
+			modify: func(a *AnalysisStrategy) { a.Gates[1].Query = "" },
+			err:    "require a query",
+		},
+		{
+			name:   "missing action",
+			modify: func(a *AnalysisStrategy) { a.Gates[2].Action = "" },
+			err:    "require a task and an action",
+		},
+		{
+			name:   "invalid type",
+			modify: func(a *AnalysisStrategy) { a.Gates[0].Type = "grpc" },
+			err:    "invalid type",
+		},
+		{
+			name:   "missing timeout",
+			modify: func(a *AnalysisStrategy) { a.Gates[0].Timeout = 0 },
+			err:    "timeout must be greater than zero",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := testAnalysisStrategy()
+			tc.modify(a)
+			err := a.Validate()
+			if tc.err == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestAnalysisStrategy_ValidateProviders(t *testing.T) {
+	ci.Parallel(t)
+
+	providers := map[string]*AnalysisProvider{
+		"checks":     {Name: "checks", Type: AnalysisGateTypeHTTP, Address: "http://localhost:8080/check"},
+		"prometheus": {Name: "prometheus", Type: AnalysisGateTypeQuery, Address: "http://localhost:9090"},
+	}
+
+	a := testAnalysisStrategy()
+	must.NoError(t, a.ValidateProviders(providers))
+
+	a.Gates[0].Provider = "prometheus"
+	must.ErrorContains(t, a.ValidateProviders(providers), `can't use provider "prometheus" of type "query"`)
+
+	a.Gates[0].Provider = "unknown"
+	must.ErrorContains(t, a.ValidateProviders(providers), `references unknown provider "unknown"`)
+
+	must.ErrorContains(t, testAnalysisStrategy().ValidateProviders(nil), `unknown provider "checks"`)
+}
+
+func TestAnalysisProvider_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		provider *AnalysisProvider
+		err      string
+	}{
+		{
+			name: "valid http",
+			provider: &AnalysisProvider{
+				Name:    "checks",
+				Type:    AnalysisGateTypeHTTP,
+				Address: "https://checks.example.com/check",
+				Method:  "POST",
+				Headers: map[string]string{"Authorization": "Bearer secret"},
+			},
+		},
+		{
+			name: "valid query",
+			provider: &AnalysisProvider{
+				Name:    "prometheus",
+				Type:    AnalysisGateTypeQuery,
+				Address: "http://localhost:9090",
+			},
+		},
+		{
+			name:     "missing name",
+			provider: &AnalysisProvider{Type: AnalysisGateTypeHTTP, Address: "http://localhost:8080"},
+			err:      "missing name",
+		},
+		{
+			name:     "invalid type",
+			provider: &AnalysisProvider{Name: "checks", Type: "action", Address: "http://localhost:8080"},
+			err:      `invalid type "action"`,
+		},
+		{
+			name:     "invalid address",
+			provider: &AnalysisProvider{Name: "checks", Type: AnalysisGateTypeHTTP, Address: "localhost:8080"},
+			err:      "invalid address",
+		},
+		{
+			name: "query method",
+			provider: &AnalysisProvider{
+				Name:    "prometheus",
+				Type:    AnalysisGateTypeQuery,
+				Address: "http://localhost:9090",
+				Method:  "POST",
+			},
+			err: "only supported by http providers",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.provider.Validate()
+			if tc.err == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestAnalysisStrategy_StepTarget(t *testing.T) {
+	ci.Parallel(t)
+
+	a := &AnalysisStrategy{Steps: []int{10, 50, 100}}
+
+	target, ok := a.StepTarget(0, 5)
+	must.True(t, ok)
+	must.Eq(t, 1, target)
+
+	target, ok = a.StepTarget(1, 5)
+	must.True(t, ok)
+	must.Eq(t, 3, target)
+
+	target, ok = a.StepTarget(2, 5)
+	must.True(t, ok)
+	must.Eq(t, 5, target)
+
+	_, ok = a.StepTarget(3, 5)
+	must.False(t, ok)
+	must.True(t, a.Passed(3))
+	must.False(t, a.Passed(2))
+
+	var nilAnalysis *AnalysisStrategy
+	_, ok = nilAnalysis.StepTarget(0, 5)
+	must.False(t, ok)
+	must.True(t, nilAnalysis.Passed(0))
+}
+
+func TestNewAnalysisResult(t *testing.T) {
+	ci.Parallel(t)
+
+	r := NewAnalysisResult(10, "webhook", nil)
+	must.True(t, r.Passed())
+	must.Eq(t, "", r.Message)
+
+	r = NewAnalysisResult(10, "webhook", errors.New(strings.Repeat("a", 1000)))
+	must.False(t, r.Passed())
+	must.Eq(t, AnalysisStatusFailed, r.Status)
+	must.Eq(t, maxAnalysisResultMessage, len(r.Message))
+}
+
+func TestTaskGroup_Validate_AnalysisActionGate(t *testing.T) {
+	ci.Parallel(t)
+
+	j := testJob()
+	tg := j.TaskGroups[0]
+	tg.Update = DefaultUpdateStrategy.Copy()
+	tg.Update.Analysis = &AnalysisStrategy{
+		Steps: []int{100},
+		Gates: []*AnalysisGate{{
+			Name:    "smoke",
+			Type:    AnalysisGateTypeAction,
+			Task:    "unknown",
+			Action:  "smoke",
+			Timeout: DefaultAnalysisGateTimeout,
+		}},
+	}
+	err := tg.Validate(j)
+	must.ErrorContains(t, err, `references unknown task "unknown"`)
+
+	tg.Update.Analysis.Gates[0].Task = tg.Tasks[0].Name
+	err = tg.Validate(j)
+	must.ErrorContains(t, err, `unknown action "smoke"`)
+
+	tg.Tasks[0].Actions = []*Action{{Name: "smoke", Command: "/bin/true"}}
+	must.NoError(t, tg.Validate(j))
+}
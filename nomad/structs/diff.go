@@ -74,7 +74,7 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	// See agent.ApiJobToStructJob Update is a default for TaskGroups
 	diff := &JobDiff{Type: DiffTypeNone}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
-	filter := []string{"ID", "Status", "StatusDescription", "Version", "Stable", "CreateIndex",
+	filter := []string{"ID", "Status", "StatusDescription", "CompletionFailed", "Version", "Stable", "CreateIndex",
 		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID", "VaultToken"}
 
 	if j == nil && other == nil {
@@ -129,6 +129,17 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// Dependencies diff
+	depsDiff := primitiveObjectSetDiff(
+		interfaceSlice(j.DependsOn),
+		interfaceSlice(other.DependsOn),
+		nil,
+		"DependsOn",
+		contextual)
+	if depsDiff != nil {
+		diff.Objects = append(diff.Objects, depsDiff...)
+	}
+
 	// Task groups diff
 	tgs, err := taskGroupDiffs(j.TaskGroups, other.TaskGroups, contextual)
 	if err != nil {
@@ -315,6 +326,16 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// Topology spreads diff
+	if tsDiffs := topologySpreadDiffs(tg.TopologySpreads, other.TopologySpreads, contextual); tsDiffs != nil {
+		diff.Objects = append(diff.Objects, tsDiffs...)
+	}
+
+	// Allocation affinities diff
+	if aaDiffs := allocationAffinityDiffs(tg.AllocationAffinities, other.AllocationAffinities, contextual); aaDiffs != nil {
+		diff.Objects = append(diff.Objects, aaDiffs...)
+	}
+
 	// Restart policy diff
 	rDiff := primitiveObjectDiff(tg.RestartPolicy, other.RestartPolicy, nil, "RestartPolicy", contextual)
 	if rDiff != nil {
@@ -340,10 +361,48 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 
 	// Update diff
 	// COMPAT: Remove "Stagger" in 0.7.0.
-	if uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual); uDiff != nil {
+	uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual)
+	var oldAnalysis, newAnalysis *AnalysisStrategy
+	var oldDependsOn, newDependsOn []string
+	if tg.Update != nil {
+		oldAnalysis = tg.Update.Analysis
+		oldDependsOn = tg.Update.DependsOn
+	}
+	if other.Update != nil {
+		newAnalysis = other.Update.Analysis
+		newDependsOn = other.Update.DependsOn
+	}
+	if aDiff := analysisStrategyDiff(oldAnalysis, newAnalysis, contextual); aDiff != nil {
+		if uDiff == nil {
+			uDiff = &ObjectDiff{Type: DiffTypeEdited, Name: "Update"}
+		}
+		uDiff.Objects = append(uDiff.Objects, aDiff)
+	}
+	if setDiff := stringSetDiff(oldDependsOn, newDependsOn, "DependsOn", contextual); setDiff != nil && setDiff.Type != DiffTypeNone {
+		if uDiff == nil {
+			uDiff = &ObjectDiff{Type: DiffTypeEdited, Name: "Update"}
+		}
+		uDiff.Objects = append(uDiff.Objects, setDiff)
+	}
+	if uDiff != nil {
 		diff.Objects = append(diff.Objects, uDiff)
 	}
 
+	// Disruption budget diff
+	if dbDiff := primitiveObjectDiff(tg.DisruptionBudget, other.DisruptionBudget, nil, "DisruptionBudget", contextual); dbDiff != nil {
+		diff.Objects = append(diff.Objects, dbDiff)
+	}
+
+	// Array diff
+	if arrayDiff := primitiveObjectDiff(tg.Array, other.Array, nil, "Array", contextual); arrayDiff != nil {
+		diff.Objects = append(diff.Objects, arrayDiff)
+	}
+
+	// Completion diff
+	if completionDiff := primitiveObjectDiff(tg.Completion, other.Completion, nil, "Completion", contextual); completionDiff != nil {
+		diff.Objects = append(diff.Objects, completionDiff)
+	}
+
 	// Disconnect diff
 	if disconnectDiff := disconectStrategyDiffs(tg.Disconnect, other.Disconnect, contextual); disconnectDiff != nil {
 		diff.Objects = append(diff.Objects, disconnectDiff)
@@ -1962,6 +2021,263 @@ func serviceCheckDiffs(old, new []*ServiceCheck, contextual bool) []*ObjectDiff
 	return diffs
 }
 
+// analysisStrategyDiff returns the diff of two deployment analysis objects. If
+// contextual diff is enabled, all fields will be returned, even if no diff
+// occurred.
+func analysisStrategyDiff(old, new *AnalysisStrategy, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Analysis"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &AnalysisStrategy{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &AnalysisStrategy{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// The steps are diffed as a single field
+	stepsString := func(steps []int) string {
+		s := make([]string, 0, len(steps))
+		for _, step := range steps {
+			s = append(s, strconv.Itoa(step))
+		}
+		return strings.Join(s, ", ")
+	}
+	if len(old.Steps) != 0 {
+		oldPrimitiveFlat["Steps"] = stepsString(old.Steps)
+	}
+	if len(new.Steps) != 0 {
+		newPrimitiveFlat["Steps"] = stepsString(new.Steps)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Diff the gates
+	if gateDiffs := analysisGateDiffs(old.Gates, new.Gates, contextual); gateDiffs != nil {
+		diff.Objects = append(diff.Objects, gateDiffs...)
+	}
+
+	return diff
+}
+
+// analysisGateDiffs diffs a set of analysis gates, keyed by their name. If
+// contextual diff is enabled, unchanged fields within objects nested in the
+// gates will be returned.
+func analysisGateDiffs(old, new []*AnalysisGate, contextual bool) []*ObjectDiff {
+	oldMap := make(map[string]*AnalysisGate, len(old))
+	newMap := make(map[string]*AnalysisGate, len(new))
+	for _, o := range old {
+		oldMap[o.Name] = o
+	}
+	for _, n := range new {
+		newMap[n.Name] = n
+	}
+
+	var diffs []*ObjectDiff
+	for name, oldGate := range oldMap {
+		// Diff the same, deleted and edited
+		if diff := analysisGateDiff(oldGate, newMap[name], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	for name, newGate := range newMap {
+		// Diff the added
+		if old, ok := oldMap[name]; !ok {
+			if diff := analysisGateDiff(old, newGate, contextual); diff != nil {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
+// analysisGateDiff returns the diff of two analysis gates. If contextual diff
+// is enabled, all fields will be returned, even if no diff occurred.
+func analysisGateDiff(old, new *AnalysisGate, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Gate"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &AnalysisGate{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &AnalysisGate{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	return diff
+}
+
+// topologySpreadDiffs diffs a set of topology spreads, keyed by their
+// attributes. If contextual diff is enabled, all fields will be returned, even
+// if no diff occurred.
+func topologySpreadDiffs(old, new []*TopologySpread, contextual bool) []*ObjectDiff {
+	key := func(ts *TopologySpread) string { return strings.Join(ts.Attributes, ",") }
+
+	oldMap := make(map[string]*TopologySpread, len(old))
+	newMap := make(map[string]*TopologySpread, len(new))
+	for _, o := range old {
+		oldMap[key(o)] = o
+	}
+	for _, n := range new {
+		newMap[key(n)] = n
+	}
+
+	var diffs []*ObjectDiff
+	for k, oldTS := range oldMap {
+		// Diff the same, deleted and edited
+		if diff := topologySpreadDiff(oldTS, newMap[k], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	for k, newTS := range newMap {
+		// Diff the added
+		if old, ok := oldMap[k]; !ok {
+			if diff := topologySpreadDiff(old, newTS, contextual); diff != nil {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
+// topologySpreadDiff returns the diff of two topology spreads. If contextual
+// diff is enabled, all fields will be returned, even if no diff occurred.
+func topologySpreadDiff(old, new *TopologySpread, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "TopologySpread"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if old.Equal(new) {
+		if !contextual {
+			return nil
+		}
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if old == nil {
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// The attributes are not primitive fields
+	if old != nil {
+		oldPrimitiveFlat["Attributes"] = strings.Join(old.Attributes, ",")
+	}
+	if new != nil {
+		newPrimitiveFlat["Attributes"] = strings.Join(new.Attributes, ",")
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+	return diff
+}
+
+// allocationAffinityDiffs diffs a set of allocation affinities, keyed by the
+// allocations they match. If contextual diff is enabled, all fields will be
+// returned, even if no diff occurred.
+func allocationAffinityDiffs(old, new []*AllocationAffinity, contextual bool) []*ObjectDiff {
+	key := func(aa *AllocationAffinity) string {
+		meta := make([]string, 0, len(aa.Meta))
+		for k, v := range aa.Meta {
+			meta = append(meta, k+"="+v)
+		}
+		sort.Strings(meta)
+		return strings.Join([]string{aa.Namespace, aa.JobID, aa.TaskGroup, strings.Join(meta, ",")}, "/")
+	}
+
+	oldMap := make(map[string]*AllocationAffinity, len(old))
+	newMap := make(map[string]*AllocationAffinity, len(new))
+	for _, o := range old {
+		oldMap[key(o)] = o
+	}
+	for _, n := range new {
+		newMap[key(n)] = n
+	}
+
+	var diffs []*ObjectDiff
+	for k, oldAA := range oldMap {
+		// Diff the same, deleted and edited
+		if diff := allocationAffinityDiff(oldAA, newMap[k], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	for k, newAA := range newMap {
+		// Diff the added
+		if old, ok := oldMap[k]; !ok {
+			if diff := allocationAffinityDiff(old, newAA, contextual); diff != nil {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
+// allocationAffinityDiff returns the diff of two allocation affinities. If
+// contextual diff is enabled, all fields will be returned, even if no diff
+// occurred.
+func allocationAffinityDiff(old, new *AllocationAffinity, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "AllocationAffinity"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if old.Equal(new) {
+		if !contextual {
+			return nil
+		}
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if old == nil {
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+	return diff
+}
+
 // vaultDiff returns the diff of two vault objects. If contextual diff is
 // enabled, all fields will be returned, even if no diff occurred.
 func vaultDiff(old, new *Vault, contextual bool) *ObjectDiff {
@@ -2620,6 +2936,11 @@ func (r *Resources) Diff(other *Resources, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, nDiff)
 	}
 
+	// IO resources diff
+	if ioDiff := r.IO.Diff(other.IO, contextual); ioDiff != nil {
+		diff.Objects = append(diff.Objects, ioDiff)
+	}
+
 	return diff
 }
 
@@ -2851,6 +3172,85 @@ func (r *NUMA) Diff(other *NUMA, contextual bool) *ObjectDiff {
 	return diff
 }
 
+// Diff returns a diff of two IO resources. If contextual diff is enabled,
+// non-changed fields will still be returned.
+func (r *IOResources) Diff(other *IOResources, contextual bool) *ObjectDiff {
+	if r.Equal(other) {
+		return nil
+	}
+
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "IO"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if r == nil {
+		r = &IOResources{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(other, nil, true)
+	} else if other == nil {
+		other = &IOResources{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(r, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(r, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(other, nil, true)
+	}
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// IO device limits diff
+	oldDevices := make(map[string]*IODeviceResource, len(r.Devices))
+	for _, d := range r.Devices {
+		oldDevices[d.Path] = d
+	}
+	newDevices := make(map[string]*IODeviceResource, len(other.Devices))
+	for _, d := range other.Devices {
+		newDevices[d.Path] = d
+	}
+	var deviceDiffs []*ObjectDiff
+	for path, oldD := range oldDevices {
+		if dDiff := oldD.Diff(newDevices[path], contextual); dDiff != nil {
+			deviceDiffs = append(deviceDiffs, dDiff)
+		}
+	}
+	for path, newD := range newDevices {
+		if _, ok := oldDevices[path]; !ok {
+			if dDiff := (*IODeviceResource)(nil).Diff(newD, contextual); dDiff != nil {
+				deviceDiffs = append(deviceDiffs, dDiff)
+			}
+		}
+	}
+	sort.Sort(ObjectDiffs(deviceDiffs))
+	diff.Objects = append(diff.Objects, deviceDiffs...)
+
+	return diff
+}
+
+// Diff returns a diff of two IO device limits. If contextual diff is enabled,
+// non-changed fields will still be returned.
+func (d *IODeviceResource) Diff(other *IODeviceResource, contextual bool) *ObjectDiff {
+	if d.Equal(other) {
+		return nil
+	}
+
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Device"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if d == nil {
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(other, nil, true)
+	} else if other == nil {
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(d, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(d, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(other, nil, true)
+	}
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	return diff
+}
+
 // Diff returns a diff of two requested devices. If contextual diff is enabled,
 // non-changed fields will still be returned.
 func (r *RequestedDevice) Diff(other *RequestedDevice, contextual bool) *ObjectDiff {
@@ -534,6 +534,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "HistoryLimit",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "ProhibitOverlap",
@@ -552,6 +558,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "foo",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "StartingDeadline",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "TimeZone",
@@ -588,6 +600,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "HistoryLimit",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "ProhibitOverlap",
@@ -600,6 +618,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "foo",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "StartingDeadline",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "TimeZone",
@@ -656,6 +680,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "false",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "HistoryLimit",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "ProhibitOverlap",
@@ -674,6 +704,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "foo",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "StartingDeadline",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "TimeZone",
@@ -1027,12 +1063,30 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "CatchUp",
+								Old:  "",
+								New:  "",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "ConcurrencyPolicy",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Enabled",
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "HistoryLimit",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "ProhibitOverlap",
@@ -1051,6 +1105,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "foo",
 								New:  "foo",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "StartingDeadline",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "TimeZone",
@@ -1299,6 +1359,18 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Gang",
+								Old:  "",
+								New:  "false",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxRunDuration",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "PreventRescheduleOnLost",
@@ -1333,6 +1405,18 @@ func TestJobDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Gang",
+								Old:  "false",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxRunDuration",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "PreventRescheduleOnLost",
@@ -2134,6 +2218,254 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			TestCase: "TopologySpreads edited",
+			Old: &TaskGroup{
+				TopologySpreads: []*TopologySpread{
+					{
+						Attributes:        []string{"${node.datacenter}"},
+						MaxSkew:           1,
+						WhenUnsatisfiable: TopologySpreadBlock,
+					},
+				},
+			},
+			New: &TaskGroup{
+				TopologySpreads: []*TopologySpread{
+					{
+						Attributes:        []string{"${node.datacenter}"},
+						MaxSkew:           2,
+						WhenUnsatisfiable: TopologySpreadBlock,
+					},
+					{
+						Attributes:        []string{"${node.datacenter}", "${meta.rack}"},
+						MaxSkew:           1,
+						WhenUnsatisfiable: TopologySpreadBestEffort,
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "TopologySpread",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxSkew",
+								Old:  "1",
+								New:  "2",
+							},
+						},
+					},
+					{
+						Type: DiffTypeAdded,
+						Name: "TopologySpread",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Attributes",
+								Old:  "",
+								New:  "${node.datacenter},${meta.rack}",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxSkew",
+								Old:  "",
+								New:  "1",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "WhenUnsatisfiable",
+								Old:  "",
+								New:  "best_effort",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			TestCase: "AllocationAffinities edited",
+			Old: &TaskGroup{
+				AllocationAffinities: []*AllocationAffinity{
+					{
+						JobID:  "db",
+						Weight: 50,
+					},
+					{
+						TaskGroup: "cache",
+						Anti:      true,
+						Weight:    20,
+					},
+				},
+			},
+			New: &TaskGroup{
+				AllocationAffinities: []*AllocationAffinity{
+					{
+						JobID:  "db",
+						Weight: 80,
+					},
+					{
+						Meta:     map[string]string{"tier": "web"},
+						Required: true,
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "AllocationAffinity",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Weight",
+								Old:  "50",
+								New:  "80",
+							},
+						},
+					},
+					{
+						Type: DiffTypeAdded,
+						Name: "AllocationAffinity",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Anti",
+								Old:  "",
+								New:  "false",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Meta[tier]",
+								Old:  "",
+								New:  "web",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Required",
+								Old:  "",
+								New:  "true",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Weight",
+								Old:  "",
+								New:  "0",
+							},
+						},
+					},
+					{
+						Type: DiffTypeDeleted,
+						Name: "AllocationAffinity",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "Anti",
+								Old:  "true",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Required",
+								Old:  "false",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "TaskGroup",
+								Old:  "cache",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Weight",
+								Old:  "20",
+								New:  "",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			TestCase:   "AllocationAffinities edited with context",
+			Contextual: true,
+			Old: &TaskGroup{
+				AllocationAffinities: []*AllocationAffinity{
+					{
+						JobID:  "db",
+						Meta:   map[string]string{"tier": "web"},
+						Weight: 50,
+					},
+				},
+			},
+			New: &TaskGroup{
+				AllocationAffinities: []*AllocationAffinity{
+					{
+						JobID:  "db",
+						Meta:   map[string]string{"tier": "web"},
+						Anti:   true,
+						Weight: 50,
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "AllocationAffinity",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Anti",
+								Old:  "false",
+								New:  "true",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "JobID",
+								Old:  "db",
+								New:  "db",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "Meta[tier]",
+								Old:  "web",
+								New:  "web",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "Namespace",
+								Old:  "",
+								New:  "",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "Required",
+								Old:  "false",
+								New:  "false",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "TaskGroup",
+								Old:  "",
+								New:  "",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "Weight",
+								Old:  "50",
+								New:  "50",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			TestCase: "Affinities edited",
 			Old: &TaskGroup{
@@ -2366,6 +2698,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "1000000000",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxDelay",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Mode",
@@ -2419,6 +2757,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1000000000",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxDelay",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Mode",
@@ -2537,12 +2881,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1000000000",
 								New:  "1000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "DelayFunction",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Interval",
 								Old:  "1000000000",
 								New:  "2000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxDelay",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Mode",
@@ -2610,6 +2966,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "20000000000",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "OnMaxRunDuration",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Unlimited",
@@ -2671,6 +3033,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "20000000000",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "OnMaxRunDuration",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Unlimited",
@@ -2798,6 +3166,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "OnMaxRunDuration",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Unlimited",
@@ -2842,12 +3216,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "0",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "CanaryPercent",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "HealthyDeadline",
 								Old:  "0",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "HoldPeriod",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "MaxParallel",
@@ -2904,12 +3290,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "CanaryPercent",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "HealthyDeadline",
 								Old:  "",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "HoldPeriod",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "MaxParallel",
@@ -3071,6 +3469,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "2",
 								New:  "2",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "CanaryPercent",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "HealthCheck",
@@ -3083,6 +3487,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "30000000000",
 								New:  "30000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "HoldPeriod",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "MaxParallel",
@@ -3101,6 +3511,169 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "30000000000",
 								New:  "30000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Strategy",
+								Old:  "",
+								New:  "",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			TestCase: "Update dependencies edited",
+			Old: &TaskGroup{
+				Update: &UpdateStrategy{
+					MaxParallel: 1,
+					DependsOn:   []string{"api"},
+				},
+			},
+			New: &TaskGroup{
+				Update: &UpdateStrategy{
+					MaxParallel: 1,
+					DependsOn:   []string{"db"},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Update",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "DependsOn",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "DependsOn",
+										Old:  "",
+										New:  "db",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "DependsOn",
+										Old:  "api",
+										New:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			TestCase: "Update analysis edited",
+			Old: &TaskGroup{
+				Update: &UpdateStrategy{
+					MaxParallel: 1,
+					Analysis: &AnalysisStrategy{
+						Steps: []int{50, 100},
+						Gates: []*AnalysisGate{{
+							Name:     "webhook",
+							Type:     AnalysisGateTypeHTTP,
+							Provider: "checks-a",
+							Timeout:  30 * time.Second,
+						}},
+					},
+				},
+			},
+			New: &TaskGroup{
+				Update: &UpdateStrategy{
+					MaxParallel: 1,
+					Analysis: &AnalysisStrategy{
+						Steps: []int{10, 100},
+						Gates: []*AnalysisGate{
+							{
+								Name:     "webhook",
+								Type:     AnalysisGateTypeHTTP,
+								Provider: "checks-b",
+								Timeout:  30 * time.Second,
+							},
+							{
+								Name:    "smoke",
+								Type:    AnalysisGateTypeAction,
+								Task:    "web",
+								Action:  "smoke",
+								Timeout: 30 * time.Second,
+							},
+						},
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Update",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Analysis",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Steps",
+										Old:  "50, 100",
+										New:  "10, 100",
+									},
+								},
+								Objects: []*ObjectDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Gate",
+										Fields: []*FieldDiff{
+											{
+												Type: DiffTypeEdited,
+												Name: "Provider",
+												Old:  "checks-a",
+												New:  "checks-b",
+											},
+										},
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Gate",
+										Fields: []*FieldDiff{
+											{
+												Type: DiffTypeAdded,
+												Name: "Action",
+												Old:  "",
+												New:  "smoke",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "Name",
+												Old:  "",
+												New:  "smoke",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "Task",
+												Old:  "",
+												New:  "web",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "Timeout",
+												Old:  "",
+												New:  "30000000000",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "Type",
+												Old:  "",
+												New:  "action",
+											},
+										},
+									},
+								},
+							},
 						},
 					},
 				},
@@ -4698,6 +5271,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxRunDuration",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "ShutdownDelay",
@@ -4744,6 +5323,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "false",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxRunDuration",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "ShutdownDelay",
@@ -5989,6 +6574,62 @@ func TestTaskDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Resources IO edited",
+			Old: &Task{
+				Resources: &Resources{
+					IO: &IOResources{
+						Weight:  100,
+						Devices: []*IODeviceResource{{Path: "/dev/sda", ReadBps: 1024}},
+					},
+				},
+			},
+			New: &Task{
+				Resources: &Resources{
+					IO: &IOResources{
+						Weight:  200,
+						Devices: []*IODeviceResource{{Path: "/dev/sda", ReadBps: 2048}},
+					},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Resources",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "IO",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Weight",
+										Old:  "100",
+										New:  "200",
+									},
+								},
+								Objects: []*ObjectDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Device",
+										Fields: []*FieldDiff{
+											{
+												Type: DiffTypeEdited,
+												Name: "ReadBps",
+												Old:  "1024",
+												New:  "2048",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:       "Resources edited (no networks) with context",
 			Contextual: true,
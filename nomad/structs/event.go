@@ -38,11 +38,13 @@ const (
 	TypeNodeEligibilityUpdate         = "NodeEligibility"
 	TypeNodeDrain                     = "NodeDrain"
 	TypeNodeEvent                     = "NodeStreamEvent"
+	TypeNodeTaints                    = "NodeTaints"
 	TypeNodePoolUpserted              = "NodePoolUpserted"
 	TypeNodePoolDeleted               = "NodePoolDeleted"
 	TypeDeploymentUpdate              = "DeploymentStatusUpdate"
 	TypeDeploymentPromotion           = "DeploymentPromotion"
 	TypeDeploymentAllocHealth         = "DeploymentAllocHealth"
+	TypeDeploymentAnalysis            = "DeploymentAnalysis"
 	TypeAllocationCreated             = "AllocationCreated"
 	TypeAllocationUpdated             = "AllocationUpdated"
 	TypeAllocationUpdateDesiredStatus = "AllocationUpdateDesiredStatus"
@@ -273,6 +273,51 @@ func ScoreFitSpread(node *Node, util *ComparableResources) float64 {
 	return score
 }
 
+// ScoreFitBalanced computes a fit score to achieve balanced behavior, where
+// the CPU, memory and disk of a node are kept as evenly utilized as possible
+// so that its remaining capacity stays usable by allocations with different
+// resource profiles.
+// Score is in [0, 18]
+//
+// The score decreases linearly with the standard deviation of the utilization
+// of each resource. Disk is only taken into account if the node has disk
+// capacity.
+func ScoreFitBalanced(node *Node, util *ComparableResources) float64 {
+	freePctCpu, freePctRam := computeFreePercentage(node, util)
+	utilization := []float64{1 - freePctCpu, 1 - freePctRam}
+
+	nodeDisk := float64(node.NodeResources.Disk.DiskMB)
+	if node.ReservedResources != nil {
+		nodeDisk -= float64(node.ReservedResources.Disk.DiskMB)
+	}
+	if nodeDisk > 0 {
+		utilization = append(utilization, float64(util.Shared.DiskMB)/nodeDisk)
+	}
+
+	var mean float64
+	for _, u := range utilization {
+		mean += u
+	}
+	mean /= float64(len(utilization))
+
+	var variance float64
+	for _, u := range utilization {
+		variance += (u - mean) * (u - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(utilization)))
+
+	// The standard deviation of values in [0, 1] is at most 0.5, so a node
+	// with one resource fully utilized and another unused scores 0.
+	score := 18.0 * (1 - 2*stdDev)
+
+	if score > 18.0 {
+		score = 18.0
+	} else if score < 0 {
+		score = 0
+	}
+	return score
+}
+
 func CopySliceConstraints(s []*Constraint) []*Constraint {
 	l := len(s)
 	if l == 0 {
@@ -312,6 +357,32 @@ func CopySliceSpreads(s []*Spread) []*Spread {
 	return c
 }
 
+func CopySliceTopologySpreads(s []*TopologySpread) []*TopologySpread {
+	l := len(s)
+	if l == 0 {
+		return nil
+	}
+
+	c := make([]*TopologySpread, l)
+	for i, v := range s {
+		c[i] = v.Copy()
+	}
+	return c
+}
+
+func CopySliceAllocationAffinities(s []*AllocationAffinity) []*AllocationAffinity {
+	l := len(s)
+	if l == 0 {
+		return nil
+	}
+
+	c := make([]*AllocationAffinity, l)
+	for i, v := range s {
+		c[i] = v.Copy()
+	}
+	return c
+}
+
 func CopySliceSpreadTarget(s []*SpreadTarget) []*SpreadTarget {
 	l := len(s)
 	if l == 0 {
@@ -595,6 +595,100 @@ func TestScoreFitBinPack(t *testing.T) {
 	}
 }
 
+func TestScoreFitBalanced(t *testing.T) {
+	ci.Parallel(t)
+
+	node := &Node{}
+	node.NodeResources = &NodeResources{
+		Processors: NodeProcessorResources{
+			Topology: &numalib.Topology{
+				Distances: numalib.SLIT{[]numalib.Cost{10}},
+				Cores: []numalib.Core{{
+					ID:        0,
+					Grade:     numalib.Performance,
+					BaseSpeed: 4096,
+				}},
+			},
+		},
+		Memory: NodeMemoryResources{
+			MemoryMB: 8192,
+		},
+	}
+	node.NodeResources.Processors.Topology.SetNodes(idset.From[hw.NodeID]([]hw.NodeID{0}))
+	node.NodeResources.Compatibility()
+	node.ReservedResources = &NodeReservedResources{
+		Cpu: NodeReservedCpuResources{
+			CpuShares: 2048,
+		},
+		Memory: NodeReservedMemoryResources{
+			MemoryMB: 4096,
+		},
+	}
+
+	cases := []struct {
+		name      string
+		nodeDisk  int64
+		flattened AllocatedTaskResources
+		diskMB    int64
+		score     float64
+	}{
+		{
+			name: "even utilization",
+			flattened: AllocatedTaskResources{
+				Cpu:    AllocatedCpuResources{CpuShares: 1024},
+				Memory: AllocatedMemoryResources{MemoryMB: 2048},
+			},
+			score: 18,
+		},
+		{
+			name: "cpu filled and memory unused",
+			flattened: AllocatedTaskResources{
+				Cpu:    AllocatedCpuResources{CpuShares: 2048},
+				Memory: AllocatedMemoryResources{MemoryMB: 0},
+			},
+			score: 0,
+		},
+		{
+			name: "uneven utilization",
+			flattened: AllocatedTaskResources{
+				Cpu:    AllocatedCpuResources{CpuShares: 1024},
+				Memory: AllocatedMemoryResources{MemoryMB: 1024},
+			},
+			score: 13.5,
+		},
+		{
+			name:     "even utilization with disk",
+			nodeDisk: 1000,
+			flattened: AllocatedTaskResources{
+				Cpu:    AllocatedCpuResources{CpuShares: 1024},
+				Memory: AllocatedMemoryResources{MemoryMB: 2048},
+			},
+			diskMB: 500,
+			score:  18,
+		},
+		{
+			name:     "unused disk",
+			nodeDisk: 1000,
+			flattened: AllocatedTaskResources{
+				Cpu:    AllocatedCpuResources{CpuShares: 1024},
+				Memory: AllocatedMemoryResources{MemoryMB: 2048},
+			},
+			score: 9.515,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			node.NodeResources.Disk.DiskMB = c.nodeDisk
+			util := &ComparableResources{
+				Flattened: c.flattened,
+				Shared:    AllocatedSharedResources{DiskMB: c.diskMB},
+			}
+			require.InDelta(t, c.score, ScoreFitBalanced(node, util), 0.001)
+		})
+	}
+}
+
 func TestACLPolicyListHash(t *testing.T) {
 	ci.Parallel(t)
 
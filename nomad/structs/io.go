@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// MinIOWeight and MaxIOWeight are the bounds of the cgroup v2 io.weight
+	// of a task.
+	MinIOWeight = 1
+	MaxIOWeight = 10000
+)
+
+// IOResources are the block I/O limits of a task. They are enforced through
+// the cgroup v2 io controller by the drivers that support it.
+type IOResources struct {
+	// Weight is the relative share of block I/O of the task when devices
+	// are contended, between 1 and 10000. Zero leaves the default weight.
+	Weight int
+
+	// Devices are the bandwidth and operation limits of the task on
+	// individual block devices.
+	Devices []*IODeviceResource
+}
+
+func (r *IOResources) Equal(o *IOResources) bool {
+	if r == nil || o == nil {
+		return r == o
+	}
+	return r.Weight == o.Weight &&
+		slices.EqualFunc(r.Devices, o.Devices, (*IODeviceResource).Equal)
+}
+
+func (r *IOResources) Copy() *IOResources {
+	if r == nil {
+		return nil
+	}
+	return &IOResources{
+		Weight:  r.Weight,
+		Devices: helper.CopySlice(r.Devices),
+	}
+}
+
+func (r *IOResources) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+	if r.Weight != 0 && (r.Weight < MinIOWeight || r.Weight > MaxIOWeight) {
+		mErr = multierror.Append(mErr, fmt.Errorf("io weight must be between %d and %d: %d", MinIOWeight, MaxIOWeight, r.Weight))
+	}
+
+	paths := make(map[string]struct{}, len(r.Devices))
+	for _, d := range r.Devices {
+		if _, ok := paths[d.Path]; ok {
+			mErr = multierror.Append(mErr, fmt.Errorf("io device %q is duplicated", d.Path))
+		}
+		paths[d.Path] = struct{}{}
+
+		if err := d.Validate(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("io device %q failed validation: %v", d.Path, err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// IODeviceResource limits the block I/O of a task on a device. Limits that
+// are zero are not enforced.
+type IODeviceResource struct {
+	// Path is the absolute path of the block device, such as /dev/sda.
+	Path string
+
+	// ReadBps and WriteBps limit the bytes per second.
+	ReadBps  int64
+	WriteBps int64
+
+	// ReadIOPS and WriteIOPS limit the operations per second.
+	ReadIOPS  int64
+	WriteIOPS int64
+}
+
+func (d *IODeviceResource) Equal(o *IODeviceResource) bool {
+	if d == nil || o == nil {
+		return d == o
+	}
+	return *d == *o
+}
+
+func (d *IODeviceResource) Copy() *IODeviceResource {
+	if d == nil {
+		return nil
+	}
+	c := new(IODeviceResource)
+	*c = *d
+	return c
+}
+
+func (d *IODeviceResource) Validate() error {
+	var mErr *multierror.Error
+	if !filepath.IsAbs(d.Path) {
+		mErr = multierror.Append(mErr, errors.New("path must be absolute"))
+	}
+	if d.ReadBps < 0 || d.WriteBps < 0 || d.ReadIOPS < 0 || d.WriteIOPS < 0 {
+		mErr = multierror.Append(mErr, errors.New("limits must be zero or greater"))
+	}
+	if d.ReadBps == 0 && d.WriteBps == 0 && d.ReadIOPS == 0 && d.WriteIOPS == 0 {
+		mErr = multierror.Append(mErr, errors.New("at least one limit must be set"))
+	}
+	return mErr.ErrorOrNil()
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func testIOResources() *IOResources {
+	return &IOResources{
+		Weight: 500,
+		Devices: []*IODeviceResource{
+			{
+				Path:     "/dev/sda",
+				ReadBps:  10 * BytesInMegabyte,
+				WriteBps: 5 * BytesInMegabyte,
+			},
+			{
+				Path:      "/dev/nvme0n1",
+				ReadIOPS:  1000,
+				WriteIOPS: 500,
+			},
+		},
+	}
+}
+
+func TestIOResources_Equal(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Equal[*IOResources](t, nil, nil)
+	must.NotEqual[*IOResources](t, nil, new(IOResources))
+
+	must.StructEqual(t, testIOResources(), []must.Tweak[*IOResources]{{
+		Field: "Weight",
+		Apply: func(r *IOResources) { r.Weight = 100 },
+	}, {
+		Field: "Devices",
+		Apply: func(r *IOResources) { r.Devices[0].ReadBps = 1 },
+	}})
+}
+
+func TestIOResources_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	r := testIOResources()
+	c := r.Copy()
+	must.Equal(t, r, c)
+
+	c.Devices[0].WriteBps = 1
+	must.NotEqual(t, r, c)
+}
+
+func TestIOResources_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		modify func(*IOResources)
+		err    string
+	}{
+		{
+			name:   "valid",
+			modify: func(*IOResources) {},
+		},
+		{
+			name:   "weight unset",
+			modify: func(r *IOResources) { r.Weight = 0 },
+		},
+		{
+			name:   "weight out of range",
+			modify: func(r *IOResources) { r.Weight = MaxIOWeight + 1 },
+			err:    "io weight must be between 1 and 10000",
+		},
+		{
+			name:   "duplicate device",
+			modify: func(r *IOResources) { r.Devices[1].Path = r.Devices[0].Path },
+			err:    `io device "/dev/sda" is duplicated`,
+		},
+		{
+			name:   "relative path",
+			modify: func(r *IOResources) { r.Devices[0].Path = "sda" },
+			err:    "path must be absolute",
+		},
+		{
+			name:   "negative limit",
+			modify: func(r *IOResources) { r.Devices[0].ReadIOPS = -1 },
+			err:    "limits must be zero or greater",
+		},
+		{
+			name: "no limits",
+			modify: func(r *IOResources) {
+				r.Devices[1] = &IODeviceResource{Path: "/dev/sdb"}
+			},
+			err: "at least one limit must be set",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := testIOResources()
+			tc.modify(r)
+			err := r.Validate()
+			if tc.err == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
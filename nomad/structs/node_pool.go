@@ -8,6 +8,7 @@ import (
 	"maps"
 	"regexp"
 	"sort"
+	"strconv"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/helper/pointer"
@@ -55,7 +56,7 @@ type NodePool struct {
 	Meta map[string]string
 
 	// SchedulerConfiguration is the scheduler configuration specific to the
-	// node pool.
+	// node pool. It is only available in Nomad Enterprise.
 	SchedulerConfiguration *NodePoolSchedulerConfiguration
 
 	// Hash is the hash of the node pool which is used to efficiently diff when
@@ -157,6 +158,15 @@ func (n *NodePool) SetHash() []byte {
 				_, _ = hash.Write([]byte("memory_oversubscription_disabled"))
 			}
 		}
+
+		if loadAware := n.SchedulerConfiguration.LoadAwareConfig; loadAware != nil {
+			if loadAware.Enabled {
+				_, _ = hash.Write([]byte("load_aware_enabled"))
+			} else {
+				_, _ = hash.Write([]byte("load_aware_disabled"))
+			}
+			_, _ = hash.Write([]byte(strconv.Itoa(loadAware.UtilizationThreshold)))
+		}
 	}
 
 	// sort keys to ensure hash stability when meta is stored later
@@ -193,6 +203,11 @@ type NodePoolSchedulerConfiguration struct {
 	// MemoryOversubscriptionEnabled specifies whether memory oversubscription
 	// is enabled. If not defined, the global cluster configuration is used.
 	MemoryOversubscriptionEnabled *bool `hcl:"memory_oversubscription_enabled"`
+
+	// LoadAwareConfig specifies how the actual utilization of the nodes in
+	// the pool is used to score them. If not defined, the global cluster
+	// configuration is used.
+	LoadAwareConfig *LoadAwareConfig `hcl:"load_aware_config"`
 }
 
 // Copy returns a deep copy of the node pool scheduler configuration.
@@ -207,6 +222,10 @@ func (n *NodePoolSchedulerConfiguration) Copy() *NodePoolSchedulerConfiguration
 	if n.MemoryOversubscriptionEnabled != nil {
 		nc.MemoryOversubscriptionEnabled = pointer.Of(*n.MemoryOversubscriptionEnabled)
 	}
+	if n.LoadAwareConfig != nil {
+		loadAware := *n.LoadAwareConfig
+		nc.LoadAwareConfig = &loadAware
+	}
 
 	return nc
 }
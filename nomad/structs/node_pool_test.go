@@ -22,6 +22,7 @@ func TestNodePool_Copy(t *testing.T) {
 		SchedulerConfiguration: &NodePoolSchedulerConfiguration{
 			SchedulerAlgorithm:            SchedulerAlgorithmSpread,
 			MemoryOversubscriptionEnabled: pointer.Of(false),
+			LoadAwareConfig:               &LoadAwareConfig{Enabled: true},
 		},
 	}
 	poolCopy := pool.Copy()
@@ -31,10 +32,12 @@ func TestNodePool_Copy(t *testing.T) {
 	poolCopy.Meta["new_key"] = "true"
 	poolCopy.SchedulerConfiguration.SchedulerAlgorithm = SchedulerAlgorithmBinpack
 	poolCopy.SchedulerConfiguration.MemoryOversubscriptionEnabled = pointer.Of(true)
+	poolCopy.SchedulerConfiguration.LoadAwareConfig.Enabled = false
 
 	must.NotEq(t, pool, poolCopy)
 	must.NotEq(t, pool.Meta, poolCopy.Meta)
 	must.NotEq(t, pool.SchedulerConfiguration, poolCopy.SchedulerConfiguration)
+	must.True(t, pool.SchedulerConfiguration.LoadAwareConfig.Enabled)
 }
 
 func TestNodePool_Validate(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/netip"
+	"slices"
 	"time"
 
 	"github.com/hashicorp/go-uuid"
@@ -209,6 +210,11 @@ const (
 	// SchedulerAlgorithmSpread indicates that the scheduler should spread
 	// allocations as evenly as possible over the available hardware.
 	SchedulerAlgorithmSpread SchedulerAlgorithm = "spread"
+
+	// SchedulerAlgorithmBalanced indicates that the scheduler should keep the
+	// utilization of the CPU, memory and disk of each node as even as
+	// possible.
+	SchedulerAlgorithmBalanced SchedulerAlgorithm = "balanced"
 )
 
 // SchedulerConfiguration is the config for controlling scheduler behavior
@@ -223,6 +229,15 @@ type SchedulerConfiguration struct {
 	// MemoryOversubscriptionEnabled specifies whether memory oversubscription is enabled
 	MemoryOversubscriptionEnabled bool `hcl:"memory_oversubscription_enabled"`
 
+	// LoadAwareConfig specifies whether the actual utilization reported by
+	// clients is taken into account when scoring nodes.
+	LoadAwareConfig LoadAwareConfig `hcl:"load_aware_config"`
+
+	// RebalanceConfig specifies whether allocations are periodically
+	// migrated to restore spread and affinity goals and to relieve
+	// over-utilized nodes.
+	RebalanceConfig RebalanceConfig `hcl:"rebalance_config"`
+
 	// RejectJobRegistration disables new job registrations except with a
 	// management ACL token
 	RejectJobRegistration bool `hcl:"reject_job_registration"`
@@ -244,6 +259,7 @@ func (s *SchedulerConfiguration) Copy() *SchedulerConfiguration {
 	}
 
 	ns := *s
+	ns.RebalanceConfig.Namespaces = slices.Clone(s.RebalanceConfig.Namespaces)
 	return &ns
 }
 
@@ -271,6 +287,9 @@ func (s *SchedulerConfiguration) WithNodePool(pool *NodePool) *SchedulerConfigur
 	if poolConfig.MemoryOversubscriptionEnabled != nil {
 		schedConfig.MemoryOversubscriptionEnabled = *poolConfig.MemoryOversubscriptionEnabled
 	}
+	if poolConfig.LoadAwareConfig != nil {
+		schedConfig.LoadAwareConfig = *poolConfig.LoadAwareConfig
+	}
 
 	return schedConfig
 }
@@ -287,12 +306,15 @@ func (s *SchedulerConfiguration) Validate() error {
 	}
 
 	switch s.SchedulerAlgorithm {
-	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread:
+	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread, SchedulerAlgorithmBalanced:
 	default:
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
-	return nil
+	if err := s.LoadAwareConfig.Validate(); err != nil {
+		return err
+	}
+	return s.RebalanceConfig.Validate()
 }
 
 // SchedulerConfigurationResponse is the response object that wraps SchedulerConfiguration
@@ -303,6 +325,27 @@ type SchedulerConfigurationResponse struct {
 	QueryMeta
 }
 
+// RebalanceCandidate is an allocation the rebalancer migrates, or would
+// migrate in dry run mode.
+type RebalanceCandidate struct {
+	AllocID   string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	NodeID    string
+
+	// Reason is why the allocation would be better placed elsewhere.
+	Reason string
+}
+
+// SchedulerRebalanceCandidatesResponse is the response object used to
+// retrieve the allocations the rebalancer would migrate.
+type SchedulerRebalanceCandidatesResponse struct {
+	Candidates []*RebalanceCandidate
+
+	QueryMeta
+}
+
 // SchedulerSetConfigurationResponse is the response object used
 // when updating scheduler configuration
 type SchedulerSetConfigurationResponse struct {
@@ -313,6 +356,82 @@ type SchedulerSetConfigurationResponse struct {
 	WriteMeta
 }
 
+// LoadAwareConfig specifies how the actual CPU and memory utilization reported
+// by clients is used to score nodes.
+type LoadAwareConfig struct {
+	// Enabled specifies whether nodes are penalized according to their
+	// actual utilization.
+	Enabled bool `hcl:"enabled"`
+
+	// UtilizationThreshold is the percentage of CPU or memory utilization
+	// above which nodes are penalized. Nodes are penalized proportionally to
+	// how far their utilization is above the threshold.
+	UtilizationThreshold int `hcl:"utilization_threshold"`
+}
+
+func (l *LoadAwareConfig) Validate() error {
+	if l.UtilizationThreshold < 0 || l.UtilizationThreshold >= 100 {
+		return fmt.Errorf("invalid load aware utilization threshold: %d must be within the range [0,100)",
+			l.UtilizationThreshold)
+	}
+	return nil
+}
+
+// DefaultRebalanceMaxMigrations is the maximum number of allocations migrated
+// per rebalance run when RebalanceConfig.MaxMigrations is not set.
+const DefaultRebalanceMaxMigrations = 10
+
+// RebalanceConfig specifies how allocations of service jobs are periodically
+// migrated to restore their spread and affinity goals, or to move them off of
+// over-utilized nodes. Migrations honor the disruption budget and migrate
+// strategy of each task group.
+type RebalanceConfig struct {
+	// Enabled specifies whether the rebalancer runs.
+	Enabled bool `hcl:"enabled"`
+
+	// DryRun specifies whether the rebalancer only reports the allocations
+	// it would migrate without migrating them.
+	DryRun bool `hcl:"dry_run"`
+
+	// Namespaces is the list of namespaces opted in for rebalancing. The
+	// wildcard "*" opts in every namespace.
+	Namespaces []string `hcl:"namespaces"`
+
+	// MaxMigrations is the maximum number of allocations migrated per
+	// rebalance run. Defaults to DefaultRebalanceMaxMigrations.
+	MaxMigrations int `hcl:"max_migrations"`
+
+	// UtilizationThreshold is the percentage of CPU or memory utilization
+	// reported by a client above which its node is considered
+	// over-utilized. Zero disables rebalancing based on utilization.
+	UtilizationThreshold int `hcl:"utilization_threshold"`
+}
+
+func (r *RebalanceConfig) Validate() error {
+	if r.MaxMigrations < 0 {
+		return fmt.Errorf("invalid rebalance max migrations: %d must be >= 0", r.MaxMigrations)
+	}
+	if r.UtilizationThreshold < 0 || r.UtilizationThreshold >= 100 {
+		return fmt.Errorf("invalid rebalance utilization threshold: %d must be within the range [0,100)",
+			r.UtilizationThreshold)
+	}
+	return nil
+}
+
+// EffectiveMaxMigrations returns the maximum number of allocations migrated
+// per rebalance run.
+func (r *RebalanceConfig) EffectiveMaxMigrations() int {
+	if r.MaxMigrations == 0 {
+		return DefaultRebalanceMaxMigrations
+	}
+	return r.MaxMigrations
+}
+
+// NamespaceEnabled returns whether the namespace is opted in for rebalancing.
+func (r *RebalanceConfig) NamespaceEnabled(namespace string) bool {
+	return slices.Contains(r.Namespaces, "*") || slices.Contains(r.Namespaces, namespace)
+}
+
 // PreemptionConfig specifies whether preemption is enabled based on scheduler type
 type PreemptionConfig struct {
 	// SystemSchedulerEnabled specifies if preemption is enabled for system jobs
@@ -96,6 +96,32 @@ func TestSchedulerConfiguration_WithNodePool(t *testing.T) {
 				SchedulerAlgorithm: SchedulerAlgorithmSpread,
 			},
 		},
+		{
+			name: "pool with load aware config overwrites config",
+			schedConfig: &SchedulerConfiguration{
+				LoadAwareConfig: LoadAwareConfig{Enabled: true, UtilizationThreshold: 50},
+			},
+			pool: &NodePool{
+				SchedulerConfiguration: &NodePoolSchedulerConfiguration{
+					LoadAwareConfig: &LoadAwareConfig{Enabled: false},
+				},
+			},
+			expected: &SchedulerConfiguration{
+				LoadAwareConfig: LoadAwareConfig{Enabled: false},
+			},
+		},
+		{
+			name: "pool without load aware config does not modify config",
+			schedConfig: &SchedulerConfiguration{
+				LoadAwareConfig: LoadAwareConfig{Enabled: true, UtilizationThreshold: 50},
+			},
+			pool: &NodePool{
+				SchedulerConfiguration: &NodePoolSchedulerConfiguration{},
+			},
+			expected: &SchedulerConfiguration{
+				LoadAwareConfig: LoadAwareConfig{Enabled: true, UtilizationThreshold: 50},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -106,3 +132,85 @@ func TestSchedulerConfiguration_WithNodePool(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedulerConfiguration_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		schedConfig *SchedulerConfiguration
+		err         string
+	}{
+		{
+			name:        "default",
+			schedConfig: &SchedulerConfiguration{},
+		},
+		{
+			name:        "invalid algorithm",
+			schedConfig: &SchedulerConfiguration{SchedulerAlgorithm: "random"},
+			err:         "invalid scheduler algorithm",
+		},
+		{
+			name:        "balanced algorithm",
+			schedConfig: &SchedulerConfiguration{SchedulerAlgorithm: SchedulerAlgorithmBalanced},
+		},
+		{
+			name: "load aware",
+			schedConfig: &SchedulerConfiguration{
+				LoadAwareConfig: LoadAwareConfig{Enabled: true, UtilizationThreshold: 80},
+			},
+		},
+		{
+			name: "invalid load aware threshold",
+			schedConfig: &SchedulerConfiguration{
+				LoadAwareConfig: LoadAwareConfig{Enabled: true, UtilizationThreshold: 100},
+			},
+			err: "invalid load aware utilization threshold",
+		},
+		{
+			name: "rebalance",
+			schedConfig: &SchedulerConfiguration{
+				RebalanceConfig: RebalanceConfig{Enabled: true, MaxMigrations: 5, UtilizationThreshold: 90},
+			},
+		},
+		{
+			name: "invalid rebalance max migrations",
+			schedConfig: &SchedulerConfiguration{
+				RebalanceConfig: RebalanceConfig{Enabled: true, MaxMigrations: -1},
+			},
+			err: "invalid rebalance max migrations",
+		},
+		{
+			name: "invalid rebalance threshold",
+			schedConfig: &SchedulerConfiguration{
+				RebalanceConfig: RebalanceConfig{Enabled: true, UtilizationThreshold: 100},
+			},
+			err: "invalid rebalance utilization threshold",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.schedConfig.Validate()
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRebalanceConfig_NamespaceEnabled(t *testing.T) {
+	ci.Parallel(t)
+
+	config := &RebalanceConfig{}
+	must.False(t, config.NamespaceEnabled("default"))
+
+	config.Namespaces = []string{"default"}
+	must.True(t, config.NamespaceEnabled("default"))
+	must.False(t, config.NamespaceEnabled("other"))
+
+	config.Namespaces = []string{"*"}
+	must.True(t, config.NamespaceEnabled("other"))
+}
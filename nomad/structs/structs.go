@@ -128,6 +128,9 @@ const (
 	ACLBindingRulesDeleteRequestType             MessageType = 58
 	NodePoolUpsertRequestType                    MessageType = 59
 	NodePoolDeleteRequestType                    MessageType = 60
+	NodeUpdateTaintsRequestType                  MessageType = 61
+	NodeUpdateUtilizationRequestType             MessageType = 62
+	DeploymentAnalysisRequestType                MessageType = 63
 
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
@@ -180,6 +183,9 @@ const (
 	// maxNamespaceDescriptionLength limits a namespace description length
 	maxNamespaceDescriptionLength = 256
 
+	// maxNamespaceEvalQueueWeight limits the eval queue weight of a namespace
+	maxNamespaceEvalQueueWeight = 1000
+
 	// JitterFraction is a the limit to the amount of jitter we apply
 	// to a user specified MaxQueryTime. We divide the specified time by
 	// the fraction. So 16 == 6.25% limit of jitter. This jitter is also
@@ -640,6 +646,19 @@ type NodeUpdateStatusRequest struct {
 	Status    string
 	NodeEvent *NodeEvent
 	UpdatedAt int64
+
+	// Utilization is the utilization of the node reported by the client
+	// along with its heartbeat, if any.
+	Utilization *NodeUtilization
+
+	WriteRequest
+}
+
+// NodeUpdateUtilizationRequest is used for updating the utilization of a node
+// reported by its client
+type NodeUpdateUtilizationRequest struct {
+	NodeID      string
+	Utilization *NodeUtilization
 	WriteRequest
 }
 
@@ -705,6 +724,25 @@ type NodeUpdateEligibilityRequest struct {
 	WriteRequest
 }
 
+// NodeUpdateTaintsRequest is used for replacing the taints of a node
+type NodeUpdateTaintsRequest struct {
+	NodeID string
+	Taints []*NodeTaint
+
+	// NodeModifyIndex, if set, is the ModifyIndex the node must have for its
+	// taints to be replaced. It prevents concurrent updates from overwriting
+	// each other.
+	NodeModifyIndex uint64
+
+	// NodeEvent is the event added to the node
+	NodeEvent *NodeEvent
+
+	// UpdatedAt represents server time of receiving request
+	UpdatedAt int64
+
+	WriteRequest
+}
+
 // NodeEvaluateRequest is used to re-evaluate the node
 type NodeEvaluateRequest struct {
 	NodeID string
@@ -803,6 +841,12 @@ type JobEvaluateRequest struct {
 // EvalOptions is used to encapsulate options when forcing a job evaluation
 type EvalOptions struct {
 	ForceReschedule bool
+
+	// ArrayIndices limits forced rescheduling to the failed allocations of
+	// array task groups at these indices. Allocations at these indices are
+	// rescheduled even if the reschedule policy of their task group is
+	// disabled or exhausted.
+	ArrayIndices []int
 }
 
 // JobSubmissionRequest is used to query a JobSubmission object associated with a
@@ -1567,6 +1611,14 @@ type NodeEligibilityUpdateResponse struct {
 	WriteMeta
 }
 
+// NodeTaintsUpdateResponse is used to respond to a node taints update
+type NodeTaintsUpdateResponse struct {
+	NodeModifyIndex uint64
+	EvalIDs         []string
+	EvalCreateIndex uint64
+	WriteMeta
+}
+
 // NodeAllocsResponse is used to return allocs for a single node
 type NodeAllocsResponse struct {
 	Allocs []*Allocation
@@ -1960,6 +2012,159 @@ const (
 	NodeSchedulingIneligible = "ineligible"
 )
 
+const (
+	// NodeTaintEffectNoSchedule prevents allocations that do not tolerate
+	// the taint from being placed on the node.
+	NodeTaintEffectNoSchedule = "no_schedule"
+
+	// NodeTaintEffectPreferNoSchedule makes the scheduler avoid placing
+	// allocations that do not tolerate the taint on the node.
+	NodeTaintEffectPreferNoSchedule = "prefer_no_schedule"
+
+	// NodeTaintEffectNoExecute prevents allocations that do not tolerate the
+	// taint from being placed on the node, and evicts the ones running on it.
+	NodeTaintEffectNoExecute = "no_execute"
+
+	// NodeTaintsEnforceIndexErrPrefix is the prefix to use in errors caused
+	// by enforcing the node modify index during taints updates.
+	NodeTaintsEnforceIndexErrPrefix = "Enforcing node modify index"
+)
+
+// NodeTaint marks a node so that only allocations that tolerate the taint
+// are placed on it.
+type NodeTaint struct {
+	Key    string
+	Value  string
+	Effect string
+}
+
+func (t *NodeTaint) Copy() *NodeTaint {
+	if t == nil {
+		return nil
+	}
+	nt := *t
+	return &nt
+}
+
+func (t *NodeTaint) Equal(o *NodeTaint) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	return t.Key == o.Key && t.Value == o.Value && t.Effect == o.Effect
+}
+
+func (t *NodeTaint) String() string {
+	if t.Value == "" {
+		return fmt.Sprintf("%s:%s", t.Key, t.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect)
+}
+
+func (t *NodeTaint) Validate() error {
+	var mErr multierror.Error
+	if t.Key == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Missing taint key"))
+	} else if strings.ContainsAny(t.Key, "=:") {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Taint key %q must not contain '=' or ':'", t.Key))
+	}
+	if strings.Contains(t.Value, ":") {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Taint value %q must not contain ':'", t.Value))
+	}
+	switch t.Effect {
+	case NodeTaintEffectNoSchedule, NodeTaintEffectPreferNoSchedule, NodeTaintEffectNoExecute:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid taint effect %q", t.Effect))
+	}
+	return mErr.ErrorOrNil()
+}
+
+const (
+	// TolerationOperatorEqual tolerates taints with the toleration's key and
+	// value.
+	TolerationOperatorEqual = "equal"
+
+	// TolerationOperatorExists tolerates taints with the toleration's key,
+	// whatever their value.
+	TolerationOperatorExists = "exists"
+)
+
+// Toleration allows the allocations of a task group to be placed on, and keep
+// running on, nodes with matching taints.
+type Toleration struct {
+	// Key of the tolerated taints. An empty key with the exists operator
+	// tolerates every taint.
+	Key string
+
+	// Operator is one of TolerationOperatorEqual or
+	// TolerationOperatorExists.
+	Operator string
+
+	// Value of the tolerated taints when using the equal operator.
+	Value string
+
+	// Effect of the tolerated taints. Tolerates every effect if empty.
+	Effect string
+}
+
+func (t *Toleration) Copy() *Toleration {
+	if t == nil {
+		return nil
+	}
+	nt := *t
+	return &nt
+}
+
+func (t *Toleration) Equal(o *Toleration) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	return t.Key == o.Key && t.Operator == o.Operator &&
+		t.Value == o.Value && t.Effect == o.Effect
+}
+
+func (t *Toleration) String() string {
+	return fmt.Sprintf("%s %s %s:%s", t.Key, t.Operator, t.Value, t.Effect)
+}
+
+func (t *Toleration) Canonicalize() {
+	if t.Operator == "" {
+		t.Operator = TolerationOperatorEqual
+	}
+}
+
+func (t *Toleration) Validate() error {
+	var mErr multierror.Error
+	switch t.Operator {
+	case TolerationOperatorEqual:
+		if t.Key == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("Toleration operator \"equal\" requires a key"))
+		}
+	case TolerationOperatorExists:
+		if t.Value != "" {
+			mErr.Errors = append(mErr.Errors, errors.New("Toleration operator \"exists\" must not have a value"))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid toleration operator %q", t.Operator))
+	}
+	switch t.Effect {
+	case "", NodeTaintEffectNoSchedule, NodeTaintEffectPreferNoSchedule, NodeTaintEffectNoExecute:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid toleration effect %q", t.Effect))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Tolerates returns whether the toleration tolerates the taint.
+func (t *Toleration) Tolerates(taint *NodeTaint) bool {
+	if t.Effect != "" && t.Effect != taint.Effect {
+		return false
+	}
+	if t.Operator == TolerationOperatorExists {
+		return t.Key == "" || t.Key == taint.Key
+	}
+	return t.Key == taint.Key && t.Value == taint.Value
+}
+
 // DrainSpec describes a Node's desired drain behavior.
 type DrainSpec struct {
 	// Deadline is the duration after StartTime when the remaining
@@ -2117,6 +2322,15 @@ type Node struct {
 	// reserved from scheduling.
 	ReservedResources *NodeReservedResources
 
+	// Utilization is the smoothed CPU and memory utilization reported by the
+	// client. It is nil until the client reports it.
+	Utilization *NodeUtilization
+
+	// Pressure is the resource pressure reported by a client that evicts
+	// allocations when its host runs low on memory or disk. The scheduler
+	// does not place allocations on the node while it is under pressure.
+	Pressure *NodePressure
+
 	// Resources is the available resources on the client.
 	// For example 'cpu=2' 'memory=2048'
 	// COMPAT(0.10): Remove after 0.10
@@ -2158,6 +2372,10 @@ type Node struct {
 	// placements.
 	SchedulingEligibility string
 
+	// Taints restrict the allocations placed on, and running on, this node
+	// to the ones that tolerate them.
+	Taints []*NodeTaint
+
 	// Status of this node
 	Status string
 
@@ -2227,7 +2445,8 @@ func (n *Node) Sanitize() *Node {
 
 // Ready returns true if the node is ready for running allocations
 func (n *Node) Ready() bool {
-	return n.Status == NodeStatusReady && n.DrainStrategy == nil && n.SchedulingEligibility == NodeSchedulingEligible
+	return n.Status == NodeStatusReady && n.DrainStrategy == nil &&
+		n.SchedulingEligibility == NodeSchedulingEligible && !n.Pressure.Pressured()
 }
 
 func (n *Node) Canonicalize() {
@@ -2287,11 +2506,14 @@ func (n *Node) Copy() *Node {
 	nn.Attributes = maps.Clone(nn.Attributes)
 	nn.NodeResources = nn.NodeResources.Copy()
 	nn.ReservedResources = nn.ReservedResources.Copy()
+	nn.Utilization = nn.Utilization.Copy()
+	nn.Pressure = nn.Pressure.Copy()
 	nn.Resources = nn.Resources.Copy()
 	nn.Reserved = nn.Reserved.Copy()
 	nn.Links = maps.Clone(nn.Links)
 	nn.Meta = maps.Clone(nn.Meta)
 	nn.DrainStrategy = nn.DrainStrategy.Copy()
+	nn.Taints = helper.CopySlice(n.Taints)
 	nn.Events = helper.CopySlice(n.Events)
 	nn.Drivers = helper.DeepCopyMap(n.Drivers)
 	nn.CSIControllerPlugins = helper.DeepCopyMap(nn.CSIControllerPlugins)
@@ -2339,6 +2561,21 @@ func (n *Node) IsInPool(pool string) bool {
 	return pool == NodePoolAll || n.NodePool == pool
 }
 
+// UntoleratedTaints returns the node's taints with one of the given effects
+// that are not tolerated by any of the tolerations.
+func (n *Node) UntoleratedTaints(tolerations []*Toleration, effects ...string) []*NodeTaint {
+	var untolerated []*NodeTaint
+	for _, taint := range n.Taints {
+		if !slices.Contains(effects, taint.Effect) {
+			continue
+		}
+		if !slices.ContainsFunc(tolerations, func(t *Toleration) bool { return t.Tolerates(taint) }) {
+			untolerated = append(untolerated, taint)
+		}
+	}
+	return untolerated
+}
+
 // HasEvent returns true if the node has the given message in its events list.
 func (n *Node) HasEvent(msg string) bool {
 	for _, ev := range n.Events {
@@ -2432,6 +2669,7 @@ type Resources struct {
 	Networks    Networks
 	Devices     ResourceDevices
 	NUMA        *NUMA
+	IO          *IOResources
 }
 
 const (
@@ -2496,6 +2734,10 @@ func (r *Resources) Validate() error {
 		}
 	}
 
+	if err := r.IO.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
 	// ensure memory_max is greater than memory, unless it is set to 0 or -1 which
 	// are both sentinel values
 	if (r.MemoryMaxMB != 0 && r.MemoryMaxMB != memoryNoLimit) && r.MemoryMaxMB < r.MemoryMB {
@@ -2529,6 +2771,9 @@ func (r *Resources) Merge(other *Resources) {
 	if len(other.Devices) != 0 {
 		r.Devices = other.Devices
 	}
+	if other.IO != nil {
+		r.IO = other.IO
+	}
 }
 
 // Equal Resources.
@@ -2548,7 +2793,8 @@ func (r *Resources) Equal(o *Resources) bool {
 		r.DiskMB == o.DiskMB &&
 		r.IOPS == o.IOPS &&
 		r.Networks.Equal(&o.Networks) &&
-		r.Devices.Equal(&o.Devices)
+		r.Devices.Equal(&o.Devices) &&
+		r.IO.Equal(o.IO)
 }
 
 // ResourceDevices are part of Resources.
@@ -2645,6 +2891,7 @@ func (r *Resources) Copy() *Resources {
 		Networks:    r.Networks.Copy(),
 		Devices:     r.Devices.Copy(),
 		NUMA:        r.NUMA.Copy(),
+		IO:          r.IO.Copy(),
 	}
 }
 
@@ -3634,6 +3881,99 @@ func (n *NodeDeviceLocality) Copy() *NodeDeviceLocality {
 	return &nn
 }
 
+// NodeUtilization is the smoothed CPU and memory utilization of a node, as
+// measured by its client.
+type NodeUtilization struct {
+	// CPUPercent is the percentage of the CPU of the node in use.
+	CPUPercent float64
+
+	// MemoryPercent is the percentage of the memory of the node in use.
+	MemoryPercent float64
+
+	// UpdatedAt is the time at which the client measured the utilization.
+	UpdatedAt int64
+}
+
+func (u *NodeUtilization) Copy() *NodeUtilization {
+	if u == nil {
+		return nil
+	}
+
+	nu := *u
+	return &nu
+}
+
+func (u *NodeUtilization) Equal(o *NodeUtilization) bool {
+	if u == nil || o == nil {
+		return u == o
+	}
+	return *u == *o
+}
+
+// NodeUtilizationChangeThreshold is the change, in percentage points, of the
+// CPU or memory utilization of a node below which a new utilization is not
+// worth storing.
+const NodeUtilizationChangeThreshold = 5.0
+
+// ChangedSignificantly returns true if the CPU or memory utilization differs
+// from the previous utilization by at least NodeUtilizationChangeThreshold.
+func (u *NodeUtilization) ChangedSignificantly(prev *NodeUtilization) bool {
+	if u == nil || prev == nil {
+		return u != prev
+	}
+	return math.Abs(u.CPUPercent-prev.CPUPercent) >= NodeUtilizationChangeThreshold ||
+		math.Abs(u.MemoryPercent-prev.MemoryPercent) >= NodeUtilizationChangeThreshold
+}
+
+// NodePressure is the resource pressure of a node, as reported by its client
+// when it evicts allocations.
+type NodePressure struct {
+	// Memory is true if the host memory in use is above the eviction
+	// threshold of the client.
+	Memory bool
+
+	// Disk is true if the disk of the allocation directory in use is above
+	// the eviction threshold of the client.
+	Disk bool
+
+	// UpdatedAt is the time at which the client detected the pressure.
+	UpdatedAt int64
+}
+
+func (p *NodePressure) Copy() *NodePressure {
+	if p == nil {
+		return nil
+	}
+
+	np := *p
+	return &np
+}
+
+// Equal compares the resources under pressure, ignoring the time of update.
+func (p *NodePressure) Equal(o *NodePressure) bool {
+	return p.Pressured() == o.Pressured() &&
+		(!p.Pressured() || (p.Memory == o.Memory && p.Disk == o.Disk))
+}
+
+// Pressured returns true if any resource of the node is under pressure.
+func (p *NodePressure) Pressured() bool {
+	return p != nil && (p.Memory || p.Disk)
+}
+
+// String returns the resources under pressure, such as "memory, disk".
+func (p *NodePressure) String() string {
+	var resources []string
+	if p.Pressured() {
+		if p.Memory {
+			resources = append(resources, "memory")
+		}
+		if p.Disk {
+			resources = append(resources, "disk")
+		}
+	}
+	return strings.Join(resources, ", ")
+}
+
 // NodeReservedResources is used to capture the resources on a client node that
 // should be reserved and not made available to jobs.
 type NodeReservedResources struct {
@@ -3838,6 +4178,7 @@ type AllocatedTaskResources struct {
 	Memory   AllocatedMemoryResources
 	Networks Networks
 	Devices  []*AllocatedDeviceResource
+	IO       *IOResources
 }
 
 func (a *AllocatedTaskResources) Copy() *AllocatedTaskResources {
@@ -3859,6 +4200,8 @@ func (a *AllocatedTaskResources) Copy() *AllocatedTaskResources {
 		}
 	}
 
+	newA.IO = a.IO.Copy()
+
 	return newA
 }
 
@@ -4405,6 +4748,10 @@ type Job struct {
 	// allocations across a desired attribute, such as datacenter
 	Spreads []*Spread
 
+	// DependsOn is the set of jobs, in the same namespace, whose conditions
+	// must be met before the allocations of this job are placed
+	DependsOn []*JobDependency
+
 	// TaskGroups are the collections of task groups that this job needs
 	// to run. Each task group is an atomic unit of scheduling and placement.
 	TaskGroups []*TaskGroup
@@ -4464,6 +4811,10 @@ type Job struct {
 	// StatusDescription is meant to provide more human useful information
 	StatusDescription string
 
+	// CompletionFailed is set by the server when the job becomes dead because
+	// a task group exhausted the backoff limit of its completion policy.
+	CompletionFailed bool
+
 	// Stable marks a job as stable. Stability is only defined on "service" and
 	// "system" jobs. The stability of a job will be set automatically as part
 	// of a deployment and can be manually set via APIs. This field is updated
@@ -4528,6 +4879,108 @@ func (l *JobUILink) Copy() *JobUILink {
 	return copy
 }
 
+const (
+	// JobDependencyConditionComplete is met once all the allocations of the
+	// job are terminal.
+	JobDependencyConditionComplete = "complete"
+
+	// JobDependencyConditionSuccessful is met once all the allocations of
+	// the job have completed successfully.
+	JobDependencyConditionSuccessful = "successful"
+
+	// JobDependencyConditionHealthy is met once the job is running with all
+	// of its allocations healthy.
+	JobDependencyConditionHealthy = "healthy"
+)
+
+// JobDependency references a job, in the same namespace, whose condition must
+// be met before the allocations of the dependent job are placed.
+type JobDependency struct {
+	// JobID is the ID of the job depended on.
+	JobID string
+
+	// Condition is the condition of the job depended on.
+	Condition string
+}
+
+func (d *JobDependency) Copy() *JobDependency {
+	if d == nil {
+		return nil
+	}
+	nd := *d
+	return &nd
+}
+
+func (d *JobDependency) Validate() error {
+	var mErr multierror.Error
+	if d.JobID == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Missing job ID"))
+	}
+	switch d.Condition {
+	case JobDependencyConditionComplete, JobDependencyConditionSuccessful, JobDependencyConditionHealthy:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid condition %q, must be one of %q, %q or %q",
+			d.Condition, JobDependencyConditionComplete, JobDependencyConditionSuccessful,
+			JobDependencyConditionHealthy))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Met returns whether the condition of the dependency is met by the job it
+// references, given the allocations of that job and its latest deployment.
+// Stopped jobs never meet a dependency.
+func (d *JobDependency) Met(job *Job, allocs []*Allocation, deployment *Deployment) bool {
+	if job == nil || job.Stop {
+		return false
+	}
+
+	// Only consider the allocations of the current job version that have not
+	// been replaced.
+	current := make([]*Allocation, 0, len(allocs))
+	for _, alloc := range allocs {
+		if alloc.NextAllocation != "" || (alloc.Job != nil && alloc.Job.Version != job.Version) {
+			continue
+		}
+		current = append(current, alloc)
+	}
+
+	switch d.Condition {
+	case JobDependencyConditionComplete:
+		return job.Status == JobStatusDead
+	case JobDependencyConditionSuccessful:
+		if job.Status != JobStatusDead {
+			return false
+		}
+		for _, alloc := range current {
+			if alloc.ClientStatus != AllocClientStatusComplete {
+				return false
+			}
+		}
+		return true
+	case JobDependencyConditionHealthy:
+		if job.Status != JobStatusRunning {
+			return false
+		}
+		if deployment != nil && deployment.JobVersion == job.Version {
+			return deployment.Status == DeploymentStatusSuccessful
+		}
+
+		// Jobs without deployments are healthy once all their allocations
+		// are running.
+		expected, running := 0, 0
+		for _, tg := range job.TaskGroups {
+			expected += tg.Count
+		}
+		for _, alloc := range current {
+			if alloc.ClientStatus == AllocClientStatusRunning && !alloc.TerminalStatus() {
+				running++
+			}
+		}
+		return running >= expected
+	}
+	return false
+}
+
 // NamespacedID returns the namespaced id useful for logging
 func (j *Job) NamespacedID() NamespacedID {
 	return NamespacedID{
@@ -4597,6 +5050,10 @@ func (j *Job) Canonicalize() {
 		j.Spreads = nil
 	}
 
+	if len(j.DependsOn) == 0 {
+		j.DependsOn = nil
+	}
+
 	// Ensure the job is in a namespace.
 	if j.Namespace == "" {
 		j.Namespace = DefaultNamespace
@@ -4637,6 +5094,14 @@ func (j *Job) Copy() *Job {
 	nj.Multiregion = j.Multiregion.Copy()
 	nj.UI = j.UI.Copy()
 
+	if j.DependsOn != nil {
+		deps := make([]*JobDependency, len(j.DependsOn))
+		for i, dep := range j.DependsOn {
+			deps[i] = dep.Copy()
+		}
+		nj.DependsOn = deps
+	}
+
 	if j.TaskGroups != nil {
 		tgs := make([]*TaskGroup, len(j.TaskGroups))
 		for i, tg := range j.TaskGroups {
@@ -4726,6 +5191,26 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	if len(j.DependsOn) != 0 && j.Type != JobTypeService && j.Type != JobTypeBatch {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf(
+			"Job dependencies can only be used with %q or %q scheduler", JobTypeService, JobTypeBatch))
+	}
+	dependencies := make(map[string]struct{}, len(j.DependsOn))
+	for idx, dep := range j.DependsOn {
+		if err := dep.Validate(); err != nil {
+			outer := fmt.Errorf("Dependency %d validation failed: %s", idx+1, err)
+			mErr.Errors = append(mErr.Errors, outer)
+			continue
+		}
+		if dep.JobID == j.ID {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Dependency %d references the job itself", idx+1))
+		}
+		if _, ok := dependencies[dep.JobID]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Dependency %d redefines job %q", idx+1, dep.JobID))
+		}
+		dependencies[dep.JobID] = struct{}{}
+	}
+
 	const MaxDescriptionCharacters = 1000
 	if j.UI != nil {
 		if len(j.UI.Description) > MaxDescriptionCharacters {
@@ -4778,6 +5263,10 @@ func (j *Job) Validate() error {
 			mErr.Errors = append(mErr.Errors, outer)
 		}
 	}
+	if cycle := j.updateDependencyCycle(); len(cycle) != 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf(
+			"Task group update dependencies form a cycle: %s", strings.Join(cycle, " -> ")))
+	}
 
 	// Validate periodic is only used with batch or sysbatch jobs.
 	if j.IsPeriodic() && j.Periodic.Enabled {
@@ -4831,7 +5320,7 @@ func (j *Job) Warnings() error {
 			hasAutoPromote = hasAutoPromote || u.AutoPromote
 
 			// Having no canaries implies auto-promotion since there are no canaries to promote.
-			allAutoPromote = allAutoPromote && (u.Canary == 0 || u.AutoPromote)
+			allAutoPromote = allAutoPromote && (u.Canaries(tg.Count) == 0 || u.AutoPromote)
 		}
 	}
 
@@ -4863,6 +5352,121 @@ func (j *Job) LookupTaskGroup(name string) *TaskGroup {
 	return nil
 }
 
+// UpdateDependencies returns the task groups whose deployment must complete
+// before the given task group is updated.
+func (j *Job) UpdateDependencies(name string) []string {
+	tg := j.LookupTaskGroup(name)
+	if tg == nil || tg.Update == nil {
+		return nil
+	}
+	return tg.Update.DependsOn
+}
+
+// UpdateDependents returns the task groups whose update depends on the given
+// task group. They are the dependencies of the task group when a deployment is
+// rolled back.
+func (j *Job) UpdateDependents(name string) []string {
+	if j == nil {
+		return nil
+	}
+
+	var dependents []string
+	for _, tg := range j.TaskGroups {
+		if tg.Update != nil && slices.Contains(tg.Update.DependsOn, name) {
+			dependents = append(dependents, tg.Name)
+		}
+	}
+	return dependents
+}
+
+// updateDependencyCycle returns the task groups that form a cycle through
+// their update dependencies, if any.
+func (j *Job) updateDependencyCycle() []string {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(j.TaskGroups))
+
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			start := slices.Index(path, name)
+			return append(slices.Clone(path[start:]), name)
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range j.UpdateDependencies(name) {
+			if dep == name {
+				// Validated by the task group
+				continue
+			}
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, tg := range j.TaskGroups {
+		if cycle := visit(tg.Name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// HasCompletionPolicy returns whether any task group of a batch job has a
+// completion policy.
+func (j *Job) HasCompletionPolicy() bool {
+	if j.Type != JobTypeBatch {
+		return false
+	}
+	for _, tg := range j.TaskGroups {
+		if tg.Completion != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// FailedCompletionGroup returns the first task group of the job whose
+// completion policy backoff limit is exhausted by the failed allocations, or
+// nil if there is none. Terminal allocations from older versions of the job
+// are not counted.
+func (j *Job) FailedCompletionGroup(allocs []*Allocation) *TaskGroup {
+	failed := make(map[string]int)
+	for _, alloc := range allocs {
+		if j.CompletionFailure(alloc) {
+			failed[alloc.TaskGroup]++
+		}
+	}
+
+	for _, tg := range j.TaskGroups {
+		if tg.Completion != nil && tg.Completion.Exhausted(failed[tg.Name]) {
+			return tg
+		}
+	}
+	return nil
+}
+
+// CompletionFailure returns whether the allocation failed while running the
+// current version of the job, which counts toward the backoff limit of the
+// completion policy of its task group.
+func (j *Job) CompletionFailure(alloc *Allocation) bool {
+	if alloc.ClientStatus != AllocClientStatusFailed || alloc.Job == nil {
+		return false
+	}
+	return alloc.Job.Version >= j.Version && alloc.Job.CreateIndex >= j.CreateIndex
+}
+
 // CombinedTaskMeta takes a TaskGroup and Task name and returns the combined
 // meta data for the task. When joining Job, Group and Task Meta, the precedence
 // is by deepest scope (Task > Group > Job).
@@ -4905,6 +5509,17 @@ func (j *Job) Stopped() bool {
 	return j == nil || j.Stop
 }
 
+// HasGangTaskGroups returns if any task group in the job requires gang
+// scheduling.
+func (j *Job) HasGangTaskGroups() bool {
+	for _, tg := range j.TaskGroups {
+		if tg.Gang {
+			return true
+		}
+	}
+	return false
+}
+
 // HasUpdateStrategy returns if any task group in the job has an update strategy
 func (j *Job) HasUpdateStrategy() bool {
 	for _, tg := range j.TaskGroups {
@@ -5104,6 +5719,7 @@ func (j *Job) SpecChanged(new *Job) bool {
 	// Update the new job so we can do a reflect
 	c.Status = j.Status
 	c.StatusDescription = j.StatusDescription
+	c.CompletionFailed = j.CompletionFailed
 	c.Stable = j.Stable
 	c.Version = j.Version
 	c.CreateIndex = j.CreateIndex
@@ -5206,6 +5822,26 @@ type TaskGroupSummary struct {
 	Starting int
 	Lost     int
 	Unknown  int
+
+	// ArraySucceeded and ArrayFailed are the indices of an array task group
+	// whose latest allocation completed successfully or failed. An index
+	// that succeeded once is never reported as failed.
+	ArraySucceeded ArrayIndices
+	ArrayFailed    ArrayIndices
+}
+
+// UpdateArrayIndex records the client status of the allocation for the given
+// array index.
+func (s *TaskGroupSummary) UpdateArrayIndex(index int, clientStatus string) {
+	switch clientStatus {
+	case AllocClientStatusComplete:
+		s.ArraySucceeded = s.ArraySucceeded.Add(index)
+		s.ArrayFailed = s.ArrayFailed.Remove(index)
+	case AllocClientStatusFailed:
+		if !s.ArraySucceeded.Contains(index) {
+			s.ArrayFailed = s.ArrayFailed.Add(index)
+		}
+	}
 }
 
 const (
@@ -5223,6 +5859,16 @@ const (
 	UpdateStrategyHealthCheck_Manual = "manual"
 )
 
+const (
+	// UpdateStrategyRolling replaces the allocations of a task group
+	// MaxParallel at a time, after promoting the canaries if any.
+	UpdateStrategyRolling = "rolling"
+
+	// UpdateStrategyBlueGreen places a full new set of allocations as
+	// canaries and switches to them at once when the deployment is promoted.
+	UpdateStrategyBlueGreen = "blue_green"
+)
+
 var (
 	// DefaultUpdateStrategy provides a baseline that can be used to upgrade
 	// jobs with the old policy or for populating field defaults.
@@ -5236,6 +5882,7 @@ var (
 		AutoRevert:       false,
 		AutoPromote:      false,
 		Canary:           0,
+		CanaryPercent:    0,
 	}
 )
 
@@ -5278,8 +5925,31 @@ type UpdateStrategy struct {
 	AutoPromote bool
 
 	// Canary is the number of canaries to deploy when a change to the task
-	// group is detected.
+	// group is detected. It is ignored by system jobs.
 	Canary int
+
+	// CanaryPercent is the percentage of the nodes, rounded up, that run
+	// canaries when a change to the task group of a system job is detected.
+	// Setting it tracks the updates of the task group by a deployment.
+	CanaryPercent int
+
+	// Analysis configures the steps at which the deployment pauses to run
+	// analysis gates.
+	Analysis *AnalysisStrategy
+
+	// Strategy is how the allocations of the task group are replaced, either
+	// rolling or blue_green. An empty strategy is a rolling one.
+	Strategy string
+
+	// HoldPeriod is how long the previous allocations of a blue/green
+	// deployment keep running once it is promoted, so the deployment can be
+	// rolled back instantly by failing it.
+	HoldPeriod time.Duration
+
+	// DependsOn are the task groups whose deployment must complete before
+	// the task group is updated. Rollbacks update the task groups in reverse
+	// order.
+	DependsOn []string
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -5289,6 +5959,8 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 
 	c := new(UpdateStrategy)
 	*c = *u
+	c.Analysis = u.Analysis.Copy()
+	c.DependsOn = slices.Clone(u.DependsOn)
 	return c
 }
 
@@ -5310,9 +5982,27 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Canary < 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Canary count can not be less than zero: %d < 0", u.Canary))
 	}
-	if u.Canary == 0 && u.AutoPromote {
+	if u.CanaryPercent < 0 || u.CanaryPercent > 100 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Canary percent must be between 0 and 100: %d", u.CanaryPercent))
+	}
+	if u.Canary == 0 && u.CanaryPercent == 0 && u.AutoPromote && !u.BlueGreen() {
 		_ = multierror.Append(&mErr, fmt.Errorf("Auto Promote requires a Canary count greater than zero"))
 	}
+	switch u.Strategy {
+	case "", UpdateStrategyRolling:
+		if u.HoldPeriod != 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Hold period requires the %q strategy", UpdateStrategyBlueGreen))
+		}
+	case UpdateStrategyBlueGreen:
+		if u.Canary != 0 || u.CanaryPercent != 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Canary count can not be set with the %q strategy", UpdateStrategyBlueGreen))
+		}
+		if u.HoldPeriod < 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Hold period must be zero or greater: %v", u.HoldPeriod))
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid strategy given: %q", u.Strategy))
+	}
 	if u.MinHealthyTime < 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Minimum healthy time may not be less than zero: %v", u.MinHealthyTime))
 	}
@@ -5331,6 +6021,9 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Stagger <= 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Stagger must be greater than zero: %v", u.Stagger))
 	}
+	if err := u.Analysis.Validate(); err != nil {
+		_ = multierror.Append(&mErr, err)
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -5349,6 +6042,24 @@ func (u *UpdateStrategy) IsEmpty() bool {
 	return u.MaxParallel == 0
 }
 
+// BlueGreen returns whether the task group is updated with a blue/green
+// deployment.
+func (u *UpdateStrategy) BlueGreen() bool {
+	return u != nil && u.Strategy == UpdateStrategyBlueGreen
+}
+
+// Canaries returns the number of canaries to deploy for a task group with the
+// given count. Blue/green deployments replace every allocation with a canary.
+func (u *UpdateStrategy) Canaries(count int) int {
+	if u == nil {
+		return 0
+	}
+	if u.BlueGreen() {
+		return count
+	}
+	return u.Canary
+}
+
 // Rolling returns if a rolling strategy should be used.
 // TODO(alexdadgar): Remove once no longer used by the scheduler.
 func (u *UpdateStrategy) Rolling() bool {
@@ -5427,6 +6138,11 @@ type Namespace struct {
 	// against.
 	Quota string
 
+	// EvalQueueWeight is the relative share of the eval broker given to the
+	// namespace when evaluations of the same priority from several namespaces
+	// are ready to be processed. Defaults to 1 if unset.
+	EvalQueueWeight int
+
 	// Capabilities is the set of capabilities allowed for this namespace
 	Capabilities *NamespaceCapabilities
 
@@ -5490,6 +6206,10 @@ func (n *Namespace) Validate() error {
 		err := fmt.Errorf("description longer than %d", maxNamespaceDescriptionLength)
 		mErr.Errors = append(mErr.Errors, err)
 	}
+	if n.EvalQueueWeight < 0 || n.EvalQueueWeight > maxNamespaceEvalQueueWeight {
+		err := fmt.Errorf("eval queue weight must be between 0 and %d", maxNamespaceEvalQueueWeight)
+		mErr.Errors = append(mErr.Errors, err)
+	}
 
 	err := n.NodePoolConfiguration.Validate()
 	switch e := err.(type) {
@@ -5536,6 +6256,9 @@ func (n *Namespace) SetHash() []byte {
 	_, _ = hash.Write([]byte(n.Name))
 	_, _ = hash.Write([]byte(n.Description))
 	_, _ = hash.Write([]byte(n.Quota))
+	if n.EvalQueueWeight != 0 {
+		_, _ = hash.Write([]byte(strconv.Itoa(n.EvalQueueWeight)))
+	}
 	if n.Capabilities != nil {
 		for _, driver := range n.Capabilities.EnabledTaskDrivers {
 			_, _ = hash.Write([]byte(driver))
@@ -5690,6 +6413,38 @@ const (
 	PeriodicSpecTest = "_internal_test"
 )
 
+const (
+	// PeriodicCatchUpLatest launches the most recent of the launches missed
+	// while the cluster had no leader. It is the default.
+	PeriodicCatchUpLatest = "latest"
+
+	// PeriodicCatchUpAll launches all the launches missed while the cluster
+	// had no leader, in order.
+	PeriodicCatchUpAll = "all"
+
+	// PeriodicCatchUpNone skips the launches missed while the cluster had no
+	// leader.
+	PeriodicCatchUpNone = "none"
+
+	// PeriodicMaxCatchUpLaunches is the maximum number of missed launches
+	// that are launched late. Only the most recent ones are launched.
+	PeriodicMaxCatchUpLaunches = 100
+)
+
+const (
+	// PeriodicConcurrencyAllow launches a periodic job even if children of
+	// previous launches are still running. It is the default.
+	PeriodicConcurrencyAllow = "allow"
+
+	// PeriodicConcurrencyForbid skips the launches that occur while children
+	// of previous launches are still running, like ProhibitOverlap.
+	PeriodicConcurrencyForbid = "forbid"
+
+	// PeriodicConcurrencyReplace stops the children of previous launches that
+	// are still running before launching the periodic job.
+	PeriodicConcurrencyReplace = "replace"
+)
+
 // Periodic defines the interval a job should be run at.
 type PeriodicConfig struct {
 	// Enabled determines if the job should be run periodically.
@@ -5709,6 +6464,24 @@ type PeriodicConfig struct {
 	// ProhibitOverlap enforces that spawned jobs do not run in parallel.
 	ProhibitOverlap bool
 
+	// ConcurrencyPolicy determines what happens when the job is launched
+	// while children of previous launches are still running.
+	ConcurrencyPolicy string
+
+	// StartingDeadline is how late a launch can occur, for instance because
+	// the cluster had no leader at the launch time. Launches that are late by
+	// more are skipped. Zero means there is no deadline.
+	StartingDeadline time.Duration
+
+	// CatchUp determines which of the launches missed while the cluster had
+	// no leader occur once a leader is elected.
+	CatchUp string
+
+	// HistoryLimit is the number of dead child jobs to keep. Older dead
+	// children are purged when the job is launched. Zero means there is no
+	// limit.
+	HistoryLimit int
+
 	// TimeZone is the user specified string that determines the time zone to
 	// launch against. The time zones must be specified from IANA Time Zone
 	// database, such as "America/New_York".
@@ -5770,9 +6543,114 @@ func (p *PeriodicConfig) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Unknown periodic specification type %q", p.SpecType))
 	}
 
+	switch p.ConcurrencyPolicy {
+	case "", PeriodicConcurrencyForbid:
+	case PeriodicConcurrencyAllow, PeriodicConcurrencyReplace:
+		if p.ProhibitOverlap {
+			_ = multierror.Append(&mErr, fmt.Errorf("Concurrency policy %q can't be used with prohibit overlap", p.ConcurrencyPolicy))
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Unknown concurrency policy %q", p.ConcurrencyPolicy))
+	}
+
+	switch p.CatchUp {
+	case "", PeriodicCatchUpLatest, PeriodicCatchUpAll, PeriodicCatchUpNone:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Unknown catch up policy %q", p.CatchUp))
+	}
+
+	if p.StartingDeadline < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Starting deadline must be >= 0 but found %v", p.StartingDeadline))
+	}
+	if p.HistoryLimit < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("History limit must be >= 0 but found %d", p.HistoryLimit))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
+// ProhibitsOverlap returns whether launches are skipped while children of
+// previous launches are still running.
+func (p *PeriodicConfig) ProhibitsOverlap() bool {
+	return p.ProhibitOverlap || p.ConcurrencyPolicy == PeriodicConcurrencyForbid
+}
+
+// Late returns whether a launch at the given time is later than the starting
+// deadline allows.
+func (p *PeriodicConfig) Late(launch, now time.Time) bool {
+	return p.StartingDeadline > 0 && now.Sub(launch) > p.StartingDeadline
+}
+
+// MissedLaunches returns the launches after the last launch and before now
+// that should occur late, in order, according to the catch up policy, the
+// concurrency policy and the starting deadline.
+func (p *PeriodicConfig) MissedLaunches(last, now time.Time) ([]time.Time, error) {
+	if p.CatchUp == PeriodicCatchUpNone {
+		return nil, nil
+	}
+
+	// Only the most recent launch occurs unless all of them are caught up,
+	// which is only possible for jobs that allow overlapping launches.
+	limit := 1
+	if p.CatchUp == PeriodicCatchUpAll && !p.ProhibitsOverlap() &&
+		p.ConcurrencyPolicy != PeriodicConcurrencyReplace {
+		limit = PeriodicMaxCatchUpLaunches
+	}
+
+	// Walk back from now rather than forward from the last launch, so that
+	// long gaps between the last launch and now don't enumerate every missed
+	// launch.
+	var missed []time.Time
+	for before := now; len(missed) < limit; {
+		launch, err := p.prev(last, before)
+		if err != nil {
+			return nil, err
+		}
+		if launch.IsZero() || p.Late(launch, now) {
+			break
+		}
+		missed = append(missed, launch)
+		before = launch
+	}
+
+	slices.Reverse(missed)
+	return missed, nil
+}
+
+// prev returns the latest launch after the after time and before the before
+// time, or the zero time if there is none. Since Next is monotonic, it is
+// found by bisecting the time between after and before for the latest time
+// whose next launch is before the before time, which takes at most a few
+// dozens of calls to Next.
+func (p *PeriodicConfig) prev(after, before time.Time) (time.Time, error) {
+	launchesBefore := func(t time.Time) (time.Time, bool, error) {
+		next, err := p.Next(t)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		return next, !next.IsZero() && next.Before(before), nil
+	}
+
+	if _, ok, err := launchesBefore(after); err != nil || !ok {
+		return time.Time{}, err
+	}
+	lo, hi := after, before
+	for d := hi.Sub(lo); d > 1; d = hi.Sub(lo) {
+		mid := lo.Add(d / 2)
+		_, ok, err := launchesBefore(mid)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	launch, _, err := launchesBefore(lo)
+	return launch, err
+}
+
 func (p *PeriodicConfig) Canonicalize() {
 	// Load the location
 	l, err := time.LoadLocation(p.TimeZone)
@@ -6019,6 +6897,7 @@ var (
 
 	DefaultServiceJobRestartPolicy = RestartPolicy{
 		Delay:           15 * time.Second,
+		DelayFunction:   "constant",
 		Attempts:        2,
 		Interval:        30 * time.Minute,
 		Mode:            RestartPolicyModeFail,
@@ -6026,6 +6905,7 @@ var (
 	}
 	DefaultBatchJobRestartPolicy = RestartPolicy{
 		Delay:           15 * time.Second,
+		DelayFunction:   "constant",
 		Attempts:        3,
 		Interval:        24 * time.Hour,
 		Mode:            RestartPolicyModeFail,
@@ -6359,6 +7239,14 @@ type RestartPolicy struct {
 	// Delay is the time between a failure and a restart.
 	Delay time.Duration
 
+	// DelayFunction determines how the delay progressively changes on
+	// subsequent restarts within an interval. Valid values are "constant",
+	// "exponential" and "fibonacci".
+	DelayFunction string
+
+	// MaxDelay is an upper bound on the delay.
+	MaxDelay time.Duration
+
 	// Mode controls what happens when the task restarts more than attempt times
 	// in an interval.
 	Mode string
@@ -6396,6 +7284,14 @@ func (r *RestartPolicy) Validate() error {
 		_ = multierror.Append(&mErr,
 			fmt.Errorf("Nomad can't restart the TaskGroup %v times in an interval of %v with a delay of %v", r.Attempts, r.Interval, r.Delay))
 	}
+
+	// An empty delay function is the constant delay of older jobs
+	if r.DelayFunction != "" && !isValidDelayFunction(r.DelayFunction) {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid delay function %q, must be one of %q", r.DelayFunction, RescheduleDelayFunctions))
+	}
+	if r.DelayFunction != "" && r.DelayFunction != "constant" && r.MaxDelay < r.Delay {
+		_ = multierror.Append(&mErr, fmt.Errorf("Max Delay cannot be less than Delay %v (got %v)", r.Delay, r.MaxDelay))
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -6438,6 +7334,10 @@ type ReschedulePolicy struct {
 	// Unlimited allows infinite rescheduling attempts. Only allowed when delay is set
 	// between reschedule attempts.
 	Unlimited bool
+
+	// OnMaxRunDuration allows rescheduling allocations that failed because a
+	// task exceeded its max run duration.
+	OnMaxRunDuration bool
 }
 
 func (r *ReschedulePolicy) Copy() *ReschedulePolicy {
@@ -6677,7 +7577,281 @@ func (m *MigrateStrategy) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("MinHealthyTime must be less than HealthyDeadline"))
 	}
 
-	return mErr.ErrorOrNil()
+	return mErr.ErrorOrNil()
+}
+
+// DisruptionBudget bounds the number of allocations of a task group that can
+// be voluntarily disrupted at the same time, such as by node drains or
+// preemption. Exactly one of MaxUnavailable or MinAvailable must be set.
+type DisruptionBudget struct {
+	// MaxUnavailable is the maximum number of allocations of the task group
+	// that can be unavailable before no more disruptions are allowed.
+	MaxUnavailable int
+
+	// MinAvailable is the minimum number of allocations of the task group
+	// that must remain available.
+	MinAvailable int
+}
+
+func (d *DisruptionBudget) Copy() *DisruptionBudget {
+	if d == nil {
+		return nil
+	}
+	nd := new(DisruptionBudget)
+	*nd = *d
+	return nd
+}
+
+func (d *DisruptionBudget) Equal(o *DisruptionBudget) bool {
+	if d == nil || o == nil {
+		return d == o
+	}
+	return *d == *o
+}
+
+func (d *DisruptionBudget) Validate() error {
+	var mErr multierror.Error
+
+	if d.MaxUnavailable < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("MaxUnavailable must be >= 0 but found %d", d.MaxUnavailable))
+	}
+	if d.MinAvailable < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("MinAvailable must be >= 0 but found %d", d.MinAvailable))
+	}
+
+	switch {
+	case d.MaxUnavailable > 0 && d.MinAvailable > 0:
+		_ = multierror.Append(&mErr, fmt.Errorf("Only one of MaxUnavailable or MinAvailable can be set"))
+	case d.MaxUnavailable == 0 && d.MinAvailable == 0:
+		_ = multierror.Append(&mErr, fmt.Errorf("One of MaxUnavailable or MinAvailable must be set"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// AllowedDisruptions returns how many more allocations of a task group with
+// the given count can be disrupted when available of them are currently
+// available. The returned value is never negative.
+func (d *DisruptionBudget) AllowedDisruptions(count, available int) int {
+	var allowed int
+	if d.MaxUnavailable > 0 {
+		allowed = d.MaxUnavailable - (count - available)
+	} else {
+		allowed = available - d.MinAvailable
+	}
+	return max(allowed, 0)
+}
+
+// ArrayConfig configures a batch task group to run one allocation for each
+// index of a range, instead of Count identical allocations. The index of an
+// allocation is exposed to its tasks as NOMAD_ARRAY_INDEX.
+type ArrayConfig struct {
+	// Start and End are the first and last index of the array, inclusive.
+	Start int
+	End   int
+
+	// MaxParallel is the maximum number of allocations of the array that
+	// can run at the same time. Zero means there is no limit.
+	MaxParallel int
+}
+
+func (a *ArrayConfig) Copy() *ArrayConfig {
+	if a == nil {
+		return nil
+	}
+	na := new(ArrayConfig)
+	*na = *a
+	return na
+}
+
+func (a *ArrayConfig) Equal(o *ArrayConfig) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	return *a == *o
+}
+
+// Size returns the number of indices of the array.
+func (a *ArrayConfig) Size() int {
+	return a.End - a.Start + 1
+}
+
+func (a *ArrayConfig) Validate() error {
+	var mErr multierror.Error
+
+	if a.Start < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Start must be >= 0 but found %d", a.Start))
+	}
+	if a.End < a.Start {
+		_ = multierror.Append(&mErr, fmt.Errorf("End must be >= Start but found %d < %d", a.End, a.Start))
+	}
+	if a.MaxParallel < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("MaxParallel must be >= 0 but found %d", a.MaxParallel))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// CompletionPolicy configures a batch task group to keep placing allocations
+// until a number of them complete successfully, instead of running Count
+// allocations once. Failed allocations are replaced until more of them failed
+// than the backoff limit allows, at which point the task group and its job
+// are failed.
+type CompletionPolicy struct {
+	// Completions is the number of allocations that must complete
+	// successfully.
+	Completions int
+
+	// Parallelism is the maximum number of allocations that can run at the
+	// same time. Zero means there is no limit.
+	Parallelism int
+
+	// BackoffLimit is the number of failed allocations tolerated before the
+	// task group is failed.
+	BackoffLimit int
+}
+
+func (c *CompletionPolicy) Copy() *CompletionPolicy {
+	if c == nil {
+		return nil
+	}
+	nc := new(CompletionPolicy)
+	*nc = *c
+	return nc
+}
+
+func (c *CompletionPolicy) Equal(o *CompletionPolicy) bool {
+	if c == nil || o == nil {
+		return c == o
+	}
+	return *c == *o
+}
+
+// Exhausted returns whether the number of failed allocations exceeds the
+// backoff limit.
+func (c *CompletionPolicy) Exhausted(failed int) bool {
+	return failed > c.BackoffLimit
+}
+
+func (c *CompletionPolicy) Validate() error {
+	var mErr multierror.Error
+
+	if c.Completions < 1 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Completions must be >= 1 but found %d", c.Completions))
+	}
+	if c.Parallelism < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Parallelism must be >= 0 but found %d", c.Parallelism))
+	}
+	if c.BackoffLimit < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("BackoffLimit must be >= 0 but found %d", c.BackoffLimit))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// ArrayIndexRange is an inclusive range of array indices.
+type ArrayIndexRange struct {
+	Start int
+	End   int
+}
+
+// ArrayIndices is a set of array indices stored as sorted, non-overlapping
+// and non-adjacent ranges, so that the set stays small for large arrays. The
+// methods that modify the set return a new set and never modify the receiver,
+// which allows sets to be shared between copies of a job summary.
+type ArrayIndices []ArrayIndexRange
+
+// Contains returns whether the index is in the set.
+func (a ArrayIndices) Contains(i int) bool {
+	_, found := slices.BinarySearchFunc(a, i, func(r ArrayIndexRange, i int) int {
+		switch {
+		case r.End < i:
+			return -1
+		case r.Start > i:
+			return 1
+		}
+		return 0
+	})
+	return found
+}
+
+// Len returns the number of indices in the set.
+func (a ArrayIndices) Len() int {
+	n := 0
+	for _, r := range a {
+		n += r.End - r.Start + 1
+	}
+	return n
+}
+
+// Add returns the set with the index added.
+func (a ArrayIndices) Add(i int) ArrayIndices {
+	if a.Contains(i) {
+		return a
+	}
+
+	out := make(ArrayIndices, 0, len(a)+1)
+	added := false
+	for _, r := range a {
+		if !added && i < r.Start {
+			out = out.appendRange(ArrayIndexRange{Start: i, End: i})
+			added = true
+		}
+		out = out.appendRange(r)
+	}
+	if !added {
+		out = out.appendRange(ArrayIndexRange{Start: i, End: i})
+	}
+	return out
+}
+
+// appendRange appends a range that starts after the ranges of the set,
+// merging it with the last range if they are adjacent.
+func (a ArrayIndices) appendRange(r ArrayIndexRange) ArrayIndices {
+	if n := len(a); n > 0 && a[n-1].End+1 >= r.Start {
+		a[n-1].End = max(a[n-1].End, r.End)
+		return a
+	}
+	return append(a, r)
+}
+
+// Remove returns the set with the index removed.
+func (a ArrayIndices) Remove(i int) ArrayIndices {
+	if !a.Contains(i) {
+		return a
+	}
+
+	out := make(ArrayIndices, 0, len(a)+1)
+	for _, r := range a {
+		if i < r.Start || i > r.End {
+			out = append(out, r)
+			continue
+		}
+		if r.Start < i {
+			out = append(out, ArrayIndexRange{Start: r.Start, End: i - 1})
+		}
+		if i < r.End {
+			out = append(out, ArrayIndexRange{Start: i + 1, End: r.End})
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// String returns the set as a comma separated list of ranges, such as
+// "0-4,7,9-12".
+func (a ArrayIndices) String() string {
+	parts := make([]string, 0, len(a))
+	for _, r := range a {
+		if r.Start == r.End {
+			parts = append(parts, strconv.Itoa(r.Start))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", r.Start, r.End))
+		}
+	}
+	return strings.Join(parts, ",")
 }
 
 // TaskGroup is an atomic unit of placement. Each task group belongs to
@@ -6697,6 +7871,10 @@ type TaskGroup struct {
 	// Migrate is used to control the migration strategy for this task group
 	Migrate *MigrateStrategy
 
+	// DisruptionBudget bounds the number of allocations of this task group
+	// that can be voluntarily disrupted at the same time
+	DisruptionBudget *DisruptionBudget
+
 	// Constraints can be specified at a task group level and apply to
 	// all the tasks contained.
 	Constraints []*Constraint
@@ -6733,6 +7911,20 @@ type TaskGroup struct {
 	// allocations across a desired attribute, such as datacenter
 	Spreads []*Spread
 
+	// TopologySpreads can be specified at the task group level to bound the
+	// difference in the number of allocations placed across the topology
+	// domains formed by one or more node attributes.
+	TopologySpreads []*TopologySpread
+
+	// AllocationAffinities can be specified at the task group level to
+	// co-locate allocations with, or keep them away from, the allocations
+	// of other jobs.
+	AllocationAffinities []*AllocationAffinity
+
+	// Tolerations allow the task group to be placed on, and keep running
+	// on, nodes with matching taints.
+	Tolerations []*Toleration
+
 	// Networks are the network configuration for the task group. This can be
 	// overridden in the task.
 	Networks Networks
@@ -6765,6 +7957,26 @@ type TaskGroup struct {
 	// To be deprecated after 1.8.0
 	// To be deprecated after 1.8.0 infavor of Disconnect.Replace
 	PreventRescheduleOnLost bool
+
+	// Gang, if set, requires that all the allocations of the task group are
+	// placed together. The scheduler will not submit a plan that places only
+	// some of the group's allocations and instead blocks the evaluation until
+	// the whole group fits.
+	Gang bool
+
+	// Array, if set, runs one allocation of the batch task group for each
+	// index of a range. The count of the task group is the size of the
+	// array.
+	Array *ArrayConfig
+
+	// Completion, if set, keeps placing allocations of the batch task group
+	// until enough of them complete successfully. The count of the task
+	// group is the number of completions.
+	Completion *CompletionPolicy
+
+	// MaxRunDuration is the default max run duration of the tasks of the
+	// group that don't set their own.
+	MaxRunDuration time.Duration
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -6774,12 +7986,18 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg := new(TaskGroup)
 	*ntg = *tg
 	ntg.Update = ntg.Update.Copy()
+	ntg.DisruptionBudget = ntg.DisruptionBudget.Copy()
+	ntg.Array = ntg.Array.Copy()
+	ntg.Completion = ntg.Completion.Copy()
 	ntg.Constraints = CopySliceConstraints(ntg.Constraints)
 	ntg.RestartPolicy = ntg.RestartPolicy.Copy()
 	ntg.Disconnect = ntg.Disconnect.Copy()
 	ntg.ReschedulePolicy = ntg.ReschedulePolicy.Copy()
 	ntg.Affinities = CopySliceAffinities(ntg.Affinities)
 	ntg.Spreads = CopySliceSpreads(ntg.Spreads)
+	ntg.TopologySpreads = CopySliceTopologySpreads(ntg.TopologySpreads)
+	ntg.AllocationAffinities = CopySliceAllocationAffinities(ntg.AllocationAffinities)
+	ntg.Tolerations = helper.CopySlice(ntg.Tolerations)
 	ntg.Volumes = CopyMapVolumeRequest(ntg.Volumes)
 	ntg.Scaling = ntg.Scaling.Copy()
 	ntg.Consul = ntg.Consul.Copy()
@@ -6837,6 +8055,16 @@ func (tg *TaskGroup) Canonicalize(job *Job) {
 		tg.Meta = nil
 	}
 
+	// Array task groups run one allocation per index
+	if tg.Array != nil {
+		tg.Count = tg.Array.Size()
+	}
+
+	// Completion task groups run until their completions succeed
+	if tg.Completion != nil {
+		tg.Count = tg.Completion.Completions
+	}
+
 	if len(tg.Constraints) == 0 {
 		tg.Constraints = nil
 	}
@@ -6849,6 +8077,30 @@ func (tg *TaskGroup) Canonicalize(job *Job) {
 		tg.Spreads = nil
 	}
 
+	if len(tg.TopologySpreads) == 0 {
+		tg.TopologySpreads = nil
+	}
+
+	for _, ts := range tg.TopologySpreads {
+		ts.Canonicalize()
+	}
+
+	if len(tg.AllocationAffinities) == 0 {
+		tg.AllocationAffinities = nil
+	}
+
+	for _, aa := range tg.AllocationAffinities {
+		aa.Canonicalize()
+	}
+
+	if len(tg.Tolerations) == 0 {
+		tg.Tolerations = nil
+	}
+
+	for _, t := range tg.Tolerations {
+		t.Canonicalize()
+	}
+
 	// Set the default restart policy.
 	if tg.RestartPolicy == nil {
 		tg.RestartPolicy = NewRestartPolicy(job.Type)
@@ -6963,6 +8215,41 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr = multierror.Append(mErr, errors.New("max_client_disconnect cannot be negative"))
 	}
 
+	if tg.Gang && j.Type != JobTypeBatch {
+		mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow gang scheduling", j.Type))
+	}
+
+	if tg.Array != nil {
+		if j.Type != JobTypeBatch {
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow array task groups", j.Type))
+		}
+		if tg.Scaling != nil {
+			mErr = multierror.Append(mErr, errors.New("Array task groups cannot have a scaling policy"))
+		}
+		if err := tg.Array.Validate(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Array: %v", err))
+		} else if tg.Count != tg.Array.Size() {
+			mErr = multierror.Append(mErr, fmt.Errorf("Array task group count must be %d but found %d", tg.Array.Size(), tg.Count))
+		}
+	}
+
+	if tg.Completion != nil {
+		if j.Type != JobTypeBatch {
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow completion policies", j.Type))
+		}
+		if tg.Array != nil {
+			mErr = multierror.Append(mErr, errors.New("Array task groups cannot have a completion policy"))
+		}
+		if tg.Scaling != nil {
+			mErr = multierror.Append(mErr, errors.New("Completion task groups cannot have a scaling policy"))
+		}
+		if err := tg.Completion.Validate(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Completion: %v", err))
+		} else if tg.Count != tg.Completion.Completions {
+			mErr = multierror.Append(mErr, fmt.Errorf("Completion task group count must be %d but found %d", tg.Completion.Completions, tg.Count))
+		}
+	}
+
 	if tg.Disconnect != nil {
 		if tg.MaxClientDisconnect != nil && tg.Disconnect.LostAfter > 0 {
 			return multierror.Append(mErr, errors.New("using both lost_after and max_client_disconnect is not allowed"))
@@ -7021,6 +8308,39 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	if j.Type == JobTypeSystem || j.Type == JobTypeSysBatch {
+		if tg.TopologySpreads != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow topology_spread block", j.Type))
+		}
+	} else {
+		for idx, ts := range tg.TopologySpreads {
+			if err := ts.Validate(); err != nil {
+				outer := fmt.Errorf("Topology spread %d validation failed: %s", idx+1, err)
+				mErr = multierror.Append(mErr, outer)
+			}
+		}
+	}
+
+	if j.Type == JobTypeSystem || j.Type == JobTypeSysBatch {
+		if tg.AllocationAffinities != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow allocation_affinity block", j.Type))
+		}
+	} else {
+		for idx, aa := range tg.AllocationAffinities {
+			if err := aa.Validate(); err != nil {
+				outer := fmt.Errorf("Allocation affinity %d validation failed: %s", idx+1, err)
+				mErr = multierror.Append(mErr, outer)
+			}
+		}
+	}
+
+	for idx, t := range tg.Tolerations {
+		if err := t.Validate(); err != nil {
+			outer := fmt.Errorf("Toleration %d validation failed: %s", idx+1, err)
+			mErr = multierror.Append(mErr, outer)
+		}
+	}
+
 	if j.Type == JobTypeSystem {
 		if tg.ReschedulePolicy != nil {
 			mErr = multierror.Append(mErr, fmt.Errorf("System jobs should not have a reschedule policy"))
@@ -7053,6 +8373,45 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		if err := u.Validate(); err != nil {
 			mErr = multierror.Append(mErr, err)
 		}
+
+		if j.Type != JobTypeSystem && u.CanaryPercent != 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow canary percent", j.Type))
+		}
+		if j.Type == JobTypeSystem && u.BlueGreen() {
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow the %q strategy", j.Type, UpdateStrategyBlueGreen))
+		}
+
+		// System jobs only track their updates by a deployment, which
+		// reverting them requires, when they run canaries or analysis
+		if j.Type == JobTypeSystem && u.AutoRevert && u.CanaryPercent == 0 && u.Analysis == nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow auto revert without canary percent or analysis", j.Type))
+		}
+
+		// Task groups can only depend on the other task groups of the job
+		if j.Type == JobTypeSystem && len(u.DependsOn) != 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow update dependencies", j.Type))
+		}
+		for _, dep := range u.DependsOn {
+			if dep == tg.Name {
+				mErr = multierror.Append(mErr, errors.New("Update can not depend on its own task group"))
+			} else if j.LookupTaskGroup(dep) == nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("Update depends on unknown task group %q", dep))
+			}
+		}
+
+		// Action gates run actions of the tasks of the group
+		if u.Analysis != nil {
+			for _, gate := range u.Analysis.Gates {
+				if gate.Type != AnalysisGateTypeAction || gate.Task == "" {
+					continue
+				}
+				if task := tg.LookupTask(gate.Task); task == nil {
+					mErr = multierror.Append(mErr, fmt.Errorf("Analysis gate %q references unknown task %q", gate.Name, gate.Task))
+				} else if task.GetAction(gate.Action) == nil {
+					mErr = multierror.Append(mErr, fmt.Errorf("Analysis gate %q references unknown action %q of task %q", gate.Name, gate.Action, gate.Task))
+				}
+			}
+		}
 	}
 
 	// Validate the migration strategy
@@ -7069,6 +8428,13 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	// Validate the disruption budget
+	if tg.DisruptionBudget != nil {
+		if err := tg.DisruptionBudget.Validate(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("Disruption budget: %v", err))
+		}
+	}
+
 	// Check that there is only one leader task if any
 	tasks := make(map[string]int)
 	leaderTasks := 0
@@ -7093,7 +8459,7 @@ func (tg *TaskGroup) Validate(j *Job) error {
 	// Validate the volume requests
 	var canaries int
 	if tg.Update != nil {
-		canaries = tg.Update.Canary
+		canaries = tg.Update.Canaries(tg.Count)
 	}
 	for name, volReq := range tg.Volumes {
 		if err := volReq.Validate(j.Type, tg.Count, canaries); err != nil {
@@ -7427,6 +8793,12 @@ func (tg *TaskGroup) Warnings(j *Job) error {
 				fmt.Errorf("Update max parallel count is greater than task group count (%d > %d). "+
 					"A destructive change would result in the simultaneous replacement of all allocations.", u.MaxParallel, tg.Count))
 		}
+
+		// System jobs run canaries on a percentage of the nodes instead
+		if j.Type == JobTypeSystem && u.Canary != 0 {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("Update canary is ignored by system jobs, use canary_percent instead"))
+		}
 	}
 
 	if tg.MaxClientDisconnect != nil {
@@ -7764,6 +9136,10 @@ type Task struct {
 	// task from Consul and sending it a signal to shutdown. See #2441
 	ShutdownDelay time.Duration
 
+	// MaxRunDuration is the maximum duration the task is allowed to run for
+	// before being killed and failed. Restarts don't reset the duration.
+	MaxRunDuration time.Duration
+
 	// VolumeMounts is a list of Volume name <-> mount configurations that will be
 	// attached to this task.
 	VolumeMounts []*VolumeMount
@@ -7956,6 +9332,10 @@ func (t *Task) Canonicalize(job *Job, tg *TaskGroup) {
 		t.RestartPolicy = tg.RestartPolicy
 	}
 
+	if t.MaxRunDuration == 0 {
+		t.MaxRunDuration = tg.MaxRunDuration
+	}
+
 	// Set the default timeout if it is not specified.
 	if t.KillTimeout == 0 {
 		t.KillTimeout = DefaultKillTimeout
@@ -8034,6 +9414,11 @@ func (t *Task) Validate(jobType string, tg *TaskGroup) error {
 	if t.ShutdownDelay < 0 {
 		mErr.Errors = append(mErr.Errors, errors.New("ShutdownDelay must be a positive value"))
 	}
+	if t.MaxRunDuration < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("MaxRunDuration must be a positive value"))
+	} else if t.MaxRunDuration > 0 && jobType != JobTypeBatch && jobType != JobTypeSysBatch {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("MaxRunDuration is not allowed for %q jobs", jobType))
+	}
 
 	// Validate the resources.
 	if t.Resources == nil {
@@ -9012,6 +10397,31 @@ func (ts *TaskState) Successful() bool {
 	return ts.State == TaskStateDead && !ts.Failed
 }
 
+// MaxRunDurationExceeded returns true if the task failed because it was killed
+// for running longer than its max run duration.
+func (ts *TaskState) MaxRunDurationExceeded() bool {
+	if ts == nil || !ts.Failed {
+		return false
+	}
+	return slices.ContainsFunc(ts.Events, func(e *TaskEvent) bool {
+		return e.Type == TaskMaxRunDurationExceeded
+	})
+}
+
+// EvictionReason returns the reason the task failed because it was evicted
+// by the client, or the empty string if it was not evicted.
+func (ts *TaskState) EvictionReason() string {
+	if ts == nil || !ts.Failed {
+		return ""
+	}
+	for _, e := range ts.Events {
+		if e.Type == TaskEvicted {
+			return e.KillReason
+		}
+	}
+	return ""
+}
+
 func (ts *TaskState) Equal(o *TaskState) bool {
 	if ts.State != o.State {
 		return false
@@ -9156,6 +10566,26 @@ const (
 	// TaskRunning indicates a task is running due to a schedule or schedule
 	// override. (Enterprise)
 	TaskRunning = "Running"
+
+	// TaskMaxRunDurationExceeded indicates that the task is being killed
+	// because it ran longer than its max run duration.
+	TaskMaxRunDurationExceeded = "Max Run Duration Exceeded"
+
+	// TaskOOMKilled indicates that processes of a running task were killed by
+	// the kernel because the task reached its memory limit.
+	TaskOOMKilled = "OOM Killed"
+
+	// TaskMemoryPressure indicates that the processes of a task spend a
+	// sustained share of their time stalled waiting on memory.
+	TaskMemoryPressure = "Memory Pressure"
+
+	// TaskCPUThrottled indicates that a task is being throttled because it
+	// reached its CPU limit in most scheduling periods.
+	TaskCPUThrottled = "CPU Throttled"
+
+	// TaskEvicted indicates that the task is being killed by the client
+	// because the node is running low on memory or disk.
+	TaskEvicted = "Evicted"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -9369,6 +10799,18 @@ func (e *TaskEvent) PopulateEventDisplayMessage() {
 		desc = "Main tasks in the group died"
 	case TaskClientReconnected:
 		desc = "Client reconnected"
+	case TaskMaxRunDurationExceeded:
+		if e.KillReason != "" {
+			desc = e.KillReason
+		} else {
+			desc = "Task exceeded its max run duration"
+		}
+	case TaskEvicted:
+		if e.KillReason != "" {
+			desc = e.KillReason
+		} else {
+			desc = "Task evicted by the client"
+		}
 	default:
 		desc = e.Message
 	}
@@ -10088,6 +11530,196 @@ func (s *Spread) Validate() error {
 	return mErr.ErrorOrNil()
 }
 
+const (
+	// TopologySpreadBlock filters out nodes whose placement would violate the
+	// topology spread's max skew, blocking placements that cannot satisfy it.
+	TopologySpreadBlock = "block"
+
+	// TopologySpreadBestEffort penalizes the score of nodes whose placement
+	// would violate the topology spread's max skew, but still allows them.
+	TopologySpreadBestEffort = "best_effort"
+)
+
+// TopologySpread is used to bound how unevenly the allocations of a task group
+// are spread across topology domains. A topology domain is the combination of
+// the values of Attributes on a node, such as a datacenter and rack pair.
+type TopologySpread struct {
+	// Attributes are the node attributes whose combined values form a
+	// topology domain.
+	Attributes []string
+
+	// MaxSkew is the maximum allowed difference between the number of
+	// allocations in any two topology domains.
+	MaxSkew int
+
+	// WhenUnsatisfiable controls how the scheduler treats nodes that would
+	// exceed MaxSkew, and is one of TopologySpreadBlock or
+	// TopologySpreadBestEffort.
+	WhenUnsatisfiable string
+}
+
+func (ts *TopologySpread) Copy() *TopologySpread {
+	if ts == nil {
+		return nil
+	}
+	nts := new(TopologySpread)
+	*nts = *ts
+	nts.Attributes = slices.Clone(ts.Attributes)
+	return nts
+}
+
+func (ts *TopologySpread) Equal(o *TopologySpread) bool {
+	if ts == nil || o == nil {
+		return ts == o
+	}
+	switch {
+	case !slices.Equal(ts.Attributes, o.Attributes):
+		return false
+	case ts.MaxSkew != o.MaxSkew:
+		return false
+	case ts.WhenUnsatisfiable != o.WhenUnsatisfiable:
+		return false
+	}
+	return true
+}
+
+func (ts *TopologySpread) String() string {
+	return fmt.Sprintf("%s max_skew=%d %s",
+		strings.Join(ts.Attributes, ","), ts.MaxSkew, ts.WhenUnsatisfiable)
+}
+
+func (ts *TopologySpread) Canonicalize() {
+	if ts.MaxSkew == 0 {
+		ts.MaxSkew = 1
+	}
+	if ts.WhenUnsatisfiable == "" {
+		ts.WhenUnsatisfiable = TopologySpreadBlock
+	}
+}
+
+func (ts *TopologySpread) Validate() error {
+	var mErr multierror.Error
+	if len(ts.Attributes) == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Missing topology spread attributes"))
+	}
+	seen := make(map[string]struct{}, len(ts.Attributes))
+	for _, attr := range ts.Attributes {
+		if attr == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("Topology spread attribute must not be empty"))
+			continue
+		}
+		if _, ok := seen[attr]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Topology spread attribute %q already defined", attr))
+		}
+		seen[attr] = struct{}{}
+	}
+	if ts.MaxSkew < 1 {
+		mErr.Errors = append(mErr.Errors, errors.New("Topology spread max_skew must be at least 1"))
+	}
+	switch ts.WhenUnsatisfiable {
+	case TopologySpreadBlock, TopologySpreadBestEffort:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Topology spread when_unsatisfiable must be %q or %q; got %q",
+			TopologySpreadBlock, TopologySpreadBestEffort, ts.WhenUnsatisfiable))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// AllocationAffinityAnyNamespace is the AllocationAffinity namespace that
+// matches allocations in every namespace.
+const AllocationAffinityAnyNamespace = "*"
+
+// AllocationAffinity is used to co-locate the allocations of a task group
+// with, or keep them away from, other allocations. Allocations are matched by
+// namespace, job ID, task group and meta, rather than by node attributes.
+type AllocationAffinity struct {
+	// Namespace of the allocations to match. Defaults to the namespace of
+	// the job, and AllocationAffinityAnyNamespace matches every namespace.
+	Namespace string
+
+	// JobID of the allocations to match. Matches any job if empty.
+	JobID string
+
+	// TaskGroup of the allocations to match. Matches any group if empty.
+	TaskGroup string
+
+	// Meta that the allocations to match must have. The meta of an
+	// allocation is its job's meta merged with its task group's meta.
+	Meta map[string]string
+
+	// Anti avoids nodes running matching allocations instead of preferring
+	// them.
+	Anti bool
+
+	// Required makes the affinity a placement requirement rather than a
+	// scoring preference.
+	Required bool
+
+	// Weight applied to nodes that satisfy a preferred affinity. It is
+	// ignored for required affinities.
+	Weight int8
+}
+
+func (a *AllocationAffinity) Copy() *AllocationAffinity {
+	if a == nil {
+		return nil
+	}
+	na := new(AllocationAffinity)
+	*na = *a
+	na.Meta = maps.Clone(a.Meta)
+	return na
+}
+
+func (a *AllocationAffinity) Equal(o *AllocationAffinity) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	switch {
+	case a.Namespace != o.Namespace:
+		return false
+	case a.JobID != o.JobID:
+		return false
+	case a.TaskGroup != o.TaskGroup:
+		return false
+	case !maps.Equal(a.Meta, o.Meta):
+		return false
+	case a.Anti != o.Anti:
+		return false
+	case a.Required != o.Required:
+		return false
+	case a.Weight != o.Weight:
+		return false
+	}
+	return true
+}
+
+func (a *AllocationAffinity) String() string {
+	return fmt.Sprintf("namespace=%s job=%s group=%s meta=%v anti=%t required=%t %v",
+		a.Namespace, a.JobID, a.TaskGroup, a.Meta, a.Anti, a.Required, a.Weight)
+}
+
+func (a *AllocationAffinity) Canonicalize() {
+	if len(a.Meta) == 0 {
+		a.Meta = nil
+	}
+	if !a.Required && a.Weight == 0 {
+		a.Weight = 50
+	}
+}
+
+func (a *AllocationAffinity) Validate() error {
+	var mErr multierror.Error
+	if a.JobID == "" && a.TaskGroup == "" && len(a.Meta) == 0 {
+		mErr.Errors = append(mErr.Errors,
+			errors.New("Allocation affinity must match on at least one of job, group or meta"))
+	}
+	if !a.Required && (a.Weight < 1 || a.Weight > 100) {
+		mErr.Errors = append(mErr.Errors,
+			errors.New("Allocation affinity weight must be within the range [1,100]"))
+	}
+	return mErr.ErrorOrNil()
+}
+
 // SpreadTarget is used to specify desired percentages for each attribute value
 type SpreadTarget struct {
 	// Value is a single attribute value, like "dc1"
@@ -10344,6 +11976,7 @@ const (
 	DeploymentStatusDescriptionFailedAllocations     = "Failed due to unhealthy allocations"
 	DeploymentStatusDescriptionProgressDeadline      = "Failed due to progress deadline"
 	DeploymentStatusDescriptionFailedByUser          = "Deployment marked as failed"
+	DeploymentStatusDescriptionFailedAnalysis        = "Failed due to failed analysis gates"
 
 	// used only in multiregion deployments
 	DeploymentStatusDescriptionFailedByPeer   = "Failed because of an error in peer region"
@@ -10584,6 +12217,45 @@ type DeploymentState struct {
 
 	// UnhealthyAllocs are allocations that have been marked as unhealthy.
 	UnhealthyAllocs int
+
+	// AnalysisStep is the index of the next analysis step of the task group,
+	// the gates of every previous step passed.
+	AnalysisStep int
+
+	// AnalysisResults are the results of the analysis gates of the task
+	// group.
+	AnalysisResults []*AnalysisResult
+
+	// HoldPeriod is how long the previous allocations of a blue/green task
+	// group keep running once it is promoted. This value is set by the
+	// jobspec `update.hold_period` field.
+	HoldPeriod time.Duration
+
+	// HoldUntil is the time until which the previous allocations of a
+	// promoted blue/green task group keep running. It is set when the task
+	// group is promoted.
+	HoldUntil time.Time
+
+	// HoldEvalID is the ID of the follow up evaluation created for the end of
+	// the hold period, so that it is only created once.
+	HoldEvalID string
+
+	// DependsOn are the task groups whose deployment must complete before the
+	// task group is updated. They are the groups the task group depends on,
+	// or the groups depending on it when the deployment is a rollback.
+	DependsOn []string
+}
+
+// Holding returns whether the previous allocations of the promoted task group
+// are kept running at the given time.
+func (d *DeploymentState) Holding(now time.Time) bool {
+	return d != nil && d.Promoted && now.Before(d.HoldUntil)
+}
+
+// Waiting returns whether the task group is waiting for the deployment of the
+// task groups it depends on to complete before placing its canaries.
+func (d *DeploymentState) Waiting() bool {
+	return d != nil && len(d.DependsOn) != 0 && d.DesiredCanaries != 0 && len(d.PlacedCanaries) == 0
 }
 
 func (d *DeploymentState) GoString() string {
@@ -10603,6 +12275,8 @@ func (d *DeploymentState) Copy() *DeploymentState {
 	c := &DeploymentState{}
 	*c = *d
 	c.PlacedCanaries = slices.Clone(d.PlacedCanaries)
+	c.AnalysisResults = helper.CopySlice(d.AnalysisResults)
+	c.DependsOn = slices.Clone(d.DependsOn)
 	return c
 }
 
@@ -10616,6 +12290,12 @@ type DeploymentStatusUpdate struct {
 
 	// StatusDescription is the new status description of the deployment.
 	StatusDescription string
+
+	// HoldEvalIDs are the follow up evaluations created for the end of the
+	// hold period of blue/green task groups, by task group. An update that
+	// only records them has an empty Status and leaves the status of the
+	// deployment unchanged.
+	HoldEvalIDs map[string]string
 }
 
 // RescheduleTracker encapsulates previous reschedule events
@@ -10882,6 +12562,12 @@ type Allocation struct {
 	// ClientStatusDescription is meant to provide more human useful information
 	ClientDescription string
 
+	// Evicted is true if the client failed the allocation by evicting it
+	// because the node was under resource pressure. Evicted allocations have
+	// the failed client status so that they are rescheduled like any other
+	// failed allocation.
+	Evicted bool
+
 	// TaskStates stores the state of each task,
 	TaskStates map[string]*TaskState
 
@@ -11013,6 +12699,19 @@ func (a *Allocation) Index() uint {
 	return AllocIndexFromName(a.Name, a.JobID, a.TaskGroup)
 }
 
+// ArrayIndex returns the array index of the allocation and whether its task
+// group is an array.
+func (a *Allocation) ArrayIndex() (int, bool) {
+	if a.Job == nil {
+		return 0, false
+	}
+	tg := a.Job.LookupTaskGroup(a.TaskGroup)
+	if tg == nil || tg.Array == nil {
+		return 0, false
+	}
+	return tg.Array.Start + int(a.Index()), true
+}
+
 // AllocIndexFromName returns the index of an allocation given its name, the
 // jobID and the task group name.
 func AllocIndexFromName(allocName, jobID, taskGroup string) uint {
@@ -11134,6 +12833,23 @@ func (a *Allocation) ClientTerminalStatus() bool {
 	}
 }
 
+// DisruptionAvailable returns if the allocation counts as available against
+// the disruption budget of its task group. An allocation is available if it
+// is running, has not been marked unhealthy and is not already being stopped
+// or migrated.
+func (a *Allocation) DisruptionAvailable() bool {
+	return a.ClientStatus == AllocClientStatusRunning &&
+		!a.TerminalStatus() &&
+		!a.DesiredTransition.ShouldMigrate() &&
+		!a.DeploymentStatus.IsUnhealthy()
+}
+
+// DisruptionKey returns the key identifying the disruption budget of the
+// allocation's task group, in the form "job/group".
+func (a *Allocation) DisruptionKey() string {
+	return a.JobID + "/" + a.TaskGroup
+}
+
 // ShouldReschedule returns if the allocation is eligible to be rescheduled according
 // to its status and ReschedulePolicy given its failure time
 func (a *Allocation) ShouldReschedule(reschedulePolicy *ReschedulePolicy, failTime time.Time) bool {
@@ -11154,9 +12870,26 @@ func (a *Allocation) ShouldReschedule(reschedulePolicy *ReschedulePolicy, failTi
 // RescheduleEligible returns if the allocation is eligible to be rescheduled according
 // to its ReschedulePolicy and the current state of its reschedule trackers
 func (a *Allocation) RescheduleEligible(reschedulePolicy *ReschedulePolicy, failTime time.Time) bool {
+	if a.MaxRunDurationExceeded() && (reschedulePolicy == nil || !reschedulePolicy.OnMaxRunDuration) {
+		return false
+	}
 	return a.RescheduleTracker.RescheduleEligible(reschedulePolicy, failTime)
 }
 
+// MaxRunDurationExceeded returns true if the allocation failed because one of
+// its tasks ran longer than its max run duration.
+func (a *Allocation) MaxRunDurationExceeded() bool {
+	if a.ClientStatus != AllocClientStatusFailed {
+		return false
+	}
+	for _, ts := range a.TaskStates {
+		if ts.MaxRunDurationExceeded() {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *Allocation) RescheduleInfo() (int, int) {
 	return a.RescheduleTracker.rescheduleInfo(a.ReschedulePolicy(), a.LastEventTime())
 }
@@ -11215,6 +12948,10 @@ func (a *Allocation) NextRescheduleTime() (time.Time, bool) {
 		return time.Time{}, false
 	}
 
+	if a.MaxRunDurationExceeded() && !reschedulePolicy.OnMaxRunDuration {
+		return time.Time{}, false
+	}
+
 	return a.nextRescheduleTime(failTime, reschedulePolicy)
 }
 
@@ -11504,6 +13241,7 @@ func (a *Allocation) Stub(fields *AllocStubFields) *AllocListStub {
 		DesiredDescription:    a.DesiredDescription,
 		ClientStatus:          a.ClientStatus,
 		ClientDescription:     a.ClientDescription,
+		Evicted:               a.Evicted,
 		DesiredTransition:     a.DesiredTransition,
 		TaskStates:            a.TaskStates,
 		DeploymentStatus:      a.DeploymentStatus,
@@ -11805,6 +13543,7 @@ type AllocListStub struct {
 	DesiredDescription    string
 	ClientStatus          string
 	ClientDescription     string
+	Evicted               bool
 	DesiredTransition     DesiredTransition
 	TaskStates            map[string]*TaskState
 	DeploymentStatus      *AllocDeploymentStatus
@@ -11893,6 +13632,11 @@ type AllocMetric struct {
 	// QuotaExhausted provides the exhausted dimensions
 	QuotaExhausted []string
 
+	// DisruptionBudgetExhausted is the number of allocations that were not
+	// preempted because the disruption budget of their task group was
+	// exhausted, keyed by job ID and task group name.
+	DisruptionBudgetExhausted map[string]int
+
 	// ResourcesExhausted provides the amount of resources exhausted by task
 	// during the allocation placement
 	ResourcesExhausted map[string]*Resources
@@ -11936,6 +13680,7 @@ func (a *AllocMetric) Copy() *AllocMetric {
 	na.ClassExhausted = maps.Clone(na.ClassExhausted)
 	na.DimensionExhausted = maps.Clone(na.DimensionExhausted)
 	na.QuotaExhausted = slices.Clone(na.QuotaExhausted)
+	na.DisruptionBudgetExhausted = maps.Clone(na.DisruptionBudgetExhausted)
 	na.Scores = maps.Clone(na.Scores)
 	na.ScoreMetaData = CopySliceNodeScoreMeta(na.ScoreMetaData)
 	return na
@@ -11985,6 +13730,15 @@ func (a *AllocMetric) ExhaustQuota(dimensions []string) {
 	a.QuotaExhausted = append(a.QuotaExhausted, dimensions...)
 }
 
+// ExhaustDisruptionBudget records that the allocation could not be preempted
+// because the disruption budget of its task group is exhausted.
+func (a *AllocMetric) ExhaustDisruptionBudget(alloc *Allocation) {
+	if a.DisruptionBudgetExhausted == nil {
+		a.DisruptionBudgetExhausted = make(map[string]int)
+	}
+	a.DisruptionBudgetExhausted[alloc.DisruptionKey()] += 1
+}
+
 // ExhaustResources updates the amount of resources exhausted for the
 // allocation because of the given task group.
 func (a *AllocMetric) ExhaustResources(tg *TaskGroup) {
@@ -12173,6 +13927,11 @@ type AllocDeploymentStatus struct {
 	// been promoted will have this field set to false.
 	Canary bool
 
+	// Standby marks a previous allocation held by a promoted blue/green
+	// deployment. Its services are deregistered until the deployment fails
+	// or is cancelled.
+	Standby bool
+
 	// ModifyIndex is the raft index in which the deployment status was last
 	// changed.
 	ModifyIndex uint64
@@ -12212,6 +13971,16 @@ func (a *AllocDeploymentStatus) IsCanary() bool {
 	return a.Canary
 }
 
+// IsStandby returns if the allocation is held by a promoted blue/green
+// deployment.
+func (a *AllocDeploymentStatus) IsStandby() bool {
+	if a == nil {
+		return false
+	}
+
+	return a.Standby
+}
+
 func (a *AllocDeploymentStatus) Copy() *AllocDeploymentStatus {
 	if a == nil {
 		return nil
@@ -12271,6 +14040,10 @@ const (
 	EvalTriggerScaling              = "job-scaling"
 	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
 	EvalTriggerReconnect            = "reconnect"
+	EvalTriggerRebalance            = "rebalance"
+	EvalTriggerArrayProgress        = "array-progress"
+	EvalTriggerCompletionProgress   = "completion-progress"
+	EvalTriggerDeploymentHold       = "deployment-hold"
 )
 
 const (
@@ -12331,6 +14104,11 @@ const (
 
 	// CoreJobForceGC is used to force garbage collection of all GCable objects.
 	CoreJobForceGC = "force-gc"
+
+	// CoreJobRebalance is used to periodically migrate allocations that
+	// violate their spread or affinity goals, or that run on over-utilized
+	// nodes, when enabled in the scheduler configuration.
+	CoreJobRebalance = "rebalance"
 )
 
 // Evaluation is used anytime we need to apply business logic as a result
@@ -12419,6 +14197,11 @@ type Evaluation struct {
 	// to determine the cause.
 	FailedTGAllocs map[string]*AllocMetric
 
+	// BlockedDisruptions is the number of allocations that could not be
+	// drained or preempted because the disruption budget of their task group
+	// was exhausted, keyed by job ID and task group name.
+	BlockedDisruptions map[string]int
+
 	// ClassEligibility tracks computed node classes that have been explicitly
 	// marked as eligible or ineligible.
 	ClassEligibility map[string]bool
@@ -12427,6 +14210,11 @@ type Evaluation struct {
 	// evaluation.
 	QuotaLimitReached string
 
+	// PendingDependencies is the set of jobs, in the namespace of the
+	// evaluation, whose dependency conditions were not met when the
+	// evaluation was blocked.
+	PendingDependencies []string
+
 	// EscapedComputedClass marks whether the job has constraints that are not
 	// captured by computed node classes.
 	EscapedComputedClass bool
@@ -12590,6 +14378,8 @@ func (e *Evaluation) Copy() *Evaluation {
 		ne.FailedTGAllocs = failedTGs
 	}
 
+	ne.BlockedDisruptions = maps.Clone(e.BlockedDisruptions)
+
 	// Copy queued allocations
 	if e.QueuedAllocations != nil {
 		queuedAllocations := make(map[string]int, len(e.QueuedAllocations))
@@ -12599,6 +14389,8 @@ func (e *Evaluation) Copy() *Evaluation {
 		ne.QueuedAllocations = queuedAllocations
 	}
 
+	ne.PendingDependencies = slices.Clone(e.PendingDependencies)
+
 	return ne
 }
 
@@ -12893,6 +14685,7 @@ func (p *Plan) AppendPreemptedAlloc(alloc *Allocation, preemptingAllocID string)
 	newAlloc.ID = alloc.ID
 	newAlloc.JobID = alloc.JobID
 	newAlloc.Namespace = alloc.Namespace
+	newAlloc.TaskGroup = alloc.TaskGroup
 	newAlloc.DesiredStatus = AllocDesiredStatusEvict
 	newAlloc.PreemptedByAllocation = preemptingAllocID
 
@@ -63,6 +63,14 @@ func TestNamespace_Validate(t *testing.T) {
 			},
 			Expected: "description longer than",
 		},
+		{
+			Test: "negative eval queue weight",
+			Namespace: &Namespace{
+				Name:            "foo",
+				EvalQueueWeight: -1,
+			},
+			Expected: "eval queue weight must be between",
+		},
 		{
 			Test: "valid",
 			Namespace: &Namespace{
@@ -455,6 +463,143 @@ func TestJob_ValidateScaling(t *testing.T) {
 	)
 }
 
+func TestJob_ValidateDependsOn(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.DependsOn = []*JobDependency{
+		{JobID: "db", Condition: JobDependencyConditionHealthy},
+		{JobID: "migrate", Condition: JobDependencyConditionSuccessful},
+	}
+	must.NoError(t, job.Validate())
+
+	job.DependsOn = append(job.DependsOn,
+		&JobDependency{JobID: "db", Condition: JobDependencyConditionComplete},
+		&JobDependency{JobID: job.ID, Condition: JobDependencyConditionComplete},
+		&JobDependency{Condition: "done"},
+	)
+	err := job.Validate()
+	requireErrors(t, err,
+		`Dependency 3 redefines job "db"`,
+		"Dependency 4 references the job itself",
+		"Dependency 5 validation failed: 2 errors occurred",
+		"Missing job ID",
+		`Invalid condition "done"`,
+	)
+
+	job = testJob()
+	job.Type = JobTypeSystem
+	job.DependsOn = []*JobDependency{{JobID: "db", Condition: JobDependencyConditionHealthy}}
+	requireErrors(t, job.Validate(), "Job dependencies can only be used with")
+}
+
+func TestJobDependency_Met(t *testing.T) {
+	ci.Parallel(t)
+
+	newJob := func(status string) *Job {
+		job := testJob()
+		job.Version = 2
+		job.Status = status
+		job.TaskGroups[0].Count = 2
+		return job
+	}
+	newAlloc := func(job *Job, clientStatus string) *Allocation {
+		return &Allocation{Job: job, ClientStatus: clientStatus, DesiredStatus: AllocDesiredStatusRun}
+	}
+
+	deadJob := newJob(JobStatusDead)
+	oldJob := deadJob.Copy()
+	oldJob.Version = 1
+	runningJob := newJob(JobStatusRunning)
+	stoppedJob := newJob(JobStatusRunning)
+	stoppedJob.Stop = true
+
+	cases := []struct {
+		name       string
+		condition  string
+		job        *Job
+		allocs     []*Allocation
+		deployment *Deployment
+		met        bool
+	}{
+		{
+			name:      "missing job",
+			condition: JobDependencyConditionComplete,
+			met:       false,
+		},
+		{
+			name:      "stopped job",
+			condition: JobDependencyConditionHealthy,
+			job:       stoppedJob,
+			allocs:    []*Allocation{newAlloc(stoppedJob, AllocClientStatusRunning), newAlloc(stoppedJob, AllocClientStatusRunning)},
+			met:       false,
+		},
+		{
+			name:      "complete",
+			condition: JobDependencyConditionComplete,
+			job:       deadJob,
+			allocs:    []*Allocation{newAlloc(deadJob, AllocClientStatusFailed)},
+			met:       true,
+		},
+		{
+			name:      "complete running",
+			condition: JobDependencyConditionComplete,
+			job:       runningJob,
+			met:       false,
+		},
+		{
+			name:      "successful",
+			condition: JobDependencyConditionSuccessful,
+			job:       deadJob,
+			allocs:    []*Allocation{newAlloc(deadJob, AllocClientStatusComplete), newAlloc(oldJob, AllocClientStatusFailed)},
+			met:       true,
+		},
+		{
+			name:      "successful failed",
+			condition: JobDependencyConditionSuccessful,
+			job:       deadJob,
+			allocs:    []*Allocation{newAlloc(deadJob, AllocClientStatusComplete), newAlloc(deadJob, AllocClientStatusFailed)},
+			met:       false,
+		},
+		{
+			name:      "healthy without deployment",
+			condition: JobDependencyConditionHealthy,
+			job:       runningJob,
+			allocs:    []*Allocation{newAlloc(runningJob, AllocClientStatusRunning), newAlloc(runningJob, AllocClientStatusRunning)},
+			met:       true,
+		},
+		{
+			name:      "healthy without deployment pending",
+			condition: JobDependencyConditionHealthy,
+			job:       runningJob,
+			allocs:    []*Allocation{newAlloc(runningJob, AllocClientStatusRunning), newAlloc(runningJob, AllocClientStatusPending)},
+			met:       false,
+		},
+		{
+			name:       "healthy deployment running",
+			condition:  JobDependencyConditionHealthy,
+			job:        runningJob,
+			allocs:     []*Allocation{newAlloc(runningJob, AllocClientStatusRunning), newAlloc(runningJob, AllocClientStatusRunning)},
+			deployment: &Deployment{JobVersion: 2, Status: DeploymentStatusRunning},
+			met:        false,
+		},
+		{
+			name:       "healthy deployment successful",
+			condition:  JobDependencyConditionHealthy,
+			job:        runningJob,
+			deployment: &Deployment{JobVersion: 2, Status: DeploymentStatusSuccessful},
+			met:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dep := &JobDependency{JobID: "db", Condition: tc.condition}
+			must.Eq(t, tc.met, dep.Met(tc.job, tc.allocs, tc.deployment))
+		})
+	}
+}
+
 func TestJob_ValidateNullChar(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1753,6 +1898,22 @@ func TestTaskGroup_Validate(t *testing.T) {
 			},
 			jobType: JobTypeService,
 		},
+		{
+			name: "gang scheduling on service job",
+			tg: &TaskGroup{
+				Name: "group-a",
+				Gang: true,
+				Tasks: []*Task{
+					{
+						Name: "task-a",
+					},
+				},
+			},
+			expErr: []string{
+				`Job type "service" does not allow gang scheduling`,
+			},
+			jobType: JobTypeService,
+		},
 	}
 
 	for _, tc := range tests {
@@ -2239,6 +2400,32 @@ func TestTask_Validate_Resources(t *testing.T) {
 	}
 }
 
+func TestTask_MaxRunDuration(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Type = JobTypeBatch
+	tg := job.TaskGroups[0]
+	tg.MaxRunDuration = time.Hour
+
+	// Tasks inherit the max run duration of their group
+	task := tg.Tasks[0].Copy()
+	task.Canonicalize(job, tg)
+	must.Eq(t, time.Hour, task.MaxRunDuration)
+	must.NoError(t, task.Validate(job.Type, tg))
+
+	task = tg.Tasks[0].Copy()
+	task.MaxRunDuration = time.Minute
+	task.Canonicalize(job, tg)
+	must.Eq(t, time.Minute, task.MaxRunDuration)
+
+	task.MaxRunDuration = -time.Minute
+	must.ErrorContains(t, task.Validate(job.Type, tg), "MaxRunDuration must be a positive value")
+
+	task.MaxRunDuration = time.Minute
+	must.ErrorContains(t, task.Validate(JobTypeService, tg), `MaxRunDuration is not allowed for "service" jobs`)
+}
+
 func TestTask_Canonicalize(t *testing.T) {
 	ci.Parallel(t)
 	job := testJob()
@@ -3685,6 +3872,62 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 	)
 }
 
+func TestUpdateStrategy_Validate_BlueGreen(t *testing.T) {
+	ci.Parallel(t)
+
+	u := DefaultUpdateStrategy.Copy()
+	u.Strategy = UpdateStrategyBlueGreen
+	u.HoldPeriod = time.Hour
+	u.AutoPromote = true
+	must.NoError(t, u.Validate())
+	must.Eq(t, 5, u.Canaries(5))
+
+	u.Canary = 1
+	u.HoldPeriod = -time.Second
+	requireErrors(t, u.Validate(),
+		"Canary count can not be set with the \"blue_green\" strategy",
+		"Hold period must be zero or greater",
+	)
+
+	u = DefaultUpdateStrategy.Copy()
+	u.Canary = 2
+	u.HoldPeriod = time.Hour
+	requireErrors(t, u.Validate(), "Hold period requires the \"blue_green\" strategy")
+	must.Eq(t, 2, u.Canaries(5))
+
+	u.Strategy = "recreate"
+	requireErrors(t, u.Validate(), "Invalid strategy given")
+}
+
+func TestJob_Validate_UpdateDependsOn(t *testing.T) {
+	ci.Parallel(t)
+
+	j := testJob()
+	web := j.TaskGroups[0]
+	web.Update = DefaultUpdateStrategy.Copy()
+	api := web.Copy()
+	api.Name = "api"
+	db := web.Copy()
+	db.Name = "db"
+	j.TaskGroups = append(j.TaskGroups, api, db)
+
+	web.Update.DependsOn = []string{"api"}
+	api.Update.DependsOn = []string{"db"}
+	must.NoError(t, j.Validate())
+	must.Eq(t, []string{"web"}, j.UpdateDependents("api"))
+	must.Nil(t, j.UpdateDependents("web"))
+
+	db.Update.DependsOn = []string{"web", "db", "cache"}
+	err := j.Validate()
+	must.ErrorContains(t, err, "Update can not depend on its own task group")
+	must.ErrorContains(t, err, `Update depends on unknown task group "cache"`)
+	must.ErrorContains(t, err, "Task group update dependencies form a cycle: web -> api -> db -> web")
+
+	db.Update.DependsOn = nil
+	j.Type = JobTypeSystem
+	must.ErrorContains(t, j.Validate(), `Job type "system" does not allow update dependencies`)
+}
+
 func TestResource_NetIndex(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4468,6 +4711,176 @@ func TestPeriodicConfig_DST(t *testing.T) {
 	require.Equal(e2, n2.UTC())
 }
 
+func TestPeriodicConfig_ValidatePolicies(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		mutate   func(*PeriodicConfig)
+		errorMsg string
+	}{
+		{
+			name:   "defaults",
+			mutate: func(*PeriodicConfig) {},
+		},
+		{
+			name: "forbid with prohibit overlap",
+			mutate: func(p *PeriodicConfig) {
+				p.ConcurrencyPolicy = PeriodicConcurrencyForbid
+				p.ProhibitOverlap = true
+			},
+		},
+		{
+			name: "replace with prohibit overlap",
+			mutate: func(p *PeriodicConfig) {
+				p.ConcurrencyPolicy = PeriodicConcurrencyReplace
+				p.ProhibitOverlap = true
+			},
+			errorMsg: "can't be used with prohibit overlap",
+		},
+		{
+			name:     "unknown concurrency policy",
+			mutate:   func(p *PeriodicConfig) { p.ConcurrencyPolicy = "foo" },
+			errorMsg: "Unknown concurrency policy",
+		},
+		{
+			name:     "unknown catch up policy",
+			mutate:   func(p *PeriodicConfig) { p.CatchUp = "foo" },
+			errorMsg: "Unknown catch up policy",
+		},
+		{
+			name:     "negative starting deadline",
+			mutate:   func(p *PeriodicConfig) { p.StartingDeadline = -time.Second },
+			errorMsg: "Starting deadline must be >= 0",
+		},
+		{
+			name:     "negative history limit",
+			mutate:   func(p *PeriodicConfig) { p.HistoryLimit = -1 },
+			errorMsg: "History limit must be >= 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &PeriodicConfig{Enabled: true, SpecType: PeriodicSpecCron, Spec: "@hourly"}
+			tc.mutate(p)
+			p.Canonicalize()
+			err := p.Validate()
+			if tc.errorMsg == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.errorMsg)
+			}
+		})
+	}
+}
+
+func TestPeriodicConfig_MissedLaunches(t *testing.T) {
+	ci.Parallel(t)
+
+	last := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	now := last.Add(3*time.Hour + 30*time.Minute)
+	hour := func(n int) time.Time { return last.Add(time.Duration(n) * time.Hour) }
+
+	testCases := []struct {
+		name     string
+		mutate   func(*PeriodicConfig)
+		expected []time.Time
+	}{
+		{
+			name:     "latest",
+			mutate:   func(*PeriodicConfig) {},
+			expected: []time.Time{hour(3)},
+		},
+		{
+			name:     "all",
+			mutate:   func(p *PeriodicConfig) { p.CatchUp = PeriodicCatchUpAll },
+			expected: []time.Time{hour(1), hour(2), hour(3)},
+		},
+		{
+			name:   "none",
+			mutate: func(p *PeriodicConfig) { p.CatchUp = PeriodicCatchUpNone },
+		},
+		{
+			name: "all with starting deadline",
+			mutate: func(p *PeriodicConfig) {
+				p.CatchUp = PeriodicCatchUpAll
+				p.StartingDeadline = 2 * time.Hour
+			},
+			expected: []time.Time{hour(2), hour(3)},
+		},
+		{
+			name:   "latest past starting deadline",
+			mutate: func(p *PeriodicConfig) { p.StartingDeadline = 10 * time.Minute },
+		},
+		{
+			name: "all with forbidden overlap",
+			mutate: func(p *PeriodicConfig) {
+				p.CatchUp = PeriodicCatchUpAll
+				p.ConcurrencyPolicy = PeriodicConcurrencyForbid
+			},
+			expected: []time.Time{hour(3)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &PeriodicConfig{Enabled: true, SpecType: PeriodicSpecCron, Spec: "@hourly"}
+			tc.mutate(p)
+			p.Canonicalize()
+			missed, err := p.MissedLaunches(last, now)
+			must.NoError(t, err)
+			must.Eq(t, tc.expected, missed)
+		})
+	}
+}
+
+func TestPeriodicConfig_MissedLaunches_LongGap(t *testing.T) {
+	ci.Parallel(t)
+
+	last := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+
+	// Only the most recent launches are kept after a long gap
+	p := &PeriodicConfig{Enabled: true, SpecType: PeriodicSpecCron, Spec: "@hourly", CatchUp: PeriodicCatchUpAll}
+	p.Canonicalize()
+	now := last.AddDate(1, 0, 0).Add(30 * time.Minute)
+	missed, err := p.MissedLaunches(last, now)
+	must.NoError(t, err)
+	must.Len(t, PeriodicMaxCatchUpLaunches, missed)
+	for i, launch := range missed {
+		must.Eq(t, now.Add(-30*time.Minute).Add(time.Duration(i+1-len(missed))*time.Hour), launch)
+	}
+
+	// Launches before the starting deadline aren't enumerated, otherwise a
+	// decade of minutes would be walked through
+	p = &PeriodicConfig{Enabled: true, SpecType: PeriodicSpecCron, Spec: "* * * * *",
+		CatchUp: PeriodicCatchUpAll, StartingDeadline: 5 * time.Minute}
+	p.Canonicalize()
+	now = last.AddDate(10, 0, 0).Add(30 * time.Second)
+	missed, err = p.MissedLaunches(last, now)
+	must.NoError(t, err)
+	must.Len(t, 5, missed)
+	must.Eq(t, now.Add(-30*time.Second), missed[4])
+
+	// Neither are the launches of the latest policy without a starting
+	// deadline, otherwise half a century of minutes would be walked through
+	p = &PeriodicConfig{Enabled: true, SpecType: PeriodicSpecCron, Spec: "* * * * *"}
+	p.Canonicalize()
+	now = last.AddDate(50, 0, 0).Add(30 * time.Second)
+	missed, err = p.MissedLaunches(last, now)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{now.Add(-30 * time.Second)}, missed)
+
+	// Nor the launches of the all policy, only the most recent of which are
+	// caught up
+	p.CatchUp = PeriodicCatchUpAll
+	missed, err = p.MissedLaunches(last, now)
+	must.NoError(t, err)
+	must.Len(t, PeriodicMaxCatchUpLaunches, missed)
+	must.Eq(t, now.Add(-30*time.Second), missed[len(missed)-1])
+	must.Eq(t, now.Add(-30*time.Second).Add(-(PeriodicMaxCatchUpLaunches-1)*time.Minute), missed[0])
+}
+
 func TestTaskLifecycleConfig_Validate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4570,6 +4983,37 @@ func TestRestartPolicy_Validate(t *testing.T) {
 	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "Interval can not be less than") {
 		t.Fatalf("expect interval too small error, got: %v", err)
 	}
+
+	// Fails with an unknown delay function
+	p = &RestartPolicy{
+		Mode:          RestartPolicyModeDelay,
+		Attempts:      3,
+		Delay:         5 * time.Second,
+		DelayFunction: "linear",
+		Interval:      time.Minute,
+	}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "Invalid delay function") {
+		t.Fatalf("expect delay function error, got: %v", err)
+	}
+
+	// Fails when the max delay is less than the delay
+	p = &RestartPolicy{
+		Mode:          RestartPolicyModeDelay,
+		Attempts:      3,
+		Delay:         5 * time.Second,
+		DelayFunction: "exponential",
+		MaxDelay:      time.Second,
+		Interval:      time.Minute,
+	}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "Max Delay cannot be less than Delay") {
+		t.Fatalf("expect max delay error, got: %v", err)
+	}
+
+	// Passes with a delay function and max delay
+	p.MaxDelay = 5 * time.Minute
+	if err := p.Validate(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
 }
 
 func TestReschedulePolicy_Validate(t *testing.T) {
@@ -5179,6 +5623,7 @@ func TestPlan_AppendPreemptedAllocAppendsAllocWithUpdatedAttrs(t *testing.T) {
 		PreemptedByAllocation: preemptingAllocID,
 		JobID:                 alloc.JobID,
 		Namespace:             alloc.Namespace,
+		TaskGroup:             alloc.TaskGroup,
 		DesiredStatus:         AllocDesiredStatusEvict,
 		DesiredDescription:    fmt.Sprintf("Preempted by alloc ID %v", preemptingAllocID),
 		AllocatedResources:    alloc.AllocatedResources,
@@ -5386,6 +5831,50 @@ func TestAllocation_ShouldReschedule(t *testing.T) {
 	}
 }
 
+func TestAllocation_MaxRunDurationExceeded(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	alloc := MockAlloc()
+	alloc.Job.TaskGroups[0].ReschedulePolicy = &ReschedulePolicy{
+		Attempts: 1,
+		Interval: time.Hour,
+		Delay:    5 * time.Second,
+	}
+	alloc.ClientStatus = AllocClientStatusFailed
+	alloc.TaskStates = map[string]*TaskState{
+		"web": {
+			State:      TaskStateDead,
+			Failed:     true,
+			FinishedAt: now,
+			Events: []*TaskEvent{
+				NewTaskEvent(TaskStarted),
+				NewTaskEvent(TaskMaxRunDurationExceeded).SetFailsTask(),
+				NewTaskEvent(TaskKilled),
+			},
+		},
+	}
+	must.True(t, alloc.MaxRunDurationExceeded())
+
+	// Allocations killed for exceeding their max run duration are not
+	// rescheduled by default
+	policy := alloc.ReschedulePolicy()
+	must.False(t, alloc.ShouldReschedule(policy, now))
+	_, eligible := alloc.NextRescheduleTime()
+	must.False(t, eligible)
+
+	policy.OnMaxRunDuration = true
+	must.True(t, alloc.ShouldReschedule(policy, now))
+	_, eligible = alloc.NextRescheduleTime()
+	must.True(t, eligible)
+
+	// Other failures are rescheduled
+	policy.OnMaxRunDuration = false
+	alloc.TaskStates["web"].Events = []*TaskEvent{NewTaskEvent(TaskTerminated)}
+	must.False(t, alloc.MaxRunDurationExceeded())
+	must.True(t, alloc.ShouldReschedule(policy, now))
+}
+
 func TestAllocation_LastEventTime(t *testing.T) {
 	ci.Parallel(t)
 	type testCase struct {
@@ -6918,6 +7407,31 @@ func TestNode_Copy(t *testing.T) {
 	must.Eq(t, node.Drivers, node2.Drivers)
 }
 
+func TestNodePressure(t *testing.T) {
+	ci.Parallel(t)
+
+	var none *NodePressure
+	must.False(t, none.Pressured())
+	must.False(t, (&NodePressure{}).Pressured())
+	must.True(t, none.Equal(&NodePressure{UpdatedAt: 10}))
+
+	memory := &NodePressure{Memory: true, UpdatedAt: 10}
+	must.True(t, memory.Pressured())
+	must.Eq(t, "memory", memory.String())
+	must.False(t, memory.Equal(none))
+	must.True(t, memory.Equal(&NodePressure{Memory: true, UpdatedAt: 20}))
+	must.False(t, memory.Equal(&NodePressure{Memory: true, Disk: true}))
+	must.Eq(t, "memory, disk", (&NodePressure{Memory: true, Disk: true}).String())
+
+	node := &Node{
+		Status:                NodeStatusReady,
+		SchedulingEligibility: NodeSchedulingEligible,
+	}
+	must.True(t, node.Ready())
+	node.Pressure = memory
+	must.False(t, node.Ready())
+}
+
 func TestNode_GetID(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7087,6 +7601,493 @@ func TestSpread_Validate(t *testing.T) {
 	}
 }
 
+func TestTopologySpread_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		spread *TopologySpread
+		err    string
+	}{
+		{
+			name:   "missing attributes",
+			spread: &TopologySpread{MaxSkew: 1, WhenUnsatisfiable: TopologySpreadBlock},
+			err:    "Missing topology spread attributes",
+		},
+		{
+			name: "duplicate attribute",
+			spread: &TopologySpread{
+				Attributes:        []string{"${node.datacenter}", "${node.datacenter}"},
+				MaxSkew:           1,
+				WhenUnsatisfiable: TopologySpreadBlock,
+			},
+			err: `Topology spread attribute "${node.datacenter}" already defined`,
+		},
+		{
+			name: "invalid max skew",
+			spread: &TopologySpread{
+				Attributes:        []string{"${node.datacenter}"},
+				WhenUnsatisfiable: TopologySpreadBlock,
+			},
+			err: "Topology spread max_skew must be at least 1",
+		},
+		{
+			name: "invalid when unsatisfiable",
+			spread: &TopologySpread{
+				Attributes:        []string{"${node.datacenter}"},
+				MaxSkew:           1,
+				WhenUnsatisfiable: "sometimes",
+			},
+			err: "Topology spread when_unsatisfiable must be",
+		},
+		{
+			name: "valid",
+			spread: &TopologySpread{
+				Attributes:        []string{"${node.datacenter}", "${meta.rack}"},
+				MaxSkew:           2,
+				WhenUnsatisfiable: TopologySpreadBestEffort,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.spread.Validate()
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAllocationAffinity_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		affinity *AllocationAffinity
+		err      string
+	}{
+		{
+			name:     "missing match",
+			affinity: &AllocationAffinity{Namespace: "prod", Weight: 50},
+			err:      "must match on at least one of job, group or meta",
+		},
+		{
+			name:     "invalid weight",
+			affinity: &AllocationAffinity{JobID: "api", Weight: -10},
+			err:      "weight must be within the range [1,100]",
+		},
+		{
+			name:     "required ignores weight",
+			affinity: &AllocationAffinity{JobID: "db-replica", Anti: true, Required: true},
+		},
+		{
+			name: "valid",
+			affinity: &AllocationAffinity{
+				Namespace: AllocationAffinityAnyNamespace,
+				Meta:      map[string]string{"tier": "frontend"},
+				Weight:    100,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.affinity.Validate()
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodeTaint_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name  string
+		taint *NodeTaint
+		err   string
+	}{
+		{
+			name:  "missing key",
+			taint: &NodeTaint{Effect: NodeTaintEffectNoSchedule},
+			err:   "Missing taint key",
+		},
+		{
+			name:  "invalid key",
+			taint: &NodeTaint{Key: "gpu=true", Effect: NodeTaintEffectNoSchedule},
+			err:   "must not contain",
+		},
+		{
+			name:  "invalid value",
+			taint: &NodeTaint{Key: "gpu", Value: "a:b", Effect: NodeTaintEffectNoSchedule},
+			err:   "must not contain",
+		},
+		{
+			name:  "invalid effect",
+			taint: &NodeTaint{Key: "gpu", Effect: "NoSchedule"},
+			err:   `Invalid taint effect "NoSchedule"`,
+		},
+		{
+			name:  "valid",
+			taint: &NodeTaint{Key: "gpu", Value: "true", Effect: NodeTaintEffectNoExecute},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.taint.Validate()
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestToleration_Tolerates(t *testing.T) {
+	ci.Parallel(t)
+
+	taint := &NodeTaint{Key: "dedicated", Value: "gpu", Effect: NodeTaintEffectNoSchedule}
+
+	testCases := []struct {
+		name       string
+		toleration *Toleration
+		expected   bool
+	}{
+		{
+			name:       "equal",
+			toleration: &Toleration{Key: "dedicated", Operator: TolerationOperatorEqual, Value: "gpu"},
+			expected:   true,
+		},
+		{
+			name:       "equal different value",
+			toleration: &Toleration{Key: "dedicated", Operator: TolerationOperatorEqual, Value: "db"},
+			expected:   false,
+		},
+		{
+			name:       "exists",
+			toleration: &Toleration{Key: "dedicated", Operator: TolerationOperatorExists},
+			expected:   true,
+		},
+		{
+			name:       "exists any key",
+			toleration: &Toleration{Operator: TolerationOperatorExists},
+			expected:   true,
+		},
+		{
+			name: "matching effect",
+			toleration: &Toleration{Key: "dedicated", Operator: TolerationOperatorExists,
+				Effect: NodeTaintEffectNoSchedule},
+			expected: true,
+		},
+		{
+			name: "different effect",
+			toleration: &Toleration{Key: "dedicated", Operator: TolerationOperatorExists,
+				Effect: NodeTaintEffectNoExecute},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, tc.toleration.Tolerates(taint))
+		})
+	}
+}
+
+func TestDisruptionBudget_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		budget *DisruptionBudget
+		err    string
+	}{
+		{
+			name:   "empty",
+			budget: &DisruptionBudget{},
+			err:    "One of MaxUnavailable or MinAvailable must be set",
+		},
+		{
+			name:   "both set",
+			budget: &DisruptionBudget{MaxUnavailable: 1, MinAvailable: 2},
+			err:    "Only one of MaxUnavailable or MinAvailable can be set",
+		},
+		{
+			name:   "negative",
+			budget: &DisruptionBudget{MaxUnavailable: -1},
+			err:    "MaxUnavailable must be >= 0",
+		},
+		{
+			name:   "max unavailable",
+			budget: &DisruptionBudget{MaxUnavailable: 1},
+		},
+		{
+			name:   "min available",
+			budget: &DisruptionBudget{MinAvailable: 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.budget.Validate()
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDisruptionBudget_AllowedDisruptions(t *testing.T) {
+	ci.Parallel(t)
+
+	maxUnavailable := &DisruptionBudget{MaxUnavailable: 2}
+	must.Eq(t, 2, maxUnavailable.AllowedDisruptions(5, 5))
+	must.Eq(t, 1, maxUnavailable.AllowedDisruptions(5, 4))
+	must.Eq(t, 0, maxUnavailable.AllowedDisruptions(5, 2))
+
+	minAvailable := &DisruptionBudget{MinAvailable: 3}
+	must.Eq(t, 2, minAvailable.AllowedDisruptions(5, 5))
+	must.Eq(t, 0, minAvailable.AllowedDisruptions(5, 3))
+	must.Eq(t, 0, minAvailable.AllowedDisruptions(5, 1))
+}
+
+func TestTaskGroup_Validate_Array(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Type = JobTypeBatch
+	tg := job.TaskGroups[0]
+	tg.Update = nil
+	tg.Migrate = nil
+	tg.Array = &ArrayConfig{Start: 5, End: 14, MaxParallel: 2}
+	tg.Canonicalize(job)
+	must.Eq(t, 10, tg.Count)
+	must.NoError(t, tg.Validate(job))
+
+	tg.Count = 3
+	must.ErrorContains(t, tg.Validate(job), "Array task group count must be 10 but found 3")
+
+	tg.Array = &ArrayConfig{Start: 5, End: 4, MaxParallel: -1}
+	err := tg.Validate(job)
+	must.ErrorContains(t, err, "End must be >= Start")
+	must.ErrorContains(t, err, "MaxParallel must be >= 0")
+
+	job.Type = JobTypeService
+	tg.Array = &ArrayConfig{End: 2}
+	tg.Canonicalize(job)
+	must.ErrorContains(t, tg.Validate(job), `Job type "service" does not allow array task groups`)
+}
+
+func TestTaskGroup_Validate_Completion(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Type = JobTypeBatch
+	tg := job.TaskGroups[0]
+	tg.Update = nil
+	tg.Migrate = nil
+	tg.Completion = &CompletionPolicy{Completions: 50, Parallelism: 10, BackoffLimit: 5}
+	tg.Canonicalize(job)
+	must.Eq(t, 50, tg.Count)
+	must.NoError(t, tg.Validate(job))
+
+	tg.Count = 3
+	must.ErrorContains(t, tg.Validate(job), "Completion task group count must be 50 but found 3")
+
+	tg.Completion = &CompletionPolicy{Parallelism: -1, BackoffLimit: -1}
+	err := tg.Validate(job)
+	must.ErrorContains(t, err, "Completions must be >= 1")
+	must.ErrorContains(t, err, "Parallelism must be >= 0")
+	must.ErrorContains(t, err, "BackoffLimit must be >= 0")
+
+	tg.Completion = &CompletionPolicy{Completions: 10}
+	tg.Array = &ArrayConfig{End: 9}
+	tg.Canonicalize(job)
+	must.ErrorContains(t, tg.Validate(job), "Array task groups cannot have a completion policy")
+
+	job.Type = JobTypeService
+	tg.Array = nil
+	must.ErrorContains(t, tg.Validate(job), `Job type "service" does not allow completion policies`)
+}
+
+func TestTaskGroup_Validate_CanaryPercent(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Type = JobTypeSystem
+	tg := job.TaskGroups[0]
+	tg.Count = 1
+	tg.Migrate = nil
+	tg.ReschedulePolicy = nil
+	tg.Update = DefaultUpdateStrategy.Copy()
+	tg.Update.CanaryPercent = 100
+	must.NoError(t, tg.Validate(job))
+
+	tg.Update.CanaryPercent = 101
+	must.ErrorContains(t, tg.Validate(job), "Canary percent must be between 0 and 100: 101")
+
+	tg.Update.CanaryPercent = -1
+	must.ErrorContains(t, tg.Validate(job), "Canary percent must be between 0 and 100: -1")
+
+	// The canary count is ignored by system jobs
+	tg.Update.CanaryPercent = 0
+	tg.Update.Canary = 1
+	must.NoError(t, tg.Validate(job))
+	must.ErrorContains(t, tg.Warnings(job), "Update canary is ignored by system jobs, use canary_percent instead")
+
+	// Auto revert requires a deployment, so a canary percent or analysis
+	tg.Update.Canary = 0
+	tg.Update.AutoRevert = true
+	must.ErrorContains(t, tg.Validate(job), `Job type "system" does not allow auto revert without canary percent or analysis`)
+	tg.Update.CanaryPercent = 10
+	must.NoError(t, tg.Validate(job))
+	tg.Update.CanaryPercent = 0
+	tg.Update.Analysis = &AnalysisStrategy{
+		Steps: []int{100},
+		Gates: []*AnalysisGate{{
+			Name:     "webhook",
+			Type:     AnalysisGateTypeHTTP,
+			Provider: "checks",
+			Timeout:  DefaultAnalysisGateTimeout,
+		}},
+	}
+	must.NoError(t, tg.Validate(job))
+
+	// Only system jobs allow canary percents
+	job = testJob()
+	tg = job.TaskGroups[0]
+	tg.Update = DefaultUpdateStrategy.Copy()
+	tg.Update.CanaryPercent = 10
+	must.ErrorContains(t, tg.Validate(job), `Job type "service" does not allow canary percent`)
+}
+
+func TestJob_FailedCompletionGroup(t *testing.T) {
+	ci.Parallel(t)
+
+	job := MockJob()
+	job.Type = JobTypeBatch
+	job.Version = 2
+	job.TaskGroups[0].Completion = &CompletionPolicy{Completions: 10, BackoffLimit: 1}
+
+	newAlloc := func(version uint64, status string) *Allocation {
+		alloc := MockAlloc()
+		alloc.Job = job.Copy()
+		alloc.Job.Version = version
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.ClientStatus = status
+		return alloc
+	}
+
+	// Failed allocations of older versions are not counted
+	allocs := []*Allocation{
+		newAlloc(1, AllocClientStatusFailed),
+		newAlloc(1, AllocClientStatusFailed),
+		newAlloc(2, AllocClientStatusFailed),
+		newAlloc(2, AllocClientStatusComplete),
+	}
+	must.Nil(t, job.FailedCompletionGroup(allocs))
+
+	allocs = append(allocs, newAlloc(2, AllocClientStatusFailed))
+	must.Eq(t, job.TaskGroups[0], job.FailedCompletionGroup(allocs))
+}
+
+func TestArrayIndices(t *testing.T) {
+	ci.Parallel(t)
+
+	var indices ArrayIndices
+	for _, i := range []int{5, 3, 9, 4, 10, 0} {
+		indices = indices.Add(i)
+	}
+	must.Eq(t, ArrayIndices{{0, 0}, {3, 5}, {9, 10}}, indices)
+	must.Eq(t, "0,3-5,9-10", indices.String())
+	must.Eq(t, 6, indices.Len())
+	must.True(t, indices.Contains(4))
+	must.False(t, indices.Contains(6))
+
+	// Adding an index joins adjacent ranges
+	joined := indices.Add(1).Add(2)
+	must.Eq(t, ArrayIndices{{0, 5}, {9, 10}}, joined)
+
+	// The receiver is never modified
+	must.Eq(t, ArrayIndices{{0, 0}, {3, 5}, {9, 10}}, indices)
+
+	// Removing an index splits its range
+	split := joined.Remove(3)
+	must.Eq(t, ArrayIndices{{0, 2}, {4, 5}, {9, 10}}, split)
+	must.Eq(t, split, split.Remove(7))
+	must.Eq(t, ArrayIndices{{0, 2}, {4, 5}, {10, 10}}, split.Remove(9))
+	must.Nil(t, ArrayIndices{{1, 1}}.Remove(1))
+}
+
+func TestTaskGroupSummary_UpdateArrayIndex(t *testing.T) {
+	ci.Parallel(t)
+
+	var summary TaskGroupSummary
+	summary.UpdateArrayIndex(1, AllocClientStatusFailed)
+	summary.UpdateArrayIndex(2, AllocClientStatusComplete)
+	summary.UpdateArrayIndex(3, AllocClientStatusRunning)
+	must.Eq(t, "2", summary.ArraySucceeded.String())
+	must.Eq(t, "1", summary.ArrayFailed.String())
+
+	// A retried index that succeeds is no longer failed
+	summary.UpdateArrayIndex(1, AllocClientStatusComplete)
+	must.Eq(t, "1-2", summary.ArraySucceeded.String())
+	must.Len(t, 0, summary.ArrayFailed)
+
+	// An index that succeeded is never reported as failed
+	summary.UpdateArrayIndex(2, AllocClientStatusFailed)
+	must.Len(t, 0, summary.ArrayFailed)
+}
+
+func TestAllocation_ArrayIndex(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := MockAlloc()
+	alloc.Name = AllocName(alloc.JobID, alloc.TaskGroup, 4)
+	_, ok := alloc.ArrayIndex()
+	must.False(t, ok)
+
+	alloc.Job.TaskGroups[0].Array = &ArrayConfig{Start: 10, End: 19}
+	idx, ok := alloc.ArrayIndex()
+	must.True(t, ok)
+	must.Eq(t, 14, idx)
+}
+
+func TestAllocation_DisruptionAvailable(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := MockAlloc()
+	alloc.ClientStatus = AllocClientStatusRunning
+	must.True(t, alloc.DisruptionAvailable())
+
+	pending := alloc.Copy()
+	pending.ClientStatus = AllocClientStatusPending
+	must.False(t, pending.DisruptionAvailable())
+
+	stopping := alloc.Copy()
+	stopping.DesiredStatus = AllocDesiredStatusStop
+	must.False(t, stopping.DisruptionAvailable())
+
+	migrating := alloc.Copy()
+	migrating.DesiredTransition.Migrate = pointer.Of(true)
+	must.False(t, migrating.DisruptionAvailable())
+
+	unhealthy := alloc.Copy()
+	unhealthy.DeploymentStatus = &AllocDeploymentStatus{Healthy: pointer.Of(false)}
+	must.False(t, unhealthy.DisruptionAvailable())
+}
+
 func TestNodeReservedNetworkResources_ParseReserved(t *testing.T) {
 	ci.Parallel(t)
 
@@ -22,6 +22,16 @@ const (
 	// WorkloadIdentityDefaultAud is the audience of the default identity.
 	WorkloadIdentityDefaultAud = "nomadproject.io"
 
+	// WorkloadIdentityDeploymentActionName is the name of the identities
+	// signed by servers to run the actions of deployment analysis gates.
+	WorkloadIdentityDeploymentActionName = "deployment-action"
+
+	// WorkloadIdentityDeploymentActionAud is the audience of the identities
+	// signed by servers to run the actions of deployment analysis gates.
+	// Clients only accept them to run the actions of the task they identify,
+	// so jobs can't define identities with this audience.
+	WorkloadIdentityDeploymentActionAud = "nomadproject.io/deployment-action"
+
 	// WorkloadIdentityVaultPrefix is the name prefix of workload identities
 	// used to derive Vault tokens.
 	WorkloadIdentityVaultPrefix = "vault_"
@@ -202,6 +212,9 @@ func (wi *WorkloadIdentity) Validate() error {
 		if aud == "" {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("an empty string is an invalid audience (%d)", i+1))
 		}
+		if aud == WorkloadIdentityDeploymentActionAud {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("audience %q is reserved (%d)", aud, i+1))
+		}
 	}
 
 	switch wi.ChangeMode {
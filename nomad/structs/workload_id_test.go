@@ -244,6 +244,15 @@ func TestWorkloadIdentity_Validate(t *testing.T) {
 			},
 			Warn: "identities without an expiration are insecure",
 		},
+		{
+			Desc: "Reserved audience",
+			In: WorkloadIdentity{
+				Name:     "foo",
+				Audience: []string{WorkloadIdentityDeploymentActionAud},
+				TTL:      time.Hour,
+			},
+			Err: `audience "nomadproject.io/deployment-action" is reserved`,
+		},
 	}
 
 	for _, tc := range cases {
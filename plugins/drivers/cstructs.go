@@ -17,7 +17,10 @@ type MemoryStats = cstructs.MemoryStats
 // CpuStats holds cpu usage related stats
 type CpuStats = cstructs.CpuStats
 
-// ResourceUsage holds information related to cpu and memory stats
+// IOStats holds block I/O related stats
+type IOStats = cstructs.IOStats
+
+// ResourceUsage holds information related to cpu, memory and block I/O stats
 type ResourceUsage = cstructs.ResourceUsage
 
 // TaskResourceUsage holds aggregated resource usage of all processes in a Task
@@ -236,7 +236,7 @@ func (x CPUUsage_Fields) String() string {
 }
 
 func (CPUUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{56, 0}
 }
 
 type MemoryUsage_Fields int32
@@ -276,7 +276,38 @@ func (x MemoryUsage_Fields) String() string {
 }
 
 func (MemoryUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{57, 0}
+}
+
+type IOUsage_Fields int32
+
+const (
+	IOUsage_READ_BYTES  IOUsage_Fields = 0
+	IOUsage_WRITE_BYTES IOUsage_Fields = 1
+	IOUsage_READ_OPS    IOUsage_Fields = 2
+	IOUsage_WRITE_OPS   IOUsage_Fields = 3
+)
+
+var IOUsage_Fields_name = map[int32]string{
+	0: "READ_BYTES",
+	1: "WRITE_BYTES",
+	2: "READ_OPS",
+	3: "WRITE_OPS",
+}
+
+var IOUsage_Fields_value = map[string]int32{
+	"READ_BYTES":  0,
+	"WRITE_BYTES": 1,
+	"READ_OPS":    2,
+	"WRITE_OPS":   3,
+}
+
+func (x IOUsage_Fields) String() string {
+	return proto.EnumName(IOUsage_Fields_name, int32(x))
+}
+
+func (IOUsage_Fields) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{58, 0}
 }
 
 type TaskConfigSchemaRequest struct {
@@ -2414,6 +2445,7 @@ type AllocatedTaskResources struct {
 	Cpu                  *AllocatedCpuResources    `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
 	Memory               *AllocatedMemoryResources `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
 	Networks             []*NetworkResource        `protobuf:"bytes,5,rep,name=networks,proto3" json:"networks,omitempty"`
+	Io                   *AllocatedIOResources     `protobuf:"bytes,6,opt,name=io,proto3" json:"io,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
 	XXX_sizecache        int32                     `json:"-"`
@@ -2465,6 +2497,13 @@ func (m *AllocatedTaskResources) GetNetworks() []*NetworkResource {
 	return nil
 }
 
+func (m *AllocatedTaskResources) GetIo() *AllocatedIOResources {
+	if m != nil {
+		return m.Io
+	}
+	return nil
+}
+
 type AllocatedCpuResources struct {
 	CpuShares            int64    `protobuf:"varint,1,opt,name=cpu_shares,json=cpuShares,proto3" json:"cpu_shares,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2551,6 +2590,126 @@ func (m *AllocatedMemoryResources) GetMemoryMaxMb() int64 {
 	return 0
 }
 
+type AllocatedIOResources struct {
+	// Weight is the relative share of block I/O of the task
+	Weight int64 `protobuf:"varint,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Devices are the limits of the task on individual block devices
+	Devices              []*AllocatedIODeviceResources `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *AllocatedIOResources) Reset()         { *m = AllocatedIOResources{} }
+func (m *AllocatedIOResources) String() string { return proto.CompactTextString(m) }
+func (*AllocatedIOResources) ProtoMessage()    {}
+func (*AllocatedIOResources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{41}
+}
+
+func (m *AllocatedIOResources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllocatedIOResources.Unmarshal(m, b)
+}
+func (m *AllocatedIOResources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllocatedIOResources.Marshal(b, m, deterministic)
+}
+func (m *AllocatedIOResources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocatedIOResources.Merge(m, src)
+}
+func (m *AllocatedIOResources) XXX_Size() int {
+	return xxx_messageInfo_AllocatedIOResources.Size(m)
+}
+func (m *AllocatedIOResources) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocatedIOResources.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllocatedIOResources proto.InternalMessageInfo
+
+func (m *AllocatedIOResources) GetWeight() int64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *AllocatedIOResources) GetDevices() []*AllocatedIODeviceResources {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type AllocatedIODeviceResources struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	ReadBps              int64    `protobuf:"varint,2,opt,name=read_bps,json=readBps,proto3" json:"read_bps,omitempty"`
+	WriteBps             int64    `protobuf:"varint,3,opt,name=write_bps,json=writeBps,proto3" json:"write_bps,omitempty"`
+	ReadIops             int64    `protobuf:"varint,4,opt,name=read_iops,json=readIops,proto3" json:"read_iops,omitempty"`
+	WriteIops            int64    `protobuf:"varint,5,opt,name=write_iops,json=writeIops,proto3" json:"write_iops,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AllocatedIODeviceResources) Reset()         { *m = AllocatedIODeviceResources{} }
+func (m *AllocatedIODeviceResources) String() string { return proto.CompactTextString(m) }
+func (*AllocatedIODeviceResources) ProtoMessage()    {}
+func (*AllocatedIODeviceResources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{42}
+}
+
+func (m *AllocatedIODeviceResources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllocatedIODeviceResources.Unmarshal(m, b)
+}
+func (m *AllocatedIODeviceResources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllocatedIODeviceResources.Marshal(b, m, deterministic)
+}
+func (m *AllocatedIODeviceResources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocatedIODeviceResources.Merge(m, src)
+}
+func (m *AllocatedIODeviceResources) XXX_Size() int {
+	return xxx_messageInfo_AllocatedIODeviceResources.Size(m)
+}
+func (m *AllocatedIODeviceResources) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocatedIODeviceResources.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllocatedIODeviceResources proto.InternalMessageInfo
+
+func (m *AllocatedIODeviceResources) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *AllocatedIODeviceResources) GetReadBps() int64 {
+	if m != nil {
+		return m.ReadBps
+	}
+	return 0
+}
+
+func (m *AllocatedIODeviceResources) GetWriteBps() int64 {
+	if m != nil {
+		return m.WriteBps
+	}
+	return 0
+}
+
+func (m *AllocatedIODeviceResources) GetReadIops() int64 {
+	if m != nil {
+		return m.ReadIops
+	}
+	return 0
+}
+
+func (m *AllocatedIODeviceResources) GetWriteIops() int64 {
+	if m != nil {
+		return m.WriteIops
+	}
+	return 0
+}
+
 type NetworkResource struct {
 	Device               string         `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
 	Cidr                 string         `protobuf:"bytes,2,opt,name=cidr,proto3" json:"cidr,omitempty"`
@@ -2567,7 +2726,7 @@ func (m *NetworkResource) Reset()         { *m = NetworkResource{} }
 func (m *NetworkResource) String() string { return proto.CompactTextString(m) }
 func (*NetworkResource) ProtoMessage()    {}
 func (*NetworkResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{41}
+	return fileDescriptor_4a8f45747846a74d, []int{43}
 }
 
 func (m *NetworkResource) XXX_Unmarshal(b []byte) error {
@@ -2642,7 +2801,7 @@ func (m *NetworkPort) Reset()         { *m = NetworkPort{} }
 func (m *NetworkPort) String() string { return proto.CompactTextString(m) }
 func (*NetworkPort) ProtoMessage()    {}
 func (*NetworkPort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{42}
+	return fileDescriptor_4a8f45747846a74d, []int{44}
 }
 
 func (m *NetworkPort) XXX_Unmarshal(b []byte) error {
@@ -2691,7 +2850,7 @@ func (m *PortMapping) Reset()         { *m = PortMapping{} }
 func (m *PortMapping) String() string { return proto.CompactTextString(m) }
 func (*PortMapping) ProtoMessage()    {}
 func (*PortMapping) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{43}
+	return fileDescriptor_4a8f45747846a74d, []int{45}
 }
 
 func (m *PortMapping) XXX_Unmarshal(b []byte) error {
@@ -2768,7 +2927,7 @@ func (m *LinuxResources) Reset()         { *m = LinuxResources{} }
 func (m *LinuxResources) String() string { return proto.CompactTextString(m) }
 func (*LinuxResources) ProtoMessage()    {}
 func (*LinuxResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{44}
+	return fileDescriptor_4a8f45747846a74d, []int{46}
 }
 
 func (m *LinuxResources) XXX_Unmarshal(b []byte) error {
@@ -2865,7 +3024,7 @@ func (m *Mount) Reset()         { *m = Mount{} }
 func (m *Mount) String() string { return proto.CompactTextString(m) }
 func (*Mount) ProtoMessage()    {}
 func (*Mount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{45}
+	return fileDescriptor_4a8f45747846a74d, []int{47}
 }
 
 func (m *Mount) XXX_Unmarshal(b []byte) error {
@@ -2943,7 +3102,7 @@ func (m *Device) Reset()         { *m = Device{} }
 func (m *Device) String() string { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()    {}
 func (*Device) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{46}
+	return fileDescriptor_4a8f45747846a74d, []int{48}
 }
 
 func (m *Device) XXX_Unmarshal(b []byte) error {
@@ -3005,7 +3164,7 @@ func (m *TaskHandle) Reset()         { *m = TaskHandle{} }
 func (m *TaskHandle) String() string { return proto.CompactTextString(m) }
 func (*TaskHandle) ProtoMessage()    {}
 func (*TaskHandle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{47}
+	return fileDescriptor_4a8f45747846a74d, []int{49}
 }
 
 func (m *TaskHandle) XXX_Unmarshal(b []byte) error {
@@ -3073,7 +3232,7 @@ func (m *NetworkOverride) Reset()         { *m = NetworkOverride{} }
 func (m *NetworkOverride) String() string { return proto.CompactTextString(m) }
 func (*NetworkOverride) ProtoMessage()    {}
 func (*NetworkOverride) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{48}
+	return fileDescriptor_4a8f45747846a74d, []int{50}
 }
 
 func (m *NetworkOverride) XXX_Unmarshal(b []byte) error {
@@ -3132,7 +3291,7 @@ func (m *ExitResult) Reset()         { *m = ExitResult{} }
 func (m *ExitResult) String() string { return proto.CompactTextString(m) }
 func (*ExitResult) ProtoMessage()    {}
 func (*ExitResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{49}
+	return fileDescriptor_4a8f45747846a74d, []int{51}
 }
 
 func (m *ExitResult) XXX_Unmarshal(b []byte) error {
@@ -3196,7 +3355,7 @@ func (m *TaskStatus) Reset()         { *m = TaskStatus{} }
 func (m *TaskStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskStatus) ProtoMessage()    {}
 func (*TaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{50}
+	return fileDescriptor_4a8f45747846a74d, []int{52}
 }
 
 func (m *TaskStatus) XXX_Unmarshal(b []byte) error {
@@ -3272,7 +3431,7 @@ func (m *TaskDriverStatus) Reset()         { *m = TaskDriverStatus{} }
 func (m *TaskDriverStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskDriverStatus) ProtoMessage()    {}
 func (*TaskDriverStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{51}
+	return fileDescriptor_4a8f45747846a74d, []int{53}
 }
 
 func (m *TaskDriverStatus) XXX_Unmarshal(b []byte) error {
@@ -3318,7 +3477,7 @@ func (m *TaskStats) Reset()         { *m = TaskStats{} }
 func (m *TaskStats) String() string { return proto.CompactTextString(m) }
 func (*TaskStats) ProtoMessage()    {}
 func (*TaskStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{52}
+	return fileDescriptor_4a8f45747846a74d, []int{54}
 }
 
 func (m *TaskStats) XXX_Unmarshal(b []byte) error {
@@ -3371,17 +3530,19 @@ type TaskResourceUsage struct {
 	// CPU usage stats
 	Cpu *CPUUsage `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
 	// Memory usage stats
-	Memory               *MemoryUsage `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Memory *MemoryUsage `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	// Block I/O usage stats
+	Io                   *IOUsage `protobuf:"bytes,3,opt,name=io,proto3" json:"io,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TaskResourceUsage) Reset()         { *m = TaskResourceUsage{} }
 func (m *TaskResourceUsage) String() string { return proto.CompactTextString(m) }
 func (*TaskResourceUsage) ProtoMessage()    {}
 func (*TaskResourceUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{53}
+	return fileDescriptor_4a8f45747846a74d, []int{55}
 }
 
 func (m *TaskResourceUsage) XXX_Unmarshal(b []byte) error {
@@ -3416,6 +3577,13 @@ func (m *TaskResourceUsage) GetMemory() *MemoryUsage {
 	return nil
 }
 
+func (m *TaskResourceUsage) GetIo() *IOUsage {
+	if m != nil {
+		return m.Io
+	}
+	return nil
+}
+
 type CPUUsage struct {
 	SystemMode       float64 `protobuf:"fixed64,1,opt,name=system_mode,json=systemMode,proto3" json:"system_mode,omitempty"`
 	UserMode         float64 `protobuf:"fixed64,2,opt,name=user_mode,json=userMode,proto3" json:"user_mode,omitempty"`
@@ -3434,7 +3602,7 @@ func (m *CPUUsage) Reset()         { *m = CPUUsage{} }
 func (m *CPUUsage) String() string { return proto.CompactTextString(m) }
 func (*CPUUsage) ProtoMessage()    {}
 func (*CPUUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54}
+	return fileDescriptor_4a8f45747846a74d, []int{56}
 }
 
 func (m *CPUUsage) XXX_Unmarshal(b []byte) error {
@@ -3523,7 +3691,7 @@ func (m *MemoryUsage) Reset()         { *m = MemoryUsage{} }
 func (m *MemoryUsage) String() string { return proto.CompactTextString(m) }
 func (*MemoryUsage) ProtoMessage()    {}
 func (*MemoryUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55}
+	return fileDescriptor_4a8f45747846a74d, []int{57}
 }
 
 func (m *MemoryUsage) XXX_Unmarshal(b []byte) error {
@@ -3600,6 +3768,78 @@ func (m *MemoryUsage) GetMeasuredFields() []MemoryUsage_Fields {
 	return nil
 }
 
+type IOUsage struct {
+	ReadBytes  uint64 `protobuf:"varint,1,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+	WriteBytes uint64 `protobuf:"varint,2,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"`
+	ReadOps    uint64 `protobuf:"varint,3,opt,name=read_ops,json=readOps,proto3" json:"read_ops,omitempty"`
+	WriteOps   uint64 `protobuf:"varint,4,opt,name=write_ops,json=writeOps,proto3" json:"write_ops,omitempty"`
+	// MeasuredFields indicates which fields were actually sampled
+	MeasuredFields       []IOUsage_Fields `protobuf:"varint,5,rep,packed,name=measured_fields,json=measuredFields,proto3,enum=hashicorp.nomad.plugins.drivers.proto.IOUsage_Fields" json:"measured_fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *IOUsage) Reset()         { *m = IOUsage{} }
+func (m *IOUsage) String() string { return proto.CompactTextString(m) }
+func (*IOUsage) ProtoMessage()    {}
+func (*IOUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{58}
+}
+
+func (m *IOUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IOUsage.Unmarshal(m, b)
+}
+func (m *IOUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IOUsage.Marshal(b, m, deterministic)
+}
+func (m *IOUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IOUsage.Merge(m, src)
+}
+func (m *IOUsage) XXX_Size() int {
+	return xxx_messageInfo_IOUsage.Size(m)
+}
+func (m *IOUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_IOUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IOUsage proto.InternalMessageInfo
+
+func (m *IOUsage) GetReadBytes() uint64 {
+	if m != nil {
+		return m.ReadBytes
+	}
+	return 0
+}
+
+func (m *IOUsage) GetWriteBytes() uint64 {
+	if m != nil {
+		return m.WriteBytes
+	}
+	return 0
+}
+
+func (m *IOUsage) GetReadOps() uint64 {
+	if m != nil {
+		return m.ReadOps
+	}
+	return 0
+}
+
+func (m *IOUsage) GetWriteOps() uint64 {
+	if m != nil {
+		return m.WriteOps
+	}
+	return 0
+}
+
+func (m *IOUsage) GetMeasuredFields() []IOUsage_Fields {
+	if m != nil {
+		return m.MeasuredFields
+	}
+	return nil
+}
+
 type DriverTaskEvent struct {
 	// TaskId is the id of the task for the event
 	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
@@ -3622,7 +3862,7 @@ func (m *DriverTaskEvent) Reset()         { *m = DriverTaskEvent{} }
 func (m *DriverTaskEvent) String() string { return proto.CompactTextString(m) }
 func (*DriverTaskEvent) ProtoMessage()    {}
 func (*DriverTaskEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{56}
+	return fileDescriptor_4a8f45747846a74d, []int{59}
 }
 
 func (m *DriverTaskEvent) XXX_Unmarshal(b []byte) error {
@@ -3694,6 +3934,7 @@ func init() {
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode", NetworkIsolationSpec_NetworkIsolationMode_name, NetworkIsolationSpec_NetworkIsolationMode_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.CPUUsage_Fields", CPUUsage_Fields_name, CPUUsage_Fields_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.MemoryUsage_Fields", MemoryUsage_Fields_name, MemoryUsage_Fields_value)
+	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.IOUsage_Fields", IOUsage_Fields_name, IOUsage_Fields_value)
 	proto.RegisterType((*TaskConfigSchemaRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskConfigSchemaRequest")
 	proto.RegisterType((*TaskConfigSchemaResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskConfigSchemaResponse")
 	proto.RegisterType((*CapabilitiesRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.CapabilitiesRequest")
@@ -3741,6 +3982,8 @@ func init() {
 	proto.RegisterType((*AllocatedTaskResources)(nil), "hashicorp.nomad.plugins.drivers.proto.AllocatedTaskResources")
 	proto.RegisterType((*AllocatedCpuResources)(nil), "hashicorp.nomad.plugins.drivers.proto.AllocatedCpuResources")
 	proto.RegisterType((*AllocatedMemoryResources)(nil), "hashicorp.nomad.plugins.drivers.proto.AllocatedMemoryResources")
+	proto.RegisterType((*AllocatedIOResources)(nil), "hashicorp.nomad.plugins.drivers.proto.AllocatedIOResources")
+	proto.RegisterType((*AllocatedIODeviceResources)(nil), "hashicorp.nomad.plugins.drivers.proto.AllocatedIODeviceResources")
 	proto.RegisterType((*NetworkResource)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkResource")
 	proto.RegisterType((*NetworkPort)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkPort")
 	proto.RegisterType((*PortMapping)(nil), "hashicorp.nomad.plugins.drivers.proto.PortMapping")
@@ -3759,6 +4002,7 @@ func init() {
 	proto.RegisterType((*TaskResourceUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.TaskResourceUsage")
 	proto.RegisterType((*CPUUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.CPUUsage")
 	proto.RegisterType((*MemoryUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.MemoryUsage")
+	proto.RegisterType((*IOUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.IOUsage")
 	proto.RegisterType((*DriverTaskEvent)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent.AnnotationsEntry")
 }
@@ -3768,254 +4012,268 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3945 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4f, 0x73, 0xdb, 0x48,
-	0x76, 0x17, 0x08, 0x92, 0x22, 0x1f, 0x25, 0x0a, 0x6a, 0x49, 0x36, 0xcd, 0xd9, 0x64, 0xbc, 0xd8,
-	0x9a, 0x94, 0xb2, 0x3b, 0x43, 0xcf, 0x6a, 0x93, 0xf1, 0xd8, 0xeb, 0x59, 0x0f, 0x87, 0xa2, 0x2d,
-	0xda, 0x12, 0xa5, 0x34, 0xa9, 0x78, 0x1d, 0x27, 0x83, 0x40, 0x40, 0x9b, 0x82, 0x45, 0x02, 0x18,
-	0x34, 0x28, 0x4b, 0x9b, 0x4a, 0x25, 0xb5, 0xa9, 0x4a, 0x6d, 0xaa, 0x92, 0x4a, 0x2e, 0x93, 0xbd,
-	0xe4, 0xb4, 0x55, 0x39, 0xa5, 0x72, 0x4f, 0x6d, 0x6a, 0x4e, 0x39, 0xe4, 0x4b, 0xe4, 0x92, 0x5b,
-	0x6e, 0xa9, 0x7c, 0x82, 0x6c, 0xf5, 0x1f, 0x80, 0x80, 0x48, 0x8f, 0x41, 0xca, 0x27, 0xf2, 0xbd,
-	0xee, 0xfe, 0xf5, 0xc3, 0x7b, 0xaf, 0x5f, 0xbf, 0xee, 0x7e, 0xa0, 0xfb, 0xc3, 0xf1, 0xc0, 0x71,
-	0xe9, 0x1d, 0x3b, 0x70, 0xce, 0x49, 0x40, 0xef, 0xf8, 0x81, 0x17, 0x7a, 0x92, 0x6a, 0x70, 0x02,
-	0x7d, 0x70, 0x6a, 0xd2, 0x53, 0xc7, 0xf2, 0x02, 0xbf, 0xe1, 0x7a, 0x23, 0xd3, 0x6e, 0xc8, 0x31,
-	0x0d, 0x39, 0x46, 0x74, 0xab, 0xff, 0xf6, 0xc0, 0xf3, 0x06, 0x43, 0x22, 0x10, 0x4e, 0xc6, 0x2f,
-	0xef, 0xd8, 0xe3, 0xc0, 0x0c, 0x1d, 0xcf, 0x95, 0xed, 0xef, 0x5f, 0x6d, 0x0f, 0x9d, 0x11, 0xa1,
-	0xa1, 0x39, 0xf2, 0x65, 0x87, 0x0f, 0x22, 0x59, 0xe8, 0xa9, 0x19, 0x10, 0xfb, 0xce, 0xa9, 0x35,
-	0xa4, 0x3e, 0xb1, 0xd8, 0xaf, 0xc1, 0xfe, 0xc8, 0x6e, 0x1f, 0x5e, 0xe9, 0x46, 0xc3, 0x60, 0x6c,
-	0x85, 0x91, 0xe4, 0x66, 0x18, 0x06, 0xce, 0xc9, 0x38, 0x24, 0xa2, 0xb7, 0x7e, 0x0b, 0x6e, 0xf6,
-	0x4d, 0x7a, 0xd6, 0xf2, 0xdc, 0x97, 0xce, 0xa0, 0x67, 0x9d, 0x92, 0x91, 0x89, 0xc9, 0x57, 0x63,
-	0x42, 0x43, 0xfd, 0x8f, 0xa1, 0x36, 0xdd, 0x44, 0x7d, 0xcf, 0xa5, 0x04, 0x7d, 0x0e, 0x79, 0x36,
-	0x65, 0x4d, 0xb9, 0xad, 0x6c, 0x57, 0x76, 0x3e, 0x6c, 0xbc, 0x49, 0x05, 0x42, 0x86, 0x86, 0x14,
-	0xb5, 0xd1, 0xf3, 0x89, 0x85, 0xf9, 0x48, 0x7d, 0x0b, 0x36, 0x5a, 0xa6, 0x6f, 0x9e, 0x38, 0x43,
-	0x27, 0x74, 0x08, 0x8d, 0x26, 0x1d, 0xc3, 0x66, 0x9a, 0x2d, 0x27, 0xfc, 0x13, 0x58, 0xb1, 0x12,
-	0x7c, 0x39, 0xf1, 0xbd, 0x46, 0x26, 0xdd, 0x37, 0x76, 0x39, 0x95, 0x02, 0x4e, 0xc1, 0xe9, 0x9b,
-	0x80, 0x1e, 0x39, 0xee, 0x80, 0x04, 0x7e, 0xe0, 0xb8, 0x61, 0x24, 0xcc, 0x37, 0x2a, 0x6c, 0xa4,
-	0xd8, 0x52, 0x98, 0x57, 0x00, 0xb1, 0x1e, 0x99, 0x28, 0xea, 0x76, 0x65, 0xe7, 0x49, 0x46, 0x51,
-	0x66, 0xe0, 0x35, 0x9a, 0x31, 0x58, 0xdb, 0x0d, 0x83, 0x4b, 0x9c, 0x40, 0x47, 0x5f, 0x42, 0xf1,
-	0x94, 0x98, 0xc3, 0xf0, 0xb4, 0x96, 0xbb, 0xad, 0x6c, 0x57, 0x77, 0x1e, 0x5d, 0x63, 0x9e, 0x3d,
-	0x0e, 0xd4, 0x0b, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x02, 0x24, 0xfe, 0x19, 0x36, 0xa1, 0x56,
-	0xe0, 0xf8, 0xcc, 0x25, 0x6b, 0xea, 0x6d, 0x65, 0xbb, 0x8c, 0xd7, 0x45, 0xcb, 0xee, 0xa4, 0xa1,
-	0xee, 0xc3, 0xda, 0x15, 0x69, 0x91, 0x06, 0xea, 0x19, 0xb9, 0xe4, 0x16, 0x29, 0x63, 0xf6, 0x17,
-	0x3d, 0x86, 0xc2, 0xb9, 0x39, 0x1c, 0x13, 0x2e, 0x72, 0x65, 0xe7, 0x87, 0x6f, 0x73, 0x0f, 0xe9,
-	0xa2, 0x13, 0x3d, 0x60, 0x31, 0xfe, 0x7e, 0xee, 0x53, 0x45, 0xbf, 0x07, 0x95, 0x84, 0xdc, 0xa8,
-	0x0a, 0x70, 0xdc, 0xdd, 0x6d, 0xf7, 0xdb, 0xad, 0x7e, 0x7b, 0x57, 0x5b, 0x42, 0xab, 0x50, 0x3e,
-	0xee, 0xee, 0xb5, 0x9b, 0xfb, 0xfd, 0xbd, 0xe7, 0x9a, 0x82, 0x2a, 0xb0, 0x1c, 0x11, 0x39, 0xfd,
-	0x02, 0x10, 0x26, 0x96, 0x77, 0x4e, 0x02, 0xe6, 0xc8, 0xd2, 0xaa, 0xe8, 0x26, 0x2c, 0x87, 0x26,
-	0x3d, 0x33, 0x1c, 0x5b, 0xca, 0x5c, 0x64, 0x64, 0xc7, 0x46, 0x1d, 0x28, 0x9e, 0x9a, 0xae, 0x3d,
-	0x7c, 0xbb, 0xdc, 0x69, 0x55, 0x33, 0xf0, 0x3d, 0x3e, 0x10, 0x4b, 0x00, 0xe6, 0xdd, 0xa9, 0x99,
-	0x85, 0x01, 0xf4, 0xe7, 0xa0, 0xf5, 0x42, 0x33, 0x08, 0x93, 0xe2, 0xb4, 0x21, 0xcf, 0xe6, 0x97,
-	0x1e, 0x3d, 0xcf, 0x9c, 0x62, 0x65, 0x62, 0x3e, 0x5c, 0xff, 0xbf, 0x1c, 0xac, 0x27, 0xb0, 0xa5,
-	0xa7, 0x3e, 0x83, 0x62, 0x40, 0xe8, 0x78, 0x18, 0x72, 0xf8, 0xea, 0xce, 0xc3, 0x8c, 0xf0, 0x53,
-	0x48, 0x0d, 0xcc, 0x61, 0xb0, 0x84, 0x43, 0xdb, 0xa0, 0x89, 0x11, 0x06, 0x09, 0x02, 0x2f, 0x30,
-	0x46, 0x74, 0xc0, 0xb5, 0x56, 0xc6, 0x55, 0xc1, 0x6f, 0x33, 0xf6, 0x01, 0x1d, 0x24, 0xb4, 0xaa,
-	0x5e, 0x53, 0xab, 0xc8, 0x04, 0xcd, 0x25, 0xe1, 0x6b, 0x2f, 0x38, 0x33, 0x98, 0x6a, 0x03, 0xc7,
-	0x26, 0xb5, 0x3c, 0x07, 0xfd, 0x24, 0x23, 0x68, 0x57, 0x0c, 0x3f, 0x94, 0xa3, 0xf1, 0x9a, 0x9b,
-	0x66, 0xe8, 0x3f, 0x80, 0xa2, 0xf8, 0x52, 0xe6, 0x49, 0xbd, 0xe3, 0x56, 0xab, 0xdd, 0xeb, 0x69,
-	0x4b, 0xa8, 0x0c, 0x05, 0xdc, 0xee, 0x63, 0xe6, 0x61, 0x65, 0x28, 0x3c, 0x6a, 0xf6, 0x9b, 0xfb,
-	0x5a, 0x4e, 0xff, 0x3e, 0xac, 0x3d, 0x33, 0x9d, 0x30, 0x8b, 0x73, 0xe9, 0x1e, 0x68, 0x93, 0xbe,
-	0xd2, 0x3a, 0x9d, 0x94, 0x75, 0xb2, 0xab, 0xa6, 0x7d, 0xe1, 0x84, 0x57, 0xec, 0xa1, 0x81, 0x4a,
-	0x82, 0x40, 0x9a, 0x80, 0xfd, 0xd5, 0x5f, 0xc3, 0x5a, 0x2f, 0xf4, 0xfc, 0x4c, 0x9e, 0xff, 0x23,
-	0x58, 0x66, 0xbb, 0x8d, 0x37, 0x0e, 0xa5, 0xeb, 0xdf, 0x6a, 0x88, 0xdd, 0xa8, 0x11, 0xed, 0x46,
-	0x8d, 0x5d, 0xb9, 0x5b, 0xe1, 0xa8, 0x27, 0xba, 0x01, 0x45, 0xea, 0x0c, 0x5c, 0x73, 0x28, 0xa3,
-	0x85, 0xa4, 0x74, 0xc4, 0x9c, 0x3c, 0x9a, 0x58, 0x3a, 0x7e, 0x0b, 0xd0, 0x2e, 0xa1, 0x61, 0xe0,
-	0x5d, 0x66, 0x92, 0x67, 0x13, 0x0a, 0x2f, 0xbd, 0xc0, 0x12, 0x0b, 0xb1, 0x84, 0x05, 0xc1, 0x16,
-	0x55, 0x0a, 0x44, 0x62, 0x7f, 0x04, 0xa8, 0xe3, 0xb2, 0x3d, 0x25, 0x9b, 0x21, 0xfe, 0x21, 0x07,
-	0x1b, 0xa9, 0xfe, 0xd2, 0x18, 0x8b, 0xaf, 0x43, 0x16, 0x98, 0xc6, 0x54, 0xac, 0x43, 0x74, 0x08,
-	0x45, 0xd1, 0x43, 0x6a, 0xf2, 0xee, 0x1c, 0x40, 0x62, 0x9b, 0x92, 0x70, 0x12, 0x66, 0xa6, 0xd3,
-	0xab, 0xef, 0xd6, 0xe9, 0x5f, 0x83, 0x16, 0x7d, 0x07, 0x7d, 0xab, 0x6d, 0x9e, 0xc0, 0x86, 0xe5,
-	0x0d, 0x87, 0xc4, 0x62, 0xde, 0x60, 0x38, 0x6e, 0x48, 0x82, 0x73, 0x73, 0xf8, 0x76, 0xbf, 0x41,
-	0x93, 0x51, 0x1d, 0x39, 0x48, 0x7f, 0x01, 0xeb, 0x89, 0x89, 0xa5, 0x21, 0x1e, 0x41, 0x81, 0x32,
-	0x86, 0xb4, 0xc4, 0xc7, 0x73, 0x5a, 0x82, 0x62, 0x31, 0x5c, 0xdf, 0x10, 0xe0, 0xed, 0x73, 0xe2,
-	0xc6, 0x9f, 0xa5, 0xef, 0xc2, 0x7a, 0x8f, 0xbb, 0x69, 0x26, 0x3f, 0x9c, 0xb8, 0x78, 0x2e, 0xe5,
-	0xe2, 0x9b, 0x80, 0x92, 0x28, 0xd2, 0x11, 0x2f, 0x61, 0xad, 0x7d, 0x41, 0xac, 0x4c, 0xc8, 0x35,
-	0x58, 0xb6, 0xbc, 0xd1, 0xc8, 0x74, 0xed, 0x5a, 0xee, 0xb6, 0xba, 0x5d, 0xc6, 0x11, 0x99, 0x5c,
-	0x8b, 0x6a, 0xd6, 0xb5, 0xa8, 0xff, 0x9d, 0x02, 0xda, 0x64, 0x6e, 0xa9, 0x48, 0x26, 0x7d, 0x68,
-	0x33, 0x20, 0x36, 0xf7, 0x0a, 0x96, 0x94, 0xe4, 0x47, 0xe1, 0x42, 0xf0, 0x49, 0x10, 0x24, 0xc2,
-	0x91, 0x7a, 0xcd, 0x70, 0xa4, 0xef, 0xc1, 0x77, 0x22, 0x71, 0x7a, 0x61, 0x40, 0xcc, 0x91, 0xe3,
-	0x0e, 0x3a, 0x87, 0x87, 0x3e, 0x11, 0x82, 0x23, 0x04, 0x79, 0xdb, 0x0c, 0x4d, 0x29, 0x18, 0xff,
-	0xcf, 0x16, 0xbd, 0x35, 0xf4, 0x68, 0xbc, 0xe8, 0x39, 0xa1, 0xff, 0xa7, 0x0a, 0xb5, 0x29, 0xa8,
-	0x48, 0xbd, 0x2f, 0xa0, 0x40, 0x49, 0x38, 0xf6, 0xa5, 0xab, 0xb4, 0x33, 0x0b, 0x3c, 0x1b, 0xaf,
-	0xd1, 0x63, 0x60, 0x58, 0x60, 0xa2, 0x01, 0x94, 0xc2, 0xf0, 0xd2, 0xa0, 0xce, 0xcf, 0xa2, 0x84,
-	0x60, 0xff, 0xba, 0xf8, 0x7d, 0x12, 0x8c, 0x1c, 0xd7, 0x1c, 0xf6, 0x9c, 0x9f, 0x11, 0xbc, 0x1c,
-	0x86, 0x97, 0xec, 0x0f, 0x7a, 0xce, 0x1c, 0xde, 0x76, 0x5c, 0xa9, 0xf6, 0xd6, 0xa2, 0xb3, 0x24,
-	0x14, 0x8c, 0x05, 0x62, 0x7d, 0x1f, 0x0a, 0xfc, 0x9b, 0x16, 0x71, 0x44, 0x0d, 0xd4, 0x30, 0xbc,
-	0xe4, 0x42, 0x95, 0x30, 0xfb, 0x5b, 0x7f, 0x00, 0x2b, 0xc9, 0x2f, 0x60, 0x8e, 0x74, 0x4a, 0x9c,
-	0xc1, 0xa9, 0x70, 0xb0, 0x02, 0x96, 0x14, 0xb3, 0xe4, 0x6b, 0xc7, 0x96, 0x29, 0x6b, 0x01, 0x0b,
-	0x42, 0xff, 0xb7, 0x1c, 0xdc, 0x9a, 0xa1, 0x19, 0xe9, 0xac, 0x2f, 0x52, 0xce, 0xfa, 0x8e, 0xb4,
-	0x10, 0x79, 0xfc, 0x8b, 0x94, 0xc7, 0xbf, 0x43, 0x70, 0xb6, 0x6c, 0x6e, 0x40, 0x91, 0x5c, 0x38,
-	0x21, 0xb1, 0xa5, 0xaa, 0x24, 0x95, 0x58, 0x4e, 0xf9, 0xeb, 0x2e, 0xa7, 0x03, 0xd8, 0x6c, 0x05,
-	0xc4, 0x0c, 0x89, 0x0c, 0xe5, 0x91, 0xff, 0xdf, 0x82, 0x92, 0x39, 0x1c, 0x7a, 0xd6, 0xc4, 0xac,
-	0xcb, 0x9c, 0xee, 0xd8, 0xa8, 0x0e, 0xa5, 0x53, 0x8f, 0x86, 0xae, 0x39, 0x22, 0x32, 0x78, 0xc5,
-	0xb4, 0xfe, 0xb5, 0x02, 0x5b, 0x57, 0xf0, 0xa4, 0x15, 0x4e, 0xa0, 0xea, 0x50, 0x6f, 0xc8, 0x3f,
-	0xd0, 0x48, 0x9c, 0xf0, 0x7e, 0x3c, 0xdf, 0x56, 0xd3, 0x89, 0x30, 0xf8, 0x81, 0x6f, 0xd5, 0x49,
-	0x92, 0xdc, 0xe3, 0xf8, 0xe4, 0xb6, 0x5c, 0xe9, 0x11, 0xa9, 0xff, 0xa3, 0x02, 0x5b, 0x72, 0x87,
-	0xcf, 0xfe, 0xa1, 0xd3, 0x22, 0xe7, 0xde, 0xb5, 0xc8, 0x7a, 0x0d, 0x6e, 0x5c, 0x95, 0x4b, 0xc6,
-	0xfc, 0xff, 0x2d, 0x00, 0x9a, 0x3e, 0x5d, 0xa2, 0xef, 0xc2, 0x0a, 0x25, 0xae, 0x6d, 0x88, 0xfd,
-	0x42, 0x6c, 0x65, 0x25, 0x5c, 0x61, 0x3c, 0xb1, 0x71, 0x50, 0x16, 0x02, 0xc9, 0x85, 0x94, 0xb6,
-	0x84, 0xf9, 0x7f, 0x74, 0x0a, 0x2b, 0x2f, 0xa9, 0x11, 0xcf, 0xcd, 0x1d, 0xaa, 0x9a, 0x39, 0xac,
-	0x4d, 0xcb, 0xd1, 0x78, 0xd4, 0x8b, 0xbf, 0x0b, 0x57, 0x5e, 0xd2, 0x98, 0x40, 0xbf, 0x50, 0xe0,
-	0x66, 0x94, 0x56, 0x4c, 0xd4, 0x37, 0xf2, 0x6c, 0x42, 0x6b, 0xf9, 0xdb, 0xea, 0x76, 0x75, 0xe7,
-	0xe8, 0x1a, 0xfa, 0x9b, 0x62, 0x1e, 0x78, 0x36, 0xc1, 0x5b, 0xee, 0x0c, 0x2e, 0x45, 0x0d, 0xd8,
-	0x18, 0x8d, 0x69, 0x68, 0x08, 0x2f, 0x30, 0x64, 0xa7, 0x5a, 0x81, 0xeb, 0x65, 0x9d, 0x35, 0xa5,
-	0x7c, 0x15, 0x9d, 0xc1, 0xea, 0xc8, 0x1b, 0xbb, 0xa1, 0x61, 0xf1, 0xf3, 0x0f, 0xad, 0x15, 0xe7,
-	0x3a, 0x18, 0xcf, 0xd0, 0xd2, 0x01, 0x83, 0x13, 0xa7, 0x29, 0x8a, 0x57, 0x46, 0x09, 0x8a, 0x19,
-	0x32, 0x20, 0x23, 0x2f, 0x24, 0x06, 0x8b, 0x97, 0xb4, 0xb6, 0x2c, 0x0c, 0x29, 0x78, 0x2c, 0x34,
-	0x50, 0xf4, 0x7b, 0x70, 0xc3, 0x76, 0xa8, 0x79, 0x32, 0x24, 0xc6, 0xd0, 0x1b, 0x18, 0x93, 0x34,
-	0xa7, 0x56, 0xe2, 0x9d, 0x37, 0x65, 0xeb, 0xbe, 0x37, 0x68, 0xc5, 0x6d, 0x7c, 0xd4, 0xa5, 0x6b,
-	0x8e, 0x1c, 0xcb, 0x60, 0x5f, 0x35, 0xf4, 0x4c, 0xdb, 0x18, 0x53, 0x12, 0xd0, 0x5a, 0x59, 0x8e,
-	0x12, 0xad, 0xcf, 0x64, 0xe3, 0x31, 0x6b, 0xd3, 0xef, 0x43, 0x25, 0x61, 0x52, 0x54, 0x82, 0x7c,
-	0xf7, 0xb0, 0xdb, 0xd6, 0x96, 0x10, 0x40, 0xb1, 0xb5, 0x87, 0x0f, 0x0f, 0xfb, 0xe2, 0x84, 0xd2,
-	0x39, 0x68, 0x3e, 0x6e, 0x6b, 0x39, 0xc6, 0x3e, 0xee, 0xfe, 0x61, 0xbb, 0xb3, 0xaf, 0xa9, 0x7a,
-	0x1b, 0x56, 0x92, 0x1f, 0x8a, 0x10, 0x54, 0x8f, 0xbb, 0x4f, 0xbb, 0x87, 0xcf, 0xba, 0xc6, 0xc1,
-	0xe1, 0x71, 0xb7, 0xcf, 0xce, 0x39, 0x55, 0x80, 0x66, 0xf7, 0xf9, 0x84, 0x5e, 0x85, 0x72, 0xf7,
-	0x30, 0x22, 0x95, 0x7a, 0x4e, 0x53, 0xf4, 0xff, 0x50, 0x61, 0x73, 0x96, 0xcd, 0x91, 0x0d, 0x79,
-	0xe6, 0x3f, 0xf2, 0xa4, 0xf9, 0xee, 0xdd, 0x87, 0xa3, 0xb3, 0x65, 0xe3, 0x9b, 0x72, 0x6b, 0x29,
-	0x63, 0xfe, 0x1f, 0x19, 0x50, 0x1c, 0x9a, 0x27, 0x64, 0x48, 0x6b, 0x2a, 0xbf, 0x8b, 0x79, 0x7c,
-	0x9d, 0xb9, 0xf7, 0x39, 0x92, 0xb8, 0x88, 0x91, 0xb0, 0xa8, 0x0f, 0x15, 0x16, 0x3c, 0xa9, 0x50,
-	0x9d, 0x8c, 0xe7, 0x3b, 0x19, 0x67, 0xd9, 0x9b, 0x8c, 0xc4, 0x49, 0x98, 0xfa, 0x3d, 0xa8, 0x24,
-	0x26, 0x9b, 0x71, 0x8f, 0xb2, 0x99, 0xbc, 0x47, 0x29, 0x27, 0x2f, 0x45, 0x1e, 0x4e, 0xdb, 0x80,
-	0xe9, 0x88, 0x39, 0xc4, 0xde, 0x61, 0xaf, 0x2f, 0x4e, 0xac, 0x8f, 0xf1, 0xe1, 0xf1, 0x91, 0xa6,
-	0x30, 0x66, 0xbf, 0xd9, 0x7b, 0xaa, 0xe5, 0x62, 0x7f, 0x51, 0xf5, 0x16, 0x54, 0x12, 0x72, 0xa5,
-	0x76, 0x0b, 0x25, 0xbd, 0x5b, 0xb0, 0x78, 0x6d, 0xda, 0x76, 0x40, 0x28, 0x95, 0x72, 0x44, 0xa4,
-	0xfe, 0x02, 0xca, 0xbb, 0xdd, 0x9e, 0x84, 0xa8, 0xc1, 0x32, 0x25, 0x01, 0xfb, 0x6e, 0x7e, 0x23,
-	0x56, 0xc6, 0x11, 0xc9, 0xc0, 0x29, 0x31, 0x03, 0xeb, 0x94, 0x50, 0x99, 0x63, 0xc4, 0x34, 0x1b,
-	0xe5, 0xf1, 0x9b, 0x25, 0x61, 0xbb, 0x32, 0x8e, 0x48, 0xfd, 0xff, 0x4b, 0x00, 0x93, 0x5b, 0x0e,
-	0x54, 0x85, 0x5c, 0x1c, 0xfb, 0x73, 0x8e, 0xcd, 0xfc, 0x20, 0xb1, 0xb7, 0xf1, 0xff, 0x68, 0x07,
-	0xb6, 0x46, 0x74, 0xe0, 0x9b, 0xd6, 0x99, 0x21, 0x2f, 0x27, 0x44, 0x88, 0xe0, 0x71, 0x74, 0x05,
-	0x6f, 0xc8, 0x46, 0x19, 0x01, 0x04, 0xee, 0x3e, 0xa8, 0xc4, 0x3d, 0xe7, 0x31, 0xaf, 0xb2, 0x73,
-	0x7f, 0xee, 0xdb, 0x97, 0x46, 0xdb, 0x3d, 0x17, 0xbe, 0xc2, 0x60, 0x90, 0x01, 0x60, 0x93, 0x73,
-	0xc7, 0x22, 0x06, 0x03, 0x2d, 0x70, 0xd0, 0xcf, 0xe7, 0x07, 0xdd, 0xe5, 0x18, 0x31, 0x74, 0xd9,
-	0x8e, 0x68, 0xd4, 0x85, 0x72, 0x40, 0xa8, 0x37, 0x0e, 0x2c, 0x22, 0x02, 0x5f, 0xf6, 0x03, 0x12,
-	0x8e, 0xc6, 0xe1, 0x09, 0x04, 0xda, 0x85, 0x22, 0x8f, 0x77, 0x2c, 0xb2, 0xa9, 0xdf, 0x7a, 0x95,
-	0x9b, 0x06, 0xe3, 0x91, 0x04, 0xcb, 0xb1, 0xe8, 0x31, 0x2c, 0x0b, 0x11, 0x69, 0xad, 0xc4, 0x61,
-	0x3e, 0xca, 0x1a, 0x8c, 0xf9, 0x28, 0x1c, 0x8d, 0x66, 0x56, 0x65, 0x41, 0x90, 0xc7, 0xc0, 0x32,
-	0xe6, 0xff, 0xd1, 0x7b, 0x50, 0x16, 0x7b, 0xbf, 0xed, 0x04, 0x35, 0x10, 0xce, 0xc9, 0x19, 0xbb,
-	0x4e, 0x80, 0xde, 0x87, 0x8a, 0xc8, 0xf1, 0x0c, 0x1e, 0x15, 0x2a, 0xbc, 0x19, 0x04, 0xeb, 0x88,
-	0xc5, 0x06, 0xd1, 0x81, 0x04, 0x81, 0xe8, 0xb0, 0x12, 0x77, 0x20, 0x41, 0xc0, 0x3b, 0xfc, 0x0e,
-	0xac, 0xf1, 0xcc, 0x78, 0x10, 0x78, 0x63, 0xdf, 0xe0, 0x3e, 0xb5, 0xca, 0x3b, 0xad, 0x32, 0xf6,
-	0x63, 0xc6, 0xed, 0x32, 0xe7, 0xba, 0x05, 0xa5, 0x57, 0xde, 0x89, 0xe8, 0x50, 0x15, 0xeb, 0xe0,
-	0x95, 0x77, 0x12, 0x35, 0xc5, 0xd9, 0xc9, 0x5a, 0x3a, 0x3b, 0xf9, 0x0a, 0x6e, 0x4c, 0x6f, 0xb3,
-	0x3c, 0x4b, 0xd1, 0xae, 0x9f, 0xa5, 0x6c, 0xba, 0xb3, 0xe2, 0xf0, 0x17, 0xa0, 0xda, 0x2e, 0xad,
-	0xad, 0xcf, 0xe5, 0x1c, 0xf1, 0x3a, 0xc6, 0x6c, 0x30, 0xda, 0x82, 0x22, 0xfb, 0x58, 0xc7, 0xae,
-	0x21, 0x11, 0x7a, 0x5e, 0x79, 0x27, 0x1d, 0x1b, 0x7d, 0x07, 0xca, 0xec, 0xfb, 0xa9, 0x6f, 0x5a,
-	0xa4, 0xb6, 0xc1, 0x5b, 0x26, 0x0c, 0x66, 0x28, 0xd7, 0xb3, 0x89, 0x50, 0xd1, 0xa6, 0x30, 0x14,
-	0x63, 0x70, 0x1d, 0xdd, 0x84, 0x65, 0xde, 0xe8, 0xd8, 0xb5, 0x2d, 0x71, 0x00, 0x61, 0x64, 0xc7,
-	0x46, 0x3a, 0xac, 0xfa, 0x66, 0x40, 0xdc, 0xd0, 0x90, 0x33, 0xde, 0xe0, 0xcd, 0x15, 0xc1, 0x7c,
-	0xc2, 0xe6, 0xad, 0x7f, 0x02, 0xa5, 0x68, 0x31, 0xcc, 0x13, 0x26, 0xeb, 0x0f, 0xa0, 0x9a, 0x5e,
-	0x4a, 0x73, 0x05, 0xd9, 0x7f, 0xce, 0x41, 0x39, 0x5e, 0x34, 0xc8, 0x85, 0x0d, 0x6e, 0x54, 0x96,
-	0xa9, 0x1a, 0x93, 0x35, 0x28, 0xf2, 0xe3, 0xcf, 0x32, 0xaa, 0xb9, 0x19, 0x21, 0xc8, 0x83, 0xba,
-	0x5c, 0x90, 0x28, 0x46, 0x9e, 0xcc, 0xf7, 0x25, 0xac, 0x0d, 0x1d, 0x77, 0x7c, 0x91, 0x98, 0x4b,
-	0x24, 0xb6, 0xbf, 0x9f, 0x71, 0xae, 0x7d, 0x36, 0x7a, 0x32, 0x47, 0x75, 0x98, 0xa2, 0xd1, 0x1e,
-	0x14, 0x7c, 0x2f, 0x08, 0xa3, 0x3d, 0x33, 0xeb, 0x6e, 0x76, 0xe4, 0x05, 0xe1, 0x81, 0xe9, 0xfb,
-	0xec, 0xec, 0x26, 0x00, 0xf4, 0xaf, 0x73, 0x70, 0x63, 0xf6, 0x87, 0xa1, 0x2e, 0xa8, 0x96, 0x3f,
-	0x96, 0x4a, 0x7a, 0x30, 0xaf, 0x92, 0x5a, 0xfe, 0x78, 0x22, 0x3f, 0x03, 0x42, 0xcf, 0xa0, 0x38,
-	0x22, 0x23, 0x2f, 0xb8, 0x94, 0xba, 0x78, 0x38, 0x2f, 0xe4, 0x01, 0x1f, 0x3d, 0x41, 0x95, 0x70,
-	0x08, 0x43, 0x49, 0x2e, 0x26, 0x2a, 0xc3, 0xf6, 0x9c, 0xb7, 0x6b, 0x11, 0x24, 0x8e, 0x71, 0xf4,
-	0x4f, 0x60, 0x6b, 0xe6, 0xa7, 0xa0, 0xdf, 0x02, 0xb0, 0xfc, 0xb1, 0xc1, 0x5f, 0x3f, 0x84, 0x07,
-	0xa9, 0xb8, 0x6c, 0xf9, 0xe3, 0x1e, 0x67, 0xe8, 0x2f, 0xa0, 0xf6, 0x26, 0x79, 0xd9, 0x1a, 0x13,
-	0x12, 0x1b, 0xa3, 0x13, 0xae, 0x03, 0x15, 0x97, 0x04, 0xe3, 0xe0, 0x84, 0x2d, 0xa5, 0xa8, 0xd1,
-	0xbc, 0x60, 0x1d, 0x54, 0xde, 0xa1, 0x22, 0x3b, 0x98, 0x17, 0x07, 0x27, 0xfa, 0x2f, 0x73, 0xb0,
-	0x76, 0x45, 0x64, 0x76, 0x82, 0x15, 0x01, 0x38, 0xba, 0x1b, 0x10, 0x14, 0x8b, 0xc6, 0x96, 0x63,
-	0x47, 0xb7, 0xca, 0xfc, 0x3f, 0xdf, 0x87, 0x7d, 0x79, 0xe3, 0x9b, 0x73, 0x7c, 0xb6, 0x7c, 0x46,
-	0x27, 0x4e, 0x48, 0x79, 0x52, 0x54, 0xc0, 0x82, 0x40, 0xcf, 0xa1, 0x1a, 0x10, 0xbe, 0xff, 0xdb,
-	0x86, 0xf0, 0xb2, 0xc2, 0x5c, 0x5e, 0x26, 0x25, 0x64, 0xce, 0x86, 0x57, 0x23, 0x24, 0x46, 0x51,
-	0xf4, 0x0c, 0x56, 0xa3, 0xc4, 0x59, 0x20, 0x17, 0x17, 0x46, 0x5e, 0x91, 0x40, 0x1c, 0x58, 0xbf,
-	0x07, 0x95, 0x44, 0x23, 0xfb, 0x30, 0x9e, 0xfd, 0x49, 0x9d, 0x08, 0x22, 0x1d, 0x2d, 0x0a, 0x32,
-	0x5a, 0xe8, 0x27, 0x50, 0x49, 0xac, 0x8b, 0x79, 0x86, 0x32, 0x7d, 0x86, 0x1e, 0xd7, 0x67, 0x01,
-	0xe7, 0x42, 0x8f, 0xc5, 0x49, 0x96, 0x79, 0x19, 0x8e, 0xcf, 0x35, 0x5a, 0xc6, 0x45, 0x46, 0x76,
-	0x7c, 0xfd, 0xd7, 0x39, 0xa8, 0xa6, 0x97, 0x74, 0xe4, 0x47, 0x3e, 0x09, 0x1c, 0xcf, 0x4e, 0xf8,
-	0xd1, 0x11, 0x67, 0x30, 0x5f, 0x61, 0xcd, 0x5f, 0x8d, 0xbd, 0xd0, 0x8c, 0x7c, 0xc5, 0xf2, 0xc7,
-	0x7f, 0xc0, 0xe8, 0x2b, 0x3e, 0xa8, 0x5e, 0xf1, 0x41, 0xf4, 0x21, 0x20, 0xe9, 0x4a, 0x43, 0x67,
-	0xe4, 0x84, 0xc6, 0xc9, 0x65, 0x48, 0x84, 0x8d, 0x55, 0xac, 0x89, 0x96, 0x7d, 0xd6, 0xf0, 0x05,
-	0xe3, 0x33, 0xc7, 0xf3, 0xbc, 0x91, 0x41, 0x2d, 0x2f, 0x20, 0x86, 0x69, 0xbf, 0xe2, 0x87, 0x37,
-	0x15, 0x57, 0x3c, 0x6f, 0xd4, 0x63, 0xbc, 0xa6, 0xfd, 0x8a, 0x6d, 0xc4, 0x96, 0x3f, 0xa6, 0x24,
-	0x34, 0xd8, 0x0f, 0xcf, 0x5d, 0xca, 0x18, 0x04, 0xab, 0xe5, 0x8f, 0x29, 0xfa, 0x1e, 0xac, 0x46,
-	0x1d, 0xf8, 0x5e, 0x2c, 0x93, 0x80, 0x15, 0xd9, 0x85, 0xf3, 0x90, 0x0e, 0x2b, 0x47, 0x24, 0xb0,
-	0x88, 0x1b, 0xf6, 0x1d, 0xeb, 0x8c, 0xf2, 0x23, 0x96, 0x82, 0x53, 0xbc, 0x27, 0xf9, 0xd2, 0xb2,
-	0x56, 0xc2, 0xd1, 0x6c, 0x23, 0x32, 0xa2, 0xfa, 0xbf, 0x2a, 0x50, 0xe0, 0x29, 0x0b, 0x53, 0x0a,
-	0xdf, 0xee, 0x79, 0x36, 0x20, 0x53, 0x5d, 0xc6, 0xe0, 0xb9, 0xc0, 0x7b, 0x50, 0xe6, 0xca, 0x4f,
-	0x9c, 0x30, 0x78, 0x1e, 0xcc, 0x1b, 0xeb, 0x50, 0x0a, 0x88, 0x69, 0x7b, 0xee, 0x30, 0xba, 0x14,
-	0x8b, 0x69, 0xf4, 0xbb, 0xa0, 0xf9, 0x81, 0xe7, 0x9b, 0x83, 0xc9, 0x39, 0x5a, 0x9a, 0x6f, 0x2d,
-	0xc1, 0xe7, 0x29, 0xfa, 0xf7, 0x60, 0x95, 0x12, 0x11, 0xd9, 0x85, 0x93, 0x14, 0xc4, 0x67, 0x4a,
-	0x26, 0x3f, 0x11, 0xe8, 0x5f, 0x41, 0x51, 0x6c, 0x5c, 0xd7, 0x90, 0xf7, 0x23, 0x40, 0x42, 0x91,
-	0xcc, 0x41, 0x46, 0x0e, 0xa5, 0x32, 0xcb, 0xe6, 0x2f, 0xbb, 0xa2, 0xe5, 0x68, 0xd2, 0xa0, 0xff,
-	0x97, 0x22, 0xf2, 0x6d, 0xf1, 0xe6, 0xc6, 0x12, 0x73, 0xb6, 0x6a, 0xd8, 0x31, 0x56, 0x5c, 0xee,
-	0x45, 0x24, 0xea, 0x40, 0x51, 0xa6, 0xd5, 0xb9, 0x45, 0x9f, 0x2c, 0x25, 0x40, 0x74, 0xd5, 0x4f,
-	0xe4, 0x45, 0xc7, 0xbc, 0x57, 0xfd, 0x44, 0x5c, 0xf5, 0x13, 0x76, 0x4a, 0x97, 0x09, 0xbf, 0x80,
-	0xcb, 0xf3, 0x7c, 0xbf, 0x62, 0xc7, 0xef, 0x29, 0x44, 0xff, 0x1f, 0x25, 0x8e, 0x7b, 0xd1, 0xbb,
-	0x07, 0xfa, 0x12, 0x4a, 0x2c, 0x84, 0x18, 0x23, 0xd3, 0x97, 0xaf, 0xf8, 0xad, 0xc5, 0x9e, 0x54,
-	0xa2, 0x5d, 0x51, 0xa4, 0xeb, 0xcb, 0xbe, 0xa0, 0x58, 0xfc, 0x64, 0x47, 0xa5, 0x28, 0x7e, 0xb2,
-	0xff, 0xe8, 0x03, 0xa8, 0x9a, 0xe3, 0xd0, 0x33, 0x4c, 0xfb, 0x9c, 0x04, 0xa1, 0x43, 0x89, 0xf4,
-	0xa5, 0x55, 0xc6, 0x6d, 0x46, 0xcc, 0xfa, 0x7d, 0x58, 0x49, 0x62, 0xbe, 0x2d, 0x6f, 0x29, 0x24,
-	0xf3, 0x96, 0x3f, 0x05, 0x98, 0xdc, 0x21, 0x32, 0x1f, 0x21, 0x17, 0x4e, 0x68, 0x58, 0xd1, 0xd9,
-	0xbc, 0x80, 0x4b, 0x8c, 0xd1, 0x62, 0xce, 0x98, 0x7e, 0xe0, 0x28, 0x44, 0x0f, 0x1c, 0x2c, 0x3a,
-	0xb0, 0x05, 0x7d, 0xe6, 0x0c, 0x87, 0xf1, 0xbd, 0x66, 0xd9, 0xf3, 0x46, 0x4f, 0x39, 0x43, 0xff,
-	0x26, 0x27, 0x7c, 0x45, 0x3c, 0x55, 0x65, 0x3a, 0x9b, 0xbd, 0x2b, 0x53, 0xdf, 0x03, 0xa0, 0xa1,
-	0x19, 0xb0, 0x24, 0xcc, 0x8c, 0x6e, 0x56, 0xeb, 0x53, 0x2f, 0x24, 0xfd, 0xa8, 0x76, 0x06, 0x97,
-	0x65, 0xef, 0x66, 0x88, 0x3e, 0x83, 0x15, 0xcb, 0x1b, 0xf9, 0x43, 0x22, 0x07, 0x17, 0xde, 0x3a,
-	0xb8, 0x12, 0xf7, 0x6f, 0x86, 0x89, 0xfb, 0xdc, 0xe2, 0x75, 0xef, 0x73, 0x7f, 0xad, 0x88, 0x17,
-	0xb7, 0xe4, 0x83, 0x1f, 0x1a, 0xcc, 0xa8, 0x2a, 0x79, 0xbc, 0xe0, 0xeb, 0xe1, 0xb7, 0x95, 0x94,
-	0xd4, 0x3f, 0xcb, 0x52, 0xc3, 0xf1, 0xe6, 0xb4, 0xf8, 0xdf, 0x55, 0x28, 0xc7, 0x8f, 0x6d, 0x53,
-	0xb6, 0xff, 0x14, 0xca, 0x71, 0xe1, 0x92, 0x0c, 0x10, 0xdf, 0x6a, 0x9e, 0xb8, 0x33, 0x7a, 0x09,
-	0xc8, 0x1c, 0x0c, 0xe2, 0x74, 0xd7, 0x18, 0x53, 0x73, 0x10, 0x3d, 0x75, 0x7e, 0x3a, 0x87, 0x1e,
-	0xa2, 0xfd, 0xf1, 0x98, 0x8d, 0xc7, 0x9a, 0x39, 0x18, 0xa4, 0x38, 0xe8, 0xcf, 0x60, 0x2b, 0x3d,
-	0x87, 0x71, 0x72, 0x69, 0xf8, 0x8e, 0x2d, 0xef, 0x00, 0xf6, 0xe6, 0x7d, 0x6f, 0x6c, 0xa4, 0xe0,
-	0xbf, 0xb8, 0x3c, 0x72, 0x6c, 0xa1, 0x73, 0x14, 0x4c, 0x35, 0xd4, 0xff, 0x02, 0x6e, 0xbe, 0xa1,
-	0xfb, 0x0c, 0x1b, 0x74, 0xd3, 0x75, 0x34, 0x8b, 0x2b, 0x21, 0x61, 0xbd, 0x5f, 0x29, 0xe2, 0x59,
-	0x34, 0xad, 0x93, 0x66, 0x32, 0x4f, 0xbf, 0x93, 0x71, 0x9e, 0xd6, 0xd1, 0xb1, 0x80, 0xe7, 0xa9,
-	0xf9, 0x93, 0x2b, 0xa9, 0x79, 0xd6, 0x84, 0x4c, 0x64, 0xb8, 0x02, 0x48, 0x22, 0xe8, 0xff, 0xa2,
-	0x42, 0x29, 0x42, 0xe7, 0x27, 0xf8, 0x4b, 0x1a, 0x92, 0x91, 0x11, 0x5f, 0x2f, 0x2a, 0x18, 0x04,
-	0x8b, 0xef, 0xa8, 0xef, 0x41, 0x79, 0x4c, 0x49, 0x20, 0x9a, 0x73, 0xbc, 0xb9, 0xc4, 0x18, 0xbc,
-	0xf1, 0x7d, 0xa8, 0x84, 0x5e, 0x68, 0x0e, 0x8d, 0x90, 0xe7, 0x0b, 0xaa, 0x18, 0xcd, 0x59, 0x3c,
-	0x5b, 0x40, 0x3f, 0x80, 0xf5, 0xf0, 0x34, 0xf0, 0xc2, 0x70, 0xc8, 0x72, 0x55, 0x9e, 0x39, 0x89,
-	0x44, 0x27, 0x8f, 0xb5, 0xb8, 0x41, 0x64, 0x54, 0x94, 0x45, 0xef, 0x49, 0x67, 0xe6, 0xba, 0x3c,
-	0x88, 0xe4, 0xf1, 0x6a, 0xcc, 0x65, 0xae, 0xcd, 0x36, 0x4f, 0x5f, 0x64, 0x24, 0x3c, 0x56, 0x28,
-	0x38, 0x22, 0x91, 0x01, 0x6b, 0x23, 0x62, 0xd2, 0x71, 0x40, 0x6c, 0xe3, 0xa5, 0x43, 0x86, 0xb6,
-	0xb8, 0x78, 0xa9, 0x66, 0x3e, 0x6e, 0x44, 0x6a, 0x69, 0x3c, 0xe2, 0xa3, 0x71, 0x35, 0x82, 0x13,
-	0x34, 0xcb, 0x1c, 0xc4, 0x3f, 0xb4, 0x06, 0x95, 0xde, 0xf3, 0x5e, 0xbf, 0x7d, 0x60, 0x1c, 0x1c,
-	0xee, 0xb6, 0x65, 0xa9, 0x54, 0xaf, 0x8d, 0x05, 0xa9, 0xb0, 0xf6, 0xfe, 0x61, 0xbf, 0xb9, 0x6f,
-	0xf4, 0x3b, 0xad, 0xa7, 0x3d, 0x2d, 0x87, 0xb6, 0x60, 0xbd, 0xbf, 0x87, 0x0f, 0xfb, 0xfd, 0xfd,
-	0xf6, 0xae, 0x71, 0xd4, 0xc6, 0x9d, 0xc3, 0xdd, 0x9e, 0xa6, 0x22, 0x04, 0xd5, 0x09, 0xbb, 0xdf,
-	0x39, 0x68, 0x6b, 0x79, 0x54, 0x81, 0xe5, 0xa3, 0x36, 0x6e, 0xb5, 0xbb, 0x7d, 0xad, 0xa0, 0xff,
-	0x52, 0x85, 0x4a, 0xc2, 0x8a, 0xcc, 0x91, 0x03, 0x2a, 0xce, 0x35, 0x79, 0xcc, 0xfe, 0xf2, 0xa7,
-	0x5d, 0xd3, 0x3a, 0x15, 0xd6, 0xc9, 0x63, 0x41, 0xf0, 0xb3, 0x8c, 0x79, 0x91, 0x58, 0xe7, 0x79,
-	0x5c, 0x1a, 0x99, 0x17, 0x02, 0xe4, 0xbb, 0xb0, 0x72, 0x46, 0x02, 0x97, 0x0c, 0x65, 0xbb, 0xb0,
-	0x48, 0x45, 0xf0, 0x44, 0x97, 0x6d, 0xd0, 0x64, 0x97, 0x09, 0x8c, 0x30, 0x47, 0x55, 0xf0, 0x0f,
-	0x22, 0xb0, 0x4d, 0x28, 0x88, 0xe6, 0x65, 0x31, 0x3f, 0x27, 0xd8, 0x36, 0x45, 0x5f, 0x9b, 0x3e,
-	0xcf, 0x21, 0xf3, 0x98, 0xff, 0x47, 0x27, 0xd3, 0xf6, 0x29, 0x72, 0xfb, 0xdc, 0x9b, 0xdf, 0x9d,
-	0xdf, 0x64, 0xa2, 0xd3, 0xd8, 0x44, 0xcb, 0xa0, 0xe2, 0xa8, 0xbe, 0xa8, 0xd5, 0x6c, 0xed, 0x31,
-	0xb3, 0xac, 0x42, 0xf9, 0xa0, 0xf9, 0x53, 0xe3, 0xb8, 0x27, 0x6e, 0xf0, 0x35, 0x58, 0x79, 0xda,
-	0xc6, 0xdd, 0xf6, 0xbe, 0xe4, 0xa8, 0x68, 0x13, 0x34, 0xc9, 0x99, 0xf4, 0xcb, 0x33, 0x04, 0xf1,
-	0xb7, 0x80, 0x4a, 0x90, 0xef, 0x3d, 0x6b, 0x1e, 0x69, 0x45, 0xfd, 0xbf, 0x73, 0xb0, 0x26, 0xb6,
-	0x85, 0xb8, 0x12, 0xe2, 0xcd, 0x2f, 0xc1, 0xc9, 0x5b, 0xac, 0x5c, 0xfa, 0x16, 0x2b, 0x4a, 0x42,
-	0xf9, 0xae, 0xae, 0x4e, 0x92, 0x50, 0x7e, 0xb3, 0x93, 0x8a, 0xf8, 0xf9, 0x79, 0x22, 0x7e, 0x0d,
-	0x96, 0x47, 0x84, 0xc6, 0x76, 0x2b, 0xe3, 0x88, 0x44, 0x0e, 0x54, 0x4c, 0xd7, 0xf5, 0x42, 0x53,
-	0x5c, 0x0d, 0x17, 0xe7, 0xda, 0x0c, 0xaf, 0x7c, 0x71, 0xa3, 0x39, 0x41, 0x12, 0x81, 0x39, 0x89,
-	0x5d, 0xff, 0x09, 0x68, 0x57, 0x3b, 0xcc, 0xb3, 0x1d, 0x7e, 0xff, 0x87, 0x93, 0xdd, 0x90, 0xb0,
-	0x75, 0x21, 0xdf, 0x54, 0xb4, 0x25, 0x46, 0xe0, 0xe3, 0x6e, 0xb7, 0xd3, 0x7d, 0xac, 0x29, 0x08,
-	0xa0, 0xd8, 0xfe, 0x69, 0xa7, 0xdf, 0xde, 0xd5, 0x72, 0x3b, 0xbf, 0x5a, 0x87, 0xa2, 0x10, 0x12,
-	0x7d, 0x2d, 0x33, 0x81, 0x64, 0x95, 0x2d, 0xfa, 0xc9, 0xdc, 0x19, 0x75, 0xaa, 0x72, 0xb7, 0xfe,
-	0x70, 0xe1, 0xf1, 0xf2, 0x55, 0x73, 0x09, 0xfd, 0x8d, 0x02, 0x2b, 0xa9, 0x17, 0xcd, 0xac, 0x57,
-	0xe3, 0x33, 0x8a, 0x7a, 0xeb, 0x3f, 0x5e, 0x68, 0x6c, 0x2c, 0xcb, 0x2f, 0x14, 0xa8, 0x24, 0xca,
-	0x59, 0xd1, 0xbd, 0x45, 0x4a, 0x60, 0x85, 0x24, 0xf7, 0x17, 0xaf, 0x9e, 0xd5, 0x97, 0x3e, 0x56,
-	0xd0, 0x5f, 0x2b, 0x50, 0x49, 0x14, 0x76, 0x66, 0x16, 0x65, 0xba, 0x0c, 0x35, 0xb3, 0x28, 0xb3,
-	0xea, 0x48, 0x97, 0xd0, 0x5f, 0x2a, 0x50, 0x8e, 0x8b, 0x34, 0xd1, 0xdd, 0xf9, 0xcb, 0x3a, 0x85,
-	0x10, 0x9f, 0x2e, 0x5a, 0x0f, 0xaa, 0x2f, 0xa1, 0x3f, 0x87, 0x52, 0x54, 0xd1, 0x88, 0xb2, 0xee,
-	0x5e, 0x57, 0xca, 0x25, 0xeb, 0x77, 0xe7, 0x1e, 0x97, 0x9c, 0x3e, 0x2a, 0x33, 0xcc, 0x3c, 0xfd,
-	0x95, 0x82, 0xc8, 0xfa, 0xdd, 0xb9, 0xc7, 0xc5, 0xd3, 0x33, 0x4f, 0x48, 0x54, 0x23, 0x66, 0xf6,
-	0x84, 0xe9, 0x32, 0xc8, 0xcc, 0x9e, 0x30, 0xab, 0xf8, 0x51, 0x08, 0x92, 0xa8, 0x67, 0xcc, 0x2c,
-	0xc8, 0x74, 0xcd, 0x64, 0x66, 0x41, 0x66, 0x94, 0x4f, 0xea, 0x4b, 0xe8, 0xe7, 0x4a, 0xf2, 0x5c,
-	0x70, 0x77, 0xee, 0xb2, 0xbd, 0x39, 0x5d, 0x72, 0xaa, 0x70, 0x90, 0x2f, 0xd0, 0x9f, 0xcb, 0x5b,
-	0x0c, 0x51, 0xf5, 0x87, 0xe6, 0x01, 0x4b, 0x15, 0x0a, 0xd6, 0x3f, 0x59, 0x6c, 0xb3, 0xe1, 0x42,
-	0xfc, 0x95, 0x02, 0x30, 0xa9, 0x0f, 0xcc, 0x2c, 0xc4, 0x54, 0x61, 0x62, 0xfd, 0xde, 0x02, 0x23,
-	0x93, 0x0b, 0x24, 0xaa, 0x5f, 0xca, 0xbc, 0x40, 0xae, 0xd4, 0x2f, 0x66, 0x5e, 0x20, 0x57, 0x6b,
-	0x0f, 0xf5, 0x25, 0xf4, 0x4f, 0x0a, 0xac, 0x4f, 0xd5, 0x4f, 0xa1, 0x87, 0xd7, 0x2c, 0xa1, 0xab,
-	0x7f, 0xbe, 0x38, 0x40, 0x24, 0xda, 0xb6, 0xf2, 0xb1, 0x82, 0xfe, 0x56, 0x81, 0xd5, 0x74, 0x5d,
-	0x49, 0xe6, 0x5d, 0x6a, 0x46, 0x25, 0x56, 0xfd, 0xc1, 0x62, 0x83, 0x63, 0x6d, 0xfd, 0xbd, 0x02,
-	0xd5, 0x74, 0x89, 0x11, 0x7a, 0x30, 0x5f, 0x58, 0xb8, 0x22, 0xd0, 0x67, 0x0b, 0x8e, 0x8e, 0x24,
-	0xfa, 0x62, 0xf9, 0x8f, 0x0a, 0x22, 0x7b, 0x2b, 0xf2, 0x9f, 0x1f, 0xfd, 0x26, 0x00, 0x00, 0xff,
-	0xff, 0x5b, 0xc7, 0xff, 0xf4, 0x0c, 0x35, 0x00, 0x00,
+	// 4162 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x3a, 0x4d, 0x93, 0x1b, 0x49,
+	0x56, 0x2e, 0x7d, 0xb5, 0xf4, 0xd4, 0xad, 0x56, 0xa7, 0xbb, 0x3d, 0xb2, 0x66, 0x61, 0xbc, 0xb5,
+	0x31, 0x84, 0xd9, 0x9d, 0x91, 0x67, 0x7b, 0xd9, 0xf1, 0xd8, 0xeb, 0x19, 0x8f, 0xac, 0x96, 0xdd,
+	0xb2, 0xbb, 0xa5, 0x26, 0xa5, 0xc6, 0x6b, 0x0c, 0x53, 0x54, 0xab, 0xd2, 0xea, 0xb2, 0xa5, 0xaa,
+	0x9a, 0xca, 0x52, 0xbb, 0x7b, 0x09, 0x02, 0x62, 0x89, 0x20, 0x96, 0x00, 0x02, 0x2e, 0xcb, 0x5e,
+	0x38, 0x11, 0x70, 0x20, 0x08, 0xee, 0xc4, 0x12, 0x7b, 0xe2, 0xc0, 0x9f, 0xe0, 0xc2, 0x9e, 0xb8,
+	0x11, 0xfc, 0x02, 0x88, 0x97, 0x1f, 0xa5, 0x52, 0xab, 0xbd, 0x96, 0xd4, 0x3e, 0x49, 0xef, 0x65,
+	0xe6, 0xcb, 0x57, 0xef, 0x2b, 0xdf, 0xcb, 0x7c, 0x60, 0x06, 0xc3, 0xf1, 0xc0, 0xf5, 0xf8, 0x2d,
+	0x27, 0x74, 0x4f, 0x58, 0xc8, 0x6f, 0x05, 0xa1, 0x1f, 0xf9, 0x0a, 0xaa, 0x09, 0x80, 0x7c, 0x78,
+	0x6c, 0xf3, 0x63, 0xb7, 0xef, 0x87, 0x41, 0xcd, 0xf3, 0x47, 0xb6, 0x53, 0x53, 0x6b, 0x6a, 0x6a,
+	0x8d, 0x9c, 0x56, 0xfd, 0xf5, 0x81, 0xef, 0x0f, 0x86, 0x4c, 0x52, 0x38, 0x1a, 0xbf, 0xb8, 0xe5,
+	0x8c, 0x43, 0x3b, 0x72, 0x7d, 0x4f, 0x8d, 0x7f, 0x70, 0x7e, 0x3c, 0x72, 0x47, 0x8c, 0x47, 0xf6,
+	0x28, 0x50, 0x13, 0x3e, 0xd4, 0xbc, 0xf0, 0x63, 0x3b, 0x64, 0xce, 0xad, 0xe3, 0xfe, 0x90, 0x07,
+	0xac, 0x8f, 0xbf, 0x16, 0xfe, 0x51, 0xd3, 0x3e, 0x3a, 0x37, 0x8d, 0x47, 0xe1, 0xb8, 0x1f, 0x69,
+	0xce, 0xed, 0x28, 0x0a, 0xdd, 0xa3, 0x71, 0xc4, 0xe4, 0x6c, 0xf3, 0x3a, 0xbc, 0xd7, 0xb3, 0xf9,
+	0xab, 0x86, 0xef, 0xbd, 0x70, 0x07, 0xdd, 0xfe, 0x31, 0x1b, 0xd9, 0x94, 0x7d, 0x3d, 0x66, 0x3c,
+	0x32, 0x7f, 0x0f, 0x2a, 0xb3, 0x43, 0x3c, 0xf0, 0x3d, 0xce, 0xc8, 0x97, 0x90, 0xc1, 0x2d, 0x2b,
+	0xc6, 0x0d, 0xe3, 0x66, 0x71, 0xfb, 0xa3, 0xda, 0x9b, 0x44, 0x20, 0x79, 0xa8, 0x29, 0x56, 0x6b,
+	0xdd, 0x80, 0xf5, 0xa9, 0x58, 0x69, 0x6e, 0xc1, 0xd5, 0x86, 0x1d, 0xd8, 0x47, 0xee, 0xd0, 0x8d,
+	0x5c, 0xc6, 0xf5, 0xa6, 0x63, 0xd8, 0x9c, 0x46, 0xab, 0x0d, 0x7f, 0x1f, 0x56, 0xfb, 0x09, 0xbc,
+	0xda, 0xf8, 0x4e, 0x6d, 0x2e, 0xd9, 0xd7, 0x76, 0x04, 0x34, 0x45, 0x78, 0x8a, 0x9c, 0xb9, 0x09,
+	0xe4, 0xa1, 0xeb, 0x0d, 0x58, 0x18, 0x84, 0xae, 0x17, 0x69, 0x66, 0x7e, 0x91, 0x86, 0xab, 0x53,
+	0x68, 0xc5, 0xcc, 0x4b, 0x80, 0x58, 0x8e, 0xc8, 0x4a, 0xfa, 0x66, 0x71, 0xfb, 0xf1, 0x9c, 0xac,
+	0x5c, 0x40, 0xaf, 0x56, 0x8f, 0x89, 0x35, 0xbd, 0x28, 0x3c, 0xa3, 0x09, 0xea, 0xe4, 0x2b, 0xc8,
+	0x1d, 0x33, 0x7b, 0x18, 0x1d, 0x57, 0x52, 0x37, 0x8c, 0x9b, 0xa5, 0xed, 0x87, 0x97, 0xd8, 0x67,
+	0x57, 0x10, 0xea, 0x46, 0x76, 0xc4, 0xa8, 0xa2, 0x4a, 0x3e, 0x06, 0x22, 0xff, 0x59, 0x0e, 0xe3,
+	0xfd, 0xd0, 0x0d, 0xd0, 0x24, 0x2b, 0xe9, 0x1b, 0xc6, 0xcd, 0x02, 0xdd, 0x90, 0x23, 0x3b, 0x93,
+	0x81, 0x6a, 0x00, 0xeb, 0xe7, 0xb8, 0x25, 0x65, 0x48, 0xbf, 0x62, 0x67, 0x42, 0x23, 0x05, 0x8a,
+	0x7f, 0xc9, 0x23, 0xc8, 0x9e, 0xd8, 0xc3, 0x31, 0x13, 0x2c, 0x17, 0xb7, 0xbf, 0xfb, 0x36, 0xf3,
+	0x50, 0x26, 0x3a, 0x91, 0x03, 0x95, 0xeb, 0xef, 0xa6, 0x3e, 0x33, 0xcc, 0x3b, 0x50, 0x4c, 0xf0,
+	0x4d, 0x4a, 0x00, 0x87, 0xed, 0x9d, 0x66, 0xaf, 0xd9, 0xe8, 0x35, 0x77, 0xca, 0x57, 0xc8, 0x1a,
+	0x14, 0x0e, 0xdb, 0xbb, 0xcd, 0xfa, 0x5e, 0x6f, 0xf7, 0x59, 0xd9, 0x20, 0x45, 0x58, 0xd1, 0x40,
+	0xca, 0x3c, 0x05, 0x42, 0x59, 0xdf, 0x3f, 0x61, 0x21, 0x1a, 0xb2, 0xd2, 0x2a, 0x79, 0x0f, 0x56,
+	0x22, 0x9b, 0xbf, 0xb2, 0x5c, 0x47, 0xf1, 0x9c, 0x43, 0xb0, 0xe5, 0x90, 0x16, 0xe4, 0x8e, 0x6d,
+	0xcf, 0x19, 0xbe, 0x9d, 0xef, 0x69, 0x51, 0x23, 0xf1, 0x5d, 0xb1, 0x90, 0x2a, 0x02, 0x68, 0xdd,
+	0x53, 0x3b, 0x4b, 0x05, 0x98, 0xcf, 0xa0, 0xdc, 0x8d, 0xec, 0x30, 0x4a, 0xb2, 0xd3, 0x84, 0x0c,
+	0xee, 0x5f, 0x31, 0x16, 0xde, 0x53, 0x7a, 0x26, 0x15, 0xcb, 0xcd, 0xff, 0x4d, 0xc1, 0x46, 0x82,
+	0xb6, 0xb2, 0xd4, 0xa7, 0x90, 0x0b, 0x19, 0x1f, 0x0f, 0x23, 0x41, 0xbe, 0xb4, 0x7d, 0x7f, 0x4e,
+	0xf2, 0x33, 0x94, 0x6a, 0x54, 0x90, 0xa1, 0x8a, 0x1c, 0xb9, 0x09, 0x65, 0xb9, 0xc2, 0x62, 0x61,
+	0xe8, 0x87, 0xd6, 0x88, 0x0f, 0x84, 0xd4, 0x0a, 0xb4, 0x24, 0xf1, 0x4d, 0x44, 0xef, 0xf3, 0x41,
+	0x42, 0xaa, 0xe9, 0x4b, 0x4a, 0x95, 0xd8, 0x50, 0xf6, 0x58, 0xf4, 0xda, 0x0f, 0x5f, 0x59, 0x28,
+	0xda, 0xd0, 0x75, 0x58, 0x25, 0x23, 0x88, 0x7e, 0x3a, 0x27, 0xd1, 0xb6, 0x5c, 0xde, 0x51, 0xab,
+	0xe9, 0xba, 0x37, 0x8d, 0x30, 0xbf, 0x03, 0x39, 0xf9, 0xa5, 0x68, 0x49, 0xdd, 0xc3, 0x46, 0xa3,
+	0xd9, 0xed, 0x96, 0xaf, 0x90, 0x02, 0x64, 0x69, 0xb3, 0x47, 0xd1, 0xc2, 0x0a, 0x90, 0x7d, 0x58,
+	0xef, 0xd5, 0xf7, 0xca, 0x29, 0xf3, 0xdb, 0xb0, 0xfe, 0xd4, 0x76, 0xa3, 0x79, 0x8c, 0xcb, 0xf4,
+	0xa1, 0x3c, 0x99, 0xab, 0xb4, 0xd3, 0x9a, 0xd2, 0xce, 0xfc, 0xa2, 0x69, 0x9e, 0xba, 0xd1, 0x39,
+	0x7d, 0x94, 0x21, 0xcd, 0xc2, 0x50, 0xa9, 0x00, 0xff, 0x9a, 0xaf, 0x61, 0xbd, 0x1b, 0xf9, 0xc1,
+	0x5c, 0x96, 0xff, 0x3d, 0x58, 0xc1, 0xd3, 0xc6, 0x1f, 0x47, 0xca, 0xf4, 0xaf, 0xd7, 0xe4, 0x69,
+	0x54, 0xd3, 0xa7, 0x51, 0x6d, 0x47, 0x9d, 0x56, 0x54, 0xcf, 0x24, 0xd7, 0x20, 0xc7, 0xdd, 0x81,
+	0x67, 0x0f, 0x55, 0xb4, 0x50, 0x90, 0x49, 0xa0, 0x3c, 0xd9, 0x58, 0x19, 0x7e, 0x03, 0xc8, 0x0e,
+	0xe3, 0x51, 0xe8, 0x9f, 0xcd, 0xc5, 0xcf, 0x26, 0x64, 0x5f, 0xf8, 0x61, 0x5f, 0x3a, 0x62, 0x9e,
+	0x4a, 0x00, 0x9d, 0x6a, 0x8a, 0x88, 0xa2, 0xfd, 0x31, 0x90, 0x96, 0x87, 0x67, 0xca, 0x7c, 0x8a,
+	0xf8, 0x9b, 0x14, 0x5c, 0x9d, 0x9a, 0xaf, 0x94, 0xb1, 0xbc, 0x1f, 0x62, 0x60, 0x1a, 0x73, 0xe9,
+	0x87, 0xa4, 0x03, 0x39, 0x39, 0x43, 0x49, 0xf2, 0xf6, 0x02, 0x84, 0xe4, 0x31, 0xa5, 0xc8, 0x29,
+	0x32, 0x17, 0x1a, 0x7d, 0xfa, 0xdd, 0x1a, 0xfd, 0x6b, 0x28, 0xeb, 0xef, 0xe0, 0x6f, 0xd5, 0xcd,
+	0x63, 0xb8, 0xda, 0xf7, 0x87, 0x43, 0xd6, 0x47, 0x6b, 0xb0, 0x5c, 0x2f, 0x62, 0xe1, 0x89, 0x3d,
+	0x7c, 0xbb, 0xdd, 0x90, 0xc9, 0xaa, 0x96, 0x5a, 0x64, 0x3e, 0x87, 0x8d, 0xc4, 0xc6, 0x4a, 0x11,
+	0x0f, 0x21, 0xcb, 0x11, 0xa1, 0x34, 0xf1, 0xc9, 0x82, 0x9a, 0xe0, 0x54, 0x2e, 0x37, 0xaf, 0x4a,
+	0xe2, 0xcd, 0x13, 0xe6, 0xc5, 0x9f, 0x65, 0xee, 0xc0, 0x46, 0x57, 0x98, 0xe9, 0x5c, 0x76, 0x38,
+	0x31, 0xf1, 0xd4, 0x94, 0x89, 0x6f, 0x02, 0x49, 0x52, 0x51, 0x86, 0x78, 0x06, 0xeb, 0xcd, 0x53,
+	0xd6, 0x9f, 0x8b, 0x72, 0x05, 0x56, 0xfa, 0xfe, 0x68, 0x64, 0x7b, 0x4e, 0x25, 0x75, 0x23, 0x7d,
+	0xb3, 0x40, 0x35, 0x98, 0xf4, 0xc5, 0xf4, 0xbc, 0xbe, 0x68, 0xfe, 0x95, 0x01, 0xe5, 0xc9, 0xde,
+	0x4a, 0x90, 0xc8, 0x7d, 0xe4, 0x20, 0x21, 0xdc, 0x7b, 0x95, 0x2a, 0x48, 0xe1, 0x75, 0xb8, 0x90,
+	0x78, 0x16, 0x86, 0x89, 0x70, 0x94, 0xbe, 0x64, 0x38, 0x32, 0x77, 0xe1, 0x1b, 0x9a, 0x9d, 0x6e,
+	0x14, 0x32, 0x7b, 0xe4, 0x7a, 0x83, 0x56, 0xa7, 0x13, 0x30, 0xc9, 0x38, 0x21, 0x90, 0x71, 0xec,
+	0xc8, 0x56, 0x8c, 0x89, 0xff, 0xe8, 0xf4, 0xfd, 0xa1, 0xcf, 0x63, 0xa7, 0x17, 0x80, 0xf9, 0x1f,
+	0x69, 0xa8, 0xcc, 0x90, 0xd2, 0xe2, 0x7d, 0x0e, 0x59, 0xce, 0xa2, 0x71, 0xa0, 0x4c, 0xa5, 0x39,
+	0x37, 0xc3, 0x17, 0xd3, 0xab, 0x75, 0x91, 0x18, 0x95, 0x34, 0xc9, 0x00, 0xf2, 0x51, 0x74, 0x66,
+	0x71, 0xf7, 0x47, 0x3a, 0x21, 0xd8, 0xbb, 0x2c, 0xfd, 0x1e, 0x0b, 0x47, 0xae, 0x67, 0x0f, 0xbb,
+	0xee, 0x8f, 0x18, 0x5d, 0x89, 0xa2, 0x33, 0xfc, 0x43, 0x9e, 0xa1, 0xc1, 0x3b, 0xae, 0xa7, 0xc4,
+	0xde, 0x58, 0x76, 0x97, 0x84, 0x80, 0xa9, 0xa4, 0x58, 0xdd, 0x83, 0xac, 0xf8, 0xa6, 0x65, 0x0c,
+	0xb1, 0x0c, 0xe9, 0x28, 0x3a, 0x13, 0x4c, 0xe5, 0x29, 0xfe, 0xad, 0xde, 0x83, 0xd5, 0xe4, 0x17,
+	0xa0, 0x21, 0x1d, 0x33, 0x77, 0x70, 0x2c, 0x0d, 0x2c, 0x4b, 0x15, 0x84, 0x9a, 0x7c, 0xed, 0x3a,
+	0x2a, 0x65, 0xcd, 0x52, 0x09, 0x98, 0xff, 0x9a, 0x82, 0xeb, 0x17, 0x48, 0x46, 0x19, 0xeb, 0xf3,
+	0x29, 0x63, 0x7d, 0x47, 0x52, 0xd0, 0x16, 0xff, 0x7c, 0xca, 0xe2, 0xdf, 0x21, 0x71, 0x74, 0x9b,
+	0x6b, 0x90, 0x63, 0xa7, 0x6e, 0xc4, 0x1c, 0x25, 0x2a, 0x05, 0x25, 0xdc, 0x29, 0x73, 0x59, 0x77,
+	0xda, 0x87, 0xcd, 0x46, 0xc8, 0xec, 0x88, 0xa9, 0x50, 0xae, 0xed, 0xff, 0x3a, 0xe4, 0xed, 0xe1,
+	0xd0, 0xef, 0x4f, 0xd4, 0xba, 0x22, 0xe0, 0x96, 0x43, 0xaa, 0x90, 0x3f, 0xf6, 0x79, 0xe4, 0xd9,
+	0x23, 0xa6, 0x82, 0x57, 0x0c, 0x9b, 0x3f, 0x35, 0x60, 0xeb, 0x1c, 0x3d, 0xa5, 0x85, 0x23, 0x28,
+	0xb9, 0xdc, 0x1f, 0x8a, 0x0f, 0xb4, 0x12, 0x15, 0xde, 0x0f, 0x16, 0x3b, 0x6a, 0x5a, 0x9a, 0x86,
+	0x28, 0xf8, 0xd6, 0xdc, 0x24, 0x28, 0x2c, 0x4e, 0x6c, 0xee, 0x28, 0x4f, 0xd7, 0xa0, 0xf9, 0xb7,
+	0x06, 0x6c, 0xa9, 0x13, 0x7e, 0xfe, 0x0f, 0x9d, 0x65, 0x39, 0xf5, 0xae, 0x59, 0x36, 0x2b, 0x70,
+	0xed, 0x3c, 0x5f, 0x2a, 0xe6, 0xff, 0x4f, 0x16, 0xc8, 0x6c, 0x75, 0x49, 0xbe, 0x09, 0xab, 0x9c,
+	0x79, 0x8e, 0x25, 0xcf, 0x0b, 0x79, 0x94, 0xe5, 0x69, 0x11, 0x71, 0xf2, 0xe0, 0xe0, 0x18, 0x02,
+	0xd9, 0xa9, 0xe2, 0x36, 0x4f, 0xc5, 0x7f, 0x72, 0x0c, 0xab, 0x2f, 0xb8, 0x15, 0xef, 0x2d, 0x0c,
+	0xaa, 0x34, 0x77, 0x58, 0x9b, 0xe5, 0xa3, 0xf6, 0xb0, 0x1b, 0x7f, 0x17, 0x2d, 0xbe, 0xe0, 0x31,
+	0x40, 0x7e, 0x62, 0xc0, 0x7b, 0x3a, 0xad, 0x98, 0x88, 0x6f, 0xe4, 0x3b, 0x8c, 0x57, 0x32, 0x37,
+	0xd2, 0x37, 0x4b, 0xdb, 0x07, 0x97, 0x90, 0xdf, 0x0c, 0x72, 0xdf, 0x77, 0x18, 0xdd, 0xf2, 0x2e,
+	0xc0, 0x72, 0x52, 0x83, 0xab, 0xa3, 0x31, 0x8f, 0x2c, 0x69, 0x05, 0x96, 0x9a, 0x54, 0xc9, 0x0a,
+	0xb9, 0x6c, 0xe0, 0xd0, 0x94, 0xad, 0x92, 0x57, 0xb0, 0x36, 0xf2, 0xc7, 0x5e, 0x64, 0xf5, 0x45,
+	0xfd, 0xc3, 0x2b, 0xb9, 0x85, 0x0a, 0xe3, 0x0b, 0xa4, 0xb4, 0x8f, 0xe4, 0x64, 0x35, 0xc5, 0xe9,
+	0xea, 0x28, 0x01, 0xa1, 0x22, 0x43, 0x36, 0xf2, 0x23, 0x66, 0x61, 0xbc, 0xe4, 0x95, 0x15, 0xa9,
+	0x48, 0x89, 0xc3, 0xd0, 0xc0, 0xc9, 0x6f, 0xc1, 0x35, 0xc7, 0xe5, 0xf6, 0xd1, 0x90, 0x59, 0x43,
+	0x7f, 0x60, 0x4d, 0xd2, 0x9c, 0x4a, 0x5e, 0x4c, 0xde, 0x54, 0xa3, 0x7b, 0xfe, 0xa0, 0x11, 0x8f,
+	0x89, 0x55, 0x67, 0x9e, 0x3d, 0x72, 0xfb, 0x16, 0x7e, 0xd5, 0xd0, 0xb7, 0x1d, 0x6b, 0xcc, 0x59,
+	0xc8, 0x2b, 0x05, 0xb5, 0x4a, 0x8e, 0x3e, 0x55, 0x83, 0x87, 0x38, 0x66, 0xde, 0x85, 0x62, 0x42,
+	0xa5, 0x24, 0x0f, 0x99, 0x76, 0xa7, 0xdd, 0x2c, 0x5f, 0x21, 0x00, 0xb9, 0xc6, 0x2e, 0xed, 0x74,
+	0x7a, 0xb2, 0x42, 0x69, 0xed, 0xd7, 0x1f, 0x35, 0xcb, 0x29, 0x44, 0x1f, 0xb6, 0x7f, 0xa7, 0xd9,
+	0xda, 0x2b, 0xa7, 0xcd, 0x26, 0xac, 0x26, 0x3f, 0x94, 0x10, 0x28, 0x1d, 0xb6, 0x9f, 0xb4, 0x3b,
+	0x4f, 0xdb, 0xd6, 0x7e, 0xe7, 0xb0, 0xdd, 0xc3, 0x3a, 0xa7, 0x04, 0x50, 0x6f, 0x3f, 0x9b, 0xc0,
+	0x6b, 0x50, 0x68, 0x77, 0x34, 0x68, 0x54, 0x53, 0x65, 0xc3, 0xfc, 0xf7, 0x34, 0x6c, 0x5e, 0xa4,
+	0x73, 0xe2, 0x40, 0x06, 0xed, 0x47, 0x55, 0x9a, 0xef, 0xde, 0x7c, 0x04, 0x75, 0x74, 0x9b, 0xc0,
+	0x56, 0x47, 0x4b, 0x81, 0x8a, 0xff, 0xc4, 0x82, 0xdc, 0xd0, 0x3e, 0x62, 0x43, 0x5e, 0x49, 0x8b,
+	0xbb, 0x98, 0x47, 0x97, 0xd9, 0x7b, 0x4f, 0x50, 0x92, 0x17, 0x31, 0x8a, 0x2c, 0xe9, 0x41, 0x11,
+	0x83, 0x27, 0x97, 0xa2, 0x53, 0xf1, 0x7c, 0x7b, 0xce, 0x5d, 0x76, 0x27, 0x2b, 0x69, 0x92, 0x4c,
+	0xf5, 0x0e, 0x14, 0x13, 0x9b, 0x5d, 0x70, 0x8f, 0xb2, 0x99, 0xbc, 0x47, 0x29, 0x24, 0x2f, 0x45,
+	0xee, 0xc3, 0xe6, 0x45, 0x32, 0x42, 0x83, 0xd8, 0xed, 0x74, 0x7b, 0xb2, 0x62, 0x7d, 0x44, 0x3b,
+	0x87, 0x07, 0x65, 0x03, 0x91, 0xbd, 0x7a, 0xf7, 0x49, 0x39, 0x15, 0xdb, 0x4b, 0xda, 0x6c, 0x40,
+	0x31, 0xc1, 0xd7, 0xd4, 0x69, 0x61, 0x4c, 0x9f, 0x16, 0x18, 0xaf, 0x6d, 0xc7, 0x09, 0x19, 0xe7,
+	0x8a, 0x0f, 0x0d, 0x9a, 0xcf, 0xa1, 0xb0, 0xd3, 0xee, 0x2a, 0x12, 0x15, 0x58, 0xe1, 0x2c, 0xc4,
+	0xef, 0x16, 0x37, 0x62, 0x05, 0xaa, 0x41, 0x24, 0xce, 0x99, 0x1d, 0xf6, 0x8f, 0x19, 0x57, 0x39,
+	0x46, 0x0c, 0xe3, 0x2a, 0x5f, 0xdc, 0x2c, 0x49, 0xdd, 0x15, 0xa8, 0x06, 0xcd, 0xff, 0xcb, 0x03,
+	0x4c, 0x6e, 0x39, 0x48, 0x09, 0x52, 0x71, 0xec, 0x4f, 0xb9, 0x0e, 0xda, 0x41, 0xe2, 0x6c, 0x13,
+	0xff, 0xc9, 0x36, 0x6c, 0x8d, 0xf8, 0x20, 0xb0, 0xfb, 0xaf, 0x2c, 0x75, 0x39, 0x21, 0x43, 0x84,
+	0x88, 0xa3, 0xab, 0xf4, 0xaa, 0x1a, 0x54, 0x11, 0x40, 0xd2, 0xdd, 0x83, 0x34, 0xf3, 0x4e, 0x44,
+	0xcc, 0x2b, 0x6e, 0xdf, 0x5d, 0xf8, 0xf6, 0xa5, 0xd6, 0xf4, 0x4e, 0xa4, 0xad, 0x20, 0x19, 0x62,
+	0x01, 0x38, 0xec, 0xc4, 0xed, 0x33, 0x0b, 0x89, 0x66, 0x05, 0xd1, 0x2f, 0x17, 0x27, 0xba, 0x23,
+	0x68, 0xc4, 0xa4, 0x0b, 0x8e, 0x86, 0x49, 0x1b, 0x0a, 0x21, 0xe3, 0xfe, 0x38, 0xec, 0x33, 0x19,
+	0xf8, 0xe6, 0x2f, 0x90, 0xa8, 0x5e, 0x47, 0x27, 0x24, 0xc8, 0x0e, 0xe4, 0x44, 0xbc, 0xc3, 0xc8,
+	0x96, 0xfe, 0x95, 0x57, 0xb9, 0xd3, 0xc4, 0x44, 0x24, 0xa1, 0x6a, 0x2d, 0x79, 0x04, 0x2b, 0x92,
+	0x45, 0x5e, 0xc9, 0x0b, 0x32, 0x1f, 0xcf, 0x1b, 0x8c, 0xc5, 0x2a, 0xaa, 0x57, 0xa3, 0x56, 0x31,
+	0x08, 0x8a, 0x18, 0x58, 0xa0, 0xe2, 0x3f, 0x79, 0x1f, 0x0a, 0xf2, 0xec, 0x77, 0xdc, 0xb0, 0x02,
+	0xd2, 0x38, 0x05, 0x62, 0xc7, 0x0d, 0xc9, 0x07, 0x50, 0x94, 0x39, 0x9e, 0x25, 0xa2, 0x42, 0x51,
+	0x0c, 0x83, 0x44, 0x1d, 0x60, 0x6c, 0x90, 0x13, 0x58, 0x18, 0xca, 0x09, 0xab, 0xf1, 0x04, 0x16,
+	0x86, 0x62, 0xc2, 0x6f, 0xc0, 0xba, 0xc8, 0x8c, 0x07, 0xa1, 0x3f, 0x0e, 0x2c, 0x61, 0x53, 0x6b,
+	0x62, 0xd2, 0x1a, 0xa2, 0x1f, 0x21, 0xb6, 0x8d, 0xc6, 0x75, 0x1d, 0xf2, 0x2f, 0xfd, 0x23, 0x39,
+	0xa1, 0x24, 0xfd, 0xe0, 0xa5, 0x7f, 0xa4, 0x87, 0xe2, 0xec, 0x64, 0x7d, 0x3a, 0x3b, 0xf9, 0x1a,
+	0xae, 0xcd, 0x1e, 0xb3, 0x22, 0x4b, 0x29, 0x5f, 0x3e, 0x4b, 0xd9, 0xf4, 0x2e, 0xc0, 0x92, 0x07,
+	0x90, 0x76, 0x3c, 0x5e, 0xd9, 0x58, 0xc8, 0x38, 0x62, 0x3f, 0xa6, 0xb8, 0x98, 0x6c, 0x41, 0x0e,
+	0x3f, 0xd6, 0x75, 0x2a, 0x44, 0x86, 0x9e, 0x97, 0xfe, 0x51, 0xcb, 0x21, 0xdf, 0x80, 0x02, 0x7e,
+	0x3f, 0x0f, 0xec, 0x3e, 0xab, 0x5c, 0x15, 0x23, 0x13, 0x04, 0x2a, 0xca, 0xf3, 0x1d, 0x26, 0x45,
+	0xb4, 0x29, 0x15, 0x85, 0x08, 0x21, 0xa3, 0xf7, 0x60, 0x45, 0x0c, 0xba, 0x4e, 0x65, 0x4b, 0x0c,
+	0xe5, 0x10, 0x6c, 0x39, 0xc4, 0x84, 0xb5, 0xc0, 0x0e, 0x99, 0x17, 0x59, 0x6a, 0xc7, 0x6b, 0x62,
+	0xb8, 0x28, 0x91, 0x8f, 0x71, 0xdf, 0xea, 0xa7, 0x90, 0xd7, 0xce, 0xb0, 0x48, 0x98, 0xac, 0xde,
+	0x83, 0xd2, 0xb4, 0x2b, 0x2d, 0x14, 0x64, 0xff, 0x31, 0x05, 0x85, 0xd8, 0x69, 0x88, 0x07, 0x57,
+	0x85, 0x52, 0xed, 0x88, 0x39, 0xd6, 0xc4, 0x07, 0x65, 0x7e, 0xfc, 0xf9, 0x9c, 0x62, 0xae, 0x6b,
+	0x0a, 0xaa, 0x50, 0x57, 0x0e, 0x49, 0x62, 0xca, 0x93, 0xfd, 0xbe, 0x82, 0xf5, 0xa1, 0xeb, 0x8d,
+	0x4f, 0x13, 0x7b, 0xc9, 0xc4, 0xf6, 0xfb, 0x73, 0xee, 0xb5, 0x87, 0xab, 0x27, 0x7b, 0x94, 0x86,
+	0x53, 0x30, 0xd9, 0x85, 0x6c, 0xe0, 0x87, 0x91, 0x3e, 0x33, 0xe7, 0x3d, 0xcd, 0x0e, 0xfc, 0x30,
+	0xda, 0xb7, 0x83, 0x00, 0x6b, 0x37, 0x49, 0xc0, 0xfc, 0x65, 0x0a, 0xae, 0x5d, 0xfc, 0x61, 0xa4,
+	0x0d, 0xe9, 0x7e, 0x30, 0x56, 0x42, 0xba, 0xb7, 0xa8, 0x90, 0x1a, 0xc1, 0x78, 0xc2, 0x3f, 0x12,
+	0xc2, 0xfb, 0xec, 0x11, 0x1b, 0xf9, 0xe1, 0x99, 0x92, 0xc5, 0xfd, 0x45, 0x49, 0xee, 0x8b, 0xd5,
+	0x13, 0xaa, 0x8a, 0x1c, 0xa1, 0x90, 0x57, 0xce, 0xc4, 0x55, 0xd8, 0x5e, 0xf0, 0x76, 0x4d, 0x93,
+	0xa4, 0x31, 0x1d, 0xf2, 0x04, 0x52, 0xae, 0x5f, 0xc9, 0x2d, 0xe4, 0xe7, 0x31, 0xa3, 0xad, 0xce,
+	0x84, 0xc9, 0x94, 0xeb, 0x9b, 0x9f, 0xc2, 0xd6, 0x85, 0x72, 0x21, 0xbf, 0x06, 0xd0, 0x0f, 0xc6,
+	0x96, 0x78, 0x4a, 0x91, 0xe6, 0x98, 0xa6, 0x85, 0x7e, 0x30, 0xee, 0x0a, 0x84, 0xf9, 0x1c, 0x2a,
+	0x6f, 0xfa, 0x78, 0x74, 0x58, 0xf9, 0xf9, 0xd6, 0xe8, 0x48, 0x08, 0x34, 0x4d, 0xf3, 0x12, 0xb1,
+	0x7f, 0x84, 0x7e, 0xa9, 0x07, 0xed, 0x53, 0x9c, 0x90, 0x16, 0x13, 0x8a, 0x6a, 0x82, 0x7d, 0xba,
+	0x7f, 0x64, 0xfe, 0x85, 0x01, 0x9b, 0x17, 0x71, 0x8c, 0x35, 0xf1, 0xeb, 0xc9, 0xcd, 0x40, 0x9a,
+	0x2a, 0x88, 0x3c, 0x9f, 0x1c, 0x14, 0x29, 0x21, 0xe5, 0xfa, 0xe2, 0x72, 0x51, 0x67, 0x46, 0x2c,
+	0x1d, 0x4d, 0xd1, 0xfc, 0x07, 0x03, 0xaa, 0x6f, 0x9e, 0x17, 0x67, 0x8e, 0x46, 0x22, 0x73, 0xbc,
+	0x0e, 0xf9, 0x90, 0xd9, 0x8e, 0x75, 0x14, 0x70, 0x25, 0x80, 0x15, 0x84, 0x1f, 0x04, 0x42, 0x38,
+	0xaf, 0x43, 0x37, 0x62, 0x62, 0x4c, 0x7e, 0x7b, 0x5e, 0x20, 0xd4, 0xa0, 0x58, 0xe7, 0xfa, 0x01,
+	0x17, 0xe9, 0x60, 0x9a, 0x0a, 0x42, 0x2d, 0x3f, 0x10, 0x1a, 0x91, 0x2b, 0xc5, 0x68, 0x56, 0x6a,
+	0x44, 0x60, 0x70, 0xd8, 0xfc, 0x59, 0x0a, 0xd6, 0xcf, 0x19, 0x0d, 0xca, 0x4b, 0x7e, 0x85, 0xbe,
+	0x9d, 0x91, 0x10, 0xf2, 0xdc, 0x77, 0x1d, 0x7d, 0xaf, 0x2f, 0xfe, 0x8b, 0x4c, 0x28, 0x50, 0x77,
+	0xee, 0x29, 0x37, 0xc0, 0x00, 0x36, 0x3a, 0x72, 0x23, 0xc9, 0x47, 0x96, 0x4a, 0x80, 0x3c, 0x83,
+	0x52, 0xc8, 0x44, 0x06, 0xe6, 0x58, 0xd2, 0xcf, 0xb3, 0x0b, 0xf9, 0xb9, 0xe2, 0x10, 0xdd, 0x9d,
+	0xae, 0x69, 0x4a, 0x08, 0x71, 0xf2, 0x14, 0xd6, 0x74, 0xe9, 0x22, 0x29, 0xe7, 0x96, 0xa6, 0xbc,
+	0xaa, 0x08, 0x09, 0xc2, 0xf8, 0xd4, 0x97, 0x18, 0xc4, 0x0f, 0x13, 0xf9, 0xb7, 0x92, 0x89, 0x04,
+	0xa6, 0xe3, 0x75, 0x56, 0xc5, 0x6b, 0xf3, 0x08, 0x8a, 0x89, 0xc8, 0xb4, 0xc8, 0x52, 0x94, 0x67,
+	0xe4, 0x0b, 0x79, 0x66, 0x69, 0x2a, 0xf2, 0xf1, 0xa4, 0xc2, 0xdc, 0xd7, 0x72, 0x03, 0x21, 0xd1,
+	0x02, 0xcd, 0x21, 0xd8, 0x0a, 0xcc, 0x9f, 0xa7, 0xa0, 0x34, 0x1d, 0x54, 0xb5, 0xf3, 0x05, 0x2c,
+	0x74, 0x7d, 0x27, 0xe1, 0x7c, 0x07, 0x02, 0x81, 0x66, 0x82, 0xc3, 0x5f, 0x8f, 0xfd, 0xc8, 0xd6,
+	0x0e, 0xd6, 0x0f, 0xc6, 0xbf, 0x8d, 0xf0, 0x39, 0xc7, 0x4d, 0x9f, 0x73, 0x5c, 0xf2, 0x11, 0x10,
+	0xe5, 0x7f, 0x43, 0x77, 0xe4, 0x46, 0xd6, 0xd1, 0x59, 0xc4, 0xb4, 0xad, 0x95, 0xe5, 0xc8, 0x1e,
+	0x0e, 0x3c, 0x40, 0x3c, 0x7a, 0xab, 0xef, 0x8f, 0x2c, 0xde, 0xf7, 0x43, 0x66, 0xd9, 0xce, 0x4b,
+	0x65, 0x76, 0x45, 0xdf, 0x1f, 0x75, 0x11, 0x57, 0x77, 0x5e, 0x62, 0x2a, 0xd4, 0x0f, 0xc6, 0x9c,
+	0x45, 0x16, 0xfe, 0x88, 0xc0, 0x54, 0xa0, 0x20, 0x51, 0x8d, 0x60, 0xcc, 0xc9, 0xb7, 0x60, 0x4d,
+	0x4f, 0x10, 0xd9, 0x90, 0x4a, 0xc3, 0x56, 0xd5, 0x14, 0x81, 0x23, 0x26, 0xac, 0x1e, 0xb0, 0xb0,
+	0xcf, 0xbc, 0xa8, 0xe7, 0xf6, 0x5f, 0x71, 0x51, 0xe4, 0x1a, 0x74, 0x0a, 0xf7, 0x38, 0x93, 0x5f,
+	0x29, 0xe7, 0xa9, 0xde, 0x6d, 0xc4, 0x46, 0xdc, 0xfc, 0x17, 0x03, 0xb2, 0x22, 0x69, 0x44, 0xa1,
+	0x88, 0x84, 0x2b, 0xe1, 0x8c, 0x79, 0x44, 0x88, 0x6c, 0xec, 0x7d, 0x28, 0x08, 0xe1, 0x27, 0x6a,
+	0x3c, 0x51, 0x89, 0x88, 0xc1, 0xaa, 0xf4, 0x56, 0xdf, 0x1b, 0xea, 0x6b, 0xc9, 0x18, 0x26, 0xbf,
+	0x09, 0xe5, 0x20, 0xf4, 0x03, 0x7b, 0x30, 0xb9, 0xc9, 0x50, 0xea, 0x5b, 0x4f, 0xe0, 0x45, 0x91,
+	0xf4, 0x2d, 0x58, 0xe3, 0x4c, 0x9e, 0xad, 0xd2, 0x48, 0xb2, 0xf2, 0x33, 0x15, 0x52, 0xd4, 0x64,
+	0xe6, 0xd7, 0x90, 0x93, 0x01, 0xe4, 0x12, 0xfc, 0x7e, 0x0c, 0x44, 0x0a, 0x12, 0x0d, 0x64, 0xe4,
+	0x72, 0xae, 0xea, 0x1c, 0xf1, 0xb6, 0x2e, 0x47, 0x0e, 0x26, 0x03, 0xe6, 0x7f, 0x1a, 0x00, 0x93,
+	0x57, 0x4f, 0x2c, 0x8d, 0xd0, 0x6b, 0xf0, 0x22, 0x41, 0x5e, 0xaf, 0x6a, 0x10, 0x6f, 0x16, 0x55,
+	0x61, 0x93, 0x5a, 0xf6, 0xd1, 0x58, 0x11, 0xd0, 0x8f, 0x2d, 0x4c, 0x5d, 0x35, 0x2d, 0xfa, 0xd8,
+	0xc2, 0xe4, 0x63, 0x0b, 0xc3, 0x7b, 0x12, 0x55, 0x72, 0x49, 0x72, 0x19, 0x51, 0x71, 0x15, 0x9d,
+	0xf8, 0x45, 0x8b, 0x99, 0xff, 0x6d, 0xc4, 0x71, 0x4f, 0xbf, 0x3c, 0x91, 0xaf, 0x20, 0x8f, 0x21,
+	0xc4, 0x1a, 0xd9, 0x81, 0xea, 0xa3, 0x68, 0x2c, 0xf7, 0xa8, 0xa5, 0xf3, 0x12, 0x59, 0x30, 0xad,
+	0x04, 0x12, 0xc2, 0xf8, 0x89, 0xc5, 0xaa, 0x8e, 0x9f, 0xf8, 0x9f, 0x7c, 0x08, 0x25, 0x7b, 0x1c,
+	0xf9, 0x96, 0xed, 0x9c, 0xb0, 0x30, 0x72, 0x39, 0x53, 0xb6, 0xb4, 0x86, 0xd8, 0xba, 0x46, 0x56,
+	0xef, 0xc2, 0x6a, 0x92, 0xe6, 0xdb, 0x32, 0xc7, 0x6c, 0x32, 0x73, 0xfc, 0x03, 0x80, 0xc9, 0x2d,
+	0x2e, 0xda, 0x08, 0x5e, 0x09, 0x5b, 0x7d, 0x7d, 0x3b, 0x92, 0xa5, 0x79, 0x44, 0x34, 0xd0, 0x18,
+	0xa7, 0x9f, 0x98, 0xb2, 0xfa, 0x89, 0x09, 0xa3, 0x03, 0x3a, 0xf4, 0x2b, 0x77, 0x38, 0x8c, 0x6f,
+	0x96, 0x0b, 0xbe, 0x3f, 0x7a, 0x22, 0x10, 0xe6, 0x2f, 0x52, 0xd2, 0x56, 0xe4, 0x63, 0xe1, 0x5c,
+	0xd5, 0xf1, 0xbb, 0x52, 0xf5, 0x1d, 0x00, 0x1e, 0xd9, 0x21, 0xa6, 0xc1, 0xb6, 0xbe, 0xdb, 0xae,
+	0xce, 0xbc, 0x51, 0xf5, 0x74, 0xf7, 0x12, 0x2d, 0xa8, 0xd9, 0xf5, 0x88, 0x7c, 0x0e, 0xab, 0x7d,
+	0x7f, 0x14, 0x0c, 0x99, 0x5a, 0x9c, 0x7d, 0xeb, 0xe2, 0x62, 0x3c, 0xbf, 0x1e, 0x25, 0x6e, 0xd4,
+	0x73, 0x97, 0xbd, 0x51, 0xff, 0xb9, 0x21, 0xdf, 0x3c, 0x93, 0x4f, 0xae, 0x64, 0x70, 0x41, 0x5f,
+	0xcf, 0xa3, 0x25, 0xdf, 0x6f, 0x7f, 0x55, 0x53, 0x4f, 0xf5, 0xf3, 0x79, 0xba, 0x68, 0xde, 0x5c,
+	0x98, 0xfc, 0x5b, 0x1a, 0x0a, 0x5a, 0x2d, 0xb3, 0xba, 0xff, 0x0c, 0x0a, 0x71, 0xeb, 0x58, 0x25,
+	0xf5, 0x56, 0x09, 0x4f, 0x26, 0x93, 0x17, 0x40, 0xec, 0xc1, 0x20, 0x2e, 0x38, 0xac, 0x31, 0xb7,
+	0x07, 0xfa, 0xb1, 0xf9, 0xb3, 0x05, 0xe4, 0xa0, 0xcf, 0xc7, 0x43, 0x5c, 0x4f, 0xcb, 0xf6, 0x60,
+	0x30, 0x85, 0x21, 0x7f, 0x08, 0x5b, 0xd3, 0x7b, 0x58, 0x47, 0x67, 0x56, 0xe0, 0x3a, 0xea, 0x16,
+	0x66, 0x77, 0xd1, 0x17, 0xdf, 0xda, 0x14, 0xf9, 0x07, 0x67, 0x07, 0xae, 0x23, 0x65, 0x4e, 0xc2,
+	0x99, 0x81, 0xea, 0x1f, 0xc3, 0x7b, 0x6f, 0x98, 0x7e, 0x81, 0x0e, 0xda, 0xd3, 0x9d, 0x4c, 0xcb,
+	0x0b, 0x21, 0xa1, 0xbd, 0x5f, 0x1a, 0xb0, 0x31, 0x33, 0x81, 0xd4, 0x93, 0x95, 0xd2, 0xad, 0x39,
+	0xf7, 0x69, 0x1c, 0x1c, 0x4a, 0xf2, 0xb8, 0x96, 0x3c, 0x3e, 0x57, 0x1c, 0xcd, 0x9b, 0x90, 0xc9,
+	0xb2, 0x40, 0x12, 0xd2, 0xf5, 0xd0, 0x17, 0xa2, 0x76, 0x91, 0xaa, 0xaf, 0xcd, 0x49, 0xa7, 0xd5,
+	0x91, 0x34, 0xb0, 0x5c, 0xf9, 0xe7, 0x34, 0xe4, 0x35, 0x77, 0xe2, 0x0e, 0xe6, 0x8c, 0x47, 0x6c,
+	0x64, 0xc5, 0x17, 0xc4, 0x06, 0x05, 0x89, 0x12, 0x27, 0xf2, 0xfb, 0x50, 0x18, 0x73, 0x16, 0xca,
+	0xe1, 0x94, 0x18, 0xce, 0x23, 0x42, 0x0c, 0x7e, 0x00, 0xc5, 0xc8, 0x8f, 0xec, 0xa1, 0x15, 0x89,
+	0x7c, 0x23, 0x2d, 0x57, 0x0b, 0x94, 0xc8, 0x36, 0xc8, 0x77, 0x60, 0x23, 0x3a, 0x0e, 0xfd, 0x28,
+	0x1a, 0x62, 0xae, 0x2b, 0x32, 0x2f, 0x99, 0x28, 0x65, 0x68, 0x39, 0x1e, 0x90, 0x19, 0x19, 0xc7,
+	0xe8, 0x3f, 0x99, 0x8c, 0xa6, 0x2f, 0x82, 0x50, 0x86, 0xae, 0xc5, 0x58, 0x74, 0x0d, 0x3c, 0x7c,
+	0x03, 0x99, 0xd1, 0x88, 0x58, 0x63, 0x50, 0x0d, 0x12, 0x0b, 0xd6, 0x47, 0xcc, 0xe6, 0xe3, 0x90,
+	0x39, 0xd6, 0x0b, 0x97, 0x0d, 0x1d, 0x79, 0x75, 0x56, 0x9a, 0xbb, 0x60, 0xd4, 0x62, 0xa9, 0x3d,
+	0x14, 0xab, 0x69, 0x49, 0x93, 0x93, 0x30, 0x66, 0x1e, 0xf2, 0x1f, 0x59, 0x87, 0x62, 0xf7, 0x59,
+	0xb7, 0xd7, 0xdc, 0xb7, 0xf6, 0x3b, 0x3b, 0x4d, 0xd5, 0xec, 0xd6, 0x6d, 0x52, 0x09, 0x1a, 0x38,
+	0xde, 0xeb, 0xf4, 0xea, 0x7b, 0x56, 0xaf, 0xd5, 0x78, 0xd2, 0x2d, 0xa7, 0xc8, 0x16, 0x6c, 0xf4,
+	0x76, 0x69, 0xa7, 0xd7, 0xdb, 0x6b, 0xee, 0x58, 0x07, 0x4d, 0xda, 0xea, 0xec, 0x74, 0xcb, 0x69,
+	0xbc, 0xe9, 0x9f, 0xa0, 0x7b, 0xad, 0xfd, 0x66, 0x39, 0x83, 0xed, 0x4d, 0x07, 0x4d, 0xda, 0x68,
+	0xb6, 0x7b, 0xe5, 0xac, 0xf9, 0xb3, 0x34, 0x14, 0x13, 0x56, 0x80, 0x8e, 0x10, 0x72, 0x59, 0x4c,
+	0x66, 0x28, 0xfe, 0x15, 0x8f, 0xf3, 0x76, 0xff, 0x58, 0x6a, 0x27, 0x43, 0x25, 0x20, 0x0a, 0x48,
+	0xfb, 0x34, 0x11, 0x27, 0x32, 0x34, 0x3f, 0xb2, 0x4f, 0x25, 0x91, 0x6f, 0xc2, 0xea, 0x2b, 0x16,
+	0x7a, 0x6c, 0xa8, 0xc6, 0xa5, 0x46, 0x8a, 0x12, 0x27, 0xa7, 0xdc, 0x84, 0xb2, 0x9a, 0x32, 0x21,
+	0x23, 0xd5, 0x51, 0x92, 0xf8, 0x7d, 0x4d, 0x6c, 0x13, 0xb2, 0x72, 0x78, 0x45, 0xee, 0x2f, 0x00,
+	0x3c, 0xe6, 0xf8, 0x6b, 0x3b, 0x10, 0x39, 0x68, 0x86, 0x8a, 0xff, 0xe4, 0x68, 0x56, 0x3f, 0x39,
+	0xa1, 0x9f, 0x3b, 0x8b, 0xbb, 0xc3, 0x9b, 0x54, 0x74, 0x1c, 0xab, 0x68, 0x05, 0xd2, 0x54, 0x77,
+	0x88, 0x35, 0xea, 0x8d, 0x5d, 0x54, 0xcb, 0x1a, 0x14, 0xf6, 0xeb, 0x3f, 0xb4, 0x0e, 0xbb, 0xf2,
+	0x0d, 0xa6, 0x0c, 0xab, 0x4f, 0x9a, 0xb4, 0xdd, 0xdc, 0x53, 0x98, 0x34, 0xd9, 0x84, 0xb2, 0xc2,
+	0x4c, 0xe6, 0x65, 0x90, 0x82, 0xfc, 0x9b, 0xc5, 0x7b, 0xfa, 0xee, 0xd3, 0xfa, 0x41, 0x39, 0x67,
+	0xfe, 0x53, 0x0a, 0x56, 0x94, 0x5f, 0x61, 0x4a, 0x20, 0x8b, 0xd5, 0xb3, 0x88, 0x69, 0xe5, 0x88,
+	0x32, 0x54, 0x96, 0x00, 0x1f, 0x40, 0x51, 0x15, 0xac, 0x62, 0x5c, 0x2a, 0x4a, 0x56, 0xa2, 0x72,
+	0x82, 0x2e, 0x76, 0x7d, 0x55, 0xd0, 0x66, 0x64, 0xb1, 0xdb, 0x49, 0x16, 0xbb, 0xba, 0x9e, 0xcd,
+	0xa8, 0x62, 0x17, 0x07, 0xbf, 0x9a, 0x95, 0x68, 0x56, 0x48, 0xf4, 0xfb, 0x8b, 0x05, 0x86, 0x37,
+	0x49, 0xf3, 0x61, 0x2c, 0xcd, 0x12, 0x00, 0x6d, 0xd6, 0x77, 0xac, 0x07, 0xcf, 0x7a, 0x4d, 0x14,
+	0xea, 0x3a, 0x14, 0x9f, 0xd2, 0x56, 0xaf, 0xa9, 0x10, 0x06, 0x59, 0x85, 0xbc, 0x98, 0xd0, 0x39,
+	0x40, 0x73, 0x5f, 0x83, 0x82, 0x1c, 0x46, 0x30, 0x6d, 0xfe, 0x57, 0x0a, 0xd6, 0xe5, 0x11, 0x1c,
+	0xf7, 0xfd, 0xbc, 0xb9, 0xef, 0x21, 0x79, 0x67, 0x9b, 0x9a, 0xbe, 0xb3, 0xd5, 0x09, 0xbf, 0xc8,
+	0xa0, 0xd2, 0x93, 0x84, 0x5f, 0xdc, 0x63, 0x4e, 0x9d, 0xae, 0x99, 0x45, 0x4e, 0xd7, 0x0a, 0xac,
+	0x8c, 0x18, 0x8f, 0x6d, 0xbc, 0x40, 0x35, 0x48, 0x5c, 0x28, 0xda, 0x9e, 0xe7, 0x47, 0xb6, 0x7c,
+	0x08, 0xc9, 0x2d, 0x94, 0x78, 0x9c, 0xfb, 0xe2, 0x5a, 0x7d, 0x42, 0x49, 0x1e, 0x82, 0x49, 0xda,
+	0xd5, 0x2f, 0xa0, 0x7c, 0x7e, 0xc2, 0x22, 0xa9, 0xc7, 0xb7, 0xbf, 0x3b, 0xc9, 0x3c, 0x18, 0xc6,
+	0x10, 0xf5, 0x82, 0x58, 0xbe, 0x82, 0x00, 0x3d, 0x6c, 0xb7, 0x5b, 0xed, 0x47, 0x65, 0x03, 0xdf,
+	0x1d, 0x9b, 0x3f, 0x6c, 0x61, 0x87, 0x6e, 0x6a, 0xfb, 0xef, 0x37, 0x20, 0x27, 0x99, 0x24, 0x3f,
+	0x55, 0x59, 0x57, 0xb2, 0xa7, 0x9c, 0x7c, 0xb1, 0x70, 0xf5, 0x32, 0xd5, 0xa7, 0x5e, 0xbd, 0xbf,
+	0xf4, 0x7a, 0xf5, 0x86, 0x7f, 0x85, 0xfc, 0xb9, 0x01, 0xab, 0x53, 0xef, 0xf7, 0xf3, 0x3e, 0x04,
+	0x5d, 0xd0, 0xc2, 0x5e, 0xfd, 0xc1, 0x52, 0x6b, 0x63, 0x5e, 0x7e, 0x62, 0x40, 0x31, 0xd1, 0xbc,
+	0x4d, 0xee, 0x2c, 0xd3, 0xf0, 0x2d, 0x39, 0xb9, 0xbb, 0x7c, 0xaf, 0xb8, 0x79, 0xe5, 0x13, 0x83,
+	0xfc, 0x99, 0x01, 0xc5, 0x44, 0x1b, 0xf3, 0xdc, 0xac, 0xcc, 0x36, 0x5d, 0x57, 0xef, 0x2e, 0xb3,
+	0x34, 0x96, 0xc9, 0x9f, 0x18, 0x50, 0x88, 0x5b, 0x92, 0xc9, 0xed, 0xc5, 0x9b, 0x98, 0x25, 0x13,
+	0x9f, 0x2d, 0xdb, 0xfd, 0x6c, 0x5e, 0x21, 0x7f, 0x04, 0x79, 0xdd, 0xbf, 0x4b, 0xe6, 0x3d, 0xe9,
+	0xcf, 0x35, 0x07, 0x57, 0x6f, 0x2f, 0xbc, 0x2e, 0xb9, 0xbd, 0x6e, 0xaa, 0x9d, 0x7b, 0xfb, 0x73,
+	0xed, 0xbf, 0xd5, 0xdb, 0x0b, 0xaf, 0x8b, 0xb7, 0x47, 0x4b, 0x48, 0xf4, 0xde, 0xce, 0x6d, 0x09,
+	0xb3, 0x4d, 0xbf, 0xd5, 0xbb, 0xcb, 0x2c, 0x9d, 0x62, 0x24, 0xd1, 0xbd, 0x3b, 0x37, 0x23, 0xb3,
+	0x1d, 0xc2, 0xd5, 0xbb, 0xcb, 0x2c, 0x8d, 0x19, 0xf9, 0xb1, 0x91, 0xac, 0xc1, 0x6e, 0x2f, 0xdc,
+	0xa4, 0xba, 0xa0, 0x49, 0xce, 0xb4, 0xc9, 0x0a, 0x07, 0xfd, 0xb1, 0xba, 0x31, 0x92, 0x3d, 0xae,
+	0x64, 0x11, 0x62, 0x53, 0x6d, 0xb1, 0xd5, 0x4f, 0x97, 0x3b, 0x6c, 0x04, 0x13, 0x7f, 0x6a, 0x00,
+	0x4c, 0xba, 0x61, 0xe7, 0x66, 0x62, 0xa6, 0x0d, 0xb7, 0x7a, 0x67, 0x89, 0x95, 0x49, 0x07, 0xd1,
+	0xdd, 0x7a, 0x73, 0x3b, 0xc8, 0xb9, 0x6e, 0xdd, 0xea, 0xed, 0x85, 0xd7, 0xc5, 0xdb, 0xff, 0x9d,
+	0x01, 0x1b, 0x33, 0xdd, 0x82, 0xe4, 0xfe, 0x25, 0x1b, 0x46, 0xab, 0x5f, 0x2e, 0x4f, 0x40, 0xb3,
+	0x76, 0xd3, 0xf8, 0xc4, 0x20, 0x7f, 0x69, 0xc0, 0xda, 0x74, 0x17, 0xd5, 0xdc, 0xa7, 0xd4, 0x05,
+	0x7d, 0x87, 0xd5, 0x7b, 0xcb, 0x2d, 0x8e, 0xa5, 0xf5, 0xd7, 0x06, 0x94, 0x94, 0x7f, 0x6b, 0x7e,
+	0xee, 0x2d, 0x16, 0x16, 0xce, 0x31, 0xf4, 0xf9, 0x92, 0xab, 0x35, 0x47, 0x0f, 0x56, 0x7e, 0x37,
+	0x2b, 0xb3, 0xb7, 0x9c, 0xf8, 0xf9, 0xde, 0xff, 0x0f, 0x00, 0x3d, 0xca, 0xfc, 0x6d, 0xfa, 0x37,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
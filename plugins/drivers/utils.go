@@ -144,6 +144,21 @@ func ResourcesFromProto(pb *proto.Resources) *Resources {
 			}
 			r.NomadResources.Networks = append(r.NomadResources.Networks, &n)
 		}
+
+		if pb.AllocatedResources.Io != nil {
+			r.NomadResources.IO = &structs.IOResources{
+				Weight: int(pb.AllocatedResources.Io.Weight),
+			}
+			for _, device := range pb.AllocatedResources.Io.Devices {
+				r.NomadResources.IO.Devices = append(r.NomadResources.IO.Devices, &structs.IODeviceResource{
+					Path:      device.Path,
+					ReadBps:   device.ReadBps,
+					WriteBps:  device.WriteBps,
+					ReadIOPS:  device.ReadIops,
+					WriteIOPS: device.WriteIops,
+				})
+			}
+		}
 	}
 
 	if pb.LinuxResources != nil {
@@ -214,6 +229,21 @@ func ResourcesToProto(r *Resources) *proto.Resources {
 			}
 			pb.AllocatedResources.Networks[i] = &n
 		}
+
+		if io := r.NomadResources.IO; io != nil {
+			pb.AllocatedResources.Io = &proto.AllocatedIOResources{
+				Weight: int64(io.Weight),
+			}
+			for _, device := range io.Devices {
+				pb.AllocatedResources.Io.Devices = append(pb.AllocatedResources.Io.Devices, &proto.AllocatedIODeviceResources{
+					Path:      device.Path,
+					ReadBps:   device.ReadBps,
+					WriteBps:  device.WriteBps,
+					ReadIops:  device.ReadIOPS,
+					WriteIops: device.WriteIOPS,
+				})
+			}
+		}
 	}
 
 	if r.LinuxResources != nil {
@@ -490,9 +520,21 @@ func resourceUsageToProto(ru *ResourceUsage) *proto.TaskResourceUsage {
 		KernelMaxUsage: ru.MemoryStats.KernelMaxUsage,
 	}
 
+	var io *proto.IOUsage
+	if ru.IOStats != nil {
+		io = &proto.IOUsage{
+			MeasuredFields: ioUsageMeasuredFieldsToProto(ru.IOStats.Measured),
+			ReadBytes:      ru.IOStats.ReadBytes,
+			WriteBytes:     ru.IOStats.WriteBytes,
+			ReadOps:        ru.IOStats.ReadOps,
+			WriteOps:       ru.IOStats.WriteOps,
+		}
+	}
+
 	return &proto.TaskResourceUsage{
 		Cpu:    cpu,
 		Memory: memory,
+		Io:     io,
 	}
 }
 
@@ -524,9 +566,21 @@ func resourceUsageFromProto(pb *proto.TaskResourceUsage) *ResourceUsage {
 		}
 	}
 
+	var io *IOStats
+	if pb.Io != nil {
+		io = &IOStats{
+			Measured:   ioUsageMeasuredFieldsFromProto(pb.Io.MeasuredFields),
+			ReadBytes:  pb.Io.ReadBytes,
+			WriteBytes: pb.Io.WriteBytes,
+			ReadOps:    pb.Io.ReadOps,
+			WriteOps:   pb.Io.WriteOps,
+		}
+	}
+
 	return &ResourceUsage{
 		CpuStats:    &cpu,
 		MemoryStats: &memory,
+		IOStats:     io,
 	}
 }
 
@@ -620,6 +674,44 @@ func memoryUsageMeasuredFieldsFromProto(fields []proto.MemoryUsage_Fields) []str
 	return r
 }
 
+var ioUsageMeasuredFieldToProtoMap = map[string]proto.IOUsage_Fields{
+	"Read Bytes":  proto.IOUsage_READ_BYTES,
+	"Write Bytes": proto.IOUsage_WRITE_BYTES,
+	"Read Ops":    proto.IOUsage_READ_OPS,
+	"Write Ops":   proto.IOUsage_WRITE_OPS,
+}
+
+var ioUsageMeasuredFieldFromProtoMap = map[proto.IOUsage_Fields]string{
+	proto.IOUsage_READ_BYTES:  "Read Bytes",
+	proto.IOUsage_WRITE_BYTES: "Write Bytes",
+	proto.IOUsage_READ_OPS:    "Read Ops",
+	proto.IOUsage_WRITE_OPS:   "Write Ops",
+}
+
+func ioUsageMeasuredFieldsToProto(fields []string) []proto.IOUsage_Fields {
+	r := make([]proto.IOUsage_Fields, 0, len(fields))
+
+	for _, f := range fields {
+		if v, ok := ioUsageMeasuredFieldToProtoMap[f]; ok {
+			r = append(r, v)
+		}
+	}
+
+	return r
+}
+
+func ioUsageMeasuredFieldsFromProto(fields []proto.IOUsage_Fields) []string {
+	r := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		if v, ok := ioUsageMeasuredFieldFromProtoMap[f]; ok {
+			r = append(r, v)
+		}
+	}
+
+	return r
+}
+
 func netIsolationModeToProto(mode NetIsolationMode) proto.NetworkIsolationSpec_NetworkIsolationMode {
 	switch mode {
 	case NetIsolationModeHost:
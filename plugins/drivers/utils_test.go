@@ -32,6 +32,13 @@ func TestResourceUsageRoundTrip(t *testing.T) {
 			KernelMaxUsage: 45,
 			Measured:       []string{"RSS", "Swap"},
 		},
+		IOStats: &IOStats{
+			ReadBytes:  4096,
+			WriteBytes: 8192,
+			ReadOps:    1,
+			WriteOps:   2,
+			Measured:   []string{"Read Bytes", "Write Bytes", "Read Ops", "Write Ops"},
+		},
 	}
 
 	parsed := resourceUsageFromProto(resourceUsageToProto(input))
@@ -59,6 +66,14 @@ func TestTaskConfigRoundTrip(t *testing.T) {
 				Memory: structs.AllocatedMemoryResources{
 					MemoryMB: int64(300),
 				},
+				IO: &structs.IOResources{
+					Weight: 100,
+					Devices: []*structs.IODeviceResource{{
+						Path:     "/dev/sda",
+						ReadBps:  1024,
+						WriteBps: 2048,
+					}},
+				},
 			},
 			LinuxResources: &LinuxResources{
 				MemoryLimitBytes: 300 * 1024 * 1024,
@@ -32,6 +32,7 @@ const (
 	FilterConstraintDrivers                        = "missing drivers"
 	FilterConstraintDevices                        = "missing devices"
 	FilterConstraintsCSIPluginTopology             = "did not meet topology requirement"
+	FilterConstraintTaintTemplate                  = "untolerated taint %s"
 )
 
 var (
@@ -134,6 +135,42 @@ func NewRandomIterator(ctx Context, nodes []*structs.Node) *StaticIterator {
 	return NewStaticIterator(ctx, nodes)
 }
 
+// TaintChecker is a FeasibilityChecker which returns whether a task group
+// tolerates the no_schedule and no_execute taints of a node.
+type TaintChecker struct {
+	ctx         Context
+	tolerations []*structs.Toleration
+}
+
+// NewTaintChecker creates a TaintChecker
+func NewTaintChecker(ctx Context) *TaintChecker {
+	return &TaintChecker{
+		ctx: ctx,
+	}
+}
+
+// SetTolerations takes the tolerations of a task group and updates the
+// checker.
+func (c *TaintChecker) SetTolerations(tolerations []*structs.Toleration) {
+	c.tolerations = tolerations
+}
+
+func (c *TaintChecker) Feasible(candidate *structs.Node) bool {
+	// Fast path: the node has no taints
+	if len(candidate.Taints) == 0 {
+		return true
+	}
+
+	untolerated := candidate.UntoleratedTaints(c.tolerations,
+		structs.NodeTaintEffectNoSchedule, structs.NodeTaintEffectNoExecute)
+	if len(untolerated) == 0 {
+		return true
+	}
+
+	c.ctx.Metrics().FilterNode(candidate, fmt.Sprintf(FilterConstraintTaintTemplate, untolerated[0]))
+	return false
+}
+
 // HostVolumeChecker is a FeasibilityChecker which returns whether a node has
 // the host volumes necessary to schedule a task group.
 type HostVolumeChecker struct {
@@ -748,6 +785,184 @@ func (iter *DistinctPropertyIterator) Reset() {
 	}
 }
 
+// AllocationAffinityIterator is a FeasibleIterator which returns nodes that
+// satisfy the required allocation_affinity blocks of a task group. A required
+// affinity filters out nodes that are not running a matching allocation, and
+// a required anti-affinity filters out nodes that are.
+type AllocationAffinityIterator struct {
+	ctx     Context
+	source  FeasibleIterator
+	matcher *allocationAffinityMatcher
+
+	// required are the required allocation affinities of the task group
+	required []*structs.AllocationAffinity
+}
+
+// NewAllocationAffinityIterator creates an AllocationAffinityIterator from a
+// source.
+func NewAllocationAffinityIterator(ctx Context, source FeasibleIterator) *AllocationAffinityIterator {
+	return &AllocationAffinityIterator{
+		ctx:     ctx,
+		source:  source,
+		matcher: newAllocationAffinityMatcher(ctx),
+	}
+}
+
+func (iter *AllocationAffinityIterator) SetJob(job *structs.Job) {
+	iter.matcher.SetJob(job)
+}
+
+func (iter *AllocationAffinityIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.required = iter.required[:0]
+	for _, aa := range tg.AllocationAffinities {
+		if aa.Required {
+			iter.required = append(iter.required, aa)
+		}
+	}
+}
+
+func (iter *AllocationAffinityIterator) Next() *structs.Node {
+	for {
+		option := iter.source.Next()
+
+		// Hot-path if the option is nil or there are no required allocation
+		// affinities.
+		if option == nil || len(iter.required) == 0 {
+			return option
+		}
+
+		if reason := iter.unsatisfied(option); reason != "" {
+			iter.ctx.Metrics().FilterNode(option, reason)
+			continue
+		}
+
+		return option
+	}
+}
+
+// unsatisfied returns the reason the node does not satisfy the required
+// allocation affinities, or an empty string if it does.
+func (iter *AllocationAffinityIterator) unsatisfied(option *structs.Node) string {
+	for _, aa := range iter.required {
+		matched, err := iter.matcher.matchesNode(aa, option.ID)
+		if err != nil {
+			iter.ctx.Logger().Named("allocation_affinity").Error("failed to match allocations", "error", err)
+			return "allocation_affinity: failed to match allocations"
+		}
+		if matched && aa.Anti {
+			return fmt.Sprintf("allocation_affinity: node runs allocations matching anti-affinity %s", aa)
+		}
+		if !matched && !aa.Anti {
+			return fmt.Sprintf("allocation_affinity: node runs no allocations matching %s", aa)
+		}
+	}
+	return ""
+}
+
+func (iter *AllocationAffinityIterator) Reset() {
+	iter.source.Reset()
+}
+
+// allocationAffinityMatcher matches the proposed allocations of a node
+// against allocation affinities.
+type allocationAffinityMatcher struct {
+	ctx Context
+	job *structs.Job
+
+	// jobs memoizes the jobs of proposed allocations, by namespace and ID,
+	// which are needed to match allocation affinities on meta.
+	jobs map[structs.NamespacedID]*structs.Job
+}
+
+func newAllocationAffinityMatcher(ctx Context) *allocationAffinityMatcher {
+	return &allocationAffinityMatcher{
+		ctx:  ctx,
+		jobs: make(map[structs.NamespacedID]*structs.Job),
+	}
+}
+
+func (m *allocationAffinityMatcher) SetJob(job *structs.Job) {
+	m.job = job
+	m.jobs = make(map[structs.NamespacedID]*structs.Job)
+}
+
+// matchesNode returns whether any of the proposed allocations of the node
+// matches the allocation affinity.
+func (m *allocationAffinityMatcher) matchesNode(aa *structs.AllocationAffinity, nodeID string) (bool, error) {
+	proposed, err := m.ctx.ProposedAllocs(nodeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get proposed allocations: %v", err)
+	}
+
+	for _, alloc := range proposed {
+		matched, err := m.matchesAlloc(aa, alloc)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matchesAlloc returns whether the allocation matches the allocation affinity.
+func (m *allocationAffinityMatcher) matchesAlloc(aa *structs.AllocationAffinity, alloc *structs.Allocation) (bool, error) {
+	if alloc.TerminalStatus() {
+		return false, nil
+	}
+
+	namespace := aa.Namespace
+	if namespace == "" {
+		namespace = m.job.Namespace
+	}
+	switch {
+	case namespace != structs.AllocationAffinityAnyNamespace && alloc.Namespace != namespace:
+		return false, nil
+	case aa.JobID != "" && alloc.JobID != aa.JobID:
+		return false, nil
+	case aa.TaskGroup != "" && alloc.TaskGroup != aa.TaskGroup:
+		return false, nil
+	case len(aa.Meta) == 0:
+		return true, nil
+	}
+
+	job, err := m.allocJob(alloc)
+	if err != nil || job == nil {
+		return false, err
+	}
+	meta := job.CombinedTaskMeta(alloc.TaskGroup, "")
+	for k, v := range aa.Meta {
+		if value, ok := meta[k]; !ok || value != v {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// allocJob returns the job of the allocation. Allocations placed by the plan
+// do not have their job set, so it is looked up from the plan or the state.
+func (m *allocationAffinityMatcher) allocJob(alloc *structs.Allocation) (*structs.Job, error) {
+	if alloc.Job != nil {
+		return alloc.Job, nil
+	}
+
+	id := structs.NewNamespacedID(alloc.JobID, alloc.Namespace)
+	if m.job != nil && m.job.NamespacedID() == id {
+		return m.job, nil
+	}
+	if job, ok := m.jobs[id]; ok {
+		return job, nil
+	}
+
+	job, err := m.ctx.State().JobByID(nil, alloc.Namespace, alloc.JobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup job %q: %v", alloc.JobID, err)
+	}
+	m.jobs[id] = job
+	return job, nil
+}
+
 // ConstraintChecker is a FeasibilityChecker which returns nodes that match a
 // given set of constraints. This is used to filter on job, task group, and task
 // constraints.
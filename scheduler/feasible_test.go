@@ -2474,6 +2474,168 @@ func TestDistinctPropertyIterator_TaskGroupDistinctProperty(t *testing.T) {
 	}
 }
 
+func TestTaintChecker(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+	nodes := []*structs.Node{mock.Node(), mock.Node(), mock.Node(), mock.Node()}
+	nodes[1].Taints = []*structs.NodeTaint{
+		{Key: "dedicated", Value: "gpu", Effect: structs.NodeTaintEffectNoSchedule},
+	}
+	nodes[2].Taints = []*structs.NodeTaint{
+		{Key: "maintenance", Effect: structs.NodeTaintEffectNoExecute},
+	}
+	nodes[3].Taints = []*structs.NodeTaint{
+		{Key: "spot", Effect: structs.NodeTaintEffectPreferNoSchedule},
+	}
+
+	cases := []struct {
+		name        string
+		tolerations []*structs.Toleration
+		results     []bool
+	}{
+		{
+			name:    "no tolerations",
+			results: []bool{true, false, false, true},
+		},
+		{
+			name: "tolerates value",
+			tolerations: []*structs.Toleration{
+				{Key: "dedicated", Operator: structs.TolerationOperatorEqual, Value: "gpu"},
+			},
+			results: []bool{true, true, false, true},
+		},
+		{
+			name: "wrong value",
+			tolerations: []*structs.Toleration{
+				{Key: "dedicated", Operator: structs.TolerationOperatorEqual, Value: "db"},
+			},
+			results: []bool{true, false, false, true},
+		},
+		{
+			name: "tolerates everything",
+			tolerations: []*structs.Toleration{
+				{Operator: structs.TolerationOperatorExists},
+			},
+			results: []bool{true, true, true, true},
+		},
+		{
+			name: "wrong effect",
+			tolerations: []*structs.Toleration{
+				{Key: "maintenance", Operator: structs.TolerationOperatorExists,
+					Effect: structs.NodeTaintEffectNoSchedule},
+			},
+			results: []bool{true, false, false, true},
+		},
+	}
+
+	checker := NewTaintChecker(ctx)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker.SetTolerations(tc.tolerations)
+			for i, node := range nodes {
+				must.Eq(t, tc.results[i], checker.Feasible(node), must.Sprintf("node %d", i))
+			}
+		})
+	}
+}
+
+func TestAllocationAffinityIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	store, ctx := testContext(t)
+	nodes := []*structs.Node{mock.Node(), mock.Node(), mock.Node()}
+
+	// Run an alloc of the "api" job on node0
+	api := mock.Job()
+	api.ID = "api"
+	api.Meta = map[string]string{"tier": "frontend"}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, api))
+	existing := mock.Alloc()
+	existing.Job = api
+	existing.JobID = api.ID
+	existing.NodeID = nodes[0].ID
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001,
+		[]*structs.Allocation{existing}))
+
+	// Propose an alloc of the "db-replica" job on node1. Allocs in the plan
+	// do not have their job set, so its meta is looked up from the state.
+	replica := mock.Job()
+	replica.ID = "db-replica"
+	replica.Meta = map[string]string{"tier": "storage"}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, replica))
+	ctx.Plan().NodeAllocation[nodes[1].ID] = []*structs.Allocation{{
+		ID:        uuid.Generate(),
+		Namespace: replica.Namespace,
+		JobID:     replica.ID,
+		TaskGroup: replica.TaskGroups[0].Name,
+		NodeID:    nodes[1].ID,
+	}}
+
+	cases := []struct {
+		name     string
+		affinity *structs.AllocationAffinity
+		expected []*structs.Node
+	}{
+		{
+			name:     "affinity to job",
+			affinity: &structs.AllocationAffinity{JobID: "api", Required: true},
+			expected: []*structs.Node{nodes[0]},
+		},
+		{
+			name:     "anti-affinity to proposed job",
+			affinity: &structs.AllocationAffinity{JobID: "db-replica", Anti: true, Required: true},
+			expected: []*structs.Node{nodes[0], nodes[2]},
+		},
+		{
+			name: "affinity to meta of proposed job",
+			affinity: &structs.AllocationAffinity{
+				Meta:     map[string]string{"tier": "storage"},
+				Required: true,
+			},
+			expected: []*structs.Node{nodes[1]},
+		},
+		{
+			name: "affinity to other namespace",
+			affinity: &structs.AllocationAffinity{
+				Namespace: "other",
+				JobID:     "api",
+				Required:  true,
+			},
+			expected: nil,
+		},
+		{
+			name: "affinity to any namespace",
+			affinity: &structs.AllocationAffinity{
+				Namespace: structs.AllocationAffinityAnyNamespace,
+				JobID:     "api",
+				Required:  true,
+			},
+			expected: []*structs.Node{nodes[0]},
+		},
+		{
+			name:     "preferred affinity is not filtered",
+			affinity: &structs.AllocationAffinity{JobID: "api", Weight: 50},
+			expected: nodes,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := mock.Job()
+			tg := job.TaskGroups[0]
+			tg.AllocationAffinities = []*structs.AllocationAffinity{tc.affinity}
+
+			static := NewStaticIterator(ctx, nodes)
+			iter := NewAllocationAffinityIterator(ctx, static)
+			iter.SetJob(job)
+			iter.SetTaskGroup(tg)
+
+			must.Eq(t, tc.expected, collectFeasible(iter))
+		})
+	}
+}
+
 func collectFeasible(iter FeasibleIterator) (out []*structs.Node) {
 	for {
 		next := iter.Next()
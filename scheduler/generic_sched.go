@@ -6,6 +6,7 @@ package scheduler
 import (
 	"fmt"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"time"
 
@@ -56,6 +57,10 @@ const (
 	// allocRescheduled is the status used when an allocation failed and was rescheduled
 	allocRescheduled = "alloc was rescheduled because it failed"
 
+	// allocCompletionFailed is the status used when stopping an alloc because
+	// its task group exceeded the backoff limit of its completion policy.
+	allocCompletionFailed = "alloc not needed as task group exceeded its backoff limit"
+
 	// blockedEvalMaxPlanDesc is the description used for blocked evals that are
 	// a result of hitting the max number of plan attempts
 	blockedEvalMaxPlanDesc = "created due to placement conflicts"
@@ -64,6 +69,10 @@ const (
 	// that are a result of failing to place all allocations.
 	blockedEvalFailedPlacements = "created to place remaining allocations"
 
+	// blockedEvalDependencies is the description used for blocked evals that
+	// are waiting for the dependencies of their job to be met.
+	blockedEvalDependencies = "created to wait on job dependencies"
+
 	// reschedulingFollowupEvalDesc is the description used when creating follow
 	// up evals for delayed rescheduling
 	reschedulingFollowupEvalDesc = "created for delayed rescheduling"
@@ -73,6 +82,10 @@ const (
 	// timeout has passed.
 	disconnectTimeoutFollowupEvalDesc = "created for delayed disconnect timeout"
 
+	// holdFollowupEvalDesc is the description used when creating follow up
+	// evals for the end of the hold period of a blue/green deployment.
+	holdFollowupEvalDesc = "created for the end of a blue/green hold period"
+
 	// maxPastRescheduleEvents is the maximum number of past reschedule event
 	// that we track when unlimited rescheduling is enabled
 	maxPastRescheduleEvents = 5
@@ -119,6 +132,16 @@ type GenericScheduler struct {
 	blocked        *structs.Evaluation
 	failedTGAllocs map[string]*structs.AllocMetric
 	queuedAllocs   map[string]int
+
+	// pendingDependencies are the IDs of the jobs the job depends on whose
+	// condition is not met.
+	pendingDependencies []string
+
+	// dependencyJob is the older version of the job that is still running
+	// while the dependencies of the new version are pending. It is scheduled
+	// instead of the job, so that its allocations keep being rescheduled and
+	// replaced while the placements of the new version are held.
+	dependencyJob *structs.Job
 }
 
 // NewServiceScheduler is a factory function to instantiate a new service scheduler
@@ -170,7 +193,9 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		structs.EvalTriggerPeriodicJob, structs.EvalTriggerMaxPlans,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerRetryFailedAlloc,
 		structs.EvalTriggerFailedFollowUp, structs.EvalTriggerPreemption,
-		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect:
+		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect,
+		structs.EvalTriggerRebalance, structs.EvalTriggerArrayProgress,
+		structs.EvalTriggerCompletionProgress, structs.EvalTriggerDeploymentHold:
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
@@ -179,6 +204,11 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 			s.deployment.GetID())
 	}
 
+	// Hold the evaluation until the dependencies of the job are met.
+	if held, err := s.holdForDependencies(); held || err != nil {
+		return err
+	}
+
 	// Retry up to the maxScheduleAttempts and reset if progress is made.
 	progress := func() bool { return progressMade(s.planResult) }
 	limit := maxServiceScheduleAttempts
@@ -204,13 +234,16 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 	}
 
 	// If the current evaluation is a blocked evaluation and we didn't place
-	// everything, do not update the status to complete.
-	if s.eval.Status == structs.EvalStatusBlocked && len(s.failedTGAllocs) != 0 {
+	// everything, or the dependencies of the job are still pending, do not
+	// update the status to complete.
+	if s.eval.Status == structs.EvalStatusBlocked &&
+		(len(s.failedTGAllocs) != 0 || len(s.pendingDependencies) != 0) {
 		e := s.ctx.Eligibility()
 		newEval := s.eval.Copy()
 		newEval.EscapedComputedClass = e.HasEscaped()
 		newEval.ClassEligibility = e.GetClasses()
 		newEval.QuotaLimitReached = e.QuotaLimitReached()
+		newEval.PendingDependencies = s.pendingDependencies
 		return s.planner.ReblockEval(newEval)
 	}
 
@@ -220,6 +253,57 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		s.deployment.GetID())
 }
 
+// holdForDependencies blocks the evaluation if the dependencies of the job are
+// not met. If the evaluation is not already blocked, a blocked eval waiting on
+// the dependencies is created. Only the placements of the new version of the
+// job are held: while an older version is still running, the evaluation
+// schedules that version instead so its allocations keep being rescheduled and
+// replaced. It returns whether the evaluation was held without scheduling.
+func (s *GenericScheduler) holdForDependencies() (bool, error) {
+	job, err := s.state.JobByID(nil, s.eval.Namespace, s.eval.JobID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get job %q: %v", s.eval.JobID, err)
+	}
+
+	pending, err := pendingJobDependencies(s.state, job)
+	if err != nil {
+		return false, err
+	}
+	if len(pending) == 0 {
+		return false, nil
+	}
+	s.logger.Debug("job dependencies not met", "pending", pending)
+	s.pendingDependencies = pending
+
+	s.dependencyJob, err = runningJobVersion(s.state, job)
+	if err != nil {
+		return false, err
+	}
+
+	// A blocked evaluation is reblocked once the running version is
+	// scheduled, if any
+	if s.eval.Status == structs.EvalStatusBlocked {
+		if s.dependencyJob != nil {
+			return false, nil
+		}
+		newEval := s.eval.Copy()
+		newEval.PendingDependencies = pending
+		return true, s.planner.ReblockEval(newEval)
+	}
+
+	s.blocked = s.eval.CreateBlockedEval(nil, false, "", nil)
+	s.blocked.PendingDependencies = pending
+	s.blocked.StatusDescription = blockedEvalDependencies
+	if err := s.planner.CreateEval(s.blocked); err != nil {
+		return true, err
+	}
+	if s.dependencyJob != nil {
+		return false, nil
+	}
+	return true, setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
+		nil, structs.EvalStatusComplete, "", nil, "")
+}
+
 // createBlockedEval creates a blocked eval and submits it to the planner. If
 // failure is set to true, the eval's trigger reason reflects that.
 func (s *GenericScheduler) createBlockedEval(planFailure bool) error {
@@ -233,6 +317,7 @@ func (s *GenericScheduler) createBlockedEval(planFailure bool) error {
 	}
 
 	s.blocked = s.eval.CreateBlockedEval(classEligibility, escaped, e.QuotaLimitReached(), s.failedTGAllocs)
+	s.blocked.PendingDependencies = s.pendingDependencies
 	if planFailure {
 		s.blocked.TriggeredBy = structs.EvalTriggerMaxPlans
 		s.blocked.StatusDescription = blockedEvalMaxPlanDesc
@@ -253,6 +338,9 @@ func (s *GenericScheduler) process() (bool, error) {
 	if err != nil {
 		return false, fmt.Errorf("failed to get job %q: %v", s.eval.JobID, err)
 	}
+	if s.dependencyJob != nil {
+		s.job = s.dependencyJob
+	}
 
 	numTaskGroups := 0
 	stopped := s.job.Stopped()
@@ -265,6 +353,12 @@ func (s *GenericScheduler) process() (bool, error) {
 	// Create a plan
 	s.plan = s.eval.MakePlan(s.job)
 
+	// Gang task groups must never be partially placed, so require the plan
+	// applier to commit the plan in full or not at all.
+	if !stopped && s.job.HasGangTaskGroups() {
+		s.plan.AllAtOnce = true
+	}
+
 	if !s.batch {
 		// Get any existing deployment
 		s.deployment, err = s.state.LatestDeploymentByJobID(ws, s.eval.Namespace, s.eval.JobID)
@@ -524,6 +618,10 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 	// Capture current time to use as the start time for any rescheduled allocations
 	now := time.Now()
 
+	// Track the placements made for gang task groups so they can be backed
+	// out if any placement of the same group fails.
+	var gangPlaced map[string][]*gangPlacement
+
 	// Have to handle destructive changes first as we need to discount their
 	// resources. To understand this imagine the resources were reduced and the
 	// count was scaled up.
@@ -707,6 +805,18 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 				// Track the placement
 				s.plan.AppendAlloc(alloc, downgradedJob)
 
+				if tg.Gang {
+					if gangPlaced == nil {
+						gangPlaced = make(map[string][]*gangPlacement)
+					}
+					gangPlaced[tg.Name] = append(gangPlaced[tg.Name], &gangPlacement{
+						alloc:         alloc,
+						prevAlloc:     prevAllocation,
+						stopPrevAlloc: stopPrevAlloc,
+						rescheduling:  missing.IsRescheduling(),
+					})
+				}
+
 			} else {
 				// Lazy initialize the failed map
 				if s.failedTGAllocs == nil {
@@ -739,9 +849,105 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 		}
 	}
 
+	// Gang task groups are placed all-or-nothing, so back out every
+	// placement of a group that could not be placed in full.
+	for tgName, placed := range gangPlaced {
+		metric, ok := s.failedTGAllocs[tgName]
+		if !ok {
+			continue
+		}
+		s.rollbackGangPlacements(placed)
+		metric.CoalescedFailures += len(placed)
+		s.logger.Debug("failed to place all allocations of gang task group, placements reverted",
+			"task_group", tgName, "reverted", len(placed))
+	}
+
 	return nil
 }
 
+// gangPlacement records a successful placement for a gang task group so that
+// it can be reverted if the rest of the group cannot be placed.
+type gangPlacement struct {
+	alloc         *structs.Allocation
+	prevAlloc     *structs.Allocation
+	stopPrevAlloc bool
+	rescheduling  bool
+}
+
+// rollbackGangPlacements removes the given placements from the plan, along
+// with the stops of the allocations they replaced and any allocations they
+// preempted.
+func (s *GenericScheduler) rollbackGangPlacements(placed []*gangPlacement) {
+	for _, p := range placed {
+		s.plan.NodeAllocation[p.alloc.NodeID] = removeAllocByID(
+			s.plan.NodeAllocation[p.alloc.NodeID], p.alloc.ID)
+		if len(s.plan.NodeAllocation[p.alloc.NodeID]) == 0 {
+			delete(s.plan.NodeAllocation, p.alloc.NodeID)
+		}
+
+		for nodeID, preempted := range s.plan.NodePreemptions {
+			remaining := make([]*structs.Allocation, 0, len(preempted))
+			for _, alloc := range preempted {
+				if alloc.PreemptedByAllocation != p.alloc.ID {
+					remaining = append(remaining, alloc)
+				}
+			}
+			s.unannotatePreemptions(p.alloc, preempted, remaining)
+			if len(remaining) == 0 {
+				delete(s.plan.NodePreemptions, nodeID)
+			} else {
+				s.plan.NodePreemptions[nodeID] = remaining
+			}
+		}
+
+		if p.prevAlloc == nil {
+			continue
+		}
+		if p.stopPrevAlloc {
+			s.plan.NodeUpdate[p.prevAlloc.NodeID] = removeAllocByID(
+				s.plan.NodeUpdate[p.prevAlloc.NodeID], p.prevAlloc.ID)
+			if len(s.plan.NodeUpdate[p.prevAlloc.NodeID]) == 0 {
+				delete(s.plan.NodeUpdate, p.prevAlloc.NodeID)
+			}
+		}
+		if p.rescheduling {
+			annotateRescheduleTracker(p.prevAlloc, structs.LastRescheduleFailedToPlace)
+		}
+	}
+}
+
+// unannotatePreemptions removes the plan annotations of the preemptions that
+// were reverted along with the placement of alloc.
+func (s *GenericScheduler) unannotatePreemptions(alloc *structs.Allocation, preempted, remaining []*structs.Allocation) {
+	if s.plan.Annotations == nil || len(preempted) == len(remaining) {
+		return
+	}
+
+	reverted := len(preempted) - len(remaining)
+	if desired, ok := s.plan.Annotations.DesiredTGUpdates[alloc.TaskGroup]; ok {
+		desired.Preemptions -= uint64(reverted)
+	}
+
+	stubs := s.plan.Annotations.PreemptedAllocs[:0]
+	for _, stub := range s.plan.Annotations.PreemptedAllocs {
+		if !slices.Contains(alloc.PreemptedAllocations, stub.ID) {
+			stubs = append(stubs, stub)
+		}
+	}
+	s.plan.Annotations.PreemptedAllocs = stubs
+}
+
+// removeAllocByID returns the allocations without the one matching the given
+// ID.
+func removeAllocByID(allocs []*structs.Allocation, id string) []*structs.Allocation {
+	for i, alloc := range allocs {
+		if alloc.ID == id {
+			return append(allocs[:i:i], allocs[i+1:]...)
+		}
+	}
+	return allocs
+}
+
 // setJob updates the stack with the given job and job's node pool scheduler
 // configuration.
 func (s *GenericScheduler) setJob(job *structs.Job) error {
@@ -832,6 +1038,12 @@ func getSelectOptions(prevAllocation *structs.Allocation, preferredNode *structs
 		if prevAllocation.ClientStatus == structs.AllocClientStatusFailed {
 			penaltyNodes[prevAllocation.NodeID] = struct{}{}
 		}
+
+		// If alloc is migrated, penalize the node it is migrated from so
+		// that allocs the rebalancer moves off a node don't land back on it.
+		if prevAllocation.DesiredTransition.ShouldMigrate() {
+			penaltyNodes[prevAllocation.NodeID] = struct{}{}
+		}
 		if prevAllocation.RescheduleTracker != nil {
 			for _, reschedEvent := range prevAllocation.RescheduleTracker.Events {
 				penaltyNodes[reschedEvent.PrevNodeID] = struct{}{}
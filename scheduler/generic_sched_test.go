@@ -1778,6 +1778,149 @@ func TestServiceSched_EvaluateBlockedEval(t *testing.T) {
 	}
 }
 
+func TestServiceSched_JobDependencies(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	for i := 0; i < 3; i++ {
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), mock.Node()))
+	}
+
+	// Create a batch job that has not completed and a job depending on it
+	dep := mock.BatchJob()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, dep))
+
+	job := mock.Job()
+	job.DependsOn = []*structs.JobDependency{{JobID: dep.ID, Condition: structs.JobDependencyConditionSuccessful}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// The eval completes without a plan, handing over to a blocked eval
+	// waiting on the dependency
+	must.SliceEmpty(t, h.Plans)
+	must.Len(t, 1, h.CreateEvals)
+	blocked := h.CreateEvals[0]
+	must.Eq(t, structs.EvalStatusBlocked, blocked.Status)
+	must.Eq(t, []string{dep.ID}, blocked.PendingDependencies)
+	must.Eq(t, blockedEvalDependencies, blocked.StatusDescription)
+	must.Len(t, 1, h.Evals)
+	must.Eq(t, structs.EvalStatusComplete, h.Evals[0].Status)
+	must.Eq(t, blocked.ID, h.Evals[0].BlockedEval)
+
+	// The blocked eval is reblocked while the dependency is not met
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{blocked}))
+	must.NoError(t, h.Process(NewServiceScheduler, blocked))
+	must.SliceEmpty(t, h.Plans)
+	must.Len(t, 1, h.ReblockEvals)
+	must.Eq(t, []string{dep.ID}, h.ReblockEvals[0].PendingDependencies)
+
+	// Complete the dependency, the blocked eval places the job
+	alloc := mock.Alloc()
+	alloc.Job = dep
+	alloc.JobID = dep.ID
+	alloc.TaskGroup = dep.TaskGroups[0].Name
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	alloc.DesiredStatus = structs.AllocDesiredStatusStop
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+
+	ws := memdb.NewWatchSet()
+	out, err := h.State.JobByID(ws, dep.Namespace, dep.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.JobStatusDead, out.Status)
+
+	must.NoError(t, h.Process(NewServiceScheduler, blocked))
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 1, h.ReblockEvals)
+}
+
+func TestServiceSched_JobDependencies_RunningVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	nodes := make([]*structs.Node, 0, 3)
+	for i := 0; i < 3; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Run the first version of a job
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	allocs := make([]*structs.Allocation, 0, 2)
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = nodes[i].ID
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// Register a new version depending on a batch job that has not completed
+	dep := mock.BatchJob()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, dep))
+
+	job2 := job.Copy()
+	job2.Meta = map[string]string{"version": "2"}
+	job2.DependsOn = []*structs.JobDependency{{JobID: dep.ID, Condition: structs.JobDependencyConditionSuccessful}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job2))
+
+	// Lose the node of one of the allocations of the running version
+	must.NoError(t, h.State.UpdateNodeStatus(structs.MsgTypeTestSetup, h.NextIndex(),
+		nodes[0].ID, structs.NodeStatusDown, time.Now().UnixNano(), nil))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerNodeUpdate,
+		JobID:       job.ID,
+		NodeID:      nodes[0].ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// The lost allocation is replaced with the running version, and the other
+	// allocation is not updated to the new version
+	must.Len(t, 1, h.Plans)
+	plan := h.Plans[0]
+	must.Eq(t, uint64(0), plan.Job.Version)
+	must.MapLen(t, 1, plan.NodeUpdate)
+	must.Len(t, 1, plan.NodeUpdate[nodes[0].ID])
+	var placed []*structs.Allocation
+	for _, nodeAllocs := range plan.NodeAllocation {
+		placed = append(placed, nodeAllocs...)
+	}
+	must.Len(t, 1, placed)
+	must.Eq(t, allocs[0].Name, placed[0].Name)
+
+	// The new version is still held by a blocked eval waiting on the
+	// dependency
+	must.Len(t, 1, h.CreateEvals)
+	blocked := h.CreateEvals[0]
+	must.Eq(t, structs.EvalStatusBlocked, blocked.Status)
+	must.Eq(t, []string{dep.ID}, blocked.PendingDependencies)
+	must.Len(t, 1, h.Evals)
+	must.Eq(t, structs.EvalStatusComplete, h.Evals[0].Status)
+	must.Eq(t, blocked.ID, h.Evals[0].BlockedEval)
+}
+
 func TestServiceSched_EvaluateBlockedEval_Finished(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3982,6 +4125,64 @@ func TestServiceSched_NodeDrain(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_Rebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	node1, node2 := mock.Node(), mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node1))
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node2))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	// Create allocations with one marked for migration by the rebalancer.
+	// Nothing else runs on its node once it is stopped, so only the penalty
+	// of the node it is migrated from keeps the replacement away from it.
+	var allocs []*structs.Allocation
+	for i, node := range []*structs.Node{node1, node2} {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		allocs = append(allocs, alloc)
+	}
+	allocs[0].DesiredTransition.Migrate = pointer.Of(true)
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerRebalance,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Ensure the marked allocation was migrated
+	must.Len(t, 1, h.Plans)
+	plan := h.Plans[0]
+	must.Len(t, 1, plan.NodeUpdate[node1.ID])
+	must.Eq(t, allocs[0].ID, plan.NodeUpdate[node1.ID][0].ID)
+
+	var planned []*structs.Allocation
+	for _, allocList := range plan.NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	must.Len(t, 1, planned)
+	must.Eq(t, allocs[0].ID, planned[0].PreviousAllocation)
+	must.Eq(t, node2.ID, planned[0].NodeID, must.Sprint("expected the replacement on another node"))
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
 func TestServiceSched_NodeDrain_Down(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5190,6 +5391,249 @@ func TestBatchSched_Run_FailedAlloc(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestBatchSched_Gang_AllPlaced(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create enough nodes for the whole group
+	for i := 0; i < 2; i++ {
+		node := mock.Node()
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Create a gang job
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.TaskGroups[0].Count = 4
+	job.TaskGroups[0].Gang = true
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 2048
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	must.NoError(t, h.Process(NewBatchScheduler, eval))
+
+	// Ensure a single plan that must be applied all at once
+	must.Len(t, 1, h.Plans)
+	plan := h.Plans[0]
+	must.True(t, plan.AllAtOnce)
+
+	var planned []*structs.Allocation
+	for _, allocList := range plan.NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	must.Len(t, 4, planned)
+	must.Len(t, 0, h.CreateEvals)
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestBatchSched_Gang_PartialPlacement(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create a node with capacity for only part of the group
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create a gang job that does not fit in full
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.TaskGroups[0].Count = 10
+	job.TaskGroups[0].Gang = true
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 2048
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	must.NoError(t, h.Process(NewBatchScheduler, eval))
+
+	// Ensure no partial placement reached the planner
+	must.Len(t, 0, h.Plans)
+
+	out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	must.Len(t, 0, out)
+
+	// Ensure the eval was blocked
+	must.Len(t, 1, h.CreateEvals)
+	must.Eq(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+
+	must.Len(t, 1, h.Evals)
+	outEval := h.Evals[0]
+	must.Eq(t, h.CreateEvals[0].ID, outEval.BlockedEval)
+
+	// Ensure the whole group is reported as failed
+	metrics, ok := outEval.FailedTGAllocs[job.TaskGroups[0].Name]
+	must.True(t, ok)
+	must.Eq(t, 9, metrics.CoalescedFailures)
+	must.Eq(t, 10, outEval.QueuedAllocations["web"])
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestBatchSched_Array_MaxParallel(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create an array job that only runs 3 indices at a time
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.TaskGroups[0].Array = &structs.ArrayConfig{Start: 0, End: 9, MaxParallel: 3}
+	job.TaskGroups[0].Count = 10
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	process := func(triggeredBy string) []*structs.Allocation {
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    job.Priority,
+			TriggeredBy: triggeredBy,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		must.NoError(t, h.Process(NewBatchScheduler, eval))
+
+		var planned []*structs.Allocation
+		for _, allocList := range h.Plans[len(h.Plans)-1].NodeAllocation {
+			planned = append(planned, allocList...)
+		}
+		return planned
+	}
+
+	// Ensure only the first indices are placed
+	planned := process(structs.EvalTriggerJobRegister)
+	must.Len(t, 3, planned)
+	indices := []int{}
+	for _, alloc := range planned {
+		index, ok := alloc.ArrayIndex()
+		must.True(t, ok)
+		indices = append(indices, index)
+	}
+	must.SliceContainsAll(t, []int{0, 1, 2}, indices)
+
+	// Complete one of the allocations
+	out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	done := out[0].Copy()
+	done.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, h.State.UpdateAllocsFromClient(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{done}))
+
+	// Ensure the next index is placed
+	planned = process(structs.EvalTriggerArrayProgress)
+	must.Len(t, 1, planned)
+	index, ok := planned[0].ArrayIndex()
+	must.True(t, ok)
+	must.Eq(t, 3, index)
+
+	must.Len(t, 2, h.Evals)
+	for _, eval := range h.Evals {
+		must.Eq(t, structs.EvalStatusComplete, eval.Status)
+	}
+}
+
+func TestBatchSched_Completion(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create a job that needs 5 completions, runs 3 allocations at a time
+	// and tolerates a single failure. Rescheduling is disabled to ensure
+	// failed allocations are replaced regardless of the reschedule policy.
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.TaskGroups[0].Completion = &structs.CompletionPolicy{Completions: 5, Parallelism: 3, BackoffLimit: 1}
+	job.TaskGroups[0].Count = 5
+	job.TaskGroups[0].ReschedulePolicy = &structs.ReschedulePolicy{Attempts: 0, Unlimited: false}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	process := func(triggeredBy string) *structs.Plan {
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    job.Priority,
+			TriggeredBy: triggeredBy,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		must.NoError(t, h.Process(NewBatchScheduler, eval))
+		return h.Plans[len(h.Plans)-1]
+	}
+	planned := func(plan *structs.Plan) []*structs.Allocation {
+		var out []*structs.Allocation
+		for _, allocList := range plan.NodeAllocation {
+			out = append(out, allocList...)
+		}
+		return out
+	}
+	update := func(alloc *structs.Allocation, status string) {
+		alloc = alloc.Copy()
+		alloc.ClientStatus = status
+		must.NoError(t, h.State.UpdateAllocsFromClient(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+	}
+
+	// Ensure only Parallelism allocations are placed
+	first := planned(process(structs.EvalTriggerJobRegister))
+	must.Len(t, 3, first)
+
+	// Ensure the failed allocation is replaced
+	update(first[0], structs.AllocClientStatusFailed)
+	replaced := planned(process(structs.EvalTriggerCompletionProgress))
+	must.Len(t, 1, replaced)
+	must.Eq(t, first[0].ID, replaced[0].PreviousAllocation)
+
+	// Ensure another allocation is placed once one completes
+	update(first[1], structs.AllocClientStatusComplete)
+	next := planned(process(structs.EvalTriggerCompletionProgress))
+	must.Len(t, 1, next)
+
+	// Ensure the running allocations are stopped once the backoff limit is
+	// exhausted
+	update(first[2], structs.AllocClientStatusFailed)
+	update(replaced[0], structs.AllocClientStatusFailed)
+	plan := process(structs.EvalTriggerCompletionProgress)
+	must.SliceEmpty(t, planned(plan))
+	must.Len(t, 1, plan.NodeUpdate[node.ID])
+	must.Eq(t, next[0].ID, plan.NodeUpdate[node.ID][0].ID)
+
+	// Ensure the job is failed once it is dead
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), h.Evals))
+	update(next[0], structs.AllocClientStatusComplete)
+	out, err := h.State.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.JobStatusDead, out.Status)
+	must.StrContains(t, out.StatusDescription, "exceeding its backoff limit")
+}
+
 func TestBatchSched_Run_LostAlloc(t *testing.T) {
 	ci.Parallel(t)
 
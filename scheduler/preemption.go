@@ -4,6 +4,7 @@
 package scheduler
 
 import (
+	"maps"
 	"math"
 	"sort"
 
@@ -25,6 +26,33 @@ type allocInfo struct {
 	resources   *structs.ComparableResources
 }
 
+// disruptionBudgets tracks the number of allocations that can still be
+// preempted per job and task group, for task groups with a disruption budget.
+type disruptionBudgets map[structs.NamespacedID]map[string]int
+
+func (d disruptionBudgets) copy() disruptionBudgets {
+	c := make(disruptionBudgets, len(d))
+	for id, groups := range d {
+		c[id] = maps.Clone(groups)
+	}
+	return c
+}
+
+// take consumes one disruption from the budget of the allocation's task group
+// and returns false if the budget is already exhausted. Allocations whose task
+// group has no disruption budget can always be taken.
+func (d disruptionBudgets) take(alloc *structs.Allocation) bool {
+	left, ok := d[structs.NewNamespacedID(alloc.JobID, alloc.Namespace)][alloc.TaskGroup]
+	if !ok {
+		return true
+	}
+	if left <= 0 {
+		return false
+	}
+	d[structs.NewNamespacedID(alloc.JobID, alloc.Namespace)][alloc.TaskGroup] = left - 1
+	return true
+}
+
 // PreemptionResource interface is implemented by different
 // types of resources.
 type PreemptionResource interface {
@@ -102,6 +130,11 @@ type Preemptor struct {
 	// it tracks the number of preempted allocations per job/taskgroup
 	currentPreemptions map[structs.NamespacedID]map[string]int
 
+	// disruptionBudgets is a map computed when SetCandidates is called
+	// it tracks the number of allocations of each job/taskgroup with a
+	// disruption budget that can still be preempted
+	disruptionBudgets disruptionBudgets
+
 	// allocDetails is a map computed when SetCandidates is called
 	// it stores some precomputed details about the allocation needed
 	// when scoring it for preemption
@@ -129,6 +162,7 @@ func NewPreemptor(jobPriority int, ctx Context, jobID *structs.NamespacedID) *Pr
 		currentPreemptions: make(map[structs.NamespacedID]map[string]int),
 		jobPriority:        jobPriority,
 		jobID:              jobID,
+		disruptionBudgets:  make(disruptionBudgets),
 		allocDetails:       make(map[string]*allocInfo),
 		ctx:                ctx,
 	}
@@ -161,17 +195,65 @@ func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 		if tg != nil && tg.Migrate != nil {
 			maxParallel = tg.Migrate.MaxParallel
 		}
+
+		// Ignore allocations whose disruption budget is already exhausted
+		if tg != nil && tg.DisruptionBudget != nil && p.allowedDisruptions(alloc, tg) <= 0 {
+			p.ctx.Metrics().ExhaustDisruptionBudget(alloc)
+			continue
+		}
 		p.allocDetails[alloc.ID] = &allocInfo{maxParallel: maxParallel, resources: alloc.AllocatedResources.Comparable()}
 		p.currentAllocs = append(p.currentAllocs, alloc)
 	}
 }
 
+// allowedDisruptions returns the number of allocations of the alloc's job and
+// task group that can still be preempted without exceeding the task group's
+// disruption budget, accounting for the allocations already preempted by the
+// plan. The result is cached for the candidate set.
+func (p *Preemptor) allowedDisruptions(alloc *structs.Allocation, tg *structs.TaskGroup) int {
+	id := structs.NewNamespacedID(alloc.JobID, alloc.Namespace)
+	if allowed, ok := p.disruptionBudgets[id][tg.Name]; ok {
+		return allowed
+	}
+
+	allocs, err := p.ctx.State().AllocsByJob(nil, alloc.Namespace, alloc.JobID, false)
+	if err != nil {
+		p.ctx.Logger().Error("failed to lookup allocations for disruption budget",
+			"job_id", alloc.JobID, "namespace", alloc.Namespace, "error", err)
+		return 0
+	}
+
+	available := 0
+	for _, a := range allocs {
+		if a.TaskGroup == tg.Name && a.DisruptionAvailable() {
+			available++
+		}
+	}
+	allowed := tg.DisruptionBudget.AllowedDisruptions(tg.Count, available) - p.getNumPreemptions(alloc)
+
+	if p.disruptionBudgets[id] == nil {
+		p.disruptionBudgets[id] = make(map[string]int)
+	}
+	p.disruptionBudgets[id][tg.Name] = allowed
+	return allowed
+}
+
+// consumeDisruptions updates the disruption budgets with the allocations
+// chosen for preemption, so that following preemptions on the same node take
+// them into account.
+func (p *Preemptor) consumeDisruptions(allocs []*structs.Allocation) {
+	for _, alloc := range allocs {
+		p.disruptionBudgets.take(alloc)
+	}
+}
+
 // SetPreemptions initializes a map tracking existing counts of preempted allocations
 // per job/task group. This is used while scoring preemption options
 func (p *Preemptor) SetPreemptions(allocs []*structs.Allocation) {
 
 	// Clear out existing values since this can be called more than once
 	p.currentPreemptions = make(map[structs.NamespacedID]map[string]int)
+	p.disruptionBudgets = make(disruptionBudgets)
 
 	// Initialize counts
 	for _, alloc := range allocs {
@@ -216,6 +298,9 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 	// Initialize variable to track resources as they become available from preemption
 	availableResources := p.nodeRemainingResources.Copy()
 
+	// Track the disruption budgets consumed by the allocations chosen
+	budgets := p.disruptionBudgets.copy()
+
 	resourcesAsked := resourceAsk.Comparable()
 	// Iterate over allocations grouped by priority to find preemptible allocations
 	for _, allocGrp := range allocsByPriority {
@@ -234,6 +319,15 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 				}
 			}
 			closestAlloc := allocGrp.allocs[closestAllocIndex]
+			allocGrp.allocs[closestAllocIndex] = allocGrp.allocs[len(allocGrp.allocs)-1]
+			allocGrp.allocs = allocGrp.allocs[:len(allocGrp.allocs)-1]
+
+			// Skip the alloc if previous choices exhausted its disruption budget
+			if !budgets.take(closestAlloc) {
+				p.ctx.Metrics().ExhaustDisruptionBudget(closestAlloc)
+				continue
+			}
+
 			closestResources := p.allocDetails[closestAlloc.ID].resources
 			availableResources.Add(closestResources)
 
@@ -242,9 +336,6 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 
 			bestAllocs = append(bestAllocs, closestAlloc)
 
-			allocGrp.allocs[closestAllocIndex] = allocGrp.allocs[len(allocGrp.allocs)-1]
-			allocGrp.allocs = allocGrp.allocs[:len(allocGrp.allocs)-1]
-
 			// This is the remaining total of resources needed
 			resourcesNeeded.Subtract(closestResources)
 		}
@@ -263,6 +354,7 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 	basePreemptionResource := GetBasePreemptionResourceFactory()
 	resourcesNeeded = resourceAsk.Comparable()
 	filteredBestAllocs := p.filterSuperset(bestAllocs, p.nodeRemainingResources, resourcesNeeded, basePreemptionResource)
+	p.consumeDisruptions(filteredBestAllocs)
 	return filteredBestAllocs
 
 }
@@ -358,6 +450,7 @@ OUTER:
 
 		// Reset allocsToPreempt since we don't want to preempt across devices for the same task
 		allocsToPreempt = nil
+		budgets := p.disruptionBudgets.copy()
 
 		// usedPortToAlloc tracks used ports by allocs in this device
 		usedPortToAlloc := make(map[int]*structs.Allocation)
@@ -379,6 +472,12 @@ OUTER:
 			for _, port := range reservedPortsNeeded {
 				alloc, ok := usedPortToAlloc[port.Value]
 				if ok {
+					// The port can't be freed if the alloc using it can't
+					// be disrupted so we skip to the next device
+					if !budgets.take(alloc) {
+						p.ctx.Metrics().ExhaustDisruptionBudget(alloc)
+						continue OUTER
+					}
 					allocResources := p.allocDetails[alloc.ID].resources
 					preemptedBandwidth += allocResources.Flattened.Networks[0].MBits
 					allocsToPreempt = append(allocsToPreempt, alloc)
@@ -415,6 +514,10 @@ OUTER:
 
 			// Iterate over allocs until end of if requirements have been met
 			for _, alloc := range allocs {
+				if !budgets.take(alloc) {
+					p.ctx.Metrics().ExhaustDisruptionBudget(alloc)
+					continue
+				}
 				allocResources := p.allocDetails[alloc.ID].resources
 				preemptedBandwidth += allocResources.Flattened.Networks[0].MBits
 				allocsToPreempt = append(allocsToPreempt, alloc)
@@ -453,6 +556,7 @@ OUTER:
 		},
 	}
 	filteredBestAllocs := p.filterSuperset(allocsToPreempt, nodeRemainingResources, resourcesNeeded, preemptionResourceFactory)
+	p.consumeDisruptions(filteredBestAllocs)
 	return filteredBestAllocs
 }
 
@@ -522,14 +626,20 @@ OUTER:
 		// First group and sort allocations using this device by priority
 		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, allocsGrp.allocs)
 
-		// Reset preempted count for this device
+		// Reset preempted count and disruption budgets for this device
 		preemptedCount := 0
+		budgets := p.disruptionBudgets.copy()
 
 		// Initialize slice of preempted allocations
 		var preemptedAllocs []*structs.Allocation
 
 		for _, grpAllocs := range allocsByPriority {
 			for _, alloc := range grpAllocs.allocs {
+				if !budgets.take(alloc) {
+					p.ctx.Metrics().ExhaustDisruptionBudget(alloc)
+					continue
+				}
+
 				// Look up the device instance from the device allocator
 				devInst := devAlloc.Devices[deviceIDTuple]
 
@@ -551,7 +661,9 @@ OUTER:
 
 	// Find the combination of allocs with lowest net priority
 	if len(preemptionOptions) > 0 {
-		return selectBestAllocs(preemptionOptions, int(neededCount))
+		bestAllocs := selectBestAllocs(preemptionOptions, int(neededCount))
+		p.consumeDisruptions(bestAllocs)
+		return bestAllocs
 	}
 
 	return nil
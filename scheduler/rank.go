@@ -193,8 +193,11 @@ func (iter *BinPackIterator) SetSchedulerConfiguration(schedConfig *structs.Sche
 	// Set scoring function.
 	algorithm := schedConfig.EffectiveSchedulerAlgorithm()
 	scoreFn := structs.ScoreFitBinPack
-	if algorithm == structs.SchedulerAlgorithmSpread {
+	switch algorithm {
+	case structs.SchedulerAlgorithmSpread:
 		scoreFn = structs.ScoreFitSpread
+	case structs.SchedulerAlgorithmBalanced:
+		scoreFn = structs.ScoreFitBalanced
 	}
 	iter.scoreFit = scoreFn
 
@@ -372,6 +375,7 @@ OUTER:
 				Memory: structs.AllocatedMemoryResources{
 					MemoryMB: int64(task.Resources.MemoryMB),
 				},
+				IO: task.Resources.IO.Copy(),
 			}
 			if iter.memoryOversubscription {
 				taskResources.Memory.MemoryMaxMB = int64(task.Resources.MemoryMaxMB)
@@ -590,6 +594,55 @@ func (iter *BinPackIterator) Reset() {
 	iter.source.Reset()
 }
 
+// NodeLoadIterator is used to penalize nodes according to the actual CPU and
+// memory utilization reported by their client. Nodes whose highest utilization
+// is above the configured threshold are penalized proportionally to how far
+// above the threshold it is, so that among nodes with similar reserved
+// resources the least loaded ones are preferred.
+type NodeLoadIterator struct {
+	ctx       Context
+	source    RankIterator
+	enabled   bool
+	threshold float64
+}
+
+// NewNodeLoadIterator is used to create a NodeLoadIterator that applies a
+// scoring penalty to nodes with high utilization. It is disabled until
+// enabled by SetSchedulerConfiguration.
+func NewNodeLoadIterator(ctx Context, source RankIterator) *NodeLoadIterator {
+	return &NodeLoadIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *NodeLoadIterator) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
+	iter.enabled = schedConfig != nil && schedConfig.LoadAwareConfig.Enabled
+	if iter.enabled {
+		iter.threshold = float64(schedConfig.LoadAwareConfig.UtilizationThreshold)
+	}
+}
+
+func (iter *NodeLoadIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil || !iter.enabled || option.Node.Utilization == nil {
+		return option
+	}
+
+	utilization := max(option.Node.Utilization.CPUPercent, option.Node.Utilization.MemoryPercent)
+	utilization = min(utilization, 100.0)
+	if utilization > iter.threshold {
+		score := -(utilization - iter.threshold) / (100.0 - iter.threshold)
+		option.Scores = append(option.Scores, score)
+		iter.ctx.Metrics().ScoreNode(option.Node, "node-load", score)
+	}
+	return option
+}
+
+func (iter *NodeLoadIterator) Reset() {
+	iter.source.Reset()
+}
+
 // JobAntiAffinityIterator is used to apply an anti-affinity to allocating
 // along side other allocations from this job. This is used to help distribute
 // load across the cluster.
@@ -705,6 +758,46 @@ func (iter *NodeReschedulingPenaltyIterator) Reset() {
 	iter.source.Reset()
 }
 
+// NodeTaintPenaltyIterator is used to apply a penalty to nodes with
+// prefer_no_schedule taints that the task group does not tolerate.
+type NodeTaintPenaltyIterator struct {
+	ctx         Context
+	source      RankIterator
+	tolerations []*structs.Toleration
+}
+
+// NewNodeTaintPenaltyIterator is used to create a NodeTaintPenaltyIterator
+// that applies a scoring penalty to nodes with untolerated prefer_no_schedule
+// taints.
+func NewNodeTaintPenaltyIterator(ctx Context, source RankIterator) *NodeTaintPenaltyIterator {
+	return &NodeTaintPenaltyIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *NodeTaintPenaltyIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tolerations = tg.Tolerations
+}
+
+func (iter *NodeTaintPenaltyIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil || len(option.Node.Taints) == 0 {
+		return option
+	}
+
+	untolerated := option.Node.UntoleratedTaints(iter.tolerations, structs.NodeTaintEffectPreferNoSchedule)
+	if len(untolerated) > 0 {
+		option.Scores = append(option.Scores, -1)
+		iter.ctx.Metrics().ScoreNode(option.Node, "node-taint", -1)
+	}
+	return option
+}
+
+func (iter *NodeTaintPenaltyIterator) Reset() {
+	iter.source.Reset()
+}
+
 // NodeAffinityIterator is used to resolve any affinity rules in the job or task group,
 // and apply a weighted score to nodes if they match.
 type NodeAffinityIterator struct {
@@ -794,6 +887,88 @@ func matchesAffinity(ctx Context, affinity *structs.Affinity, option *structs.No
 	return checkAffinity(ctx, affinity.Operand, lVal, rVal, lOk, rOk)
 }
 
+// AllocationAffinityScoringIterator is used to apply a weighted score to nodes
+// according to whether they run allocations matching the preferred
+// allocation_affinity blocks of a task group. Nodes running matching
+// allocations are favored by affinities and penalized by anti-affinities.
+type AllocationAffinityScoringIterator struct {
+	ctx       Context
+	source    RankIterator
+	matcher   *allocationAffinityMatcher
+	preferred []*structs.AllocationAffinity
+}
+
+// NewAllocationAffinityScoringIterator is used to create an
+// AllocationAffinityScoringIterator that scores nodes according to the
+// allocations they run.
+func NewAllocationAffinityScoringIterator(ctx Context, source RankIterator) *AllocationAffinityScoringIterator {
+	return &AllocationAffinityScoringIterator{
+		ctx:     ctx,
+		source:  source,
+		matcher: newAllocationAffinityMatcher(ctx),
+	}
+}
+
+func (iter *AllocationAffinityScoringIterator) SetJob(job *structs.Job) {
+	iter.matcher.SetJob(job)
+}
+
+func (iter *AllocationAffinityScoringIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.preferred = iter.preferred[:0]
+	for _, aa := range tg.AllocationAffinities {
+		if !aa.Required {
+			iter.preferred = append(iter.preferred, aa)
+		}
+	}
+}
+
+func (iter *AllocationAffinityScoringIterator) hasAllocationAffinities() bool {
+	return len(iter.preferred) > 0
+}
+
+func (iter *AllocationAffinityScoringIterator) Next() *RankedNode {
+	for {
+		option := iter.source.Next()
+		if option == nil || !iter.hasAllocationAffinities() {
+			return option
+		}
+
+		sumWeight := 0.0
+		totalScore := 0.0
+		failed := false
+		for _, aa := range iter.preferred {
+			sumWeight += float64(aa.Weight)
+
+			matched, err := iter.matcher.matchesNode(aa, option.Node.ID)
+			if err != nil {
+				iter.ctx.Logger().Named("allocation_affinity").Error("failed to match allocations", "error", err)
+				failed = true
+				break
+			}
+			switch {
+			case matched && aa.Anti:
+				totalScore -= float64(aa.Weight)
+			case matched:
+				totalScore += float64(aa.Weight)
+			}
+		}
+		if failed {
+			continue
+		}
+
+		if totalScore != 0.0 {
+			normScore := totalScore / sumWeight
+			option.Scores = append(option.Scores, normScore)
+			iter.ctx.Metrics().ScoreNode(option.Node, "allocation-affinity", normScore)
+		}
+		return option
+	}
+}
+
+func (iter *AllocationAffinityScoringIterator) Reset() {
+	iter.source.Reset()
+}
+
 // ScoreNormalizationIterator is used to combine scores from various prior
 // iterators and combine them into one final score. The current implementation
 // averages the scores together.
@@ -2075,6 +2075,66 @@ func TestBinPackIterator_Device_Failure_With_Eviction(t *testing.T) {
 	require.Equal(1, ctx.metrics.DimensionExhausted["devices: no devices match request"])
 }
 
+func TestNodeLoadIterator(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	// Node 0 has not reported its utilization
+	nodes[1].Node.Utilization = &structs.NodeUtilization{CPUPercent: 20, MemoryPercent: 40}
+	nodes[2].Node.Utilization = &structs.NodeUtilization{CPUPercent: 90, MemoryPercent: 10}
+	nodes[3].Node.Utilization = &structs.NodeUtilization{CPUPercent: 60, MemoryPercent: 70}
+
+	cases := []struct {
+		name           string
+		loadAware      structs.LoadAwareConfig
+		expectedScores []float64
+	}{
+		{
+			name:           "disabled",
+			loadAware:      structs.LoadAwareConfig{Enabled: false},
+			expectedScores: []float64{0, 0, 0, 0},
+		},
+		{
+			name:           "no threshold",
+			loadAware:      structs.LoadAwareConfig{Enabled: true},
+			expectedScores: []float64{0, -0.4, -0.9, -0.7},
+		},
+		{
+			name:           "threshold",
+			loadAware:      structs.LoadAwareConfig{Enabled: true, UtilizationThreshold: 50},
+			expectedScores: []float64{0, 0, -0.8, -0.4},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, n := range nodes {
+				n.Scores = nil
+				n.FinalScore = 0
+			}
+			static := NewStaticRankIterator(ctx, nodes)
+
+			nodeLoad := NewNodeLoadIterator(ctx, static)
+			nodeLoad.SetSchedulerConfiguration(&structs.SchedulerConfiguration{
+				LoadAwareConfig: tc.loadAware,
+			})
+
+			scoreNorm := NewScoreNormalizationIterator(ctx, nodeLoad)
+
+			out := collectRanked(scoreNorm)
+			require.Len(t, out, len(nodes))
+			for i, n := range out {
+				require.InDelta(t, tc.expectedScores[i], n.FinalScore, 0.0001)
+			}
+		})
+	}
+}
+
 func TestJobAntiAffinity_PlannedAlloc(t *testing.T) {
 	_, ctx := testContext(t)
 	nodes := []*RankedNode{
@@ -2256,6 +2316,49 @@ func TestScoreNormalizationIterator(t *testing.T) {
 	require.Equal(out[1].FinalScore, 0.0)
 }
 
+func TestNodeTaintPenaltyIterator(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	nodes[1].Node.Taints = []*structs.NodeTaint{
+		{Key: "spot", Effect: structs.NodeTaintEffectPreferNoSchedule},
+	}
+	nodes[2].Node.Taints = []*structs.NodeTaint{
+		{Key: "maintenance", Effect: structs.NodeTaintEffectPreferNoSchedule},
+	}
+	static := NewStaticRankIterator(ctx, nodes)
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Tolerations = []*structs.Toleration{
+		{Key: "spot", Operator: structs.TolerationOperatorExists},
+	}
+
+	taintPenalty := NewNodeTaintPenaltyIterator(ctx, static)
+	taintPenalty.SetTaskGroup(tg)
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, taintPenalty)
+
+	out := collectRanked(scoreNorm)
+
+	// Only node 2 has an untolerated taint
+	expectedScores := map[string]float64{
+		nodes[0].Node.ID: 0,
+		nodes[1].Node.ID: 0,
+		nodes[2].Node.ID: -1,
+	}
+
+	require := require.New(t)
+	require.Len(out, 3)
+	for _, n := range out {
+		require.Equal(expectedScores[n.Node.ID], n.FinalScore)
+	}
+}
+
 func TestNodeAffinityIterator(t *testing.T) {
 	_, ctx := testContext(t)
 	nodes := []*RankedNode{
@@ -2330,3 +2433,61 @@ func TestNodeAffinityIterator(t *testing.T) {
 	}
 
 }
+
+func TestAllocationAffinityScoringIterator(t *testing.T) {
+	store, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	// Run an alloc of the "api" job on node0 and node1, and an alloc of the
+	// "db-replica" job on node1
+	allocs := make([]*structs.Allocation, 0, 3)
+	for _, placement := range []struct {
+		jobID string
+		node  *structs.Node
+	}{
+		{"api", nodes[0].Node},
+		{"api", nodes[1].Node},
+		{"db-replica", nodes[1].Node},
+	} {
+		alloc := mock.Alloc()
+		alloc.Job.ID = placement.jobID
+		alloc.JobID = placement.jobID
+		alloc.NodeID = placement.node.ID
+		allocs = append(allocs, alloc)
+	}
+	require := require.New(t)
+	require.NoError(store.UpsertAllocs(structs.MsgTypeTestSetup, 1000, allocs))
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.AllocationAffinities = []*structs.AllocationAffinity{
+		{JobID: "api", Weight: 100},
+		{JobID: "db-replica", Anti: true, Weight: 50},
+	}
+
+	static := NewStaticRankIterator(ctx, nodes)
+	allocAffinity := NewAllocationAffinityScoringIterator(ctx, static)
+	allocAffinity.SetJob(job)
+	allocAffinity.SetTaskGroup(tg)
+	scoreNorm := NewScoreNormalizationIterator(ctx, allocAffinity)
+
+	out := collectRanked(scoreNorm)
+	require.Len(out, 3)
+
+	// Total weight = 150
+	expectedScores := map[string]float64{
+		// Node 0 matches the affinity
+		nodes[0].Node.ID: 100.0 / 150.0,
+		// Node 1 matches the affinity and the anti-affinity
+		nodes[1].Node.ID: 50.0 / 150.0,
+		// Node 2 matches neither
+		nodes[2].Node.ID: 0,
+	}
+	for _, n := range out {
+		require.Equal(expectedScores[n.Node.ID], n.FinalScore)
+	}
+}
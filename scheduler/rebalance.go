@@ -0,0 +1,370 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// RebalanceReasonNodeUtilization is used when an allocation runs on a
+	// node whose utilization is above the configured threshold.
+	RebalanceReasonNodeUtilization = "node-utilization"
+
+	// RebalanceReasonSpread is used when an allocation runs on a node with
+	// more allocations of its task group than its spread targets allow.
+	RebalanceReasonSpread = "spread"
+
+	// RebalanceReasonAffinity is used when an allocation runs on a node that
+	// scores lower on its affinities than other feasible nodes.
+	RebalanceReasonAffinity = "affinity"
+)
+
+// RebalanceCandidate is an allocation the rebalancer should migrate.
+type RebalanceCandidate struct {
+	Alloc  *structs.Allocation
+	Reason string
+}
+
+// Rebalancer finds the allocations of service jobs that would be better
+// placed elsewhere: allocations that violate the spread or affinity goals of
+// their task group, or that run on over-utilized nodes. It only reports the
+// allocations, marking them for migration is left to the caller.
+type Rebalancer struct {
+	ctx    Context
+	state  State
+	config *structs.RebalanceConfig
+}
+
+// NewRebalancer returns a Rebalancer that finds candidates in the given state
+// according to the rebalance configuration.
+func NewRebalancer(logger log.Logger, state State, config *structs.RebalanceConfig) *Rebalancer {
+	// The plan is never submitted, it only seeds the node shuffling of the
+	// stacks and holds no proposed allocations.
+	plan := &structs.Plan{
+		EvalID:          uuid.Generate(),
+		NodeUpdate:      make(map[string][]*structs.Allocation),
+		NodeAllocation:  make(map[string][]*structs.Allocation),
+		NodePreemptions: make(map[string][]*structs.Allocation),
+	}
+	return &Rebalancer{
+		ctx:    NewEvalContext(nil, state, plan, logger),
+		state:  state,
+		config: config,
+	}
+}
+
+// JobCandidates returns the allocations of the job that should be migrated.
+// The number of candidates per task group never exceeds what its disruption
+// budget and migrate strategy allow. Jobs that are not service jobs, are
+// stopped, have an active deployment or whose namespace is not opted in have
+// no candidates.
+func (r *Rebalancer) JobCandidates(job *structs.Job) ([]*RebalanceCandidate, error) {
+	if job.Type != structs.JobTypeService || job.Stopped() || !r.config.NamespaceEnabled(job.Namespace) {
+		return nil, nil
+	}
+
+	// Don't interfere with deployments in progress
+	deployment, err := r.state.LatestDeploymentByJobID(nil, job.Namespace, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup latest deployment: %v", err)
+	}
+	if deployment != nil && deployment.Active() {
+		return nil, nil
+	}
+
+	allocs, err := r.state.AllocsByJob(nil, job.Namespace, job.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup allocations: %v", err)
+	}
+	if len(allocs) == 0 {
+		return nil, nil
+	}
+
+	nodes, _, _, err := readyNodesInDCsAndPool(r.state, job.Datacenters, job.NodePool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup nodes: %v", err)
+	}
+	readyNodes := make(map[string]*structs.Node, len(nodes))
+	for _, node := range nodes {
+		readyNodes[node.ID] = node
+	}
+
+	// Use the stack of the scheduler to find the nodes the task groups could
+	// be placed on, so that allocations are only moved to nodes the
+	// scheduler would place them on.
+	pool, err := r.state.NodePoolByName(nil, job.NodePool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job node pool %q: %v", job.NodePool, err)
+	}
+	_, schedConfig, err := r.state.SchedulerConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler configuration: %v", err)
+	}
+	stack := NewGenericStack(false, r.ctx)
+	stack.SetJob(job)
+	stack.SetSchedulerConfiguration(schedConfig.WithNodePool(pool))
+	stack.SetNodes(slices.Clone(nodes))
+
+	groupAllocs := make(map[string][]*structs.Allocation)
+	for _, alloc := range allocs {
+		groupAllocs[alloc.TaskGroup] = append(groupAllocs[alloc.TaskGroup], alloc)
+	}
+
+	var candidates []*RebalanceCandidate
+	for _, tg := range job.TaskGroups {
+		allowed := allowedMigrations(tg, groupAllocs[tg.Name])
+		if allowed <= 0 {
+			continue
+		}
+
+		found := r.groupCandidates(job, tg, groupAllocs[tg.Name], stack, readyNodes)
+		candidates = append(candidates, found[:min(allowed, len(found))]...)
+	}
+	return candidates, nil
+}
+
+// allowedMigrations returns how many allocations of the task group can be
+// migrated, according to its migrate strategy and disruption budget.
+func allowedMigrations(tg *structs.TaskGroup, allocs []*structs.Allocation) int {
+	maxParallel := 1
+	if tg.Migrate != nil && tg.Migrate.MaxParallel > 0 {
+		maxParallel = tg.Migrate.MaxParallel
+	}
+
+	migrating, available := 0, 0
+	for _, alloc := range allocs {
+		if alloc.DisruptionAvailable() {
+			available++
+		} else if !alloc.TerminalStatus() && alloc.DesiredTransition.ShouldMigrate() {
+			migrating++
+		}
+	}
+
+	allowed := maxParallel - migrating
+	if tg.DisruptionBudget != nil {
+		allowed = min(allowed, tg.DisruptionBudget.AllowedDisruptions(tg.Count, available))
+	}
+	return allowed
+}
+
+// groupCandidates returns the allocations of the task group that should be
+// migrated, ordered by reason.
+func (r *Rebalancer) groupCandidates(job *structs.Job, tg *structs.TaskGroup,
+	allocs []*structs.Allocation, stack *GenericStack, readyNodes map[string]*structs.Node) []*RebalanceCandidate {
+
+	// Only consider available allocations of the current job version that
+	// run on ready nodes. Newer allocations are migrated first.
+	var eligible []*structs.Allocation
+	for _, alloc := range allocs {
+		if _, ok := readyNodes[alloc.NodeID]; !ok {
+			continue
+		}
+		if alloc.Job == nil || alloc.Job.Version != job.Version || !alloc.DisruptionAvailable() {
+			continue
+		}
+		eligible = append(eligible, alloc)
+	}
+	slices.SortFunc(eligible, func(a, b *structs.Allocation) int {
+		return cmp.Or(cmp.Compare(b.CreateIndex, a.CreateIndex), cmp.Compare(a.ID, b.ID))
+	})
+	if len(eligible) == 0 {
+		return nil
+	}
+
+	feasible := stack.FeasibleNodes(tg)
+	if len(feasible) == 0 {
+		return nil
+	}
+
+	var candidates []*RebalanceCandidate
+	seen := make(map[string]struct{})
+	add := func(allocs []*structs.Allocation, reason string) {
+		for _, alloc := range allocs {
+			if _, ok := seen[alloc.ID]; ok {
+				continue
+			}
+			seen[alloc.ID] = struct{}{}
+			candidates = append(candidates, &RebalanceCandidate{Alloc: alloc, Reason: reason})
+		}
+	}
+
+	add(r.overUtilized(eligible, feasible, readyNodes), RebalanceReasonNodeUtilization)
+
+	spreads := append(slices.Clone(tg.Spreads), job.Spreads...)
+	for _, spread := range spreads {
+		add(spreadViolations(spread, tg.Count, eligible, feasible, readyNodes), RebalanceReasonSpread)
+	}
+
+	affinities := append(slices.Clone(job.Affinities), tg.Affinities...)
+	add(r.affinityViolations(affinities, eligible, feasible, readyNodes), RebalanceReasonAffinity)
+
+	return candidates
+}
+
+// overUtilized returns the allocations running on nodes whose utilization is
+// above the configured threshold, as long as there is a feasible node below
+// it to move them to.
+func (r *Rebalancer) overUtilized(allocs []*structs.Allocation, feasible []*structs.Node,
+	readyNodes map[string]*structs.Node) []*structs.Allocation {
+
+	threshold := float64(r.config.UtilizationThreshold)
+	if threshold <= 0 {
+		return nil
+	}
+
+	isOverUtilized := func(node *structs.Node) bool {
+		return node.Utilization != nil &&
+			max(node.Utilization.CPUPercent, node.Utilization.MemoryPercent) > threshold
+	}
+	if !slices.ContainsFunc(feasible, func(node *structs.Node) bool { return !isOverUtilized(node) }) {
+		return nil
+	}
+
+	var out []*structs.Allocation
+	for _, alloc := range allocs {
+		if isOverUtilized(readyNodes[alloc.NodeID]) {
+			out = append(out, alloc)
+		}
+	}
+	return out
+}
+
+// spreadViolations returns the allocations in excess of the desired count of
+// the spread attribute value of their node. Without spread targets the
+// allocations are expected to be evenly spread across the values of the
+// feasible nodes and of the nodes they run on. Allocations are only reported
+// if a feasible node has a value below its desired count to move them to.
+func spreadViolations(spread *structs.Spread, count int, allocs []*structs.Allocation,
+	feasible []*structs.Node, readyNodes map[string]*structs.Node) []*structs.Allocation {
+
+	// Group the allocations by the attribute value of their node, allocations
+	// on nodes without the attribute are ignored.
+	byValue := make(map[string][]*structs.Allocation)
+	for _, alloc := range allocs {
+		value, ok := resolveTarget(spread.Attribute, readyNodes[alloc.NodeID])
+		if !ok {
+			continue
+		}
+		byValue[value] = append(byValue[value], alloc)
+	}
+
+	feasibleValues := make(map[string]struct{})
+	for _, node := range feasible {
+		if value, ok := resolveTarget(spread.Attribute, node); ok {
+			feasibleValues[value] = struct{}{}
+		}
+	}
+	if len(feasibleValues) == 0 {
+		return nil
+	}
+
+	var out []*structs.Allocation
+	excess := func(allocs []*structs.Allocation, desired float64) {
+		n := len(allocs) - int(math.Ceil(desired))
+		if n > 0 {
+			out = append(out, allocs[:n]...)
+		}
+	}
+
+	if len(spread.SpreadTarget) == 0 {
+		values := maps.Clone(feasibleValues)
+		for value := range byValue {
+			values[value] = struct{}{}
+		}
+		desired := float64(count) / float64(len(values))
+		for _, value := range sortedKeys(byValue) {
+			excess(byValue[value], desired)
+		}
+		if !slices.ContainsFunc(sortedKeys(feasibleValues), func(value string) bool {
+			return float64(len(byValue[value])) < desired
+		}) {
+			return nil
+		}
+		return out
+	}
+
+	// Allocations on values without an explicit target share the remaining
+	// percentage.
+	sumDesired := 0.0
+	targets := make(map[string]float64, len(spread.SpreadTarget))
+	for _, st := range spread.SpreadTarget {
+		desired := float64(st.Percent) / 100 * float64(count)
+		targets[st.Value] = desired
+		sumDesired += desired
+	}
+	implicitDesired := max(float64(count)-sumDesired, 0)
+
+	var implicit []*structs.Allocation
+	for _, value := range sortedKeys(byValue) {
+		if desired, ok := targets[value]; ok {
+			excess(byValue[value], desired)
+		} else {
+			implicit = append(implicit, byValue[value]...)
+		}
+	}
+	excess(implicit, implicitDesired)
+
+	if !slices.ContainsFunc(sortedKeys(feasibleValues), func(value string) bool {
+		if desired, ok := targets[value]; ok {
+			return float64(len(byValue[value])) < desired
+		}
+		return float64(len(implicit)) < implicitDesired
+	}) {
+		return nil
+	}
+	return out
+}
+
+// affinityViolations returns the allocations running on nodes whose affinity
+// score has a lower sign than the best feasible node. Only comparing the sign
+// of the scores avoids moving allocations back and forth between nodes that
+// satisfy the affinities to a different degree.
+func (r *Rebalancer) affinityViolations(affinities []*structs.Affinity, allocs []*structs.Allocation,
+	feasible []*structs.Node, readyNodes map[string]*structs.Node) []*structs.Allocation {
+
+	if len(affinities) == 0 {
+		return nil
+	}
+
+	score := func(node *structs.Node) int {
+		total := 0
+		for _, affinity := range affinities {
+			if matchesAffinity(r.ctx, affinity, node) {
+				total += int(affinity.Weight)
+			}
+		}
+		return cmp.Compare(total, 0)
+	}
+
+	best := math.MinInt
+	for _, node := range feasible {
+		best = max(best, score(node))
+	}
+
+	var out []*structs.Allocation
+	for _, alloc := range allocs {
+		if score(readyNodes[alloc.NodeID]) < best {
+			out = append(out, alloc)
+		}
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,291 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// rebalanceTestState upserts the nodes, the job and count running allocations
+// of its task group for each node in placements.
+func rebalanceTestState(t *testing.T, nodes []*structs.Node, job *structs.Job,
+	placements map[*structs.Node]int) (*state.StateStore, []*structs.Allocation) {
+
+	store := state.TestStateStore(t)
+	for i, node := range nodes {
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(1000+i), node))
+	}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1100, nil, job))
+
+	var allocs []*structs.Allocation
+	for _, node := range nodes {
+		for i := 0; i < placements[node]; i++ {
+			alloc := mock.Alloc()
+			alloc.Job = job
+			alloc.JobID = job.ID
+			alloc.TaskGroup = job.TaskGroups[0].Name
+			alloc.NodeID = node.ID
+			alloc.ClientStatus = structs.AllocClientStatusRunning
+			allocs = append(allocs, alloc)
+		}
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1200, allocs))
+	return store, allocs
+}
+
+func rebalanceTestJob(count int) *structs.Job {
+	job := mock.Job()
+	job.TaskGroups[0].Count = count
+	job.TaskGroups[0].Migrate = &structs.MigrateStrategy{MaxParallel: count}
+	return job
+}
+
+func TestRebalancer_NamespaceOptIn(t *testing.T) {
+	ci.Parallel(t)
+
+	node1, node2 := mock.Node(), mock.Node()
+	node1.Utilization = &structs.NodeUtilization{CPUPercent: 95}
+	job := rebalanceTestJob(2)
+	store, _ := rebalanceTestState(t, []*structs.Node{node1, node2}, job,
+		map[*structs.Node]int{node1: 2})
+
+	config := &structs.RebalanceConfig{
+		Enabled:              true,
+		Namespaces:           []string{"other"},
+		UtilizationThreshold: 80,
+	}
+	candidates, err := NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+	must.NoError(t, err)
+	must.SliceEmpty(t, candidates)
+
+	config.Namespaces = []string{"*"}
+	candidates, err = NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+	must.NoError(t, err)
+	must.Len(t, 2, candidates)
+}
+
+func TestRebalancer_NodeUtilization(t *testing.T) {
+	ci.Parallel(t)
+
+	node1, node2 := mock.Node(), mock.Node()
+	node1.Utilization = &structs.NodeUtilization{CPUPercent: 40, MemoryPercent: 95}
+	node2.Utilization = &structs.NodeUtilization{CPUPercent: 30, MemoryPercent: 20}
+	job := rebalanceTestJob(3)
+	store, _ := rebalanceTestState(t, []*structs.Node{node1, node2}, job,
+		map[*structs.Node]int{node1: 2, node2: 1})
+
+	config := &structs.RebalanceConfig{Enabled: true, Namespaces: []string{"default"}, UtilizationThreshold: 80}
+	candidates, err := NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+	must.NoError(t, err)
+	must.Len(t, 2, candidates)
+	for _, c := range candidates {
+		must.Eq(t, node1.ID, c.Alloc.NodeID)
+		must.Eq(t, RebalanceReasonNodeUtilization, c.Reason)
+	}
+
+	// Allocations are not migrated if every node is over-utilized
+	node2 = node2.Copy()
+	node2.Utilization.CPUPercent = 85
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1300, node2))
+	candidates, err = NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+	must.NoError(t, err)
+	must.SliceEmpty(t, candidates)
+}
+
+// TestRebalancer_Feasibility asserts allocations are only migrated to nodes
+// the scheduler would place them on.
+func TestRebalancer_Feasibility(t *testing.T) {
+	ci.Parallel(t)
+
+	node1, node2 := mock.Node(), mock.Node()
+	node1.Utilization = &structs.NodeUtilization{CPUPercent: 95}
+	node2.Utilization = &structs.NodeUtilization{CPUPercent: 10}
+	node2.Taints = []*structs.NodeTaint{
+		{Key: "dedicated", Effect: structs.NodeTaintEffectNoSchedule},
+	}
+	job := rebalanceTestJob(2)
+	store, _ := rebalanceTestState(t, []*structs.Node{node1, node2}, job,
+		map[*structs.Node]int{node1: 2})
+
+	// The only under-utilized node has a taint the job doesn't tolerate
+	config := &structs.RebalanceConfig{Enabled: true, Namespaces: []string{"default"}, UtilizationThreshold: 80}
+	candidates, err := NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+	must.NoError(t, err)
+	must.SliceEmpty(t, candidates)
+
+	// Or it lacks the driver of the tasks
+	noDriver := node2.Copy()
+	noDriver.Taints = nil
+	delete(noDriver.Attributes, "driver.exec")
+	delete(noDriver.Drivers, "exec")
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1300, noDriver))
+	candidates, err = NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+	must.NoError(t, err)
+	must.SliceEmpty(t, candidates)
+
+	// Once the node has the driver and the job tolerates the taint the
+	// allocations are migrated
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1400, node2.Copy()))
+	job.TaskGroups[0].Tolerations = []*structs.Toleration{{
+		Key:      "dedicated",
+		Operator: structs.TolerationOperatorExists,
+	}}
+	candidates, err = NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+	must.NoError(t, err)
+	must.Len(t, 2, candidates)
+}
+
+func TestRebalancer_Spread(t *testing.T) {
+	ci.Parallel(t)
+
+	node1, node2 := mock.Node(), mock.Node()
+	node1.Datacenter = "dc1"
+	node2.Datacenter = "dc2"
+
+	job := rebalanceTestJob(4)
+	job.Datacenters = []string{"dc1", "dc2"}
+	job.TaskGroups[0].Spreads = []*structs.Spread{{
+		Attribute: "${node.datacenter}",
+		Weight:    100,
+	}}
+	store, _ := rebalanceTestState(t, []*structs.Node{node1, node2}, job,
+		map[*structs.Node]int{node1: 3, node2: 1})
+
+	config := &structs.RebalanceConfig{Enabled: true, Namespaces: []string{"default"}}
+	candidates, err := NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+	must.NoError(t, err)
+	must.Len(t, 1, candidates)
+	must.Eq(t, node1.ID, candidates[0].Alloc.NodeID)
+	must.Eq(t, RebalanceReasonSpread, candidates[0].Reason)
+
+	// With targets the allocations are expected in the given proportions
+	job.TaskGroups[0].Spreads[0].SpreadTarget = []*structs.SpreadTarget{
+		{Value: "dc1", Percent: 25},
+	}
+	candidates, err = NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+	must.NoError(t, err)
+	must.Len(t, 2, candidates)
+	for _, c := range candidates {
+		must.Eq(t, node1.ID, c.Alloc.NodeID)
+	}
+}
+
+func TestRebalancer_Affinity(t *testing.T) {
+	ci.Parallel(t)
+
+	node1, node2, node3 := mock.Node(), mock.Node(), mock.Node()
+	node2.Meta["rack"] = "r1"
+	node3.Meta["rack"] = "r2"
+
+	job := rebalanceTestJob(3)
+	job.TaskGroups[0].Affinities = []*structs.Affinity{{
+		LTarget: "${meta.rack}",
+		RTarget: "r1",
+		Operand: "=",
+		Weight:  50,
+	}}
+	store, _ := rebalanceTestState(t, []*structs.Node{node1, node2, node3}, job,
+		map[*structs.Node]int{node1: 1, node2: 1, node3: 1})
+
+	config := &structs.RebalanceConfig{Enabled: true, Namespaces: []string{"default"}}
+	candidates, err := NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+	must.NoError(t, err)
+	must.Len(t, 2, candidates)
+	for _, c := range candidates {
+		must.NotEq(t, node2.ID, c.Alloc.NodeID)
+		must.Eq(t, RebalanceReasonAffinity, c.Reason)
+	}
+}
+
+func TestRebalancer_Limits(t *testing.T) {
+	ci.Parallel(t)
+
+	node1, node2 := mock.Node(), mock.Node()
+	node1.Utilization = &structs.NodeUtilization{CPUPercent: 95}
+	config := &structs.RebalanceConfig{Enabled: true, Namespaces: []string{"default"}, UtilizationThreshold: 80}
+
+	testCases := []struct {
+		name     string
+		mutate   func(*structs.Job, []*structs.Allocation)
+		expected int
+	}{
+		{
+			name:     "max parallel",
+			mutate:   func(job *structs.Job, _ []*structs.Allocation) { job.TaskGroups[0].Migrate.MaxParallel = 2 },
+			expected: 2,
+		},
+		{
+			name: "max parallel with migrating allocs",
+			mutate: func(job *structs.Job, allocs []*structs.Allocation) {
+				job.TaskGroups[0].Migrate.MaxParallel = 2
+				allocs[0].DesiredTransition.Migrate = pointer.Of(true)
+			},
+			expected: 1,
+		},
+		{
+			name: "disruption budget",
+			mutate: func(job *structs.Job, _ []*structs.Allocation) {
+				job.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{MinAvailable: 3}
+			},
+			expected: 1,
+		},
+		{
+			name:     "stopped job",
+			mutate:   func(job *structs.Job, _ []*structs.Allocation) { job.Stop = true },
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := rebalanceTestJob(4)
+			store, allocs := rebalanceTestState(t, []*structs.Node{node1, node2}, job,
+				map[*structs.Node]int{node1: 4})
+
+			job = job.Copy()
+			tc.mutate(job, allocs)
+			must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1300, nil, job))
+
+			// Upserting the job bumps its version
+			job, err := store.JobByID(nil, job.Namespace, job.ID)
+			must.NoError(t, err)
+			for _, alloc := range allocs {
+				alloc.Job = job
+			}
+			must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1301, allocs))
+
+			candidates, err := NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+			must.NoError(t, err)
+			must.Len(t, tc.expected, candidates)
+		})
+	}
+}
+
+func TestRebalancer_ActiveDeployment(t *testing.T) {
+	ci.Parallel(t)
+
+	node1, node2 := mock.Node(), mock.Node()
+	node1.Utilization = &structs.NodeUtilization{CPUPercent: 95}
+	job := rebalanceTestJob(2)
+	store, _ := rebalanceTestState(t, []*structs.Node{node1, node2}, job,
+		map[*structs.Node]int{node1: 2})
+
+	d := mock.Deployment()
+	d.JobID = job.ID
+	d.Status = structs.DeploymentStatusRunning
+	must.NoError(t, store.UpsertDeployment(1300, d))
+
+	config := &structs.RebalanceConfig{Enabled: true, Namespaces: []string{"default"}, UtilizationThreshold: 80}
+	candidates, err := NewRebalancer(testlog.HCLogger(t), store, config).JobCandidates(job)
+	must.NoError(t, err)
+	must.SliceEmpty(t, candidates)
+}
@@ -258,14 +258,83 @@ func (a *allocReconciler) Compute() *reconcileResults {
 
 func (a *allocReconciler) computeDeploymentComplete(m allocMatrix) bool {
 	complete := true
-	for group, as := range m {
-		groupComplete := a.computeGroup(group, as)
+	groupsComplete := make(map[string]bool, len(m))
+	for _, group := range a.groupOrder(m) {
+		groupComplete := a.computeGroup(group, m[group], a.isGroupWaiting(group, groupsComplete))
+		groupsComplete[group] = groupComplete
 		complete = complete && groupComplete
 	}
 
 	return complete
 }
 
+// groupOrder returns the groups of the allocation matrix in the order they are
+// computed, so that every group comes after the groups its update depends on.
+func (a *allocReconciler) groupOrder(m allocMatrix) []string {
+	groups := make([]string, 0, len(m))
+	for group := range m {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	order := make([]string, 0, len(groups))
+	visited := make(map[string]bool, len(groups))
+	var visit func(group string)
+	visit = func(group string) {
+		if visited[group] {
+			return
+		}
+		visited[group] = true
+		for _, dep := range a.groupDependencies(group) {
+			if _, ok := m[dep]; ok {
+				visit(dep)
+			}
+		}
+		order = append(order, group)
+	}
+	for _, group := range groups {
+		visit(group)
+	}
+	return order
+}
+
+// groupDependencies returns the groups whose deployment must complete before
+// the group is updated. Rollbacks update the groups in reverse order, so a
+// group then waits for the groups that depend on it.
+func (a *allocReconciler) groupDependencies(group string) []string {
+	if a.deployment != nil {
+		if dstate, ok := a.deployment.TaskGroups[group]; ok {
+			return dstate.DependsOn
+		}
+	}
+	if a.isRollback() {
+		return a.job.UpdateDependents(group)
+	}
+	return a.job.UpdateDependencies(group)
+}
+
+// isGroupWaiting returns whether the group waits for the deployment of the
+// groups it depends on to complete before it is updated.
+func (a *allocReconciler) isGroupWaiting(group string, groupsComplete map[string]bool) bool {
+	for _, dep := range a.groupDependencies(group) {
+		if complete, ok := groupsComplete[dep]; ok && !complete {
+			return true
+		}
+	}
+	return false
+}
+
+// isRollback returns whether the job is rolled back to a stable version after
+// its previous deployment failed. A reverted job keeps the stability of the
+// version it is reverted to.
+func (a *allocReconciler) isRollback() bool {
+	return a.job.Stable &&
+		a.oldDeployment != nil &&
+		a.oldDeployment.Status == structs.DeploymentStatusFailed &&
+		a.oldDeployment.JobCreateIndex == a.job.CreateIndex &&
+		a.oldDeployment.JobVersion < a.job.Version
+}
+
 func (a *allocReconciler) computeDeploymentUpdates(deploymentComplete bool) {
 	if a.deployment != nil {
 		// Mark the deployment as complete if possible
@@ -431,7 +500,7 @@ func (a *allocReconciler) markDelayed(allocs allocSet, clientStatus, statusDescr
 
 // computeGroup reconciles state for a particular task group. It returns whether
 // the deployment it is for is complete with regards to the task group.
-func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
+func (a *allocReconciler) computeGroup(groupName string, all allocSet, waiting bool) bool {
 
 	// Create the desired update object for the group
 	desiredChanges := new(structs.DesiredUpdates)
@@ -455,14 +524,34 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	all, ignore := a.filterOldTerminalAllocs(all)
 	desiredChanges.Ignore += uint64(len(ignore))
 
+	// Completion task groups stop all their allocations once more of them
+	// failed than their backoff limit allows. Only the failures of the current
+	// version of the job count, so that updating it retries the group.
+	if tg.Completion != nil && tg.Completion.Exhausted(all.countCompletionFailures(a.job)) {
+		stop := filterByTerminal(all)
+		a.markStop(stop, "", allocCompletionFailed)
+		desiredChanges.Stop += uint64(len(stop))
+		desiredChanges.Ignore += uint64(len(all) - len(stop))
+		return true
+	}
+
 	canaries, all := a.cancelUnneededCanaries(all, desiredChanges)
 
 	// Determine what set of allocations are on tainted nodes
 	untainted, migrate, lost, disconnecting, reconnecting, ignore, expiring := all.filterByTainted(a.taintedNodes, a.supportsDisconnectedClients, a.now)
 	desiredChanges.Ignore += uint64(len(ignore))
 
-	// Determine what set of terminal allocations need to be rescheduled
-	untainted, rescheduleNow, rescheduleLater := untainted.filterByRescheduleable(a.batch, false, a.now, a.evalID, a.deployment)
+	// Determine what set of terminal allocations need to be rescheduled.
+	// Completion task groups replace their failed allocations right away,
+	// regardless of their reschedule policy, until their backoff limit is
+	// exhausted.
+	var rescheduleNow allocSet
+	var rescheduleLater []*delayedRescheduleInfo
+	if tg.Completion != nil {
+		untainted, rescheduleNow = untainted.filterByFailed(a.batch)
+	} else {
+		untainted, rescheduleNow, rescheduleLater = untainted.filterByRescheduleable(a.batch, false, a.now, a.evalID, a.deployment)
+	}
 
 	// If there are allocations reconnecting we need to reconcile them and
 	// their replacements first because there is specific logic when deciding
@@ -549,6 +638,20 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	nameIndex := newAllocNameIndex(a.jobID, groupName, tg.Count, untainted.union(migrate, rescheduleNow, lost))
 	a.result.taskGroupAllocNameIndexes[groupName] = nameIndex
 
+	// Keep the previous allocations of a promoted blue/green deployment
+	// running until its hold period ends, so failing the deployment rolls it
+	// back instantly. A failed deployment no longer holds them back. The
+	// follow up evaluation for the end of the hold period is only created
+	// once and recorded on the deployment.
+	if dstate.Holding(a.now) && !a.deploymentFailed {
+		var held allocSet
+		untainted, held = untainted.filterByDeployment(a.deployment.ID)
+		desiredChanges.Ignore += uint64(len(held))
+		if dstate.HoldEvalID == "" {
+			a.recordHoldEval(tg.Name, a.createHoldEval(tg.Name, dstate.HoldUntil))
+		}
+	}
+
 	// Stop any unneeded allocations and update the untainted set to not
 	// include stopped allocations.
 	isCanarying := dstate != nil && dstate.DesiredCanaries != 0 && !dstate.Promoted
@@ -575,7 +678,7 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	}
 	requiresCanaries := a.requiresCanaries(tg, dstate, destructive, canaries)
 	if requiresCanaries {
-		a.computeCanaries(tg, dstate, destructive, canaries, desiredChanges, nameIndex)
+		a.computeCanaries(tg, dstate, destructive, canaries, desiredChanges, nameIndex, waiting)
 	}
 
 	// Determine how many non-canary allocs we can place
@@ -584,6 +687,7 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 
 	// Place if:
 	// * The deployment is not paused or failed
+	// * The group is not waiting for the groups it depends on
 	// * Not placing any canaries
 	// * If there are any canaries that they have been promoted
 	// * There is no delayed stop_after_client_disconnect alloc, which delays scheduling for the whole group
@@ -598,11 +702,12 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 
 	// deploymentPlaceReady tracks whether the deployment is in a state where
 	// placements can be made without any other consideration.
-	deploymentPlaceReady := !a.deploymentPaused && !a.deploymentFailed && !isCanarying
+	deploymentPlaceReady := !a.deploymentPaused && !a.deploymentFailed && !isCanarying && !waiting
 
 	underProvisionedBy = a.computeReplacements(deploymentPlaceReady, desiredChanges, place, rescheduleNow, lost, underProvisionedBy)
 
 	if deploymentPlaceReady {
+		underProvisionedBy = min(underProvisionedBy, a.computeAnalysisLimit(tg, dstate, untainted))
 		a.computeDestructiveUpdates(destructive, underProvisionedBy, desiredChanges, tg)
 	} else {
 		desiredChanges.Ignore += uint64(len(destructive))
@@ -637,6 +742,10 @@ func (a *allocReconciler) initializeDeploymentState(group string, tg *structs.Ta
 			dstate.AutoRevert = tg.Update.AutoRevert
 			dstate.AutoPromote = tg.Update.AutoPromote
 			dstate.ProgressDeadline = tg.Update.ProgressDeadline
+			if tg.Update.BlueGreen() {
+				dstate.HoldPeriod = tg.Update.HoldPeriod
+			}
+			dstate.DependsOn = a.groupDependencies(group)
 		}
 	}
 
@@ -648,16 +757,16 @@ func (a *allocReconciler) requiresCanaries(tg *structs.TaskGroup, dstate *struct
 	canariesPromoted := dstate != nil && dstate.Promoted
 	return tg.Update != nil &&
 		len(destructive) != 0 &&
-		len(canaries) < tg.Update.Canary &&
+		len(canaries) < tg.Update.Canaries(tg.Count) &&
 		!canariesPromoted
 }
 
 func (a *allocReconciler) computeCanaries(tg *structs.TaskGroup, dstate *structs.DeploymentState,
-	destructive, canaries allocSet, desiredChanges *structs.DesiredUpdates, nameIndex *allocNameIndex) {
-	dstate.DesiredCanaries = tg.Update.Canary
+	destructive, canaries allocSet, desiredChanges *structs.DesiredUpdates, nameIndex *allocNameIndex, waiting bool) {
+	dstate.DesiredCanaries = tg.Update.Canaries(tg.Count)
 
-	if !a.deploymentPaused && !a.deploymentFailed {
-		desiredChanges.Canary += uint64(tg.Update.Canary - len(canaries))
+	if !a.deploymentPaused && !a.deploymentFailed && !waiting {
+		desiredChanges.Canary += uint64(dstate.DesiredCanaries - len(canaries))
 		for _, name := range nameIndex.NextCanaries(uint(desiredChanges.Canary), canaries, destructive) {
 			a.result.place = append(a.result.place, allocPlaceResult{
 				name:      name,
@@ -699,19 +808,23 @@ func (a *allocReconciler) cancelUnneededCanaries(original allocSet, desiredChang
 
 	all = original
 
-	// Cancel any non-promoted canaries from the older deployment
+	// Cancel any non-promoted canaries from the older deployment, and the
+	// canaries of a blue/green deployment that failed while holding the
+	// previous allocations
 	if a.oldDeployment != nil {
+		oldFailed := a.oldDeployment.Status == structs.DeploymentStatusFailed
 		for _, dstate := range a.oldDeployment.TaskGroups {
-			if !dstate.Promoted {
+			if !dstate.Promoted || (oldFailed && dstate.Holding(a.now)) {
 				stop = append(stop, dstate.PlacedCanaries...)
 			}
 		}
 	}
 
-	// Cancel any non-promoted canaries from a failed deployment
+	// Cancel any non-promoted canaries from a failed deployment, or any held
+	// blue/green canaries
 	if a.deployment != nil && a.deployment.Status == structs.DeploymentStatusFailed {
 		for _, dstate := range a.deployment.TaskGroups {
-			if !dstate.Promoted {
+			if !dstate.Promoted || dstate.Holding(a.now) {
 				stop = append(stop, dstate.PlacedCanaries...)
 			}
 		}
@@ -791,6 +904,28 @@ func (a *allocReconciler) computeUnderProvisionedBy(group *structs.TaskGroup, un
 	return underProvisionedBy
 }
 
+// computeAnalysisLimit returns the number of allocations of the group that
+// can be updated before the deployment reaches its next analysis step. The
+// deployment waits for the analysis gates of the step to pass before updating
+// more allocations.
+func (a *allocReconciler) computeAnalysisLimit(group *structs.TaskGroup, dstate *structs.DeploymentState, untainted allocSet) int {
+	if group.Update.IsEmpty() {
+		return group.Count
+	}
+
+	target, ok := group.Update.Analysis.StepTarget(dstate.AnalysisStep, dstate.DesiredTotal)
+	if !ok {
+		return group.Count
+	}
+
+	updated := 0
+	if a.deployment != nil {
+		partOf, _ := untainted.filterByDeployment(a.deployment.ID)
+		updated = len(partOf)
+	}
+	return max(0, target-updated)
+}
+
 // computePlacements returns the set of allocations to place given the group
 // definition, the set of untainted, migrating and reschedule allocations for the group.
 //
@@ -840,8 +975,21 @@ func (a *allocReconciler) computePlacements(group *structs.TaskGroup,
 	}
 
 	// Add remaining placement results
-	if existing < group.Count {
-		for _, name := range nameIndex.Next(uint(group.Count - existing)) {
+	remaining := group.Count - existing
+	if group.Array != nil && group.Array.MaxParallel > 0 {
+		// Array task groups start new indices only while fewer than
+		// MaxParallel allocations are running, including the replacements
+		active := len(filterByTerminal(untainted)) + len(filterByTerminal(migrate)) + len(place)
+		remaining = min(remaining, group.Array.MaxParallel-active)
+	}
+	if group.Completion != nil && group.Completion.Parallelism > 0 {
+		// Completion task groups work towards their completions with at
+		// most Parallelism allocations running, including the replacements
+		active := len(filterByTerminal(untainted)) + len(filterByTerminal(migrate)) + len(place)
+		remaining = min(remaining, group.Completion.Parallelism-active)
+	}
+	if remaining > 0 {
+		for _, name := range nameIndex.Next(uint(remaining)) {
 			place = append(place, allocPlaceResult{
 				name:               name,
 				taskGroup:          group,
@@ -1008,14 +1156,21 @@ func (a *allocReconciler) isDeploymentComplete(groupName string, destructive, in
 	complete := len(destructive)+len(inplace)+len(place)+len(migrate)+len(rescheduleNow)+len(rescheduleLater) == 0 &&
 		!requiresCanaries
 
-	if !complete || a.deployment == nil {
+	if !complete {
 		return false
 	}
 
-	// Final check to see if the deployment is complete is to ensure everything is healthy
+	// A group without a deployment has nothing left to update. Otherwise the
+	// final check to see if the deployment is complete is to ensure
+	// everything is healthy
+	if a.deployment == nil {
+		return true
+	}
 	if dstate, ok := a.deployment.TaskGroups[groupName]; ok {
 		if dstate.HealthyAllocs < max(dstate.DesiredTotal, dstate.DesiredCanaries) || // Make sure we have enough healthy allocs
-			(dstate.DesiredCanaries > 0 && !dstate.Promoted) { // Make sure we are promoted if we have canaries
+			(dstate.DesiredCanaries > 0 && !dstate.Promoted) || // Make sure we are promoted if we have canaries
+			dstate.Holding(a.now) || // Make sure the previous allocations are no longer held
+			!a.analysisPassed(groupName, dstate) { // Make sure the gates of every analysis step passed
 			complete = false
 		}
 	}
@@ -1023,6 +1178,15 @@ func (a *allocReconciler) isDeploymentComplete(groupName string, destructive, in
 	return complete
 }
 
+// analysisPassed returns whether the group passed every step of its analysis.
+func (a *allocReconciler) analysisPassed(groupName string, dstate *structs.DeploymentState) bool {
+	tg := a.job.LookupTaskGroup(groupName)
+	if tg == nil || tg.Update.IsEmpty() {
+		return true
+	}
+	return tg.Update.Analysis.Passed(dstate.AnalysisStep)
+}
+
 // computeStop returns the set of allocations that are marked for stopping given
 // the group definition, the set of allocations in various states and whether we
 // are canarying.
@@ -1330,6 +1494,43 @@ func (a *allocReconciler) computeReconnecting(reconnecting allocSet) {
 	}
 }
 
+// createHoldEval creates a followup evaluation with the WaitUntil field set to
+// the end of the hold period of a blue/green deployment, when the previous
+// allocations of the task group are stopped. It returns the ID of the
+// evaluation.
+func (a *allocReconciler) createHoldEval(tgName string, holdUntil time.Time) string {
+	eval := &structs.Evaluation{
+		ID:                uuid.Generate(),
+		Namespace:         a.job.Namespace,
+		Priority:          a.evalPriority,
+		Type:              a.job.Type,
+		TriggeredBy:       structs.EvalTriggerDeploymentHold,
+		JobID:             a.job.ID,
+		JobModifyIndex:    a.job.ModifyIndex,
+		Status:            structs.EvalStatusPending,
+		StatusDescription: holdFollowupEvalDesc,
+		WaitUntil:         holdUntil,
+	}
+	a.appendFollowupEvals(tgName, []*structs.Evaluation{eval})
+	return eval.ID
+}
+
+// recordHoldEval adds the hold follow up evaluation of the task group to the
+// deployment update recording them, so that only the field is updated rather
+// than the whole deployment.
+func (a *allocReconciler) recordHoldEval(tgName, evalID string) {
+	for _, u := range a.result.deploymentUpdates {
+		if u.DeploymentID == a.deployment.ID && u.Status == "" {
+			u.HoldEvalIDs[tgName] = evalID
+			return
+		}
+	}
+	a.result.deploymentUpdates = append(a.result.deploymentUpdates, &structs.DeploymentStatusUpdate{
+		DeploymentID: a.deployment.ID,
+		HoldEvalIDs:  map[string]string{tgName: evalID},
+	})
+}
+
 // handleDelayedLost creates batched followup evaluations with the WaitUntil field set for
 // lost allocations. followupEvals are appended to a.result as a side effect, we return a
 // map of alloc IDs to their followupEval IDs.
@@ -3006,6 +3006,99 @@ func TestReconciler_CreateDeployment_RollingUpgrade_Destructive(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 3), destructiveResultsToNames(r.destructiveUpdate))
 }
 
+// Tests the reconciler only updates the allocations of the current analysis
+// step of the deployment
+func TestReconciler_RollingUpgrade_AnalysisSteps(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Update = noCanaryUpdate.Copy()
+	job.TaskGroups[0].Update.Analysis = &structs.AnalysisStrategy{
+		Steps: []int{20, 100},
+		Gates: []*structs.AnalysisGate{{
+			Name:     "webhook",
+			Type:     structs.AnalysisGateTypeHTTP,
+			Provider: "checks",
+			Timeout:  structs.DefaultAnalysisGateTimeout,
+		}},
+	}
+
+	// Create 10 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	// The first step only updates 20% of the allocations
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job,
+		nil, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	d := structs.NewDeployment(job, 50)
+	d.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		DesiredTotal: 10,
+	}
+	assertResults(t, r, &resultExpectation{
+		createDeployment: d,
+		destructive:      2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				DestructiveUpdate: 2,
+				Ignore:            8,
+			},
+		},
+	})
+
+	// The allocations of the step are updated and healthy
+	d = structs.NewDeployment(job, 50)
+	dstate := &structs.DeploymentState{
+		DesiredTotal:  10,
+		PlacedAllocs:  2,
+		HealthyAllocs: 2,
+	}
+	d.TaskGroups[job.TaskGroups[0].Name] = dstate
+	handled := make(map[string]allocUpdateType)
+	for _, alloc := range allocs[:2] {
+		alloc.DeploymentID = d.ID
+		alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(true)}
+		handled[alloc.ID] = allocUpdateFnIgnore
+	}
+	updateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+
+	// Nothing is updated until the gates of the step pass
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), updateFn, false, job.ID, job,
+		d, allocs, nil, "", 50, true)
+	r = reconciler.Compute()
+	assertResults(t, r, &resultExpectation{
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Ignore: 10,
+			},
+		},
+	})
+
+	// Once they pass the deployment continues at the rate of max_parallel
+	dstate.AnalysisStep = 1
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), updateFn, false, job.ID, job,
+		d, allocs, nil, "", 50, true)
+	r = reconciler.Compute()
+	assertResults(t, r, &resultExpectation{
+		destructive: 4,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				DestructiveUpdate: 4,
+				Ignore:            6,
+			},
+		},
+	})
+}
+
 // Tests the reconciler creates a deployment for inplace updates
 func TestReconciler_CreateDeployment_RollingUpgrade_Inplace(t *testing.T) {
 	ci.Parallel(t)
@@ -4091,6 +4184,453 @@ func TestReconciler_PromoteCanaries_CanariesEqualCount(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 1), stopResultsToNames(r.stop))
 }
 
+// Tests the reconciler places a full set of canaries for a blue/green
+// deployment
+func TestReconciler_BlueGreen_NewCanaries(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 4
+	job.TaskGroups[0].Update = noCanaryUpdate.Copy()
+	job.TaskGroups[0].Update.Strategy = structs.UpdateStrategyBlueGreen
+	job.TaskGroups[0].Update.HoldPeriod = time.Hour
+
+	// Create 4 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 4; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job,
+		nil, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	d := structs.NewDeployment(job, 50)
+	d.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+	d.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		DesiredCanaries: 4,
+		DesiredTotal:    4,
+		HoldPeriod:      time.Hour,
+	}
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  d,
+		deploymentUpdates: nil,
+		place:             4,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Canary: 4,
+				Ignore: 4,
+			},
+		},
+	})
+
+	assertNamesHaveIndexes(t, intRange(0, 3), placeResultsToNames(r.place))
+}
+
+// Tests the reconciler keeps the previous allocations of a promoted blue/green
+// deployment until its hold period ends
+func TestReconciler_BlueGreen_HoldPeriod(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Update = noCanaryUpdate.Copy()
+	job.TaskGroups[0].Update.Strategy = structs.UpdateStrategyBlueGreen
+	job.TaskGroups[0].Update.HoldPeriod = time.Hour
+
+	now := time.Now()
+	d := structs.NewDeployment(job, 50)
+	s := &structs.DeploymentState{
+		Promoted:        true,
+		DesiredTotal:    2,
+		DesiredCanaries: 2,
+		PlacedAllocs:    2,
+		HealthyAllocs:   2,
+		HoldPeriod:      time.Hour,
+		HoldUntil:       now.Add(time.Hour),
+	}
+	d.TaskGroups[job.TaskGroups[0].Name] = s
+
+	// Create 2 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	// Create the promoted canaries
+	handled := make(map[string]allocUpdateType)
+	for i := 0; i < 2; i++ {
+		canary := mock.Alloc()
+		canary.Job = job
+		canary.JobID = job.ID
+		canary.NodeID = uuid.Generate()
+		canary.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		canary.TaskGroup = job.TaskGroups[0].Name
+		s.PlacedCanaries = append(s.PlacedCanaries, canary.ID)
+		canary.DeploymentID = d.ID
+		canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: pointer.Of(true),
+		}
+		allocs = append(allocs, canary)
+		handled[canary.ID] = allocUpdateFnIgnore
+	}
+	mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+
+	// The previous allocations are held and a follow up evaluation is created
+	// for the end of the hold period
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		d, allocs, nil, "", 50, true, AllocRenconcilerWithNow(now))
+	r := reconciler.Compute()
+
+	evals := r.desiredFollowupEvals[job.TaskGroups[0].Name]
+	must.Len(t, 1, evals)
+	must.Eq(t, structs.EvalTriggerDeploymentHold, evals[0].TriggeredBy)
+	must.Eq(t, s.HoldUntil, evals[0].WaitUntil)
+
+	// The follow up evaluation is recorded on the deployment with a targeted
+	// update rather than by upserting the whole deployment
+	assertResults(t, r, &resultExpectation{
+		deploymentUpdates: []*structs.DeploymentStatusUpdate{
+			{
+				DeploymentID: d.ID,
+				HoldEvalIDs:  map[string]string{job.TaskGroups[0].Name: evals[0].ID},
+			},
+		},
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Ignore: 4,
+			},
+		},
+	})
+
+	// Reconciling again during the hold period doesn't create another follow
+	// up evaluation
+	held := d.Copy()
+	held.TaskGroups[job.TaskGroups[0].Name].HoldEvalID = evals[0].ID
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		held, allocs, nil, "", 50, true, AllocRenconcilerWithNow(now.Add(time.Minute)))
+	r = reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Ignore: 4,
+			},
+		},
+	})
+	must.MapLen(t, 0, r.desiredFollowupEvals)
+
+	// Once the hold period ends they are stopped and the deployment completes
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		d, allocs, nil, "", 50, true, AllocRenconcilerWithNow(s.HoldUntil))
+	r = reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		deploymentUpdates: []*structs.DeploymentStatusUpdate{
+			{
+				DeploymentID:      d.ID,
+				Status:            structs.DeploymentStatusSuccessful,
+				StatusDescription: structs.DeploymentStatusDescriptionSuccessful,
+			},
+		},
+		stop: 2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   2,
+				Ignore: 2,
+			},
+		},
+	})
+
+	assertNoCanariesStopped(t, d, r.stop)
+	must.MapLen(t, 0, r.desiredFollowupEvals)
+}
+
+// Tests the reconciler stops the canaries of a blue/green deployment that
+// failed during its hold period and keeps the previous allocations
+func TestReconciler_BlueGreen_FailedDuringHold(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Update = noCanaryUpdate.Copy()
+	job.TaskGroups[0].Update.Strategy = structs.UpdateStrategyBlueGreen
+	job.TaskGroups[0].Update.HoldPeriod = time.Hour
+
+	now := time.Now()
+	d := structs.NewDeployment(job, 50)
+	d.Status = structs.DeploymentStatusFailed
+	s := &structs.DeploymentState{
+		Promoted:        true,
+		DesiredTotal:    2,
+		DesiredCanaries: 2,
+		PlacedAllocs:    2,
+		HealthyAllocs:   2,
+		HoldPeriod:      time.Hour,
+		HoldUntil:       now.Add(time.Hour),
+	}
+	d.TaskGroups[job.TaskGroups[0].Name] = s
+
+	// Create 2 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	// Create the promoted canaries
+	handled := make(map[string]allocUpdateType)
+	canaries := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		canary := mock.Alloc()
+		canary.Job = job
+		canary.JobID = job.ID
+		canary.NodeID = uuid.Generate()
+		canary.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		canary.TaskGroup = job.TaskGroups[0].Name
+		s.PlacedCanaries = append(s.PlacedCanaries, canary.ID)
+		canary.DeploymentID = d.ID
+		canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: pointer.Of(true),
+		}
+		allocs = append(allocs, canary)
+		handled[canary.ID] = allocUpdateFnIgnore
+		canaries[canary.ID] = true
+	}
+	mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		d, allocs, nil, "", 50, true, AllocRenconcilerWithNow(now))
+	r := reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		stop: 2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   2,
+				Ignore: 2,
+			},
+		},
+	})
+
+	for _, stop := range r.stop {
+		must.True(t, canaries[stop.alloc.ID])
+	}
+
+	// The previous allocations are no longer held
+	must.MapEmpty(t, r.desiredFollowupEvals)
+}
+
+// dependsOnJob returns a job whose web group depends on its api group, with 2
+// allocations from the old job for each group.
+func dependsOnJob() (*structs.Job, []*structs.Allocation) {
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Update = noCanaryUpdate.Copy()
+	api := job.TaskGroups[0].Copy()
+	api.Name = "api"
+	job.TaskGroups = append(job.TaskGroups, api)
+	job.TaskGroups[0].Update.DependsOn = []string{"api"}
+
+	var allocs []*structs.Allocation
+	for _, tg := range job.TaskGroups {
+		for i := 0; i < 2; i++ {
+			alloc := mock.Alloc()
+			alloc.Job = job
+			alloc.JobID = job.ID
+			alloc.NodeID = uuid.Generate()
+			alloc.Name = structs.AllocName(job.ID, tg.Name, uint(i))
+			alloc.TaskGroup = tg.Name
+			allocs = append(allocs, alloc)
+		}
+	}
+	return job, allocs
+}
+
+// Tests the reconciler waits for the deployment of the groups a group depends
+// on to complete before updating it
+func TestReconciler_DependsOn_WaitsForDependencies(t *testing.T) {
+	ci.Parallel(t)
+
+	job, allocs := dependsOnJob()
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job,
+		nil, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	d := structs.NewDeployment(job, 50)
+	d.TaskGroups["api"] = &structs.DeploymentState{DesiredTotal: 2}
+	d.TaskGroups["web"] = &structs.DeploymentState{DesiredTotal: 2, DependsOn: []string{"api"}}
+
+	assertResults(t, r, &resultExpectation{
+		createDeployment: d,
+		destructive:      2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			"api": {
+				DestructiveUpdate: 2,
+			},
+			"web": {
+				Ignore: 2,
+			},
+		},
+	})
+	for _, u := range r.destructiveUpdate {
+		must.Eq(t, "api", u.placeTaskGroup.Name)
+	}
+
+	// Replace the allocations of the api group, only one of them is healthy
+	handled := make(map[string]allocUpdateType)
+	var updated []*structs.Allocation
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != "api" {
+			updated = append(updated, alloc)
+			continue
+		}
+		replacement := alloc.Copy()
+		replacement.ID = uuid.Generate()
+		replacement.DeploymentID = d.ID
+		replacement.DeploymentStatus = &structs.AllocDeploymentStatus{}
+		updated = append(updated, replacement)
+		handled[replacement.ID] = allocUpdateFnIgnore
+	}
+	updated[2].DeploymentStatus.Healthy = pointer.Of(true)
+	d.TaskGroups["api"].PlacedAllocs = 2
+	d.TaskGroups["api"].HealthyAllocs = 1
+	mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		d, updated, nil, "", 50, true)
+	r = reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			"api": {
+				Ignore: 2,
+			},
+			"web": {
+				Ignore: 2,
+			},
+		},
+	})
+
+	// Once the api group is healthy the web group is updated
+	updated[3].DeploymentStatus.Healthy = pointer.Of(true)
+	d.TaskGroups["api"].HealthyAllocs = 2
+
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		d, updated, nil, "", 50, true)
+	r = reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		destructive: 2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			"api": {
+				Ignore: 2,
+			},
+			"web": {
+				DestructiveUpdate: 2,
+			},
+		},
+	})
+	for _, u := range r.destructiveUpdate {
+		must.Eq(t, "web", u.placeTaskGroup.Name)
+	}
+}
+
+// Tests the reconciler waits for the dependencies of a group before placing
+// its canaries
+func TestReconciler_DependsOn_Canaries(t *testing.T) {
+	ci.Parallel(t)
+
+	job, allocs := dependsOnJob()
+	for _, tg := range job.TaskGroups {
+		tg.Update.Canary = 1
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job,
+		nil, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	d := structs.NewDeployment(job, 50)
+	d.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+	d.TaskGroups["api"] = &structs.DeploymentState{DesiredCanaries: 1, DesiredTotal: 2}
+	d.TaskGroups["web"] = &structs.DeploymentState{DesiredCanaries: 1, DesiredTotal: 2, DependsOn: []string{"api"}}
+
+	assertResults(t, r, &resultExpectation{
+		createDeployment: d,
+		place:            1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			"api": {
+				Canary: 1,
+				Ignore: 2,
+			},
+			"web": {
+				Ignore: 2,
+			},
+		},
+	})
+	must.Eq(t, "api", r.place[0].taskGroup.Name)
+	must.True(t, d.TaskGroups["web"].Waiting())
+}
+
+// Tests a rollback updates the groups in the reverse order of their
+// dependencies
+func TestReconciler_DependsOn_Rollback(t *testing.T) {
+	ci.Parallel(t)
+
+	job, allocs := dependsOnJob()
+	job.Version = 2
+	job.Stable = true
+
+	// The deployment of the previous version failed and reverted the job
+	failed := structs.NewDeployment(job, 50)
+	failed.JobVersion = 1
+	failed.Status = structs.DeploymentStatusFailed
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job,
+		failed, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	d := structs.NewDeployment(job, 50)
+	d.TaskGroups["api"] = &structs.DeploymentState{DesiredTotal: 2, DependsOn: []string{"web"}}
+	d.TaskGroups["web"] = &structs.DeploymentState{DesiredTotal: 2}
+
+	assertResults(t, r, &resultExpectation{
+		createDeployment: d,
+		destructive:      2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			"api": {
+				Ignore: 2,
+			},
+			"web": {
+				DestructiveUpdate: 2,
+			},
+		},
+	})
+	for _, u := range r.destructiveUpdate {
+		must.Eq(t, "web", u.placeTaskGroup.Name)
+	}
+}
+
 // Tests the reconciler checks the health of placed allocs to determine the
 // limit
 func TestReconciler_DeploymentLimit_HealthAccounting(t *testing.T) {
@@ -4864,6 +5404,74 @@ func TestReconciler_Batch_Rerun(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 9), placeResultsToNames(r.place))
 }
 
+// Tests that a completion task group whose backoff limit is exhausted stops its
+// allocations, and that only the failures of the current version of the job
+// count toward the backoff limit once it is updated.
+func TestReconciler_Completion_BackoffLimit_JobUpdate(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.TaskGroups[0].Update = nil
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].Completion = &structs.CompletionPolicy{Completions: 3, Parallelism: 3, BackoffLimit: 1}
+
+	// Two allocations failed, exhausting the backoff limit, and one is still
+	// running
+	var allocs []*structs.Allocation
+	for i := 0; i < 3; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.ClientStatus = structs.AllocClientStatusFailed
+		allocs = append(allocs, alloc)
+	}
+	allocs[2].ClientStatus = structs.AllocClientStatusRunning
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, true, job.ID, job,
+		nil, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		stop: 1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   1,
+				Ignore: 2,
+			},
+		},
+	})
+	must.Eq(t, allocs[2].ID, r.stop[0].alloc.ID)
+	must.Eq(t, allocCompletionFailed, r.stop[0].statusDescription)
+
+	// Once the job is updated, the failures of the previous version don't
+	// count anymore and the group is retried
+	allocs[2] = allocs[2].Copy()
+	allocs[2].DesiredStatus = structs.AllocDesiredStatusStop
+	allocs[2].ClientStatus = structs.AllocClientStatusComplete
+
+	job2 := job.Copy()
+	job2.Version++
+	job2.CreateIndex++
+
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, true, job2.ID, job2,
+		nil, allocs, nil, "", 50, true)
+	r = reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		place: 3,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Place:  3,
+				Ignore: 3,
+			},
+		},
+	})
+}
+
 // Test that a failed deployment will not result in rescheduling failed allocations
 func TestReconciler_FailedDeployment_DontReschedule(t *testing.T) {
 	ci.Parallel(t)
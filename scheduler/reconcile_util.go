@@ -439,6 +439,31 @@ func (a allocSet) filterByRescheduleable(isBatch, isDisconnecting bool, now time
 	return untainted, rescheduleNow, rescheduleLater
 }
 
+// filterByFailed filters the allocations that failed and have not been
+// replaced yet from the allocations that count against the desired total,
+// without considering the reschedule policy of the allocations. Allocations
+// that should be ignored are filtered out.
+func (a allocSet) filterByFailed(isBatch bool) (untainted, failed allocSet) {
+	untainted = make(map[string]*structs.Allocation)
+	failed = make(map[string]*structs.Allocation)
+
+	for _, alloc := range a {
+		// Ignore failed allocs that have already been replaced
+		if alloc.NextAllocation != "" && alloc.TerminalStatus() {
+			continue
+		}
+
+		isUntainted, ignore := shouldFilter(alloc, isBatch)
+		switch {
+		case isUntainted:
+			untainted[alloc.ID] = alloc
+		case !ignore:
+			failed[alloc.ID] = alloc
+		}
+	}
+	return untainted, failed
+}
+
 // shouldFilter returns whether the alloc should be ignored or considered untainted.
 //
 // Ignored allocs are filtered out.
@@ -629,6 +654,18 @@ func (a allocSet) filterByClientStatus(clientStatus string) allocSet {
 	return allocs
 }
 
+// countCompletionFailures returns the number of allocs from the set that count
+// toward the backoff limit of the completion policy of the job.
+func (a allocSet) countCompletionFailures(job *structs.Job) int {
+	count := 0
+	for _, alloc := range a {
+		if job.CompletionFailure(alloc) {
+			count++
+		}
+	}
+	return count
+}
+
 // allocNameIndex is used to select allocation names for placement or removal
 // given an existing set of placed allocations.
 type allocNameIndex struct {
@@ -6,6 +6,7 @@ package scheduler
 import (
 	"fmt"
 	"runtime/debug"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
@@ -49,6 +50,16 @@ type SystemScheduler struct {
 	limitReached bool
 	nextEval     *structs.Evaluation
 
+	// deployment is the deployment of the job version, if its task groups
+	// use canaries or auto-revert. canaries is the set of allocations whose
+	// replacements are canaries.
+	deployment *structs.Deployment
+	canaries   map[string]struct{}
+
+	// deploymentUpdating is set when the task groups of the deployment have
+	// allocations left to update or analysis steps left to pass.
+	deploymentUpdating bool
+
 	failedTGAllocs map[string]*structs.AllocMetric
 	queuedAllocs   map[string]int
 }
@@ -115,7 +126,7 @@ func (s *SystemScheduler) Process(eval *structs.Evaluation) (err error) {
 
 	// Update the status to complete
 	return setStatus(s.logger, s.planner, s.eval, s.nextEval, nil, s.failedTGAllocs, structs.EvalStatusComplete, "",
-		s.queuedAllocs, "")
+		s.queuedAllocs, s.deployment.GetID())
 }
 
 // process is wrapped in retryMax to iteratively run the handler until we have no
@@ -147,6 +158,18 @@ func (s *SystemScheduler) process() (bool, error) {
 	// Create a plan
 	s.plan = s.eval.MakePlan(s.job)
 
+	// Get any existing deployment
+	var deployment *structs.Deployment
+	if !s.sysbatch {
+		deployment, err = s.state.LatestDeploymentByJobID(ws, s.eval.Namespace, s.eval.JobID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get job deployment %q: %v", s.eval.JobID, err)
+		}
+	}
+	s.deployment = deployment.Copy()
+	s.canaries = make(map[string]struct{})
+	s.deploymentUpdating = false
+
 	// Reset the failed allocations
 	s.failedTGAllocs = nil
 
@@ -165,6 +188,13 @@ func (s *SystemScheduler) process() (bool, error) {
 		return false, err
 	}
 
+	s.computeDeploymentComplete()
+
+	// Add the created or updated deployment to the plan
+	if systemDeploymentChanged(deployment, s.deployment) {
+		s.plan.Deployment = s.deployment
+	}
+
 	// If the plan is a no-op, we can bail. If AnnotatePlan is set submit the plan
 	// anyways to get the annotations.
 	if s.plan.IsNoOp() && !s.eval.AnnotatePlan {
@@ -288,6 +318,14 @@ func (s *SystemScheduler) computeJobAllocs() error {
 	destructiveUpdates, inplaceUpdates := inplaceUpdate(s.ctx, s.eval, s.job, s.stack, updates)
 	diff.update = destructiveUpdates
 
+	// Split out the destructive updates of task groups whose updates are
+	// tracked by a deployment, they are limited by the deployment instead of
+	// the rolling update.
+	var deployed []allocTuple
+	if !s.sysbatch {
+		deployed, diff.update = s.computeDeployment(diff, inplaceUpdates)
+	}
+
 	if s.eval.AnnotatePlan {
 		s.plan.Annotations = &structs.PlanAnnotations{
 			DesiredTGUpdates: desiredUpdates(diff, inplaceUpdates, destructiveUpdates),
@@ -302,6 +340,8 @@ func (s *SystemScheduler) computeJobAllocs() error {
 
 	// Treat non in-place updates as an eviction and new placement.
 	s.limitReached = evictAndPlace(s.ctx, diff, diff.update, allocUpdating, &limit)
+	deployedLimit := len(deployed)
+	evictAndPlace(s.ctx, diff, deployed, allocUpdating, &deployedLimit)
 
 	// Nothing remaining to do if placement is not required
 	if len(diff.place) == 0 {
@@ -322,6 +362,238 @@ func (s *SystemScheduler) computeJobAllocs() error {
 	return s.computePlacements(diff.place)
 }
 
+// computeDeployment cancels the deployments of older versions of the job and
+// creates the deployment of the current version when its task groups use
+// canaries or auto-revert. It returns the destructive updates of those task
+// groups that can be done now and the destructive updates of the other task
+// groups. Until the deployment is promoted only the canary nodes are updated,
+// then at most max_parallel allocations are updated until they are healthy.
+// Updates are held while the deployment is paused or failed.
+func (s *SystemScheduler) computeDeployment(diff *diffResult, inplace []allocTuple) (deployed, other []allocTuple) {
+	s.cancelUnneededDeployment()
+	if s.job.Stopped() {
+		return nil, diff.update
+	}
+
+	// Group the allocations by task group. Only allocations of the current
+	// job version count towards the deployment.
+	groups := make(map[string]*diffResult, len(s.job.TaskGroups))
+	group := func(tuple allocTuple) *diffResult {
+		name := tuple.TaskGroup.Name
+		if _, ok := groups[name]; !ok {
+			groups[name] = new(diffResult)
+		}
+		return groups[name]
+	}
+	for _, tuple := range diff.place {
+		g := group(tuple)
+		g.place = append(g.place, tuple)
+	}
+	for _, tuple := range diff.update {
+		g := group(tuple)
+		g.update = append(g.update, tuple)
+	}
+	for _, tuple := range diff.ignore {
+		if tuple.Alloc.Job != nil && tuple.Alloc.Job.JobModifyIndex == s.job.JobModifyIndex &&
+			!tuple.Alloc.TerminalStatus() {
+			g := group(tuple)
+			g.ignore = append(g.ignore, tuple)
+		}
+	}
+
+	// In-place updates are part of the deployment as well
+	inplaceByGroup := make(map[string][]allocTuple)
+	for _, tuple := range inplace {
+		inplaceByGroup[tuple.TaskGroup.Name] = append(inplaceByGroup[tuple.TaskGroup.Name], tuple)
+	}
+
+	created := false
+	for _, tg := range s.job.TaskGroups {
+		g, ok := groups[tg.Name]
+		if !ok {
+			g = new(diffResult)
+		}
+		if !systemDeploymentEnabled(tg) {
+			other = append(other, g.update...)
+			continue
+		}
+
+		inplace := inplaceByGroup[tg.Name]
+		total := len(g.place) + len(g.update) + len(inplace) + len(g.ignore)
+
+		var dstate *structs.DeploymentState
+		if s.deployment != nil {
+			dstate = s.deployment.TaskGroups[tg.Name]
+		}
+
+		if dstate == nil {
+			// Only create a deployment when the task group is updated or
+			// placed for the first time.
+			updating := len(g.update)+len(inplace) != 0
+			if !updating && (len(g.ignore) != 0 || len(g.place) == 0) {
+				continue
+			}
+
+			dstate = &structs.DeploymentState{
+				AutoRevert:       tg.Update.AutoRevert,
+				AutoPromote:      tg.Update.AutoPromote,
+				ProgressDeadline: tg.Update.ProgressDeadline,
+			}
+			if len(g.update) != 0 {
+				dstate.DesiredCanaries = systemCanaries(tg.Update.CanaryPercent, total)
+			}
+
+			if s.deployment == nil {
+				s.deployment = structs.NewDeployment(s.job, s.eval.Priority)
+				created = true
+			}
+			s.deployment.TaskGroups[tg.Name] = dstate
+		}
+		dstate.DesiredTotal = total
+
+		s.setInplaceDeployment(inplace)
+
+		canary := dstate.DesiredCanaries != 0 && !dstate.Promoted
+		if canary || len(g.update) != 0 || len(inplace) != 0 || !tg.Update.Analysis.Passed(dstate.AnalysisStep) {
+			s.deploymentUpdating = true
+		}
+
+		// Hold the updates until the deployment is resumed, failed
+		// deployments are reverted or replaced by a new job version.
+		if s.deployment.Status != structs.DeploymentStatusRunning {
+			continue
+		}
+
+		var limit int
+		if canary {
+			limit = dstate.DesiredCanaries - len(dstate.PlacedCanaries)
+		} else {
+			limit = tg.Update.MaxParallel
+			updated := 0
+			for _, tuple := range g.ignore {
+				if tuple.Alloc.DeploymentID != s.deployment.ID {
+					continue
+				}
+				updated++
+				if !tuple.Alloc.DeploymentStatus.IsHealthy() {
+					limit--
+				}
+			}
+
+			// Wait for the analysis gates of the step to pass before
+			// updating more allocations
+			if target, ok := tg.Update.Analysis.StepTarget(dstate.AnalysisStep, dstate.DesiredTotal); ok {
+				limit = min(limit, target-updated)
+			}
+		}
+		limit = max(0, min(limit, len(g.update)))
+
+		for _, tuple := range g.update[:limit] {
+			if canary {
+				s.canaries[tuple.Alloc.ID] = struct{}{}
+			}
+			deployed = append(deployed, tuple)
+		}
+
+		// Start the progress deadline with the first placements of the task
+		// group, as the generic scheduler's deployments do
+		placing := limit != 0 || len(g.place) != 0 || len(inplace) != 0
+		if placing && dstate.ProgressDeadline != 0 && dstate.RequireProgressBy.IsZero() {
+			dstate.RequireProgressBy = time.Now().Add(dstate.ProgressDeadline)
+		}
+	}
+
+	// Set the description of a created deployment
+	if d := s.deployment; created && d.RequiresPromotion() {
+		if d.HasAutoPromote() {
+			d.StatusDescription = structs.DeploymentStatusDescriptionRunningAutoPromotion
+		} else {
+			d.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+		}
+	}
+
+	return deployed, other
+}
+
+// computeDeploymentComplete marks the running deployment as successful once
+// the allocations of all its task groups are updated and healthy.
+func (s *SystemScheduler) computeDeploymentComplete() {
+	d := s.deployment
+	if d == nil || d.Status != structs.DeploymentStatusRunning || s.deploymentUpdating {
+		return
+	}
+
+	// Allocations placed by this plan are not healthy yet
+	for _, allocs := range s.plan.NodeAllocation {
+		for _, alloc := range allocs {
+			if alloc.DeploymentID == d.ID {
+				return
+			}
+		}
+	}
+
+	for _, dstate := range d.TaskGroups {
+		if dstate.HealthyAllocs < dstate.DesiredTotal {
+			return
+		}
+	}
+
+	s.plan.DeploymentUpdates = append(s.plan.DeploymentUpdates, &structs.DeploymentStatusUpdate{
+		DeploymentID:      d.ID,
+		Status:            structs.DeploymentStatusSuccessful,
+		StatusDescription: structs.DeploymentStatusDescriptionSuccessful,
+	})
+}
+
+// cancelUnneededDeployment cancels the active deployment of an older version
+// of the job or of a stopped job. The deployment is cleared if it is not the
+// deployment of the current job version or if it is successful.
+func (s *SystemScheduler) cancelUnneededDeployment() {
+	d := s.deployment
+	if d == nil {
+		return
+	}
+
+	stale := s.job.Stopped() || d.JobCreateIndex != s.job.CreateIndex || d.JobVersion != s.job.Version
+	if stale && d.Active() {
+		desc := structs.DeploymentStatusDescriptionNewerJob
+		if s.job.Stopped() {
+			desc = structs.DeploymentStatusDescriptionStoppedJob
+		}
+		s.plan.DeploymentUpdates = append(s.plan.DeploymentUpdates, &structs.DeploymentStatusUpdate{
+			DeploymentID:      d.ID,
+			Status:            structs.DeploymentStatusCancelled,
+			StatusDescription: desc,
+		})
+	}
+
+	if stale || d.Status == structs.DeploymentStatusSuccessful {
+		s.deployment = nil
+	}
+}
+
+// setInplaceDeployment makes the allocations updated in-place part of the
+// deployment, so their health is tracked again.
+func (s *SystemScheduler) setInplaceDeployment(inplace []allocTuple) {
+	for _, tuple := range inplace {
+		for _, alloc := range s.plan.NodeAllocation[tuple.Alloc.NodeID] {
+			if alloc.ID == tuple.Alloc.ID && alloc.DeploymentID != s.deployment.ID {
+				alloc.DeploymentID = s.deployment.ID
+				alloc.DeploymentStatus = nil
+			}
+		}
+	}
+}
+
+// deploymentState returns the state of the task group in the active
+// deployment, or nil if its allocations are not tracked by a deployment.
+func (s *SystemScheduler) deploymentState(tgName string) *structs.DeploymentState {
+	if s.deployment == nil || !s.deployment.Active() {
+		return nil
+	}
+	return s.deployment.TaskGroups[tgName]
+}
+
 func mergeNodeFiltered(acc, curr *structs.AllocMetric) *structs.AllocMetric {
 	if acc == nil {
 		return curr.Copy()
@@ -396,6 +668,12 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 					s.failedTGAllocs[tgName] = filteredMetrics[tgName]
 				}
 
+				// The node doesn't count towards the deployment of the task
+				// group
+				if dstate := s.deploymentState(tgName); dstate != nil {
+					dstate.DesiredTotal--
+				}
+
 				// If we are annotating the plan, then decrement the desired
 				// placements based on whether the node meets the constraints
 				if s.eval.AnnotatePlan && s.plan.Annotations != nil &&
@@ -487,6 +765,15 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 			alloc.PreviousAllocation = missing.Alloc.ID
 		}
 
+		// Track the allocation in the deployment of its task group, replacing
+		// the allocation of a canary node places a canary
+		if s.deploymentState(tgName) != nil {
+			alloc.DeploymentID = s.deployment.ID
+			if _, ok := s.canaries[alloc.PreviousAllocation]; ok {
+				alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Canary: true}
+			}
+		}
+
 		// If this placement involves preemption, set DesiredState to evict for those allocations
 		if option.PreemptedAllocs != nil {
 			var preemptedAllocIDs []string
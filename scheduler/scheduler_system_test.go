@@ -805,6 +805,326 @@ func TestSystemSched_JobModify_InPlace(t *testing.T) {
 	}
 }
 
+func TestSystemSched_JobModify_Canary(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create some nodes
+	nodes := createNodes(t, h, 10)
+
+	// Generate a fake job with allocations
+	job := mock.SystemJob()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	var allocs []*structs.Allocation
+	for _, node := range nodes {
+		alloc := mock.AllocForNode(node)
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.Name = "my-job.web[0]"
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// Update the job with canaries on 20% of the nodes, such that it cannot
+	// be done in-place
+	job2 := job.Copy()
+	job2.TaskGroups[0].Update = &structs.UpdateStrategy{
+		MaxParallel:      2,
+		CanaryPercent:    20,
+		AutoRevert:       true,
+		HealthCheck:      structs.UpdateStrategyHealthCheck_Checks,
+		MinHealthyTime:   10 * time.Second,
+		HealthyDeadline:  10 * time.Minute,
+		ProgressDeadline: 15 * time.Minute,
+	}
+	job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job2))
+
+	process := func() *structs.Plan {
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    50,
+			TriggeredBy: structs.EvalTriggerJobRegister,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		plans := len(h.Plans)
+		must.NoError(t, h.Process(NewSystemScheduler, eval))
+		if len(h.Plans) == plans {
+			return nil
+		}
+		return h.Plans[len(h.Plans)-1]
+	}
+
+	// Ensure the plan creates a deployment and only replaces the allocations
+	// of the canary nodes
+	plan := process()
+	must.NotNil(t, plan)
+	must.NotNil(t, plan.Deployment)
+	dstate := plan.Deployment.TaskGroups["web"]
+	must.NotNil(t, dstate)
+	must.Eq(t, 10, dstate.DesiredTotal)
+	must.Eq(t, 2, dstate.DesiredCanaries)
+	must.True(t, dstate.AutoRevert)
+	must.Eq(t, structs.DeploymentStatusDescriptionRunningNeedsPromotion, plan.Deployment.StatusDescription)
+
+	// Ensure the progress deadline starts with the canary placements
+	must.False(t, dstate.RequireProgressBy.IsZero())
+	must.True(t, dstate.RequireProgressBy.After(time.Now().Add(14*time.Minute)))
+	must.True(t, dstate.RequireProgressBy.Before(time.Now().Add(16*time.Minute)))
+
+	var update []*structs.Allocation
+	for _, updateList := range plan.NodeUpdate {
+		update = append(update, updateList...)
+	}
+	must.Len(t, 2, update)
+
+	var planned []*structs.Allocation
+	for _, allocList := range plan.NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	must.Len(t, 2, planned)
+	for _, alloc := range planned {
+		must.Eq(t, plan.Deployment.ID, alloc.DeploymentID)
+		must.NotNil(t, alloc.DeploymentStatus)
+		must.True(t, alloc.DeploymentStatus.Canary)
+	}
+	must.SliceEmpty(t, h.CreateEvals)
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+
+	// Ensure nothing else is updated until the deployment is promoted
+	plan = process()
+	must.Nil(t, plan)
+
+	// Promote the deployment with healthy canaries
+	deployment, err := h.State.DeploymentByID(nil, h.Plans[0].Deployment.ID)
+	must.NoError(t, err)
+	must.Len(t, 2, deployment.TaskGroups["web"].PlacedCanaries)
+
+	var canaries []*structs.Allocation
+	for _, id := range deployment.TaskGroups["web"].PlacedCanaries {
+		alloc, err := h.State.AllocByID(nil, id)
+		must.NoError(t, err)
+		alloc = alloc.Copy()
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		alloc.DeploymentStatus.Healthy = pointer.Of(true)
+		canaries = append(canaries, alloc)
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), canaries))
+
+	deployment = deployment.Copy()
+	deployment.TaskGroups["web"].Promoted = true
+	must.NoError(t, h.State.UpsertDeployment(h.NextIndex(), deployment))
+
+	// Ensure the rest of the nodes are updated max_parallel at a time
+	plan = process()
+	must.NotNil(t, plan)
+	must.Nil(t, plan.Deployment)
+
+	planned = nil
+	for _, allocList := range plan.NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	must.Len(t, 2, planned)
+	for _, alloc := range planned {
+		must.Eq(t, deployment.ID, alloc.DeploymentID)
+		must.Nil(t, alloc.DeploymentStatus)
+	}
+
+	// Ensure the updates wait for the new allocations to be healthy
+	plan = process()
+	must.Nil(t, plan)
+}
+
+func TestSystemSched_JobModify_NoCanaryPercent(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create some nodes
+	nodes := createNodes(t, h, 4)
+
+	// Generate a fake job with allocations
+	job := mock.SystemJob()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	var allocs []*structs.Allocation
+	for _, node := range nodes {
+		alloc := mock.AllocForNode(node)
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.Name = "my-job.web[0]"
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// Update the job with a canary count and auto-revert, which system jobs
+	// ignore without a canary percent
+	job2 := job.Copy()
+	job2.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job2.TaskGroups[0].Update.MaxParallel = 4
+	job2.TaskGroups[0].Update.Canary = 1
+	job2.TaskGroups[0].Update.AutoRevert = true
+	job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job2))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewSystemScheduler, eval))
+
+	// Ensure the job is updated as a rolling update without a deployment
+	must.Len(t, 1, h.Plans)
+	plan := h.Plans[0]
+	must.Nil(t, plan.Deployment)
+
+	var planned []*structs.Allocation
+	for _, allocList := range plan.NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	must.Len(t, 4, planned)
+	for _, alloc := range planned {
+		must.Eq(t, "", alloc.DeploymentID)
+	}
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestSystemSched_JobModify_FailedDeployment(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create some nodes
+	nodes := createNodes(t, h, 4)
+
+	// Generate a fake job with allocations
+	job := mock.SystemJob()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	var allocs []*structs.Allocation
+	for _, node := range nodes {
+		alloc := mock.AllocForNode(node)
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.Name = "my-job.web[0]"
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// Update the job with auto-revert
+	job2 := job.Copy()
+	job2.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job2.TaskGroups[0].Update.AutoRevert = true
+	job2.TaskGroups[0].Update.CanaryPercent = 50
+	job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job2))
+	job2, err := h.State.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+
+	// Create a failed deployment for the job version
+	d := structs.NewDeployment(job2, 50)
+	d.Status = structs.DeploymentStatusFailed
+	d.TaskGroups["web"] = &structs.DeploymentState{
+		AutoRevert:   true,
+		DesiredTotal: 4,
+	}
+	must.NoError(t, h.State.UpsertDeployment(h.NextIndex(), d))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewSystemScheduler, eval))
+
+	// Ensure the updates are held
+	must.SliceEmpty(t, h.Plans)
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestSystemSched_DeploymentComplete(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create some nodes
+	nodes := createNodes(t, h, 2)
+
+	// Generate a fake job with canaries and auto-revert
+	job := mock.SystemJob()
+	job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job.TaskGroups[0].Update.AutoRevert = true
+	job.TaskGroups[0].Update.CanaryPercent = 50
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+	job, err := h.State.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+
+	// Create a running deployment of the job version
+	d := structs.NewDeployment(job, 50)
+	d.TaskGroups["web"] = &structs.DeploymentState{
+		AutoRevert:   true,
+		DesiredTotal: 2,
+	}
+	must.NoError(t, h.State.UpsertDeployment(h.NextIndex(), d))
+
+	var allocs []*structs.Allocation
+	for _, node := range nodes {
+		alloc := mock.AllocForNode(node)
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.Name = "my-job.web[0]"
+		alloc.DeploymentID = d.ID
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	process := func() {
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    50,
+			TriggeredBy: structs.EvalTriggerDeploymentWatcher,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		must.NoError(t, h.Process(NewSystemScheduler, eval))
+	}
+
+	// Ensure the deployment isn't complete until the allocations are healthy
+	process()
+	must.SliceEmpty(t, h.Plans)
+
+	for i, alloc := range allocs {
+		alloc = alloc.Copy()
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: pointer.Of(true)}
+		allocs[i] = alloc
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	process()
+	must.Len(t, 1, h.Plans)
+	plan := h.Plans[0]
+	must.Len(t, 1, plan.DeploymentUpdates)
+	must.Eq(t, d.ID, plan.DeploymentUpdates[0].DeploymentID)
+	must.Eq(t, structs.DeploymentStatusSuccessful, plan.DeploymentUpdates[0].Status)
+}
+
 func TestSystemSched_JobModify_RemoveDC(t *testing.T) {
 	ci.Parallel(t)
 
@@ -58,16 +58,22 @@ type GenericStack struct {
 	taskGroupHostVolumes *HostVolumeChecker
 	taskGroupCSIVolumes  *CSIVolumeChecker
 	taskGroupNetwork     *NetworkChecker
+	taskGroupTaints      *TaintChecker
 
 	distinctHostsConstraint    *DistinctHostsIterator
 	distinctPropertyConstraint *DistinctPropertyIterator
+	allocAffinityConstraint    *AllocationAffinityIterator
 	binPack                    *BinPackIterator
+	nodeLoad                   *NodeLoadIterator
 	jobAntiAff                 *JobAntiAffinityIterator
 	nodeReschedulingPenalty    *NodeReschedulingPenaltyIterator
+	nodeTaintPenalty           *NodeTaintPenaltyIterator
 	limit                      *LimitIterator
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
+	allocAffinity              *AllocationAffinityScoringIterator
 	spread                     *SpreadIterator
+	topologySpread             *TopologySpreadIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -78,6 +84,7 @@ func (s *GenericStack) SetNodes(baseNodes []*structs.Node) {
 
 	// Update the set of base nodes
 	s.source.SetNodes(baseNodes)
+	s.topologySpread.SetNodes(baseNodes)
 
 	// Apply a limit function. This is to avoid scanning *every* possible node.
 	// For batch jobs we only need to evaluate 2 options and depend on the
@@ -105,10 +112,13 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctHostsConstraint.SetJob(job)
 	s.distinctPropertyConstraint.SetJob(job)
+	s.allocAffinityConstraint.SetJob(job)
 	s.binPack.SetJob(job)
 	s.jobAntiAff.SetJob(job)
 	s.nodeAffinity.SetJob(job)
+	s.allocAffinity.SetJob(job)
 	s.spread.SetJob(job)
+	s.topologySpread.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
@@ -123,6 +133,7 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 // on the node pool being used.
 func (s *GenericStack) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
 	s.binPack.SetSchedulerConfiguration(schedConfig)
+	s.nodeLoad.SetSchedulerConfiguration(schedConfig)
 }
 
 func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *RankedNode {
@@ -147,6 +158,45 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	s.ctx.Reset()
 	start := time.Now()
 
+	s.setTaskGroup(tg, options)
+
+	if s.nodeAffinity.hasAffinities() || s.allocAffinity.hasAllocationAffinities() ||
+		s.spread.hasSpreads() || s.topologySpread.hasTopologySpreads() {
+		// scoring spread across all nodes has quadratic behavior, so
+		// we need to consider a subset of nodes to keep evaluaton times
+		// reasonable but enough to ensure spread is correct. this
+		// value was empirically determined.
+		s.limit.SetLimit(tg.Count)
+		if tg.Count < 100 {
+			s.limit.SetLimit(100)
+		}
+	}
+
+	// Find the node with the max score
+	option := s.maxScore.Next()
+
+	// Store the compute time
+	s.ctx.Metrics().AllocationTime = time.Since(start)
+	return option
+}
+
+// FeasibleNodes returns all the nodes the task group could be placed on: the
+// nodes that pass the feasibility checks of the stack and have the resources
+// available for it. Unlike Select, the nodes are neither scored nor limited.
+func (s *GenericStack) FeasibleNodes(tg *structs.TaskGroup) []*structs.Node {
+	s.binPack.Reset()
+	s.ctx.Reset()
+	s.setTaskGroup(tg, &SelectOptions{})
+
+	var nodes []*structs.Node
+	for option := s.binPack.Next(); option != nil; option = s.binPack.Next() {
+		nodes = append(nodes, option.Node)
+	}
+	return nodes
+}
+
+// setTaskGroup updates the parameters of the iterators for the task group.
+func (s *GenericStack) setTaskGroup(tg *structs.TaskGroup, options *SelectOptions) {
 	// Get the task groups constraints.
 	tgConstr := taskGroupConstraints(tg)
 
@@ -156,11 +206,13 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	s.taskGroupDevices.SetTaskGroup(tg)
 	s.taskGroupHostVolumes.SetVolumes(options.AllocName, tg.Volumes)
 	s.taskGroupCSIVolumes.SetVolumes(options.AllocName, tg.Volumes)
+	s.taskGroupTaints.SetTolerations(tg.Tolerations)
 	if len(tg.Networks) > 0 {
 		s.taskGroupNetwork.SetNetwork(tg.Networks[0])
 	}
 	s.distinctHostsConstraint.SetTaskGroup(tg)
 	s.distinctPropertyConstraint.SetTaskGroup(tg)
+	s.allocAffinityConstraint.SetTaskGroup(tg)
 	s.wrappedChecks.SetTaskGroup(tg.Name)
 	s.binPack.SetTaskGroup(tg)
 	if options != nil {
@@ -170,30 +222,15 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	if options != nil {
 		s.nodeReschedulingPenalty.SetPenaltyNodes(options.PenaltyNodeIDs)
 	}
+	s.nodeTaintPenalty.SetTaskGroup(tg)
 	s.nodeAffinity.SetTaskGroup(tg)
+	s.allocAffinity.SetTaskGroup(tg)
 	s.spread.SetTaskGroup(tg)
-
-	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() {
-		// scoring spread across all nodes has quadratic behavior, so
-		// we need to consider a subset of nodes to keep evaluaton times
-		// reasonable but enough to ensure spread is correct. this
-		// value was empirically determined.
-		s.limit.SetLimit(tg.Count)
-		if tg.Count < 100 {
-			s.limit.SetLimit(100)
-		}
-	}
+	s.topologySpread.SetTaskGroup(tg)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
 		contextual.SetTaskGroup(tg)
 	}
-
-	// Find the node with the max score
-	option := s.maxScore.Next()
-
-	// Store the compute time
-	s.ctx.Metrics().AllocationTime = time.Since(start)
-	return option
 }
 
 // SystemStack is the Stack used for the System scheduler. It is designed to
@@ -211,6 +248,7 @@ type SystemStack struct {
 	taskGroupHostVolumes *HostVolumeChecker
 	taskGroupCSIVolumes  *CSIVolumeChecker
 	taskGroupNetwork     *NetworkChecker
+	taskGroupTaints      *TaintChecker
 
 	distinctPropertyConstraint *DistinctPropertyIterator
 	binPack                    *BinPackIterator
@@ -251,6 +289,9 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// Filter on available client networks
 	s.taskGroupNetwork = NewNetworkChecker(ctx)
 
+	// Filter on node taints
+	s.taskGroupTaints = NewTaintChecker(ctx)
+
 	// Create the feasibility wrapper which wraps all feasibility checks in
 	// which feasibility checking can be skipped if the computed node class has
 	// previously been marked as eligible or ineligible. Generally this will be
@@ -265,6 +306,7 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	avail := []FeasibilityChecker{
 		s.taskGroupHostVolumes,
 		s.taskGroupCSIVolumes,
+		s.taskGroupTaints,
 	}
 	s.wrappedChecks = NewFeasibilityWrapper(ctx, s.source, jobs, tgs, avail)
 
@@ -347,6 +389,7 @@ func (s *SystemStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ran
 	s.taskGroupDevices.SetTaskGroup(tg)
 	s.taskGroupHostVolumes.SetVolumes(options.AllocName, tg.Volumes)
 	s.taskGroupCSIVolumes.SetVolumes(options.AllocName, tg.Volumes)
+	s.taskGroupTaints.SetTolerations(tg.Tolerations)
 	if len(tg.Networks) > 0 {
 		s.taskGroupNetwork.SetNetwork(tg.Networks[0])
 	}
@@ -400,6 +443,9 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Filter on available client networks
 	s.taskGroupNetwork = NewNetworkChecker(ctx)
 
+	// Filter on node taints
+	s.taskGroupTaints = NewTaintChecker(ctx)
+
 	// Create the feasibility wrapper which wraps all feasibility checks in
 	// which feasibility checking can be skipped if the computed node class has
 	// previously been marked as eligible or ineligible. Generally this will be
@@ -414,6 +460,7 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	avail := []FeasibilityChecker{
 		s.taskGroupHostVolumes,
 		s.taskGroupCSIVolumes,
+		s.taskGroupTaints,
 	}
 	s.wrappedChecks = NewFeasibilityWrapper(ctx, s.source, jobs, tgs, avail)
 
@@ -423,12 +470,15 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Filter on distinct property constraints.
 	s.distinctPropertyConstraint = NewDistinctPropertyIterator(ctx, s.distinctHostsConstraint)
 
+	// Filter on required allocation affinities.
+	s.allocAffinityConstraint = NewAllocationAffinityIterator(ctx, s.distinctPropertyConstraint)
+
 	// Create the quota iterator to determine if placements would result in
 	// the quota attached to the namespace of the job to go over.
 	// Note: the quota iterator must be the last feasibility iterator before
 	// we upgrade to ranking, or our quota usage will include ineligible
 	// nodes!
-	s.quota = NewQuotaIterator(ctx, s.distinctPropertyConstraint)
+	s.quota = NewQuotaIterator(ctx, s.allocAffinityConstraint)
 
 	// Upgrade from feasible to rank iterator
 	rankSource := NewFeasibleRankIterator(ctx, s.quota)
@@ -437,22 +487,36 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// by a particular task group.
 	s.binPack = NewBinPackIterator(ctx, rankSource, false, 0)
 
+	// Apply the node load penalty. This tries to avoid placing on nodes
+	// whose actual utilization is high, when enabled
+	s.nodeLoad = NewNodeLoadIterator(ctx, s.binPack)
+
 	// Apply the job anti-affinity iterator. This is to avoid placing
 	// multiple allocations on the same node for this job.
-	s.jobAntiAff = NewJobAntiAffinityIterator(ctx, s.binPack, "")
+	s.jobAntiAff = NewJobAntiAffinityIterator(ctx, s.nodeLoad, "")
 
 	// Apply node rescheduling penalty. This tries to avoid placing on a
 	// node where the allocation failed previously
 	s.nodeReschedulingPenalty = NewNodeReschedulingPenaltyIterator(ctx, s.jobAntiAff)
 
+	// Apply node taint penalty. This tries to avoid placing on a node with
+	// prefer_no_schedule taints the task group does not tolerate
+	s.nodeTaintPenalty = NewNodeTaintPenaltyIterator(ctx, s.nodeReschedulingPenalty)
+
 	// Apply scores based on affinity block
-	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeReschedulingPenalty)
+	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeTaintPenalty)
+
+	// Apply scores based on allocation_affinity block
+	s.allocAffinity = NewAllocationAffinityScoringIterator(ctx, s.nodeAffinity)
 
 	// Apply scores based on spread block
-	s.spread = NewSpreadIterator(ctx, s.nodeAffinity)
+	s.spread = NewSpreadIterator(ctx, s.allocAffinity)
+
+	// Filter or apply scores based on topology_spread block
+	s.topologySpread = NewTopologySpreadIterator(ctx, s.spread)
 
 	// Add the preemption options scoring iterator
-	preemptionScorer := NewPreemptionScoringIterator(ctx, s.spread)
+	preemptionScorer := NewPreemptionScoringIterator(ctx, s.topologySpread)
 
 	// Normalizes scores by averaging them across various scorers
 	s.scoreNorm = NewScoreNormalizationIterator(ctx, preemptionScorer)
@@ -11,6 +11,7 @@ package scheduler
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -349,3 +350,40 @@ func evictAndPlace(ctx Context, diff *diffResult, allocs []allocTuple, desc stri
 	*limit = 0
 	return true
 }
+
+// systemDeploymentEnabled returns whether the updates of the task group of a
+// system job are tracked by a deployment, which is the case when the task
+// group sets a canary percent or analysis gates.
+func systemDeploymentEnabled(tg *structs.TaskGroup) bool {
+	return !tg.Update.IsEmpty() && (tg.Update.CanaryPercent > 0 || tg.Update.Analysis != nil)
+}
+
+// systemCanaries returns the number of canaries of a system job task group
+// running on total nodes, given the canary percent of its update strategy.
+// The number of canaries is rounded up.
+func systemCanaries(percent, total int) int {
+	return min(int(math.Ceil(float64(percent)*float64(total)/100)), total)
+}
+
+// systemDeploymentChanged returns whether the deployment computed by the
+// system scheduler differs from the existing deployment and should be part of
+// the plan.
+func systemDeploymentChanged(existing, computed *structs.Deployment) bool {
+	if computed == nil {
+		return false
+	}
+	if existing == nil || existing.ID != computed.ID {
+		return true
+	}
+	if len(existing.TaskGroups) != len(computed.TaskGroups) {
+		return true
+	}
+	for name, dstate := range computed.TaskGroups {
+		prev, ok := existing.TaskGroups[name]
+		if !ok || prev.DesiredTotal != dstate.DesiredTotal || prev.DesiredCanaries != dstate.DesiredCanaries ||
+			!prev.RequireProgressBy.Equal(dstate.RequireProgressBy) {
+			return true
+		}
+	}
+	return false
+}
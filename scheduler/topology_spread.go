@@ -0,0 +1,334 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// TopologySpreadIterator is used to bound the difference in the number of
+// allocations of a task group placed across topology domains. A topology
+// domain is the combination of the values of a topology_spread block's
+// attributes on a node.
+//
+// Nodes that would exceed the max skew of a topology_spread with
+// when_unsatisfiable set to "block" are filtered out, while nodes that would
+// exceed the max skew of a "best_effort" topology_spread have their score
+// penalized.
+type TopologySpreadIterator struct {
+	ctx    Context
+	source RankIterator
+	job    *structs.Job
+	tg     *structs.TaskGroup
+
+	// nodes is the base set of nodes that topology domains are computed
+	// from
+	nodes []*structs.Node
+
+	// groupDomains is a memoized map from task group to the topology domains
+	// of each of its topology_spread blocks. Existing allocs are computed
+	// once, and allocs from the plan are updated when Reset is called
+	groupDomains map[string][]*topologyDomains
+}
+
+// topologyDomains tracks the allocations of a task group across the topology
+// domains of a single topology_spread block.
+type topologyDomains struct {
+	spread *structs.TopologySpread
+
+	// jobID, namespace and taskGroup identify the allocations being counted
+	jobID     string
+	namespace string
+	taskGroup string
+
+	// eligible is the set of domains formed by the nodes the task group can
+	// be placed on.
+	eligible map[string]struct{}
+
+	// existing maps the ID of allocations in the state store to their domain.
+	existing map[string]string
+
+	// counts is the number of allocations per domain, taking into account
+	// the allocations placed and stopped by the plan.
+	counts map[string]int
+
+	// errorBuilding marks whether there was an error when building the
+	// domains.
+	errorBuilding error
+}
+
+// NewTopologySpreadIterator creates a TopologySpreadIterator from a source.
+func NewTopologySpreadIterator(ctx Context, source RankIterator) *TopologySpreadIterator {
+	return &TopologySpreadIterator{
+		ctx:          ctx,
+		source:       source,
+		groupDomains: make(map[string][]*topologyDomains),
+	}
+}
+
+// SetNodes sets the base set of nodes used to compute the eligible topology
+// domains.
+func (iter *TopologySpreadIterator) SetNodes(nodes []*structs.Node) {
+	iter.nodes = nodes
+	iter.groupDomains = make(map[string][]*topologyDomains)
+}
+
+func (iter *TopologySpreadIterator) SetJob(job *structs.Job) {
+	iter.job = job
+
+	// reset group domains so that when we temporarily SetJob to an older
+	// version to calculate stops we don't leak old versions of the topology
+	// spreads to the new job version
+	iter.groupDomains = make(map[string][]*topologyDomains)
+}
+
+func (iter *TopologySpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+
+	if _, ok := iter.groupDomains[tg.Name]; ok || len(tg.TopologySpreads) == 0 {
+		return
+	}
+
+	eligibleNodes := iter.eligibleNodes(tg)
+	for _, spread := range tg.TopologySpreads {
+		td := &topologyDomains{
+			spread:    spread,
+			jobID:     iter.job.ID,
+			namespace: iter.job.Namespace,
+			taskGroup: tg.Name,
+			eligible:  make(map[string]struct{}),
+		}
+		for _, node := range eligibleNodes {
+			if domain, ok := topologyDomain(node, spread.Attributes); ok {
+				td.eligible[domain] = struct{}{}
+			}
+		}
+		td.populateExisting(iter.ctx)
+		td.populateProposed(iter.ctx)
+		iter.groupDomains[tg.Name] = append(iter.groupDomains[tg.Name], td)
+	}
+}
+
+func (iter *TopologySpreadIterator) hasTopologySpreads() bool {
+	return iter.tg != nil && len(iter.groupDomains[iter.tg.Name]) != 0
+}
+
+func (iter *TopologySpreadIterator) Next() *RankedNode {
+	for {
+		option := iter.source.Next()
+
+		// Hot path if there is nothing to check
+		if option == nil || !iter.hasTopologySpreads() {
+			return option
+		}
+
+		totalScore := 0.0
+		feasible := true
+		for _, td := range iter.groupDomains[iter.tg.Name] {
+			skew, reason := td.skew(option.Node)
+			if reason == "" && skew <= td.spread.MaxSkew {
+				continue
+			}
+
+			if td.spread.WhenUnsatisfiable == structs.TopologySpreadBlock {
+				if reason == "" {
+					reason = fmt.Sprintf("topology_spread: skew %d exceeds max_skew %d on %s",
+						skew, td.spread.MaxSkew, strings.Join(td.spread.Attributes, ","))
+				}
+				iter.ctx.Metrics().FilterNode(option.Node, reason)
+				feasible = false
+				break
+			}
+
+			// Penalize proportionally to how far the placement would
+			// exceed the max skew, with the maximum penalty for nodes
+			// outside of every topology domain.
+			if reason != "" {
+				totalScore -= 1.0
+			} else {
+				totalScore -= float64(skew-td.spread.MaxSkew) / float64(skew)
+			}
+		}
+
+		if !feasible {
+			continue
+		}
+
+		if totalScore != 0.0 {
+			option.Scores = append(option.Scores, totalScore)
+			iter.ctx.Metrics().ScoreNode(option.Node, "topology-spread", totalScore)
+		}
+		return option
+	}
+}
+
+func (iter *TopologySpreadIterator) Reset() {
+	iter.source.Reset()
+
+	for _, domains := range iter.groupDomains {
+		for _, td := range domains {
+			td.populateProposed(iter.ctx)
+		}
+	}
+}
+
+// eligibleNodes returns the base nodes that meet the job and task group
+// constraints, so that domains the task group can never be placed in are not
+// taken into account when computing the skew.
+func (iter *TopologySpreadIterator) eligibleNodes(tg *structs.TaskGroup) []*structs.Node {
+	constraints := taskGroupConstraints(tg).constraints
+	if iter.job != nil {
+		constraints = append(constraints, iter.job.Constraints...)
+	}
+
+	checker := NewConstraintChecker(iter.ctx, constraints)
+	eligible := make([]*structs.Node, 0, len(iter.nodes))
+NODES:
+	for _, node := range iter.nodes {
+		for _, constraint := range constraints {
+			if !checker.meetsConstraint(constraint, node) {
+				continue NODES
+			}
+		}
+		eligible = append(eligible, node)
+	}
+	return eligible
+}
+
+// populateExisting records the domain of the existing allocations of the task
+// group.
+func (td *topologyDomains) populateExisting(ctx Context) {
+	td.existing = make(map[string]string)
+
+	ws := memdb.NewWatchSet()
+	allocs, err := ctx.State().AllocsByJob(ws, td.namespace, td.jobID, false)
+	if err != nil {
+		td.errorBuilding = fmt.Errorf("failed to get job's allocations: %v", err)
+		ctx.Logger().Named("topology_spread").Error("failed to get job's allocations",
+			"job", td.jobID, "namespace", td.namespace, "error", err)
+		return
+	}
+
+	nodes := make(map[string]*structs.Node)
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != td.taskGroup || alloc.TerminalStatus() {
+			continue
+		}
+
+		node, ok := nodes[alloc.NodeID]
+		if !ok {
+			node, err = ctx.State().NodeByID(ws, alloc.NodeID)
+			if err != nil {
+				td.errorBuilding = fmt.Errorf("failed to lookup node ID %q: %v", alloc.NodeID, err)
+				ctx.Logger().Named("topology_spread").Error("failed to lookup node",
+					"node_id", alloc.NodeID, "error", err)
+				return
+			}
+			nodes[alloc.NodeID] = node
+		}
+
+		if domain, ok := topologyDomain(node, td.spread.Attributes); ok {
+			td.existing[alloc.ID] = domain
+		}
+	}
+}
+
+// populateProposed recomputes the per-domain allocation counts from the
+// existing allocations and the placements and stops in the plan. It should be
+// called whenever the plan is updated.
+func (td *topologyDomains) populateProposed(ctx Context) {
+	allocDomains := maps.Clone(td.existing)
+	plan := ctx.Plan()
+
+	for _, stopped := range plan.NodeUpdate {
+		for _, alloc := range stopped {
+			delete(allocDomains, alloc.ID)
+		}
+	}
+
+	for nodeID, placed := range plan.NodeAllocation {
+		var domain string
+		var resolved, ok bool
+		for _, alloc := range placed {
+			if !td.tracks(alloc) {
+				continue
+			}
+			if alloc.TerminalStatus() {
+				delete(allocDomains, alloc.ID)
+				continue
+			}
+			if !resolved {
+				node, err := ctx.State().NodeByID(nil, nodeID)
+				if err != nil {
+					td.errorBuilding = fmt.Errorf("failed to lookup node ID %q: %v", nodeID, err)
+					return
+				}
+				domain, ok = topologyDomain(node, td.spread.Attributes)
+				resolved = true
+			}
+			if ok {
+				allocDomains[alloc.ID] = domain
+			}
+		}
+	}
+
+	td.counts = make(map[string]int, len(td.eligible))
+	for _, domain := range allocDomains {
+		td.counts[domain]++
+	}
+}
+
+// tracks returns whether the allocation belongs to the task group whose
+// allocations are being counted.
+func (td *topologyDomains) tracks(alloc *structs.Allocation) bool {
+	return alloc.Namespace == td.namespace && alloc.JobID == td.jobID &&
+		alloc.TaskGroup == td.taskGroup
+}
+
+// skew returns the skew that placing an allocation on the node would result
+// in, computed as the number of allocations in the node's domain after the
+// placement minus the smallest number of allocations in any eligible domain.
+// If the skew cannot be computed an explanation is given.
+func (td *topologyDomains) skew(node *structs.Node) (int, string) {
+	if td.errorBuilding != nil {
+		return 0, td.errorBuilding.Error()
+	}
+
+	domain, ok := topologyDomain(node, td.spread.Attributes)
+	if !ok {
+		return 0, fmt.Sprintf("topology_spread: missing property in %s",
+			strings.Join(td.spread.Attributes, ","))
+	}
+
+	minCount := -1
+	for eligible := range td.eligible {
+		if count := td.counts[eligible]; minCount == -1 || count < minCount {
+			minCount = count
+		}
+	}
+	if minCount == -1 {
+		minCount = 0
+	}
+
+	return td.counts[domain] + 1 - minCount, ""
+}
+
+// topologyDomain returns the topology domain of the node for the given
+// attributes. It returns false if the node is missing any of the attributes.
+func topologyDomain(node *structs.Node, attributes []string) (string, bool) {
+	values := make([]string, 0, len(attributes))
+	for _, attr := range attributes {
+		value, ok := getProperty(node, attr)
+		if !ok {
+			return "", false
+		}
+		values = append(values, value)
+	}
+	return strings.Join(values, "\x00"), true
+}
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestTopologySpreadIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name              string
+		whenUnsatisfiable string
+		proposed          bool
+		expectedScores    map[string]float64
+	}{
+		{
+			name:              "block filters nodes exceeding max skew",
+			whenUnsatisfiable: structs.TopologySpreadBlock,
+			expectedScores: map[string]float64{
+				"dc1/r2": 0,
+				"dc2/r1": 0,
+			},
+		},
+		{
+			name:              "block counts proposed allocs",
+			whenUnsatisfiable: structs.TopologySpreadBlock,
+			proposed:          true,
+			expectedScores: map[string]float64{
+				"dc2/r1": 0,
+			},
+		},
+		{
+			name:              "best effort penalizes nodes exceeding max skew",
+			whenUnsatisfiable: structs.TopologySpreadBestEffort,
+			expectedScores: map[string]float64{
+				"dc1/r1": -0.5,
+				"dc1/r2": 0,
+				"dc2/r1": 0,
+				"dc2/":   -1,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			store, ctx := testContext(t)
+			nodes := make([]*structs.Node, 0, 4)
+			for i, pair := range [][2]string{{"dc1", "r1"}, {"dc1", "r2"}, {"dc2", "r1"}, {"dc2", ""}} {
+				node := mock.Node()
+				node.Datacenter = pair[0]
+				if pair[1] != "" {
+					node.Meta["rack"] = pair[1]
+				}
+				must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+				nodes = append(nodes, node)
+			}
+
+			job := mock.Job()
+			tg := job.TaskGroups[0]
+			tg.TopologySpreads = []*structs.TopologySpread{{
+				Attributes:        []string{"${node.datacenter}", "${meta.rack}"},
+				MaxSkew:           1,
+				WhenUnsatisfiable: tc.whenUnsatisfiable,
+			}}
+
+			// Place an existing alloc in dc1/r1
+			existing := mock.Alloc()
+			existing.Job = job
+			existing.JobID = job.ID
+			existing.TaskGroup = tg.Name
+			existing.NodeID = nodes[0].ID
+			must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1000,
+				[]*structs.Allocation{existing}))
+
+			if tc.proposed {
+				proposed := &structs.Allocation{
+					Namespace: job.Namespace,
+					ID:        uuid.Generate(),
+					JobID:     job.ID,
+					TaskGroup: tg.Name,
+					NodeID:    nodes[1].ID,
+				}
+				ctx.plan.NodeAllocation[nodes[1].ID] = []*structs.Allocation{proposed}
+			}
+
+			ranked := make([]*RankedNode, 0, len(nodes))
+			for _, node := range nodes {
+				ranked = append(ranked, &RankedNode{Node: node})
+			}
+			static := NewStaticRankIterator(ctx, ranked)
+
+			iter := NewTopologySpreadIterator(ctx, static)
+			iter.SetNodes(nodes)
+			iter.SetJob(job)
+			iter.SetTaskGroup(tg)
+			scoreNorm := NewScoreNormalizationIterator(ctx, iter)
+
+			out := collectRanked(scoreNorm)
+			scores := make(map[string]float64, len(out))
+			for _, rn := range out {
+				scores[rn.Node.Datacenter+"/"+rn.Node.Meta["rack"]] = rn.FinalScore
+			}
+			must.Eq(t, tc.expectedScores, scores)
+		})
+	}
+}
+
+func TestServiceSched_TopologySpread(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create nodes in three datacenter and rack pairs, with most of the
+	// capacity in dc1/r1
+	pairs := [][2]string{{"dc1", "r1"}, {"dc1", "r1"}, {"dc1", "r1"}, {"dc1", "r2"}, {"dc2", "r1"}}
+	for _, pair := range pairs {
+		node := mock.Node()
+		node.Datacenter = pair[0]
+		node.Meta["rack"] = pair[1]
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	job := mock.Job()
+	job.Datacenters = []string{"dc1", "dc2"}
+	job.TaskGroups[0].Count = 6
+	job.TaskGroups[0].TopologySpreads = []*structs.TopologySpread{{
+		Attributes:        []string{"${node.datacenter}", "${meta.rack}"},
+		MaxSkew:           1,
+		WhenUnsatisfiable: structs.TopologySpreadBlock,
+	}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+	must.Len(t, 1, h.Plans)
+
+	// Ensure the allocs are evenly spread across the domains
+	domains := make(map[string]int)
+	for _, allocs := range h.Plans[0].NodeAllocation {
+		for _, alloc := range allocs {
+			node, err := h.State.NodeByID(nil, alloc.NodeID)
+			must.NoError(t, err)
+			domains[node.Datacenter+"/"+node.Meta["rack"]]++
+		}
+	}
+	must.Eq(t, map[string]int{"dc1/r1": 2, "dc1/r2": 2, "dc2/r1": 2}, domains)
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
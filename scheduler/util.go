@@ -243,6 +243,27 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 		return c
 	}
 
+	// Check topology spreads
+	if !slices.EqualFunc(a.TopologySpreads, b.TopologySpreads, func(a, b *structs.TopologySpread) bool {
+		return a.Equal(b)
+	}) {
+		return difference("topology spreads", a.TopologySpreads, b.TopologySpreads)
+	}
+
+	// Check allocation affinities
+	if !slices.EqualFunc(a.AllocationAffinities, b.AllocationAffinities, func(a, b *structs.AllocationAffinity) bool {
+		return a.Equal(b)
+	}) {
+		return difference("allocation affinities", a.AllocationAffinities, b.AllocationAffinities)
+	}
+
+	// Check tolerations
+	if !slices.EqualFunc(a.Tolerations, b.Tolerations, func(a, b *structs.Toleration) bool {
+		return a.Equal(b)
+	}) {
+		return difference("tolerations", a.Tolerations, b.Tolerations)
+	}
+
 	// Check consul updated
 	if c := consulUpdated(a.Consul, b.Consul); c.modified {
 		return c
@@ -368,6 +389,8 @@ func nonNetworkResourcesUpdated(a, b *structs.Resources) comparison {
 		return difference("task devices", a.Devices, b.Devices)
 	case !a.NUMA.Equal(b.NUMA):
 		return difference("numa", a.NUMA, b.NUMA)
+	case !a.IO.Equal(b.IO):
+		return difference("task io", a.IO, b.IO)
 	}
 	return same
 }
@@ -664,6 +687,20 @@ func setStatus(logger log.Logger, planner Planner,
 	newEval.StatusDescription = desc
 	newEval.DeploymentID = deploymentID
 	newEval.FailedTGAllocs = tgMetrics
+
+	// Record the preemptions blocked by disruption budgets along with the
+	// drains that may already have been recorded on the eval
+	for _, metrics := range tgMetrics {
+		if metrics == nil {
+			continue
+		}
+		for key, num := range metrics.DisruptionBudgetExhausted {
+			if newEval.BlockedDisruptions == nil {
+				newEval.BlockedDisruptions = make(map[string]int)
+			}
+			newEval.BlockedDisruptions[key] += num
+		}
+	}
 	if nextEval != nil {
 		newEval.NextEval = nextEval.ID
 	}
@@ -1057,3 +1094,87 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 		return false, false, newAlloc
 	}
 }
+
+// pendingJobDependencies returns the IDs of the jobs the job depends on whose
+// condition is not met. Dependencies only hold the placement of a job version
+// that has no allocation yet, so that a job that is already running isn't
+// held when the jobs it depends on run again.
+func pendingJobDependencies(state State, job *structs.Job) ([]string, error) {
+	if job == nil || job.Stopped() || len(job.DependsOn) == 0 {
+		return nil, nil
+	}
+
+	allocs, err := state.AllocsByJob(nil, job.Namespace, job.ID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocs for job %q: %v", job.ID, err)
+	}
+	for _, alloc := range allocs {
+		if alloc.Job != nil && alloc.Job.Version == job.Version {
+			return nil, nil
+		}
+	}
+
+	var pending []string
+	for _, dep := range job.DependsOn {
+		depJob, err := state.JobByID(nil, job.Namespace, dep.JobID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job %q: %v", dep.JobID, err)
+		}
+
+		var depAllocs []*structs.Allocation
+		var deployment *structs.Deployment
+		if depJob != nil {
+			depAllocs, err = state.AllocsByJob(nil, job.Namespace, dep.JobID, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get allocs for job %q: %v", dep.JobID, err)
+			}
+			deployment, err = state.LatestDeploymentByJobID(nil, job.Namespace, dep.JobID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get deployment for job %q: %v", dep.JobID, err)
+			}
+		}
+
+		if !dep.Met(depJob, depAllocs, deployment) {
+			pending = append(pending, dep.JobID)
+		}
+	}
+	return pending, nil
+}
+
+// runningJobVersion returns the latest version of the job, older than its
+// current version, that still has allocations that are not terminal. It
+// returns nil if no older version is running.
+func runningJobVersion(state State, job *structs.Job) (*structs.Job, error) {
+	if job == nil {
+		return nil, nil
+	}
+
+	allocs, err := state.AllocsByJob(nil, job.Namespace, job.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocs for job %q: %v", job.ID, err)
+	}
+
+	var running *structs.Job
+	for _, alloc := range allocs {
+		if alloc.Job == nil || alloc.TerminalStatus() || alloc.Job.Version >= job.Version {
+			continue
+		}
+		if running == nil || alloc.Job.Version > running.Version {
+			running = alloc.Job
+		}
+	}
+	if running == nil {
+		return nil, nil
+	}
+
+	// Prefer the stored version of the job over the copy denormalized on
+	// the allocation
+	stored, err := state.JobByIDAndVersion(nil, job.Namespace, job.ID, running.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %q version %d: %v", job.ID, running.Version, err)
+	}
+	if stored != nil {
+		running = stored
+	}
+	return running, nil
+}
@@ -792,6 +792,22 @@ func TestSetStatus(t *testing.T) {
 
 	newEval = h.Evals[0]
 	require.Equal(t, dID, newEval.DeploymentID, "setStatus() didn't set deployment id correctly: %v", newEval)
+
+	// Test preemptions blocked by disruption budgets are added to the drains
+	// already blocked
+	h = NewHarness(t)
+	drainEval := eval.Copy()
+	drainEval.BlockedDisruptions = map[string]int{"other/web": 1}
+	metrics = map[string]*structs.AllocMetric{
+		"foo": {DisruptionBudgetExhausted: map[string]int{"other/web": 2, "other/db": 1}},
+		"bar": nil,
+	}
+	require.NoError(t, setStatus(logger, h, drainEval, nil, nil, metrics, status, desc, nil, ""))
+	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
+
+	newEval = h.Evals[0]
+	require.Equal(t, map[string]int{"other/web": 3, "other/db": 1}, newEval.BlockedDisruptions)
+	require.Equal(t, map[string]int{"other/web": 1}, drainEval.BlockedDisruptions)
 }
 
 func TestInplaceUpdate_ChangedTaskGroup(t *testing.T) {
@@ -1245,6 +1261,29 @@ func TestTasksUpdated_NUMA(t *testing.T) {
 	must.True(t, tasksUpdated(j1, j2, name).modified)
 }
 
+func TestTasksUpdated_IO(t *testing.T) {
+	ci.Parallel(t)
+
+	j1 := mock.Job()
+	name := j1.TaskGroups[0].Name
+
+	j1.TaskGroups[0].Tasks[0].Resources.IO = &structs.IOResources{
+		Weight: 100,
+		Devices: []*structs.IODeviceResource{{
+			Path:    "/dev/sda",
+			ReadBps: 1024,
+		}},
+	}
+
+	j2 := j1.Copy()
+
+	must.False(t, tasksUpdated(j1, j2, name).modified)
+
+	j2.TaskGroups[0].Tasks[0].Resources.IO.Devices[0].WriteIOPS = 100
+
+	must.True(t, tasksUpdated(j1, j2, name).modified)
+}
+
 func TestTaskGroupConstraints(t *testing.T) {
 	ci.Parallel(t)
 